@@ -4,17 +4,47 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
 
+	identity "github.com/karta0898098/iam/pkg/app/identity/service"
+	rbac "github.com/karta0898098/iam/pkg/app/rbac/service"
+	scim "github.com/karta0898098/iam/pkg/app/scim/service"
 	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/federation"
 	"github.com/karta0898098/iam/pkg/http"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/ldap"
+	"github.com/karta0898098/iam/pkg/lockout"
 	"github.com/karta0898098/iam/pkg/logging"
+	"github.com/karta0898098/iam/pkg/mail"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/password"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+	"github.com/karta0898098/iam/pkg/secret"
+	"github.com/karta0898098/iam/pkg/totp"
+	"github.com/karta0898098/iam/pkg/webauthn"
 )
 
 // Configurations define this application need configs
 type Configurations struct {
-	Database db.Config      `mapstructure:"database"`
-	HTTP     http.Config    `mapstructure:"http"`
-	Log      logging.Config `mapstructure:"log"`
-	GRPC     GRPC           `mapstructure:"grpc"`
+	Database   db.Config         `mapstructure:"database"`
+	HTTP       http.Config       `mapstructure:"http"`
+	Log        logging.Config    `mapstructure:"log"`
+	GRPC       GRPC              `mapstructure:"grpc"`
+	Keys       keys.Config       `mapstructure:"keys"`
+	Password   password.Config   `mapstructure:"password"`
+	OIDC       oidc.Config       `mapstructure:"oidc"`
+	TOTP       totp.Config       `mapstructure:"totp"`
+	Secret     secret.Config     `mapstructure:"secret"`
+	WebAuthn   webauthn.Config   `mapstructure:"webauthn"`
+	Federation federation.Config `mapstructure:"federation"`
+	LDAP       ldap.Config       `mapstructure:"ldap"`
+	Lockout    lockout.Config    `mapstructure:"lockout"`
+	RateLimit  ratelimit.Config  `mapstructure:"ratelimit"`
+	Mail       mail.Config       `mapstructure:"mail"`
+	RBAC       rbac.Config       `mapstructure:"rbac"`
+	SCIM       scim.Config       `mapstructure:"scim"`
+
+	EmailVerification identity.EmailVerificationConfig `mapstructure:"email_verification"`
+	PasswordReset     identity.PasswordResetConfig     `mapstructure:"password_reset"`
 }
 
 type GRPC struct {
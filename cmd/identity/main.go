@@ -15,15 +15,36 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"github.com/karta0898098/iam/cmd/identity/configs"
+	adminpb "github.com/karta0898098/iam/pb/admin"
 	pb "github.com/karta0898098/iam/pb/identity"
+	policypb "github.com/karta0898098/iam/pb/policy"
+	rbacpb "github.com/karta0898098/iam/pb/rbac"
+	adminendpoints "github.com/karta0898098/iam/pkg/app/admin/endpoints"
+	admingrpc "github.com/karta0898098/iam/pkg/app/admin/transports/grpc"
+	adminhttp "github.com/karta0898098/iam/pkg/app/admin/transports/http"
+	auditendpoints "github.com/karta0898098/iam/pkg/app/audit/endpoints"
+	audithttp "github.com/karta0898098/iam/pkg/app/audit/transports/http"
 	"github.com/karta0898098/iam/pkg/app/identity/endpoints"
 	transportgrpc "github.com/karta0898098/iam/pkg/app/identity/transports/grpc"
 	transportshttp "github.com/karta0898098/iam/pkg/app/identity/transports/http"
+	oauth2endpoints "github.com/karta0898098/iam/pkg/app/oauth2/endpoints"
+	oauth2http "github.com/karta0898098/iam/pkg/app/oauth2/transports/http"
+	policyendpoints "github.com/karta0898098/iam/pkg/app/policy/endpoints"
+	policygrpc "github.com/karta0898098/iam/pkg/app/policy/transports/grpc"
+	policyhttp "github.com/karta0898098/iam/pkg/app/policy/transports/http"
+	rbacendpoints "github.com/karta0898098/iam/pkg/app/rbac/endpoints"
+	rbacgrpc "github.com/karta0898098/iam/pkg/app/rbac/transports/grpc"
+	rbachttp "github.com/karta0898098/iam/pkg/app/rbac/transports/http"
+	scimendpoints "github.com/karta0898098/iam/pkg/app/scim/endpoints"
+	scimhttp "github.com/karta0898098/iam/pkg/app/scim/transports/http"
+	tenantendpoints "github.com/karta0898098/iam/pkg/app/tenant/endpoints"
+	tenanthttp "github.com/karta0898098/iam/pkg/app/tenant/transports/http"
 	"github.com/karta0898098/iam/pkg/db"
 	pkggrpc "github.com/karta0898098/iam/pkg/grpc"
 	"github.com/karta0898098/iam/pkg/http"
 	"github.com/karta0898098/iam/pkg/http/middleware"
 	"github.com/karta0898098/iam/pkg/logging"
+	"github.com/karta0898098/iam/pkg/ratelimit"
 )
 
 // Application define application
@@ -32,6 +53,14 @@ type Application struct {
 	config     configs.Configurations
 	httpServer *echo.Echo
 	endpoints  endpoints.Endpoints
+	oauth2     oauth2endpoints.Endpoints
+	rbac       rbacendpoints.Endpoints
+	policy     policyendpoints.Endpoints
+	tenant     tenantendpoints.Endpoints
+	admin      adminendpoints.Endpoints
+	scim       scimendpoints.Endpoints
+	audit      auditendpoints.Endpoints
+	limiter    *ratelimit.Limiter
 }
 
 // NewApplication new application
@@ -39,12 +68,28 @@ func NewApplication(
 	logger zerolog.Logger,
 	config configs.Configurations,
 	endpoints endpoints.Endpoints,
+	oauth2 oauth2endpoints.Endpoints,
+	rbac rbacendpoints.Endpoints,
+	policy policyendpoints.Endpoints,
+	tenant tenantendpoints.Endpoints,
+	admin adminendpoints.Endpoints,
+	scim scimendpoints.Endpoints,
+	audit auditendpoints.Endpoints,
+	limiter *ratelimit.Limiter,
 ) *Application {
 	return &Application{
 		logger:     logger,
 		config:     config,
 		httpServer: http.NewEcho(config.HTTP),
 		endpoints:  endpoints,
+		oauth2:     oauth2,
+		rbac:       rbac,
+		policy:     policy,
+		tenant:     tenant,
+		admin:      admin,
+		scim:       scim,
+		audit:      audit,
+		limiter:    limiter,
 	}
 }
 
@@ -68,9 +113,18 @@ func main() {
 	}
 
 	// make app
-	app := NewApp(logger, config, dbConn)
+	app, err := NewApp(logger, config, dbConn)
+	if err != nil {
+		logger.
+			Panic().
+			Err(err).
+			Msg("failed to make app")
+	}
 	app.httpServer.Pre(middleware.NewLoggerMiddleware(logger))
 	app.httpServer.Use(middleware.NewLoggingMiddleware())
+	app.httpServer.Use(middleware.NewClientIPMiddleware())
+	app.httpServer.Use(middleware.NewRateLimitMiddleware(app.limiter))
+	app.httpServer.Use(middleware.NewAuditMiddleware())
 	app.MakeRouter()
 
 	wg := &sync.WaitGroup{}
@@ -120,6 +174,104 @@ func (app *Application) startHttpServer(ctx context.Context, wg *sync.WaitGroup)
 func (app *Application) MakeRouter() *Application {
 	app.httpServer.POST("/signin", echo.WrapHandler(transportshttp.MakeSignin(app.endpoints)))
 	app.httpServer.POST("/signup", echo.WrapHandler(transportshttp.MakeSignup(app.endpoints)))
+	app.httpServer.POST("/token/refresh", echo.WrapHandler(transportshttp.MakeRefresh(app.endpoints)))
+	app.httpServer.POST("/token/introspect", echo.WrapHandler(transportshttp.MakeIntrospect(app.endpoints)))
+	app.httpServer.POST("/signout", echo.WrapHandler(transportshttp.MakeSignout(app.endpoints)))
+	app.httpServer.POST("/signout/all", echo.WrapHandler(transportshttp.MakeSignoutAll(app.endpoints)))
+	app.httpServer.GET("/sessions", echo.WrapHandler(transportshttp.MakeListSessions(app.endpoints)))
+	app.httpServer.POST("/sessions/revoke", echo.WrapHandler(transportshttp.MakeRevokeSession(app.endpoints)))
+	app.httpServer.POST("/email/verify", echo.WrapHandler(transportshttp.MakeVerifyEmail(app.endpoints)))
+	app.httpServer.POST("/email/verify/resend", echo.WrapHandler(transportshttp.MakeResendVerification(app.endpoints)))
+	app.httpServer.POST("/password/forgot", echo.WrapHandler(transportshttp.MakeRequestPasswordReset(app.endpoints)))
+	app.httpServer.POST("/password/reset", echo.WrapHandler(transportshttp.MakeResetPassword(app.endpoints)))
+	app.httpServer.POST("/password/change", echo.WrapHandler(transportshttp.MakeChangePassword(app.endpoints)))
+	app.httpServer.GET("/profile", echo.WrapHandler(transportshttp.MakeGetProfile(app.endpoints)))
+	app.httpServer.PATCH("/profile", echo.WrapHandler(transportshttp.MakeUpdateProfile(app.endpoints)))
+	app.httpServer.POST("/mfa/verify", echo.WrapHandler(transportshttp.MakeVerifyMFA(app.endpoints)))
+	app.httpServer.POST("/mfa/totp/enroll", echo.WrapHandler(transportshttp.MakeEnrollTOTP(app.endpoints)))
+	app.httpServer.POST("/mfa/totp/confirm", echo.WrapHandler(transportshttp.MakeConfirmTOTP(app.endpoints)))
+	app.httpServer.POST("/webauthn/register/begin", echo.WrapHandler(transportshttp.MakeWebAuthnRegisterBegin(app.endpoints)))
+	app.httpServer.POST("/webauthn/register/finish", echo.WrapHandler(transportshttp.MakeWebAuthnRegisterFinish(app.endpoints)))
+	app.httpServer.POST("/webauthn/signin/begin", echo.WrapHandler(transportshttp.MakeWebAuthnSigninBegin(app.endpoints)))
+	app.httpServer.POST("/webauthn/signin/finish", echo.WrapHandler(transportshttp.MakeWebAuthnSigninFinish(app.endpoints)))
+	app.httpServer.POST("/federation/:provider/authorize", http.WrapHandler(transportshttp.MakeFederationSigninBegin(app.endpoints)))
+	app.httpServer.POST("/federation/:provider/link", http.WrapHandler(transportshttp.MakeFederationLinkBegin(app.endpoints)))
+	app.httpServer.POST("/federation/:provider/callback", http.WrapHandler(transportshttp.MakeFederationSigninFinish(app.endpoints)))
+	app.httpServer.GET("/.well-known/jwks.json", echo.WrapHandler(transportshttp.MakeJWKS(app.endpoints)))
+	app.httpServer.GET("/.well-known/openid-configuration", echo.WrapHandler(transportshttp.MakeDiscovery(app.endpoints)))
+
+	app.httpServer.GET("/authorize", echo.WrapHandler(oauth2http.MakeAuthorize(app.oauth2)))
+	app.httpServer.POST("/authorize", echo.WrapHandler(oauth2http.MakeConsent(app.oauth2)))
+	app.httpServer.POST("/token", echo.WrapHandler(oauth2http.MakeToken(app.oauth2)))
+
+	admin := app.httpServer.Group("/admin")
+	admin.POST("/clients", echo.WrapHandler(oauth2http.MakeCreateClient(app.oauth2)))
+	admin.GET("/clients", echo.WrapHandler(oauth2http.MakeListClients(app.oauth2)))
+	admin.GET("/clients/:id", http.WrapHandler(oauth2http.MakeGetClient(app.oauth2)))
+	admin.PUT("/clients/:id", http.WrapHandler(oauth2http.MakeUpdateClient(app.oauth2)))
+	admin.DELETE("/clients/:id", http.WrapHandler(oauth2http.MakeDeleteClient(app.oauth2)))
+	admin.POST("/clients/:id/secret", http.WrapHandler(oauth2http.MakeResetClientSecret(app.oauth2)))
+	admin.GET("/users", echo.WrapHandler(adminhttp.MakeListUsers(app.admin)))
+	admin.GET("/users/:id", http.WrapHandler(adminhttp.MakeGetUser(app.admin)))
+	admin.DELETE("/users/:id", http.WrapHandler(adminhttp.MakeDeleteUser(app.admin)))
+	admin.POST("/users/:id/suspend", http.WrapHandler(adminhttp.MakeSuspendUser(app.admin)))
+	admin.POST("/users/:id/reactivate", http.WrapHandler(adminhttp.MakeReactivateUser(app.admin)))
+	admin.POST("/users/:id/unlock", http.WrapHandler(transportshttp.MakeUnlockUser(app.endpoints)))
+	admin.GET("/users/:id/roles", http.WrapHandler(rbachttp.MakeListUserRoles(app.rbac)))
+	admin.POST("/users/:id/roles", http.WrapHandler(rbachttp.MakeAssignRole(app.rbac)))
+	admin.DELETE("/users/:id/roles/:role_id", http.WrapHandler(rbachttp.MakeUnassignRole(app.rbac)))
+	admin.GET("/users/:id/groups", http.WrapHandler(rbachttp.MakeListUserGroups(app.rbac)))
+	admin.POST("/permissions", echo.WrapHandler(rbachttp.MakeCreatePermission(app.rbac)))
+	admin.GET("/permissions", echo.WrapHandler(rbachttp.MakeListPermissions(app.rbac)))
+	admin.PUT("/permissions/:name", http.WrapHandler(rbachttp.MakeUpdatePermission(app.rbac)))
+	admin.DELETE("/permissions/:name", http.WrapHandler(rbachttp.MakeDeletePermission(app.rbac)))
+	admin.POST("/roles", echo.WrapHandler(rbachttp.MakeCreateRole(app.rbac)))
+	admin.GET("/roles", echo.WrapHandler(rbachttp.MakeListRoles(app.rbac)))
+	admin.GET("/roles/:id", http.WrapHandler(rbachttp.MakeGetRole(app.rbac)))
+	admin.PUT("/roles/:id", http.WrapHandler(rbachttp.MakeUpdateRole(app.rbac)))
+	admin.DELETE("/roles/:id", http.WrapHandler(rbachttp.MakeDeleteRole(app.rbac)))
+	admin.POST("/groups", echo.WrapHandler(rbachttp.MakeCreateGroup(app.rbac)))
+	admin.GET("/groups", echo.WrapHandler(rbachttp.MakeListGroups(app.rbac)))
+	admin.GET("/groups/:id", http.WrapHandler(rbachttp.MakeGetGroup(app.rbac)))
+	admin.PUT("/groups/:id", http.WrapHandler(rbachttp.MakeUpdateGroup(app.rbac)))
+	admin.DELETE("/groups/:id", http.WrapHandler(rbachttp.MakeDeleteGroup(app.rbac)))
+	admin.POST("/groups/:id/members", http.WrapHandler(rbachttp.MakeAddGroupMember(app.rbac)))
+	admin.DELETE("/groups/:id/members/:user_id", http.WrapHandler(rbachttp.MakeRemoveGroupMember(app.rbac)))
+	admin.POST("/policies", echo.WrapHandler(policyhttp.MakeCreatePolicy(app.policy)))
+	admin.GET("/policies", echo.WrapHandler(policyhttp.MakeListPolicies(app.policy)))
+	admin.GET("/policies/:id", http.WrapHandler(policyhttp.MakeGetPolicy(app.policy)))
+	admin.PUT("/policies/:id", http.WrapHandler(policyhttp.MakeUpdatePolicy(app.policy)))
+	admin.DELETE("/policies/:id", http.WrapHandler(policyhttp.MakeDeletePolicy(app.policy)))
+	admin.GET("/policies/:id/attachments", http.WrapHandler(policyhttp.MakeListAttachments(app.policy)))
+	admin.POST("/policies/:id/attachments", http.WrapHandler(policyhttp.MakeAttachPolicy(app.policy)))
+	admin.DELETE("/policies/:id/attachments/:principal_type/:principal_id", http.WrapHandler(policyhttp.MakeDetachPolicy(app.policy)))
+	admin.GET("/audit-events", echo.WrapHandler(audithttp.MakeQueryAuditLog(app.audit)))
+	admin.POST("/tenants", echo.WrapHandler(tenanthttp.MakeCreateTenant(app.tenant)))
+	admin.GET("/tenants", echo.WrapHandler(tenanthttp.MakeListTenants(app.tenant)))
+	admin.GET("/tenants/:id", http.WrapHandler(tenanthttp.MakeGetTenant(app.tenant)))
+	admin.PUT("/tenants/:id", http.WrapHandler(tenanthttp.MakeUpdateTenant(app.tenant)))
+	admin.DELETE("/tenants/:id", http.WrapHandler(tenanthttp.MakeDeleteTenant(app.tenant)))
+	admin.GET("/tenants/:id/members", http.WrapHandler(tenanthttp.MakeListMembers(app.tenant)))
+	admin.POST("/tenants/:id/members", http.WrapHandler(tenanthttp.MakeAddMember(app.tenant)))
+	admin.PUT("/tenants/:id/members/:user_id", http.WrapHandler(tenanthttp.MakeUpdateMember(app.tenant)))
+	admin.DELETE("/tenants/:id/members/:user_id", http.WrapHandler(tenanthttp.MakeRemoveMember(app.tenant)))
+
+	// SCIM 2.0 provisioning for HR systems and identity providers
+	scim := app.httpServer.Group("/scim/v2")
+	scim.GET("/ServiceProviderConfig", echo.WrapHandler(scimhttp.MakeGetServiceProviderConfig(app.scim)))
+	scim.GET("/ResourceTypes", echo.WrapHandler(scimhttp.MakeListResourceTypes(app.scim)))
+	scim.GET("/Users", echo.WrapHandler(scimhttp.MakeListUsers(app.scim)))
+	scim.POST("/Users", echo.WrapHandler(scimhttp.MakeCreateUser(app.scim)))
+	scim.GET("/Users/:id", http.WrapHandler(scimhttp.MakeGetUser(app.scim)))
+	scim.PUT("/Users/:id", http.WrapHandler(scimhttp.MakeReplaceUser(app.scim)))
+	scim.PATCH("/Users/:id", http.WrapHandler(scimhttp.MakePatchUser(app.scim)))
+	scim.DELETE("/Users/:id", http.WrapHandler(scimhttp.MakeDeleteUser(app.scim)))
+	scim.GET("/Groups", echo.WrapHandler(scimhttp.MakeListGroups(app.scim)))
+	scim.POST("/Groups", echo.WrapHandler(scimhttp.MakeCreateGroup(app.scim)))
+	scim.GET("/Groups/:id", http.WrapHandler(scimhttp.MakeGetGroup(app.scim)))
+	scim.PUT("/Groups/:id", http.WrapHandler(scimhttp.MakeReplaceGroup(app.scim)))
+	scim.PATCH("/Groups/:id", http.WrapHandler(scimhttp.MakePatchGroup(app.scim)))
+	scim.DELETE("/Groups/:id", http.WrapHandler(scimhttp.MakeDeleteGroup(app.scim)))
 
 	return app
 }
@@ -141,10 +293,17 @@ func (app *Application) startGRPCServer(ctx context.Context, wg *sync.WaitGroup)
 	server = grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			kitgrpc.Interceptor,
+			pkggrpc.UnaryServerClientIPInterceptor(),
 			pkggrpc.UnaryServerLoggerInterceptor(app.logger),
+			pkggrpc.UnaryServerErrorInterceptor(),
+			pkggrpc.UnaryServerRateLimitInterceptor(app.limiter),
+			pkggrpc.UnaryServerAuditInterceptor(),
 		),
 	)
 	pb.RegisterIdentityServiceServer(server, transportgrpc.MakeGRPCServer(app.endpoints))
+	rbacpb.RegisterRBACServiceServer(server, rbacgrpc.MakeGRPCServer(app.rbac))
+	policypb.RegisterPolicyServiceServer(server, policygrpc.MakeGRPCServer(app.policy))
+	adminpb.RegisterAdminServiceServer(server, admingrpc.MakeGRPCServer(app.admin))
 	reflection.Register(server)
 
 	app.logger.Info().Msgf("start grpc server on %v", port)
@@ -8,14 +8,32 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/karta0898098/iam/cmd/identity/configs"
+	"github.com/karta0898098/iam/pkg/app/admin"
+	"github.com/karta0898098/iam/pkg/app/audit"
 	"github.com/karta0898098/iam/pkg/app/identity"
+	"github.com/karta0898098/iam/pkg/app/oauth2"
+	"github.com/karta0898098/iam/pkg/app/policy"
+	"github.com/karta0898098/iam/pkg/app/rbac"
+	"github.com/karta0898098/iam/pkg/app/scim"
+	"github.com/karta0898098/iam/pkg/app/tenant"
 	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/ratelimit"
 )
 
-func NewApp(logger zerolog.Logger, cfg configs.Configurations, conn db.Connection) *Application {
+func NewApp(logger zerolog.Logger, cfg configs.Configurations, conn db.Connection) (*Application, error) {
 	wire.Build(
+		wire.FieldsOf(new(configs.Configurations), "Keys", "Password", "OIDC", "TOTP", "Secret", "WebAuthn", "Federation", "LDAP", "Lockout", "RateLimit", "Mail", "RBAC", "SCIM", "EmailVerification", "PasswordReset"),
 		identity.DefaultProvider,
+		oauth2.DefaultProvider,
+		rbac.DefaultProvider,
+		policy.DefaultProvider,
+		tenant.DefaultProvider,
+		admin.DefaultProvider,
+		scim.DefaultProvider,
+		audit.DefaultProvider,
+		ratelimit.New,
+		ratelimit.NewStore,
 		NewApplication,
 	)
-	return nil
+	return nil, nil
 }
@@ -10,18 +10,110 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/karta0898098/iam/cmd/identity/configs"
+	endpoints6 "github.com/karta0898098/iam/pkg/app/admin/endpoints"
+	service7 "github.com/karta0898098/iam/pkg/app/admin/service"
+	endpoints8 "github.com/karta0898098/iam/pkg/app/audit/endpoints"
+	repository3 "github.com/karta0898098/iam/pkg/app/audit/repository"
+	"github.com/karta0898098/iam/pkg/app/audit/service"
 	"github.com/karta0898098/iam/pkg/app/identity/endpoints"
 	"github.com/karta0898098/iam/pkg/app/identity/repository"
-	"github.com/karta0898098/iam/pkg/app/identity/service"
+	service4 "github.com/karta0898098/iam/pkg/app/identity/service"
+	endpoints2 "github.com/karta0898098/iam/pkg/app/oauth2/endpoints"
+	repository5 "github.com/karta0898098/iam/pkg/app/oauth2/repository"
+	service5 "github.com/karta0898098/iam/pkg/app/oauth2/service"
+	endpoints4 "github.com/karta0898098/iam/pkg/app/policy/endpoints"
+	repository6 "github.com/karta0898098/iam/pkg/app/policy/repository"
+	service6 "github.com/karta0898098/iam/pkg/app/policy/service"
+	endpoints3 "github.com/karta0898098/iam/pkg/app/rbac/endpoints"
+	repository2 "github.com/karta0898098/iam/pkg/app/rbac/repository"
+	service2 "github.com/karta0898098/iam/pkg/app/rbac/service"
+	endpoints7 "github.com/karta0898098/iam/pkg/app/scim/endpoints"
+	repository7 "github.com/karta0898098/iam/pkg/app/scim/repository"
+	service8 "github.com/karta0898098/iam/pkg/app/scim/service"
+	endpoints5 "github.com/karta0898098/iam/pkg/app/tenant/endpoints"
+	repository4 "github.com/karta0898098/iam/pkg/app/tenant/repository"
+	service3 "github.com/karta0898098/iam/pkg/app/tenant/service"
 	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/federation"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/ldap"
+	"github.com/karta0898098/iam/pkg/lockout"
+	"github.com/karta0898098/iam/pkg/mail"
+	"github.com/karta0898098/iam/pkg/password"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+	"github.com/karta0898098/iam/pkg/secret"
+	"github.com/karta0898098/iam/pkg/webauthn"
 )
 
 // Injectors from wire.go:
 
-func NewApp(logger zerolog.Logger, cfg configs.Configurations, conn db.Connection) *Application {
-	repositoryRepository := repository.New(conn)
-	identityService := service.New(repositoryRepository)
-	endpointsEndpoints := endpoints.New(identityService)
-	application := NewApplication(logger, cfg, endpointsEndpoints)
-	return application
+func NewApp(logger zerolog.Logger, cfg configs.Configurations, conn db.Connection) (*Application, error) {
+	config := cfg.Secret
+	cipher, err := secret.NewCipher(config)
+	if err != nil {
+		return nil, err
+	}
+	repositoryRepository := repository.New(conn, cipher)
+	keysConfig := cfg.Keys
+	keyManager, err := keys.NewKeyManager(keysConfig)
+	if err != nil {
+		return nil, err
+	}
+	passwordConfig := cfg.Password
+	hasher, err := password.New(passwordConfig)
+	if err != nil {
+		return nil, err
+	}
+	totpConfig := cfg.TOTP
+	webauthnConfig := cfg.WebAuthn
+	relyingParty := webauthn.New(webauthnConfig)
+	federationConfig := cfg.Federation
+	federationFederation := federation.New(federationConfig)
+	lockoutConfig := cfg.Lockout
+	store := lockout.NewSQLStore(conn)
+	guard := lockout.New(lockoutConfig, store)
+	mailConfig := cfg.Mail
+	mailer, err := mail.New(mailConfig)
+	if err != nil {
+		return nil, err
+	}
+	emailVerificationConfig := cfg.EmailVerification
+	passwordResetConfig := cfg.PasswordReset
+	serviceConfig := cfg.RBAC
+	repository8 := repository2.New(conn)
+	repository9 := repository3.New(conn)
+	auditService := service.New(repository9)
+	recorder := service.NewRecorder(auditService)
+	rbacService := service2.New(repository8, repositoryRepository, recorder)
+	roleResolver := service2.NewRoleResolver(serviceConfig, rbacService)
+	repository10 := repository4.New(conn)
+	tenantService := service3.New(repository10, repositoryRepository, recorder)
+	tenantDirectory := service3.NewTenantDirectory(tenantService)
+	ldapConfig := cfg.LDAP
+	directory := ldap.New(ldapConfig)
+	authenticator := service4.NewLDAPAuthenticator(repositoryRepository, directory, tenantDirectory)
+	identityService := service4.New(repositoryRepository, keyManager, hasher, totpConfig, relyingParty, federationFederation, guard, mailer, emailVerificationConfig, passwordResetConfig, roleResolver, tenantDirectory, authenticator, recorder)
+	oidcConfig := cfg.OIDC
+	ratelimitConfig := cfg.RateLimit
+	ratelimitStore := ratelimit.NewStore(ratelimitConfig, conn)
+	limiter := ratelimit.New(ratelimitConfig, ratelimitStore)
+	permissionChecker := service2.NewPermissionChecker(rbacService)
+	endpointsEndpoints := endpoints.New(identityService, keyManager, oidcConfig, limiter, permissionChecker)
+	repository11 := repository5.New(conn)
+	oAuth2Service := service5.New(repository11, repositoryRepository, identityService, keyManager, hasher, roleResolver, permissionChecker, recorder)
+	endpoints9 := endpoints2.New(oAuth2Service, identityService, permissionChecker, keyManager, oidcConfig, limiter)
+	endpoints10 := endpoints3.New(rbacService, identityService, limiter)
+	repository12 := repository6.New(conn)
+	policyService := service6.New(repository12, repositoryRepository, repository8, recorder)
+	endpoints11 := endpoints4.New(policyService, identityService, permissionChecker, limiter)
+	endpoints12 := endpoints5.New(tenantService, identityService, permissionChecker, limiter)
+	adminService := service7.New(repositoryRepository, recorder)
+	endpoints13 := endpoints6.New(adminService, identityService, permissionChecker, limiter)
+	repository13 := repository7.New(conn)
+	config2 := cfg.SCIM
+	scimService := service8.New(repository13, repositoryRepository, rbacService, hasher, config2, recorder)
+	endpoints14 := endpoints7.New(scimService, config2)
+	endpoints15 := endpoints8.New(auditService, identityService, permissionChecker, limiter)
+	application := NewApplication(logger, cfg, endpointsEndpoints, endpoints9, endpoints10, endpoints11, endpoints12, endpoints13, endpoints14, endpoints15, limiter)
+	return application, nil
 }
@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE sessions
+    ADD COLUMN family_id  VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN rotated_at BIGINT      NOT NULL DEFAULT 0;
+
+UPDATE sessions
+SET family_id = id
+WHERE family_id = '';
+
+CREATE INDEX IF NOT EXISTS sessions_family_id_idx ON sessions (family_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS sessions_family_id_idx;
+
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS family_id,
+    DROP COLUMN IF EXISTS rotated_at;
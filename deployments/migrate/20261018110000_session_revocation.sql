@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE sessions
+    ADD COLUMN revoked_at BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS sessions_user_id_idx;
+
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS revoked_at;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- adaptive hash is encoded in PHC string format which is longer than sha256 hex digest
+ALTER TABLE users
+    ALTER COLUMN password TYPE VARCHAR(255);
+
+-- +goose Down
+ALTER TABLE users
+    ALTER COLUMN password TYPE VARCHAR(64);
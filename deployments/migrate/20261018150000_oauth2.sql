@@ -0,0 +1,52 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS oauth2_clients
+(
+    id            VARCHAR(64)  NOT NULL UNIQUE,
+    name          VARCHAR(64)  NOT NULL,
+    secret_hash   VARCHAR(255)          DEFAULT '',
+    client_type   SMALLINT     NOT NULL DEFAULT 1,
+    redirect_uris TEXT         NOT NULL,
+    scopes        VARCHAR(255) NOT NULL DEFAULT '',
+    created_at    BIGINT       NOT NULL,
+    updated_at    BIGINT       NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS oauth2_authorization_codes
+(
+    code_hash             VARCHAR(64)  NOT NULL UNIQUE,
+    client_id             VARCHAR(64)  NOT NULL,
+    user_id               VARCHAR(20)  NOT NULL,
+    redirect_uri          VARCHAR(300) NOT NULL,
+    scopes                VARCHAR(255) NOT NULL DEFAULT '',
+    nonce                 VARCHAR(255)          DEFAULT '',
+    code_challenge        VARCHAR(128) NOT NULL,
+    code_challenge_method VARCHAR(10)  NOT NULL,
+    created_at            BIGINT       NOT NULL,
+    expires_at            BIGINT       NOT NULL,
+    used_at               BIGINT       NOT NULL DEFAULT 0,
+    PRIMARY KEY (code_hash)
+);
+
+CREATE TABLE IF NOT EXISTS oauth2_consents
+(
+    user_id    VARCHAR(20)  NOT NULL,
+    client_id  VARCHAR(64)  NOT NULL,
+    scopes     VARCHAR(255) NOT NULL DEFAULT '',
+    created_at BIGINT       NOT NULL,
+    updated_at BIGINT       NOT NULL,
+    PRIMARY KEY (user_id, client_id)
+);
+
+ALTER TABLE sessions
+    ADD COLUMN client_id VARCHAR(64) DEFAULT '',
+    ADD COLUMN scope     VARCHAR(255) DEFAULT '';
+
+-- +goose Down
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS scope,
+    DROP COLUMN IF EXISTS client_id;
+
+DROP TABLE IF EXISTS oauth2_consents;
+DROP TABLE IF EXISTS oauth2_authorization_codes;
+DROP TABLE IF EXISTS oauth2_clients;
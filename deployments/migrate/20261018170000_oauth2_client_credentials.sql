@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE oauth2_clients
+    ADD COLUMN grant_types VARCHAR(255) NOT NULL DEFAULT 'authorization_code refresh_token';
+
+-- client acting on behalf of itself is the subject of session
+ALTER TABLE sessions
+    ALTER COLUMN user_id TYPE VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS sessions_client_id_idx ON sessions (client_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS sessions_client_id_idx;
+
+ALTER TABLE sessions
+    ALTER COLUMN user_id TYPE VARCHAR(20);
+
+ALTER TABLE oauth2_clients
+    DROP COLUMN IF EXISTS grant_types;
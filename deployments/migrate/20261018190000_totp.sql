@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_totp_factors
+(
+    user_id           VARCHAR(20) NOT NULL UNIQUE,
+    secret            TEXT        NOT NULL,
+    confirmed_at      BIGINT      NOT NULL DEFAULT 0,
+    last_used_counter BIGINT      NOT NULL DEFAULT 0,
+    created_at        BIGINT      NOT NULL,
+    updated_at        BIGINT      NOT NULL,
+    PRIMARY KEY (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes
+(
+    user_id    VARCHAR(20) NOT NULL,
+    code_hash  VARCHAR(64) NOT NULL,
+    created_at BIGINT      NOT NULL,
+    used_at    BIGINT      NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, code_hash)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_totp_factors;
@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS webauthn_credentials
+(
+    id                 VARCHAR(1400) NOT NULL,
+    user_id            VARCHAR(20)   NOT NULL,
+    name               VARCHAR(64)   NOT NULL DEFAULT '',
+    public_key         BYTEA         NOT NULL,
+    aaguid             BYTEA         NOT NULL,
+    sign_count         BIGINT        NOT NULL DEFAULT 0,
+    attestation_format VARCHAR(32)   NOT NULL,
+    backup_eligible    BOOLEAN       NOT NULL DEFAULT FALSE,
+    created_at         BIGINT        NOT NULL,
+    last_used_at       BIGINT        NOT NULL DEFAULT 0,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS webauthn_credentials_user_id_idx ON webauthn_credentials (user_id);
+
+CREATE TABLE IF NOT EXISTS webauthn_challenges
+(
+    id         VARCHAR(20) NOT NULL,
+    user_id    VARCHAR(20) NOT NULL DEFAULT '',
+    ceremony   VARCHAR(20) NOT NULL,
+    challenge  BYTEA       NOT NULL,
+    created_at BIGINT      NOT NULL,
+    expires_at BIGINT      NOT NULL,
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE sessions
+    ADD COLUMN auth_method VARCHAR(16) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS auth_method;
+
+DROP TABLE IF EXISTS webauthn_challenges;
+DROP INDEX IF EXISTS webauthn_credentials_user_id_idx;
+DROP TABLE IF EXISTS webauthn_credentials;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS lockout_records
+(
+    key             VARCHAR(320) NOT NULL,
+    failures        INT          NOT NULL DEFAULT 0,
+    last_failure_at BIGINT       NOT NULL DEFAULT 0,
+    locked_until    BIGINT       NOT NULL DEFAULT 0,
+    PRIMARY KEY (key)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS lockout_records;
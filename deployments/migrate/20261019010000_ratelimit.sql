@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS ratelimit_counters
+(
+    key        VARCHAR(512) NOT NULL,
+    count      BIGINT       NOT NULL DEFAULT 0,
+    expires_at BIGINT       NOT NULL,
+    PRIMARY KEY (key)
+);
+
+CREATE INDEX IF NOT EXISTS ratelimit_counters_expires_at_idx ON ratelimit_counters (expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS ratelimit_counters_expires_at_idx;
+DROP TABLE IF EXISTS ratelimit_counters;
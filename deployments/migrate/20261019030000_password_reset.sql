@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS password_reset_tokens
+(
+    id         VARCHAR(64) NOT NULL,
+    user_id    VARCHAR(20) NOT NULL,
+    created_at BIGINT      NOT NULL,
+    expires_at BIGINT      NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS password_reset_tokens_user_id_idx ON password_reset_tokens (user_id);
+CREATE INDEX IF NOT EXISTS users_email_idx ON users (email);
+
+-- +goose Down
+DROP INDEX IF EXISTS users_email_idx;
+DROP INDEX IF EXISTS password_reset_tokens_user_id_idx;
+DROP TABLE IF EXISTS password_reset_tokens;
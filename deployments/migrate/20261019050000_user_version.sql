@@ -0,0 +1,8 @@
+-- +goose Up
+-- version is compared on update so concurrent edits of same user never overwrite each other
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE users
+    DROP COLUMN IF EXISTS version;
@@ -0,0 +1,43 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS permissions
+(
+    name        VARCHAR(128) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  BIGINT       NOT NULL,
+    updated_at  BIGINT       NOT NULL,
+    PRIMARY KEY (name)
+);
+
+CREATE TABLE IF NOT EXISTS roles
+(
+    id          VARCHAR(20)  NOT NULL UNIQUE,
+    name        VARCHAR(64)  NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  BIGINT       NOT NULL,
+    updated_at  BIGINT       NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions
+(
+    role_id    VARCHAR(20)  NOT NULL,
+    permission VARCHAR(128) NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles
+(
+    user_id    VARCHAR(20) NOT NULL,
+    role_id    VARCHAR(20) NOT NULL,
+    created_at BIGINT      NOT NULL,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_roles_role_id_idx ON user_roles (role_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS user_roles_role_id_idx;
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS permissions;
@@ -0,0 +1,50 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS groups
+(
+    id          VARCHAR(20)  NOT NULL UNIQUE,
+    name        VARCHAR(64)  NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  BIGINT       NOT NULL,
+    updated_at  BIGINT       NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS group_members
+(
+    group_id   VARCHAR(20) NOT NULL,
+    user_id    VARCHAR(20) NOT NULL,
+    created_at BIGINT      NOT NULL,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS group_members_user_id_idx ON group_members (user_id);
+
+CREATE TABLE IF NOT EXISTS policies
+(
+    id          VARCHAR(20)  NOT NULL UNIQUE,
+    name        VARCHAR(128) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    document    TEXT         NOT NULL,
+    created_at  BIGINT       NOT NULL,
+    updated_at  BIGINT       NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS policy_attachments
+(
+    policy_id      VARCHAR(20) NOT NULL,
+    principal_type VARCHAR(16) NOT NULL,
+    principal_id   VARCHAR(20) NOT NULL,
+    created_at     BIGINT      NOT NULL,
+    PRIMARY KEY (policy_id, principal_type, principal_id)
+);
+
+CREATE INDEX IF NOT EXISTS policy_attachments_principal_idx ON policy_attachments (principal_type, principal_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS policy_attachments_principal_idx;
+DROP TABLE IF EXISTS policy_attachments;
+DROP TABLE IF EXISTS policies;
+DROP INDEX IF EXISTS group_members_user_id_idx;
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS groups;
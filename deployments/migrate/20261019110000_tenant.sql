@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tenants
+(
+    id           VARCHAR(20)  NOT NULL UNIQUE,
+    name         VARCHAR(64)  NOT NULL UNIQUE,
+    display_name VARCHAR(255) NOT NULL DEFAULT '',
+    created_at   BIGINT       NOT NULL,
+    updated_at   BIGINT       NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS tenant_members
+(
+    tenant_id  VARCHAR(20) NOT NULL,
+    user_id    VARCHAR(20) NOT NULL,
+    role       VARCHAR(16) NOT NULL,
+    created_at BIGINT      NOT NULL,
+    updated_at BIGINT      NOT NULL,
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+-- existing users and sessions belong to default tenant which id is empty
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(20) NOT NULL DEFAULT '';
+
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(20) NOT NULL DEFAULT '';
+
+-- the same username can be used in different tenants
+CREATE UNIQUE INDEX IF NOT EXISTS users_tenant_id_username_idx ON users (tenant_id, username);
+
+-- +goose Down
+DROP INDEX IF EXISTS users_tenant_id_username_idx;
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS tenant_id;
+DROP TABLE IF EXISTS tenant_members;
+DROP TABLE IF EXISTS tenants;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- admin lists users of tenant newest first
+CREATE INDEX IF NOT EXISTS users_tenant_id_created_at_idx ON users (tenant_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS users_tenant_id_created_at_idx;
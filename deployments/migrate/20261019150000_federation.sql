@@ -0,0 +1,33 @@
+-- +goose Up
+-- subject of upstream provider linked to local user, the same subject is other user in other tenant
+CREATE TABLE IF NOT EXISTS identities
+(
+    tenant_id    VARCHAR(20)  NOT NULL DEFAULT '',
+    provider     VARCHAR(20)  NOT NULL,
+    subject      VARCHAR(255) NOT NULL,
+    user_id      VARCHAR(20)  NOT NULL,
+    email        VARCHAR(255) NOT NULL DEFAULT '',
+    created_at   BIGINT       NOT NULL,
+    last_used_at BIGINT       NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS identities_user_id_idx ON identities (user_id);
+
+CREATE TABLE IF NOT EXISTS federation_states
+(
+    id            VARCHAR(64) NOT NULL,
+    provider      VARCHAR(20) NOT NULL,
+    tenant_id     VARCHAR(20) NOT NULL DEFAULT '',
+    user_id       VARCHAR(20) NOT NULL DEFAULT '',
+    nonce         VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(64) NOT NULL,
+    created_at    BIGINT      NOT NULL,
+    expires_at    BIGINT      NOT NULL,
+    PRIMARY KEY (id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS federation_states;
+DROP INDEX IF EXISTS identities_user_id_idx;
+DROP TABLE IF EXISTS identities;
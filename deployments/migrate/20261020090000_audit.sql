@@ -0,0 +1,41 @@
+-- +goose Up
+-- append-only audit log, every event carry hash of the previous event so rewriting history is detectable
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    seq        BIGINT       NOT NULL,
+    tenant_id  VARCHAR(20)  NOT NULL DEFAULT '',
+    actor      VARCHAR(255) NOT NULL DEFAULT '',
+    action     VARCHAR(64)  NOT NULL,
+    target     VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64)  NOT NULL DEFAULT '',
+    platform   VARCHAR(32)  NOT NULL DEFAULT '',
+    device     VARCHAR(255) NOT NULL DEFAULT '',
+    outcome    VARCHAR(16)  NOT NULL,
+    error_code INT          NOT NULL DEFAULT 0,
+    created_at BIGINT       NOT NULL,
+    prev_hash  CHAR(64)     NOT NULL DEFAULT '',
+    hash       CHAR(64)     NOT NULL,
+    PRIMARY KEY (seq)
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_tenant_id_created_at_idx ON audit_events (tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_events_actor_idx ON audit_events (actor);
+CREATE INDEX IF NOT EXISTS audit_events_target_idx ON audit_events (target);
+
+-- head of chain, the single row is locked while appending so concurrent events are chained in turn
+CREATE TABLE IF NOT EXISTS audit_chain
+(
+    id   INT      NOT NULL,
+    seq  BIGINT   NOT NULL DEFAULT 0,
+    hash CHAR(64) NOT NULL DEFAULT '',
+    PRIMARY KEY (id)
+);
+
+INSERT INTO audit_chain (id, seq, hash) VALUES (1, 0, '');
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_chain;
+DROP INDEX IF EXISTS audit_events_target_idx;
+DROP INDEX IF EXISTS audit_events_actor_idx;
+DROP INDEX IF EXISTS audit_events_tenant_id_created_at_idx;
+DROP TABLE IF EXISTS audit_events;
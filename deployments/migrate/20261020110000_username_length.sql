@@ -0,0 +1,8 @@
+-- +goose Up
+-- username provisioned by directory or SCIM is often email address longer than 20
+ALTER TABLE users
+    ALTER COLUMN username TYPE VARCHAR(128);
+
+-- +goose Down
+ALTER TABLE users
+    ALTER COLUMN username TYPE VARCHAR(20);
@@ -0,0 +1,8 @@
+-- +goose Up
+-- session issued by exchanging code is revoked when the code is replayed
+ALTER TABLE oauth2_authorization_codes
+    ADD COLUMN IF NOT EXISTS session_id VARCHAR(20) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE oauth2_authorization_codes
+    DROP COLUMN IF EXISTS session_id;
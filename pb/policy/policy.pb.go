@@ -0,0 +1,359 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v4.23.2
+// source: pb/policy/policy.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AuthorizeReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID   string            `protobuf:"bytes,1,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	Action   string            `protobuf:"bytes,2,opt,name=Action,proto3" json:"Action,omitempty"`
+	Resource string            `protobuf:"bytes,3,opt,name=Resource,proto3" json:"Resource,omitempty"`
+	Context  map[string]string `protobuf:"bytes,4,rep,name=Context,proto3" json:"Context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *AuthorizeReq) Reset() {
+	*x = AuthorizeReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_policy_policy_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthorizeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizeReq) ProtoMessage() {}
+
+func (x *AuthorizeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_policy_policy_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizeReq.ProtoReflect.Descriptor instead.
+func (*AuthorizeReq) Descriptor() ([]byte, []int) {
+	return file_pb_policy_policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthorizeReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *AuthorizeReq) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuthorizeReq) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *AuthorizeReq) GetContext() map[string]string {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+type MatchedStatement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PolicyID string `protobuf:"bytes,1,opt,name=PolicyID,proto3" json:"PolicyID,omitempty"`
+	Sid      string `protobuf:"bytes,2,opt,name=Sid,proto3" json:"Sid,omitempty"`
+	Index    int32  `protobuf:"varint,3,opt,name=Index,proto3" json:"Index,omitempty"`
+	Effect   string `protobuf:"bytes,4,opt,name=Effect,proto3" json:"Effect,omitempty"`
+}
+
+func (x *MatchedStatement) Reset() {
+	*x = MatchedStatement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_policy_policy_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchedStatement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchedStatement) ProtoMessage() {}
+
+func (x *MatchedStatement) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_policy_policy_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchedStatement.ProtoReflect.Descriptor instead.
+func (*MatchedStatement) Descriptor() ([]byte, []int) {
+	return file_pb_policy_policy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MatchedStatement) GetPolicyID() string {
+	if x != nil {
+		return x.PolicyID
+	}
+	return ""
+}
+
+func (x *MatchedStatement) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
+func (x *MatchedStatement) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *MatchedStatement) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+type AuthorizeResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Allowed    bool                `protobuf:"varint,1,opt,name=Allowed,proto3" json:"Allowed,omitempty"`
+	Effect     string              `protobuf:"bytes,2,opt,name=Effect,proto3" json:"Effect,omitempty"`
+	Statements []*MatchedStatement `protobuf:"bytes,3,rep,name=Statements,proto3" json:"Statements,omitempty"`
+}
+
+func (x *AuthorizeResp) Reset() {
+	*x = AuthorizeResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_policy_policy_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthorizeResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizeResp) ProtoMessage() {}
+
+func (x *AuthorizeResp) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_policy_policy_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizeResp.ProtoReflect.Descriptor instead.
+func (*AuthorizeResp) Descriptor() ([]byte, []int) {
+	return file_pb_policy_policy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AuthorizeResp) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *AuthorizeResp) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+func (x *AuthorizeResp) GetStatements() []*MatchedStatement {
+	if x != nil {
+		return x.Statements
+	}
+	return nil
+}
+
+var File_pb_policy_policy_proto protoreflect.FileDescriptor
+
+var file_pb_policy_policy_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x62, 0x2f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2f, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcc, 0x01, 0x0a, 0x0c, 0x41, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
+	0x7a, 0x65, 0x52, 0x65, 0x71, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x1a, 0x3a, 0x0a, 0x0c, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x6e, 0x0a, 0x10, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x53, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x16, 0x0a, 0x06, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x22, 0x74, 0x0a, 0x0d, 0x41, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x31, 0x0a, 0x0a, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x32, 0x3b, 0x0a,
+	0x0d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2a,
+	0x0a, 0x09, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x12, 0x0d, 0x2e, 0x41, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x41, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pb_policy_policy_proto_rawDescOnce sync.Once
+	file_pb_policy_policy_proto_rawDescData = file_pb_policy_policy_proto_rawDesc
+)
+
+func file_pb_policy_policy_proto_rawDescGZIP() []byte {
+	file_pb_policy_policy_proto_rawDescOnce.Do(func() {
+		file_pb_policy_policy_proto_rawDescData = protoimpl.X.CompressGZIP(file_pb_policy_policy_proto_rawDescData)
+	})
+	return file_pb_policy_policy_proto_rawDescData
+}
+
+var file_pb_policy_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_pb_policy_policy_proto_goTypes = []interface{}{
+	(*AuthorizeReq)(nil),     // 0: AuthorizeReq
+	(*MatchedStatement)(nil), // 1: MatchedStatement
+	(*AuthorizeResp)(nil),    // 2: AuthorizeResp
+	nil,                      // 3: AuthorizeReq.ContextEntry
+}
+var file_pb_policy_policy_proto_depIdxs = []int32{
+	3, // 0: AuthorizeReq.Context:type_name -> AuthorizeReq.ContextEntry
+	1, // 1: AuthorizeResp.Statements:type_name -> MatchedStatement
+	0, // 2: PolicyService.Authorize:input_type -> AuthorizeReq
+	2, // 3: PolicyService.Authorize:output_type -> AuthorizeResp
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_pb_policy_policy_proto_init() }
+func file_pb_policy_policy_proto_init() {
+	if File_pb_policy_policy_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pb_policy_policy_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthorizeReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_policy_policy_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchedStatement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_policy_policy_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthorizeResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pb_policy_policy_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pb_policy_policy_proto_goTypes,
+		DependencyIndexes: file_pb_policy_policy_proto_depIdxs,
+		MessageInfos:      file_pb_policy_policy_proto_msgTypes,
+	}.Build()
+	File_pb_policy_policy_proto = out.File
+	file_pb_policy_policy_proto_rawDesc = nil
+	file_pb_policy_policy_proto_goTypes = nil
+	file_pb_policy_policy_proto_depIdxs = nil
+}
@@ -0,0 +1,103 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.23.2
+// source: pb/rbac/rbac.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RBACServiceClient is the client API for RBACService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RBACServiceClient interface {
+	CheckPermission(ctx context.Context, in *CheckPermissionReq, opts ...grpc.CallOption) (*CheckPermissionResp, error)
+}
+
+type rBACServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRBACServiceClient(cc grpc.ClientConnInterface) RBACServiceClient {
+	return &rBACServiceClient{cc}
+}
+
+func (c *rBACServiceClient) CheckPermission(ctx context.Context, in *CheckPermissionReq, opts ...grpc.CallOption) (*CheckPermissionResp, error) {
+	out := new(CheckPermissionResp)
+	err := c.cc.Invoke(ctx, "/RBACService/CheckPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACServiceServer is the server API for RBACService service.
+// All implementations should embed UnimplementedRBACServiceServer
+// for forward compatibility
+type RBACServiceServer interface {
+	CheckPermission(context.Context, *CheckPermissionReq) (*CheckPermissionResp, error)
+}
+
+// UnimplementedRBACServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedRBACServiceServer struct {
+}
+
+func (UnimplementedRBACServiceServer) CheckPermission(context.Context, *CheckPermissionReq) (*CheckPermissionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPermission not implemented")
+}
+
+// UnsafeRBACServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RBACServiceServer will
+// result in compilation errors.
+type UnsafeRBACServiceServer interface {
+	mustEmbedUnimplementedRBACServiceServer()
+}
+
+func RegisterRBACServiceServer(s grpc.ServiceRegistrar, srv RBACServiceServer) {
+	s.RegisterService(&RBACService_ServiceDesc, srv)
+}
+
+func _RBACService_CheckPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPermissionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RBACServiceServer).CheckPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/RBACService/CheckPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RBACServiceServer).CheckPermission(ctx, req.(*CheckPermissionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RBACService_ServiceDesc is the grpc.ServiceDesc for RBACService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RBACService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "RBACService",
+	HandlerType: (*RBACServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CheckPermission",
+			Handler:    _RBACService_CheckPermission_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pb/rbac/rbac.proto",
+}
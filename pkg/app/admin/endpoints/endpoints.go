@@ -0,0 +1,77 @@
+package endpoints
+
+import (
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/admin/service"
+	identityendpoints "github.com/karta0898098/iam/pkg/app/identity/endpoints"
+	identitysvc "github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+)
+
+// Endpoints define user management endpoints for administrator
+type Endpoints struct {
+	ListUsersEndpoint      endpoint.Endpoint
+	GetUserEndpoint        endpoint.Endpoint
+	SuspendUserEndpoint    endpoint.Endpoint
+	ReactivateUserEndpoint endpoint.Endpoint
+	DeleteUserEndpoint     endpoint.Endpoint
+}
+
+// New endpoints
+func New(
+	svc service.AdminService,
+	identitySvc identitysvc.IdentityService,
+	permissions authn.PermissionChecker,
+	limiter *ratelimit.Limiter,
+) (ep Endpoints) {
+	authenticator := identityendpoints.NewAuthenticator(identitySvc)
+
+	listUsersEndpoint := MakeListUsersEndpoint(svc)
+	listUsersEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListUsers"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListUsers"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(listUsersEndpoint)
+	ep.ListUsersEndpoint = listUsersEndpoint
+
+	getUserEndpoint := MakeGetUserEndpoint(svc)
+	getUserEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("GetUser"),
+		identityendpoints.RateLimitMiddleware(limiter, "GetUser"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(getUserEndpoint)
+	ep.GetUserEndpoint = getUserEndpoint
+
+	suspendUserEndpoint := MakeSuspendUserEndpoint(svc)
+	suspendUserEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("SuspendUser"),
+		identityendpoints.RateLimitMiddleware(limiter, "SuspendUser"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(suspendUserEndpoint)
+	ep.SuspendUserEndpoint = suspendUserEndpoint
+
+	reactivateUserEndpoint := MakeReactivateUserEndpoint(svc)
+	reactivateUserEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ReactivateUser"),
+		identityendpoints.RateLimitMiddleware(limiter, "ReactivateUser"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(reactivateUserEndpoint)
+	ep.ReactivateUserEndpoint = reactivateUserEndpoint
+
+	deleteUserEndpoint := MakeDeleteUserEndpoint(svc)
+	deleteUserEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("DeleteUser"),
+		identityendpoints.RateLimitMiddleware(limiter, "DeleteUser"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(deleteUserEndpoint)
+	ep.DeleteUserEndpoint = deleteUserEndpoint
+
+	return ep
+}
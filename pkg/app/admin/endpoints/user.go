@@ -0,0 +1,185 @@
+package endpoints
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/admin/service"
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+)
+
+// UserResponse define user seen by administrator
+type UserResponse struct {
+	ID        string `json:"id"`
+	TenantID  string `json:"tenant_id"`
+	Username  string `json:"username"`
+	Nickname  string `json:"nickname"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Avatar    string `json:"avatar"`
+	Status    string `json:"status"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// newUserResponse convert user to response
+func newUserResponse(user *entity.User) *UserResponse {
+	return &UserResponse{
+		ID:        user.ID,
+		TenantID:  user.TenantID,
+		Username:  user.Username,
+		Nickname:  user.Nickname,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Avatar:    user.Avatar,
+		Status:    user.Status.ToString(),
+		CreatedAt: user.CreatedAt.Unix(),
+		UpdatedAt: user.UpdatedAt.Unix(),
+	}
+}
+
+// ListUsersRequest define list users request,
+// created range is unix seconds and zero value means not filtered
+type ListUsersRequest struct {
+	Status         string
+	CreatedAfter   int64
+	CreatedBefore  int64
+	UsernamePrefix string
+	EmailPrefix    string
+	Page           int
+	PageSize       int
+}
+
+// ListUsersResponse define page of users response
+type ListUsersResponse struct {
+	Users []*UserResponse `json:"users"`
+	Total int64           `json:"total"`
+}
+
+// MakeListUsersEndpoint make list users endpoint
+func MakeListUsersEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ListUsersRequest)
+
+		opt := &service.ListUsersOption{
+			UsernamePrefix: req.UsernamePrefix,
+			EmailPrefix:    req.EmailPrefix,
+			Page:           req.Page,
+			PageSize:       req.PageSize,
+		}
+		if req.Status != "" {
+			status, err := entity.ParseUserAccountStatus(req.Status)
+			if err != nil {
+				return nil, err
+			}
+			opt.Status = &status
+		}
+		if req.CreatedAfter != 0 {
+			opt.CreatedAfter = time.Unix(req.CreatedAfter, 0)
+		}
+		if req.CreatedBefore != 0 {
+			opt.CreatedBefore = time.Unix(req.CreatedBefore, 0)
+		}
+
+		users, total, err := svc.ListUsers(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &ListUsersResponse{
+			Users: make([]*UserResponse, 0, len(users)),
+			Total: total,
+		}
+		for _, user := range users {
+			resp.Users = append(resp.Users, newUserResponse(user))
+		}
+		return resp, nil
+	}
+}
+
+// GetUserRequest define get user request
+type GetUserRequest struct {
+	UserID string `json:"-"`
+}
+
+// MakeGetUserEndpoint make get user endpoint
+func MakeGetUserEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*GetUserRequest)
+
+		user, err := svc.GetUser(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newUserResponse(user), nil
+	}
+}
+
+// SuspendUserRequest define suspend user request
+type SuspendUserRequest struct {
+	UserID string `json:"-"`
+}
+
+// MakeSuspendUserEndpoint make suspend user endpoint
+func MakeSuspendUserEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*SuspendUserRequest)
+
+		user, err := svc.SuspendUser(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newUserResponse(user), nil
+	}
+}
+
+// ReactivateUserRequest define reactivate user request
+type ReactivateUserRequest struct {
+	UserID string `json:"-"`
+}
+
+// MakeReactivateUserEndpoint make reactivate user endpoint
+func MakeReactivateUserEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ReactivateUserRequest)
+
+		user, err := svc.ReactivateUser(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newUserResponse(user), nil
+	}
+}
+
+// DeleteUserRequest define delete user request
+type DeleteUserRequest struct {
+	UserID string `json:"-"`
+	Hard   bool   `json:"-"`
+}
+
+// DeleteUserResponse define delete user response
+type DeleteUserResponse struct {
+}
+
+// MakeDeleteUserEndpoint make delete user endpoint
+func MakeDeleteUserEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DeleteUserRequest)
+
+		err = svc.DeleteUser(ctx, req.UserID, &service.DeleteUserOption{
+			Hard: req.Hard,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeleteUserResponse{}, nil
+	}
+}
@@ -0,0 +1,311 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/karta0898098/iam/pkg/app/admin/service"
+)
+
+// AdminService is an autogenerated mock type for the AdminService type
+type AdminService struct {
+	mock.Mock
+}
+
+type AdminService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminService) EXPECT() *AdminService_Expecter {
+	return &AdminService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteUser provides a mock function with given fields: ctx, userID, opt
+func (_m *AdminService) DeleteUser(ctx context.Context, userID string, opt *service.DeleteUserOption) error {
+	ret := _m.Called(ctx, userID, opt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.DeleteUserOption) error); ok {
+		r0 = rf(ctx, userID, opt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminService_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type AdminService_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - opt *service.DeleteUserOption
+func (_e *AdminService_Expecter) DeleteUser(ctx interface{}, userID interface{}, opt interface{}) *AdminService_DeleteUser_Call {
+	return &AdminService_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, userID, opt)}
+}
+
+func (_c *AdminService_DeleteUser_Call) Run(run func(ctx context.Context, userID string, opt *service.DeleteUserOption)) *AdminService_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*service.DeleteUserOption))
+	})
+	return _c
+}
+
+func (_c *AdminService_DeleteUser_Call) Return(err error) *AdminService_DeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *AdminService_DeleteUser_Call) RunAndReturn(run func(context.Context, string, *service.DeleteUserOption) error) *AdminService_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *AdminService) GetUser(ctx context.Context, userID string) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type AdminService_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *AdminService_Expecter) GetUser(ctx interface{}, userID interface{}) *AdminService_GetUser_Call {
+	return &AdminService_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+}
+
+func (_c *AdminService_GetUser_Call) Run(run func(ctx context.Context, userID string)) *AdminService_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AdminService_GetUser_Call) Return(user *entity.User, err error) *AdminService_GetUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *AdminService_GetUser_Call) RunAndReturn(run func(context.Context, string) (*entity.User, error)) *AdminService_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function with given fields: ctx, opt
+func (_m *AdminService) ListUsers(ctx context.Context, opt *service.ListUsersOption) ([]*entity.User, int64, error) {
+	ret := _m.Called(ctx, opt)
+
+	var r0 []*entity.User
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.ListUsersOption) ([]*entity.User, int64, error)); ok {
+		return rf(ctx, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.ListUsersOption) []*entity.User); ok {
+		r0 = rf(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.ListUsersOption) int64); ok {
+		r1 = rf(ctx, opt)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *service.ListUsersOption) error); ok {
+		r2 = rf(ctx, opt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AdminService_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type AdminService_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *service.ListUsersOption
+func (_e *AdminService_Expecter) ListUsers(ctx interface{}, opt interface{}) *AdminService_ListUsers_Call {
+	return &AdminService_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, opt)}
+}
+
+func (_c *AdminService_ListUsers_Call) Run(run func(ctx context.Context, opt *service.ListUsersOption)) *AdminService_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.ListUsersOption))
+	})
+	return _c
+}
+
+func (_c *AdminService_ListUsers_Call) Return(users []*entity.User, total int64, err error) *AdminService_ListUsers_Call {
+	_c.Call.Return(users, total, err)
+	return _c
+}
+
+func (_c *AdminService_ListUsers_Call) RunAndReturn(run func(context.Context, *service.ListUsersOption) ([]*entity.User, int64, error)) *AdminService_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReactivateUser provides a mock function with given fields: ctx, userID
+func (_m *AdminService) ReactivateUser(ctx context.Context, userID string) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_ReactivateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReactivateUser'
+type AdminService_ReactivateUser_Call struct {
+	*mock.Call
+}
+
+// ReactivateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *AdminService_Expecter) ReactivateUser(ctx interface{}, userID interface{}) *AdminService_ReactivateUser_Call {
+	return &AdminService_ReactivateUser_Call{Call: _e.mock.On("ReactivateUser", ctx, userID)}
+}
+
+func (_c *AdminService_ReactivateUser_Call) Run(run func(ctx context.Context, userID string)) *AdminService_ReactivateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AdminService_ReactivateUser_Call) Return(user *entity.User, err error) *AdminService_ReactivateUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *AdminService_ReactivateUser_Call) RunAndReturn(run func(context.Context, string) (*entity.User, error)) *AdminService_ReactivateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SuspendUser provides a mock function with given fields: ctx, userID
+func (_m *AdminService) SuspendUser(ctx context.Context, userID string) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_SuspendUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuspendUser'
+type AdminService_SuspendUser_Call struct {
+	*mock.Call
+}
+
+// SuspendUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *AdminService_Expecter) SuspendUser(ctx interface{}, userID interface{}) *AdminService_SuspendUser_Call {
+	return &AdminService_SuspendUser_Call{Call: _e.mock.On("SuspendUser", ctx, userID)}
+}
+
+func (_c *AdminService_SuspendUser_Call) Run(run func(ctx context.Context, userID string)) *AdminService_SuspendUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AdminService_SuspendUser_Call) Return(user *entity.User, err error) *AdminService_SuspendUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *AdminService_SuspendUser_Call) RunAndReturn(run func(context.Context, string) (*entity.User, error)) *AdminService_SuspendUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewAdminService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAdminService creates a new instance of AdminService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAdminService(t mockConstructorTestingTNewAdminService) *AdminService {
+	mock := &AdminService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,13 @@
+package admin
+
+import (
+	"github.com/google/wire"
+
+	"github.com/karta0898098/iam/pkg/app/admin/endpoints"
+	"github.com/karta0898098/iam/pkg/app/admin/service"
+)
+
+var DefaultProvider = wire.NewSet(
+	endpoints.New,
+	service.New,
+)
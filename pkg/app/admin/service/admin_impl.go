@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	identityrepo "github.com/karta0898098/iam/pkg/app/identity/repository"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+var _ AdminService = &Impl{}
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(AdminService) AdminService
+
+// AdminService define user management for administrator
+type AdminService interface {
+	// ListUsers list page of users match filters, total is count of users match filters
+	ListUsers(
+		ctx context.Context,
+		opt *ListUsersOption,
+	) (users []*entity.User, total int64, err error)
+
+	// GetUser get user
+	GetUser(
+		ctx context.Context,
+		userID string,
+	) (user *entity.User, err error)
+
+	// SuspendUser stop user from signing in and revoke sessions of user
+	SuspendUser(
+		ctx context.Context,
+		userID string,
+	) (user *entity.User, err error)
+
+	// ReactivateUser restore user suspended
+	ReactivateUser(
+		ctx context.Context,
+		userID string,
+	) (user *entity.User, err error)
+
+	// DeleteUser delete user and revoke sessions of user
+	DeleteUser(
+		ctx context.Context,
+		userID string,
+		opt *DeleteUserOption,
+	) (err error)
+}
+
+type Impl struct {
+	identityRepo identityrepo.Repository
+}
+
+func New(identityRepo identityrepo.Repository, recorder audit.Recorder) AdminService {
+	var svc AdminService
+	svc = &Impl{
+		identityRepo: identityRepo,
+	}
+	svc = LoggingMiddleware()(svc)
+	if recorder != nil {
+		svc = AuditMiddleware(recorder)(svc)
+	}
+
+	return svc
+}
+
+func (srv *Impl) ListUsers(ctx context.Context, opt *ListUsersOption) (users []*entity.User, total int64, err error) {
+	page := opt.Page
+	if page == 0 {
+		page = 1
+	}
+	pageSize := opt.PageSize
+	if pageSize == 0 {
+		pageSize = PageSizeDefault
+	}
+
+	if page < 0 || pageSize < 0 || pageSize > PageSizeMax {
+		return nil, 0, errors.Wrapf(errors.ErrInvalidInput, "page=%v page size=%v is out of range", page, pageSize)
+	}
+	if !opt.CreatedAfter.IsZero() && !opt.CreatedBefore.IsZero() && !opt.CreatedAfter.Before(opt.CreatedBefore) {
+		return nil, 0, errors.Wrapf(errors.ErrInvalidInput, "created after=%v is not before created before=%v", opt.CreatedAfter, opt.CreatedBefore)
+	}
+
+	return srv.identityRepo.ListUsers(ctx, &identityrepo.UserQuery{
+		Status:         opt.Status,
+		CreatedAfter:   opt.CreatedAfter,
+		CreatedBefore:  opt.CreatedBefore,
+		UsernamePrefix: opt.UsernamePrefix,
+		EmailPrefix:    opt.EmailPrefix,
+		Offset:         (page - 1) * pageSize,
+		Limit:          pageSize,
+	})
+}
+
+func (srv *Impl) GetUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	return srv.identityRepo.FindUserByID(ctx, userID)
+}
+
+func (srv *Impl) SuspendUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	user, err = srv.identityRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := user.Status
+	err = user.Suspend()
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.identityRepo.UpdateUserStatus(ctx, user, from)
+	if err != nil {
+		return nil, err
+	}
+
+	// issued tokens stop working once their sessions are revoked
+	err = srv.identityRepo.RevokeUserSessions(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (srv *Impl) ReactivateUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	user, err = srv.identityRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := user.Status
+	err = user.Reactivate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.identityRepo.UpdateUserStatus(ctx, user, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (srv *Impl) DeleteUser(ctx context.Context, userID string, opt *DeleteUserOption) (err error) {
+	if opt.Hard {
+		// sessions are deleted together with user
+		return srv.identityRepo.DeleteUser(ctx, userID)
+	}
+
+	user, err := srv.identityRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	from := user.Status
+	err = user.MarkDeleted()
+	if err != nil {
+		return err
+	}
+
+	err = srv.identityRepo.UpdateUserStatus(ctx, user, from)
+	if err != nil {
+		return err
+	}
+
+	return srv.identityRepo.RevokeUserSessions(ctx, user.ID)
+}
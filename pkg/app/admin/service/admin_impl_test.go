@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/karta0898098/iam/pkg/app/admin/service"
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	identitymocks "github.com/karta0898098/iam/pkg/app/identity/mocks"
+	identityrepo "github.com/karta0898098/iam/pkg/app/identity/repository"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+func newUser(status entity.UserAccountStatus) *entity.User {
+	return &entity.User{ID: "MOCK-USER-ID", Username: "Username", Status: status}
+}
+
+func TestImpl_SuspendUser(t *testing.T) {
+	tests := []struct {
+		name string
+		repo identityrepo.Repository
+		err  error
+	}{
+		{
+			name: "Suspend And Revoke Sessions",
+			repo: func() identityrepo.Repository {
+				repo := identitymocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(newUser(entity.UserAccountStatusActive), nil)
+				repo.EXPECT().
+					UpdateUserStatus(mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+						return user.IsSuspended()
+					}), entity.UserAccountStatus(entity.UserAccountStatusActive)).
+					Return(nil)
+				repo.EXPECT().
+					RevokeUserSessions(mock.Anything, "MOCK-USER-ID").
+					Return(nil)
+				return repo
+			}(),
+		},
+		{
+			name: "Already Suspended",
+			repo: func() identityrepo.Repository {
+				repo := identitymocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(newUser(entity.UserAccountStatusSuspend), nil)
+				return repo
+			}(),
+			err: errors.ErrConflict,
+		},
+		{
+			name: "Status Changed By Others",
+			repo: func() identityrepo.Repository {
+				repo := identitymocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(newUser(entity.UserAccountStatusActive), nil)
+				repo.EXPECT().
+					UpdateUserStatus(mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.ErrConflict)
+				return repo
+			}(),
+			err: errors.ErrConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := service.New(tt.repo, nil)
+			user, err := srv.SuspendUser(context.Background(), "MOCK-USER-ID")
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.True(t, user.IsSuspended())
+			}
+		})
+	}
+}
+
+func TestImpl_DeleteUser(t *testing.T) {
+	tests := []struct {
+		name string
+		repo identityrepo.Repository
+		opt  *service.DeleteUserOption
+	}{
+		{
+			name: "Soft Delete",
+			repo: func() identityrepo.Repository {
+				repo := identitymocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(newUser(entity.UserAccountStatusSuspend), nil)
+				repo.EXPECT().
+					UpdateUserStatus(mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+						return user.IsDeleted()
+					}), entity.UserAccountStatus(entity.UserAccountStatusSuspend)).
+					Return(nil)
+				repo.EXPECT().
+					RevokeUserSessions(mock.Anything, "MOCK-USER-ID").
+					Return(nil)
+				return repo
+			}(),
+			opt: &service.DeleteUserOption{},
+		},
+		{
+			name: "Hard Delete",
+			repo: func() identityrepo.Repository {
+				repo := identitymocks.NewRepository(t)
+				repo.EXPECT().
+					DeleteUser(mock.Anything, "MOCK-USER-ID").
+					Return(nil)
+				return repo
+			}(),
+			opt: &service.DeleteUserOption{Hard: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := service.New(tt.repo, nil)
+			err := srv.DeleteUser(context.Background(), "MOCK-USER-ID", tt.opt)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestImpl_ListUsers(t *testing.T) {
+	repo := identitymocks.NewRepository(t)
+	repo.EXPECT().
+		ListUsers(mock.Anything, &identityrepo.UserQuery{
+			UsernamePrefix: "user",
+			Offset:         service.PageSizeDefault,
+			Limit:          service.PageSizeDefault,
+		}).
+		Return([]*entity.User{newUser(entity.UserAccountStatusActive)}, int64(21), nil)
+
+	srv := service.New(repo, nil)
+	users, total, err := srv.ListUsers(context.Background(), &service.ListUsersOption{UsernamePrefix: "user", Page: 2})
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, int64(21), total)
+
+	_, _, err = srv.ListUsers(context.Background(), &service.ListUsersOption{PageSize: service.PageSizeMax + 1})
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+}
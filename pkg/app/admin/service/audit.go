@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/audit"
+)
+
+type auditMiddleware struct {
+	next     AdminService
+	recorder audit.Recorder
+}
+
+// AuditMiddleware record action changing user to audit log, read only action is not recorded
+func AuditMiddleware(recorder audit.Recorder) Middleware {
+	return func(next AdminService) AdminService {
+		return auditMiddleware{next: next, recorder: recorder}
+	}
+}
+
+func (am auditMiddleware) ListUsers(ctx context.Context, opt *ListUsersOption) (users []*entity.User, total int64, err error) {
+	return am.next.ListUsers(ctx, opt)
+}
+
+func (am auditMiddleware) GetUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	return am.next.GetUser(ctx, userID)
+}
+
+func (am auditMiddleware) SuspendUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionSuspendUser, userID, err))
+	}()
+
+	return am.next.SuspendUser(ctx, userID)
+}
+
+func (am auditMiddleware) ReactivateUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionReactivateUser, userID, err))
+	}()
+
+	return am.next.ReactivateUser(ctx, userID)
+}
+
+func (am auditMiddleware) DeleteUser(ctx context.Context, userID string, opt *DeleteUserOption) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionDeleteUser, userID, err))
+	}()
+
+	return am.next.DeleteUser(ctx, userID, opt)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+)
+
+type loggingMiddleware struct {
+	next AdminService `json:"-"`
+}
+
+// LoggingMiddleware takes a logger as a dependency
+// and returns a ServiceMiddleware.
+func LoggingMiddleware() Middleware {
+	return func(next AdminService) AdminService {
+		return loggingMiddleware{next}
+	}
+}
+
+func (lm loggingMiddleware) ListUsers(ctx context.Context, opt *ListUsersOption) (users []*entity.User, total int64, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListUsers",
+		// 	"page", opt.Page,
+		// 	"total", total,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListUsers(ctx, opt)
+}
+
+func (lm loggingMiddleware) GetUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "GetUser",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.GetUser(ctx, userID)
+}
+
+func (lm loggingMiddleware) SuspendUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "SuspendUser",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.SuspendUser(ctx, userID)
+}
+
+func (lm loggingMiddleware) ReactivateUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ReactivateUser",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ReactivateUser(ctx, userID)
+}
+
+func (lm loggingMiddleware) DeleteUser(ctx context.Context, userID string, opt *DeleteUserOption) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "DeleteUser",
+		// 	"user_id", userID,
+		// 	"hard", opt.Hard,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.DeleteUser(ctx, userID, opt)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"time"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+)
+
+const (
+	// PageSizeDefault page size used when it is not given
+	PageSizeDefault = 20
+	// PageSizeMax max users of a page
+	PageSizeMax = 100
+)
+
+// ListUsersOption define filters and page of listing users
+type ListUsersOption struct {
+	// Status filter users by status, deleted users are excluded when it is nil
+	Status *entity.UserAccountStatus
+	// CreatedAfter include users created at or after it
+	CreatedAfter time.Time
+	// CreatedBefore include users created before it
+	CreatedBefore time.Time
+	// UsernamePrefix filter users by username prefix
+	UsernamePrefix string
+	// EmailPrefix filter users by email prefix
+	EmailPrefix string
+	// Page start from 1
+	Page int
+	// PageSize max users of page
+	PageSize int
+}
+
+// DeleteUserOption define how user is deleted
+type DeleteUserOption struct {
+	// Hard delete user and data of the user,
+	// otherwise user is only marked deleted and username is kept
+	Hard bool
+}
@@ -0,0 +1,215 @@
+package grpc
+
+import (
+	"context"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+
+	pb "github.com/karta0898098/iam/pb/admin"
+	"github.com/karta0898098/iam/pkg/app/admin/endpoints"
+	"github.com/karta0898098/iam/pkg/authn"
+)
+
+type grpcServer struct {
+	listUsers      grpctransport.Handler
+	getUser        grpctransport.Handler
+	suspendUser    grpctransport.Handler
+	reactivateUser grpctransport.Handler
+	deleteUser     grpctransport.Handler
+}
+
+func (g *grpcServer) ListUsers(ctx context.Context, req *pb.ListUsersReq) (*pb.ListUsersResp, error) {
+	_, rp, err := g.listUsers.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.ListUsersResp)
+	return reply, nil
+}
+
+func (g *grpcServer) GetUser(ctx context.Context, req *pb.GetUserReq) (*pb.UserResp, error) {
+	_, rp, err := g.getUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.UserResp)
+	return reply, nil
+}
+
+func (g *grpcServer) SuspendUser(ctx context.Context, req *pb.SuspendUserReq) (*pb.UserResp, error) {
+	_, rp, err := g.suspendUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.UserResp)
+	return reply, nil
+}
+
+func (g *grpcServer) ReactivateUser(ctx context.Context, req *pb.ReactivateUserReq) (*pb.UserResp, error) {
+	_, rp, err := g.reactivateUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.UserResp)
+	return reply, nil
+}
+
+func (g *grpcServer) DeleteUser(ctx context.Context, req *pb.DeleteUserReq) (*pb.DeleteUserResp, error) {
+	_, rp, err := g.deleteUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.DeleteUserResp)
+	return reply, nil
+}
+
+// MakeGRPCServer make admin grpc server
+func MakeGRPCServer(endpoints endpoints.Endpoints) (req pb.AdminServiceServer) {
+	options := []grpctransport.ServerOption{
+		// bearer token of authorization metadata is authenticated by endpoint middleware
+		grpctransport.ServerBefore(authn.GRPCToContext()),
+	}
+
+	return &grpcServer{
+		listUsers: grpctransport.NewServer(
+			endpoints.ListUsersEndpoint,
+			decodeGRPCListUsersRequest,
+			encodeGRPCListUsersResponse,
+			options...,
+		),
+		getUser: grpctransport.NewServer(
+			endpoints.GetUserEndpoint,
+			decodeGRPCGetUserRequest,
+			encodeGRPCUserResponse,
+			options...,
+		),
+		suspendUser: grpctransport.NewServer(
+			endpoints.SuspendUserEndpoint,
+			decodeGRPCSuspendUserRequest,
+			encodeGRPCUserResponse,
+			options...,
+		),
+		reactivateUser: grpctransport.NewServer(
+			endpoints.ReactivateUserEndpoint,
+			decodeGRPCReactivateUserRequest,
+			encodeGRPCUserResponse,
+			options...,
+		),
+		deleteUser: grpctransport.NewServer(
+			endpoints.DeleteUserEndpoint,
+			decodeGRPCDeleteUserRequest,
+			encodeGRPCDeleteUserResponse,
+			options...,
+		),
+	}
+}
+
+// decodeGRPCListUsersRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCListUsersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListUsersReq)
+
+	return &endpoints.ListUsersRequest{
+		Status:         req.Status,
+		CreatedAfter:   req.CreatedAfter,
+		CreatedBefore:  req.CreatedBefore,
+		UsernamePrefix: req.UsernamePrefix,
+		EmailPrefix:    req.EmailPrefix,
+		Page:           int(req.Page),
+		PageSize:       int(req.PageSize),
+	}, nil
+}
+
+// encodeGRPCListUsersResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCListUsersResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.ListUsersResponse)
+
+	users := make([]*pb.User, 0, len(reply.Users))
+	for _, user := range reply.Users {
+		users = append(users, newUser(user))
+	}
+
+	return &pb.ListUsersResp{
+		Users: users,
+		Total: reply.Total,
+	}, nil
+}
+
+// decodeGRPCGetUserRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCGetUserRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GetUserReq)
+
+	return &endpoints.GetUserRequest{
+		UserID: req.UserID,
+	}, nil
+}
+
+// decodeGRPCSuspendUserRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCSuspendUserRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SuspendUserReq)
+
+	return &endpoints.SuspendUserRequest{
+		UserID: req.UserID,
+	}, nil
+}
+
+// decodeGRPCReactivateUserRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCReactivateUserRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ReactivateUserReq)
+
+	return &endpoints.ReactivateUserRequest{
+		UserID: req.UserID,
+	}, nil
+}
+
+// encodeGRPCUserResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCUserResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.UserResponse)
+	return &pb.UserResp{
+		User: newUser(reply),
+	}, nil
+}
+
+// decodeGRPCDeleteUserRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCDeleteUserRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.DeleteUserReq)
+
+	return &endpoints.DeleteUserRequest{
+		UserID: req.UserID,
+		Hard:   req.Hard,
+	}, nil
+}
+
+// encodeGRPCDeleteUserResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCDeleteUserResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	return &pb.DeleteUserResp{}, nil
+}
+
+// newUser convert user response to gRPC user
+func newUser(user *endpoints.UserResponse) *pb.User {
+	return &pb.User{
+		ID:        user.ID,
+		TenantID:  user.TenantID,
+		Username:  user.Username,
+		Nickname:  user.Nickname,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Avatar:    user.Avatar,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
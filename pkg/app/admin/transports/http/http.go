@@ -0,0 +1,189 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/app/admin/endpoints"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	pkghttp "github.com/karta0898098/iam/pkg/http"
+)
+
+// MakeListUsers make list users endpoint
+func MakeListUsers(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListUsersEndpoint,
+		decodeHTTPListUsersRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListUsersRequest is a transport/http.DecodeRequestFunc that decodes
+// filters and page from the URL query. Primarily useful in a server.
+func decodeHTTPListUsersRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	values := r.URL.Query()
+
+	createdAfter, err := queryInt(values, "created_after")
+	if err != nil {
+		return nil, err
+	}
+	createdBefore, err := queryInt(values, "created_before")
+	if err != nil {
+		return nil, err
+	}
+	page, err := queryInt(values, "page")
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := queryInt(values, "page_size")
+	if err != nil {
+		return nil, err
+	}
+
+	return &endpoints.ListUsersRequest{
+		Status:         values.Get("status"),
+		CreatedAfter:   createdAfter,
+		CreatedBefore:  createdBefore,
+		UsernamePrefix: values.Get("username_prefix"),
+		EmailPrefix:    values.Get("email_prefix"),
+		Page:           int(page),
+		PageSize:       int(pageSize),
+	}, nil
+}
+
+// queryInt read integer query parameter, missing parameter is zero
+func queryInt(values url.Values, name string) (int64, error) {
+	value := values.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(errors.ErrInvalidInput, "query %v=%v is not integer", name, value)
+	}
+	return i, nil
+}
+
+// MakeGetUser make get user endpoint
+func MakeGetUser(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.GetUserEndpoint,
+		decodeHTTPGetUserRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPGetUserRequest is a transport/http.DecodeRequestFunc that decodes
+// user id from the URL path. Primarily useful in a server.
+func decodeHTTPGetUserRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.GetUserRequest{
+		UserID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeSuspendUser make suspend user endpoint
+func MakeSuspendUser(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.SuspendUserEndpoint,
+		decodeHTTPSuspendUserRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPSuspendUserRequest is a transport/http.DecodeRequestFunc that decodes
+// user id from the URL path. Primarily useful in a server.
+func decodeHTTPSuspendUserRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.SuspendUserRequest{
+		UserID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeReactivateUser make reactivate user endpoint
+func MakeReactivateUser(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ReactivateUserEndpoint,
+		decodeHTTPReactivateUserRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPReactivateUserRequest is a transport/http.DecodeRequestFunc that decodes
+// user id from the URL path. Primarily useful in a server.
+func decodeHTTPReactivateUserRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ReactivateUserRequest{
+		UserID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeDeleteUser make delete user endpoint
+func MakeDeleteUser(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DeleteUserEndpoint,
+		decodeHTTPDeleteUserRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPDeleteUserRequest is a transport/http.DecodeRequestFunc that decodes
+// user id from the URL path and hard flag from the URL query. Primarily useful in a server.
+func decodeHTTPDeleteUserRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := &endpoints.DeleteUserRequest{
+		UserID: pkghttp.PathParam(r, "id"),
+	}
+
+	if value := r.URL.Query().Get("hard"); value != "" {
+		hard, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.Wrapf(errors.ErrInvalidInput, "query hard=%v is not boolean", value)
+		}
+		req.Hard = hard
+	}
+
+	return req, nil
+}
+
+// encodeHTTPResponse is a transport/http.EncodeResponseFunc that encodes
+// response as JSON into the HTTP response body. Primarily useful in a server.
+func encodeHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	if headers, ok := response.(httptransport.Headerer); ok {
+		for k, values := range headers.Headers() {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+	code := http.StatusOK
+	if sc, ok := response.(httptransport.StatusCoder); ok {
+		code = sc.StatusCode()
+	}
+	w.WriteHeader(code)
+	if code == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
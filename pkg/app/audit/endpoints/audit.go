@@ -0,0 +1,102 @@
+package endpoints
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/audit/service"
+	"github.com/karta0898098/iam/pkg/audit"
+)
+
+// EventResponse define audit event, hash chain the event after previous event
+type EventResponse struct {
+	Seq       int64  `json:"seq"`
+	TenantID  string `json:"tenant_id"`
+	Actor     string `json:"actor"`
+	Action    string `json:"action"`
+	Target    string `json:"target"`
+	IPAddress string `json:"ip_address"`
+	Platform  string `json:"platform"`
+	Device    string `json:"device"`
+	Outcome   string `json:"outcome"`
+	ErrorCode int    `json:"error_code"`
+	CreatedAt int64  `json:"created_at"`
+	PrevHash  string `json:"prev_hash"`
+	Hash      string `json:"hash"`
+}
+
+// newEventResponse convert event to response
+func newEventResponse(event *audit.Event) *EventResponse {
+	return &EventResponse{
+		Seq:       event.Seq,
+		TenantID:  event.TenantID,
+		Actor:     event.Actor,
+		Action:    string(event.Action),
+		Target:    event.Target,
+		IPAddress: event.IPAddress,
+		Platform:  event.Platform,
+		Device:    event.Device,
+		Outcome:   string(event.Outcome),
+		ErrorCode: event.ErrorCode,
+		CreatedAt: event.CreatedAt.Unix(),
+		PrevHash:  event.PrevHash,
+		Hash:      event.Hash,
+	}
+}
+
+// QueryAuditLogRequest define query audit log request,
+// created range is unix seconds and zero value means not filtered
+type QueryAuditLogRequest struct {
+	Actor         string
+	Action        string
+	Target        string
+	Outcome       string
+	CreatedAfter  int64
+	CreatedBefore int64
+	Page          int
+	PageSize      int
+}
+
+// QueryAuditLogResponse define page of events response
+type QueryAuditLogResponse struct {
+	Events []*EventResponse `json:"events"`
+	Total  int64            `json:"total"`
+}
+
+// MakeQueryAuditLogEndpoint make query audit log endpoint
+func MakeQueryAuditLogEndpoint(svc service.AuditService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*QueryAuditLogRequest)
+
+		opt := &service.QueryOption{
+			Actor:    req.Actor,
+			Action:   audit.Action(req.Action),
+			Target:   req.Target,
+			Outcome:  audit.Outcome(req.Outcome),
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		}
+		if req.CreatedAfter != 0 {
+			opt.CreatedAfter = time.Unix(req.CreatedAfter, 0)
+		}
+		if req.CreatedBefore != 0 {
+			opt.CreatedBefore = time.Unix(req.CreatedBefore, 0)
+		}
+
+		events, total, err := svc.QueryAuditLog(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &QueryAuditLogResponse{
+			Events: make([]*EventResponse, 0, len(events)),
+			Total:  total,
+		}
+		for _, event := range events {
+			resp.Events = append(resp.Events, newEventResponse(event))
+		}
+		return resp, nil
+	}
+}
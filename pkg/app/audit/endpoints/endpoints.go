@@ -0,0 +1,37 @@
+package endpoints
+
+import (
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/audit/service"
+	identityendpoints "github.com/karta0898098/iam/pkg/app/identity/endpoints"
+	identitysvc "github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+)
+
+// Endpoints define audit log endpoints for administrator
+type Endpoints struct {
+	QueryAuditLogEndpoint endpoint.Endpoint
+}
+
+// New endpoints
+func New(
+	svc service.AuditService,
+	identitySvc identitysvc.IdentityService,
+	permissions authn.PermissionChecker,
+	limiter *ratelimit.Limiter,
+) (ep Endpoints) {
+	authenticator := identityendpoints.NewAuthenticator(identitySvc)
+
+	queryAuditLogEndpoint := MakeQueryAuditLogEndpoint(svc)
+	queryAuditLogEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("QueryAuditLog"),
+		identityendpoints.RateLimitMiddleware(limiter, "QueryAuditLog"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(queryAuditLogEndpoint)
+	ep.QueryAuditLogEndpoint = queryAuditLogEndpoint
+
+	return ep
+}
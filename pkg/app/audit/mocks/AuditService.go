@@ -0,0 +1,146 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	audit "github.com/karta0898098/iam/pkg/audit"
+
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/karta0898098/iam/pkg/app/audit/service"
+)
+
+// AuditService is an autogenerated mock type for the AuditService type
+type AuditService struct {
+	mock.Mock
+}
+
+type AuditService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditService) EXPECT() *AuditService_Expecter {
+	return &AuditService_Expecter{mock: &_m.Mock}
+}
+
+// QueryAuditLog provides a mock function with given fields: ctx, opt
+func (_m *AuditService) QueryAuditLog(ctx context.Context, opt *service.QueryOption) ([]*audit.Event, int64, error) {
+	ret := _m.Called(ctx, opt)
+
+	var r0 []*audit.Event
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.QueryOption) ([]*audit.Event, int64, error)); ok {
+		return rf(ctx, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.QueryOption) []*audit.Event); ok {
+		r0 = rf(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*audit.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.QueryOption) int64); ok {
+		r1 = rf(ctx, opt)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *service.QueryOption) error); ok {
+		r2 = rf(ctx, opt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AuditService_QueryAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryAuditLog'
+type AuditService_QueryAuditLog_Call struct {
+	*mock.Call
+}
+
+// QueryAuditLog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *service.QueryOption
+func (_e *AuditService_Expecter) QueryAuditLog(ctx interface{}, opt interface{}) *AuditService_QueryAuditLog_Call {
+	return &AuditService_QueryAuditLog_Call{Call: _e.mock.On("QueryAuditLog", ctx, opt)}
+}
+
+func (_c *AuditService_QueryAuditLog_Call) Run(run func(ctx context.Context, opt *service.QueryOption)) *AuditService_QueryAuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.QueryOption))
+	})
+	return _c
+}
+
+func (_c *AuditService_QueryAuditLog_Call) Return(events []*audit.Event, total int64, err error) *AuditService_QueryAuditLog_Call {
+	_c.Call.Return(events, total, err)
+	return _c
+}
+
+func (_c *AuditService_QueryAuditLog_Call) RunAndReturn(run func(context.Context, *service.QueryOption) ([]*audit.Event, int64, error)) *AuditService_QueryAuditLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function with given fields: ctx, event
+func (_m *AuditService) Record(ctx context.Context, event *audit.Event) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *audit.Event) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditService_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type AuditService_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *audit.Event
+func (_e *AuditService_Expecter) Record(ctx interface{}, event interface{}) *AuditService_Record_Call {
+	return &AuditService_Record_Call{Call: _e.mock.On("Record", ctx, event)}
+}
+
+func (_c *AuditService_Record_Call) Run(run func(ctx context.Context, event *audit.Event)) *AuditService_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*audit.Event))
+	})
+	return _c
+}
+
+func (_c *AuditService_Record_Call) Return(err error) *AuditService_Record_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *AuditService_Record_Call) RunAndReturn(run func(context.Context, *audit.Event) error) *AuditService_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewAuditService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAuditService creates a new instance of AuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAuditService(t mockConstructorTestingTNewAuditService) *AuditService {
+	mock := &AuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,146 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	audit "github.com/karta0898098/iam/pkg/audit"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/karta0898098/iam/pkg/app/audit/repository"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// AppendEvent provides a mock function with given fields: ctx, event
+func (_m *Repository) AppendEvent(ctx context.Context, event *audit.Event) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *audit.Event) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_AppendEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AppendEvent'
+type Repository_AppendEvent_Call struct {
+	*mock.Call
+}
+
+// AppendEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *audit.Event
+func (_e *Repository_Expecter) AppendEvent(ctx interface{}, event interface{}) *Repository_AppendEvent_Call {
+	return &Repository_AppendEvent_Call{Call: _e.mock.On("AppendEvent", ctx, event)}
+}
+
+func (_c *Repository_AppendEvent_Call) Run(run func(ctx context.Context, event *audit.Event)) *Repository_AppendEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*audit.Event))
+	})
+	return _c
+}
+
+func (_c *Repository_AppendEvent_Call) Return(err error) *Repository_AppendEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_AppendEvent_Call) RunAndReturn(run func(context.Context, *audit.Event) error) *Repository_AppendEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEvents provides a mock function with given fields: ctx, query
+func (_m *Repository) ListEvents(ctx context.Context, query *repository.EventQuery) ([]*audit.Event, int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*audit.Event
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.EventQuery) ([]*audit.Event, int64, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.EventQuery) []*audit.Event); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*audit.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.EventQuery) int64); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *repository.EventQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Repository_ListEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEvents'
+type Repository_ListEvents_Call struct {
+	*mock.Call
+}
+
+// ListEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *repository.EventQuery
+func (_e *Repository_Expecter) ListEvents(ctx interface{}, query interface{}) *Repository_ListEvents_Call {
+	return &Repository_ListEvents_Call{Call: _e.mock.On("ListEvents", ctx, query)}
+}
+
+func (_c *Repository_ListEvents_Call) Run(run func(ctx context.Context, query *repository.EventQuery)) *Repository_ListEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*repository.EventQuery))
+	})
+	return _c
+}
+
+func (_c *Repository_ListEvents_Call) Return(events []*audit.Event, total int64, err error) *Repository_ListEvents_Call {
+	_c.Call.Return(events, total, err)
+	return _c
+}
+
+func (_c *Repository_ListEvents_Call) RunAndReturn(run func(context.Context, *repository.EventQuery) ([]*audit.Event, int64, error)) *Repository_ListEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,16 @@
+package audit
+
+import (
+	"github.com/google/wire"
+
+	"github.com/karta0898098/iam/pkg/app/audit/endpoints"
+	"github.com/karta0898098/iam/pkg/app/audit/repository"
+	"github.com/karta0898098/iam/pkg/app/audit/service"
+)
+
+var DefaultProvider = wire.NewSet(
+	endpoints.New,
+	service.New,
+	service.NewRecorder,
+	repository.New,
+)
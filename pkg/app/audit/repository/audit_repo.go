@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/tenant"
+)
+
+// chainHeadID id of the single row holding head of audit log chain
+const chainHeadID = 1
+
+// AuditEventDAO define audit event dao
+type AuditEventDAO struct {
+	Seq       int64  `gorm:"column:seq"`
+	TenantID  string `gorm:"column:tenant_id"`
+	Actor     string `gorm:"column:actor"`
+	Action    string `gorm:"column:action"`
+	Target    string `gorm:"column:target"`
+	IPAddress string `gorm:"column:ip_address"`
+	Platform  string `gorm:"column:platform"`
+	Device    string `gorm:"column:device"`
+	Outcome   string `gorm:"column:outcome"`
+	ErrorCode int    `gorm:"column:error_code"`
+	CreatedAt int64  `gorm:"column:created_at"`
+	PrevHash  string `gorm:"column:prev_hash"`
+	Hash      string `gorm:"column:hash"`
+}
+
+// TableName is AuditEventDAO implement table name for gorm
+func (e AuditEventDAO) TableName() string {
+	return "audit_events"
+}
+
+// AuditChainDAO define head of audit log chain, appending event lock the row
+// so concurrent appends are chained one after another
+type AuditChainDAO struct {
+	ID   int64  `gorm:"column:id"`
+	Seq  int64  `gorm:"column:seq"`
+	Hash string `gorm:"column:hash"`
+}
+
+// TableName is AuditChainDAO implement table name for gorm
+func (c AuditChainDAO) TableName() string {
+	return "audit_chain"
+}
+
+// UnmarshalAuditEventDAO unmarshal audit event to dao
+func UnmarshalAuditEventDAO(event *audit.Event) *AuditEventDAO {
+	return &AuditEventDAO{
+		Seq:       event.Seq,
+		TenantID:  event.TenantID,
+		Actor:     event.Actor,
+		Action:    string(event.Action),
+		Target:    event.Target,
+		IPAddress: event.IPAddress,
+		Platform:  event.Platform,
+		Device:    event.Device,
+		Outcome:   string(event.Outcome),
+		ErrorCode: event.ErrorCode,
+		CreatedAt: event.CreatedAt.UnixMilli(),
+		PrevHash:  event.PrevHash,
+		Hash:      event.Hash,
+	}
+}
+
+// UnmarshalAuditEvent unmarshal dao to audit event
+func UnmarshalAuditEvent(dao *AuditEventDAO) *audit.Event {
+	return &audit.Event{
+		Seq:       dao.Seq,
+		TenantID:  dao.TenantID,
+		Actor:     dao.Actor,
+		Action:    audit.Action(dao.Action),
+		Target:    dao.Target,
+		IPAddress: dao.IPAddress,
+		Platform:  dao.Platform,
+		Device:    dao.Device,
+		Outcome:   audit.Outcome(dao.Outcome),
+		ErrorCode: dao.ErrorCode,
+		CreatedAt: time.UnixMilli(dao.CreatedAt),
+		PrevHash:  dao.PrevHash,
+		Hash:      dao.Hash,
+	}
+}
+
+// EventQuery define filters and page of listing events
+type EventQuery struct {
+	Actor         string
+	Action        audit.Action
+	Target        string
+	Outcome       audit.Outcome
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Offset        int
+	Limit         int
+}
+
+// Repository define audit repository pattern, audit log is append-only
+// so there is no method updating or deleting event
+type Repository interface {
+	// AppendEvent chain event after the latest event of audit log and store it,
+	// chain is shared by every tenant
+	AppendEvent(ctx context.Context, event *audit.Event) (err error)
+
+	// ListEvents list page of events match query in tenant of context, newest first,
+	// total is count of events match query
+	ListEvents(ctx context.Context, query *EventQuery) (events []*audit.Event, total int64, err error)
+}
+
+// AuditRepository implement for Repository
+type AuditRepository struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+}
+
+// New Repository constructor
+func New(conn db.Connection) Repository {
+	return &AuditRepository{
+		readDB:  conn.ReadDB(),
+		writeDB: conn.WriteDB(),
+	}
+}
+
+// AppendEvent is SQL implement, head of chain is locked until event is stored
+// so concurrent appends wait for each other instead of being dropped
+func (repo *AuditRepository) AppendEvent(ctx context.Context, event *audit.Event) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			var (
+				head AuditChainDAO
+				prev *audit.Event
+			)
+
+			err := tx.
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ?", chainHeadID).
+				Take(&head).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to lock audit chain, err %v", err)
+			}
+			if head.Seq > 0 {
+				prev = &audit.Event{Seq: head.Seq, Hash: head.Hash}
+			}
+
+			event.Chain(prev)
+
+			err = tx.Create(UnmarshalAuditEventDAO(event)).Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to append audit event action=%v, err %v", event.Action, err)
+			}
+
+			err = tx.
+				Model(&AuditChainDAO{}).
+				Where("id = ?", chainHeadID).
+				Updates(map[string]interface{}{
+					"seq":  event.Seq,
+					"hash": event.Hash,
+				}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to move audit chain to seq=%v, err %v", event.Seq, err)
+			}
+
+			return nil
+		})
+}
+
+// ListEvents is SQL implement
+func (repo *AuditRepository) ListEvents(ctx context.Context, query *EventQuery) (events []*audit.Event, total int64, err error) {
+	var (
+		daos []AuditEventDAO
+	)
+
+	tx := repo.readDB.
+		WithContext(ctx).
+		Model(AuditEventDAO{}).
+		Scopes(tenant.Scope(ctx, "tenant_id"))
+
+	if query.Actor != "" {
+		tx = tx.Where("actor = ?", query.Actor)
+	}
+	if query.Action != "" {
+		tx = tx.Where("action = ?", query.Action)
+	}
+	if query.Target != "" {
+		tx = tx.Where("target = ?", query.Target)
+	}
+	if query.Outcome != "" {
+		tx = tx.Where("outcome = ?", query.Outcome)
+	}
+	if !query.CreatedAfter.IsZero() {
+		tx = tx.Where("created_at >= ?", query.CreatedAfter.UnixMilli())
+	}
+	if !query.CreatedBefore.IsZero() {
+		tx = tx.Where("created_at < ?", query.CreatedBefore.UnixMilli())
+	}
+
+	// count and page share the same filters
+	tx = tx.Session(&gorm.Session{})
+
+	err = tx.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	err = tx.
+		Order("seq DESC").
+		Offset(query.Offset).
+		Limit(query.Limit).
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	events = make([]*audit.Event, 0, len(daos))
+	for i := range daos {
+		events = append(events, UnmarshalAuditEvent(&daos[i]))
+	}
+
+	return events, total, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/audit/repository"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+var _ AuditService = &Impl{}
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(AuditService) AuditService
+
+// AuditService define recording and querying audit log
+type AuditService interface {
+	// Record append event to audit log, AuditService is audit.Recorder of other services
+	Record(
+		ctx context.Context,
+		event *audit.Event,
+	) (err error)
+
+	// QueryAuditLog list page of events match filters, newest first,
+	// total is count of events match filters
+	QueryAuditLog(
+		ctx context.Context,
+		opt *QueryOption,
+	) (events []*audit.Event, total int64, err error)
+}
+
+type Impl struct {
+	repo repository.Repository
+}
+
+func New(repo repository.Repository) AuditService {
+	var svc AuditService
+	svc = &Impl{
+		repo: repo,
+	}
+	svc = LoggingMiddleware()(svc)
+
+	return svc
+}
+
+// NewRecorder audit service as recorder of audited services
+func NewRecorder(svc AuditService) audit.Recorder {
+	return svc
+}
+
+func (srv *Impl) Record(ctx context.Context, event *audit.Event) (err error) {
+	return srv.repo.AppendEvent(ctx, event)
+}
+
+func (srv *Impl) QueryAuditLog(ctx context.Context, opt *QueryOption) (events []*audit.Event, total int64, err error) {
+	page := opt.Page
+	if page == 0 {
+		page = 1
+	}
+	pageSize := opt.PageSize
+	if pageSize == 0 {
+		pageSize = PageSizeDefault
+	}
+
+	if page < 0 || pageSize < 0 || pageSize > PageSizeMax {
+		return nil, 0, errors.Wrapf(errors.ErrInvalidInput, "page=%v page size=%v is out of range", page, pageSize)
+	}
+	if !opt.CreatedAfter.IsZero() && !opt.CreatedBefore.IsZero() && !opt.CreatedAfter.Before(opt.CreatedBefore) {
+		return nil, 0, errors.Wrapf(errors.ErrInvalidInput, "created after=%v is not before created before=%v", opt.CreatedAfter, opt.CreatedBefore)
+	}
+	switch opt.Outcome {
+	case "", audit.OutcomeSuccess, audit.OutcomeFailure, audit.OutcomeChallenged:
+	default:
+		return nil, 0, errors.Wrapf(errors.ErrInvalidInput, "outcome=%v is not valid", opt.Outcome)
+	}
+
+	return srv.repo.ListEvents(ctx, &repository.EventQuery{
+		Actor:         opt.Actor,
+		Action:        opt.Action,
+		Target:        opt.Target,
+		Outcome:       opt.Outcome,
+		CreatedAfter:  opt.CreatedAfter,
+		CreatedBefore: opt.CreatedBefore,
+		Offset:        (page - 1) * pageSize,
+		Limit:         pageSize,
+	})
+}
@@ -0,0 +1,82 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/karta0898098/iam/pkg/app/audit/mocks"
+	"github.com/karta0898098/iam/pkg/app/audit/repository"
+	"github.com/karta0898098/iam/pkg/app/audit/service"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+func TestImpl_QueryAuditLog(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		opt  *service.QueryOption
+		repo func(t *testing.T) repository.Repository
+		err  error
+	}{
+		{
+			name: "Default Page",
+			opt:  &service.QueryOption{Actor: "MOCK-USER-ID", Outcome: audit.OutcomeFailure},
+			repo: func(t *testing.T) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					ListEvents(mock.Anything, &repository.EventQuery{
+						Actor:   "MOCK-USER-ID",
+						Outcome: audit.OutcomeFailure,
+						Offset:  0,
+						Limit:   service.PageSizeDefault,
+					}).
+					Return([]*audit.Event{{Seq: 1}}, 1, nil)
+				return repo
+			},
+		},
+		{
+			name: "Page Size Over Max",
+			opt:  &service.QueryOption{Page: 2, PageSize: service.PageSizeMax + 1},
+			repo: func(t *testing.T) repository.Repository {
+				return mocks.NewRepository(t)
+			},
+			err: errors.ErrInvalidInput,
+		},
+		{
+			name: "Reversed Time Range",
+			opt:  &service.QueryOption{CreatedAfter: now, CreatedBefore: now.Add(-time.Hour)},
+			repo: func(t *testing.T) repository.Repository {
+				return mocks.NewRepository(t)
+			},
+			err: errors.ErrInvalidInput,
+		},
+		{
+			name: "Unknown Outcome",
+			opt:  &service.QueryOption{Outcome: "unknown"},
+			repo: func(t *testing.T) repository.Repository {
+				return mocks.NewRepository(t)
+			},
+			err: errors.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := service.New(tt.repo(t))
+			events, total, err := srv.QueryAuditLog(context.Background(), tt.opt)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Len(t, events, 1)
+				assert.Equal(t, int64(1), total)
+			}
+		})
+	}
+}
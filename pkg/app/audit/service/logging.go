@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/audit"
+)
+
+type loggingMiddleware struct {
+	next AuditService `json:"-"`
+}
+
+// LoggingMiddleware takes a logger as a dependency
+// and returns a ServiceMiddleware.
+func LoggingMiddleware() Middleware {
+	return func(next AuditService) AuditService {
+		return loggingMiddleware{next}
+	}
+}
+
+func (lm loggingMiddleware) Record(ctx context.Context, event *audit.Event) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "Record",
+		// 	"action", event.Action,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.Record(ctx, event)
+}
+
+func (lm loggingMiddleware) QueryAuditLog(ctx context.Context, opt *QueryOption) (events []*audit.Event, total int64, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "QueryAuditLog",
+		// 	"page", opt.Page,
+		// 	"total", total,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.QueryAuditLog(ctx, opt)
+}
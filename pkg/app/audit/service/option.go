@@ -0,0 +1,34 @@
+package service
+
+import (
+	"time"
+
+	"github.com/karta0898098/iam/pkg/audit"
+)
+
+const (
+	// PageSizeDefault page size used when it is not given
+	PageSizeDefault = 20
+	// PageSizeMax max events of a page
+	PageSizeMax = 100
+)
+
+// QueryOption define filters and page of querying audit log
+type QueryOption struct {
+	// Actor filter events by user id, client id or username performing action
+	Actor string
+	// Action filter events by action
+	Action audit.Action
+	// Target filter events by user id or session id the action apply to
+	Target string
+	// Outcome filter events by outcome
+	Outcome audit.Outcome
+	// CreatedAfter include events created at or after it
+	CreatedAfter time.Time
+	// CreatedBefore include events created before it
+	CreatedBefore time.Time
+	// Page start from 1
+	Page int
+	// PageSize max events of page
+	PageSize int
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/app/audit/endpoints"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// MakeQueryAuditLog make query audit log endpoint
+func MakeQueryAuditLog(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.QueryAuditLogEndpoint,
+		decodeHTTPQueryAuditLogRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPQueryAuditLogRequest is a transport/http.DecodeRequestFunc that decodes
+// filters and page from the URL query. Primarily useful in a server.
+func decodeHTTPQueryAuditLogRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	values := r.URL.Query()
+
+	createdAfter, err := queryInt(values, "created_after")
+	if err != nil {
+		return nil, err
+	}
+	createdBefore, err := queryInt(values, "created_before")
+	if err != nil {
+		return nil, err
+	}
+	page, err := queryInt(values, "page")
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := queryInt(values, "page_size")
+	if err != nil {
+		return nil, err
+	}
+
+	return &endpoints.QueryAuditLogRequest{
+		Actor:         values.Get("actor"),
+		Action:        values.Get("action"),
+		Target:        values.Get("target"),
+		Outcome:       values.Get("outcome"),
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+		Page:          int(page),
+		PageSize:      int(pageSize),
+	}, nil
+}
+
+// queryInt read integer query parameter, missing parameter is zero
+func queryInt(values url.Values, name string) (int64, error) {
+	value := values.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(errors.ErrInvalidInput, "query %v=%v is not integer", name, value)
+	}
+	return i, nil
+}
+
+// encodeHTTPResponse is a transport/http.EncodeResponseFunc that encodes
+// response as JSON into the HTTP response body. Primarily useful in a server.
+func encodeHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	return json.NewEncoder(w).Encode(response)
+}
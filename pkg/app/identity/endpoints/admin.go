@@ -0,0 +1,32 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/identity/service"
+)
+
+// UnlockUserRequest define unlock user request
+type UnlockUserRequest struct {
+	UserID string `json:"-" validate:"required"`
+}
+
+// UnlockUserResponse define unlock user response
+type UnlockUserResponse struct {
+}
+
+// MakeUnlockUserEndpoint make unlock user endpoint
+func MakeUnlockUserEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UnlockUserRequest)
+
+		err = svc.Unlock(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &UnlockUserResponse{}, nil
+	}
+}
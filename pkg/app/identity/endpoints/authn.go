@@ -0,0 +1,33 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+// NewAuthenticator authenticate access token with introspection of identity service,
+// it is used by IAM itself, other service use authn.NewIntrospectionAuthenticator
+func NewAuthenticator(svc service.IdentityService) authn.Authenticator {
+	return authn.AuthenticatorFunc(func(ctx context.Context, token string) (*authn.Principal, error) {
+		introspection, err := svc.Introspect(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+
+		if !introspection.Active {
+			return nil, errors.Wrap(errors.ErrUnauthorized, "access token is not active")
+		}
+
+		return &authn.Principal{
+			UserID:    introspection.Subject,
+			SessionID: introspection.SessionID,
+			ClientID:  introspection.ClientID,
+			TenantID:  introspection.Tenant,
+			Scopes:    oidc.ParseScopes(introspection.Scope),
+		}, nil
+	})
+}
@@ -11,16 +11,53 @@ import (
 
 	"github.com/karta0898098/iam/pkg/app/identity/entity"
 	"github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+	"github.com/karta0898098/iam/pkg/tenant"
 )
 
 // Endpoints contain all identity endpoint
 type Endpoints struct {
-	SigninEndpoint endpoint.Endpoint
-	SignupEndpoint endpoint.Endpoint
+	SigninEndpoint        endpoint.Endpoint
+	SignupEndpoint        endpoint.Endpoint
+	RefreshEndpoint       endpoint.Endpoint
+	IntrospectEndpoint    endpoint.Endpoint
+	SignoutEndpoint       endpoint.Endpoint
+	SignoutAllEndpoint    endpoint.Endpoint
+	RevokeSessionEndpoint endpoint.Endpoint
+	ListSessionsEndpoint  endpoint.Endpoint
+	JWKSEndpoint          endpoint.Endpoint
+	DiscoveryEndpoint     endpoint.Endpoint
+	EnrollTOTPEndpoint    endpoint.Endpoint
+	ConfirmTOTPEndpoint   endpoint.Endpoint
+	VerifyMFAEndpoint     endpoint.Endpoint
+
+	WebAuthnRegisterBeginEndpoint  endpoint.Endpoint
+	WebAuthnRegisterFinishEndpoint endpoint.Endpoint
+	WebAuthnSigninBeginEndpoint    endpoint.Endpoint
+	WebAuthnSigninFinishEndpoint   endpoint.Endpoint
+
+	FederationSigninBeginEndpoint  endpoint.Endpoint
+	FederationLinkBeginEndpoint    endpoint.Endpoint
+	FederationSigninFinishEndpoint endpoint.Endpoint
+
+	VerifyEmailEndpoint        endpoint.Endpoint
+	ResendVerificationEndpoint endpoint.Endpoint
+
+	RequestPasswordResetEndpoint endpoint.Endpoint
+	ResetPasswordEndpoint        endpoint.Endpoint
+
+	GetProfileEndpoint     endpoint.Endpoint
+	UpdateProfileEndpoint  endpoint.Endpoint
+	ChangePasswordEndpoint endpoint.Endpoint
+
+	UnlockUserEndpoint endpoint.Endpoint
 }
 
 // New endpoints
-func New(svc service.IdentityService) (ep Endpoints) {
+func New(svc service.IdentityService, km keys.KeyManager, oidcConfig oidc.Config, limiter *ratelimit.Limiter, permissions authn.PermissionChecker) (ep Endpoints) {
 	v := validator.New()
 	eng := en.New()
 	uni := ut.New(eng, eng)
@@ -28,25 +65,254 @@ func New(svc service.IdentityService) (ep Endpoints) {
 
 	_ = translations.RegisterDefaultTranslations(v, trans)
 
-	signinEndpoint := MakeSigninEndpoint(svc)
+	authenticator := NewAuthenticator(svc)
+
+	signinEndpoint := MakeSigninEndpoint(svc, km, oidcConfig)
 	signinEndpoint = endpoint.Chain(
 		LoggingMiddleware("Signin"),
+		RateLimitMiddleware(limiter, "Signin"),
 		ValidateMiddleware(v, trans),
 	)(signinEndpoint)
 	ep.SigninEndpoint = signinEndpoint
 
-	signupEndpoint := MakeSignupEndpoint(svc)
+	signupEndpoint := MakeSignupEndpoint(svc, km, oidcConfig)
 	signupEndpoint = endpoint.Chain(
 		LoggingMiddleware("Signup"),
+		RateLimitMiddleware(limiter, "Signup"),
 		ValidateMiddleware(v, trans),
 	)(signupEndpoint)
 	ep.SignupEndpoint = signupEndpoint
 
+	refreshEndpoint := MakeRefreshEndpoint(svc, km)
+	refreshEndpoint = endpoint.Chain(
+		LoggingMiddleware("Refresh"),
+		RateLimitMiddleware(limiter, "Refresh"),
+		ValidateMiddleware(v, trans),
+	)(refreshEndpoint)
+	ep.RefreshEndpoint = refreshEndpoint
+
+	introspectEndpoint := MakeIntrospectEndpoint(svc)
+	introspectEndpoint = endpoint.Chain(
+		LoggingMiddleware("Introspect"),
+		RateLimitMiddleware(limiter, "Introspect"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewIntrospectionMiddleware(),
+		ValidateMiddleware(v, trans),
+	)(introspectEndpoint)
+	ep.IntrospectEndpoint = introspectEndpoint
+
+	signoutEndpoint := MakeSignoutEndpoint(svc)
+	signoutEndpoint = endpoint.Chain(
+		LoggingMiddleware("Signout"),
+		RateLimitMiddleware(limiter, "Signout"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(signoutEndpoint)
+	ep.SignoutEndpoint = signoutEndpoint
+
+	signoutAllEndpoint := MakeSignoutAllEndpoint(svc)
+	signoutAllEndpoint = endpoint.Chain(
+		LoggingMiddleware("SignoutAll"),
+		RateLimitMiddleware(limiter, "SignoutAll"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(signoutAllEndpoint)
+	ep.SignoutAllEndpoint = signoutAllEndpoint
+
+	revokeSessionEndpoint := MakeRevokeSessionEndpoint(svc)
+	revokeSessionEndpoint = endpoint.Chain(
+		LoggingMiddleware("RevokeSession"),
+		RateLimitMiddleware(limiter, "RevokeSession"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(revokeSessionEndpoint)
+	ep.RevokeSessionEndpoint = revokeSessionEndpoint
+
+	listSessionsEndpoint := MakeListSessionsEndpoint(svc)
+	listSessionsEndpoint = endpoint.Chain(
+		LoggingMiddleware("ListSessions"),
+		RateLimitMiddleware(limiter, "ListSessions"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(listSessionsEndpoint)
+	ep.ListSessionsEndpoint = listSessionsEndpoint
+
+	jwksEndpoint := MakeJWKSEndpoint(km)
+	jwksEndpoint = endpoint.Chain(
+		LoggingMiddleware("JWKS"),
+		RateLimitMiddleware(limiter, "JWKS"),
+	)(jwksEndpoint)
+	ep.JWKSEndpoint = jwksEndpoint
+
+	discoveryEndpoint := MakeDiscoveryEndpoint(km, oidcConfig)
+	discoveryEndpoint = endpoint.Chain(
+		LoggingMiddleware("Discovery"),
+		RateLimitMiddleware(limiter, "Discovery"),
+	)(discoveryEndpoint)
+	ep.DiscoveryEndpoint = discoveryEndpoint
+
+	enrollTOTPEndpoint := MakeEnrollTOTPEndpoint(svc)
+	enrollTOTPEndpoint = endpoint.Chain(
+		LoggingMiddleware("EnrollTOTP"),
+		RateLimitMiddleware(limiter, "EnrollTOTP"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(enrollTOTPEndpoint)
+	ep.EnrollTOTPEndpoint = enrollTOTPEndpoint
+
+	confirmTOTPEndpoint := MakeConfirmTOTPEndpoint(svc)
+	confirmTOTPEndpoint = endpoint.Chain(
+		LoggingMiddleware("ConfirmTOTP"),
+		RateLimitMiddleware(limiter, "ConfirmTOTP"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(confirmTOTPEndpoint)
+	ep.ConfirmTOTPEndpoint = confirmTOTPEndpoint
+
+	verifyMFAEndpoint := MakeVerifyMFAEndpoint(svc, km, oidcConfig)
+	verifyMFAEndpoint = endpoint.Chain(
+		LoggingMiddleware("VerifyMFA"),
+		RateLimitMiddleware(limiter, "VerifyMFA"),
+		ValidateMiddleware(v, trans),
+	)(verifyMFAEndpoint)
+	ep.VerifyMFAEndpoint = verifyMFAEndpoint
+
+	webAuthnRegisterBeginEndpoint := MakeWebAuthnRegisterBeginEndpoint(svc)
+	webAuthnRegisterBeginEndpoint = endpoint.Chain(
+		LoggingMiddleware("WebAuthnRegisterBegin"),
+		RateLimitMiddleware(limiter, "WebAuthnRegisterBegin"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(webAuthnRegisterBeginEndpoint)
+	ep.WebAuthnRegisterBeginEndpoint = webAuthnRegisterBeginEndpoint
+
+	webAuthnRegisterFinishEndpoint := MakeWebAuthnRegisterFinishEndpoint(svc)
+	webAuthnRegisterFinishEndpoint = endpoint.Chain(
+		LoggingMiddleware("WebAuthnRegisterFinish"),
+		RateLimitMiddleware(limiter, "WebAuthnRegisterFinish"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(webAuthnRegisterFinishEndpoint)
+	ep.WebAuthnRegisterFinishEndpoint = webAuthnRegisterFinishEndpoint
+
+	webAuthnSigninBeginEndpoint := MakeWebAuthnSigninBeginEndpoint(svc)
+	webAuthnSigninBeginEndpoint = endpoint.Chain(
+		LoggingMiddleware("WebAuthnSigninBegin"),
+		RateLimitMiddleware(limiter, "WebAuthnSigninBegin"),
+		ValidateMiddleware(v, trans),
+	)(webAuthnSigninBeginEndpoint)
+	ep.WebAuthnSigninBeginEndpoint = webAuthnSigninBeginEndpoint
+
+	webAuthnSigninFinishEndpoint := MakeWebAuthnSigninFinishEndpoint(svc, km, oidcConfig)
+	webAuthnSigninFinishEndpoint = endpoint.Chain(
+		LoggingMiddleware("WebAuthnSigninFinish"),
+		RateLimitMiddleware(limiter, "WebAuthnSigninFinish"),
+		ValidateMiddleware(v, trans),
+	)(webAuthnSigninFinishEndpoint)
+	ep.WebAuthnSigninFinishEndpoint = webAuthnSigninFinishEndpoint
+
+	federationSigninBeginEndpoint := MakeFederationSigninBeginEndpoint(svc)
+	federationSigninBeginEndpoint = endpoint.Chain(
+		LoggingMiddleware("FederationSigninBegin"),
+		RateLimitMiddleware(limiter, "FederationSigninBegin"),
+		ValidateMiddleware(v, trans),
+	)(federationSigninBeginEndpoint)
+	ep.FederationSigninBeginEndpoint = federationSigninBeginEndpoint
+
+	federationLinkBeginEndpoint := MakeFederationLinkBeginEndpoint(svc)
+	federationLinkBeginEndpoint = endpoint.Chain(
+		LoggingMiddleware("FederationLinkBegin"),
+		RateLimitMiddleware(limiter, "FederationLinkBegin"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(federationLinkBeginEndpoint)
+	ep.FederationLinkBeginEndpoint = federationLinkBeginEndpoint
+
+	federationSigninFinishEndpoint := MakeFederationSigninFinishEndpoint(svc, km, oidcConfig)
+	federationSigninFinishEndpoint = endpoint.Chain(
+		LoggingMiddleware("FederationSigninFinish"),
+		RateLimitMiddleware(limiter, "FederationSigninFinish"),
+		authn.NewOptionalEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(federationSigninFinishEndpoint)
+	ep.FederationSigninFinishEndpoint = federationSigninFinishEndpoint
+
+	verifyEmailEndpoint := MakeVerifyEmailEndpoint(svc)
+	verifyEmailEndpoint = endpoint.Chain(
+		LoggingMiddleware("VerifyEmail"),
+		RateLimitMiddleware(limiter, "VerifyEmail"),
+		ValidateMiddleware(v, trans),
+	)(verifyEmailEndpoint)
+	ep.VerifyEmailEndpoint = verifyEmailEndpoint
+
+	resendVerificationEndpoint := MakeResendVerificationEndpoint(svc)
+	resendVerificationEndpoint = endpoint.Chain(
+		LoggingMiddleware("ResendVerification"),
+		RateLimitMiddleware(limiter, "ResendVerification"),
+		ValidateMiddleware(v, trans),
+	)(resendVerificationEndpoint)
+	ep.ResendVerificationEndpoint = resendVerificationEndpoint
+
+	requestPasswordResetEndpoint := MakeRequestPasswordResetEndpoint(svc)
+	requestPasswordResetEndpoint = endpoint.Chain(
+		LoggingMiddleware("RequestPasswordReset"),
+		RateLimitMiddleware(limiter, "RequestPasswordReset"),
+		ValidateMiddleware(v, trans),
+	)(requestPasswordResetEndpoint)
+	ep.RequestPasswordResetEndpoint = requestPasswordResetEndpoint
+
+	resetPasswordEndpoint := MakeResetPasswordEndpoint(svc)
+	resetPasswordEndpoint = endpoint.Chain(
+		LoggingMiddleware("ResetPassword"),
+		RateLimitMiddleware(limiter, "ResetPassword"),
+		ValidateMiddleware(v, trans),
+	)(resetPasswordEndpoint)
+	ep.ResetPasswordEndpoint = resetPasswordEndpoint
+
+	getProfileEndpoint := MakeGetProfileEndpoint(svc)
+	getProfileEndpoint = endpoint.Chain(
+		LoggingMiddleware("GetProfile"),
+		RateLimitMiddleware(limiter, "GetProfile"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(getProfileEndpoint)
+	ep.GetProfileEndpoint = getProfileEndpoint
+
+	updateProfileEndpoint := MakeUpdateProfileEndpoint(svc)
+	updateProfileEndpoint = endpoint.Chain(
+		LoggingMiddleware("UpdateProfile"),
+		RateLimitMiddleware(limiter, "UpdateProfile"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(updateProfileEndpoint)
+	ep.UpdateProfileEndpoint = updateProfileEndpoint
+
+	changePasswordEndpoint := MakeChangePasswordEndpoint(svc)
+	changePasswordEndpoint = endpoint.Chain(
+		LoggingMiddleware("ChangePassword"),
+		RateLimitMiddleware(limiter, "ChangePassword"),
+		authn.NewEndpointMiddleware(authenticator),
+		ValidateMiddleware(v, trans),
+	)(changePasswordEndpoint)
+	ep.ChangePasswordEndpoint = changePasswordEndpoint
+
+	unlockUserEndpoint := MakeUnlockUserEndpoint(svc)
+	unlockUserEndpoint = endpoint.Chain(
+		LoggingMiddleware("UnlockUser"),
+		RateLimitMiddleware(limiter, "UnlockUser"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+		ValidateMiddleware(v, trans),
+	)(unlockUserEndpoint)
+	ep.UnlockUserEndpoint = unlockUserEndpoint
+
 	return ep
 }
 
 // SigninRequest define signin request
 type SigninRequest struct {
+	// Tenant name of tenant user belong to, empty is default tenant
+	Tenant   string `json:"tenant"`
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
 
@@ -54,21 +320,37 @@ type SigninRequest struct {
 	Platform    string        `json:"platform"`
 	IdpProvider string        `json:"idp_provider"`
 	Device      entity.Device `json:"device"`
+
+	// Scope request id token claims, default is openid
+	Scope string `json:"scope"`
+	// Nonce is put into id token to mitigate replay attacks
+	Nonce string `json:"nonce"`
+}
+
+// RateLimitKeys implement ratelimit.Keyer
+func (r *SigninRequest) RateLimitKeys() ratelimit.Keys {
+	return ratelimit.Keys{
+		ratelimit.KeyUsername: tenant.Qualify(r.Tenant, r.Username),
+	}
 }
 
 // SigninResponse define signup response
+// user enrolled mfa only get mfa token to complete challenge
 type SigninResponse struct {
-	IDToken      string `json:"id_token"`
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token,omitempty"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
 }
 
 // MakeSigninEndpoint make signin endpoint
-func MakeSigninEndpoint(svc service.IdentityService) endpoint.Endpoint {
+func MakeSigninEndpoint(svc service.IdentityService, km keys.KeyManager, oidcConfig oidc.Config) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(*SigninRequest)
 
 		identity, err := svc.Signin(ctx, req.Username, req.Password, &service.SigninOption{
+			Tenant:      req.Tenant,
 			IPAddress:   req.IPAddress,
 			Platform:    req.Platform,
 			Device:      req.Device,
@@ -78,16 +360,40 @@ func MakeSigninEndpoint(svc service.IdentityService) endpoint.Endpoint {
 			return nil, err
 		}
 
+		if identity.MFARequired {
+			mfaToken, err := identity.NewMFAToken(km)
+			if err != nil {
+				return nil, err
+			}
+
+			return &SigninResponse{
+				MFARequired: true,
+				MFAToken:    mfaToken,
+			}, nil
+		}
+
+		accessToken, refreshToken, err := newTokenPair(identity, km)
+		if err != nil {
+			return nil, err
+		}
+
+		idToken, err := identity.NewIDToken(km, newIDTokenOption(oidcConfig, req.Scope, req.Nonce))
+		if err != nil {
+			return nil, err
+		}
+
 		return &SigninResponse{
-			IDToken:      "", // TODO implement
-			AccessToken:  identity.NewAccessToken(),
-			RefreshToken: identity.NewRefreshToken(),
+			IDToken:      idToken,
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
 		}, nil
 	}
 }
 
 // SignupRequest define signup response
 type SignupRequest struct {
+	// Tenant name of tenant user sign up into, empty is default tenant
+	Tenant    string        `json:"tenant,omitempty"`
 	Username  string        `json:"username,omitempty"`
 	Password  string        `json:"password,omitempty"`
 	Nickname  string        `json:"nickname,omitempty"`
@@ -97,21 +403,29 @@ type SignupRequest struct {
 	Platform  string        `json:"platform,omitempty"`
 	IPAddress string        `json:"ip_address,omitempty"`
 	Device    entity.Device `json:"device"`
+
+	// Scope request id token claims, default is openid
+	Scope string `json:"scope,omitempty"`
+	// Nonce is put into id token to mitigate replay attacks
+	Nonce string `json:"nonce,omitempty"`
 }
 
 // SignupResponse define signup response
+// user must verify email before signin get no token
 type SignupResponse struct {
-	IDToken      string `json:"id_token"`
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	IDToken                   string `json:"id_token,omitempty"`
+	AccessToken               string `json:"access_token,omitempty"`
+	RefreshToken              string `json:"refresh_token,omitempty"`
+	EmailVerificationRequired bool   `json:"email_verification_required,omitempty"`
 }
 
 // MakeSignupEndpoint make signup endpoint
-func MakeSignupEndpoint(svc service.IdentityService) endpoint.Endpoint {
+func MakeSignupEndpoint(svc service.IdentityService, km keys.KeyManager, oidcConfig oidc.Config) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(*SignupRequest)
 
 		identity, err := svc.Signup(ctx, req.Username, req.Password, &service.SignupOption{
+			Tenant:    req.Tenant,
 			Nickname:  req.Nickname,
 			FirstName: req.FirstName,
 			LastName:  req.LastName,
@@ -124,10 +438,355 @@ func MakeSignupEndpoint(svc service.IdentityService) endpoint.Endpoint {
 			return nil, err
 		}
 
+		if identity.User.IsNotConfirmed() {
+			return &SignupResponse{
+				EmailVerificationRequired: true,
+			}, nil
+		}
+
+		accessToken, refreshToken, err := newTokenPair(identity, km)
+		if err != nil {
+			return nil, err
+		}
+
+		idToken, err := identity.NewIDToken(km, newIDTokenOption(oidcConfig, req.Scope, req.Nonce))
+		if err != nil {
+			return nil, err
+		}
+
 		return &SignupResponse{
-			IDToken:      "", // TODO implement
-			AccessToken:  identity.NewAccessToken(),
-			RefreshToken: identity.NewRefreshToken(),
+			IDToken:      idToken,
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		}, nil
+	}
+}
+
+// RefreshRequest define refresh request
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// RefreshResponse define refresh response
+type RefreshResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// MakeRefreshEndpoint make refresh endpoint
+func MakeRefreshEndpoint(svc service.IdentityService, km keys.KeyManager) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*RefreshRequest)
+
+		identity, err := svc.Refresh(ctx, req.RefreshToken, "")
+		if err != nil {
+			return nil, err
+		}
+
+		accessToken, refreshToken, err := newTokenPair(identity, km)
+		if err != nil {
+			return nil, err
+		}
+
+		return &RefreshResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		}, nil
+	}
+}
+
+// IntrospectRequest define introspect request
+type IntrospectRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// IntrospectResponse define introspect response
+// follow RFC 7662 section 2.2, inactive token only has active field
+type IntrospectResponse struct {
+	Active   bool   `json:"active"`
+	Sub      string `json:"sub,omitempty"`
+	Jti      string `json:"jti,omitempty"`
+	Exp      int64  `json:"exp,omitempty"`
+	Iat      int64  `json:"iat,omitempty"`
+	Platform string `json:"platform,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+	Tenant   string `json:"tenant,omitempty"`
+}
+
+// MakeIntrospectEndpoint make introspect endpoint
+func MakeIntrospectEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*IntrospectRequest)
+
+		introspection, err := svc.Introspect(ctx, req.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		if !introspection.Active {
+			return &IntrospectResponse{Active: false}, nil
+		}
+
+		return &IntrospectResponse{
+			Active:   true,
+			Sub:      introspection.Subject,
+			Jti:      introspection.SessionID,
+			Exp:      introspection.ExpiresAt.Unix(),
+			Iat:      introspection.IssuedAt.Unix(),
+			Platform: introspection.Platform,
+			ClientID: introspection.ClientID,
+			Scope:    introspection.Scope,
+			Tenant:   introspection.Tenant,
+		}, nil
+	}
+}
+
+// newTokenPair sign access token and refresh token of identity
+func newTokenPair(identity *entity.Identity, km keys.KeyManager) (accessToken string, refreshToken string, err error) {
+	accessToken, err = identity.NewAccessToken(km)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, err = identity.NewRefreshToken(km)
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// newIDTokenOption id token of direct signin is issued to configured audience
+func newIDTokenOption(oidcConfig oidc.Config, scope string, nonce string) *entity.IDTokenOption {
+	scopes := oidc.ParseScopes(scope)
+	if len(scopes) == 0 {
+		scopes = oidc.Scopes{oidc.ScopeOpenID}
+	}
+
+	return &entity.IDTokenOption{
+		Issuer:   oidcConfig.Issuer,
+		Audience: oidcConfig.Audience,
+		Nonce:    nonce,
+		Scopes:   scopes,
+	}
+}
+
+// SignoutRequest define signout request
+type SignoutRequest struct {
+}
+
+// SignoutResponse define signout response
+type SignoutResponse struct {
+}
+
+// MakeSignoutEndpoint make signout endpoint
+func MakeSignoutEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = svc.Signout(ctx, principal.SessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SignoutResponse{}, nil
+	}
+}
+
+// SignoutAllRequest define signout all devices request
+type SignoutAllRequest struct {
+}
+
+// SignoutAllResponse define signout all devices response
+type SignoutAllResponse struct {
+}
+
+// MakeSignoutAllEndpoint make signout all devices endpoint
+func MakeSignoutAllEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = svc.SignoutAll(ctx, principal.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SignoutAllResponse{}, nil
+	}
+}
+
+// RevokeSessionRequest define revoke session request
+type RevokeSessionRequest struct {
+	SessionID string `json:"session_id" validate:"required"`
+}
+
+// RevokeSessionResponse define revoke session response
+type RevokeSessionResponse struct {
+}
+
+// MakeRevokeSessionEndpoint make revoke session endpoint
+func MakeRevokeSessionEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*RevokeSessionRequest)
+
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = svc.RevokeSession(ctx, principal.UserID, req.SessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &RevokeSessionResponse{}, nil
+	}
+}
+
+// JWKSRequest define jwks request
+type JWKSRequest struct {
+}
+
+// JWKSResponse define jwks response
+type JWKSResponse = keys.JSONWebKeySet
+
+// MakeJWKSEndpoint make endpoint publish public keys to verify token
+func MakeJWKSEndpoint(km keys.KeyManager) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		return km.JWKS(), nil
+	}
+}
+
+// DiscoveryRequest define openid configuration request
+type DiscoveryRequest struct {
+}
+
+// DiscoveryResponse define openid configuration response
+type DiscoveryResponse = oidc.Discovery
+
+// MakeDiscoveryEndpoint make endpoint publish OpenID provider metadata
+func MakeDiscoveryEndpoint(km keys.KeyManager, oidcConfig oidc.Config) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var algs []string
+		for _, key := range km.JWKS().Keys {
+			algs = append(algs, key.Alg)
+		}
+
+		return oidc.NewDiscovery(oidcConfig, algs), nil
+	}
+}
+
+// EnrollTOTPRequest define enroll totp request
+type EnrollTOTPRequest struct {
+}
+
+// EnrollTOTPResponse define enroll totp response
+type EnrollTOTPResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// MakeEnrollTOTPEndpoint make enroll totp endpoint
+func MakeEnrollTOTPEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		enrollment, err := svc.EnrollTOTP(ctx, principal.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &EnrollTOTPResponse{
+			Secret: enrollment.Secret,
+			URI:    enrollment.URI,
+		}, nil
+	}
+}
+
+// ConfirmTOTPRequest define confirm totp request
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// ConfirmTOTPResponse define confirm totp response
+type ConfirmTOTPResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MakeConfirmTOTPEndpoint make confirm totp endpoint
+func MakeConfirmTOTPEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ConfirmTOTPRequest)
+
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		recoveryCodes, err := svc.ConfirmTOTP(ctx, principal.UserID, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ConfirmTOTPResponse{
+			RecoveryCodes: recoveryCodes,
+		}, nil
+	}
+}
+
+// VerifyMFARequest define verify mfa request
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	// Code is totp code or recovery code
+	Code string `json:"code" validate:"required"`
+
+	// Scope request id token claims, default is openid
+	Scope string `json:"scope"`
+	// Nonce is put into id token to mitigate replay attacks
+	Nonce string `json:"nonce"`
+}
+
+// VerifyMFAResponse define verify mfa response
+type VerifyMFAResponse struct {
+	IDToken      string `json:"id_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// MakeVerifyMFAEndpoint make verify mfa endpoint
+func MakeVerifyMFAEndpoint(svc service.IdentityService, km keys.KeyManager, oidcConfig oidc.Config) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*VerifyMFARequest)
+
+		identity, err := svc.VerifyMFA(ctx, req.MFAToken, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		accessToken, refreshToken, err := newTokenPair(identity, km)
+		if err != nil {
+			return nil, err
+		}
+
+		idToken, err := identity.NewIDToken(km, newIDTokenOption(oidcConfig, req.Scope, req.Nonce))
+		if err != nil {
+			return nil, err
+		}
+
+		return &VerifyMFAResponse{
+			IDToken:      idToken,
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
 		}, nil
 	}
 }
@@ -0,0 +1,130 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+// FederationSigninBeginRequest define begin signin at upstream provider request
+type FederationSigninBeginRequest struct {
+	Provider string `json:"-" validate:"required"`
+	// Tenant name of tenant user sign in, empty is default tenant
+	Tenant string `json:"tenant"`
+}
+
+// FederationBeginResponse define begin federation response,
+// user agent is redirected to authorization url
+type FederationBeginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// MakeFederationSigninBeginEndpoint make begin signin at upstream provider endpoint
+func MakeFederationSigninBeginEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*FederationSigninBeginRequest)
+
+		authURL, err := svc.BeginFederatedSignin(ctx, req.Provider, req.Tenant)
+		if err != nil {
+			return nil, err
+		}
+
+		return &FederationBeginResponse{
+			AuthorizationURL: authURL,
+		}, nil
+	}
+}
+
+// FederationLinkBeginRequest define begin linking upstream provider request
+type FederationLinkBeginRequest struct {
+	Provider string `json:"-" validate:"required"`
+}
+
+// MakeFederationLinkBeginEndpoint make begin linking upstream provider endpoint
+func MakeFederationLinkBeginEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*FederationLinkBeginRequest)
+
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		authURL, err := svc.BeginFederatedLink(ctx, req.Provider, principal.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &FederationBeginResponse{
+			AuthorizationURL: authURL,
+		}, nil
+	}
+}
+
+// FederationSigninFinishRequest define finish signin at upstream provider request,
+// code and state are received by redirect url from provider
+type FederationSigninFinishRequest struct {
+	Provider string `json:"-" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+	State    string `json:"state" validate:"required"`
+
+	IPAddress string        `json:"ip_address"`
+	Platform  string        `json:"platform"`
+	Device    entity.Device `json:"device"`
+
+	// Scope request id token claims, default is openid
+	Scope string `json:"scope"`
+	// Nonce is put into id token to mitigate replay attacks
+	Nonce string `json:"nonce"`
+}
+
+// MakeFederationSigninFinishEndpoint make finish signin at upstream provider endpoint,
+// response is the same as signin
+func MakeFederationSigninFinishEndpoint(svc service.IdentityService, km keys.KeyManager, oidcConfig oidc.Config) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*FederationSigninFinishRequest)
+
+		identity, err := svc.FinishFederatedSignin(ctx, req.Provider, req.State, req.Code, &service.SigninOption{
+			IPAddress: req.IPAddress,
+			Platform:  req.Platform,
+			Device:    req.Device,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if identity.MFARequired {
+			mfaToken, err := identity.NewMFAToken(km)
+			if err != nil {
+				return nil, err
+			}
+
+			return &SigninResponse{
+				MFARequired: true,
+				MFAToken:    mfaToken,
+			}, nil
+		}
+
+		accessToken, refreshToken, err := newTokenPair(identity, km)
+		if err != nil {
+			return nil, err
+		}
+
+		idToken, err := identity.NewIDToken(km, newIDTokenOption(oidcConfig, req.Scope, req.Nonce))
+		if err != nil {
+			return nil, err
+		}
+
+		return &SigninResponse{
+			IDToken:      idToken,
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		}, nil
+	}
+}
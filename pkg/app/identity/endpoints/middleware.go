@@ -9,7 +9,9 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/rs/zerolog/log"
 
+	"github.com/karta0898098/iam/pkg/clientip"
 	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/ratelimit"
 )
 
 // LoggingMiddleware returns an endpoint middleware that logs the
@@ -62,3 +64,28 @@ func ValidateMiddleware(validate *validator.Validate, trans ut.Translator) endpo
 		}
 	}
 }
+
+// RateLimitMiddleware returns an endpoint middleware that limit invocations
+// of method per ip address of client put by transport and keys carried by request implement ratelimit.Keyer
+func RateLimitMiddleware(limiter *ratelimit.Limiter, method string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			// keys of request are copied, map returned by keyer may be nil or shared
+			keys := ratelimit.Keys{}
+			if keyer, ok := request.(ratelimit.Keyer); ok {
+				for name, value := range keyer.RateLimitKeys() {
+					keys[name] = value
+				}
+			}
+			// ip address in request is supplied by client, only address of connection is counted
+			keys[ratelimit.KeyIP] = clientip.FromContext(ctx)
+
+			err = limiter.Allow(ctx, method, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
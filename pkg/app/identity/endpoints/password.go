@@ -0,0 +1,56 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/identity/service"
+)
+
+// RequestPasswordResetRequest define request password reset request
+type RequestPasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// RequestPasswordResetResponse define request password reset response
+type RequestPasswordResetResponse struct {
+}
+
+// MakeRequestPasswordResetEndpoint make request password reset endpoint
+func MakeRequestPasswordResetEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*RequestPasswordResetRequest)
+
+		err = svc.RequestPasswordReset(ctx, req.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		return &RequestPasswordResetResponse{}, nil
+	}
+}
+
+// ResetPasswordRequest define reset password request
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// ResetPasswordResponse define reset password response
+type ResetPasswordResponse struct {
+}
+
+// MakeResetPasswordEndpoint make reset password endpoint
+func MakeResetPasswordEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ResetPasswordRequest)
+
+		err = svc.ResetPassword(ctx, req.Token, req.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ResetPasswordResponse{}, nil
+	}
+}
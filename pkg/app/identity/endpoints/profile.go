@@ -0,0 +1,142 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/authn"
+)
+
+// Profile define user profile
+type Profile struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	Nickname  string `json:"nickname"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Avatar    string `json:"avatar"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+	Version   int64  `json:"version"`
+}
+
+// newProfile convert entity user to profile
+func newProfile(user *entity.User) *Profile {
+	return &Profile{
+		ID:        user.ID,
+		Username:  user.Username,
+		Nickname:  user.Nickname,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Avatar:    user.Avatar,
+		CreatedAt: user.CreatedAt.Unix(),
+		UpdatedAt: user.UpdatedAt.Unix(),
+		Version:   user.Version,
+	}
+}
+
+// GetProfileRequest define get profile request
+type GetProfileRequest struct {
+}
+
+// GetProfileResponse define get profile response
+type GetProfileResponse struct {
+	Profile *Profile `json:"profile"`
+}
+
+// MakeGetProfileEndpoint make get profile endpoint
+func MakeGetProfileEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := svc.GetProfile(ctx, principal.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &GetProfileResponse{
+			Profile: newProfile(user),
+		}, nil
+	}
+}
+
+// UpdateProfileRequest define update profile request,
+// field not present is unchanged
+type UpdateProfileRequest struct {
+	Version   int64   `json:"version" validate:"required"`
+	Nickname  *string `json:"nickname"`
+	FirstName *string `json:"first_name"`
+	LastName  *string `json:"last_name"`
+	Email     *string `json:"email"`
+	Avatar    *string `json:"avatar"`
+}
+
+// UpdateProfileResponse define update profile response
+type UpdateProfileResponse struct {
+	Profile *Profile `json:"profile"`
+}
+
+// MakeUpdateProfileEndpoint make update profile endpoint
+func MakeUpdateProfileEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UpdateProfileRequest)
+
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := svc.UpdateProfile(ctx, principal.UserID, &service.UpdateProfileOption{
+			Version:   req.Version,
+			Nickname:  req.Nickname,
+			FirstName: req.FirstName,
+			LastName:  req.LastName,
+			Email:     req.Email,
+			Avatar:    req.Avatar,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &UpdateProfileResponse{
+			Profile: newProfile(user),
+		}, nil
+	}
+}
+
+// ChangePasswordRequest define change password request
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// ChangePasswordResponse define change password response
+type ChangePasswordResponse struct {
+}
+
+// MakeChangePasswordEndpoint make change password endpoint
+func MakeChangePasswordEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ChangePasswordRequest)
+
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = svc.ChangePassword(ctx, principal.UserID, req.CurrentPassword, req.NewPassword)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ChangePasswordResponse{}, nil
+	}
+}
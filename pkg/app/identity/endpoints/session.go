@@ -0,0 +1,72 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/authn"
+)
+
+// Session define active session seen by its owner
+type Session struct {
+	ID          string        `json:"id"`
+	IPAddress   string        `json:"ip_address"`
+	Platform    string        `json:"platform"`
+	Device      entity.Device `json:"device"`
+	IdpProvider string        `json:"idp_provider"`
+	ClientID    string        `json:"client_id"`
+	CreatedAt   int64         `json:"created_at"`
+	LastSeenAt  int64         `json:"last_seen_at"`
+	Current     bool          `json:"current"`
+}
+
+// newSession convert entity session to session,
+// creation time is the sign in time and last seen is the latest refresh
+func newSession(session *entity.Session, current string) *Session {
+	return &Session{
+		ID:          session.ID,
+		IPAddress:   session.IPAddress,
+		Platform:    session.Platform,
+		Device:      session.Device,
+		IdpProvider: session.IdpProvider,
+		ClientID:    session.ClientID,
+		CreatedAt:   session.CreateAt.Unix(),
+		LastSeenAt:  session.UpdateAt.Unix(),
+		Current:     session.ID == current,
+	}
+}
+
+// ListSessionsRequest define list sessions request
+type ListSessionsRequest struct {
+}
+
+// ListSessionsResponse define list sessions response
+type ListSessionsResponse struct {
+	Sessions []*Session `json:"sessions"`
+}
+
+// MakeListSessionsEndpoint make list sessions of signed in user endpoint
+func MakeListSessionsEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions, current, err := svc.ListSessions(ctx, principal.UserID, principal.SessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &ListSessionsResponse{
+			Sessions: make([]*Session, 0, len(sessions)),
+		}
+		for _, session := range sessions {
+			resp.Sessions = append(resp.Sessions, newSession(session, current))
+		}
+		return resp, nil
+	}
+}
@@ -0,0 +1,66 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+	"github.com/karta0898098/iam/pkg/tenant"
+)
+
+// VerifyEmailRequest define verify email request
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// VerifyEmailResponse define verify email response
+type VerifyEmailResponse struct {
+}
+
+// MakeVerifyEmailEndpoint make verify email endpoint
+func MakeVerifyEmailEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*VerifyEmailRequest)
+
+		err = svc.VerifyEmail(ctx, req.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		return &VerifyEmailResponse{}, nil
+	}
+}
+
+// ResendVerificationRequest define resend verification mail request
+type ResendVerificationRequest struct {
+	Username string `json:"username" validate:"required"`
+	// Tenant name of tenant user belong to, empty is default tenant
+	Tenant string `json:"tenant"`
+}
+
+// RateLimitKeys implement ratelimit.Keyer
+func (r *ResendVerificationRequest) RateLimitKeys() ratelimit.Keys {
+	return ratelimit.Keys{
+		ratelimit.KeyUsername: tenant.Qualify(r.Tenant, r.Username),
+	}
+}
+
+// ResendVerificationResponse define resend verification mail response
+type ResendVerificationResponse struct {
+}
+
+// MakeResendVerificationEndpoint make resend verification mail endpoint
+func MakeResendVerificationEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ResendVerificationRequest)
+
+		err = svc.ResendVerification(ctx, req.Username, req.Tenant)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ResendVerificationResponse{}, nil
+	}
+}
@@ -0,0 +1,217 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+	"github.com/karta0898098/iam/pkg/tenant"
+	"github.com/karta0898098/iam/pkg/webauthn"
+)
+
+// WebAuthnRegisterBeginRequest define begin passkey registration request
+type WebAuthnRegisterBeginRequest struct {
+}
+
+// WebAuthnRegisterBeginResponse define begin passkey registration response
+// PublicKey is passed to navigator.credentials.create
+type WebAuthnRegisterBeginResponse struct {
+	ChallengeID string                    `json:"challenge_id"`
+	PublicKey   *webauthn.CreationOptions `json:"public_key"`
+}
+
+// MakeWebAuthnRegisterBeginEndpoint make begin passkey registration endpoint
+func MakeWebAuthnRegisterBeginEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		options, err := svc.BeginWebAuthnRegistration(ctx, principal.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &WebAuthnRegisterBeginResponse{
+			ChallengeID: options.ChallengeID,
+			PublicKey:   options.PublicKey,
+		}, nil
+	}
+}
+
+// AttestationCredential define PublicKeyCredential json created by registration
+type AttestationCredential struct {
+	RawID    webauthn.URLEncodedBytes `json:"rawId" validate:"required"`
+	Response struct {
+		ClientDataJSON    webauthn.URLEncodedBytes `json:"clientDataJSON" validate:"required"`
+		AttestationObject webauthn.URLEncodedBytes `json:"attestationObject" validate:"required"`
+	} `json:"response"`
+}
+
+// WebAuthnRegisterFinishRequest define finish passkey registration request
+type WebAuthnRegisterFinishRequest struct {
+	ChallengeID string                `json:"challenge_id" validate:"required"`
+	Name        string                `json:"name" validate:"max=64"`
+	Credential  AttestationCredential `json:"credential"`
+}
+
+// WebAuthnRegisterFinishResponse define finish passkey registration response
+type WebAuthnRegisterFinishResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// MakeWebAuthnRegisterFinishEndpoint make finish passkey registration endpoint
+func MakeWebAuthnRegisterFinishEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*WebAuthnRegisterFinishRequest)
+
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		credential, err := svc.FinishWebAuthnRegistration(
+			ctx,
+			principal.UserID,
+			req.ChallengeID,
+			req.Name,
+			&webauthn.AttestationResponse{
+				ClientDataJSON:    req.Credential.Response.ClientDataJSON,
+				AttestationObject: req.Credential.Response.AttestationObject,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &WebAuthnRegisterFinishResponse{
+			ID:        credential.ID,
+			Name:      credential.Name,
+			CreatedAt: credential.CreatedAt.Unix(),
+		}, nil
+	}
+}
+
+// WebAuthnSigninBeginRequest define begin passwordless signin request
+type WebAuthnSigninBeginRequest struct {
+	// Username is optional, empty let user pick discoverable passkey
+	Username string `json:"username"`
+	// Tenant name of tenant user sign in, empty is default tenant
+	Tenant string `json:"tenant"`
+}
+
+// RateLimitKeys implement ratelimit.Keyer
+func (r *WebAuthnSigninBeginRequest) RateLimitKeys() ratelimit.Keys {
+	return ratelimit.Keys{
+		ratelimit.KeyUsername: tenant.Qualify(r.Tenant, r.Username),
+	}
+}
+
+// WebAuthnSigninBeginResponse define begin passwordless signin response
+// PublicKey is passed to navigator.credentials.get
+type WebAuthnSigninBeginResponse struct {
+	ChallengeID string                   `json:"challenge_id"`
+	PublicKey   *webauthn.RequestOptions `json:"public_key"`
+}
+
+// MakeWebAuthnSigninBeginEndpoint make begin passwordless signin endpoint
+func MakeWebAuthnSigninBeginEndpoint(svc service.IdentityService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*WebAuthnSigninBeginRequest)
+
+		options, err := svc.BeginWebAuthnSignin(ctx, req.Username, req.Tenant)
+		if err != nil {
+			return nil, err
+		}
+
+		return &WebAuthnSigninBeginResponse{
+			ChallengeID: options.ChallengeID,
+			PublicKey:   options.PublicKey,
+		}, nil
+	}
+}
+
+// AssertionCredential define PublicKeyCredential json returned by authentication
+type AssertionCredential struct {
+	RawID    webauthn.URLEncodedBytes `json:"rawId" validate:"required"`
+	Response struct {
+		ClientDataJSON    webauthn.URLEncodedBytes `json:"clientDataJSON" validate:"required"`
+		AuthenticatorData webauthn.URLEncodedBytes `json:"authenticatorData" validate:"required"`
+		Signature         webauthn.URLEncodedBytes `json:"signature" validate:"required"`
+		UserHandle        webauthn.URLEncodedBytes `json:"userHandle"`
+	} `json:"response"`
+}
+
+// WebAuthnSigninFinishRequest define finish passwordless signin request
+type WebAuthnSigninFinishRequest struct {
+	ChallengeID string              `json:"challenge_id" validate:"required"`
+	Credential  AssertionCredential `json:"credential"`
+
+	IPAddress string        `json:"ip_address"`
+	Platform  string        `json:"platform"`
+	Device    entity.Device `json:"device"`
+
+	// Scope request id token claims, default is openid
+	Scope string `json:"scope"`
+	// Nonce is put into id token to mitigate replay attacks
+	Nonce string `json:"nonce"`
+}
+
+// WebAuthnSigninFinishResponse define finish passwordless signin response
+type WebAuthnSigninFinishResponse struct {
+	IDToken      string `json:"id_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// MakeWebAuthnSigninFinishEndpoint make finish passwordless signin endpoint
+func MakeWebAuthnSigninFinishEndpoint(svc service.IdentityService, km keys.KeyManager, oidcConfig oidc.Config) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*WebAuthnSigninFinishRequest)
+
+		identity, err := svc.FinishWebAuthnSignin(
+			ctx,
+			req.ChallengeID,
+			req.Credential.RawID.String(),
+			&webauthn.AssertionResponse{
+				ClientDataJSON:    req.Credential.Response.ClientDataJSON,
+				AuthenticatorData: req.Credential.Response.AuthenticatorData,
+				Signature:         req.Credential.Response.Signature,
+				UserHandle:        req.Credential.Response.UserHandle,
+			},
+			&service.SigninOption{
+				IPAddress: req.IPAddress,
+				Platform:  req.Platform,
+				Device:    req.Device,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		accessToken, refreshToken, err := newTokenPair(identity, km)
+		if err != nil {
+			return nil, err
+		}
+
+		idToken, err := identity.NewIDToken(km, newIDTokenOption(oidcConfig, req.Scope, req.Nonce))
+		if err != nil {
+			return nil, err
+		}
+
+		return &WebAuthnSigninFinishResponse{
+			IDToken:      idToken,
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		}, nil
+	}
+}
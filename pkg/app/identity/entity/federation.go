@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/federation"
+)
+
+// ExternalIdentity link subject of upstream provider to local user,
+// subject is unique in provider and tenant
+type ExternalIdentity struct {
+	Provider string
+	Subject  string
+	UserID   string
+	TenantID string
+	// Email is last email claimed by provider, it is informational only
+	Email      string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// NewExternalIdentity new link of provider subject to user
+func NewExternalIdentity(provider string, subject string, userID string, tenantID string, email string) *ExternalIdentity {
+	now := time.Now()
+	return &ExternalIdentity{
+		Provider:   provider,
+		Subject:    subject,
+		UserID:     userID,
+		TenantID:   tenantID,
+		Email:      email,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+}
+
+// Use record signin of linked identity
+func (i *ExternalIdentity) Use(email string) {
+	i.Email = email
+	i.LastUsedAt = time.Now()
+}
+
+// FederationState define pending signin at upstream provider, the state only can be consumed once
+type FederationState struct {
+	// ID is state parameter sent to provider
+	ID       string
+	Provider string
+	TenantID string
+	// UserID is not empty when signed in user link provider to account
+	UserID       string
+	Nonce        string
+	CodeVerifier string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// NewFederationState new random state, nonce and PKCE code verifier of signin at provider
+func NewFederationState(provider string, tenantID string, userID string) (*FederationState, error) {
+	id, err := federation.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := federation.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := federation.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &FederationState{
+		ID:           id,
+		Provider:     provider,
+		TenantID:     tenantID,
+		UserID:       userID,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(federation.StateLifetime),
+	}, nil
+}
+
+// Validate state is issued for provider and not expired
+func (s *FederationState) Validate(provider string) error {
+	if s.Provider != provider {
+		return errors.Wrapf(errors.ErrUnauthorized, "federation state=%v is not issued for provider=%v", s.ID, provider)
+	}
+
+	if time.Now().After(s.ExpiresAt) {
+		return errors.Wrapf(errors.ErrUnauthorized, "federation state=%v is expired", s.ID)
+	}
+
+	return nil
+}
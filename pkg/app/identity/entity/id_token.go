@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+// IDTokenClaims define OpenID Connect id token claims
+// profile and email claims only present when scope requested
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+
+	AuthTime int64    `json:"auth_time"`
+	Nonce    string   `json:"nonce,omitempty"`
+	AMR      []string `json:"amr,omitempty"`
+
+	// profile scope
+	Name              string `json:"name,omitempty"`
+	GivenName         string `json:"given_name,omitempty"`
+	FamilyName        string `json:"family_name,omitempty"`
+	Nickname          string `json:"nickname,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+	UpdatedAt         int64  `json:"updated_at,omitempty"`
+
+	// email scope
+	Email string `json:"email,omitempty"`
+}
+
+// IDTokenOption define how to issue id token
+type IDTokenOption struct {
+	Issuer   string
+	Audience string
+	Nonce    string
+	Scopes   oidc.Scopes
+}
+
+// NewIDToken new id token signed by active key
+// empty string is returned when openid scope is not requested
+func (i *Identity) NewIDToken(km keys.KeyManager, opt *IDTokenOption) (string, error) {
+	if !opt.Scopes.Has(oidc.ScopeOpenID) || i.MFARequired {
+		return "", nil
+	}
+
+	claims := &IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    opt.Issuer,
+			Subject:   i.User.ID,
+			Audience:  jwt.ClaimStrings{opt.Audience},
+			ExpiresAt: jwt.NewNumericDate(i.Session.ExpireAt),
+			IssuedAt:  jwt.NewNumericDate(i.Session.UpdateAt),
+		},
+		AuthTime: i.Session.CreateAt.Unix(),
+		Nonce:    opt.Nonce,
+		AMR:      i.Session.AuthMethods(),
+	}
+
+	if opt.Scopes.Has(oidc.ScopeProfile) {
+		claims.Name = strings.TrimSpace(i.User.FirstName + " " + i.User.LastName)
+		claims.GivenName = i.User.FirstName
+		claims.FamilyName = i.User.LastName
+		claims.Nickname = i.User.Nickname
+		claims.PreferredUsername = i.User.Username
+		claims.Picture = i.User.Avatar
+		claims.UpdatedAt = i.User.UpdatedAt.Unix()
+	}
+
+	if opt.Scopes.Has(oidc.ScopeEmail) {
+		claims.Email = i.User.Email
+	}
+
+	return km.Sign(claims)
+}
@@ -0,0 +1,99 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+func TestIdentity_NewIDToken(t *testing.T) {
+	km, _ := keys.NewKeyManager(keys.Config{Ephemeral: true})
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+		entity.WithEmail("mock@gmail.com"),
+		entity.WithNickname("mock-name"),
+	)
+	user.FirstName = "First"
+	user.LastName = "Last"
+	session := entity.NewSession("MOCK-SESSION-ID", user.ID, "127.0.0.1", "web")
+	identity := &entity.Identity{User: user, Session: session}
+
+	tests := []struct {
+		name     string
+		scope    string
+		empty    bool
+		expected entity.IDTokenClaims
+	}{
+		{
+			name:  "Without OpenID Scope",
+			scope: "profile email",
+			empty: true,
+		},
+		{
+			name:  "OpenID Only",
+			scope: "openid",
+			expected: entity.IDTokenClaims{
+				AMR: []string{oidc.AuthMethodPassword},
+			},
+		},
+		{
+			name:  "Profile",
+			scope: "openid profile",
+			expected: entity.IDTokenClaims{
+				AMR:               []string{oidc.AuthMethodPassword},
+				Name:              "First Last",
+				GivenName:         "First",
+				FamilyName:        "Last",
+				Nickname:          "mock-name",
+				PreferredUsername: "Username",
+			},
+		},
+		{
+			name:  "Email",
+			scope: "openid email",
+			expected: entity.IDTokenClaims{
+				AMR:   []string{oidc.AuthMethodPassword},
+				Email: "mock@gmail.com",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := identity.NewIDToken(km, &entity.IDTokenOption{
+				Issuer:   "http://localhost:8080",
+				Audience: "iam",
+				Nonce:    "MOCK-NONCE",
+				Scopes:   oidc.ParseScopes(tt.scope),
+			})
+			assert.NoError(t, err)
+			if tt.empty {
+				assert.Empty(t, token)
+				return
+			}
+
+			var claims entity.IDTokenClaims
+			_, err = jwt.ParseWithClaims(token, &claims, km.Keyfunc)
+			assert.NoError(t, err)
+
+			assert.Equal(t, "http://localhost:8080", claims.Issuer)
+			assert.Equal(t, jwt.ClaimStrings{"iam"}, claims.Audience)
+			assert.Equal(t, user.ID, claims.Subject)
+			assert.Equal(t, "MOCK-NONCE", claims.Nonce)
+			assert.Equal(t, session.CreateAt.Unix(), claims.AuthTime)
+			assert.Equal(t, tt.expected.AMR, claims.AMR)
+			assert.Equal(t, tt.expected.Name, claims.Name)
+			assert.Equal(t, tt.expected.GivenName, claims.GivenName)
+			assert.Equal(t, tt.expected.FamilyName, claims.FamilyName)
+			assert.Equal(t, tt.expected.Nickname, claims.Nickname)
+			assert.Equal(t, tt.expected.PreferredUsername, claims.PreferredUsername)
+			assert.Equal(t, tt.expected.Email, claims.Email)
+		})
+	}
+}
@@ -4,46 +4,118 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/keys"
+)
+
+const (
+	// TokenTypeAccess mark token can be used to access resource
+	TokenTypeAccess = "access"
+	// TokenTypeRefresh mark token can only be used to exchange new token pair
+	TokenTypeRefresh = "refresh"
 )
 
+// Claims define iam token claims
+type Claims struct {
+	jwt.RegisteredClaims
+
+	// TokenType distinguish access token and refresh token
+	TokenType string `json:"token_type"`
+
+	// ClientID oauth2 client token issued to
+	ClientID string `json:"client_id,omitempty"`
+	// Scope space-delimited scopes granted to client
+	Scope string `json:"scope,omitempty"`
+
+	// Roles names of role bound to user, only carried by access token
+	Roles []string `json:"roles,omitempty"`
+
+	// Tenant id of tenant user belong to, omitted for default tenant
+	Tenant string `json:"tenant,omitempty"`
+}
+
 // Identity aggregate user and session
 type Identity struct {
 	*User
 	*Session
+
+	// MFARequired password verified but second factor is pending,
+	// session is not stored and only mfa token can be issued
+	MFARequired bool
+
+	// Roles names of role bound to user embedded into access token
+	Roles []string
 }
 
-// NewAccessToken new access token
-func (i *Identity) NewAccessToken() string {
+// NewAccessToken new access token signed by active key
+func (i *Identity) NewAccessToken(km keys.KeyManager) (string, error) {
+	if i.MFARequired {
+		return "", errors.Wrapf(errors.ErrUnauthorized, "user=%v mfa is required", i.User.ID)
+	}
+
 	return newSignedToken(
-		i.Session.ID,
-		i.User.ID,
-		i.Session.CreateAt,
+		km,
+		TokenTypeAccess,
+		i.Session,
+		i.Roles,
 		i.Session.ExpireAt,
 	)
 }
 
-// NewRefreshToken new refresh token
-func (i *Identity) NewRefreshToken() string {
-	expiresAt := i.Session.CreateAt.Add(time.Second * 60 * 60 * 24 * 30)
+// NewRefreshToken new refresh token signed by active key
+func (i *Identity) NewRefreshToken(km keys.KeyManager) (string, error) {
+	if i.MFARequired {
+		return "", errors.Wrapf(errors.ErrUnauthorized, "user=%v mfa is required", i.User.ID)
+	}
+
+	expiresAt := i.Session.UpdateAt.Add(RefreshTokenLifetime)
 	return newSignedToken(
-		i.Session.ID,
-		i.User.ID,
-		i.Session.CreateAt,
+		km,
+		TokenTypeRefresh,
+		i.Session,
+		nil,
 		expiresAt,
 	)
 }
 
 // newSignedToken signing token string
-func newSignedToken(sessionID, userID string, now time.Time, expiresAt time.Time) string {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.RegisteredClaims{
-		Subject:   userID,
-		ExpiresAt: jwt.NewNumericDate(now),
-		NotBefore: jwt.NewNumericDate(expiresAt),
-		IssuedAt:  jwt.NewNumericDate(now),
-		ID:        sessionID,
+func newSignedToken(km keys.KeyManager, tokenType string, session *Session, roles []string, expiresAt time.Time) (string, error) {
+	return km.Sign(&Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   session.UserID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			NotBefore: jwt.NewNumericDate(session.UpdateAt),
+			IssuedAt:  jwt.NewNumericDate(session.UpdateAt),
+			ID:        session.ID,
+		},
+		TokenType: tokenType,
+		ClientID:  session.ClientID,
+		Scope:     session.Scope,
+		Roles:     roles,
+		Tenant:    session.TenantID,
 	})
+}
+
+// ParseToken verify token signature, expiry and type
+// then return the claims carried by token
+func ParseToken(km keys.KeyManager, tokenString string, tokenType string) (*Claims, error) {
+	var (
+		claims Claims
+	)
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims, km.Keyfunc)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "failed to parse token reason %v", err)
+	}
 
-	accessToken, _ := token.SignedString([]byte(SigninKey))
+	if claims.TokenType != tokenType {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"token type=%v is not expected type=%v",
+			claims.TokenType, tokenType,
+		)
+	}
 
-	return accessToken
+	return &claims, nil
 }
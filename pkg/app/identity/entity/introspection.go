@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+)
+
+// Introspection define the state of token
+// follow RFC 7662 token introspection
+type Introspection struct {
+	// Active token is valid and session not be revoked
+	Active bool
+	// Subject user id of token owner
+	Subject string
+	// SessionID session id carried by token jti
+	SessionID string
+	// ExpiresAt token expire time
+	ExpiresAt time.Time
+	// IssuedAt token issue time
+	IssuedAt time.Time
+	// Platform where session sign in
+	Platform string
+	// ClientID oauth2 client token issued to
+	ClientID string
+	// Scope space-delimited scopes granted to client
+	Scope string
+	// Tenant id of tenant user belong to, empty is default tenant
+	Tenant string
+}
+
+// NewIntrospection new active introspection from token claims and session
+func NewIntrospection(claims *Claims, session *Session) *Introspection {
+	return &Introspection{
+		Active:    true,
+		Subject:   claims.Subject,
+		SessionID: claims.ID,
+		ExpiresAt: claims.ExpiresAt.Time,
+		IssuedAt:  claims.IssuedAt.Time,
+		Platform:  session.Platform,
+		ClientID:  claims.ClientID,
+		Scope:     claims.Scope,
+		Tenant:    claims.Tenant,
+	}
+}
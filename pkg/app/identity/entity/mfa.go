@@ -0,0 +1,196 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/xid"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/totp"
+)
+
+const (
+	// TokenTypeMFA mark token can only be used to complete mfa challenge
+	TokenTypeMFA = "mfa"
+	// MFATokenLifetime how long user can complete mfa challenge after password verified
+	MFATokenLifetime = 5 * 60 * time.Second
+
+	// RecoveryCodeCount how many recovery codes issued when totp confirmed
+	RecoveryCodeCount = 10
+)
+
+var (
+	recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// TOTPFactor define time-based one-time password factor of user
+type TOTPFactor struct {
+	UserID string
+	// Secret base32 encoded shared secret, encrypted at rest
+	Secret string
+	// ConfirmedAt the time user verified first code, zero means enrollment pending
+	ConfirmedAt time.Time
+	// LastUsedCounter time step of last accepted code, reject code replay
+	LastUsedCounter uint64
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// TOTPEnrollment define what user import into authenticator app
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// NewTOTPFactor new pending totp factor with random secret
+func NewTOTPFactor(userID string) (*TOTPFactor, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "failed to generate totp secret err %v", err)
+	}
+
+	now := time.Now()
+	return &TOTPFactor{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsConfirmed user already verified the factor
+func (f *TOTPFactor) IsConfirmed() bool {
+	return !f.ConfirmedAt.IsZero()
+}
+
+// Verify check code and move last used counter forward,
+// code of time step already used is rejected
+func (f *TOTPFactor) Verify(code string, skew uint) bool {
+	counter, ok := totp.Validate(f.Secret, code, time.Now(), skew)
+	if !ok || (f.LastUsedCounter != 0 && counter <= f.LastUsedCounter) {
+		return false
+	}
+
+	f.LastUsedCounter = counter
+	f.UpdatedAt = time.Now()
+	return true
+}
+
+// Confirm mark enrollment completed
+func (f *TOTPFactor) Confirm() {
+	f.ConfirmedAt = time.Now()
+	f.UpdatedAt = f.ConfirmedAt
+}
+
+// NewRecoveryCodes generate one-time recovery codes,
+// plaintext is shown to user once and only hash is stored
+func NewRecoveryCodes() (codes []string, hashes []string, err error) {
+	for i := 0; i < RecoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		_, err = rand.Read(b)
+		if err != nil {
+			return nil, nil, errors.Wrapf(errors.ErrInternal, "failed to generate recovery code err %v", err)
+		}
+
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		code = code[:4] + "-" + code[4:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode hash normalized recovery code
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	h := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(h[:])
+}
+
+// MFAClaims define mfa challenge token claims
+// it carries the signin context to create session after challenge completed
+type MFAClaims struct {
+	jwt.RegisteredClaims
+
+	TokenType   string `json:"token_type"`
+	IPAddress   string `json:"ip_address,omitempty"`
+	Platform    string `json:"platform,omitempty"`
+	IdpProvider string `json:"idp_provider,omitempty"`
+	AuthMethod  string `json:"auth_method,omitempty"`
+	Device      Device `json:"device"`
+	Tenant      string `json:"tenant,omitempty"`
+}
+
+// NewMFAToken new mfa challenge token of identity pending mfa
+func (i *Identity) NewMFAToken(km keys.KeyManager) (string, error) {
+	now := time.Now()
+
+	return km.Sign(&MFAClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   i.User.ID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(MFATokenLifetime)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        xid.New().String(),
+		},
+		TokenType:   TokenTypeMFA,
+		IPAddress:   i.Session.IPAddress,
+		Platform:    i.Session.Platform,
+		IdpProvider: i.Session.IdpProvider,
+		AuthMethod:  i.Session.AuthMethod,
+		Device:      i.Session.Device,
+		Tenant:      i.Session.TenantID,
+	})
+}
+
+// ParseMFAToken verify mfa challenge token
+func ParseMFAToken(km keys.KeyManager, tokenString string) (*MFAClaims, error) {
+	var (
+		claims MFAClaims
+	)
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims, km.Keyfunc)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "failed to parse mfa token reason %v", err)
+	}
+
+	if claims.TokenType != TokenTypeMFA {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"token type=%v is not expected type=%v",
+			claims.TokenType, TokenTypeMFA,
+		)
+	}
+
+	return &claims, nil
+}
+
+// NewSession new session of signin context carried by mfa token,
+// one-time password is recorded after methods of first factor
+func (c *MFAClaims) NewSession(id string) *Session {
+	opts := []NewSessionOption{
+		WithDevice(c.Device),
+		WithSessionTenant(c.Tenant),
+	}
+	if c.IdpProvider != "" {
+		opts = append(opts, WithIdpProvider(c.IdpProvider))
+	}
+	if c.AuthMethod != "" {
+		opts = append(opts, WithAuthMethod(c.AuthMethod))
+	}
+
+	session := NewSession(id, c.Subject, c.IPAddress, c.Platform, opts...)
+	session.AddAuthMethod(oidc.AuthMethodOTP)
+
+	return session
+}
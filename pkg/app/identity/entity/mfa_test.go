@@ -0,0 +1,53 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+func TestMFAClaims_NewSession(t *testing.T) {
+	km, _ := keys.NewKeyManager(keys.Config{Ephemeral: true})
+	user, _ := entity.NewUser("MOCK-USER-ID", "Username", "A12345678")
+
+	tests := []struct {
+		name     string
+		opts     []entity.NewSessionOption
+		expected []string
+	}{
+		{
+			name:     "Password",
+			expected: []string{oidc.AuthMethodPassword, oidc.AuthMethodOTP},
+		},
+		{
+			name:     "Directory Password",
+			opts:     []entity.NewSessionOption{entity.WithIdpProvider("ldap"), entity.WithAuthMethod(oidc.AuthMethodPassword)},
+			expected: []string{oidc.AuthMethodPassword, oidc.AuthMethodOTP},
+		},
+		{
+			name:     "Federated",
+			opts:     []entity.NewSessionOption{entity.WithIdpProvider("google")},
+			expected: []string{oidc.AuthMethodFederated, oidc.AuthMethodOTP},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := (&entity.Identity{
+				User:        user,
+				Session:     entity.NewSession("MOCK-SESSION-ID", user.ID, "127.0.0.1", "web", tt.opts...),
+				MFARequired: true,
+			}).NewMFAToken(km)
+			assert.NoError(t, err)
+
+			claims, err := entity.ParseMFAToken(km, token)
+			assert.NoError(t, err)
+
+			session := claims.NewSession("MOCK-MFA-SESSION-ID")
+			assert.Equal(t, tt.expected, session.AuthMethods())
+		})
+	}
+}
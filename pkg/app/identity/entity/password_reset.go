@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+const (
+	// PasswordResetTokenLifetime default lifetime of password reset link
+	PasswordResetTokenLifetime = 30 * time.Minute
+	// passwordResetTokenSize bytes of random reset token
+	passwordResetTokenSize = 32
+)
+
+// PasswordResetToken define pending password reset,
+// only hash of token is stored and token can only be used once
+type PasswordResetToken struct {
+	// ID is hash of token
+	ID        string
+	UserID    string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NewPasswordResetToken new random reset token of user, plaintext is only sent to user
+func NewPasswordResetToken(userID string, lifetime time.Duration) (token *PasswordResetToken, plaintext string, err error) {
+	if lifetime <= 0 {
+		lifetime = PasswordResetTokenLifetime
+	}
+
+	b := make([]byte, passwordResetTokenSize)
+	_, err = rand.Read(b)
+	if err != nil {
+		return nil, "", errors.Wrapf(errors.ErrInternal, "failed to generate password reset token err %v", err)
+	}
+	plaintext = base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+	return &PasswordResetToken{
+		ID:        HashPasswordResetToken(plaintext),
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(lifetime),
+	}, plaintext, nil
+}
+
+// HashPasswordResetToken hash plaintext token
+func HashPasswordResetToken(plaintext string) string {
+	h := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(h[:])
+}
+
+// Validate token is not expired
+func (t *PasswordResetToken) Validate() error {
+	if time.Now().After(t.ExpiresAt) {
+		return errors.Wrapf(errors.ErrUnauthorized, "password reset token of user=%v is expired", t.UserID)
+	}
+	return nil
+}
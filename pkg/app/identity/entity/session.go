@@ -1,11 +1,17 @@
 package entity
 
 import (
+	"strings"
 	"time"
+
+	"github.com/karta0898098/iam/pkg/oidc"
 )
 
 const (
-	SigninKey = "WfAtSGjzzEw8JeL5GZjs99QLzV5bMdxy"
+	// AccessTokenLifetime how long access token can be used
+	AccessTokenLifetime = 10 * 60 * time.Second
+	// RefreshTokenLifetime how long refresh token can be exchanged
+	RefreshTokenLifetime = 60 * 60 * 24 * 30 * time.Second
 )
 
 // Session define session
@@ -19,6 +25,24 @@ type Session struct {
 	IdpProvider string
 	Platform    string
 	Device      Device
+
+	// FamilyID is the first session id of refresh chain
+	FamilyID string
+	// RotatedAt is the time session exchanged by refresh token
+	RotatedAt time.Time
+	// RevokedAt is the time session signed out or revoked
+	RevokedAt time.Time
+
+	// ClientID is the oauth2 client session issued to, empty for first party signin
+	ClientID string
+	// Scope is the space-delimited scopes granted to client
+	Scope string
+
+	// AuthMethod is space-delimited amr values of signin, empty means derived from idp provider
+	AuthMethod string
+
+	// TenantID is the tenant of session owner, empty is default tenant
+	TenantID string
 }
 
 type Device struct {
@@ -31,7 +55,7 @@ type NewSessionOption func(p *Session)
 
 func NewSession(id string, userID string, ip string, platform string, opts ...NewSessionOption) *Session {
 	now := time.Now()
-	accessTokenExpiresAt := now.Add(10 * 60 * time.Second)
+	accessTokenExpiresAt := now.Add(AccessTokenLifetime)
 
 	s := &Session{
 		ID:        id,
@@ -41,6 +65,7 @@ func NewSession(id string, userID string, ip string, platform string, opts ...Ne
 		ExpireAt:  accessTokenExpiresAt,
 		IPAddress: ip,
 		Platform:  platform,
+		FamilyID:  id,
 	}
 
 	for _, opt := range opts {
@@ -61,3 +86,77 @@ func WithDevice(device Device) NewSessionOption {
 		p.Device = device
 	}
 }
+
+// WithClient session issued to oauth2 client with granted scope
+func WithClient(clientID string, scope string) NewSessionOption {
+	return func(p *Session) {
+		p.ClientID = clientID
+		p.Scope = scope
+	}
+}
+
+// WithAuthMethod session signed in by method other than password
+func WithAuthMethod(method string) NewSessionOption {
+	return func(p *Session) {
+		p.AuthMethod = method
+	}
+}
+
+// WithSessionTenant session owned by user of tenant
+func WithSessionTenant(tenantID string) NewSessionOption {
+	return func(p *Session) {
+		p.TenantID = tenantID
+	}
+}
+
+// AuthMethods authentication methods references of session
+func (s *Session) AuthMethods() []string {
+	if s.AuthMethod != "" {
+		return strings.Fields(s.AuthMethod)
+	}
+	if s.IdpProvider != "" {
+		return []string{oidc.AuthMethodFederated}
+	}
+	return []string{oidc.AuthMethodPassword}
+}
+
+// AddAuthMethod record method of additional factor after signin methods
+func (s *Session) AddAuthMethod(method string) {
+	s.AuthMethod = strings.Join(append(s.AuthMethods(), method), " ")
+}
+
+// IsRotated session already exchanged by refresh token
+func (s *Session) IsRotated() bool {
+	return !s.RotatedAt.IsZero()
+}
+
+// IsRevoked session already signed out or revoked
+func (s *Session) IsRevoked() bool {
+	return !s.RevokedAt.IsZero()
+}
+
+// Rotate mark session rotated and derive the next session in same family
+// next session keep the sign in time and device information
+func (s *Session) Rotate(id string) *Session {
+	now := time.Now()
+
+	s.RotatedAt = now
+	s.UpdateAt = now
+
+	return &Session{
+		ID:          id,
+		UserID:      s.UserID,
+		CreateAt:    s.CreateAt,
+		UpdateAt:    now,
+		ExpireAt:    now.Add(AccessTokenLifetime),
+		IPAddress:   s.IPAddress,
+		IdpProvider: s.IdpProvider,
+		Platform:    s.Platform,
+		Device:      s.Device,
+		FamilyID:    s.FamilyID,
+		ClientID:    s.ClientID,
+		Scope:       s.Scope,
+		AuthMethod:  s.AuthMethod,
+		TenantID:    s.TenantID,
+	}
+}
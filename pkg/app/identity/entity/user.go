@@ -1,14 +1,14 @@
 package entity
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
 	"unicode"
 
 	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/password"
 )
 
 var (
@@ -20,11 +20,16 @@ type UserAccountStatus int8
 const (
 	UsernameLengthMax = 16
 	UsernameLengthMin = 8
+	// ProvisionedUsernameLengthMax is limit of username provisioned by directory or SCIM,
+	// which is often email address, and size of users.username column
+	ProvisionedUsernameLengthMax = 128
 
 	PasswordLengthMax = 16
 	PasswordLengthMin = 8
 
 	NameLengthMax = 20
+
+	AvatarLengthMax = 300
 )
 
 const (
@@ -36,22 +41,42 @@ const (
 	UserAccountStatusSuspend
 	// UserAccountStatusNotConfirmed user not confirmed
 	UserAccountStatusNotConfirmed
+	// UserAccountStatusDeleted user has been soft deleted by admin,
+	// row is kept so username can not be taken by others
+	UserAccountStatusDeleted
 )
 
 // ToString ..
 func (s UserAccountStatus) ToString() string {
 	switch s {
 	case UserAccountStatusActive:
-		return "normal"
-	case UserAccountStatusSuspend:
 		return "active"
+	case UserAccountStatusSuspend:
+		return "suspended"
 	case UserAccountStatusNotConfirmed:
 		return "notConfirmed"
+	case UserAccountStatusDeleted:
+		return "deleted"
 	default:
 		return "unknown"
 	}
 }
 
+// ParseUserAccountStatus parse status from ToString format
+func ParseUserAccountStatus(s string) (UserAccountStatus, error) {
+	for _, status := range []UserAccountStatus{
+		UserAccountStatusActive,
+		UserAccountStatusSuspend,
+		UserAccountStatusNotConfirmed,
+		UserAccountStatusDeleted,
+	} {
+		if status.ToString() == s {
+			return status, nil
+		}
+	}
+	return UserAccountStatusUnknown, errors.Wrapf(errors.ErrInvalidInput, "user status=%v is not supported", s)
+}
+
 // User define user information
 type User struct {
 	// ID unique identity number
@@ -76,6 +101,14 @@ type User struct {
 	UpdatedAt time.Time
 	// Status this account is suspend
 	Status UserAccountStatus
+	// Version increase on every profile or password change,
+	// update based on stale version is rejected
+	Version int64
+	// TenantID tenant user belong to, username is unique in tenant
+	TenantID string
+
+	// hasher used to hash password when user created
+	hasher password.Hasher
 }
 
 // ValidatePasswordFormat check input password match rule
@@ -106,28 +139,127 @@ func (p *User) ValidatePasswordFormat(password string) bool {
 	return (number) && (!special) && letters <= PasswordLengthMax && letters >= PasswordLengthMin
 }
 
-// ValidatePassword check input password match rule
-// password length min <= len <= max
-// password need alphabet
-// password don't have any space or special symbol
-func (p *User) ValidatePassword(password string) bool {
-	return p.Password == encryptPassword(password)
+// ValidatePassword check input password match stored hash
+// every hash format ever used is supported, include legacy sha256
+func (p *User) ValidatePassword(plaintext string) bool {
+	ok, err := password.Verify(plaintext, p.Password)
+	return err == nil && ok
 }
 
-func encryptPassword(password string) string {
-	var (
-		result string
-	)
-	h := sha256.New()
-	h.Write([]byte(password))
-	result = hex.EncodeToString(h.Sum(nil))
-	return result
+// PasswordNeedsRehash stored hash is not produced by current hasher setting
+func (p *User) PasswordNeedsRehash(hasher password.Hasher) bool {
+	return hasher.NeedsRehash(p.Password)
+}
+
+// RehashPassword hash plaintext password with hasher
+// plaintext should be verified before rehash
+func (p *User) RehashPassword(hasher password.Hasher, plaintext string) error {
+	encoded, err := hasher.Hash(plaintext)
+	if err != nil {
+		return err
+	}
+
+	p.Password = encoded
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetPassword validate format of new password and hash it with hasher
+func (p *User) SetPassword(hasher password.Hasher, plaintext string) error {
+	if !p.ValidatePasswordFormat(plaintext) {
+		return errors.Wrap(errors.ErrInvalidInput, "input password format is not correct")
+	}
+
+	return p.RehashPassword(hasher, plaintext)
+}
+
+// ChangePassword replace password after current password verified
+func (p *User) ChangePassword(hasher password.Hasher, current string, plaintext string) error {
+	if !p.ValidatePassword(current) {
+		return errors.Wrapf(errors.ErrUnauthorized, "user=%v current password is not correct", p.ID)
+	}
+
+	err := p.SetPassword(hasher, plaintext)
+	if err != nil {
+		return err
+	}
+
+	p.Version++
+	return nil
+}
+
+// UpdateProfile apply validated profile options
+func (p *User) UpdateProfile(opts ...NewUserOption) error {
+	for _, opt := range opts {
+		err := opt(p)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.UpdatedAt = time.Now()
+	p.Version++
+	return nil
 }
 
 func (p *User) IsActive() bool {
 	return p.Status == UserAccountStatusActive
 }
 
+// IsNotConfirmed user has not verified email address
+func (p *User) IsNotConfirmed() bool {
+	return p.Status == UserAccountStatusNotConfirmed
+}
+
+// ConfirmEmail activate user verified email address
+func (p *User) ConfirmEmail() {
+	p.Status = UserAccountStatusActive
+	p.UpdatedAt = time.Now()
+}
+
+// IsSuspended user has been suspended by admin
+func (p *User) IsSuspended() bool {
+	return p.Status == UserAccountStatusSuspend
+}
+
+// IsDeleted user has been soft deleted by admin
+func (p *User) IsDeleted() bool {
+	return p.Status == UserAccountStatusDeleted
+}
+
+// Suspend stop user from signing in until reactivated
+func (p *User) Suspend() error {
+	if p.IsSuspended() || p.IsDeleted() {
+		return errors.Wrapf(errors.ErrConflict, "user=%v can not be suspended status=%v", p.ID, p.Status.ToString())
+	}
+
+	p.Status = UserAccountStatusSuspend
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// Reactivate restore user suspended by admin
+func (p *User) Reactivate() error {
+	if !p.IsSuspended() {
+		return errors.Wrapf(errors.ErrConflict, "user=%v is not suspended status=%v", p.ID, p.Status.ToString())
+	}
+
+	p.Status = UserAccountStatusActive
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// MarkDeleted soft delete user
+func (p *User) MarkDeleted() error {
+	if p.IsDeleted() {
+		return errors.Wrapf(errors.ErrConflict, "user=%v already deleted", p.ID)
+	}
+
+	p.Status = UserAccountStatusDeleted
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
 type NewUserOption func(p *User) error
 
 // NewUser new user constructor
@@ -137,6 +269,9 @@ func NewUser(
 	Password string,
 	opts ...NewUserOption,
 ) (*User, error) {
+	if len(Username) > ProvisionedUsernameLengthMax {
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "input username length is invalid username = %v", Username)
+	}
 
 	now := time.Now()
 	p := &User{
@@ -146,6 +281,8 @@ func NewUser(
 		CreatedAt: now,
 		UpdatedAt: now,
 		Status:    UserAccountStatusActive,
+		Version:   1,
+		hasher:    password.Default,
 	}
 
 	for _, opt := range opts {
@@ -159,11 +296,79 @@ func NewUser(
 		return nil, errors.Wrap(errors.ErrInvalidInput, "input password format is not correct")
 	}
 
-	p.Password = encryptPassword(Password)
+	encoded, err := p.hasher.Hash(Password)
+	if err != nil {
+		return nil, err
+	}
+	p.Password = encoded
+
+	return p, nil
+}
+
+// NewFederatedUser new user signed up by upstream provider,
+// the user has no password and username is user id so it never collide with chosen username
+func NewFederatedUser(ID string, opts ...NewUserOption) (*User, error) {
+	now := time.Now()
+	p := &User{
+		ID:        ID,
+		Username:  ID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Status:    UserAccountStatusActive,
+		Version:   1,
+		hasher:    password.Default,
+	}
+
+	for _, opt := range opts {
+		err := opt(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// NewDirectoryUser new user signed up by external directory such as LDAP,
+// the user has no password since directory verify it at every signin
+func NewDirectoryUser(ID string, Username string, opts ...NewUserOption) (*User, error) {
+	if Username == "" || len(Username) > ProvisionedUsernameLengthMax {
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "input username length is invalid username = %v", Username)
+	}
+
+	p, err := NewFederatedUser(ID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.Username = Username
 
 	return p, nil
 }
 
+// WithPasswordHasher hash password with hasher instead of password.Default
+func WithPasswordHasher(hasher password.Hasher) NewUserOption {
+	return func(p *User) error {
+		p.hasher = hasher
+		return nil
+	}
+}
+
+// WithTenant user belong to tenant instead of default tenant
+func WithTenant(tenantID string) NewUserOption {
+	return func(p *User) error {
+		p.TenantID = tenantID
+		return nil
+	}
+}
+
+// WithEmailVerificationRequired user is not confirmed until email address verified
+func WithEmailVerificationRequired() NewUserOption {
+	return func(p *User) error {
+		p.Status = UserAccountStatusNotConfirmed
+		return nil
+	}
+}
+
 // WithNickname the method will check nickname format
 func WithNickname(nickname string) NewUserOption {
 	return func(p *User) error {
@@ -176,6 +381,51 @@ func WithNickname(nickname string) NewUserOption {
 	}
 }
 
+// WithFirstName the method will check first name format
+func WithFirstName(firstName string) NewUserOption {
+	return func(p *User) error {
+		if len(firstName) > NameLengthMax {
+			return errors.Wrap(errors.ErrInvalidInput, "input first name length too many")
+		}
+		p.FirstName = firstName
+		return nil
+	}
+}
+
+// WithLastName the method will check last name format
+func WithLastName(lastName string) NewUserOption {
+	return func(p *User) error {
+		if len(lastName) > NameLengthMax {
+			return errors.Wrap(errors.ErrInvalidInput, "input last name length too many")
+		}
+		p.LastName = lastName
+		return nil
+	}
+}
+
+// WithAvatar the method will check avatar is http or https URL,
+// empty avatar remove profile picture
+func WithAvatar(avatar string) NewUserOption {
+	return func(p *User) error {
+		if avatar == "" {
+			p.Avatar = ""
+			return nil
+		}
+
+		if len(avatar) > AvatarLengthMax {
+			return errors.Wrap(errors.ErrInvalidInput, "input avatar length too many")
+		}
+
+		u, err := url.Parse(avatar)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Wrapf(errors.ErrInvalidInput, "input avatar is not http url avatar = %v", avatar)
+		}
+
+		p.Avatar = avatar
+		return nil
+	}
+}
+
 // WithEmail validate email format
 func WithEmail(email string) NewUserOption {
 	return func(p *User) error {
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/xid"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/keys"
+)
+
+const (
+	// TokenTypeEmailVerification mark token can only be used to verify email address
+	TokenTypeEmailVerification = "email_verification"
+	// TokenTypeEmailChange mark token can only be used to confirm new email address of user
+	TokenTypeEmailChange = "email_change"
+	// EmailVerificationTokenLifetime default lifetime of verification link
+	EmailVerificationTokenLifetime = 24 * time.Hour
+)
+
+// EmailVerificationClaims define email verification token claims,
+// token is bound to email so changing email invalidates sent link
+type EmailVerificationClaims struct {
+	jwt.RegisteredClaims
+
+	TokenType string `json:"token_type"`
+	Email     string `json:"email"`
+	// PreviousEmail is email of user when change token issued,
+	// token is rejected once email changed by other way
+	PreviousEmail string `json:"previous_email,omitempty"`
+}
+
+// NewEmailVerificationToken new token verifying current email of user
+func (p *User) NewEmailVerificationToken(km keys.KeyManager, lifetime time.Duration) (string, error) {
+	if lifetime <= 0 {
+		lifetime = EmailVerificationTokenLifetime
+	}
+
+	now := time.Now()
+	return km.Sign(&EmailVerificationClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   p.ID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        xid.New().String(),
+		},
+		TokenType: TokenTypeEmailVerification,
+		Email:     p.Email,
+	})
+}
+
+// NewEmailChangeToken new token confirming new email address of user,
+// current email is kept until new address is confirmed
+func (p *User) NewEmailChangeToken(km keys.KeyManager, email string, lifetime time.Duration) (string, error) {
+	var changed User
+	err := WithEmail(email)(&changed)
+	if err != nil {
+		return "", err
+	}
+
+	if lifetime <= 0 {
+		lifetime = EmailVerificationTokenLifetime
+	}
+
+	now := time.Now()
+	return km.Sign(&EmailVerificationClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   p.ID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        xid.New().String(),
+		},
+		TokenType:     TokenTypeEmailChange,
+		Email:         changed.Email,
+		PreviousEmail: p.Email,
+	})
+}
+
+// ParseEmailVerificationToken verify email verification or email change token
+func ParseEmailVerificationToken(km keys.KeyManager, tokenString string) (*EmailVerificationClaims, error) {
+	var (
+		claims EmailVerificationClaims
+	)
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims, km.Keyfunc)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "failed to parse email verification token reason %v", err)
+	}
+
+	if claims.TokenType != TokenTypeEmailVerification && claims.TokenType != TokenTypeEmailChange {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"token type=%v is not expected type=%v or %v",
+			claims.TokenType, TokenTypeEmailVerification, TokenTypeEmailChange,
+		)
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/webauthn"
+)
+
+const (
+	// WebAuthnCeremonyRegistration challenge of passkey registration
+	WebAuthnCeremonyRegistration = "registration"
+	// WebAuthnCeremonyAuthentication challenge of passwordless signin
+	WebAuthnCeremonyAuthentication = "authentication"
+)
+
+// WebAuthnCredential define passkey registered by user
+type WebAuthnCredential struct {
+	// ID is base64url encoded credential id
+	ID     string
+	UserID string
+	// Name is given by user to recognize the passkey
+	Name string
+	// PublicKey is COSE_Key encoded
+	PublicKey         []byte
+	AAGUID            []byte
+	SignCount         uint32
+	AttestationFormat string
+	BackupEligible    bool
+	CreatedAt         time.Time
+	LastUsedAt        time.Time
+}
+
+// NewWebAuthnCredential new credential of verified registration
+func NewWebAuthnCredential(userID string, name string, credential *webauthn.Credential) *WebAuthnCredential {
+	return &WebAuthnCredential{
+		ID:                webauthn.URLEncodedBytes(credential.ID).String(),
+		UserID:            userID,
+		Name:              name,
+		PublicKey:         credential.PublicKey,
+		AAGUID:            credential.AAGUID,
+		SignCount:         credential.SignCount,
+		AttestationFormat: credential.AttestationFormat,
+		BackupEligible:    credential.BackupEligible,
+		CreatedAt:         time.Now(),
+	}
+}
+
+// RawID decoded credential id
+func (c *WebAuthnCredential) RawID() []byte {
+	id, _ := base64.RawURLEncoding.DecodeString(c.ID)
+	return id
+}
+
+// Credential convert to webauthn credential for verifying assertion
+func (c *WebAuthnCredential) Credential() *webauthn.Credential {
+	return &webauthn.Credential{
+		ID:                c.RawID(),
+		PublicKey:         c.PublicKey,
+		AAGUID:            c.AAGUID,
+		SignCount:         c.SignCount,
+		AttestationFormat: c.AttestationFormat,
+		BackupEligible:    c.BackupEligible,
+	}
+}
+
+// Use record sign count of accepted assertion
+func (c *WebAuthnCredential) Use(signCount uint32) {
+	c.SignCount = signCount
+	c.LastUsedAt = time.Now()
+}
+
+// WebAuthnChallenge define pending ceremony, the challenge only can be consumed once
+type WebAuthnChallenge struct {
+	ID string
+	// UserID is empty when user signin with discoverable credential
+	UserID    string
+	Ceremony  string
+	Challenge []byte
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NewWebAuthnChallenge new random challenge of ceremony
+func NewWebAuthnChallenge(id string, userID string, ceremony string) (*WebAuthnChallenge, error) {
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &WebAuthnChallenge{
+		ID:        id,
+		UserID:    userID,
+		Ceremony:  ceremony,
+		Challenge: challenge,
+		CreatedAt: now,
+		ExpiresAt: now.Add(webauthn.Timeout),
+	}, nil
+}
+
+// Validate challenge belong to ceremony and not expired
+func (c *WebAuthnChallenge) Validate(ceremony string) error {
+	if c.Ceremony != ceremony {
+		return errors.Wrapf(errors.ErrUnauthorized, "webauthn challenge=%v is not %v ceremony", c.ID, ceremony)
+	}
+
+	if time.Now().After(c.ExpiresAt) {
+		return errors.Wrapf(errors.ErrUnauthorized, "webauthn challenge=%v is expired", c.ID)
+	}
+
+	return nil
+}
+
+// WebAuthnRegistrationOptions define options of passkey registration
+type WebAuthnRegistrationOptions struct {
+	ChallengeID string
+	PublicKey   *webauthn.CreationOptions
+}
+
+// WebAuthnSigninOptions define options of passwordless signin
+type WebAuthnSigninOptions struct {
+	ChallengeID string
+	PublicKey   *webauthn.RequestOptions
+}
@@ -0,0 +1,135 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Authenticator is an autogenerated mock type for the Authenticator type
+type Authenticator struct {
+	mock.Mock
+}
+
+type Authenticator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Authenticator) EXPECT() *Authenticator_Expecter {
+	return &Authenticator_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: ctx, username, password
+func (_m *Authenticator) Authenticate(ctx context.Context, username string, password string) (*entity.User, error) {
+	ret := _m.Called(ctx, username, password)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.User, error)); ok {
+		return rf(ctx, username, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.User); ok {
+		r0 = rf(ctx, username, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Authenticator_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type Authenticator_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - password string
+func (_e *Authenticator_Expecter) Authenticate(ctx interface{}, username interface{}, password interface{}) *Authenticator_Authenticate_Call {
+	return &Authenticator_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, username, password)}
+}
+
+func (_c *Authenticator_Authenticate_Call) Run(run func(ctx context.Context, username string, password string)) *Authenticator_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Authenticator_Authenticate_Call) Return(user *entity.User, err error) *Authenticator_Authenticate_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *Authenticator_Authenticate_Call) RunAndReturn(run func(context.Context, string, string) (*entity.User, error)) *Authenticator_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Name provides a mock function with given fields:
+func (_m *Authenticator) Name() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Authenticator_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type Authenticator_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *Authenticator_Expecter) Name() *Authenticator_Name_Call {
+	return &Authenticator_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *Authenticator_Name_Call) Run(run func()) *Authenticator_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Authenticator_Name_Call) Return(_a0 string) *Authenticator_Name_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Authenticator_Name_Call) RunAndReturn(run func() string) *Authenticator_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewAuthenticator interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAuthenticator creates a new instance of Authenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAuthenticator(t mockConstructorTestingTNewAuthenticator) *Authenticator {
+	mock := &Authenticator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
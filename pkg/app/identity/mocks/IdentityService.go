@@ -9,19 +9,974 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	service "github.com/karta0898098/iam/pkg/app/identity/service"
+
+	webauthn "github.com/karta0898098/iam/pkg/webauthn"
 )
 
-// IdentityService is an autogenerated mock type for the IdentityService type
-type IdentityService struct {
-	mock.Mock
+// IdentityService is an autogenerated mock type for the IdentityService type
+type IdentityService struct {
+	mock.Mock
+}
+
+type IdentityService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IdentityService) EXPECT() *IdentityService_Expecter {
+	return &IdentityService_Expecter{mock: &_m.Mock}
+}
+
+// BeginFederatedLink provides a mock function with given fields: ctx, provider, userID
+func (_m *IdentityService) BeginFederatedLink(ctx context.Context, provider string, userID string) (string, error) {
+	ret := _m.Called(ctx, provider, userID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, provider, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, provider, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_BeginFederatedLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginFederatedLink'
+type IdentityService_BeginFederatedLink_Call struct {
+	*mock.Call
+}
+
+// BeginFederatedLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider string
+//   - userID string
+func (_e *IdentityService_Expecter) BeginFederatedLink(ctx interface{}, provider interface{}, userID interface{}) *IdentityService_BeginFederatedLink_Call {
+	return &IdentityService_BeginFederatedLink_Call{Call: _e.mock.On("BeginFederatedLink", ctx, provider, userID)}
+}
+
+func (_c *IdentityService_BeginFederatedLink_Call) Run(run func(ctx context.Context, provider string, userID string)) *IdentityService_BeginFederatedLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_BeginFederatedLink_Call) Return(authURL string, err error) *IdentityService_BeginFederatedLink_Call {
+	_c.Call.Return(authURL, err)
+	return _c
+}
+
+func (_c *IdentityService_BeginFederatedLink_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *IdentityService_BeginFederatedLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BeginFederatedSignin provides a mock function with given fields: ctx, provider, tenantName
+func (_m *IdentityService) BeginFederatedSignin(ctx context.Context, provider string, tenantName string) (string, error) {
+	ret := _m.Called(ctx, provider, tenantName)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, provider, tenantName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, provider, tenantName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, tenantName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_BeginFederatedSignin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginFederatedSignin'
+type IdentityService_BeginFederatedSignin_Call struct {
+	*mock.Call
+}
+
+// BeginFederatedSignin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider string
+//   - tenantName string
+func (_e *IdentityService_Expecter) BeginFederatedSignin(ctx interface{}, provider interface{}, tenantName interface{}) *IdentityService_BeginFederatedSignin_Call {
+	return &IdentityService_BeginFederatedSignin_Call{Call: _e.mock.On("BeginFederatedSignin", ctx, provider, tenantName)}
+}
+
+func (_c *IdentityService_BeginFederatedSignin_Call) Run(run func(ctx context.Context, provider string, tenantName string)) *IdentityService_BeginFederatedSignin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_BeginFederatedSignin_Call) Return(authURL string, err error) *IdentityService_BeginFederatedSignin_Call {
+	_c.Call.Return(authURL, err)
+	return _c
+}
+
+func (_c *IdentityService_BeginFederatedSignin_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *IdentityService_BeginFederatedSignin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BeginWebAuthnRegistration provides a mock function with given fields: ctx, userID
+func (_m *IdentityService) BeginWebAuthnRegistration(ctx context.Context, userID string) (*entity.WebAuthnRegistrationOptions, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *entity.WebAuthnRegistrationOptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.WebAuthnRegistrationOptions, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.WebAuthnRegistrationOptions); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebAuthnRegistrationOptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_BeginWebAuthnRegistration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginWebAuthnRegistration'
+type IdentityService_BeginWebAuthnRegistration_Call struct {
+	*mock.Call
+}
+
+// BeginWebAuthnRegistration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *IdentityService_Expecter) BeginWebAuthnRegistration(ctx interface{}, userID interface{}) *IdentityService_BeginWebAuthnRegistration_Call {
+	return &IdentityService_BeginWebAuthnRegistration_Call{Call: _e.mock.On("BeginWebAuthnRegistration", ctx, userID)}
+}
+
+func (_c *IdentityService_BeginWebAuthnRegistration_Call) Run(run func(ctx context.Context, userID string)) *IdentityService_BeginWebAuthnRegistration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_BeginWebAuthnRegistration_Call) Return(options *entity.WebAuthnRegistrationOptions, err error) *IdentityService_BeginWebAuthnRegistration_Call {
+	_c.Call.Return(options, err)
+	return _c
+}
+
+func (_c *IdentityService_BeginWebAuthnRegistration_Call) RunAndReturn(run func(context.Context, string) (*entity.WebAuthnRegistrationOptions, error)) *IdentityService_BeginWebAuthnRegistration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BeginWebAuthnSignin provides a mock function with given fields: ctx, username, tenantName
+func (_m *IdentityService) BeginWebAuthnSignin(ctx context.Context, username string, tenantName string) (*entity.WebAuthnSigninOptions, error) {
+	ret := _m.Called(ctx, username, tenantName)
+
+	var r0 *entity.WebAuthnSigninOptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.WebAuthnSigninOptions, error)); ok {
+		return rf(ctx, username, tenantName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.WebAuthnSigninOptions); ok {
+		r0 = rf(ctx, username, tenantName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebAuthnSigninOptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, tenantName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_BeginWebAuthnSignin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginWebAuthnSignin'
+type IdentityService_BeginWebAuthnSignin_Call struct {
+	*mock.Call
+}
+
+// BeginWebAuthnSignin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - tenantName string
+func (_e *IdentityService_Expecter) BeginWebAuthnSignin(ctx interface{}, username interface{}, tenantName interface{}) *IdentityService_BeginWebAuthnSignin_Call {
+	return &IdentityService_BeginWebAuthnSignin_Call{Call: _e.mock.On("BeginWebAuthnSignin", ctx, username, tenantName)}
+}
+
+func (_c *IdentityService_BeginWebAuthnSignin_Call) Run(run func(ctx context.Context, username string, tenantName string)) *IdentityService_BeginWebAuthnSignin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_BeginWebAuthnSignin_Call) Return(options *entity.WebAuthnSigninOptions, err error) *IdentityService_BeginWebAuthnSignin_Call {
+	_c.Call.Return(options, err)
+	return _c
+}
+
+func (_c *IdentityService_BeginWebAuthnSignin_Call) RunAndReturn(run func(context.Context, string, string) (*entity.WebAuthnSigninOptions, error)) *IdentityService_BeginWebAuthnSignin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangePassword provides a mock function with given fields: ctx, userID, currentPassword, newPassword
+func (_m *IdentityService) ChangePassword(ctx context.Context, userID string, currentPassword string, newPassword string) error {
+	ret := _m.Called(ctx, userID, currentPassword, newPassword)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, currentPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdentityService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type IdentityService_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - currentPassword string
+//   - newPassword string
+func (_e *IdentityService_Expecter) ChangePassword(ctx interface{}, userID interface{}, currentPassword interface{}, newPassword interface{}) *IdentityService_ChangePassword_Call {
+	return &IdentityService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, userID, currentPassword, newPassword)}
+}
+
+func (_c *IdentityService_ChangePassword_Call) Run(run func(ctx context.Context, userID string, currentPassword string, newPassword string)) *IdentityService_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_ChangePassword_Call) Return(err error) *IdentityService_ChangePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *IdentityService_ChangePassword_Call) RunAndReturn(run func(context.Context, string, string, string) error) *IdentityService_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmTOTP provides a mock function with given fields: ctx, userID, code
+func (_m *IdentityService) ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(ctx, userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_ConfirmTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmTOTP'
+type IdentityService_ConfirmTOTP_Call struct {
+	*mock.Call
+}
+
+// ConfirmTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - code string
+func (_e *IdentityService_Expecter) ConfirmTOTP(ctx interface{}, userID interface{}, code interface{}) *IdentityService_ConfirmTOTP_Call {
+	return &IdentityService_ConfirmTOTP_Call{Call: _e.mock.On("ConfirmTOTP", ctx, userID, code)}
+}
+
+func (_c *IdentityService_ConfirmTOTP_Call) Run(run func(ctx context.Context, userID string, code string)) *IdentityService_ConfirmTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_ConfirmTOTP_Call) Return(recoveryCodes []string, err error) *IdentityService_ConfirmTOTP_Call {
+	_c.Call.Return(recoveryCodes, err)
+	return _c
+}
+
+func (_c *IdentityService_ConfirmTOTP_Call) RunAndReturn(run func(context.Context, string, string) ([]string, error)) *IdentityService_ConfirmTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnrollTOTP provides a mock function with given fields: ctx, userID
+func (_m *IdentityService) EnrollTOTP(ctx context.Context, userID string) (*entity.TOTPEnrollment, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *entity.TOTPEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.TOTPEnrollment, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.TOTPEnrollment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TOTPEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_EnrollTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollTOTP'
+type IdentityService_EnrollTOTP_Call struct {
+	*mock.Call
+}
+
+// EnrollTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *IdentityService_Expecter) EnrollTOTP(ctx interface{}, userID interface{}) *IdentityService_EnrollTOTP_Call {
+	return &IdentityService_EnrollTOTP_Call{Call: _e.mock.On("EnrollTOTP", ctx, userID)}
+}
+
+func (_c *IdentityService_EnrollTOTP_Call) Run(run func(ctx context.Context, userID string)) *IdentityService_EnrollTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_EnrollTOTP_Call) Return(enrollment *entity.TOTPEnrollment, err error) *IdentityService_EnrollTOTP_Call {
+	_c.Call.Return(enrollment, err)
+	return _c
+}
+
+func (_c *IdentityService_EnrollTOTP_Call) RunAndReturn(run func(context.Context, string) (*entity.TOTPEnrollment, error)) *IdentityService_EnrollTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishFederatedSignin provides a mock function with given fields: ctx, provider, state, code, opt
+func (_m *IdentityService) FinishFederatedSignin(ctx context.Context, provider string, state string, code string, opt *service.SigninOption) (*entity.Identity, error) {
+	ret := _m.Called(ctx, provider, state, code, opt)
+
+	var r0 *entity.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *service.SigninOption) (*entity.Identity, error)); ok {
+		return rf(ctx, provider, state, code, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *service.SigninOption) *entity.Identity); ok {
+		r0 = rf(ctx, provider, state, code, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *service.SigninOption) error); ok {
+		r1 = rf(ctx, provider, state, code, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_FinishFederatedSignin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishFederatedSignin'
+type IdentityService_FinishFederatedSignin_Call struct {
+	*mock.Call
+}
+
+// FinishFederatedSignin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider string
+//   - state string
+//   - code string
+//   - opt *service.SigninOption
+func (_e *IdentityService_Expecter) FinishFederatedSignin(ctx interface{}, provider interface{}, state interface{}, code interface{}, opt interface{}) *IdentityService_FinishFederatedSignin_Call {
+	return &IdentityService_FinishFederatedSignin_Call{Call: _e.mock.On("FinishFederatedSignin", ctx, provider, state, code, opt)}
+}
+
+func (_c *IdentityService_FinishFederatedSignin_Call) Run(run func(ctx context.Context, provider string, state string, code string, opt *service.SigninOption)) *IdentityService_FinishFederatedSignin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(*service.SigninOption))
+	})
+	return _c
+}
+
+func (_c *IdentityService_FinishFederatedSignin_Call) Return(identity *entity.Identity, err error) *IdentityService_FinishFederatedSignin_Call {
+	_c.Call.Return(identity, err)
+	return _c
+}
+
+func (_c *IdentityService_FinishFederatedSignin_Call) RunAndReturn(run func(context.Context, string, string, string, *service.SigninOption) (*entity.Identity, error)) *IdentityService_FinishFederatedSignin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishWebAuthnRegistration provides a mock function with given fields: ctx, userID, challengeID, name, resp
+func (_m *IdentityService) FinishWebAuthnRegistration(ctx context.Context, userID string, challengeID string, name string, resp *webauthn.AttestationResponse) (*entity.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, userID, challengeID, name, resp)
+
+	var r0 *entity.WebAuthnCredential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *webauthn.AttestationResponse) (*entity.WebAuthnCredential, error)); ok {
+		return rf(ctx, userID, challengeID, name, resp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *webauthn.AttestationResponse) *entity.WebAuthnCredential); ok {
+		r0 = rf(ctx, userID, challengeID, name, resp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebAuthnCredential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *webauthn.AttestationResponse) error); ok {
+		r1 = rf(ctx, userID, challengeID, name, resp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_FinishWebAuthnRegistration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishWebAuthnRegistration'
+type IdentityService_FinishWebAuthnRegistration_Call struct {
+	*mock.Call
+}
+
+// FinishWebAuthnRegistration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - challengeID string
+//   - name string
+//   - resp *webauthn.AttestationResponse
+func (_e *IdentityService_Expecter) FinishWebAuthnRegistration(ctx interface{}, userID interface{}, challengeID interface{}, name interface{}, resp interface{}) *IdentityService_FinishWebAuthnRegistration_Call {
+	return &IdentityService_FinishWebAuthnRegistration_Call{Call: _e.mock.On("FinishWebAuthnRegistration", ctx, userID, challengeID, name, resp)}
+}
+
+func (_c *IdentityService_FinishWebAuthnRegistration_Call) Run(run func(ctx context.Context, userID string, challengeID string, name string, resp *webauthn.AttestationResponse)) *IdentityService_FinishWebAuthnRegistration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(*webauthn.AttestationResponse))
+	})
+	return _c
+}
+
+func (_c *IdentityService_FinishWebAuthnRegistration_Call) Return(credential *entity.WebAuthnCredential, err error) *IdentityService_FinishWebAuthnRegistration_Call {
+	_c.Call.Return(credential, err)
+	return _c
+}
+
+func (_c *IdentityService_FinishWebAuthnRegistration_Call) RunAndReturn(run func(context.Context, string, string, string, *webauthn.AttestationResponse) (*entity.WebAuthnCredential, error)) *IdentityService_FinishWebAuthnRegistration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishWebAuthnSignin provides a mock function with given fields: ctx, challengeID, credentialID, resp, opt
+func (_m *IdentityService) FinishWebAuthnSignin(ctx context.Context, challengeID string, credentialID string, resp *webauthn.AssertionResponse, opt *service.SigninOption) (*entity.Identity, error) {
+	ret := _m.Called(ctx, challengeID, credentialID, resp, opt)
+
+	var r0 *entity.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *webauthn.AssertionResponse, *service.SigninOption) (*entity.Identity, error)); ok {
+		return rf(ctx, challengeID, credentialID, resp, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *webauthn.AssertionResponse, *service.SigninOption) *entity.Identity); ok {
+		r0 = rf(ctx, challengeID, credentialID, resp, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *webauthn.AssertionResponse, *service.SigninOption) error); ok {
+		r1 = rf(ctx, challengeID, credentialID, resp, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_FinishWebAuthnSignin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishWebAuthnSignin'
+type IdentityService_FinishWebAuthnSignin_Call struct {
+	*mock.Call
+}
+
+// FinishWebAuthnSignin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challengeID string
+//   - credentialID string
+//   - resp *webauthn.AssertionResponse
+//   - opt *service.SigninOption
+func (_e *IdentityService_Expecter) FinishWebAuthnSignin(ctx interface{}, challengeID interface{}, credentialID interface{}, resp interface{}, opt interface{}) *IdentityService_FinishWebAuthnSignin_Call {
+	return &IdentityService_FinishWebAuthnSignin_Call{Call: _e.mock.On("FinishWebAuthnSignin", ctx, challengeID, credentialID, resp, opt)}
+}
+
+func (_c *IdentityService_FinishWebAuthnSignin_Call) Run(run func(ctx context.Context, challengeID string, credentialID string, resp *webauthn.AssertionResponse, opt *service.SigninOption)) *IdentityService_FinishWebAuthnSignin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*webauthn.AssertionResponse), args[4].(*service.SigninOption))
+	})
+	return _c
+}
+
+func (_c *IdentityService_FinishWebAuthnSignin_Call) Return(identity *entity.Identity, err error) *IdentityService_FinishWebAuthnSignin_Call {
+	_c.Call.Return(identity, err)
+	return _c
+}
+
+func (_c *IdentityService_FinishWebAuthnSignin_Call) RunAndReturn(run func(context.Context, string, string, *webauthn.AssertionResponse, *service.SigninOption) (*entity.Identity, error)) *IdentityService_FinishWebAuthnSignin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProfile provides a mock function with given fields: ctx, userID
+func (_m *IdentityService) GetProfile(ctx context.Context, userID string) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
+type IdentityService_GetProfile_Call struct {
+	*mock.Call
+}
+
+// GetProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *IdentityService_Expecter) GetProfile(ctx interface{}, userID interface{}) *IdentityService_GetProfile_Call {
+	return &IdentityService_GetProfile_Call{Call: _e.mock.On("GetProfile", ctx, userID)}
+}
+
+func (_c *IdentityService_GetProfile_Call) Run(run func(ctx context.Context, userID string)) *IdentityService_GetProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_GetProfile_Call) Return(user *entity.User, err error) *IdentityService_GetProfile_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *IdentityService_GetProfile_Call) RunAndReturn(run func(context.Context, string) (*entity.User, error)) *IdentityService_GetProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Introspect provides a mock function with given fields: ctx, token
+func (_m *IdentityService) Introspect(ctx context.Context, token string) (*entity.Introspection, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *entity.Introspection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Introspection, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Introspection); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Introspection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_Introspect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Introspect'
+type IdentityService_Introspect_Call struct {
+	*mock.Call
+}
+
+// Introspect is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *IdentityService_Expecter) Introspect(ctx interface{}, token interface{}) *IdentityService_Introspect_Call {
+	return &IdentityService_Introspect_Call{Call: _e.mock.On("Introspect", ctx, token)}
+}
+
+func (_c *IdentityService_Introspect_Call) Run(run func(ctx context.Context, token string)) *IdentityService_Introspect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_Introspect_Call) Return(introspection *entity.Introspection, err error) *IdentityService_Introspect_Call {
+	_c.Call.Return(introspection, err)
+	return _c
+}
+
+func (_c *IdentityService_Introspect_Call) RunAndReturn(run func(context.Context, string) (*entity.Introspection, error)) *IdentityService_Introspect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSessions provides a mock function with given fields: ctx, userID, sessionID
+func (_m *IdentityService) ListSessions(ctx context.Context, userID string, sessionID string) ([]*entity.Session, string, error) {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	var r0 []*entity.Session
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*entity.Session, string, error)); ok {
+		return rf(ctx, userID, sessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*entity.Session); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
+		r1 = rf(ctx, userID, sessionID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, userID, sessionID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IdentityService_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type IdentityService_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sessionID string
+func (_e *IdentityService_Expecter) ListSessions(ctx interface{}, userID interface{}, sessionID interface{}) *IdentityService_ListSessions_Call {
+	return &IdentityService_ListSessions_Call{Call: _e.mock.On("ListSessions", ctx, userID, sessionID)}
+}
+
+func (_c *IdentityService_ListSessions_Call) Run(run func(ctx context.Context, userID string, sessionID string)) *IdentityService_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_ListSessions_Call) Return(sessions []*entity.Session, current string, err error) *IdentityService_ListSessions_Call {
+	_c.Call.Return(sessions, current, err)
+	return _c
+}
+
+func (_c *IdentityService_ListSessions_Call) RunAndReturn(run func(context.Context, string, string) ([]*entity.Session, string, error)) *IdentityService_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx, refreshToken, clientID
+func (_m *IdentityService) Refresh(ctx context.Context, refreshToken string, clientID string) (*entity.Identity, error) {
+	ret := _m.Called(ctx, refreshToken, clientID)
+
+	var r0 *entity.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Identity, error)); ok {
+		return rf(ctx, refreshToken, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Identity); ok {
+		r0 = rf(ctx, refreshToken, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, refreshToken, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type IdentityService_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+//   - clientID string
+func (_e *IdentityService_Expecter) Refresh(ctx interface{}, refreshToken interface{}, clientID interface{}) *IdentityService_Refresh_Call {
+	return &IdentityService_Refresh_Call{Call: _e.mock.On("Refresh", ctx, refreshToken, clientID)}
+}
+
+func (_c *IdentityService_Refresh_Call) Run(run func(ctx context.Context, refreshToken string, clientID string)) *IdentityService_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_Refresh_Call) Return(identity *entity.Identity, err error) *IdentityService_Refresh_Call {
+	_c.Call.Return(identity, err)
+	return _c
+}
+
+func (_c *IdentityService_Refresh_Call) RunAndReturn(run func(context.Context, string, string) (*entity.Identity, error)) *IdentityService_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestPasswordReset provides a mock function with given fields: ctx, email
+func (_m *IdentityService) RequestPasswordReset(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdentityService_RequestPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestPasswordReset'
+type IdentityService_RequestPasswordReset_Call struct {
+	*mock.Call
+}
+
+// RequestPasswordReset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *IdentityService_Expecter) RequestPasswordReset(ctx interface{}, email interface{}) *IdentityService_RequestPasswordReset_Call {
+	return &IdentityService_RequestPasswordReset_Call{Call: _e.mock.On("RequestPasswordReset", ctx, email)}
 }
 
-type IdentityService_Expecter struct {
-	mock *mock.Mock
+func (_c *IdentityService_RequestPasswordReset_Call) Run(run func(ctx context.Context, email string)) *IdentityService_RequestPasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
 }
 
-func (_m *IdentityService) EXPECT() *IdentityService_Expecter {
-	return &IdentityService_Expecter{mock: &_m.Mock}
+func (_c *IdentityService_RequestPasswordReset_Call) Return(err error) *IdentityService_RequestPasswordReset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *IdentityService_RequestPasswordReset_Call) RunAndReturn(run func(context.Context, string) error) *IdentityService_RequestPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResendVerification provides a mock function with given fields: ctx, username, tenantName
+func (_m *IdentityService) ResendVerification(ctx context.Context, username string, tenantName string) error {
+	ret := _m.Called(ctx, username, tenantName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, tenantName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdentityService_ResendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerification'
+type IdentityService_ResendVerification_Call struct {
+	*mock.Call
+}
+
+// ResendVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - tenantName string
+func (_e *IdentityService_Expecter) ResendVerification(ctx interface{}, username interface{}, tenantName interface{}) *IdentityService_ResendVerification_Call {
+	return &IdentityService_ResendVerification_Call{Call: _e.mock.On("ResendVerification", ctx, username, tenantName)}
+}
+
+func (_c *IdentityService_ResendVerification_Call) Run(run func(ctx context.Context, username string, tenantName string)) *IdentityService_ResendVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_ResendVerification_Call) Return(err error) *IdentityService_ResendVerification_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *IdentityService_ResendVerification_Call) RunAndReturn(run func(context.Context, string, string) error) *IdentityService_ResendVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: ctx, token, newPassword
+func (_m *IdentityService) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	ret := _m.Called(ctx, token, newPassword)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdentityService_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type IdentityService_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - newPassword string
+func (_e *IdentityService_Expecter) ResetPassword(ctx interface{}, token interface{}, newPassword interface{}) *IdentityService_ResetPassword_Call {
+	return &IdentityService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, token, newPassword)}
+}
+
+func (_c *IdentityService_ResetPassword_Call) Run(run func(ctx context.Context, token string, newPassword string)) *IdentityService_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_ResetPassword_Call) Return(err error) *IdentityService_ResetPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *IdentityService_ResetPassword_Call) RunAndReturn(run func(context.Context, string, string) error) *IdentityService_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *IdentityService) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdentityService_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type IdentityService_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sessionID string
+func (_e *IdentityService_Expecter) RevokeSession(ctx interface{}, userID interface{}, sessionID interface{}) *IdentityService_RevokeSession_Call {
+	return &IdentityService_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, userID, sessionID)}
+}
+
+func (_c *IdentityService_RevokeSession_Call) Run(run func(ctx context.Context, userID string, sessionID string)) *IdentityService_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_RevokeSession_Call) Return(err error) *IdentityService_RevokeSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *IdentityService_RevokeSession_Call) RunAndReturn(run func(context.Context, string, string) error) *IdentityService_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
 // Signin provides a mock function with given fields: ctx, username, password, opt
@@ -81,6 +1036,92 @@ func (_c *IdentityService_Signin_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
+// Signout provides a mock function with given fields: ctx, sessionID
+func (_m *IdentityService) Signout(ctx context.Context, sessionID string) error {
+	ret := _m.Called(ctx, sessionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdentityService_Signout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Signout'
+type IdentityService_Signout_Call struct {
+	*mock.Call
+}
+
+// Signout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionID string
+func (_e *IdentityService_Expecter) Signout(ctx interface{}, sessionID interface{}) *IdentityService_Signout_Call {
+	return &IdentityService_Signout_Call{Call: _e.mock.On("Signout", ctx, sessionID)}
+}
+
+func (_c *IdentityService_Signout_Call) Run(run func(ctx context.Context, sessionID string)) *IdentityService_Signout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_Signout_Call) Return(err error) *IdentityService_Signout_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *IdentityService_Signout_Call) RunAndReturn(run func(context.Context, string) error) *IdentityService_Signout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignoutAll provides a mock function with given fields: ctx, userID
+func (_m *IdentityService) SignoutAll(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdentityService_SignoutAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignoutAll'
+type IdentityService_SignoutAll_Call struct {
+	*mock.Call
+}
+
+// SignoutAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *IdentityService_Expecter) SignoutAll(ctx interface{}, userID interface{}) *IdentityService_SignoutAll_Call {
+	return &IdentityService_SignoutAll_Call{Call: _e.mock.On("SignoutAll", ctx, userID)}
+}
+
+func (_c *IdentityService_SignoutAll_Call) Run(run func(ctx context.Context, userID string)) *IdentityService_SignoutAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_SignoutAll_Call) Return(err error) *IdentityService_SignoutAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *IdentityService_SignoutAll_Call) RunAndReturn(run func(context.Context, string) error) *IdentityService_SignoutAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Signup provides a mock function with given fields: ctx, username, password, opts
 func (_m *IdentityService) Signup(ctx context.Context, username string, password string, opts *service.SignupOption) (*entity.Identity, error) {
 	ret := _m.Called(ctx, username, password, opts)
@@ -138,6 +1179,204 @@ func (_c *IdentityService_Signup_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
+// Unlock provides a mock function with given fields: ctx, userID
+func (_m *IdentityService) Unlock(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdentityService_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type IdentityService_Unlock_Call struct {
+	*mock.Call
+}
+
+// Unlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *IdentityService_Expecter) Unlock(ctx interface{}, userID interface{}) *IdentityService_Unlock_Call {
+	return &IdentityService_Unlock_Call{Call: _e.mock.On("Unlock", ctx, userID)}
+}
+
+func (_c *IdentityService_Unlock_Call) Run(run func(ctx context.Context, userID string)) *IdentityService_Unlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_Unlock_Call) Return(err error) *IdentityService_Unlock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *IdentityService_Unlock_Call) RunAndReturn(run func(context.Context, string) error) *IdentityService_Unlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, userID, opt
+func (_m *IdentityService) UpdateProfile(ctx context.Context, userID string, opt *service.UpdateProfileOption) (*entity.User, error) {
+	ret := _m.Called(ctx, userID, opt)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.UpdateProfileOption) (*entity.User, error)); ok {
+		return rf(ctx, userID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.UpdateProfileOption) *entity.User); ok {
+		r0 = rf(ctx, userID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *service.UpdateProfileOption) error); ok {
+		r1 = rf(ctx, userID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type IdentityService_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - opt *service.UpdateProfileOption
+func (_e *IdentityService_Expecter) UpdateProfile(ctx interface{}, userID interface{}, opt interface{}) *IdentityService_UpdateProfile_Call {
+	return &IdentityService_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, userID, opt)}
+}
+
+func (_c *IdentityService_UpdateProfile_Call) Run(run func(ctx context.Context, userID string, opt *service.UpdateProfileOption)) *IdentityService_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*service.UpdateProfileOption))
+	})
+	return _c
+}
+
+func (_c *IdentityService_UpdateProfile_Call) Return(user *entity.User, err error) *IdentityService_UpdateProfile_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *IdentityService_UpdateProfile_Call) RunAndReturn(run func(context.Context, string, *service.UpdateProfileOption) (*entity.User, error)) *IdentityService_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *IdentityService) VerifyEmail(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdentityService_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type IdentityService_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *IdentityService_Expecter) VerifyEmail(ctx interface{}, token interface{}) *IdentityService_VerifyEmail_Call {
+	return &IdentityService_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, token)}
+}
+
+func (_c *IdentityService_VerifyEmail_Call) Run(run func(ctx context.Context, token string)) *IdentityService_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_VerifyEmail_Call) Return(err error) *IdentityService_VerifyEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *IdentityService_VerifyEmail_Call) RunAndReturn(run func(context.Context, string) error) *IdentityService_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyMFA provides a mock function with given fields: ctx, mfaToken, code
+func (_m *IdentityService) VerifyMFA(ctx context.Context, mfaToken string, code string) (*entity.Identity, error) {
+	ret := _m.Called(ctx, mfaToken, code)
+
+	var r0 *entity.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Identity, error)); ok {
+		return rf(ctx, mfaToken, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Identity); ok {
+		r0 = rf(ctx, mfaToken, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, mfaToken, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdentityService_VerifyMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyMFA'
+type IdentityService_VerifyMFA_Call struct {
+	*mock.Call
+}
+
+// VerifyMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mfaToken string
+//   - code string
+func (_e *IdentityService_Expecter) VerifyMFA(ctx interface{}, mfaToken interface{}, code interface{}) *IdentityService_VerifyMFA_Call {
+	return &IdentityService_VerifyMFA_Call{Call: _e.mock.On("VerifyMFA", ctx, mfaToken, code)}
+}
+
+func (_c *IdentityService_VerifyMFA_Call) Run(run func(ctx context.Context, mfaToken string, code string)) *IdentityService_VerifyMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdentityService_VerifyMFA_Call) Return(identity *entity.Identity, err error) *IdentityService_VerifyMFA_Call {
+	_c.Call.Return(identity, err)
+	return _c
+}
+
+func (_c *IdentityService_VerifyMFA_Call) RunAndReturn(run func(context.Context, string, string) (*entity.Identity, error)) *IdentityService_VerifyMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 type mockConstructorTestingTNewIdentityService interface {
 	mock.TestingT
 	Cleanup(func())
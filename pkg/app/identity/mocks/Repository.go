@@ -7,6 +7,8 @@ import (
 
 	entity "github.com/karta0898098/iam/pkg/app/identity/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/karta0898098/iam/pkg/app/identity/repository"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -22,25 +24,112 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
-// FindUserByUsername provides a mock function with given fields: ctx, username
-func (_m *Repository) FindUserByUsername(ctx context.Context, username string) (*entity.User, error) {
-	ret := _m.Called(ctx, username)
+// ConfirmTOTPFactor provides a mock function with given fields: ctx, factor, recoveryCodeHashes
+func (_m *Repository) ConfirmTOTPFactor(ctx context.Context, factor *entity.TOTPFactor, recoveryCodeHashes []string) error {
+	ret := _m.Called(ctx, factor, recoveryCodeHashes)
 
-	var r0 *entity.User
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.TOTPFactor, []string) error); ok {
+		r0 = rf(ctx, factor, recoveryCodeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_ConfirmTOTPFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmTOTPFactor'
+type Repository_ConfirmTOTPFactor_Call struct {
+	*mock.Call
+}
+
+// ConfirmTOTPFactor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - factor *entity.TOTPFactor
+//   - recoveryCodeHashes []string
+func (_e *Repository_Expecter) ConfirmTOTPFactor(ctx interface{}, factor interface{}, recoveryCodeHashes interface{}) *Repository_ConfirmTOTPFactor_Call {
+	return &Repository_ConfirmTOTPFactor_Call{Call: _e.mock.On("ConfirmTOTPFactor", ctx, factor, recoveryCodeHashes)}
+}
+
+func (_c *Repository_ConfirmTOTPFactor_Call) Run(run func(ctx context.Context, factor *entity.TOTPFactor, recoveryCodeHashes []string)) *Repository_ConfirmTOTPFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.TOTPFactor), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *Repository_ConfirmTOTPFactor_Call) Return(err error) *Repository_ConfirmTOTPFactor_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_ConfirmTOTPFactor_Call) RunAndReturn(run func(context.Context, *entity.TOTPFactor, []string) error) *Repository_ConfirmTOTPFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmUserEmail provides a mock function with given fields: ctx, user
+func (_m *Repository) ConfirmUserEmail(ctx context.Context, user *entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_ConfirmUserEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmUserEmail'
+type Repository_ConfirmUserEmail_Call struct {
+	*mock.Call
+}
+
+// ConfirmUserEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *entity.User
+func (_e *Repository_Expecter) ConfirmUserEmail(ctx interface{}, user interface{}) *Repository_ConfirmUserEmail_Call {
+	return &Repository_ConfirmUserEmail_Call{Call: _e.mock.On("ConfirmUserEmail", ctx, user)}
+}
+
+func (_c *Repository_ConfirmUserEmail_Call) Run(run func(ctx context.Context, user *entity.User)) *Repository_ConfirmUserEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.User))
+	})
+	return _c
+}
+
+func (_c *Repository_ConfirmUserEmail_Call) Return(err error) *Repository_ConfirmUserEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_ConfirmUserEmail_Call) RunAndReturn(run func(context.Context, *entity.User) error) *Repository_ConfirmUserEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumeFederationState provides a mock function with given fields: ctx, stateID
+func (_m *Repository) ConsumeFederationState(ctx context.Context, stateID string) (*entity.FederationState, error) {
+	ret := _m.Called(ctx, stateID)
+
+	var r0 *entity.FederationState
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
-		return rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.FederationState, error)); ok {
+		return rf(ctx, stateID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
-		r0 = rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.FederationState); ok {
+		r0 = rf(ctx, stateID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.User)
+			r0 = ret.Get(0).(*entity.FederationState)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, username)
+		r1 = rf(ctx, stateID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -48,42 +137,42 @@ func (_m *Repository) FindUserByUsername(ctx context.Context, username string) (
 	return r0, r1
 }
 
-// Repository_FindUserByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserByUsername'
-type Repository_FindUserByUsername_Call struct {
+// Repository_ConsumeFederationState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeFederationState'
+type Repository_ConsumeFederationState_Call struct {
 	*mock.Call
 }
 
-// FindUserByUsername is a helper method to define mock.On call
+// ConsumeFederationState is a helper method to define mock.On call
 //   - ctx context.Context
-//   - username string
-func (_e *Repository_Expecter) FindUserByUsername(ctx interface{}, username interface{}) *Repository_FindUserByUsername_Call {
-	return &Repository_FindUserByUsername_Call{Call: _e.mock.On("FindUserByUsername", ctx, username)}
+//   - stateID string
+func (_e *Repository_Expecter) ConsumeFederationState(ctx interface{}, stateID interface{}) *Repository_ConsumeFederationState_Call {
+	return &Repository_ConsumeFederationState_Call{Call: _e.mock.On("ConsumeFederationState", ctx, stateID)}
 }
 
-func (_c *Repository_FindUserByUsername_Call) Run(run func(ctx context.Context, username string)) *Repository_FindUserByUsername_Call {
+func (_c *Repository_ConsumeFederationState_Call) Run(run func(ctx context.Context, stateID string)) *Repository_ConsumeFederationState_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_FindUserByUsername_Call) Return(profile *entity.User, err error) *Repository_FindUserByUsername_Call {
-	_c.Call.Return(profile, err)
+func (_c *Repository_ConsumeFederationState_Call) Return(state *entity.FederationState, err error) *Repository_ConsumeFederationState_Call {
+	_c.Call.Return(state, err)
 	return _c
 }
 
-func (_c *Repository_FindUserByUsername_Call) RunAndReturn(run func(context.Context, string) (*entity.User, error)) *Repository_FindUserByUsername_Call {
+func (_c *Repository_ConsumeFederationState_Call) RunAndReturn(run func(context.Context, string) (*entity.FederationState, error)) *Repository_ConsumeFederationState_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StoreSession provides a mock function with given fields: ctx, session
-func (_m *Repository) StoreSession(ctx context.Context, session *entity.Session) error {
-	ret := _m.Called(ctx, session)
+// ConsumePasswordResetToken provides a mock function with given fields: ctx, token
+func (_m *Repository) ConsumePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) error {
+	ret := _m.Called(ctx, token)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Session) error); ok {
-		r0 = rf(ctx, session)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PasswordResetToken) error); ok {
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -91,42 +180,42 @@ func (_m *Repository) StoreSession(ctx context.Context, session *entity.Session)
 	return r0
 }
 
-// Repository_StoreSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreSession'
-type Repository_StoreSession_Call struct {
+// Repository_ConsumePasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumePasswordResetToken'
+type Repository_ConsumePasswordResetToken_Call struct {
 	*mock.Call
 }
 
-// StoreSession is a helper method to define mock.On call
+// ConsumePasswordResetToken is a helper method to define mock.On call
 //   - ctx context.Context
-//   - session *entity.Session
-func (_e *Repository_Expecter) StoreSession(ctx interface{}, session interface{}) *Repository_StoreSession_Call {
-	return &Repository_StoreSession_Call{Call: _e.mock.On("StoreSession", ctx, session)}
+//   - token *entity.PasswordResetToken
+func (_e *Repository_Expecter) ConsumePasswordResetToken(ctx interface{}, token interface{}) *Repository_ConsumePasswordResetToken_Call {
+	return &Repository_ConsumePasswordResetToken_Call{Call: _e.mock.On("ConsumePasswordResetToken", ctx, token)}
 }
 
-func (_c *Repository_StoreSession_Call) Run(run func(ctx context.Context, session *entity.Session)) *Repository_StoreSession_Call {
+func (_c *Repository_ConsumePasswordResetToken_Call) Run(run func(ctx context.Context, token *entity.PasswordResetToken)) *Repository_ConsumePasswordResetToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.Session))
+		run(args[0].(context.Context), args[1].(*entity.PasswordResetToken))
 	})
 	return _c
 }
 
-func (_c *Repository_StoreSession_Call) Return(err error) *Repository_StoreSession_Call {
+func (_c *Repository_ConsumePasswordResetToken_Call) Return(err error) *Repository_ConsumePasswordResetToken_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Repository_StoreSession_Call) RunAndReturn(run func(context.Context, *entity.Session) error) *Repository_StoreSession_Call {
+func (_c *Repository_ConsumePasswordResetToken_Call) RunAndReturn(run func(context.Context, *entity.PasswordResetToken) error) *Repository_ConsumePasswordResetToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StoreUser provides a mock function with given fields: ctx, user
-func (_m *Repository) StoreUser(ctx context.Context, user *entity.User) error {
-	ret := _m.Called(ctx, user)
+// ConsumeRecoveryCode provides a mock function with given fields: ctx, userID, codeHash
+func (_m *Repository) ConsumeRecoveryCode(ctx context.Context, userID string, codeHash string) error {
+	ret := _m.Called(ctx, userID, codeHash)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
-		r0 = rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, codeHash)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -134,31 +223,1520 @@ func (_m *Repository) StoreUser(ctx context.Context, user *entity.User) error {
 	return r0
 }
 
-// Repository_StoreUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreUser'
-type Repository_StoreUser_Call struct {
+// Repository_ConsumeRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeRecoveryCode'
+type Repository_ConsumeRecoveryCode_Call struct {
 	*mock.Call
 }
 
-// StoreUser is a helper method to define mock.On call
+// ConsumeRecoveryCode is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user *entity.User
-func (_e *Repository_Expecter) StoreUser(ctx interface{}, user interface{}) *Repository_StoreUser_Call {
-	return &Repository_StoreUser_Call{Call: _e.mock.On("StoreUser", ctx, user)}
+//   - userID string
+//   - codeHash string
+func (_e *Repository_Expecter) ConsumeRecoveryCode(ctx interface{}, userID interface{}, codeHash interface{}) *Repository_ConsumeRecoveryCode_Call {
+	return &Repository_ConsumeRecoveryCode_Call{Call: _e.mock.On("ConsumeRecoveryCode", ctx, userID, codeHash)}
 }
 
-func (_c *Repository_StoreUser_Call) Run(run func(ctx context.Context, user *entity.User)) *Repository_StoreUser_Call {
+func (_c *Repository_ConsumeRecoveryCode_Call) Run(run func(ctx context.Context, userID string, codeHash string)) *Repository_ConsumeRecoveryCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.User))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_StoreUser_Call) Return(err error) *Repository_StoreUser_Call {
+func (_c *Repository_ConsumeRecoveryCode_Call) Return(err error) *Repository_ConsumeRecoveryCode_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Repository_StoreUser_Call) RunAndReturn(run func(context.Context, *entity.User) error) *Repository_StoreUser_Call {
+func (_c *Repository_ConsumeRecoveryCode_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_ConsumeRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumeWebAuthnChallenge provides a mock function with given fields: ctx, challengeID
+func (_m *Repository) ConsumeWebAuthnChallenge(ctx context.Context, challengeID string) (*entity.WebAuthnChallenge, error) {
+	ret := _m.Called(ctx, challengeID)
+
+	var r0 *entity.WebAuthnChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.WebAuthnChallenge, error)); ok {
+		return rf(ctx, challengeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.WebAuthnChallenge); ok {
+		r0 = rf(ctx, challengeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebAuthnChallenge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, challengeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ConsumeWebAuthnChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeWebAuthnChallenge'
+type Repository_ConsumeWebAuthnChallenge_Call struct {
+	*mock.Call
+}
+
+// ConsumeWebAuthnChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challengeID string
+func (_e *Repository_Expecter) ConsumeWebAuthnChallenge(ctx interface{}, challengeID interface{}) *Repository_ConsumeWebAuthnChallenge_Call {
+	return &Repository_ConsumeWebAuthnChallenge_Call{Call: _e.mock.On("ConsumeWebAuthnChallenge", ctx, challengeID)}
+}
+
+func (_c *Repository_ConsumeWebAuthnChallenge_Call) Run(run func(ctx context.Context, challengeID string)) *Repository_ConsumeWebAuthnChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ConsumeWebAuthnChallenge_Call) Return(challenge *entity.WebAuthnChallenge, err error) *Repository_ConsumeWebAuthnChallenge_Call {
+	_c.Call.Return(challenge, err)
+	return _c
+}
+
+func (_c *Repository_ConsumeWebAuthnChallenge_Call) RunAndReturn(run func(context.Context, string) (*entity.WebAuthnChallenge, error)) *Repository_ConsumeWebAuthnChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function with given fields: ctx, userID
+func (_m *Repository) DeleteUser(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type Repository_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) DeleteUser(ctx interface{}, userID interface{}) *Repository_DeleteUser_Call {
+	return &Repository_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, userID)}
+}
+
+func (_c *Repository_DeleteUser_Call) Run(run func(ctx context.Context, userID string)) *Repository_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteUser_Call) Return(err error) *Repository_DeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteUser_Call) RunAndReturn(run func(context.Context, string) error) *Repository_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindExternalIdentity provides a mock function with given fields: ctx, provider, subject
+func (_m *Repository) FindExternalIdentity(ctx context.Context, provider string, subject string) (*entity.ExternalIdentity, error) {
+	ret := _m.Called(ctx, provider, subject)
+
+	var r0 *entity.ExternalIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.ExternalIdentity, error)); ok {
+		return rf(ctx, provider, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.ExternalIdentity); ok {
+		r0 = rf(ctx, provider, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ExternalIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindExternalIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindExternalIdentity'
+type Repository_FindExternalIdentity_Call struct {
+	*mock.Call
+}
+
+// FindExternalIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider string
+//   - subject string
+func (_e *Repository_Expecter) FindExternalIdentity(ctx interface{}, provider interface{}, subject interface{}) *Repository_FindExternalIdentity_Call {
+	return &Repository_FindExternalIdentity_Call{Call: _e.mock.On("FindExternalIdentity", ctx, provider, subject)}
+}
+
+func (_c *Repository_FindExternalIdentity_Call) Run(run func(ctx context.Context, provider string, subject string)) *Repository_FindExternalIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindExternalIdentity_Call) Return(identity *entity.ExternalIdentity, err error) *Repository_FindExternalIdentity_Call {
+	_c.Call.Return(identity, err)
+	return _c
+}
+
+func (_c *Repository_FindExternalIdentity_Call) RunAndReturn(run func(context.Context, string, string) (*entity.ExternalIdentity, error)) *Repository_FindExternalIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPasswordResetToken provides a mock function with given fields: ctx, tokenID
+func (_m *Repository) FindPasswordResetToken(ctx context.Context, tokenID string) (*entity.PasswordResetToken, error) {
+	ret := _m.Called(ctx, tokenID)
+
+	var r0 *entity.PasswordResetToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PasswordResetToken, error)); ok {
+		return rf(ctx, tokenID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PasswordResetToken); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PasswordResetToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindPasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPasswordResetToken'
+type Repository_FindPasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// FindPasswordResetToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenID string
+func (_e *Repository_Expecter) FindPasswordResetToken(ctx interface{}, tokenID interface{}) *Repository_FindPasswordResetToken_Call {
+	return &Repository_FindPasswordResetToken_Call{Call: _e.mock.On("FindPasswordResetToken", ctx, tokenID)}
+}
+
+func (_c *Repository_FindPasswordResetToken_Call) Run(run func(ctx context.Context, tokenID string)) *Repository_FindPasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindPasswordResetToken_Call) Return(token *entity.PasswordResetToken, err error) *Repository_FindPasswordResetToken_Call {
+	_c.Call.Return(token, err)
+	return _c
+}
+
+func (_c *Repository_FindPasswordResetToken_Call) RunAndReturn(run func(context.Context, string) (*entity.PasswordResetToken, error)) *Repository_FindPasswordResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindSessionByID provides a mock function with given fields: ctx, sessionID
+func (_m *Repository) FindSessionByID(ctx context.Context, sessionID string) (*entity.Session, error) {
+	ret := _m.Called(ctx, sessionID)
+
+	var r0 *entity.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Session, error)); ok {
+		return rf(ctx, sessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Session); ok {
+		r0 = rf(ctx, sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindSessionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSessionByID'
+type Repository_FindSessionByID_Call struct {
+	*mock.Call
+}
+
+// FindSessionByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionID string
+func (_e *Repository_Expecter) FindSessionByID(ctx interface{}, sessionID interface{}) *Repository_FindSessionByID_Call {
+	return &Repository_FindSessionByID_Call{Call: _e.mock.On("FindSessionByID", ctx, sessionID)}
+}
+
+func (_c *Repository_FindSessionByID_Call) Run(run func(ctx context.Context, sessionID string)) *Repository_FindSessionByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindSessionByID_Call) Return(session *entity.Session, err error) *Repository_FindSessionByID_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *Repository_FindSessionByID_Call) RunAndReturn(run func(context.Context, string) (*entity.Session, error)) *Repository_FindSessionByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTOTPFactor provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindTOTPFactor(ctx context.Context, userID string) (*entity.TOTPFactor, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *entity.TOTPFactor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.TOTPFactor, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.TOTPFactor); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TOTPFactor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindTOTPFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTOTPFactor'
+type Repository_FindTOTPFactor_Call struct {
+	*mock.Call
+}
+
+// FindTOTPFactor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindTOTPFactor(ctx interface{}, userID interface{}) *Repository_FindTOTPFactor_Call {
+	return &Repository_FindTOTPFactor_Call{Call: _e.mock.On("FindTOTPFactor", ctx, userID)}
+}
+
+func (_c *Repository_FindTOTPFactor_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindTOTPFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindTOTPFactor_Call) Return(factor *entity.TOTPFactor, err error) *Repository_FindTOTPFactor_Call {
+	_c.Call.Return(factor, err)
+	return _c
+}
+
+func (_c *Repository_FindTOTPFactor_Call) RunAndReturn(run func(context.Context, string) (*entity.TOTPFactor, error)) *Repository_FindTOTPFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUserByID provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindUserByID(ctx context.Context, userID string) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserByID'
+type Repository_FindUserByID_Call struct {
+	*mock.Call
+}
+
+// FindUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindUserByID(ctx interface{}, userID interface{}) *Repository_FindUserByID_Call {
+	return &Repository_FindUserByID_Call{Call: _e.mock.On("FindUserByID", ctx, userID)}
+}
+
+func (_c *Repository_FindUserByID_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUserByID_Call) Return(profile *entity.User, err error) *Repository_FindUserByID_Call {
+	_c.Call.Return(profile, err)
+	return _c
+}
+
+func (_c *Repository_FindUserByID_Call) RunAndReturn(run func(context.Context, string) (*entity.User, error)) *Repository_FindUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUserByUsername provides a mock function with given fields: ctx, username
+func (_m *Repository) FindUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUserByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserByUsername'
+type Repository_FindUserByUsername_Call struct {
+	*mock.Call
+}
+
+// FindUserByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *Repository_Expecter) FindUserByUsername(ctx interface{}, username interface{}) *Repository_FindUserByUsername_Call {
+	return &Repository_FindUserByUsername_Call{Call: _e.mock.On("FindUserByUsername", ctx, username)}
+}
+
+func (_c *Repository_FindUserByUsername_Call) Run(run func(ctx context.Context, username string)) *Repository_FindUserByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUserByUsername_Call) Return(profile *entity.User, err error) *Repository_FindUserByUsername_Call {
+	_c.Call.Return(profile, err)
+	return _c
+}
+
+func (_c *Repository_FindUserByUsername_Call) RunAndReturn(run func(context.Context, string) (*entity.User, error)) *Repository_FindUserByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUsersByEmail provides a mock function with given fields: ctx, email
+func (_m *Repository) FindUsersByEmail(ctx context.Context, email string) ([]*entity.User, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 []*entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUsersByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUsersByEmail'
+type Repository_FindUsersByEmail_Call struct {
+	*mock.Call
+}
+
+// FindUsersByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *Repository_Expecter) FindUsersByEmail(ctx interface{}, email interface{}) *Repository_FindUsersByEmail_Call {
+	return &Repository_FindUsersByEmail_Call{Call: _e.mock.On("FindUsersByEmail", ctx, email)}
+}
+
+func (_c *Repository_FindUsersByEmail_Call) Run(run func(ctx context.Context, email string)) *Repository_FindUsersByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUsersByEmail_Call) Return(users []*entity.User, err error) *Repository_FindUsersByEmail_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *Repository_FindUsersByEmail_Call) RunAndReturn(run func(context.Context, string) ([]*entity.User, error)) *Repository_FindUsersByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindWebAuthnCredential provides a mock function with given fields: ctx, credentialID
+func (_m *Repository) FindWebAuthnCredential(ctx context.Context, credentialID string) (*entity.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, credentialID)
+
+	var r0 *entity.WebAuthnCredential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.WebAuthnCredential, error)); ok {
+		return rf(ctx, credentialID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.WebAuthnCredential); ok {
+		r0 = rf(ctx, credentialID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebAuthnCredential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, credentialID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindWebAuthnCredential_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindWebAuthnCredential'
+type Repository_FindWebAuthnCredential_Call struct {
+	*mock.Call
+}
+
+// FindWebAuthnCredential is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credentialID string
+func (_e *Repository_Expecter) FindWebAuthnCredential(ctx interface{}, credentialID interface{}) *Repository_FindWebAuthnCredential_Call {
+	return &Repository_FindWebAuthnCredential_Call{Call: _e.mock.On("FindWebAuthnCredential", ctx, credentialID)}
+}
+
+func (_c *Repository_FindWebAuthnCredential_Call) Run(run func(ctx context.Context, credentialID string)) *Repository_FindWebAuthnCredential_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindWebAuthnCredential_Call) Return(credential *entity.WebAuthnCredential, err error) *Repository_FindWebAuthnCredential_Call {
+	_c.Call.Return(credential, err)
+	return _c
+}
+
+func (_c *Repository_FindWebAuthnCredential_Call) RunAndReturn(run func(context.Context, string) (*entity.WebAuthnCredential, error)) *Repository_FindWebAuthnCredential_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActiveSessions provides a mock function with given fields: ctx, userID
+func (_m *Repository) ListActiveSessions(ctx context.Context, userID string) ([]*entity.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*entity.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListActiveSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveSessions'
+type Repository_ListActiveSessions_Call struct {
+	*mock.Call
+}
+
+// ListActiveSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) ListActiveSessions(ctx interface{}, userID interface{}) *Repository_ListActiveSessions_Call {
+	return &Repository_ListActiveSessions_Call{Call: _e.mock.On("ListActiveSessions", ctx, userID)}
+}
+
+func (_c *Repository_ListActiveSessions_Call) Run(run func(ctx context.Context, userID string)) *Repository_ListActiveSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ListActiveSessions_Call) Return(sessions []*entity.Session, err error) *Repository_ListActiveSessions_Call {
+	_c.Call.Return(sessions, err)
+	return _c
+}
+
+func (_c *Repository_ListActiveSessions_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Session, error)) *Repository_ListActiveSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function with given fields: ctx, query
+func (_m *Repository) ListUsers(ctx context.Context, query *repository.UserQuery) ([]*entity.User, int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*entity.User
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.UserQuery) ([]*entity.User, int64, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.UserQuery) []*entity.User); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.UserQuery) int64); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *repository.UserQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Repository_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type Repository_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *repository.UserQuery
+func (_e *Repository_Expecter) ListUsers(ctx interface{}, query interface{}) *Repository_ListUsers_Call {
+	return &Repository_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, query)}
+}
+
+func (_c *Repository_ListUsers_Call) Run(run func(ctx context.Context, query *repository.UserQuery)) *Repository_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*repository.UserQuery))
+	})
+	return _c
+}
+
+func (_c *Repository_ListUsers_Call) Return(users []*entity.User, total int64, err error) *Repository_ListUsers_Call {
+	_c.Call.Return(users, total, err)
+	return _c
+}
+
+func (_c *Repository_ListUsers_Call) RunAndReturn(run func(context.Context, *repository.UserQuery) ([]*entity.User, int64, error)) *Repository_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebAuthnCredentials provides a mock function with given fields: ctx, userID
+func (_m *Repository) ListWebAuthnCredentials(ctx context.Context, userID string) ([]*entity.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*entity.WebAuthnCredential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.WebAuthnCredential, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.WebAuthnCredential); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.WebAuthnCredential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListWebAuthnCredentials_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebAuthnCredentials'
+type Repository_ListWebAuthnCredentials_Call struct {
+	*mock.Call
+}
+
+// ListWebAuthnCredentials is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) ListWebAuthnCredentials(ctx interface{}, userID interface{}) *Repository_ListWebAuthnCredentials_Call {
+	return &Repository_ListWebAuthnCredentials_Call{Call: _e.mock.On("ListWebAuthnCredentials", ctx, userID)}
+}
+
+func (_c *Repository_ListWebAuthnCredentials_Call) Run(run func(ctx context.Context, userID string)) *Repository_ListWebAuthnCredentials_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ListWebAuthnCredentials_Call) Return(credentials []*entity.WebAuthnCredential, err error) *Repository_ListWebAuthnCredentials_Call {
+	_c.Call.Return(credentials, err)
+	return _c
+}
+
+func (_c *Repository_ListWebAuthnCredentials_Call) RunAndReturn(run func(context.Context, string) ([]*entity.WebAuthnCredential, error)) *Repository_ListWebAuthnCredentials_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeClientSessions provides a mock function with given fields: ctx, clientID
+func (_m *Repository) RevokeClientSessions(ctx context.Context, clientID string) error {
+	ret := _m.Called(ctx, clientID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_RevokeClientSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeClientSessions'
+type Repository_RevokeClientSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeClientSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+func (_e *Repository_Expecter) RevokeClientSessions(ctx interface{}, clientID interface{}) *Repository_RevokeClientSessions_Call {
+	return &Repository_RevokeClientSessions_Call{Call: _e.mock.On("RevokeClientSessions", ctx, clientID)}
+}
+
+func (_c *Repository_RevokeClientSessions_Call) Run(run func(ctx context.Context, clientID string)) *Repository_RevokeClientSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_RevokeClientSessions_Call) Return(err error) *Repository_RevokeClientSessions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_RevokeClientSessions_Call) RunAndReturn(run func(context.Context, string) error) *Repository_RevokeClientSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSessionFamily provides a mock function with given fields: ctx, familyID
+func (_m *Repository) RevokeSessionFamily(ctx context.Context, familyID string) error {
+	ret := _m.Called(ctx, familyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_RevokeSessionFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessionFamily'
+type Repository_RevokeSessionFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeSessionFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID string
+func (_e *Repository_Expecter) RevokeSessionFamily(ctx interface{}, familyID interface{}) *Repository_RevokeSessionFamily_Call {
+	return &Repository_RevokeSessionFamily_Call{Call: _e.mock.On("RevokeSessionFamily", ctx, familyID)}
+}
+
+func (_c *Repository_RevokeSessionFamily_Call) Run(run func(ctx context.Context, familyID string)) *Repository_RevokeSessionFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_RevokeSessionFamily_Call) Return(err error) *Repository_RevokeSessionFamily_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_RevokeSessionFamily_Call) RunAndReturn(run func(context.Context, string) error) *Repository_RevokeSessionFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserSessions provides a mock function with given fields: ctx, userID
+func (_m *Repository) RevokeUserSessions(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_RevokeUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserSessions'
+type Repository_RevokeUserSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeUserSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) RevokeUserSessions(ctx interface{}, userID interface{}) *Repository_RevokeUserSessions_Call {
+	return &Repository_RevokeUserSessions_Call{Call: _e.mock.On("RevokeUserSessions", ctx, userID)}
+}
+
+func (_c *Repository_RevokeUserSessions_Call) Run(run func(ctx context.Context, userID string)) *Repository_RevokeUserSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_RevokeUserSessions_Call) Return(err error) *Repository_RevokeUserSessions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_RevokeUserSessions_Call) RunAndReturn(run func(context.Context, string) error) *Repository_RevokeUserSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateSession provides a mock function with given fields: ctx, current, next
+func (_m *Repository) RotateSession(ctx context.Context, current *entity.Session, next *entity.Session) error {
+	ret := _m.Called(ctx, current, next)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Session, *entity.Session) error); ok {
+		r0 = rf(ctx, current, next)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_RotateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateSession'
+type Repository_RotateSession_Call struct {
+	*mock.Call
+}
+
+// RotateSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - current *entity.Session
+//   - next *entity.Session
+func (_e *Repository_Expecter) RotateSession(ctx interface{}, current interface{}, next interface{}) *Repository_RotateSession_Call {
+	return &Repository_RotateSession_Call{Call: _e.mock.On("RotateSession", ctx, current, next)}
+}
+
+func (_c *Repository_RotateSession_Call) Run(run func(ctx context.Context, current *entity.Session, next *entity.Session)) *Repository_RotateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Session), args[2].(*entity.Session))
+	})
+	return _c
+}
+
+func (_c *Repository_RotateSession_Call) Return(err error) *Repository_RotateSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_RotateSession_Call) RunAndReturn(run func(context.Context, *entity.Session, *entity.Session) error) *Repository_RotateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreExternalIdentity provides a mock function with given fields: ctx, identity
+func (_m *Repository) StoreExternalIdentity(ctx context.Context, identity *entity.ExternalIdentity) error {
+	ret := _m.Called(ctx, identity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ExternalIdentity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreExternalIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreExternalIdentity'
+type Repository_StoreExternalIdentity_Call struct {
+	*mock.Call
+}
+
+// StoreExternalIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identity *entity.ExternalIdentity
+func (_e *Repository_Expecter) StoreExternalIdentity(ctx interface{}, identity interface{}) *Repository_StoreExternalIdentity_Call {
+	return &Repository_StoreExternalIdentity_Call{Call: _e.mock.On("StoreExternalIdentity", ctx, identity)}
+}
+
+func (_c *Repository_StoreExternalIdentity_Call) Run(run func(ctx context.Context, identity *entity.ExternalIdentity)) *Repository_StoreExternalIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.ExternalIdentity))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreExternalIdentity_Call) Return(err error) *Repository_StoreExternalIdentity_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreExternalIdentity_Call) RunAndReturn(run func(context.Context, *entity.ExternalIdentity) error) *Repository_StoreExternalIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreFederationState provides a mock function with given fields: ctx, state
+func (_m *Repository) StoreFederationState(ctx context.Context, state *entity.FederationState) error {
+	ret := _m.Called(ctx, state)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.FederationState) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreFederationState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreFederationState'
+type Repository_StoreFederationState_Call struct {
+	*mock.Call
+}
+
+// StoreFederationState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - state *entity.FederationState
+func (_e *Repository_Expecter) StoreFederationState(ctx interface{}, state interface{}) *Repository_StoreFederationState_Call {
+	return &Repository_StoreFederationState_Call{Call: _e.mock.On("StoreFederationState", ctx, state)}
+}
+
+func (_c *Repository_StoreFederationState_Call) Run(run func(ctx context.Context, state *entity.FederationState)) *Repository_StoreFederationState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.FederationState))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreFederationState_Call) Return(err error) *Repository_StoreFederationState_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreFederationState_Call) RunAndReturn(run func(context.Context, *entity.FederationState) error) *Repository_StoreFederationState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StorePasswordResetToken provides a mock function with given fields: ctx, token
+func (_m *Repository) StorePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) error {
+	ret := _m.Called(ctx, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PasswordResetToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StorePasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StorePasswordResetToken'
+type Repository_StorePasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// StorePasswordResetToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *entity.PasswordResetToken
+func (_e *Repository_Expecter) StorePasswordResetToken(ctx interface{}, token interface{}) *Repository_StorePasswordResetToken_Call {
+	return &Repository_StorePasswordResetToken_Call{Call: _e.mock.On("StorePasswordResetToken", ctx, token)}
+}
+
+func (_c *Repository_StorePasswordResetToken_Call) Run(run func(ctx context.Context, token *entity.PasswordResetToken)) *Repository_StorePasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.PasswordResetToken))
+	})
+	return _c
+}
+
+func (_c *Repository_StorePasswordResetToken_Call) Return(err error) *Repository_StorePasswordResetToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StorePasswordResetToken_Call) RunAndReturn(run func(context.Context, *entity.PasswordResetToken) error) *Repository_StorePasswordResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreSession provides a mock function with given fields: ctx, session
+func (_m *Repository) StoreSession(ctx context.Context, session *entity.Session) error {
+	ret := _m.Called(ctx, session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreSession'
+type Repository_StoreSession_Call struct {
+	*mock.Call
+}
+
+// StoreSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session *entity.Session
+func (_e *Repository_Expecter) StoreSession(ctx interface{}, session interface{}) *Repository_StoreSession_Call {
+	return &Repository_StoreSession_Call{Call: _e.mock.On("StoreSession", ctx, session)}
+}
+
+func (_c *Repository_StoreSession_Call) Run(run func(ctx context.Context, session *entity.Session)) *Repository_StoreSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Session))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreSession_Call) Return(err error) *Repository_StoreSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreSession_Call) RunAndReturn(run func(context.Context, *entity.Session) error) *Repository_StoreSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreTOTPFactor provides a mock function with given fields: ctx, factor
+func (_m *Repository) StoreTOTPFactor(ctx context.Context, factor *entity.TOTPFactor) error {
+	ret := _m.Called(ctx, factor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.TOTPFactor) error); ok {
+		r0 = rf(ctx, factor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreTOTPFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreTOTPFactor'
+type Repository_StoreTOTPFactor_Call struct {
+	*mock.Call
+}
+
+// StoreTOTPFactor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - factor *entity.TOTPFactor
+func (_e *Repository_Expecter) StoreTOTPFactor(ctx interface{}, factor interface{}) *Repository_StoreTOTPFactor_Call {
+	return &Repository_StoreTOTPFactor_Call{Call: _e.mock.On("StoreTOTPFactor", ctx, factor)}
+}
+
+func (_c *Repository_StoreTOTPFactor_Call) Run(run func(ctx context.Context, factor *entity.TOTPFactor)) *Repository_StoreTOTPFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.TOTPFactor))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreTOTPFactor_Call) Return(err error) *Repository_StoreTOTPFactor_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreTOTPFactor_Call) RunAndReturn(run func(context.Context, *entity.TOTPFactor) error) *Repository_StoreTOTPFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreUser provides a mock function with given fields: ctx, user
+func (_m *Repository) StoreUser(ctx context.Context, user *entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreUser'
+type Repository_StoreUser_Call struct {
+	*mock.Call
+}
+
+// StoreUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *entity.User
+func (_e *Repository_Expecter) StoreUser(ctx interface{}, user interface{}) *Repository_StoreUser_Call {
+	return &Repository_StoreUser_Call{Call: _e.mock.On("StoreUser", ctx, user)}
+}
+
+func (_c *Repository_StoreUser_Call) Run(run func(ctx context.Context, user *entity.User)) *Repository_StoreUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.User))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreUser_Call) Return(err error) *Repository_StoreUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreUser_Call) RunAndReturn(run func(context.Context, *entity.User) error) *Repository_StoreUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreWebAuthnChallenge provides a mock function with given fields: ctx, challenge
+func (_m *Repository) StoreWebAuthnChallenge(ctx context.Context, challenge *entity.WebAuthnChallenge) error {
+	ret := _m.Called(ctx, challenge)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.WebAuthnChallenge) error); ok {
+		r0 = rf(ctx, challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreWebAuthnChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreWebAuthnChallenge'
+type Repository_StoreWebAuthnChallenge_Call struct {
+	*mock.Call
+}
+
+// StoreWebAuthnChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challenge *entity.WebAuthnChallenge
+func (_e *Repository_Expecter) StoreWebAuthnChallenge(ctx interface{}, challenge interface{}) *Repository_StoreWebAuthnChallenge_Call {
+	return &Repository_StoreWebAuthnChallenge_Call{Call: _e.mock.On("StoreWebAuthnChallenge", ctx, challenge)}
+}
+
+func (_c *Repository_StoreWebAuthnChallenge_Call) Run(run func(ctx context.Context, challenge *entity.WebAuthnChallenge)) *Repository_StoreWebAuthnChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.WebAuthnChallenge))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreWebAuthnChallenge_Call) Return(err error) *Repository_StoreWebAuthnChallenge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreWebAuthnChallenge_Call) RunAndReturn(run func(context.Context, *entity.WebAuthnChallenge) error) *Repository_StoreWebAuthnChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreWebAuthnCredential provides a mock function with given fields: ctx, credential
+func (_m *Repository) StoreWebAuthnCredential(ctx context.Context, credential *entity.WebAuthnCredential) error {
+	ret := _m.Called(ctx, credential)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.WebAuthnCredential) error); ok {
+		r0 = rf(ctx, credential)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreWebAuthnCredential_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreWebAuthnCredential'
+type Repository_StoreWebAuthnCredential_Call struct {
+	*mock.Call
+}
+
+// StoreWebAuthnCredential is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credential *entity.WebAuthnCredential
+func (_e *Repository_Expecter) StoreWebAuthnCredential(ctx interface{}, credential interface{}) *Repository_StoreWebAuthnCredential_Call {
+	return &Repository_StoreWebAuthnCredential_Call{Call: _e.mock.On("StoreWebAuthnCredential", ctx, credential)}
+}
+
+func (_c *Repository_StoreWebAuthnCredential_Call) Run(run func(ctx context.Context, credential *entity.WebAuthnCredential)) *Repository_StoreWebAuthnCredential_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.WebAuthnCredential))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreWebAuthnCredential_Call) Return(err error) *Repository_StoreWebAuthnCredential_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreWebAuthnCredential_Call) RunAndReturn(run func(context.Context, *entity.WebAuthnCredential) error) *Repository_StoreWebAuthnCredential_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateExternalIdentity provides a mock function with given fields: ctx, identity
+func (_m *Repository) UpdateExternalIdentity(ctx context.Context, identity *entity.ExternalIdentity) error {
+	ret := _m.Called(ctx, identity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ExternalIdentity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateExternalIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateExternalIdentity'
+type Repository_UpdateExternalIdentity_Call struct {
+	*mock.Call
+}
+
+// UpdateExternalIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identity *entity.ExternalIdentity
+func (_e *Repository_Expecter) UpdateExternalIdentity(ctx interface{}, identity interface{}) *Repository_UpdateExternalIdentity_Call {
+	return &Repository_UpdateExternalIdentity_Call{Call: _e.mock.On("UpdateExternalIdentity", ctx, identity)}
+}
+
+func (_c *Repository_UpdateExternalIdentity_Call) Run(run func(ctx context.Context, identity *entity.ExternalIdentity)) *Repository_UpdateExternalIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.ExternalIdentity))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateExternalIdentity_Call) Return(err error) *Repository_UpdateExternalIdentity_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateExternalIdentity_Call) RunAndReturn(run func(context.Context, *entity.ExternalIdentity) error) *Repository_UpdateExternalIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, user
+func (_m *Repository) UpdatePassword(ctx context.Context, user *entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type Repository_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *entity.User
+func (_e *Repository_Expecter) UpdatePassword(ctx interface{}, user interface{}) *Repository_UpdatePassword_Call {
+	return &Repository_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, user)}
+}
+
+func (_c *Repository_UpdatePassword_Call) Run(run func(ctx context.Context, user *entity.User)) *Repository_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.User))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdatePassword_Call) Return(err error) *Repository_UpdatePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdatePassword_Call) RunAndReturn(run func(context.Context, *entity.User) error) *Repository_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTOTPCounter provides a mock function with given fields: ctx, factor
+func (_m *Repository) UpdateTOTPCounter(ctx context.Context, factor *entity.TOTPFactor) error {
+	ret := _m.Called(ctx, factor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.TOTPFactor) error); ok {
+		r0 = rf(ctx, factor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateTOTPCounter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTOTPCounter'
+type Repository_UpdateTOTPCounter_Call struct {
+	*mock.Call
+}
+
+// UpdateTOTPCounter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - factor *entity.TOTPFactor
+func (_e *Repository_Expecter) UpdateTOTPCounter(ctx interface{}, factor interface{}) *Repository_UpdateTOTPCounter_Call {
+	return &Repository_UpdateTOTPCounter_Call{Call: _e.mock.On("UpdateTOTPCounter", ctx, factor)}
+}
+
+func (_c *Repository_UpdateTOTPCounter_Call) Run(run func(ctx context.Context, factor *entity.TOTPFactor)) *Repository_UpdateTOTPCounter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.TOTPFactor))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateTOTPCounter_Call) Return(err error) *Repository_UpdateTOTPCounter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateTOTPCounter_Call) RunAndReturn(run func(context.Context, *entity.TOTPFactor) error) *Repository_UpdateTOTPCounter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user, version
+func (_m *Repository) UpdateUser(ctx context.Context, user *entity.User, version int64) error {
+	ret := _m.Called(ctx, user, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User, int64) error); ok {
+		r0 = rf(ctx, user, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type Repository_UpdateUser_Call struct {
+	*mock.Call
+}
+
+// UpdateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *entity.User
+//   - version int64
+func (_e *Repository_Expecter) UpdateUser(ctx interface{}, user interface{}, version interface{}) *Repository_UpdateUser_Call {
+	return &Repository_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, user, version)}
+}
+
+func (_c *Repository_UpdateUser_Call) Run(run func(ctx context.Context, user *entity.User, version int64)) *Repository_UpdateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.User), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateUser_Call) Return(err error) *Repository_UpdateUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateUser_Call) RunAndReturn(run func(context.Context, *entity.User, int64) error) *Repository_UpdateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserStatus provides a mock function with given fields: ctx, user, from
+func (_m *Repository) UpdateUserStatus(ctx context.Context, user *entity.User, from entity.UserAccountStatus) error {
+	ret := _m.Called(ctx, user, from)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User, entity.UserAccountStatus) error); ok {
+		r0 = rf(ctx, user, from)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateUserStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserStatus'
+type Repository_UpdateUserStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateUserStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *entity.User
+//   - from entity.UserAccountStatus
+func (_e *Repository_Expecter) UpdateUserStatus(ctx interface{}, user interface{}, from interface{}) *Repository_UpdateUserStatus_Call {
+	return &Repository_UpdateUserStatus_Call{Call: _e.mock.On("UpdateUserStatus", ctx, user, from)}
+}
+
+func (_c *Repository_UpdateUserStatus_Call) Run(run func(ctx context.Context, user *entity.User, from entity.UserAccountStatus)) *Repository_UpdateUserStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.User), args[2].(entity.UserAccountStatus))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateUserStatus_Call) Return(err error) *Repository_UpdateUserStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateUserStatus_Call) RunAndReturn(run func(context.Context, *entity.User, entity.UserAccountStatus) error) *Repository_UpdateUserStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWebAuthnSignCount provides a mock function with given fields: ctx, credential
+func (_m *Repository) UpdateWebAuthnSignCount(ctx context.Context, credential *entity.WebAuthnCredential) error {
+	ret := _m.Called(ctx, credential)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.WebAuthnCredential) error); ok {
+		r0 = rf(ctx, credential)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateWebAuthnSignCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebAuthnSignCount'
+type Repository_UpdateWebAuthnSignCount_Call struct {
+	*mock.Call
+}
+
+// UpdateWebAuthnSignCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credential *entity.WebAuthnCredential
+func (_e *Repository_Expecter) UpdateWebAuthnSignCount(ctx interface{}, credential interface{}) *Repository_UpdateWebAuthnSignCount_Call {
+	return &Repository_UpdateWebAuthnSignCount_Call{Call: _e.mock.On("UpdateWebAuthnSignCount", ctx, credential)}
+}
+
+func (_c *Repository_UpdateWebAuthnSignCount_Call) Run(run func(ctx context.Context, credential *entity.WebAuthnCredential)) *Repository_UpdateWebAuthnSignCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.WebAuthnCredential))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateWebAuthnSignCount_Call) Return(err error) *Repository_UpdateWebAuthnSignCount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateWebAuthnSignCount_Call) RunAndReturn(run func(context.Context, *entity.WebAuthnCredential) error) *Repository_UpdateWebAuthnSignCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RoleResolver is an autogenerated mock type for the RoleResolver type
+type RoleResolver struct {
+	mock.Mock
+}
+
+type RoleResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RoleResolver) EXPECT() *RoleResolver_Expecter {
+	return &RoleResolver_Expecter{mock: &_m.Mock}
+}
+
+// UserRoles provides a mock function with given fields: ctx, userID
+func (_m *RoleResolver) UserRoles(ctx context.Context, userID string) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RoleResolver_UserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserRoles'
+type RoleResolver_UserRoles_Call struct {
+	*mock.Call
+}
+
+// UserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *RoleResolver_Expecter) UserRoles(ctx interface{}, userID interface{}) *RoleResolver_UserRoles_Call {
+	return &RoleResolver_UserRoles_Call{Call: _e.mock.On("UserRoles", ctx, userID)}
+}
+
+func (_c *RoleResolver_UserRoles_Call) Run(run func(ctx context.Context, userID string)) *RoleResolver_UserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RoleResolver_UserRoles_Call) Return(roles []string, err error) *RoleResolver_UserRoles_Call {
+	_c.Call.Return(roles, err)
+	return _c
+}
+
+func (_c *RoleResolver_UserRoles_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *RoleResolver_UserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewRoleResolver interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRoleResolver creates a new instance of RoleResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRoleResolver(t mockConstructorTestingTNewRoleResolver) *RoleResolver {
+	mock := &RoleResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,134 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TenantDirectory is an autogenerated mock type for the TenantDirectory type
+type TenantDirectory struct {
+	mock.Mock
+}
+
+type TenantDirectory_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TenantDirectory) EXPECT() *TenantDirectory_Expecter {
+	return &TenantDirectory_Expecter{mock: &_m.Mock}
+}
+
+// JoinTenant provides a mock function with given fields: ctx, tenantID, userID
+func (_m *TenantDirectory) JoinTenant(ctx context.Context, tenantID string, userID string) error {
+	ret := _m.Called(ctx, tenantID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenantID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TenantDirectory_JoinTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JoinTenant'
+type TenantDirectory_JoinTenant_Call struct {
+	*mock.Call
+}
+
+// JoinTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - userID string
+func (_e *TenantDirectory_Expecter) JoinTenant(ctx interface{}, tenantID interface{}, userID interface{}) *TenantDirectory_JoinTenant_Call {
+	return &TenantDirectory_JoinTenant_Call{Call: _e.mock.On("JoinTenant", ctx, tenantID, userID)}
+}
+
+func (_c *TenantDirectory_JoinTenant_Call) Run(run func(ctx context.Context, tenantID string, userID string)) *TenantDirectory_JoinTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TenantDirectory_JoinTenant_Call) Return(err error) *TenantDirectory_JoinTenant_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TenantDirectory_JoinTenant_Call) RunAndReturn(run func(context.Context, string, string) error) *TenantDirectory_JoinTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveTenant provides a mock function with given fields: ctx, name
+func (_m *TenantDirectory) ResolveTenant(ctx context.Context, name string) (string, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantDirectory_ResolveTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveTenant'
+type TenantDirectory_ResolveTenant_Call struct {
+	*mock.Call
+}
+
+// ResolveTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *TenantDirectory_Expecter) ResolveTenant(ctx interface{}, name interface{}) *TenantDirectory_ResolveTenant_Call {
+	return &TenantDirectory_ResolveTenant_Call{Call: _e.mock.On("ResolveTenant", ctx, name)}
+}
+
+func (_c *TenantDirectory_ResolveTenant_Call) Run(run func(ctx context.Context, name string)) *TenantDirectory_ResolveTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TenantDirectory_ResolveTenant_Call) Return(tenantID string, err error) *TenantDirectory_ResolveTenant_Call {
+	_c.Call.Return(tenantID, err)
+	return _c
+}
+
+func (_c *TenantDirectory_ResolveTenant_Call) RunAndReturn(run func(context.Context, string) (string, error)) *TenantDirectory_ResolveTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewTenantDirectory interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTenantDirectory creates a new instance of TenantDirectory. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTenantDirectory(t mockConstructorTestingTNewTenantDirectory) *TenantDirectory {
+	mock := &TenantDirectory{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
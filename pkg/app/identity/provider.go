@@ -6,10 +6,28 @@ import (
 	"github.com/karta0898098/iam/pkg/app/identity/endpoints"
 	"github.com/karta0898098/iam/pkg/app/identity/repository"
 	"github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/federation"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/ldap"
+	"github.com/karta0898098/iam/pkg/lockout"
+	"github.com/karta0898098/iam/pkg/mail"
+	"github.com/karta0898098/iam/pkg/password"
+	"github.com/karta0898098/iam/pkg/secret"
+	"github.com/karta0898098/iam/pkg/webauthn"
 )
 
 var DefaultProvider = wire.NewSet(
 	endpoints.New,
 	service.New,
+	service.NewLDAPAuthenticator,
 	repository.New,
+	keys.NewKeyManager,
+	password.New,
+	secret.NewCipher,
+	webauthn.New,
+	federation.New,
+	ldap.New,
+	lockout.New,
+	lockout.NewSQLStore,
+	mail.New,
 )
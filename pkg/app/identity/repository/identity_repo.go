@@ -2,13 +2,22 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	oauth2repo "github.com/karta0898098/iam/pkg/app/oauth2/repository"
+	policy "github.com/karta0898098/iam/pkg/app/policy/entity"
+	policyrepo "github.com/karta0898098/iam/pkg/app/policy/repository"
+	rbacrepo "github.com/karta0898098/iam/pkg/app/rbac/repository"
+	tenantrepo "github.com/karta0898098/iam/pkg/app/tenant/repository"
 	"github.com/karta0898098/iam/pkg/db"
 	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/secret"
+	"github.com/karta0898098/iam/pkg/tenant"
 )
 
 // UserDAO define user information
@@ -24,6 +33,8 @@ type UserDAO struct {
 	CreatedAt int64                    `gorm:"column:created_at"` // CreatedAt this account create time
 	UpdatedAt int64                    `gorm:"column:updated_at"` // UpdatedAt this account update time
 	Status    entity.UserAccountStatus `gorm:"column:status"`     // Status this account is suspend
+	Version   int64                    `gorm:"column:version"`    // Version increase on every profile or password change
+	TenantID  string                   `gorm:"column:tenant_id"`  // TenantID tenant user belong to
 }
 
 // TableName is UserDAO implement table name for gorm
@@ -45,6 +56,8 @@ func UnmarshalUserDAO(user *entity.User) *UserDAO {
 		CreatedAt: user.CreatedAt.UnixMilli(),
 		UpdatedAt: user.UpdatedAt.UnixMilli(),
 		Status:    user.Status,
+		Version:   user.Version,
+		TenantID:  user.TenantID,
 	}
 }
 
@@ -62,6 +75,8 @@ func UnmarshalUser(dao *UserDAO) *entity.User {
 		CreatedAt: time.UnixMilli(dao.CreatedAt),
 		UpdatedAt: time.UnixMilli(dao.UpdatedAt),
 		Status:    dao.Status,
+		Version:   dao.Version,
+		TenantID:  dao.TenantID,
 	}
 }
 
@@ -73,11 +88,18 @@ type SessionDAO struct {
 	UpdatedAt       int64  `grom:"column:updated_at"`
 	ExpireAt        int64  `grom:"column:expire_at"`
 	IPAddress       string `grom:"column:ip_address"`
-	IdpProvider     string `grom:"column:idp_provider"`
+	IdpProvider     string `gorm:"column:idp_provider"`
 	Platform        string `grom:"column:platform"`
 	DeviceModel     string `grom:"column:device_model"`
 	DeviceName      string `grom:"column:device_name"`
 	DeviceOSVersion string `grom:"column:device_os_version"`
+	FamilyID        string `gorm:"column:family_id"`
+	RotatedAt       int64  `gorm:"column:rotated_at"`
+	RevokedAt       int64  `gorm:"column:revoked_at"`
+	ClientID        string `gorm:"column:client_id"`
+	Scope           string `gorm:"column:scope"`
+	AuthMethod      string `gorm:"column:auth_method"`
+	TenantID        string `gorm:"column:tenant_id"`
 }
 
 // TableName is SessionDAO implement table name for gorm
@@ -99,32 +121,388 @@ func UnmarshalSessionDAO(session *entity.Session) *SessionDAO {
 		DeviceModel:     session.Device.Model,
 		DeviceName:      session.Device.Name,
 		DeviceOSVersion: session.Device.OSVersion,
+		FamilyID:        session.FamilyID,
+		RotatedAt:       unixMilli(session.RotatedAt),
+		RevokedAt:       unixMilli(session.RevokedAt),
+		ClientID:        session.ClientID,
+		Scope:           session.Scope,
+		AuthMethod:      session.AuthMethod,
+		TenantID:        session.TenantID,
 	}
 }
 
+// UnmarshalSession unmarshal dao to entity session
+func UnmarshalSession(dao *SessionDAO) *entity.Session {
+	return &entity.Session{
+		ID:          dao.ID,
+		UserID:      dao.UserID,
+		CreateAt:    time.UnixMilli(dao.CreatedAt),
+		UpdateAt:    time.UnixMilli(dao.UpdatedAt),
+		ExpireAt:    time.UnixMilli(dao.ExpireAt),
+		IPAddress:   dao.IPAddress,
+		IdpProvider: dao.IdpProvider,
+		Platform:    dao.Platform,
+		Device: entity.Device{
+			Model:     dao.DeviceModel,
+			Name:      dao.DeviceName,
+			OSVersion: dao.DeviceOSVersion,
+		},
+		FamilyID:   dao.FamilyID,
+		RotatedAt:  fromUnixMilli(dao.RotatedAt),
+		RevokedAt:  fromUnixMilli(dao.RevokedAt),
+		ClientID:   dao.ClientID,
+		Scope:      dao.Scope,
+		AuthMethod: dao.AuthMethod,
+		TenantID:   dao.TenantID,
+	}
+}
+
+// unixMilli convert time to unix millisecond, zero time is stored as 0
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// fromUnixMilli convert unix millisecond to time, 0 means zero time
+func fromUnixMilli(msec int64) time.Time {
+	if msec == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(msec)
+}
+
+// TOTPFactorDAO define totp factor dao, secret is encrypted
+type TOTPFactorDAO struct {
+	UserID          string `gorm:"column:user_id"`
+	Secret          string `gorm:"column:secret"`
+	ConfirmedAt     int64  `gorm:"column:confirmed_at"`
+	LastUsedCounter int64  `gorm:"column:last_used_counter"`
+	CreatedAt       int64  `gorm:"column:created_at"`
+	UpdatedAt       int64  `gorm:"column:updated_at"`
+}
+
+// TableName is TOTPFactorDAO implement table name for gorm
+func (f TOTPFactorDAO) TableName() string {
+	return "user_totp_factors"
+}
+
+// RecoveryCodeDAO define recovery code dao
+type RecoveryCodeDAO struct {
+	UserID    string `gorm:"column:user_id"`
+	CodeHash  string `gorm:"column:code_hash"`
+	CreatedAt int64  `gorm:"column:created_at"`
+	UsedAt    int64  `gorm:"column:used_at"`
+}
+
+// TableName is RecoveryCodeDAO implement table name for gorm
+func (c RecoveryCodeDAO) TableName() string {
+	return "user_recovery_codes"
+}
+
+// WebAuthnCredentialDAO define webauthn credential dao
+type WebAuthnCredentialDAO struct {
+	ID                string `gorm:"column:id"`
+	UserID            string `gorm:"column:user_id"`
+	Name              string `gorm:"column:name"`
+	PublicKey         []byte `gorm:"column:public_key"`
+	AAGUID            []byte `gorm:"column:aaguid"`
+	SignCount         int64  `gorm:"column:sign_count"`
+	AttestationFormat string `gorm:"column:attestation_format"`
+	BackupEligible    bool   `gorm:"column:backup_eligible"`
+	CreatedAt         int64  `gorm:"column:created_at"`
+	LastUsedAt        int64  `gorm:"column:last_used_at"`
+}
+
+// TableName is WebAuthnCredentialDAO implement table name for gorm
+func (c WebAuthnCredentialDAO) TableName() string {
+	return "webauthn_credentials"
+}
+
+// UnmarshalWebAuthnCredentialDAO unmarshal entity webauthn credential to dao
+func UnmarshalWebAuthnCredentialDAO(credential *entity.WebAuthnCredential) *WebAuthnCredentialDAO {
+	return &WebAuthnCredentialDAO{
+		ID:                credential.ID,
+		UserID:            credential.UserID,
+		Name:              credential.Name,
+		PublicKey:         credential.PublicKey,
+		AAGUID:            credential.AAGUID,
+		SignCount:         int64(credential.SignCount),
+		AttestationFormat: credential.AttestationFormat,
+		BackupEligible:    credential.BackupEligible,
+		CreatedAt:         credential.CreatedAt.UnixMilli(),
+		LastUsedAt:        unixMilli(credential.LastUsedAt),
+	}
+}
+
+// UnmarshalWebAuthnCredential unmarshal dao to entity webauthn credential
+func UnmarshalWebAuthnCredential(dao *WebAuthnCredentialDAO) *entity.WebAuthnCredential {
+	return &entity.WebAuthnCredential{
+		ID:                dao.ID,
+		UserID:            dao.UserID,
+		Name:              dao.Name,
+		PublicKey:         dao.PublicKey,
+		AAGUID:            dao.AAGUID,
+		SignCount:         uint32(dao.SignCount),
+		AttestationFormat: dao.AttestationFormat,
+		BackupEligible:    dao.BackupEligible,
+		CreatedAt:         time.UnixMilli(dao.CreatedAt),
+		LastUsedAt:        fromUnixMilli(dao.LastUsedAt),
+	}
+}
+
+// WebAuthnChallengeDAO define webauthn challenge dao
+type WebAuthnChallengeDAO struct {
+	ID        string `gorm:"column:id"`
+	UserID    string `gorm:"column:user_id"`
+	Ceremony  string `gorm:"column:ceremony"`
+	Challenge []byte `gorm:"column:challenge"`
+	CreatedAt int64  `gorm:"column:created_at"`
+	ExpiresAt int64  `gorm:"column:expires_at"`
+}
+
+// TableName is WebAuthnChallengeDAO implement table name for gorm
+func (c WebAuthnChallengeDAO) TableName() string {
+	return "webauthn_challenges"
+}
+
+// PasswordResetTokenDAO define password reset token dao
+type PasswordResetTokenDAO struct {
+	ID        string `gorm:"column:id"`
+	UserID    string `gorm:"column:user_id"`
+	CreatedAt int64  `gorm:"column:created_at"`
+	ExpiresAt int64  `gorm:"column:expires_at"`
+}
+
+// TableName is PasswordResetTokenDAO implement table name for gorm
+func (t PasswordResetTokenDAO) TableName() string {
+	return "password_reset_tokens"
+}
+
+// ExternalIdentityDAO define linked identity of upstream provider dao
+type ExternalIdentityDAO struct {
+	TenantID   string `gorm:"column:tenant_id"`
+	Provider   string `gorm:"column:provider"`
+	Subject    string `gorm:"column:subject"`
+	UserID     string `gorm:"column:user_id"`
+	Email      string `gorm:"column:email"`
+	CreatedAt  int64  `gorm:"column:created_at"`
+	LastUsedAt int64  `gorm:"column:last_used_at"`
+}
+
+// TableName is ExternalIdentityDAO implement table name for gorm
+func (i ExternalIdentityDAO) TableName() string {
+	return "identities"
+}
+
+// UnmarshalExternalIdentityDAO unmarshal entity external identity to dao
+func UnmarshalExternalIdentityDAO(identity *entity.ExternalIdentity) *ExternalIdentityDAO {
+	return &ExternalIdentityDAO{
+		TenantID:   identity.TenantID,
+		Provider:   identity.Provider,
+		Subject:    identity.Subject,
+		UserID:     identity.UserID,
+		Email:      identity.Email,
+		CreatedAt:  identity.CreatedAt.UnixMilli(),
+		LastUsedAt: unixMilli(identity.LastUsedAt),
+	}
+}
+
+// UnmarshalExternalIdentity unmarshal dao to entity external identity
+func UnmarshalExternalIdentity(dao *ExternalIdentityDAO) *entity.ExternalIdentity {
+	return &entity.ExternalIdentity{
+		TenantID:   dao.TenantID,
+		Provider:   dao.Provider,
+		Subject:    dao.Subject,
+		UserID:     dao.UserID,
+		Email:      dao.Email,
+		CreatedAt:  time.UnixMilli(dao.CreatedAt),
+		LastUsedAt: fromUnixMilli(dao.LastUsedAt),
+	}
+}
+
+// FederationStateDAO define pending federated signin dao
+type FederationStateDAO struct {
+	ID           string `gorm:"column:id"`
+	Provider     string `gorm:"column:provider"`
+	TenantID     string `gorm:"column:tenant_id"`
+	UserID       string `gorm:"column:user_id"`
+	Nonce        string `gorm:"column:nonce"`
+	CodeVerifier string `gorm:"column:code_verifier"`
+	CreatedAt    int64  `gorm:"column:created_at"`
+	ExpiresAt    int64  `gorm:"column:expires_at"`
+}
+
+// TableName is FederationStateDAO implement table name for gorm
+func (s FederationStateDAO) TableName() string {
+	return "federation_states"
+}
+
+// UserQuery define filters and page of listing users,
+// zero value of filter means not filtered
+type UserQuery struct {
+	// Status filter users by status, deleted users are only listed when asked
+	Status *entity.UserAccountStatus
+	// CreatedAfter include users created at or after it
+	CreatedAfter time.Time
+	// CreatedBefore include users created before it
+	CreatedBefore time.Time
+	// UsernamePrefix filter users by username prefix
+	UsernamePrefix string
+	// EmailPrefix filter users by email prefix
+	EmailPrefix string
+	// Offset skip users of previous pages
+	Offset int
+	// Limit max users of page
+	Limit int
+}
+
+// likePrefix escape wildcard of prefix for LIKE pattern
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
+
 // Repository define identity repository pattern
 type Repository interface {
 	// StoreUser store user into datastore
 	StoreUser(ctx context.Context, user *entity.User) (err error)
 
-	// FindUserByUsername find user by username
+	// FindUserByUsername find user by username in tenant of context, username is unique in tenant
 	FindUserByUsername(ctx context.Context, username string) (profile *entity.User, err error)
 
+	// FindUserByID find user by user id
+	FindUserByID(ctx context.Context, userID string) (profile *entity.User, err error)
+
+	// FindUsersByEmail find users by email, email is not unique between users
+	FindUsersByEmail(ctx context.Context, email string) (users []*entity.User, err error)
+
+	// UpdatePassword update password hash of user
+	UpdatePassword(ctx context.Context, user *entity.User) (err error)
+
+	// UpdateUser update profile and password of user when stored version still match,
+	// ErrConflict is returned when user is changed by others
+	UpdateUser(ctx context.Context, user *entity.User, version int64) (err error)
+
+	// ConfirmUserEmail activate user still not confirmed,
+	// ErrConflict is returned when status already changed
+	ConfirmUserEmail(ctx context.Context, user *entity.User) (err error)
+
+	// ListUsers list users match query ordered by newest first,
+	// total is count of users match query without page
+	ListUsers(ctx context.Context, query *UserQuery) (users []*entity.User, total int64, err error)
+
+	// UpdateUserStatus change status of user when stored status still match from,
+	// ErrConflict is returned when status already changed
+	UpdateUserStatus(ctx context.Context, user *entity.User, from entity.UserAccountStatus) (err error)
+
+	// DeleteUser delete user with sessions, factors, credentials and tokens of the user,
+	// roles, group and tenant memberships, policy attachments and oauth2 grants are deleted in the same transaction
+	DeleteUser(ctx context.Context, userID string) (err error)
+
+	// StorePasswordResetToken store password reset token
+	StorePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) (err error)
+
+	// FindPasswordResetToken find password reset token by hash
+	FindPasswordResetToken(ctx context.Context, tokenID string) (token *entity.PasswordResetToken, err error)
+
+	// ConsumePasswordResetToken delete token and every other reset token of the user,
+	// ErrResourceNotFound is returned when token already consumed
+	ConsumePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) (err error)
+
 	// StoreSession store session into datastore
 	StoreSession(ctx context.Context, session *entity.Session) (err error)
+
+	// FindSessionByID find session by session id
+	FindSessionByID(ctx context.Context, sessionID string) (session *entity.Session, err error)
+
+	// ListActiveSessions list sessions of user not rotated, revoked or expired, most recently used first,
+	// it is the latest session of every sign in
+	ListActiveSessions(ctx context.Context, userID string) (sessions []*entity.Session, err error)
+
+	// RotateSession mark current session rotated and store the next session
+	// return ErrConflict when current session already rotated or revoked
+	RotateSession(ctx context.Context, current *entity.Session, next *entity.Session) (err error)
+
+	// RevokeSessionFamily revoke all sessions derived from same sign in
+	RevokeSessionFamily(ctx context.Context, familyID string) (err error)
+
+	// RevokeUserSessions revoke all sessions of user
+	RevokeUserSessions(ctx context.Context, userID string) (err error)
+
+	// RevokeClientSessions revoke all sessions issued to oauth2 client
+	RevokeClientSessions(ctx context.Context, clientID string) (err error)
+
+	// StoreTOTPFactor create or replace pending totp factor of user
+	StoreTOTPFactor(ctx context.Context, factor *entity.TOTPFactor) (err error)
+
+	// FindTOTPFactor find totp factor of user
+	FindTOTPFactor(ctx context.Context, userID string) (factor *entity.TOTPFactor, err error)
+
+	// ConfirmTOTPFactor mark pending factor confirmed and replace recovery codes
+	// return ErrConflict when factor already confirmed
+	ConfirmTOTPFactor(ctx context.Context, factor *entity.TOTPFactor, recoveryCodeHashes []string) (err error)
+
+	// UpdateTOTPCounter move last used counter forward
+	// return ErrConflict when counter already used, the code is replayed
+	UpdateTOTPCounter(ctx context.Context, factor *entity.TOTPFactor) (err error)
+
+	// ConsumeRecoveryCode mark recovery code used
+	// return ErrResourceNotFound when code not exist or already used
+	ConsumeRecoveryCode(ctx context.Context, userID string, codeHash string) (err error)
+
+	// StoreWebAuthnChallenge store pending ceremony challenge
+	StoreWebAuthnChallenge(ctx context.Context, challenge *entity.WebAuthnChallenge) (err error)
+
+	// ConsumeWebAuthnChallenge find and delete challenge
+	// return ErrResourceNotFound when challenge not exist or already consumed
+	ConsumeWebAuthnChallenge(ctx context.Context, challengeID string) (challenge *entity.WebAuthnChallenge, err error)
+
+	// StoreWebAuthnCredential store credential of user
+	// return ErrConflict when credential already registered
+	StoreWebAuthnCredential(ctx context.Context, credential *entity.WebAuthnCredential) (err error)
+
+	// FindWebAuthnCredential find credential by credential id
+	FindWebAuthnCredential(ctx context.Context, credentialID string) (credential *entity.WebAuthnCredential, err error)
+
+	// ListWebAuthnCredentials list credentials of user
+	ListWebAuthnCredentials(ctx context.Context, userID string) (credentials []*entity.WebAuthnCredential, err error)
+
+	// UpdateWebAuthnSignCount record sign count and last used time of credential
+	// return ErrConflict when stored sign count is not less than the new one
+	UpdateWebAuthnSignCount(ctx context.Context, credential *entity.WebAuthnCredential) (err error)
+
+	// StoreFederationState store pending signin at upstream provider
+	StoreFederationState(ctx context.Context, state *entity.FederationState) (err error)
+
+	// ConsumeFederationState find and delete state
+	// return ErrResourceNotFound when state not exist or already consumed
+	ConsumeFederationState(ctx context.Context, stateID string) (state *entity.FederationState, err error)
+
+	// FindExternalIdentity find identity of provider subject in tenant of context
+	FindExternalIdentity(ctx context.Context, provider string, subject string) (identity *entity.ExternalIdentity, err error)
+
+	// StoreExternalIdentity link provider subject to user
+	// return ErrConflict when subject already linked
+	StoreExternalIdentity(ctx context.Context, identity *entity.ExternalIdentity) (err error)
+
+	// UpdateExternalIdentity record email and last used time of identity
+	UpdateExternalIdentity(ctx context.Context, identity *entity.ExternalIdentity) (err error)
 }
 
 // IdentityRepository implement for Repository
 type IdentityRepository struct {
 	readDB  *gorm.DB
 	writeDB *gorm.DB
+	cipher  secret.Cipher
 }
 
 // New Repository constructor
-func New(conn db.Connection) Repository {
+func New(conn db.Connection, cipher secret.Cipher) Repository {
 	return &IdentityRepository{
 		readDB:  conn.ReadDB(),
 		writeDB: conn.WriteDB(),
+		cipher:  cipher,
 	}
 }
 
@@ -141,7 +519,7 @@ func (repo *IdentityRepository) FindUserByUsername(ctx context.Context, username
 	err = repo.readDB.
 		WithContext(ctx).
 		Model(user).
-		Where("username = ?", username).
+		Where("tenant_id = ? AND username = ?", tenant.ID(ctx), username).
 		First(&user).
 		Error
 	if err != nil {
@@ -154,6 +532,332 @@ func (repo *IdentityRepository) FindUserByUsername(ctx context.Context, username
 	return UnmarshalUser(&user), nil
 }
 
+// FindUserByID is SQL implement
+func (repo *IdentityRepository) FindUserByID(ctx context.Context, userID string) (profile *entity.User, err error) {
+	var (
+		user UserDAO
+	)
+
+	if userID == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input user id is empty")
+	}
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(user).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("id = ?", userID).
+		First(&user).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found profile id=%v", userID)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalUser(&user), nil
+}
+
+// FindUsersByEmail is SQL implement
+func (repo *IdentityRepository) FindUsersByEmail(ctx context.Context, email string) (users []*entity.User, err error) {
+	var (
+		daos []UserDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(UserDAO{}).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("email = ?", email).
+		Order("created_at").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	users = make([]*entity.User, 0, len(daos))
+	for i := range daos {
+		users = append(users, UnmarshalUser(&daos[i]))
+	}
+
+	return users, nil
+}
+
+// UpdatePassword is SQL implement
+func (repo *IdentityRepository) UpdatePassword(ctx context.Context, user *entity.User) (err error) {
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(UserDAO{}).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("id = ?", user.ID).
+		Updates(map[string]interface{}{
+			"password":   user.Password,
+			"updated_at": user.UpdatedAt.UnixMilli(),
+			// pending profile update based on old password is stale
+			"version": gorm.Expr("version + 1"),
+		}).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update password of user=%v, err %v", user.ID, err)
+	}
+
+	return nil
+}
+
+// UpdateUser is SQL implement
+func (repo *IdentityRepository) UpdateUser(ctx context.Context, user *entity.User, version int64) (err error) {
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(UserDAO{}).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("id = ? AND version = ?", user.ID, version).
+		Updates(map[string]interface{}{
+			"password":   user.Password,
+			"nickname":   user.Nickname,
+			"first_name": user.FirstName,
+			"last_name":  user.LastName,
+			"email":      user.Email,
+			"avatar":     user.Avatar,
+			"updated_at": user.UpdatedAt.UnixMilli(),
+			"version":    user.Version,
+		})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update user=%v, err %v", user.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrConflict, "user=%v is changed by others, version=%v is stale", user.ID, version)
+	}
+
+	return nil
+}
+
+// ConfirmUserEmail is SQL implement
+func (repo *IdentityRepository) ConfirmUserEmail(ctx context.Context, user *entity.User) (err error) {
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(UserDAO{}).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("id = ? AND status = ?", user.ID, entity.UserAccountStatusNotConfirmed).
+		Updates(map[string]interface{}{
+			"status":     user.Status,
+			"updated_at": user.UpdatedAt.UnixMilli(),
+		})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to confirm email of user=%v, err %v", user.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrConflict, "user=%v is not waiting email confirmation", user.ID)
+	}
+
+	return nil
+}
+
+// ListUsers is SQL implement
+func (repo *IdentityRepository) ListUsers(ctx context.Context, query *UserQuery) (users []*entity.User, total int64, err error) {
+	var (
+		daos []UserDAO
+	)
+
+	tx := repo.readDB.
+		WithContext(ctx).
+		Model(UserDAO{}).
+		Scopes(tenant.Scope(ctx, "tenant_id"))
+
+	if query.Status != nil {
+		tx = tx.Where("status = ?", *query.Status)
+	} else {
+		tx = tx.Where("status <> ?", entity.UserAccountStatusDeleted)
+	}
+	if !query.CreatedAfter.IsZero() {
+		tx = tx.Where("created_at >= ?", query.CreatedAfter.UnixMilli())
+	}
+	if !query.CreatedBefore.IsZero() {
+		tx = tx.Where("created_at < ?", query.CreatedBefore.UnixMilli())
+	}
+	if query.UsernamePrefix != "" {
+		tx = tx.Where("username LIKE ?", likePrefix(query.UsernamePrefix))
+	}
+	if query.EmailPrefix != "" {
+		tx = tx.Where("email LIKE ?", likePrefix(query.EmailPrefix))
+	}
+
+	// count and page share the same filters
+	tx = tx.Session(&gorm.Session{})
+
+	err = tx.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	err = tx.
+		Order("created_at DESC, id").
+		Offset(query.Offset).
+		Limit(query.Limit).
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	users = make([]*entity.User, 0, len(daos))
+	for i := range daos {
+		users = append(users, UnmarshalUser(&daos[i]))
+	}
+
+	return users, total, nil
+}
+
+// UpdateUserStatus is SQL implement
+func (repo *IdentityRepository) UpdateUserStatus(ctx context.Context, user *entity.User, from entity.UserAccountStatus) (err error) {
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(UserDAO{}).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("id = ? AND status = ?", user.ID, from).
+		Updates(map[string]interface{}{
+			"status":     user.Status,
+			"updated_at": user.UpdatedAt.UnixMilli(),
+		})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update status of user=%v, err %v", user.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrConflict, "user=%v status is changed by others", user.ID)
+	}
+
+	return nil
+}
+
+// DeleteUser is SQL implement
+func (repo *IdentityRepository) DeleteUser(ctx context.Context, userID string) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			result := tx.
+				Scopes(tenant.Scope(ctx, "tenant_id")).
+				Where("id = ?", userID).
+				Delete(&UserDAO{})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete user=%v, err %v", userID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "cant not found profile id=%v", userID)
+			}
+
+			for _, dao := range []interface{}{
+				&SessionDAO{},
+				&TOTPFactorDAO{},
+				&RecoveryCodeDAO{},
+				&WebAuthnCredentialDAO{},
+				&WebAuthnChallengeDAO{},
+				&PasswordResetTokenDAO{},
+				&ExternalIdentityDAO{},
+				&FederationStateDAO{},
+				// roles, memberships and oauth2 grants of user are kept by other modules
+				&rbacrepo.UserRoleDAO{},
+				&rbacrepo.GroupMemberDAO{},
+				&tenantrepo.MemberDAO{},
+				&oauth2repo.ConsentDAO{},
+				&oauth2repo.AuthorizationCodeDAO{},
+			} {
+				err := tx.
+					Where("user_id = ?", userID).
+					Delete(dao).
+					Error
+				if err != nil {
+					return errors.Wrapf(errors.ErrInternal, "failed to delete data of user=%v, err %v", userID, err)
+				}
+			}
+
+			err := tx.
+				Where("principal_type = ? AND principal_id = ?", string(policy.PrincipalUser), userID).
+				Delete(&policyrepo.AttachmentDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete policy attachments of user=%v, err %v", userID, err)
+			}
+
+			return nil
+		})
+}
+
+// StorePasswordResetToken is SQL implement
+func (repo *IdentityRepository) StorePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) (err error) {
+	dao := &PasswordResetTokenDAO{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		CreatedAt: token.CreatedAt.UnixMilli(),
+		ExpiresAt: token.ExpiresAt.UnixMilli(),
+	}
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to store password reset token of user=%v, err %v", token.UserID, err)
+	}
+
+	return nil
+}
+
+// FindPasswordResetToken is SQL implement
+func (repo *IdentityRepository) FindPasswordResetToken(ctx context.Context, tokenID string) (token *entity.PasswordResetToken, err error) {
+	var (
+		dao PasswordResetTokenDAO
+	)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Where("id = ?", tokenID).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrap(errors.ErrResourceNotFound, "cant not found password reset token")
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return &entity.PasswordResetToken{
+		ID:        dao.ID,
+		UserID:    dao.UserID,
+		CreatedAt: time.UnixMilli(dao.CreatedAt),
+		ExpiresAt: time.UnixMilli(dao.ExpiresAt),
+	}, nil
+}
+
+// ConsumePasswordResetToken is SQL implement
+func (repo *IdentityRepository) ConsumePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			result := tx.
+				Where("id = ?", token.ID).
+				Delete(&PasswordResetTokenDAO{})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete password reset token of user=%v, err %v", token.UserID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "password reset token of user=%v already consumed", token.UserID)
+			}
+
+			err := tx.
+				Where("user_id = ?", token.UserID).
+				Delete(&PasswordResetTokenDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete password reset tokens of user=%v, err %v", token.UserID, err)
+			}
+
+			return nil
+		})
+}
+
 // StoreSession is SQL implement
 func (repo *IdentityRepository) StoreSession(ctx context.Context, session *entity.Session) (err error) {
 	dao := UnmarshalSessionDAO(session)
@@ -185,3 +889,590 @@ func (repo *IdentityRepository) StoreUser(ctx context.Context, user *entity.User
 
 	return nil
 }
+
+// FindSessionByID is SQL implement
+func (repo *IdentityRepository) FindSessionByID(ctx context.Context, sessionID string) (session *entity.Session, err error) {
+	var (
+		dao SessionDAO
+	)
+
+	if sessionID == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input session id is empty")
+	}
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("id = ?", sessionID).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found session id=%v", sessionID)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalSession(&dao), nil
+}
+
+// ListActiveSessions is SQL implement
+func (repo *IdentityRepository) ListActiveSessions(ctx context.Context, userID string) (sessions []*entity.Session, err error) {
+	var (
+		daos []SessionDAO
+	)
+
+	// refresh token of session expire since it is issued at updated time
+	expiredBefore := time.Now().Add(-entity.RefreshTokenLifetime).UnixMilli()
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(SessionDAO{}).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("user_id = ? AND rotated_at = 0 AND revoked_at = 0 AND updated_at > ?", userID, expiredBefore).
+		Order("updated_at DESC").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	sessions = make([]*entity.Session, 0, len(daos))
+	for i := range daos {
+		sessions = append(sessions, UnmarshalSession(&daos[i]))
+	}
+
+	return sessions, nil
+}
+
+// RotateSession is SQL implement
+// the current session only can be rotated once and must not be revoked,
+// concurrent refresh with same token will get ErrConflict
+func (repo *IdentityRepository) RotateSession(ctx context.Context, current *entity.Session, next *entity.Session) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			result := tx.
+				Model(SessionDAO{}).
+				Where("id = ? AND rotated_at = 0 AND revoked_at = 0", current.ID).
+				Updates(map[string]interface{}{
+					"rotated_at": current.RotatedAt.UnixMilli(),
+					"updated_at": current.UpdateAt.UnixMilli(),
+				})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to rotate session id=%v, err %v", current.ID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrConflict, "session id=%v already rotated or revoked", current.ID)
+			}
+
+			dao := UnmarshalSessionDAO(next)
+			err := tx.
+				Model(dao).
+				Create(dao).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to create session %#v, err %v", next, err)
+			}
+
+			return nil
+		})
+}
+
+// RevokeSessionFamily is SQL implement
+func (repo *IdentityRepository) RevokeSessionFamily(ctx context.Context, familyID string) (err error) {
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(SessionDAO{}).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("family_id = ? AND revoked_at = 0", familyID).
+		UpdateColumn("revoked_at", time.Now().UnixMilli()).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to revoke session family=%v, err %v", familyID, err)
+	}
+
+	return nil
+}
+
+// RevokeUserSessions is SQL implement
+func (repo *IdentityRepository) RevokeUserSessions(ctx context.Context, userID string) (err error) {
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(SessionDAO{}).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("user_id = ? AND revoked_at = 0", userID).
+		UpdateColumn("revoked_at", time.Now().UnixMilli()).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to revoke sessions of user=%v, err %v", userID, err)
+	}
+
+	return nil
+}
+
+// RevokeClientSessions is SQL implement
+func (repo *IdentityRepository) RevokeClientSessions(ctx context.Context, clientID string) (err error) {
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(SessionDAO{}).
+		Where("client_id = ? AND revoked_at = 0", clientID).
+		UpdateColumn("revoked_at", time.Now().UnixMilli()).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to revoke sessions of client=%v, err %v", clientID, err)
+	}
+
+	return nil
+}
+
+// StoreTOTPFactor is SQL implement
+func (repo *IdentityRepository) StoreTOTPFactor(ctx context.Context, factor *entity.TOTPFactor) (err error) {
+	encrypted, err := repo.cipher.Encrypt([]byte(factor.Secret))
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to encrypt totp secret of user=%v, err %v", factor.UserID, err)
+	}
+
+	dao := &TOTPFactorDAO{
+		UserID:          factor.UserID,
+		Secret:          encrypted,
+		ConfirmedAt:     unixMilli(factor.ConfirmedAt),
+		LastUsedCounter: int64(factor.LastUsedCounter),
+		CreatedAt:       factor.CreatedAt.UnixMilli(),
+		UpdatedAt:       factor.UpdatedAt.UnixMilli(),
+	}
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"secret", "confirmed_at", "last_used_counter", "created_at", "updated_at"}),
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Eq{Column: "user_totp_factors.confirmed_at", Value: 0}}},
+		}).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to store totp factor of user=%v, err %v", factor.UserID, err)
+	}
+
+	return nil
+}
+
+// FindTOTPFactor is SQL implement
+func (repo *IdentityRepository) FindTOTPFactor(ctx context.Context, userID string) (factor *entity.TOTPFactor, err error) {
+	var (
+		dao TOTPFactorDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Where("user_id = ?", userID).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found totp factor user=%v", userID)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	plaintext, err := repo.cipher.Decrypt(dao.Secret)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "failed to decrypt totp secret of user=%v, err %v", userID, err)
+	}
+
+	return &entity.TOTPFactor{
+		UserID:          dao.UserID,
+		Secret:          string(plaintext),
+		ConfirmedAt:     fromUnixMilli(dao.ConfirmedAt),
+		LastUsedCounter: uint64(dao.LastUsedCounter),
+		CreatedAt:       time.UnixMilli(dao.CreatedAt),
+		UpdatedAt:       time.UnixMilli(dao.UpdatedAt),
+	}, nil
+}
+
+// ConfirmTOTPFactor is SQL implement
+func (repo *IdentityRepository) ConfirmTOTPFactor(ctx context.Context, factor *entity.TOTPFactor, recoveryCodeHashes []string) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			result := tx.
+				Model(TOTPFactorDAO{}).
+				Where("user_id = ? AND confirmed_at = 0", factor.UserID).
+				Updates(map[string]interface{}{
+					"confirmed_at":      factor.ConfirmedAt.UnixMilli(),
+					"last_used_counter": int64(factor.LastUsedCounter),
+					"updated_at":        factor.UpdatedAt.UnixMilli(),
+				})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to confirm totp factor of user=%v, err %v", factor.UserID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrConflict, "totp factor of user=%v already confirmed", factor.UserID)
+			}
+
+			err := tx.
+				Where("user_id = ?", factor.UserID).
+				Delete(&RecoveryCodeDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete recovery codes of user=%v, err %v", factor.UserID, err)
+			}
+
+			daos := make([]RecoveryCodeDAO, 0, len(recoveryCodeHashes))
+			for _, hash := range recoveryCodeHashes {
+				daos = append(daos, RecoveryCodeDAO{
+					UserID:    factor.UserID,
+					CodeHash:  hash,
+					CreatedAt: factor.ConfirmedAt.UnixMilli(),
+				})
+			}
+
+			err = tx.
+				Model(RecoveryCodeDAO{}).
+				Create(&daos).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to create recovery codes of user=%v, err %v", factor.UserID, err)
+			}
+
+			return nil
+		})
+}
+
+// UpdateTOTPCounter is SQL implement
+func (repo *IdentityRepository) UpdateTOTPCounter(ctx context.Context, factor *entity.TOTPFactor) (err error) {
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(TOTPFactorDAO{}).
+		Where("user_id = ? AND last_used_counter < ?", factor.UserID, int64(factor.LastUsedCounter)).
+		Updates(map[string]interface{}{
+			"last_used_counter": int64(factor.LastUsedCounter),
+			"updated_at":        factor.UpdatedAt.UnixMilli(),
+		})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update totp counter of user=%v, err %v", factor.UserID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrConflict, "totp counter=%v of user=%v already used", factor.LastUsedCounter, factor.UserID)
+	}
+
+	return nil
+}
+
+// ConsumeRecoveryCode is SQL implement
+func (repo *IdentityRepository) ConsumeRecoveryCode(ctx context.Context, userID string, codeHash string) (err error) {
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(RecoveryCodeDAO{}).
+		Where("user_id = ? AND code_hash = ? AND used_at = 0", userID, codeHash).
+		UpdateColumn("used_at", time.Now().UnixMilli())
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to consume recovery code of user=%v, err %v", userID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "recovery code of user=%v not exist or used", userID)
+	}
+
+	return nil
+}
+
+// StoreWebAuthnChallenge is SQL implement
+func (repo *IdentityRepository) StoreWebAuthnChallenge(ctx context.Context, challenge *entity.WebAuthnChallenge) (err error) {
+	dao := &WebAuthnChallengeDAO{
+		ID:        challenge.ID,
+		UserID:    challenge.UserID,
+		Ceremony:  challenge.Ceremony,
+		Challenge: challenge.Challenge,
+		CreatedAt: challenge.CreatedAt.UnixMilli(),
+		ExpiresAt: challenge.ExpiresAt.UnixMilli(),
+	}
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to store webauthn challenge id=%v, err %v", challenge.ID, err)
+	}
+
+	return nil
+}
+
+// ConsumeWebAuthnChallenge is SQL implement
+// the challenge only can be consumed once, concurrent ceremony will get ErrResourceNotFound
+func (repo *IdentityRepository) ConsumeWebAuthnChallenge(ctx context.Context, challengeID string) (challenge *entity.WebAuthnChallenge, err error) {
+	var (
+		dao WebAuthnChallengeDAO
+	)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Model(dao).
+				Where("id = ?", challengeID).
+				First(&dao).
+				Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errors.Wrapf(errors.ErrResourceNotFound, "cant not found webauthn challenge id=%v", challengeID)
+				}
+				return errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+			}
+
+			result := tx.
+				Where("id = ?", challengeID).
+				Delete(&WebAuthnChallengeDAO{})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete webauthn challenge id=%v, err %v", challengeID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "webauthn challenge id=%v already consumed", challengeID)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.WebAuthnChallenge{
+		ID:        dao.ID,
+		UserID:    dao.UserID,
+		Ceremony:  dao.Ceremony,
+		Challenge: dao.Challenge,
+		CreatedAt: time.UnixMilli(dao.CreatedAt),
+		ExpiresAt: time.UnixMilli(dao.ExpiresAt),
+	}, nil
+}
+
+// StoreWebAuthnCredential is SQL implement
+func (repo *IdentityRepository) StoreWebAuthnCredential(ctx context.Context, credential *entity.WebAuthnCredential) (err error) {
+	dao := UnmarshalWebAuthnCredentialDAO(credential)
+
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(dao)
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to store webauthn credential of user=%v, err %v", credential.UserID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrConflict, "webauthn credential id=%v already registered", credential.ID)
+	}
+
+	return nil
+}
+
+// FindWebAuthnCredential is SQL implement
+func (repo *IdentityRepository) FindWebAuthnCredential(ctx context.Context, credentialID string) (credential *entity.WebAuthnCredential, err error) {
+	var (
+		dao WebAuthnCredentialDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Where("id = ?", credentialID).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found webauthn credential id=%v", credentialID)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalWebAuthnCredential(&dao), nil
+}
+
+// ListWebAuthnCredentials is SQL implement
+func (repo *IdentityRepository) ListWebAuthnCredentials(ctx context.Context, userID string) (credentials []*entity.WebAuthnCredential, err error) {
+	var (
+		daos []WebAuthnCredentialDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(WebAuthnCredentialDAO{}).
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	credentials = make([]*entity.WebAuthnCredential, 0, len(daos))
+	for i := range daos {
+		credentials = append(credentials, UnmarshalWebAuthnCredential(&daos[i]))
+	}
+
+	return credentials, nil
+}
+
+// UpdateWebAuthnSignCount is SQL implement
+// authenticator never count keeps sign count 0, only last used time is updated
+func (repo *IdentityRepository) UpdateWebAuthnSignCount(ctx context.Context, credential *entity.WebAuthnCredential) (err error) {
+	query := repo.writeDB.
+		WithContext(ctx).
+		Model(WebAuthnCredentialDAO{}).
+		Where("id = ?", credential.ID)
+	if credential.SignCount != 0 {
+		query = query.Where("sign_count < ?", int64(credential.SignCount))
+	}
+
+	result := query.Updates(map[string]interface{}{
+		"sign_count":   int64(credential.SignCount),
+		"last_used_at": unixMilli(credential.LastUsedAt),
+	})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update webauthn sign count id=%v, err %v", credential.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrConflict, "webauthn sign count=%v of id=%v already used", credential.SignCount, credential.ID)
+	}
+
+	return nil
+}
+
+// StoreFederationState is SQL implement
+func (repo *IdentityRepository) StoreFederationState(ctx context.Context, state *entity.FederationState) (err error) {
+	dao := &FederationStateDAO{
+		ID:           state.ID,
+		Provider:     state.Provider,
+		TenantID:     state.TenantID,
+		UserID:       state.UserID,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+		CreatedAt:    state.CreatedAt.UnixMilli(),
+		ExpiresAt:    state.ExpiresAt.UnixMilli(),
+	}
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to store federation state of provider=%v, err %v", state.Provider, err)
+	}
+
+	return nil
+}
+
+// ConsumeFederationState is SQL implement
+// the state only can be consumed once, replayed authorization response will get ErrResourceNotFound
+func (repo *IdentityRepository) ConsumeFederationState(ctx context.Context, stateID string) (state *entity.FederationState, err error) {
+	var (
+		dao FederationStateDAO
+	)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Model(dao).
+				Where("id = ?", stateID).
+				First(&dao).
+				Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errors.Wrap(errors.ErrResourceNotFound, "cant not found federation state")
+				}
+				return errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+			}
+
+			result := tx.
+				Where("id = ?", stateID).
+				Delete(&FederationStateDAO{})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete federation state of provider=%v, err %v", dao.Provider, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "federation state of provider=%v already consumed", dao.Provider)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.FederationState{
+		ID:           dao.ID,
+		Provider:     dao.Provider,
+		TenantID:     dao.TenantID,
+		UserID:       dao.UserID,
+		Nonce:        dao.Nonce,
+		CodeVerifier: dao.CodeVerifier,
+		CreatedAt:    time.UnixMilli(dao.CreatedAt),
+		ExpiresAt:    time.UnixMilli(dao.ExpiresAt),
+	}, nil
+}
+
+// FindExternalIdentity is SQL implement
+func (repo *IdentityRepository) FindExternalIdentity(ctx context.Context, provider string, subject string) (identity *entity.ExternalIdentity, err error) {
+	var (
+		dao ExternalIdentityDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Scopes(tenant.Scope(ctx, "tenant_id")).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found identity of provider=%v", provider)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalExternalIdentity(&dao), nil
+}
+
+// StoreExternalIdentity is SQL implement
+func (repo *IdentityRepository) StoreExternalIdentity(ctx context.Context, identity *entity.ExternalIdentity) (err error) {
+	dao := UnmarshalExternalIdentityDAO(identity)
+
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(dao)
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to store identity of user=%v, err %v", identity.UserID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrConflict, "identity of provider=%v already linked", identity.Provider)
+	}
+
+	return nil
+}
+
+// UpdateExternalIdentity is SQL implement
+func (repo *IdentityRepository) UpdateExternalIdentity(ctx context.Context, identity *entity.ExternalIdentity) (err error) {
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(ExternalIdentityDAO{}).
+		Where("tenant_id = ? AND provider = ? AND subject = ?", identity.TenantID, identity.Provider, identity.Subject).
+		Updates(map[string]interface{}{
+			"email":        identity.Email,
+			"last_used_at": unixMilli(identity.LastUsedAt),
+		}).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update identity of user=%v, err %v", identity.UserID, err)
+	}
+
+	return nil
+}
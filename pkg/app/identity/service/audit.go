@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/webauthn"
+)
+
+type auditMiddleware struct {
+	next     IdentityService
+	recorder audit.Recorder
+}
+
+// AuditMiddleware record authentication and account changing action to audit log,
+// read only action is not recorded
+func AuditMiddleware(recorder audit.Recorder) Middleware {
+	return func(next IdentityService) IdentityService {
+		return auditMiddleware{next: next, recorder: recorder}
+	}
+}
+
+// principalActor user id or client id of authenticated caller, fallback is used when caller is not authenticated
+func principalActor(ctx context.Context, fallback string) string {
+	principal, ok := authn.FromContext(ctx)
+	if !ok {
+		return fallback
+	}
+	if principal.UserID != "" {
+		return principal.UserID
+	}
+	return principal.ClientID
+}
+
+// identityEvent new event of action result in identity, actor is user of identity when it is known
+func identityEvent(action audit.Action, actor string, identity *entity.Identity, err error) *audit.Event {
+	if identity == nil || identity.User == nil {
+		return audit.NewEvent(action, actor, "", err)
+	}
+
+	event := audit.NewEvent(action, identity.User.ID, identity.User.ID, err)
+	event.TenantID = identity.User.TenantID
+	if identity.MFARequired {
+		event.Outcome = audit.OutcomeChallenged
+	}
+	return event
+}
+
+// withSigninOption set platform and device of signin option to event,
+// ip address is left to audit.Emit which take it from transport
+func withSigninOption(event *audit.Event, opt *SigninOption) *audit.Event {
+	if opt == nil {
+		return event
+	}
+	return event.WithClient("", opt.Platform, audit.DeviceName(opt.Device.Name, opt.Device.Model, opt.Device.OSVersion))
+}
+
+func (am auditMiddleware) Signin(ctx context.Context, username string, password string, opt *SigninOption) (identity *entity.Identity, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, withSigninOption(identityEvent(audit.ActionSignin, username, identity, err), opt))
+	}()
+
+	return am.next.Signin(ctx, username, password, opt)
+}
+
+func (am auditMiddleware) Signup(ctx context.Context, username string, password string, opts *SignupOption) (identity *entity.Identity, err error) {
+	defer func() {
+		event := identityEvent(audit.ActionSignup, username, identity, err)
+		if opts != nil {
+			event.WithClient("", opts.Platform, audit.DeviceName(opts.Device.Name, opts.Device.Model, opts.Device.OSVersion))
+		}
+		audit.Emit(ctx, am.recorder, event)
+	}()
+
+	return am.next.Signup(ctx, username, password, opts)
+}
+
+func (am auditMiddleware) Refresh(ctx context.Context, refreshToken string, clientID string) (identity *entity.Identity, err error) {
+	defer func() {
+		event := identityEvent(audit.ActionRefresh, "", identity, err)
+		if identity != nil && identity.Session != nil {
+			event.Target = identity.Session.ID
+		}
+		audit.Emit(ctx, am.recorder, event)
+	}()
+
+	return am.next.Refresh(ctx, refreshToken, clientID)
+}
+
+func (am auditMiddleware) Introspect(ctx context.Context, token string) (introspection *entity.Introspection, err error) {
+	return am.next.Introspect(ctx, token)
+}
+
+func (am auditMiddleware) Signout(ctx context.Context, sessionID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionSignout, principalActor(ctx, ""), sessionID, err))
+	}()
+
+	return am.next.Signout(ctx, sessionID)
+}
+
+func (am auditMiddleware) SignoutAll(ctx context.Context, userID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionSignoutAll, principalActor(ctx, userID), userID, err))
+	}()
+
+	return am.next.SignoutAll(ctx, userID)
+}
+
+func (am auditMiddleware) ListSessions(ctx context.Context, userID string, sessionID string) (sessions []*entity.Session, current string, err error) {
+	return am.next.ListSessions(ctx, userID, sessionID)
+}
+
+func (am auditMiddleware) RevokeSession(ctx context.Context, userID string, sessionID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionRevokeSession, principalActor(ctx, userID), sessionID, err))
+	}()
+
+	return am.next.RevokeSession(ctx, userID, sessionID)
+}
+
+func (am auditMiddleware) EnrollTOTP(ctx context.Context, userID string) (enrollment *entity.TOTPEnrollment, err error) {
+	return am.next.EnrollTOTP(ctx, userID)
+}
+
+// ConfirmTOTP is recorded as enrollment since totp is not active until it is confirmed
+func (am auditMiddleware) ConfirmTOTP(ctx context.Context, userID string, code string) (recoveryCodes []string, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionEnrollTOTP, principalActor(ctx, userID), userID, err))
+	}()
+
+	return am.next.ConfirmTOTP(ctx, userID, code)
+}
+
+func (am auditMiddleware) VerifyMFA(ctx context.Context, mfaToken string, code string) (identity *entity.Identity, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, identityEvent(audit.ActionVerifyMFA, "", identity, err))
+	}()
+
+	return am.next.VerifyMFA(ctx, mfaToken, code)
+}
+
+func (am auditMiddleware) BeginWebAuthnRegistration(ctx context.Context, userID string) (options *entity.WebAuthnRegistrationOptions, err error) {
+	return am.next.BeginWebAuthnRegistration(ctx, userID)
+}
+
+func (am auditMiddleware) FinishWebAuthnRegistration(ctx context.Context, userID string, challengeID string, name string, resp *webauthn.AttestationResponse) (credential *entity.WebAuthnCredential, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionRegisterWebAuthn, principalActor(ctx, userID), userID, err))
+	}()
+
+	return am.next.FinishWebAuthnRegistration(ctx, userID, challengeID, name, resp)
+}
+
+func (am auditMiddleware) BeginWebAuthnSignin(ctx context.Context, username string, tenantName string) (options *entity.WebAuthnSigninOptions, err error) {
+	return am.next.BeginWebAuthnSignin(ctx, username, tenantName)
+}
+
+func (am auditMiddleware) FinishWebAuthnSignin(ctx context.Context, challengeID string, credentialID string, resp *webauthn.AssertionResponse, opt *SigninOption) (identity *entity.Identity, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, withSigninOption(identityEvent(audit.ActionWebAuthnSignin, "", identity, err), opt))
+	}()
+
+	return am.next.FinishWebAuthnSignin(ctx, challengeID, credentialID, resp, opt)
+}
+
+func (am auditMiddleware) BeginFederatedSignin(ctx context.Context, provider string, tenantName string) (authURL string, err error) {
+	return am.next.BeginFederatedSignin(ctx, provider, tenantName)
+}
+
+func (am auditMiddleware) BeginFederatedLink(ctx context.Context, provider string, userID string) (authURL string, err error) {
+	return am.next.BeginFederatedLink(ctx, provider, userID)
+}
+
+func (am auditMiddleware) FinishFederatedSignin(ctx context.Context, provider string, state string, code string, opt *SigninOption) (identity *entity.Identity, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, withSigninOption(identityEvent(audit.ActionFederatedSignin, "", identity, err), opt))
+	}()
+
+	return am.next.FinishFederatedSignin(ctx, provider, state, code, opt)
+}
+
+func (am auditMiddleware) Unlock(ctx context.Context, userID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionUnlockUser, principalActor(ctx, ""), userID, err))
+	}()
+
+	return am.next.Unlock(ctx, userID)
+}
+
+func (am auditMiddleware) VerifyEmail(ctx context.Context, token string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionVerifyEmail, principalActor(ctx, ""), "", err))
+	}()
+
+	return am.next.VerifyEmail(ctx, token)
+}
+
+func (am auditMiddleware) ResendVerification(ctx context.Context, username string, tenantName string) (err error) {
+	return am.next.ResendVerification(ctx, username, tenantName)
+}
+
+func (am auditMiddleware) RequestPasswordReset(ctx context.Context, email string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionRequestPasswordReset, email, "", err))
+	}()
+
+	return am.next.RequestPasswordReset(ctx, email)
+}
+
+func (am auditMiddleware) ResetPassword(ctx context.Context, token string, newPassword string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionResetPassword, principalActor(ctx, ""), "", err))
+	}()
+
+	return am.next.ResetPassword(ctx, token, newPassword)
+}
+
+func (am auditMiddleware) GetProfile(ctx context.Context, userID string) (user *entity.User, err error) {
+	return am.next.GetProfile(ctx, userID)
+}
+
+func (am auditMiddleware) UpdateProfile(ctx context.Context, userID string, opt *UpdateProfileOption) (user *entity.User, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionUpdateProfile, principalActor(ctx, userID), userID, err))
+	}()
+
+	return am.next.UpdateProfile(ctx, userID, opt)
+}
+
+func (am auditMiddleware) ChangePassword(ctx context.Context, userID string, currentPassword string, newPassword string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewEvent(audit.ActionChangePassword, principalActor(ctx, userID), userID, err))
+	}()
+
+	return am.next.ChangePassword(ctx, userID, currentPassword, newPassword)
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/app/identity/repository"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/ldap"
+	"github.com/karta0898098/iam/pkg/password"
+	"github.com/karta0898098/iam/pkg/tenant"
+)
+
+// ProviderLDAP is provider of identity linked to directory entry and idp provider of its session
+const ProviderLDAP = "ldap"
+
+// Authenticator verify password of username for Signin,
+// authenticators are tried in order until one of them accept the password
+type Authenticator interface {
+	// Name is recorded as idp provider of session, empty for local user
+	Name() string
+	// Authenticate return user of username,
+	// ErrResourceNotFound or ErrUnauthorized let next authenticator try
+	Authenticate(ctx context.Context, username string, password string) (user *entity.User, err error)
+}
+
+// localAuthenticator verify password stored in users table
+type localAuthenticator struct {
+	repo   repository.Repository
+	hasher password.Hasher
+}
+
+func (a *localAuthenticator) Name() string {
+	return ""
+}
+
+func (a *localAuthenticator) Authenticate(ctx context.Context, username string, password string) (*entity.User, error) {
+	user, err := a.repo.FindUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.ValidatePassword(password) {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"user=%s validate password failed",
+			user.ID,
+		)
+	}
+
+	// upgrade legacy or outdated hash while plaintext password is known
+	// failed to upgrade should not block user signin
+	if user.PasswordNeedsRehash(a.hasher) {
+		err = user.RehashPassword(a.hasher, password)
+		if err == nil {
+			err = a.repo.UpdatePassword(ctx, user)
+		}
+		if err != nil {
+			log.Ctx(ctx).
+				Warn().
+				Err(err).
+				Str("user_id", user.ID).
+				Msg("failed to rehash password")
+		}
+	}
+
+	return user, nil
+}
+
+// ldapAuthenticator verify password by binding to directory,
+// user is signed up at first signin and linked to its entry
+type ldapAuthenticator struct {
+	repo      repository.Repository
+	directory *ldap.Directory
+	tenants   TenantDirectory
+}
+
+// NewLDAPAuthenticator authenticate user against directory after local user,
+// nil if directory is not configured
+func NewLDAPAuthenticator(repo repository.Repository, directory *ldap.Directory, tenants TenantDirectory) Authenticator {
+	if directory == nil {
+		return nil
+	}
+
+	return &ldapAuthenticator{
+		repo:      repo,
+		directory: directory,
+		tenants:   tenants,
+	}
+}
+
+func (a *ldapAuthenticator) Name() string {
+	return ProviderLDAP
+}
+
+func (a *ldapAuthenticator) Authenticate(ctx context.Context, username string, password string) (*entity.User, error) {
+	tenantID, err := a.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// directory users belong to configured tenant only,
+	// they must not be signed up into whatever tenant is requested
+	if tenantID != tenant.ID(ctx) {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "tenant=%v is not tenant of directory", tenant.ID(ctx))
+	}
+
+	entry, err := a.directory.Authenticate(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := a.directory.Attributes()
+	email := entry.Get(attributes.Email)
+	// dn is case insensitive
+	subject := strings.ToLower(entry.DN)
+
+	linked, err := a.repo.FindExternalIdentity(ctx, ProviderLDAP, subject)
+	if err != nil && !errors.Is(err, errors.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	if linked != nil {
+		linked.Use(email)
+		err = a.repo.UpdateExternalIdentity(ctx, linked)
+		if err != nil {
+			log.Ctx(ctx).
+				Warn().
+				Err(err).
+				Str("user_id", linked.UserID).
+				Msg("failed to update external identity")
+		}
+
+		return a.repo.FindUserByID(ctx, linked.UserID)
+	}
+
+	// local user of the same username is someone else, directory can not take it over
+	_, err = a.repo.FindUserByUsername(ctx, username)
+	if err == nil {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "username=%v of directory entry is taken by local user", username)
+	}
+	if !errors.Is(err, errors.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	candidates := []entity.NewUserOption{
+		entity.WithFirstName(truncate(entry.Get(attributes.FirstName), entity.NameLengthMax)),
+		entity.WithLastName(truncate(entry.Get(attributes.LastName), entity.NameLengthMax)),
+	}
+	// directory is managed by organization, its email address is trusted as verified
+	if email != "" {
+		candidates = append(candidates, entity.WithEmail(email))
+	}
+
+	user, err := entity.NewDirectoryUser(xid.New().String(), username, profileOptions(tenantID, candidates)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.repo.StoreUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenantID != tenant.Default {
+		err = a.tenants.JoinTenant(ctx, tenantID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = a.repo.StoreExternalIdentity(ctx, entity.NewExternalIdentity(ProviderLDAP, subject, user.ID, tenantID, email))
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// tenant resolve id of tenant configured for directory
+func (a *ldapAuthenticator) tenant(ctx context.Context) (string, error) {
+	name := a.directory.Tenant()
+	if name == "" {
+		return tenant.Default, nil
+	}
+
+	if a.tenants == nil {
+		return "", errors.Wrapf(errors.ErrInternal, "tenant=%v of directory is not found, multi-tenancy is disabled", name)
+	}
+
+	return a.tenants.ResolveTenant(ctx, name)
+}
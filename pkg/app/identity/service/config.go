@@ -0,0 +1,26 @@
+package service
+
+import (
+	"time"
+)
+
+// ReasonEmailNotVerified error detail reason of signin rejected until email verified
+const ReasonEmailNotVerified = "email_not_verified"
+
+// EmailVerificationConfig for verifying email address of new user
+type EmailVerificationConfig struct {
+	// Required signup create not confirmed user, user can not signin until email verified
+	Required bool `mapstructure:"required"`
+	// URL of page verifying email, token is appended as query parameter
+	URL string `mapstructure:"url"`
+	// Lifetime of verification link, default is 24 hours
+	Lifetime time.Duration `mapstructure:"lifetime"`
+}
+
+// PasswordResetConfig for resetting forgotten password
+type PasswordResetConfig struct {
+	// URL of page resetting password, token is appended as query parameter
+	URL string `mapstructure:"url"`
+	// Lifetime of reset link, default is 30 minutes
+	Lifetime time.Duration `mapstructure:"lifetime"`
+}
@@ -2,12 +2,28 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
 
 	"github.com/karta0898098/iam/pkg/app/identity/entity"
 	"github.com/karta0898098/iam/pkg/app/identity/repository"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/authn"
 	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/federation"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/lockout"
+	"github.com/karta0898098/iam/pkg/mail"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/password"
+	"github.com/karta0898098/iam/pkg/tenant"
+	"github.com/karta0898098/iam/pkg/totp"
+	"github.com/karta0898098/iam/pkg/webauthn"
 )
 
 var _ IdentityService = &Impl{}
@@ -32,18 +48,267 @@ type IdentityService interface {
 		password string,
 		opts *SignupOption,
 	) (identity *entity.Identity, err error)
+
+	// Refresh exchange refresh token to a new token pair
+	// the refresh token can only be used once,
+	// replay it will revoke whole session family.
+	// clientID is oauth2 client the session must be issued to, empty for first-party signin
+	Refresh(
+		ctx context.Context,
+		refreshToken string,
+		clientID string,
+	) (identity *entity.Identity, err error)
+
+	// Introspect verify access token signature, expiry and session state
+	// invalid token is reported as inactive instead of error
+	Introspect(
+		ctx context.Context,
+		token string,
+	) (introspection *entity.Introspection, err error)
+
+	// Signout revoke current session
+	// tokens refreshed from the same sign in are revoked too
+	Signout(
+		ctx context.Context,
+		sessionID string,
+	) (err error)
+
+	// SignoutAll revoke every session of user
+	SignoutAll(
+		ctx context.Context,
+		userID string,
+	) (err error)
+
+	// ListSessions list active sessions of user with device information,
+	// current is id of the listed session the caller signed in with
+	ListSessions(
+		ctx context.Context,
+		userID string,
+		sessionID string,
+	) (sessions []*entity.Session, current string, err error)
+
+	// RevokeSession revoke the session owned by user
+	RevokeSession(
+		ctx context.Context,
+		userID string,
+		sessionID string,
+	) (err error)
+
+	// EnrollTOTP generate totp secret for user,
+	// enrollment is pending until first code confirmed
+	EnrollTOTP(
+		ctx context.Context,
+		userID string,
+	) (enrollment *entity.TOTPEnrollment, err error)
+
+	// ConfirmTOTP verify first code of pending enrollment
+	// and return one-time recovery codes
+	ConfirmTOTP(
+		ctx context.Context,
+		userID string,
+		code string,
+	) (recoveryCodes []string, err error)
+
+	// VerifyMFA complete mfa challenge of signin with totp or recovery code
+	VerifyMFA(
+		ctx context.Context,
+		mfaToken string,
+		code string,
+	) (identity *entity.Identity, err error)
+
+	// BeginWebAuthnRegistration create registration challenge of passkey,
+	// passkeys already registered by user are excluded
+	BeginWebAuthnRegistration(
+		ctx context.Context,
+		userID string,
+	) (options *entity.WebAuthnRegistrationOptions, err error)
+
+	// FinishWebAuthnRegistration verify attestation of challenge and store passkey of user
+	FinishWebAuthnRegistration(
+		ctx context.Context,
+		userID string,
+		challengeID string,
+		name string,
+		resp *webauthn.AttestationResponse,
+	) (credential *entity.WebAuthnCredential, err error)
+
+	// BeginWebAuthnSignin create authentication challenge for user of tenant,
+	// empty username let user pick discoverable passkey
+	BeginWebAuthnSignin(
+		ctx context.Context,
+		username string,
+		tenantName string,
+	) (options *entity.WebAuthnSigninOptions, err error)
+
+	// FinishWebAuthnSignin verify assertion of challenge and sign in without password
+	FinishWebAuthnSignin(
+		ctx context.Context,
+		challengeID string,
+		credentialID string,
+		resp *webauthn.AssertionResponse,
+		opt *SigninOption,
+	) (identity *entity.Identity, err error)
+
+	// BeginFederatedSignin start signin at upstream provider into tenant,
+	// user agent is redirected to returned authorization url
+	BeginFederatedSignin(
+		ctx context.Context,
+		provider string,
+		tenantName string,
+	) (authURL string, err error)
+
+	// BeginFederatedLink start linking upstream provider to signed in user
+	BeginFederatedLink(
+		ctx context.Context,
+		provider string,
+		userID string,
+	) (authURL string, err error)
+
+	// FinishFederatedSignin exchange code of authorization response and sign in user
+	// linked to provider subject, user is signed up when subject is not linked yet,
+	// link started by BeginFederatedLink must be finished by the same authenticated user
+	FinishFederatedSignin(
+		ctx context.Context,
+		provider string,
+		state string,
+		code string,
+		opt *SigninOption,
+	) (identity *entity.Identity, err error)
+
+	// Unlock clear signin failures and lockout of user
+	Unlock(
+		ctx context.Context,
+		userID string,
+	) (err error)
+
+	// VerifyEmail verify token of verification mail and activate user
+	VerifyEmail(
+		ctx context.Context,
+		token string,
+	) (err error)
+
+	// ResendVerification send verification mail again to user of tenant not confirmed,
+	// unknown or confirmed username is ignored silently
+	ResendVerification(
+		ctx context.Context,
+		username string,
+		tenantName string,
+	) (err error)
+
+	// RequestPasswordReset mail reset link to active users of email,
+	// unknown email is ignored silently so response never tell account exist
+	RequestPasswordReset(
+		ctx context.Context,
+		email string,
+	) (err error)
+
+	// ResetPassword set new password with single-use reset token,
+	// every session of user is revoked
+	ResetPassword(
+		ctx context.Context,
+		token string,
+		newPassword string,
+	) (err error)
+
+	// GetProfile get profile of user
+	GetProfile(
+		ctx context.Context,
+		userID string,
+	) (user *entity.User, err error)
+
+	// UpdateProfile update profile of user, ErrConflict is returned
+	// when version of option is not the latest one,
+	// changed email is mailed a confirmation link and applied by VerifyEmail when verification is required
+	UpdateProfile(
+		ctx context.Context,
+		userID string,
+		opt *UpdateProfileOption,
+	) (user *entity.User, err error)
+
+	// ChangePassword replace password of user, current password is required
+	ChangePassword(
+		ctx context.Context,
+		userID string,
+		currentPassword string,
+		newPassword string,
+	) (err error)
+}
+
+// RoleResolver resolve names of role bound to user,
+// they are embedded into access token at every issuance
+type RoleResolver interface {
+	UserRoles(ctx context.Context, userID string) (roles []string, err error)
+}
+
+// TenantDirectory resolve tenant of signin and signup by name,
+// user signed up into tenant become its member
+type TenantDirectory interface {
+	ResolveTenant(ctx context.Context, name string) (tenantID string, err error)
+	JoinTenant(ctx context.Context, tenantID string, userID string) (err error)
 }
 
 type Impl struct {
-	repo repository.Repository
+	repo       repository.Repository
+	keys       keys.KeyManager
+	hasher     password.Hasher
+	totp       totp.Config
+	webauthn   *webauthn.RelyingParty
+	federation *federation.Federation
+	lockout    *lockout.Guard
+	mailer     mail.Mailer
+	roles      RoleResolver
+	tenants    TenantDirectory
+
+	// authenticators verify password of Signin, local user first
+	authenticators []Authenticator
+
+	verification  EmailVerificationConfig
+	passwordReset PasswordResetConfig
 }
 
-func New(repo repository.Repository) IdentityService {
+func New(
+	repo repository.Repository,
+	km keys.KeyManager,
+	hasher password.Hasher,
+	totpConfig totp.Config,
+	rp *webauthn.RelyingParty,
+	fed *federation.Federation,
+	guard *lockout.Guard,
+	mailer mail.Mailer,
+	verification EmailVerificationConfig,
+	passwordReset PasswordResetConfig,
+	roles RoleResolver,
+	tenants TenantDirectory,
+	directory Authenticator,
+	recorder audit.Recorder,
+) IdentityService {
+	authenticators := []Authenticator{&localAuthenticator{repo: repo, hasher: hasher}}
+	if directory != nil {
+		authenticators = append(authenticators, directory)
+	}
+
 	var svc IdentityService
 	svc = &Impl{
-		repo: repo,
+		repo:       repo,
+		keys:       km,
+		hasher:     hasher,
+		totp:       totpConfig,
+		webauthn:   rp,
+		federation: fed,
+		lockout:    guard,
+		mailer:     mailer,
+		roles:      roles,
+		tenants:    tenants,
+
+		authenticators: authenticators,
+
+		verification:  verification,
+		passwordReset: passwordReset,
 	}
 	svc = LoggingMiddleware()(svc)
+	if recorder != nil {
+		svc = AuditMiddleware(recorder)(svc)
+	}
 
 	return svc
 }
@@ -54,15 +319,33 @@ func (srv *Impl) Signin(
 	password string,
 	opt *SigninOption,
 ) (identity *entity.Identity, err error) {
-	user, err := srv.repo.FindUserByUsername(ctx, username)
+	ctx, tenantID, err := srv.withTenant(ctx, opt.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	// the same username in other tenant is other account
+	account := tenant.Qualify(tenantID, username)
+
+	err = srv.lockout.Check(ctx, account, opt.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	user, provider, err := srv.authenticate(ctx, username, password)
 	if err != nil {
+		// unknown username is counted too, otherwise lockout tell which account exist
+		if errors.Is(err, errors.ErrResourceNotFound) || errors.Is(err, errors.ErrUnauthorized) {
+			srv.signinFailed(ctx, account, opt.IPAddress)
+		}
 		return nil, err
 	}
 
-	if !user.ValidatePassword(password) {
+	// only told after password verified, client can offer resending verification mail
+	if user.IsNotConfirmed() {
 		return nil, errors.Wrapf(
-			errors.ErrUnauthorized,
-			"user=%s validate password failed",
+			errors.ErrForbidden.WithDetails(errors.Detail{Reason: ReasonEmailNotVerified}),
+			"user=%s email is not verified",
 			user.ID,
 		)
 	}
@@ -75,23 +358,225 @@ func (srv *Impl) Signin(
 		)
 	}
 
+	sessionOpts := []entity.NewSessionOption{
+		entity.WithDevice(opt.Device),
+		entity.WithSessionTenant(user.TenantID),
+	}
+	// password verified by directory is still password, not federation
+	if provider != "" {
+		sessionOpts = append(sessionOpts, entity.WithIdpProvider(provider), entity.WithAuthMethod(oidc.AuthMethodPassword))
+	}
 	session := entity.NewSession(
 		xid.New().String(),
 		user.ID,
 		opt.IPAddress,
 		opt.Platform,
-		entity.WithDevice(opt.Device),
+		sessionOpts...,
 	)
 
+	// session is stored after second factor verified
+	mfaRequired, err := srv.mfaRequired(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaRequired {
+		// failures are kept until second factor verified
+		return &entity.Identity{
+			User:        user,
+			Session:     session,
+			MFARequired: true,
+		}, nil
+	}
+
 	err = srv.repo.StoreSession(ctx, session)
 	if err != nil {
 		return nil, err
 	}
 
-	return &entity.Identity{
+	srv.signinSucceeded(ctx, account)
+
+	return srv.withRoles(ctx, &entity.Identity{
 		User:    user,
 		Session: session,
-	}, nil
+	})
+}
+
+// authenticate verify password by authenticators in order and return name of the one accepted it,
+// ErrResourceNotFound is returned only if none of them know the username
+func (srv *Impl) authenticate(ctx context.Context, username string, password string) (*entity.User, string, error) {
+	var failure error
+	for _, authenticator := range srv.authenticators {
+		user, err := authenticator.Authenticate(ctx, username, password)
+		if err == nil {
+			return user, authenticator.Name(), nil
+		}
+		if !errors.Is(err, errors.ErrResourceNotFound) && !errors.Is(err, errors.ErrUnauthorized) {
+			return nil, "", err
+		}
+		if failure == nil || errors.Is(failure, errors.ErrResourceNotFound) {
+			failure = err
+		}
+	}
+
+	return nil, "", failure
+}
+
+// withTenant resolve tenant name and return context scoped to the tenant,
+// empty name is default tenant
+func (srv *Impl) withTenant(ctx context.Context, name string) (context.Context, string, error) {
+	if name == "" {
+		return tenant.NewContext(ctx, tenant.Default), tenant.Default, nil
+	}
+
+	if srv.tenants == nil {
+		return ctx, "", errors.Wrapf(errors.ErrInvalidInput, "tenant=%v is not found, multi-tenancy is disabled", name)
+	}
+
+	tenantID, err := srv.tenants.ResolveTenant(ctx, name)
+	if err != nil {
+		return ctx, "", err
+	}
+
+	return tenant.NewContext(ctx, tenantID), tenantID, nil
+}
+
+// signinFailed count failure of username and ip address,
+// failed to record should not hide the signin error
+func (srv *Impl) signinFailed(ctx context.Context, username string, ip string) {
+	err := srv.lockout.Fail(ctx, username, ip)
+	if err != nil {
+		log.Ctx(ctx).
+			Warn().
+			Err(err).
+			Str("username", username).
+			Msg("failed to record signin failure")
+	}
+}
+
+// signinSucceeded reset failures of username
+func (srv *Impl) signinSucceeded(ctx context.Context, username string) {
+	err := srv.lockout.Succeed(ctx, username)
+	if err != nil {
+		log.Ctx(ctx).
+			Warn().
+			Err(err).
+			Str("username", username).
+			Msg("failed to reset signin failures")
+	}
+}
+
+func (srv *Impl) Unlock(ctx context.Context, userID string) (err error) {
+	user, err := srv.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return srv.lockout.Unlock(ctx, tenant.Qualify(user.TenantID, user.Username))
+}
+
+func (srv *Impl) GetProfile(ctx context.Context, userID string) (user *entity.User, err error) {
+	return srv.repo.FindUserByID(ctx, userID)
+}
+
+func (srv *Impl) UpdateProfile(ctx context.Context, userID string, opt *UpdateProfileOption) (user *entity.User, err error) {
+	user, err = srv.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// fail fast without validation, repository still compare version on update
+	if opt.Version != user.Version {
+		return nil, errors.Wrapf(errors.ErrConflict, "user=%v version=%v is stale, latest is %v", user.ID, opt.Version, user.Version)
+	}
+
+	var opts []entity.NewUserOption
+	if opt.Nickname != nil {
+		opts = append(opts, entity.WithNickname(*opt.Nickname))
+	}
+	if opt.FirstName != nil {
+		opts = append(opts, entity.WithFirstName(*opt.FirstName))
+	}
+	if opt.LastName != nil {
+		opts = append(opts, entity.WithLastName(*opt.LastName))
+	}
+	if opt.Avatar != nil {
+		opts = append(opts, entity.WithAvatar(*opt.Avatar))
+	}
+
+	// new email is kept until owner of the address confirm it,
+	// otherwise password reset could be mailed to address of someone else
+	var (
+		changedEmail string
+		changeToken  string
+	)
+	if opt.Email != nil {
+		if !srv.verification.Required {
+			opts = append(opts, entity.WithEmail(*opt.Email))
+		} else if email := strings.ToLower(*opt.Email); email != user.Email {
+			changeToken, err = user.NewEmailChangeToken(srv.keys, email, srv.verification.Lifetime)
+			if err != nil {
+				return nil, err
+			}
+			changedEmail = email
+		}
+	}
+
+	err = user.UpdateProfile(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.UpdateUser(ctx, user, opt.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if changeToken != "" {
+		err = srv.sendEmailChangeEmail(ctx, user, changedEmail, changeToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+func (srv *Impl) ChangePassword(ctx context.Context, userID string, currentPassword string, newPassword string) (err error) {
+	user, err := srv.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// guessing current password with stolen access token is limited as signin
+	account := tenant.Qualify(user.TenantID, user.Username)
+	err = srv.lockout.Check(ctx, account, "")
+	if err != nil {
+		return err
+	}
+
+	version := user.Version
+	err = user.ChangePassword(srv.hasher, currentPassword, newPassword)
+	if err != nil {
+		if errors.Is(err, errors.ErrUnauthorized) {
+			srv.signinFailed(ctx, account, "")
+		}
+		return err
+	}
+
+	return srv.repo.UpdateUser(ctx, user, version)
+}
+
+// mfaRequired user has confirmed second factor
+func (srv *Impl) mfaRequired(ctx context.Context, userID string) (bool, error) {
+	factor, err := srv.repo.FindTOTPFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return factor.IsConfirmed(), nil
 }
 
 func (srv *Impl) Signup(
@@ -100,14 +585,27 @@ func (srv *Impl) Signup(
 	password string,
 	opt *SignupOption,
 ) (identity *entity.Identity, err error) {
+	ctx, tenantID, err := srv.withTenant(ctx, opt.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []entity.NewUserOption{
+		entity.WithEmail(opt.Email),
+		entity.WithNickname(opt.Nickname),
+		entity.WithPasswordHasher(srv.hasher),
+		entity.WithTenant(tenantID),
+	}
+	if srv.verification.Required {
+		opts = append(opts, entity.WithEmailVerificationRequired())
+	}
 
 	// build a new user
 	newUser, err := entity.NewUser(
 		xid.New().String(),
 		username,
 		password,
-		entity.WithEmail(opt.Email),
-		entity.WithNickname(opt.Nickname),
+		opts...,
 	)
 	if err != nil {
 		return nil, err
@@ -134,6 +632,30 @@ func (srv *Impl) Signup(
 		return nil, err
 	}
 
+	if tenantID != tenant.Default {
+		err = srv.tenants.JoinTenant(ctx, tenantID, newUser.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// session is created after email verified and user signin,
+	// failed to send mail should not fail signup since user can resend
+	if newUser.IsNotConfirmed() {
+		err = srv.sendVerificationEmail(ctx, newUser)
+		if err != nil {
+			log.Ctx(ctx).
+				Warn().
+				Err(err).
+				Str("user_id", newUser.ID).
+				Msg("failed to send verification email")
+		}
+
+		return &entity.Identity{
+			User: newUser,
+		}, nil
+	}
+
 	// create session to record
 	session := entity.NewSession(
 		xid.New().String(),
@@ -141,6 +663,7 @@ func (srv *Impl) Signup(
 		opt.IPAddress,
 		opt.Platform,
 		entity.WithDevice(opt.Device),
+		entity.WithSessionTenant(tenantID),
 	)
 
 	err = srv.repo.StoreSession(ctx, session)
@@ -148,8 +671,1189 @@ func (srv *Impl) Signup(
 		return nil, err
 	}
 
-	return &entity.Identity{
+	return srv.withRoles(ctx, &entity.Identity{
 		User:    newUser,
 		Session: session,
-	}, nil
+	})
+}
+
+func (srv *Impl) Refresh(
+	ctx context.Context,
+	refreshToken string,
+	clientID string,
+) (identity *entity.Identity, err error) {
+	claims, err := entity.ParseToken(srv.keys, refreshToken, entity.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := srv.repo.FindSessionByID(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, errors.Wrapf(
+				errors.ErrUnauthorized,
+				"session=%v of refresh token not exist",
+				claims.ID,
+			)
+		}
+		return nil, err
+	}
+
+	if session.UserID != claims.Subject {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"session=%v not belong to user=%v",
+			session.ID, claims.Subject,
+		)
+	}
+
+	// session issued to oauth2 client is refreshed by token endpoint authenticating the client
+	if session.ClientID != clientID {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"session=%v issued to client=%v not client=%v",
+			session.ID, session.ClientID, clientID,
+		)
+	}
+
+	if session.IsRevoked() {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"session=%v already revoked",
+			session.ID,
+		)
+	}
+
+	// refresh token already exchanged, someone replay it
+	// then revoke all sessions issued from the same sign in
+	if session.IsRotated() {
+		return nil, srv.revokeSessionFamily(ctx, session)
+	}
+
+	ctx = tenant.NewContext(ctx, session.TenantID)
+
+	user, err := srv.repo.FindUserByID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"user=%s is not active status=%v",
+			user.ID, user.Status,
+		)
+	}
+
+	next := session.Rotate(xid.New().String())
+
+	err = srv.repo.RotateSession(ctx, session, next)
+	if err != nil {
+		if errors.Is(err, errors.ErrConflict) {
+			return nil, srv.revokeSessionFamily(ctx, session)
+		}
+		return nil, err
+	}
+
+	return srv.withRoles(ctx, &entity.Identity{
+		User:    user,
+		Session: next,
+	})
+}
+
+// withRoles put roles of user into identity before tokens issued,
+// they are resolved again at refresh so changed binding take effect
+func (srv *Impl) withRoles(ctx context.Context, identity *entity.Identity) (*entity.Identity, error) {
+	if srv.roles == nil {
+		return identity, nil
+	}
+
+	roles, err := srv.roles.UserRoles(ctx, identity.User.ID)
+	if err != nil {
+		return nil, err
+	}
+	identity.Roles = roles
+
+	return identity, nil
+}
+
+// revokeSessionFamily revoke all sessions in the family of replayed session
+func (srv *Impl) revokeSessionFamily(ctx context.Context, session *entity.Session) error {
+	err := srv.repo.RevokeSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrapf(
+		errors.ErrUnauthorized,
+		"refresh token of session=%v replayed, family=%v revoked",
+		session.ID, session.FamilyID,
+	)
+}
+
+func (srv *Impl) Introspect(
+	ctx context.Context,
+	token string,
+) (introspection *entity.Introspection, err error) {
+	claims, session, err := srv.verifyAccessToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, errors.ErrUnauthorized) {
+			return &entity.Introspection{Active: false}, nil
+		}
+		return nil, err
+	}
+
+	return entity.NewIntrospection(claims, session), nil
+}
+
+// verifyAccessToken check access token is signed by iam and not expired,
+// then check the session of token still exist
+func (srv *Impl) verifyAccessToken(ctx context.Context, token string) (*entity.Claims, *entity.Session, error) {
+	claims, err := entity.ParseToken(srv.keys, token, entity.TokenTypeAccess)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session, err := srv.repo.FindSessionByID(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, nil, errors.Wrapf(
+				errors.ErrUnauthorized,
+				"session=%v of access token not exist",
+				claims.ID,
+			)
+		}
+		return nil, nil, err
+	}
+
+	if session.UserID != claims.Subject {
+		return nil, nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"session=%v not belong to user=%v",
+			session.ID, claims.Subject,
+		)
+	}
+
+	if session.IsRevoked() {
+		return nil, nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"session=%v already revoked",
+			session.ID,
+		)
+	}
+
+	return claims, session, nil
+}
+
+func (srv *Impl) Signout(
+	ctx context.Context,
+	sessionID string,
+) (err error) {
+	session, err := srv.repo.FindSessionByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return srv.repo.RevokeSessionFamily(ctx, session.FamilyID)
+}
+
+func (srv *Impl) SignoutAll(
+	ctx context.Context,
+	userID string,
+) (err error) {
+	return srv.repo.RevokeUserSessions(ctx, userID)
+}
+
+func (srv *Impl) ListSessions(
+	ctx context.Context,
+	userID string,
+	sessionID string,
+) (sessions []*entity.Session, current string, err error) {
+	sessions, err = srv.repo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// access token issued before refresh still carry the rotated session,
+	// the current one is the latest session of the same family
+	session, err := srv.repo.FindSessionByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return sessions, "", nil
+		}
+		return nil, "", err
+	}
+
+	for _, active := range sessions {
+		if active.FamilyID == session.FamilyID {
+			current = active.ID
+		}
+	}
+
+	return sessions, current, nil
+}
+
+func (srv *Impl) RevokeSession(
+	ctx context.Context,
+	userID string,
+	sessionID string,
+) (err error) {
+	session, err := srv.repo.FindSessionByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	// hide other user session existence
+	if session.UserID != userID {
+		return errors.Wrapf(
+			errors.ErrResourceNotFound,
+			"session=%v not belong to user=%v",
+			sessionID, userID,
+		)
+	}
+
+	return srv.repo.RevokeSessionFamily(ctx, session.FamilyID)
+}
+
+func (srv *Impl) EnrollTOTP(
+	ctx context.Context,
+	userID string,
+) (enrollment *entity.TOTPEnrollment, err error) {
+	user, err := srv.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	mfaRequired, err := srv.mfaRequired(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaRequired {
+		return nil, errors.Wrapf(errors.ErrConflict, "user=%v totp already enrolled", user.ID)
+	}
+
+	factor, err := entity.NewTOTPFactor(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.StoreTOTPFactor(ctx, factor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.TOTPEnrollment{
+		Secret: factor.Secret,
+		URI:    totp.URI(srv.totp.Issuer, user.Username, factor.Secret),
+	}, nil
+}
+
+func (srv *Impl) ConfirmTOTP(
+	ctx context.Context,
+	userID string,
+	code string,
+) (recoveryCodes []string, err error) {
+	factor, err := srv.repo.FindTOTPFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if factor.IsConfirmed() {
+		return nil, errors.Wrapf(errors.ErrConflict, "user=%v totp already enrolled", userID)
+	}
+
+	if !factor.Verify(code, srv.totp.Skew) {
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "user=%v totp code is invalid", userID)
+	}
+	factor.Confirm()
+
+	recoveryCodes, hashes, err := entity.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.ConfirmTOTPFactor(ctx, factor, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (srv *Impl) VerifyMFA(
+	ctx context.Context,
+	mfaToken string,
+	code string,
+) (identity *entity.Identity, err error) {
+	claims, err := entity.ParseMFAToken(srv.keys, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tenant.NewContext(ctx, claims.Tenant)
+
+	user, err := srv.repo.FindUserByID(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, errors.Wrapf(errors.ErrUnauthorized, "user=%v of mfa token not exist", claims.Subject)
+		}
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"user=%s is not active status=%v",
+			user.ID, user.Status,
+		)
+	}
+
+	factor, err := srv.repo.FindTOTPFactor(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, errors.Wrapf(errors.ErrUnauthorized, "user=%v has no totp factor", user.ID)
+		}
+		return nil, err
+	}
+
+	if !factor.IsConfirmed() {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "user=%v totp is not confirmed", user.ID)
+	}
+
+	// guessing second factor is limited by the same counter as password
+	account := tenant.Qualify(user.TenantID, user.Username)
+	err = srv.lockout.Check(ctx, account, "")
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.verifySecondFactor(ctx, factor, code)
+	if err != nil {
+		if errors.Is(err, errors.ErrUnauthorized) {
+			srv.signinFailed(ctx, account, "")
+		}
+		return nil, err
+	}
+
+	session := claims.NewSession(xid.New().String())
+	err = srv.repo.StoreSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	srv.signinSucceeded(ctx, account)
+
+	return srv.withRoles(ctx, &entity.Identity{
+		User:    user,
+		Session: session,
+	})
+}
+
+// verifySecondFactor accept totp code or unused recovery code
+func (srv *Impl) verifySecondFactor(ctx context.Context, factor *entity.TOTPFactor, code string) error {
+	if len(code) != totp.Digits {
+		err := srv.repo.ConsumeRecoveryCode(ctx, factor.UserID, entity.HashRecoveryCode(code))
+		if err != nil {
+			if errors.Is(err, errors.ErrResourceNotFound) {
+				return errors.Wrapf(errors.ErrUnauthorized, "user=%v recovery code is invalid", factor.UserID)
+			}
+			return err
+		}
+		return nil
+	}
+
+	if !factor.Verify(code, srv.totp.Skew) {
+		return errors.Wrapf(errors.ErrUnauthorized, "user=%v totp code is invalid", factor.UserID)
+	}
+
+	// concurrent verify with same code only one can pass
+	err := srv.repo.UpdateTOTPCounter(ctx, factor)
+	if err != nil {
+		if errors.Is(err, errors.ErrConflict) {
+			return errors.Wrapf(errors.ErrUnauthorized, "user=%v totp code is replayed", factor.UserID)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (srv *Impl) BeginWebAuthnRegistration(
+	ctx context.Context,
+	userID string,
+) (options *entity.WebAuthnRegistrationOptions, err error) {
+	user, err := srv.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := srv.repo.ListWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := make([][]byte, 0, len(credentials))
+	for _, credential := range credentials {
+		exclude = append(exclude, credential.RawID())
+	}
+
+	challenge, err := entity.NewWebAuthnChallenge(xid.New().String(), user.ID, entity.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.StoreWebAuthnChallenge(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := user.Nickname
+	if displayName == "" {
+		displayName = user.Username
+	}
+
+	return &entity.WebAuthnRegistrationOptions{
+		ChallengeID: challenge.ID,
+		PublicKey: srv.webauthn.CreationOptions(
+			challenge.Challenge,
+			webauthn.UserEntity{
+				ID:          []byte(user.ID),
+				Name:        user.Username,
+				DisplayName: displayName,
+			},
+			exclude,
+		),
+	}, nil
+}
+
+func (srv *Impl) FinishWebAuthnRegistration(
+	ctx context.Context,
+	userID string,
+	challengeID string,
+	name string,
+	resp *webauthn.AttestationResponse,
+) (credential *entity.WebAuthnCredential, err error) {
+	challenge, err := srv.consumeWebAuthnChallenge(ctx, challengeID, entity.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.UserID != userID {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "webauthn challenge=%v not belong to user=%v", challengeID, userID)
+	}
+
+	verified, err := srv.webauthn.VerifyRegistration(challenge.Challenge, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	credential = entity.NewWebAuthnCredential(userID, name, verified)
+	err = srv.repo.StoreWebAuthnCredential(ctx, credential)
+	if err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+func (srv *Impl) BeginWebAuthnSignin(
+	ctx context.Context,
+	username string,
+	tenantName string,
+) (options *entity.WebAuthnSigninOptions, err error) {
+	var (
+		userID string
+		allow  [][]byte
+	)
+
+	ctx, _, err = srv.withTenant(ctx, tenantName)
+	if err != nil {
+		return nil, err
+	}
+
+	// unknown username fallback to discoverable credential
+	// so the response not reveal whether user exist
+	if username != "" {
+		user, err := srv.repo.FindUserByUsername(ctx, username)
+		if err != nil && !errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, err
+		}
+
+		if user != nil {
+			credentials, err := srv.repo.ListWebAuthnCredentials(ctx, user.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, credential := range credentials {
+				allow = append(allow, credential.RawID())
+			}
+			if len(allow) != 0 {
+				userID = user.ID
+			}
+		}
+	}
+
+	challenge, err := entity.NewWebAuthnChallenge(xid.New().String(), userID, entity.WebAuthnCeremonyAuthentication)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.StoreWebAuthnChallenge(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.WebAuthnSigninOptions{
+		ChallengeID: challenge.ID,
+		PublicKey:   srv.webauthn.RequestOptions(challenge.Challenge, allow),
+	}, nil
+}
+
+func (srv *Impl) FinishWebAuthnSignin(
+	ctx context.Context,
+	challengeID string,
+	credentialID string,
+	resp *webauthn.AssertionResponse,
+	opt *SigninOption,
+) (identity *entity.Identity, err error) {
+	challenge, err := srv.consumeWebAuthnChallenge(ctx, challengeID, entity.WebAuthnCeremonyAuthentication)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := srv.repo.FindWebAuthnCredential(ctx, credentialID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, errors.Wrapf(errors.ErrUnauthorized, "webauthn credential=%v not registered", credentialID)
+		}
+		return nil, err
+	}
+
+	if challenge.UserID != "" && challenge.UserID != credential.UserID {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "webauthn credential=%v not allowed by challenge", credentialID)
+	}
+
+	if len(resp.UserHandle) != 0 && string(resp.UserHandle) != credential.UserID {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "webauthn user handle not match owner of credential=%v", credentialID)
+	}
+
+	signCount, err := srv.webauthn.VerifyAssertion(challenge.Challenge, credential.Credential(), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := srv.repo.FindUserByID(ctx, credential.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"user=%s is not active status=%v",
+			user.ID, user.Status,
+		)
+	}
+
+	// concurrent assertion with same sign count only one can pass
+	credential.Use(signCount)
+	err = srv.repo.UpdateWebAuthnSignCount(ctx, credential)
+	if err != nil {
+		if errors.Is(err, errors.ErrConflict) {
+			return nil, errors.Wrapf(errors.ErrUnauthorized, "webauthn credential=%v sign count is replayed", credentialID)
+		}
+		return nil, err
+	}
+
+	session := entity.NewSession(
+		xid.New().String(),
+		user.ID,
+		opt.IPAddress,
+		opt.Platform,
+		entity.WithDevice(opt.Device),
+		entity.WithAuthMethod(oidc.AuthMethodProofOfPossession),
+		entity.WithSessionTenant(user.TenantID),
+	)
+
+	err = srv.repo.StoreSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.withRoles(ctx, &entity.Identity{
+		User:    user,
+		Session: session,
+	})
+}
+
+// consumeWebAuthnChallenge consume challenge once and check its ceremony
+func (srv *Impl) consumeWebAuthnChallenge(ctx context.Context, challengeID string, ceremony string) (*entity.WebAuthnChallenge, error) {
+	challenge, err := srv.repo.ConsumeWebAuthnChallenge(ctx, challengeID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, errors.Wrapf(errors.ErrUnauthorized, "webauthn challenge=%v not exist or used", challengeID)
+		}
+		return nil, err
+	}
+
+	err = challenge.Validate(ceremony)
+	if err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+func (srv *Impl) BeginFederatedSignin(
+	ctx context.Context,
+	provider string,
+	tenantName string,
+) (authURL string, err error) {
+	_, tenantID, err := srv.withTenant(ctx, tenantName)
+	if err != nil {
+		return "", err
+	}
+
+	return srv.beginFederation(ctx, provider, tenantID, "")
+}
+
+func (srv *Impl) BeginFederatedLink(
+	ctx context.Context,
+	provider string,
+	userID string,
+) (authURL string, err error) {
+	user, err := srv.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return srv.beginFederation(ctx, provider, user.TenantID, user.ID)
+}
+
+// beginFederation store state of pending signin and build authorization url of provider
+func (srv *Impl) beginFederation(ctx context.Context, provider string, tenantID string, userID string) (string, error) {
+	p, err := srv.federation.Provider(provider)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := entity.NewFederationState(p.Name(), tenantID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	// metadata of provider is fetched before state stored,
+	// so unreachable provider leave nothing behind
+	authURL, err := p.AuthCodeURL(ctx, state.ID, state.Nonce, state.CodeVerifier)
+	if err != nil {
+		return "", err
+	}
+
+	err = srv.repo.StoreFederationState(ctx, state)
+	if err != nil {
+		return "", err
+	}
+
+	return authURL, nil
+}
+
+func (srv *Impl) FinishFederatedSignin(
+	ctx context.Context,
+	provider string,
+	stateID string,
+	code string,
+	opt *SigninOption,
+) (identity *entity.Identity, err error) {
+	p, err := srv.federation.Provider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := srv.repo.ConsumeFederationState(ctx, stateID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, errors.Wrapf(errors.ErrUnauthorized, "federation state of provider=%v not exist or used", provider)
+		}
+		return nil, err
+	}
+
+	err = state.Validate(p.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	// link is finished by the user started it, otherwise url of provider sent to other person
+	// would attach identity of that person to the account of the sender
+	if state.UserID != "" {
+		principal, ok := authn.FromContext(ctx)
+		if !ok || principal.UserID != state.UserID {
+			return nil, errors.Wrapf(errors.ErrForbidden, "link to provider=%v is not finished by user started it", provider)
+		}
+	}
+
+	ctx = tenant.NewContext(ctx, state.TenantID)
+
+	claims, err := p.Authenticate(ctx, code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := srv.federatedUser(ctx, state, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"user=%s is not active status=%v",
+			user.ID, user.Status,
+		)
+	}
+
+	session := entity.NewSession(
+		xid.New().String(),
+		user.ID,
+		opt.IPAddress,
+		opt.Platform,
+		entity.WithDevice(opt.Device),
+		entity.WithIdpProvider(p.Name()),
+		entity.WithSessionTenant(user.TenantID),
+	)
+
+	// provider is trusted for first factor only, enrolled second factor is still required
+	mfaRequired, err := srv.mfaRequired(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaRequired {
+		return &entity.Identity{
+			User:        user,
+			Session:     session,
+			MFARequired: true,
+		}, nil
+	}
+
+	err = srv.repo.StoreSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.withRoles(ctx, &entity.Identity{
+		User:    user,
+		Session: session,
+	})
+}
+
+// federatedUser find user linked to subject of claims,
+// subject not linked yet is linked to user of state or a new user signed up for it
+func (srv *Impl) federatedUser(ctx context.Context, state *entity.FederationState, claims *federation.Claims) (*entity.User, error) {
+	linked, err := srv.repo.FindExternalIdentity(ctx, state.Provider, claims.Subject)
+	if err != nil && !errors.Is(err, errors.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	if linked != nil {
+		if state.UserID != "" && state.UserID != linked.UserID {
+			return nil, errors.Wrapf(errors.ErrConflict, "identity of provider=%v already linked to other user", state.Provider)
+		}
+
+		// email of provider is informational, failed to record should not block signin
+		linked.Use(claims.Email)
+		err = srv.repo.UpdateExternalIdentity(ctx, linked)
+		if err != nil {
+			log.Ctx(ctx).
+				Warn().
+				Err(err).
+				Str("user_id", linked.UserID).
+				Msg("failed to update external identity")
+		}
+
+		return srv.repo.FindUserByID(ctx, linked.UserID)
+	}
+
+	var user *entity.User
+	if state.UserID != "" {
+		user, err = srv.repo.FindUserByID(ctx, state.UserID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		user, err = entity.NewFederatedUser(xid.New().String(), federatedUserOptions(state.TenantID, claims)...)
+		if err != nil {
+			return nil, err
+		}
+
+		err = srv.repo.StoreUser(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		if state.TenantID != tenant.Default {
+			err = srv.tenants.JoinTenant(ctx, state.TenantID, user.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = srv.repo.StoreExternalIdentity(ctx, entity.NewExternalIdentity(state.Provider, claims.Subject, user.ID, state.TenantID, claims.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// federatedUserOptions build profile of user signed up by provider
+func federatedUserOptions(tenantID string, claims *federation.Claims) []entity.NewUserOption {
+	nickname := claims.Name
+	if nickname == "" {
+		nickname = claims.PreferredUsername
+	}
+
+	candidates := []entity.NewUserOption{
+		entity.WithNickname(truncate(nickname, entity.NameLengthMax)),
+		entity.WithFirstName(truncate(claims.GivenName, entity.NameLengthMax)),
+		entity.WithLastName(truncate(claims.FamilyName, entity.NameLengthMax)),
+		entity.WithAvatar(claims.Picture),
+	}
+	// unverified email may belong to others, it is not trusted for password reset mail
+	if claims.EmailVerified {
+		candidates = append(candidates, entity.WithEmail(claims.Email))
+	}
+
+	return profileOptions(tenantID, candidates)
+}
+
+// profileOptions keep profile options of external identity accepted by profile rule,
+// rejected value is left empty instead of failing signin
+func profileOptions(tenantID string, candidates []entity.NewUserOption) []entity.NewUserOption {
+	opts := []entity.NewUserOption{entity.WithTenant(tenantID)}
+	for _, opt := range candidates {
+		if opt(&entity.User{}) == nil {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
+
+// truncate s to at most n bytes without splitting character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	end := 0
+	for i := range s {
+		if i > n {
+			break
+		}
+		end = i
+	}
+	return s[:end]
+}
+
+func (srv *Impl) VerifyEmail(ctx context.Context, token string) (err error) {
+	claims, err := entity.ParseEmailVerificationToken(srv.keys, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := srv.repo.FindUserByID(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return errors.Wrapf(errors.ErrUnauthorized, "user=%v of email verification token not exist", claims.Subject)
+		}
+		return err
+	}
+
+	if claims.TokenType == entity.TokenTypeEmailChange {
+		return srv.confirmEmailChange(ctx, user, claims)
+	}
+
+	if user.Email != claims.Email {
+		return errors.Wrapf(errors.ErrUnauthorized, "user=%v email is changed after verification token issued", user.ID)
+	}
+
+	// link opened twice is not an error
+	if user.IsActive() {
+		return nil
+	}
+
+	if !user.IsNotConfirmed() {
+		return errors.Wrapf(
+			errors.ErrUnauthorized,
+			"user=%s is not waiting email confirmation status=%v",
+			user.ID, user.Status,
+		)
+	}
+
+	user.ConfirmEmail()
+	return srv.repo.ConfirmUserEmail(ctx, user)
+}
+
+// confirmEmailChange replace email of user by address confirmed by email change token
+func (srv *Impl) confirmEmailChange(ctx context.Context, user *entity.User, claims *entity.EmailVerificationClaims) error {
+	// link opened twice is not an error
+	if user.Email == claims.Email {
+		return nil
+	}
+
+	if user.Email != claims.PreviousEmail {
+		return errors.Wrapf(errors.ErrUnauthorized, "user=%v email is changed after email change token issued", user.ID)
+	}
+
+	if !user.IsActive() {
+		return errors.Wrapf(
+			errors.ErrUnauthorized,
+			"user=%s is not active status=%v",
+			user.ID, user.Status,
+		)
+	}
+
+	version := user.Version
+	err := user.UpdateProfile(entity.WithEmail(claims.Email))
+	if err != nil {
+		return err
+	}
+
+	return srv.repo.UpdateUser(ctx, user, version)
+}
+
+func (srv *Impl) ResendVerification(ctx context.Context, username string, tenantName string) (err error) {
+	ctx, _, err = srv.withTenant(ctx, tenantName)
+	if err != nil {
+		return err
+	}
+
+	user, err := srv.repo.FindUserByUsername(ctx, username)
+	if err != nil {
+		// response must not tell which username exist
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if !user.IsNotConfirmed() {
+		return nil
+	}
+
+	return srv.sendVerificationEmail(ctx, user)
+}
+
+// sendVerificationEmail mail verification link to email address of user
+func (srv *Impl) sendVerificationEmail(ctx context.Context, user *entity.User) error {
+	lifetime := srv.verification.Lifetime
+	if lifetime <= 0 {
+		lifetime = entity.EmailVerificationTokenLifetime
+	}
+
+	token, err := user.NewEmailVerificationToken(srv.keys, lifetime)
+	if err != nil {
+		return err
+	}
+
+	link, err := tokenLink(srv.verification.URL, token)
+	if err != nil {
+		return err
+	}
+
+	name := user.Nickname
+	if name == "" {
+		name = user.Username
+	}
+
+	return srv.mailer.Send(ctx, &mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Text: fmt.Sprintf(
+			"Hi %s,\n\nPlease verify your email address by opening the link below, it expires in %v.\n\n%s\n\nIf you did not sign up, please ignore this mail.\n",
+			name, lifetime, link,
+		),
+	})
+}
+
+// sendEmailChangeEmail mail link confirming new email address to the new address
+func (srv *Impl) sendEmailChangeEmail(ctx context.Context, user *entity.User, email string, token string) error {
+	lifetime := srv.verification.Lifetime
+	if lifetime <= 0 {
+		lifetime = entity.EmailVerificationTokenLifetime
+	}
+
+	link, err := tokenLink(srv.verification.URL, token)
+	if err != nil {
+		return err
+	}
+
+	name := user.Nickname
+	if name == "" {
+		name = user.Username
+	}
+
+	return srv.mailer.Send(ctx, &mail.Message{
+		To:      email,
+		Subject: "Confirm your new email address",
+		Text: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your new email address by opening the link below, it expires in %v.\n\n%s\n\nIf you did not change your email address, please ignore this mail.\n",
+			name, lifetime, link,
+		),
+	})
+}
+
+func (srv *Impl) RequestPasswordReset(ctx context.Context, email string) (err error) {
+	users, err := srv.repo.FindUsersByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return err
+	}
+
+	// mail is sent in background so response time never tell whether account exist,
+	// failure is only logged, error of existing account would tell it exist
+	ctx = detachedContext{ctx}
+	go func() {
+		for _, user := range users {
+			if !user.IsActive() {
+				continue
+			}
+
+			err := srv.sendPasswordResetEmail(ctx, user)
+			if err != nil {
+				log.Ctx(ctx).
+					Warn().
+					Err(err).
+					Str("user_id", user.ID).
+					Msg("failed to send password reset email")
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (srv *Impl) ResetPassword(ctx context.Context, token string, newPassword string) (err error) {
+	resetToken, err := srv.repo.FindPasswordResetToken(ctx, entity.HashPasswordResetToken(token))
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return errors.Wrap(errors.ErrUnauthorized, "password reset token is invalid")
+		}
+		return err
+	}
+
+	err = resetToken.Validate()
+	if err != nil {
+		return err
+	}
+
+	user, err := srv.repo.FindUserByID(ctx, resetToken.UserID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return errors.Wrapf(errors.ErrUnauthorized, "user=%v of password reset token not exist", resetToken.UserID)
+		}
+		return err
+	}
+
+	if !user.IsActive() {
+		return errors.Wrapf(
+			errors.ErrUnauthorized,
+			"user=%s is not active status=%v",
+			user.ID, user.Status,
+		)
+	}
+
+	// validated before token consumed, password rejected by format keep the link usable
+	err = user.SetPassword(srv.hasher, newPassword)
+	if err != nil {
+		return err
+	}
+
+	// concurrent reset with same token only one can pass
+	err = srv.repo.ConsumePasswordResetToken(ctx, resetToken)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return errors.Wrap(errors.ErrUnauthorized, "password reset token is already used")
+		}
+		return err
+	}
+
+	err = srv.repo.UpdatePassword(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	err = srv.repo.RevokeUserSessions(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	// user proved ownership of email, lockout of guessing old password is lifted
+	err = srv.lockout.Unlock(ctx, tenant.Qualify(user.TenantID, user.Username))
+	if err != nil {
+		log.Ctx(ctx).
+			Warn().
+			Err(err).
+			Str("user_id", user.ID).
+			Msg("failed to unlock user after password reset")
+	}
+
+	return nil
+}
+
+// sendPasswordResetEmail store new reset token and mail reset link to user
+func (srv *Impl) sendPasswordResetEmail(ctx context.Context, user *entity.User) error {
+	resetToken, token, err := entity.NewPasswordResetToken(user.ID, srv.passwordReset.Lifetime)
+	if err != nil {
+		return err
+	}
+
+	err = srv.repo.StorePasswordResetToken(ctx, resetToken)
+	if err != nil {
+		return err
+	}
+
+	link, err := tokenLink(srv.passwordReset.URL, token)
+	if err != nil {
+		return err
+	}
+
+	return srv.mailer.Send(ctx, &mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Text: fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to reset password of account %s. Open the link below to choose a new password, it expires in %v.\n\n%s\n\nIf you did not ask, please ignore this mail and your password stays unchanged.\n",
+			user.Username, user.Username, resetToken.ExpiresAt.Sub(resetToken.CreatedAt), link,
+		),
+	})
+}
+
+// tokenLink append token as query parameter of page url
+func tokenLink(pageURL string, token string) (string, error) {
+	link, err := url.Parse(pageURL)
+	if err != nil {
+		return "", errors.Wrapf(errors.ErrInternal, "page url=%v is invalid err %v", pageURL, err)
+	}
+
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	return link.String(), nil
+}
+
+// detachedContext keep values of parent context but never canceled,
+// work continued after response is not aborted by request ended
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
 }
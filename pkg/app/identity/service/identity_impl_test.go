@@ -2,7 +2,11 @@ package service_test
 
 import (
 	"context"
+	"net/url"
+	"regexp"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -11,9 +15,32 @@ import (
 	"github.com/karta0898098/iam/pkg/app/identity/mocks"
 	"github.com/karta0898098/iam/pkg/app/identity/repository"
 	"github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/authn"
 	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/federation"
+	"github.com/karta0898098/iam/pkg/federation/federationtest"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/ldap"
+	"github.com/karta0898098/iam/pkg/ldap/ldaptest"
+	"github.com/karta0898098/iam/pkg/lockout"
+	"github.com/karta0898098/iam/pkg/mail"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/password"
+	"github.com/karta0898098/iam/pkg/tenant"
+	"github.com/karta0898098/iam/pkg/totp"
+	"github.com/karta0898098/iam/pkg/webauthn"
+	"github.com/karta0898098/iam/pkg/webauthn/webauthntest"
 )
 
+var km, _ = keys.NewKeyManager(keys.Config{Ephemeral: true})
+
+var rp = webauthn.New(webauthn.Config{
+	RPID:    "localhost",
+	RPName:  "IAM",
+	Origins: []string{"http://localhost:3000"},
+})
+
 func TestImpl_Signin(t *testing.T) {
 	type args struct {
 		username string
@@ -41,6 +68,10 @@ func TestImpl_Signin(t *testing.T) {
 					FindUserByUsername(mock.Anything, mock.Anything).
 					Return(user, nil)
 
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(nil, errors.ErrResourceNotFound)
+
 				repo.EXPECT().
 					StoreSession(mock.Anything, mock.Anything).
 					Return(nil)
@@ -67,6 +98,90 @@ func TestImpl_Signin(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "Upgrade Legacy Password Hash",
+			repo: func() repository.Repository {
+				user := &entity.User{
+					ID:       "MOCK-USER-ID",
+					Username: "Username",
+					// sha256 hex digest of A12345678
+					Password: "3b4e266a89805c9d020f9aca6638ad63e8701fc8c75c0ca1952d14054d1f10cf",
+					Status:   entity.UserAccountStatusActive,
+				}
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByUsername(mock.Anything, mock.Anything).
+					Return(user, nil)
+
+				repo.EXPECT().
+					UpdatePassword(mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+						return !password.Default.NeedsRehash(user.Password)
+					})).
+					Return(nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(nil, errors.ErrResourceNotFound)
+
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.Anything).
+					Return(nil)
+				return repo
+			}(),
+			args: args{
+				username: "Username",
+				password: "A12345678",
+				opt: &service.SigninOption{
+					IPAddress: "127.0.0.1",
+					Platform:  "web",
+				},
+			},
+			expected: &entity.Identity{
+				User: &entity.User{
+					ID:       "MOCK-USER-ID",
+					Username: "Username",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "MFA Required",
+			repo: func() repository.Repository {
+				user, _ := entity.NewUser(
+					"MOCK-USER-ID",
+					"Username",
+					"A12345678",
+				)
+				user.Status = entity.UserAccountStatusActive
+				factor, _ := entity.NewTOTPFactor("MOCK-USER-ID")
+				factor.Confirm()
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByUsername(mock.Anything, mock.Anything).
+					Return(user, nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(factor, nil)
+				return repo
+			}(),
+			args: args{
+				username: "Username",
+				password: "A12345678",
+				opt: &service.SigninOption{
+					IPAddress: "127.0.0.1",
+					Platform:  "web",
+				},
+			},
+			expected: &entity.Identity{
+				User: &entity.User{
+					ID:       "MOCK-USER-ID",
+					Username: "Username",
+				},
+				MFARequired: true,
+			},
+			err: nil,
+		},
 		{
 			name: "Wrong Password",
 			repo: func() repository.Repository {
@@ -131,7 +246,7 @@ func TestImpl_Signin(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			srv := service.New(tt.repo)
+			srv := service.New(tt.repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
 			actual, err := srv.Signin(
 				ctx,
 				tt.args.username,
@@ -147,11 +262,356 @@ func TestImpl_Signin(t *testing.T) {
 
 			assert.Equal(t, tt.expected.User.ID, actual.User.ID)
 			assert.Equal(t, tt.expected.User.Username, actual.User.Username)
+			assert.Equal(t, tt.expected.MFARequired, actual.MFARequired)
 			assert.NotEmpty(t, actual.Session.ID)
 		})
 	}
 }
 
+func TestImpl_Signin_LDAP(t *testing.T) {
+	directory := ldaptest.NewServer()
+	defer directory.Close()
+	directory.AddEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass":  {"person"},
+		"uid":          {"alice"},
+		"mail":         {"Alice@Example.com"},
+		"givenName":    {"Alice"},
+		"sn":           {"Liddell"},
+		"userPassword": {"DIRECTORY-PASSWORD"},
+	})
+
+	local, _ := entity.NewUser("LOCAL-USER-ID", "alice", "A12345678")
+	local.Status = entity.UserAccountStatusActive
+	linked, _ := entity.NewDirectoryUser("MOCK-USER-ID", "alice")
+	subject := "uid=alice,ou=people,dc=example,dc=com"
+
+	tests := []struct {
+		name     string
+		password string
+		repo     func(repo *mocks.Repository)
+		provider string
+		err      error
+	}{
+		{
+			name:     "Sign Up At First Signin",
+			password: "DIRECTORY-PASSWORD",
+			repo: func(repo *mocks.Repository) {
+				repo.EXPECT().
+					FindUserByUsername(mock.Anything, "alice").
+					Return(nil, errors.ErrResourceNotFound)
+
+				repo.EXPECT().
+					FindExternalIdentity(mock.Anything, service.ProviderLDAP, subject).
+					Return(nil, errors.ErrResourceNotFound)
+
+				var created *entity.User
+				repo.EXPECT().
+					StoreUser(mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+						created = user
+						return user.Username == "alice" && user.Email == "alice@example.com" &&
+							user.FirstName == "Alice" && user.LastName == "Liddell" &&
+							user.Password == "" && user.IsActive()
+					})).
+					Return(nil)
+
+				repo.EXPECT().
+					StoreExternalIdentity(mock.Anything, mock.MatchedBy(func(identity *entity.ExternalIdentity) bool {
+						return identity.UserID == created.ID && identity.Provider == service.ProviderLDAP && identity.Subject == subject
+					})).
+					Return(nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, mock.Anything).
+					Return(nil, errors.ErrResourceNotFound)
+
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			provider: service.ProviderLDAP,
+		},
+		{
+			name:     "Linked Entry",
+			password: "DIRECTORY-PASSWORD",
+			repo: func(repo *mocks.Repository) {
+				// user signed up by directory has no local password
+				repo.EXPECT().
+					FindUserByUsername(mock.Anything, "alice").
+					Return(linked, nil)
+
+				repo.EXPECT().
+					FindExternalIdentity(mock.Anything, service.ProviderLDAP, subject).
+					Return(entity.NewExternalIdentity(service.ProviderLDAP, subject, "MOCK-USER-ID", "", ""), nil)
+
+				repo.EXPECT().
+					UpdateExternalIdentity(mock.Anything, mock.Anything).
+					Return(nil)
+
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(linked, nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(nil, errors.ErrResourceNotFound)
+
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.MatchedBy(func(session *entity.Session) bool {
+						return session.UserID == "MOCK-USER-ID" && session.IdpProvider == service.ProviderLDAP
+					})).
+					Return(nil)
+			},
+			provider: service.ProviderLDAP,
+		},
+		{
+			name:     "Local User First",
+			password: "A12345678",
+			repo: func(repo *mocks.Repository) {
+				repo.EXPECT().
+					FindUserByUsername(mock.Anything, "alice").
+					Return(local, nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "LOCAL-USER-ID").
+					Return(nil, errors.ErrResourceNotFound)
+
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			provider: "",
+		},
+		{
+			name:     "Local User Is Not Taken Over",
+			password: "DIRECTORY-PASSWORD",
+			repo: func(repo *mocks.Repository) {
+				repo.EXPECT().
+					FindUserByUsername(mock.Anything, "alice").
+					Return(local, nil)
+
+				repo.EXPECT().
+					FindExternalIdentity(mock.Anything, service.ProviderLDAP, subject).
+					Return(nil, errors.ErrResourceNotFound)
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name:     "Wrong Password",
+			password: "WRONG-PASSWORD",
+			repo: func(repo *mocks.Repository) {
+				repo.EXPECT().
+					FindUserByUsername(mock.Anything, "alice").
+					Return(nil, errors.ErrResourceNotFound)
+			},
+			err: errors.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.repo(repo)
+
+			authenticator := service.NewLDAPAuthenticator(repo, ldap.New(ldap.Config{
+				URL:            directory.URL(),
+				UserDNTemplate: "uid=%s,ou=people,dc=example,dc=com",
+			}), nil)
+			srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, authenticator, nil)
+
+			actual, err := srv.Signin(context.Background(), "alice", tt.password, &service.SigninOption{
+				IPAddress: "127.0.0.1",
+				Platform:  "web",
+			})
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.provider, actual.Session.IdpProvider)
+			assert.Equal(t, []string{oidc.AuthMethodPassword}, actual.Session.AuthMethods())
+		})
+	}
+}
+
+func TestImpl_Signin_LDAP_Tenant(t *testing.T) {
+	directory := ldaptest.NewServer()
+	defer directory.Close()
+	directory.AddEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass":  {"person"},
+		"uid":          {"alice"},
+		"userPassword": {"DIRECTORY-PASSWORD"},
+	})
+
+	tenants := mocks.NewTenantDirectory(t)
+	tenants.EXPECT().
+		ResolveTenant(mock.Anything, "acme").
+		Return("MOCK-TENANT-ID", nil)
+	tenants.EXPECT().
+		ResolveTenant(mock.Anything, "other").
+		Return("MOCK-OTHER-TENANT-ID", nil)
+	tenants.EXPECT().
+		JoinTenant(mock.Anything, "MOCK-TENANT-ID", mock.Anything).
+		Return(nil)
+
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		FindUserByUsername(mock.Anything, "alice").
+		Return(nil, errors.ErrResourceNotFound)
+	repo.EXPECT().
+		FindExternalIdentity(mock.Anything, service.ProviderLDAP, "uid=alice,ou=people,dc=example,dc=com").
+		Return(nil, errors.ErrResourceNotFound)
+	repo.EXPECT().
+		StoreUser(mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.TenantID == "MOCK-TENANT-ID"
+		})).
+		Return(nil).
+		Once()
+	repo.EXPECT().
+		StoreExternalIdentity(mock.Anything, mock.Anything).
+		Return(nil)
+	repo.EXPECT().
+		FindTOTPFactor(mock.Anything, mock.Anything).
+		Return(nil, errors.ErrResourceNotFound)
+	repo.EXPECT().
+		StoreSession(mock.Anything, mock.Anything).
+		Return(nil)
+
+	authenticator := service.NewLDAPAuthenticator(repo, ldap.New(ldap.Config{
+		URL:            directory.URL(),
+		UserDNTemplate: "uid=%s,ou=people,dc=example,dc=com",
+		Tenant:         "acme",
+	}), tenants)
+	srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, tenants, authenticator, nil)
+
+	// directory user is not signed up into tenant other than configured one
+	_, err := srv.Signin(context.Background(), "alice", "DIRECTORY-PASSWORD", &service.SigninOption{Tenant: "other", IPAddress: "127.0.0.1", Platform: "web"})
+	assert.True(t, errors.Is(err, errors.ErrUnauthorized), err)
+
+	_, err = srv.Signin(context.Background(), "alice", "DIRECTORY-PASSWORD", &service.SigninOption{IPAddress: "127.0.0.1", Platform: "web"})
+	assert.True(t, errors.Is(err, errors.ErrUnauthorized), err)
+
+	actual, err := srv.Signin(context.Background(), "alice", "DIRECTORY-PASSWORD", &service.SigninOption{Tenant: "acme", IPAddress: "127.0.0.1", Platform: "web"})
+	assert.NoError(t, err)
+	assert.Equal(t, "MOCK-TENANT-ID", actual.User.TenantID)
+}
+
+func TestImpl_Signin_Lockout(t *testing.T) {
+	ctx := context.Background()
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+	)
+	user.Status = entity.UserAccountStatusActive
+
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		FindUserByUsername(mock.Anything, "Username").
+		Return(user, nil).
+		Times(4)
+	repo.EXPECT().
+		FindUserByID(mock.Anything, "MOCK-USER-ID").
+		Return(user, nil)
+	repo.EXPECT().
+		FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+		Return(nil, errors.ErrResourceNotFound)
+	repo.EXPECT().
+		StoreSession(mock.Anything, mock.Anything).
+		Return(nil)
+
+	guard := lockout.New(lockout.Config{MaxUsernameFailures: 3}, lockout.NewMemoryStore())
+	srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, guard, mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+	opt := &service.SigninOption{IPAddress: "127.0.0.1", Platform: "web"}
+
+	for i := 0; i < 3; i++ {
+		_, err := srv.Signin(ctx, "Username", "wrong-password", opt)
+		assert.True(t, errors.Is(err, errors.ErrUnauthorized))
+	}
+
+	// locked account is rejected before password is checked
+	_, err := srv.Signin(ctx, "Username", "A12345678", opt)
+	assert.True(t, errors.Is(err, errors.ErrTooManyRequests))
+	assert.Greater(t, errors.TryConvert(err).RetryAfter(), time.Duration(0))
+
+	err = srv.Unlock(ctx, "MOCK-USER-ID")
+	assert.NoError(t, err)
+
+	actual, err := srv.Signin(ctx, "Username", "A12345678", opt)
+	assert.NoError(t, err)
+	assert.Equal(t, "MOCK-USER-ID", actual.User.ID)
+}
+
+func TestImpl_Signin_Tenant(t *testing.T) {
+	ctx := context.Background()
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+		entity.WithTenant("MOCK-TENANT-ID"),
+	)
+	user.Status = entity.UserAccountStatusActive
+
+	inTenant := mock.MatchedBy(func(ctx context.Context) bool {
+		return tenant.ID(ctx) == "MOCK-TENANT-ID"
+	})
+
+	tenants := mocks.NewTenantDirectory(t)
+	tenants.EXPECT().
+		ResolveTenant(mock.Anything, "acme").
+		Return("MOCK-TENANT-ID", nil)
+	tenants.EXPECT().
+		ResolveTenant(mock.Anything, "unknown").
+		Return("", errors.ErrResourceNotFound)
+
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		FindUserByUsername(inTenant, "Username").
+		Return(user, nil)
+	repo.EXPECT().
+		FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+		Return(nil, errors.ErrResourceNotFound)
+	repo.EXPECT().
+		StoreSession(mock.Anything, mock.MatchedBy(func(session *entity.Session) bool {
+			return session.TenantID == "MOCK-TENANT-ID"
+		})).
+		Return(nil)
+
+	srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, tenants, nil, nil)
+
+	actual, err := srv.Signin(ctx, "Username", "A12345678", &service.SigninOption{Tenant: "acme", IPAddress: "127.0.0.1", Platform: "web"})
+	assert.NoError(t, err)
+	assert.Equal(t, "MOCK-USER-ID", actual.User.ID)
+
+	// username is not looked up when tenant is unknown
+	_, err = srv.Signin(ctx, "Username", "A12345678", &service.SigninOption{Tenant: "unknown", IPAddress: "127.0.0.1", Platform: "web"})
+	assert.True(t, errors.Is(err, errors.ErrResourceNotFound))
+}
+
+func TestImpl_ListSessions(t *testing.T) {
+	ctx := context.Background()
+
+	phone := entity.NewSession("MOCK-PHONE-ID", "MOCK-USER-ID", "127.0.0.1", "ios")
+	rotated := entity.NewSession("MOCK-LAPTOP-ID", "MOCK-USER-ID", "127.0.0.1", "web")
+	laptop := rotated.Rotate("MOCK-LAPTOP-NEXT-ID")
+
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		ListActiveSessions(mock.Anything, "MOCK-USER-ID").
+		Return([]*entity.Session{laptop, phone}, nil)
+	// access token issued before refresh still carry rotated session
+	repo.EXPECT().
+		FindSessionByID(mock.Anything, rotated.ID).
+		Return(rotated, nil)
+
+	srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+
+	sessions, current, err := srv.ListSessions(ctx, "MOCK-USER-ID", rotated.ID)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, laptop.ID, current)
+	assert.Equal(t, rotated.CreateAt, laptop.CreateAt)
+	assert.False(t, laptop.UpdateAt.Before(rotated.UpdateAt))
+}
+
 func TestImpl_Signup(t *testing.T) {
 	type args struct {
 		username string
@@ -245,7 +705,7 @@ func TestImpl_Signup(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			srv := service.New(tt.repo)
+			srv := service.New(tt.repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
 			actual, err := srv.Signup(
 				ctx,
 				tt.args.username,
@@ -266,3 +726,1655 @@ func TestImpl_Signup(t *testing.T) {
 		})
 	}
 }
+
+// mailbox keep sent mails in memory
+type mailbox struct {
+	mu       sync.Mutex
+	messages []*mail.Message
+}
+
+func (m *mailbox) Send(ctx context.Context, msg *mail.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// received wait until mail sent in background is delivered
+func (m *mailbox) received(t *testing.T, count int) []*mail.Message {
+	assert.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return len(m.messages) >= count
+	}, time.Second, 10*time.Millisecond)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*mail.Message(nil), m.messages...)
+}
+
+var verificationLinkRegex = regexp.MustCompile(`http\S+`)
+
+func TestImpl_EmailVerification(t *testing.T) {
+	ctx := context.Background()
+	inbox := &mailbox{}
+	config := service.EmailVerificationConfig{
+		Required: true,
+		URL:      "http://localhost:3000/verify-email",
+	}
+
+	var stored *entity.User
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		FindUserByUsername(mock.Anything, "Username").
+		Return(nil, errors.ErrResourceNotFound).
+		Once()
+	repo.EXPECT().
+		StoreUser(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, user *entity.User) {
+			stored = user
+		}).
+		Return(nil)
+
+	srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), inbox, config, service.PasswordResetConfig{}, nil, nil, nil, nil)
+
+	identity, err := srv.Signup(ctx, "Username", "A12345678", &service.SignupOption{
+		Email:     "mock@gmail.com",
+		IPAddress: "127.0.0.1",
+	})
+	assert.NoError(t, err)
+	assert.True(t, identity.User.IsNotConfirmed())
+	assert.Nil(t, identity.Session)
+	assert.Len(t, inbox.messages, 1)
+	assert.Equal(t, "mock@gmail.com", inbox.messages[0].To)
+
+	// not confirmed user can not signin
+	repo.EXPECT().
+		FindUserByUsername(mock.Anything, "Username").
+		Return(stored, nil).
+		Once()
+	_, err = srv.Signin(ctx, "Username", "A12345678", &service.SigninOption{IPAddress: "127.0.0.1"})
+	assert.True(t, errors.Is(err, errors.ErrForbidden))
+
+	// resend to unknown username is ignored silently
+	repo.EXPECT().
+		FindUserByUsername(mock.Anything, "Unknown").
+		Return(nil, errors.ErrResourceNotFound)
+	assert.NoError(t, srv.ResendVerification(ctx, "Unknown", ""))
+	assert.Len(t, inbox.messages, 1)
+
+	link, err := url.Parse(verificationLinkRegex.FindString(inbox.messages[0].Text))
+	assert.NoError(t, err)
+	token := link.Query().Get("token")
+
+	repo.EXPECT().
+		FindUserByID(mock.Anything, stored.ID).
+		Return(stored, nil)
+	repo.EXPECT().
+		ConfirmUserEmail(mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.IsActive()
+		})).
+		Return(nil)
+
+	assert.True(t, errors.Is(srv.VerifyEmail(ctx, "invalid-token"), errors.ErrUnauthorized))
+	assert.NoError(t, srv.VerifyEmail(ctx, token))
+	assert.True(t, stored.IsActive())
+}
+
+func TestImpl_PasswordReset(t *testing.T) {
+	ctx := context.Background()
+	inbox := &mailbox{}
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+	)
+	user.Email = "mock@gmail.com"
+
+	var stored *entity.PasswordResetToken
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		FindUsersByEmail(mock.Anything, "mock@gmail.com").
+		Return([]*entity.User{user}, nil)
+	repo.EXPECT().
+		FindUsersByEmail(mock.Anything, "unknown@gmail.com").
+		Return(nil, nil)
+	repo.EXPECT().
+		StorePasswordResetToken(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, token *entity.PasswordResetToken) {
+			stored = token
+		}).
+		Return(nil)
+
+	srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), inbox, service.EmailVerificationConfig{}, service.PasswordResetConfig{
+		URL: "http://localhost:3000/reset-password",
+	}, nil, nil, nil, nil)
+
+	// unknown email looks the same as existing one
+	assert.NoError(t, srv.RequestPasswordReset(ctx, "unknown@gmail.com"))
+	assert.Len(t, inbox.messages, 0)
+	assert.NoError(t, srv.RequestPasswordReset(ctx, " Mock@gmail.com "))
+	messages := inbox.received(t, 1)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "mock@gmail.com", messages[0].To)
+
+	link, err := url.Parse(verificationLinkRegex.FindString(messages[0].Text))
+	assert.NoError(t, err)
+	token := link.Query().Get("token")
+
+	// only hash of token is stored
+	assert.NotEqual(t, token, stored.ID)
+	assert.Equal(t, entity.HashPasswordResetToken(token), stored.ID)
+
+	repo.EXPECT().
+		FindPasswordResetToken(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, id string) (*entity.PasswordResetToken, error) {
+			if stored == nil || id != stored.ID {
+				return nil, errors.ErrResourceNotFound
+			}
+			return stored, nil
+		})
+	repo.EXPECT().
+		FindUserByID(mock.Anything, user.ID).
+		Return(user, nil)
+	repo.EXPECT().
+		ConsumePasswordResetToken(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, token *entity.PasswordResetToken) {
+			stored = nil
+		}).
+		Return(nil)
+	repo.EXPECT().
+		UpdatePassword(mock.Anything, user).
+		Return(nil).
+		Once()
+	repo.EXPECT().
+		RevokeUserSessions(mock.Anything, user.ID).
+		Return(nil).
+		Once()
+
+	assert.True(t, errors.Is(srv.ResetPassword(ctx, "invalid-token", "B12345678"), errors.ErrUnauthorized))
+
+	// password rejected by format keep the token usable
+	assert.True(t, errors.Is(srv.ResetPassword(ctx, token, "short"), errors.ErrInvalidInput))
+	assert.NotNil(t, stored)
+
+	assert.NoError(t, srv.ResetPassword(ctx, token, "B12345678"))
+	assert.True(t, user.ValidatePassword("B12345678"))
+	assert.False(t, user.ValidatePassword("A12345678"))
+
+	// token is single-use
+	assert.True(t, errors.Is(srv.ResetPassword(ctx, token, "C12345678"), errors.ErrUnauthorized))
+	assert.True(t, user.ValidatePassword("B12345678"))
+}
+
+func TestImpl_UpdateProfile(t *testing.T) {
+	nickname := "Nickname"
+	email := "New@gmail.com"
+	avatar := "ftp://localhost/avatar.png"
+
+	tests := []struct {
+		name     string
+		repo     func(user *entity.User) repository.Repository
+		opt      *service.UpdateProfileOption
+		expected *entity.User
+		err      error
+	}{
+		{
+			name: "Success",
+			repo: func(user *entity.User) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+				repo.EXPECT().
+					UpdateUser(mock.Anything, user, int64(1)).
+					Return(nil)
+				return repo
+			},
+			opt: &service.UpdateProfileOption{
+				Version:  1,
+				Nickname: &nickname,
+				Email:    &email,
+			},
+			expected: &entity.User{
+				Nickname: "Nickname",
+				Email:    "new@gmail.com",
+				Version:  2,
+			},
+		},
+		{
+			name: "Stale Version",
+			repo: func(user *entity.User) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+				return repo
+			},
+			opt: &service.UpdateProfileOption{
+				Version:  0,
+				Nickname: &nickname,
+			},
+			err: errors.ErrConflict,
+		},
+		{
+			name: "Concurrent Update",
+			repo: func(user *entity.User) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+				repo.EXPECT().
+					UpdateUser(mock.Anything, user, int64(1)).
+					Return(errors.ErrConflict)
+				return repo
+			},
+			opt: &service.UpdateProfileOption{
+				Version:  1,
+				Nickname: &nickname,
+			},
+			err: errors.ErrConflict,
+		},
+		{
+			name: "Invalid Avatar",
+			repo: func(user *entity.User) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+				return repo
+			},
+			opt: &service.UpdateProfileOption{
+				Version: 1,
+				Avatar:  &avatar,
+			},
+			err: errors.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			user, _ := entity.NewUser(
+				"MOCK-USER-ID",
+				"Username",
+				"A12345678",
+				entity.WithEmail("mock@gmail.com"),
+			)
+
+			srv := service.New(tt.repo(user), km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+
+			actual, err := srv.UpdateProfile(ctx, user.ID, tt.opt)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected.Nickname, actual.Nickname)
+			assert.Equal(t, tt.expected.Email, actual.Email)
+			assert.Equal(t, tt.expected.Version, actual.Version)
+		})
+	}
+}
+
+func TestImpl_UpdateProfile_EmailChange(t *testing.T) {
+	ctx := context.Background()
+	inbox := &mailbox{}
+	email := "New@gmail.com"
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+		entity.WithEmail("mock@gmail.com"),
+	)
+
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		FindUserByID(mock.Anything, user.ID).
+		Return(user, nil)
+	repo.EXPECT().
+		UpdateUser(mock.Anything, user, int64(1)).
+		Return(nil).
+		Once()
+
+	srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), inbox, service.EmailVerificationConfig{
+		Required: true,
+		URL:      "http://localhost:3000/verify-email",
+	}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+
+	// email is kept until new address is confirmed
+	actual, err := srv.UpdateProfile(ctx, user.ID, &service.UpdateProfileOption{Version: 1, Email: &email})
+	assert.NoError(t, err)
+	assert.Equal(t, "mock@gmail.com", actual.Email)
+	assert.Len(t, inbox.messages, 1)
+	assert.Equal(t, "new@gmail.com", inbox.messages[0].To)
+
+	link, err := url.Parse(verificationLinkRegex.FindString(inbox.messages[0].Text))
+	assert.NoError(t, err)
+	token := link.Query().Get("token")
+
+	repo.EXPECT().
+		UpdateUser(mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Email == "new@gmail.com"
+		}), int64(2)).
+		Return(nil).
+		Once()
+
+	assert.NoError(t, srv.VerifyEmail(ctx, token))
+	assert.Equal(t, "new@gmail.com", user.Email)
+	// link opened twice is not an error
+	assert.NoError(t, srv.VerifyEmail(ctx, token))
+}
+
+func TestImpl_ChangePassword(t *testing.T) {
+	type args struct {
+		currentPassword string
+		newPassword     string
+	}
+	tests := []struct {
+		name string
+		repo func(user *entity.User) repository.Repository
+		args args
+		err  error
+	}{
+		{
+			name: "Success",
+			repo: func(user *entity.User) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+				repo.EXPECT().
+					UpdateUser(mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+						return user.ValidatePassword("B12345678") && user.Version == 2
+					}), int64(1)).
+					Return(nil)
+				return repo
+			},
+			args: args{
+				currentPassword: "A12345678",
+				newPassword:     "B12345678",
+			},
+		},
+		{
+			name: "Wrong Current Password",
+			repo: func(user *entity.User) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+				return repo
+			},
+			args: args{
+				currentPassword: "Z12345678",
+				newPassword:     "B12345678",
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name: "Invalid New Password",
+			repo: func(user *entity.User) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+				return repo
+			},
+			args: args{
+				currentPassword: "A12345678",
+				newPassword:     "short",
+			},
+			err: errors.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			user, _ := entity.NewUser(
+				"MOCK-USER-ID",
+				"Username",
+				"A12345678",
+			)
+
+			srv := service.New(tt.repo(user), km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+
+			err := srv.ChangePassword(ctx, user.ID, tt.args.currentPassword, tt.args.newPassword)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestImpl_ChangePassword_Tenant(t *testing.T) {
+	ctx := context.Background()
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+		entity.WithTenant("MOCK-TENANT-ID"),
+	)
+
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		FindUserByID(mock.Anything, user.ID).
+		Return(user, nil)
+
+	guard := lockout.New(lockout.Config{MaxUsernameFailures: 1}, lockout.NewMemoryStore())
+	srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, guard, mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+
+	// lockout of the same username in default tenant is other account
+	assert.NoError(t, guard.Fail(ctx, "Username", ""))
+
+	err := srv.ChangePassword(ctx, user.ID, "Z12345678", "B12345678")
+	assert.True(t, errors.Is(err, errors.ErrUnauthorized), err)
+
+	err = guard.Check(ctx, tenant.Qualify("MOCK-TENANT-ID", "Username"), "")
+	assert.Error(t, err)
+}
+
+func TestImpl_ResendVerification_Tenant(t *testing.T) {
+	ctx := context.Background()
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+		entity.WithTenant("MOCK-TENANT-ID"),
+		entity.WithEmail("mock@gmail.com"),
+		entity.WithEmailVerificationRequired(),
+	)
+
+	tenants := mocks.NewTenantDirectory(t)
+	tenants.EXPECT().
+		ResolveTenant(mock.Anything, "acme").
+		Return("MOCK-TENANT-ID", nil)
+
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		FindUserByUsername(mock.MatchedBy(func(ctx context.Context) bool {
+			return tenant.ID(ctx) == "MOCK-TENANT-ID"
+		}), "Username").
+		Return(user, nil)
+
+	inbox := &mailbox{}
+	config := service.EmailVerificationConfig{
+		Required: true,
+		URL:      "http://localhost:3000/verify-email",
+	}
+	srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), inbox, config, service.PasswordResetConfig{}, nil, tenants, nil, nil)
+
+	assert.NoError(t, srv.ResendVerification(ctx, "Username", "acme"))
+	assert.Len(t, inbox.messages, 1)
+}
+
+func TestImpl_Refresh(t *testing.T) {
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+	)
+	session := entity.NewSession(
+		"MOCK-SESSION-ID",
+		user.ID,
+		"127.0.0.1",
+		"web",
+	)
+	refreshToken, _ := (&entity.Identity{User: user, Session: session}).NewRefreshToken(km)
+	accessToken, _ := (&entity.Identity{User: user, Session: session}).NewAccessToken(km)
+
+	tests := []struct {
+		name         string
+		repo         repository.Repository
+		refreshToken string
+		err          error
+	}{
+		{
+			name: "Success",
+			repo: func() repository.Repository {
+				current := *session
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(&current, nil)
+
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+
+				repo.EXPECT().
+					RotateSession(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				return repo
+			}(),
+			refreshToken: refreshToken,
+			err:          nil,
+		},
+		{
+			name: "Access Token Can Not Refresh",
+			repo: func() repository.Repository {
+				return mocks.NewRepository(t)
+			}(),
+			refreshToken: accessToken,
+			err:          errors.ErrUnauthorized,
+		},
+		{
+			name: "Session Not Exist",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(nil, errors.ErrResourceNotFound)
+				return repo
+			}(),
+			refreshToken: refreshToken,
+			err:          errors.ErrUnauthorized,
+		},
+		{
+			name: "Session Issued To OAuth2 Client",
+			repo: func() repository.Repository {
+				issued := *session
+				issued.ClientID = "MOCK-CLIENT-ID"
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(&issued, nil)
+				return repo
+			}(),
+			refreshToken: refreshToken,
+			err:          errors.ErrUnauthorized,
+		},
+		{
+			name: "Replayed Refresh Token",
+			repo: func() repository.Repository {
+				rotated := *session
+				rotated.Rotate("MOCK-NEXT-SESSION-ID")
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(&rotated, nil)
+
+				repo.EXPECT().
+					RevokeSessionFamily(mock.Anything, session.FamilyID).
+					Return(nil)
+				return repo
+			}(),
+			refreshToken: refreshToken,
+			err:          errors.ErrUnauthorized,
+		},
+		{
+			name: "Concurrent Refresh",
+			repo: func() repository.Repository {
+				current := *session
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(&current, nil)
+
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+
+				repo.EXPECT().
+					RotateSession(mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.ErrConflict)
+
+				repo.EXPECT().
+					RevokeSessionFamily(mock.Anything, session.FamilyID).
+					Return(nil)
+				return repo
+			}(),
+			refreshToken: refreshToken,
+			err:          errors.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+			actual, err := srv.Refresh(ctx, tt.refreshToken, "")
+			if tt.err != nil || err != nil {
+				if !errors.Is(tt.err, err) {
+					t.Errorf("Refresh() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+
+			assert.Equal(t, user.ID, actual.User.ID)
+			assert.NotEqual(t, session.ID, actual.Session.ID)
+			assert.Equal(t, session.FamilyID, actual.Session.FamilyID)
+			assert.Equal(t, session.CreateAt, actual.Session.CreateAt)
+		})
+	}
+}
+
+func TestImpl_Introspect(t *testing.T) {
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+	)
+	session := entity.NewSession(
+		"MOCK-SESSION-ID",
+		user.ID,
+		"127.0.0.1",
+		"web",
+	)
+	identity := &entity.Identity{User: user, Session: session}
+	accessToken, _ := identity.NewAccessToken(km)
+	refreshToken, _ := identity.NewRefreshToken(km)
+
+	tests := []struct {
+		name     string
+		repo     repository.Repository
+		token    string
+		expected *entity.Introspection
+		err      error
+	}{
+		{
+			name: "Active",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(session, nil)
+				return repo
+			}(),
+			token: accessToken,
+			expected: &entity.Introspection{
+				Active:    true,
+				Subject:   user.ID,
+				SessionID: session.ID,
+				Platform:  "web",
+			},
+		},
+		{
+			name: "Session Revoked",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(nil, errors.ErrResourceNotFound)
+				return repo
+			}(),
+			token:    accessToken,
+			expected: &entity.Introspection{Active: false},
+		},
+		{
+			name: "Session Signed Out",
+			repo: func() repository.Repository {
+				revoked := *session
+				revoked.RevokedAt = time.Now()
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(&revoked, nil)
+				return repo
+			}(),
+			token:    accessToken,
+			expected: &entity.Introspection{Active: false},
+		},
+		{
+			name: "Refresh Token",
+			repo: func() repository.Repository {
+				return mocks.NewRepository(t)
+			}(),
+			token:    refreshToken,
+			expected: &entity.Introspection{Active: false},
+		},
+		{
+			name: "Malformed Token",
+			repo: func() repository.Repository {
+				return mocks.NewRepository(t)
+			}(),
+			token:    "malformed",
+			expected: &entity.Introspection{Active: false},
+		},
+		{
+			name: "Datastore Failure",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(nil, errors.ErrInternal)
+				return repo
+			}(),
+			token: accessToken,
+			err:   errors.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+			actual, err := srv.Introspect(ctx, tt.token)
+			if tt.err != nil || err != nil {
+				if !errors.Is(tt.err, err) {
+					t.Errorf("Introspect() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+
+			assert.Equal(t, tt.expected.Active, actual.Active)
+			assert.Equal(t, tt.expected.Subject, actual.Subject)
+			assert.Equal(t, tt.expected.SessionID, actual.SessionID)
+			assert.Equal(t, tt.expected.Platform, actual.Platform)
+		})
+	}
+}
+
+func TestImpl_RevokeSession(t *testing.T) {
+	session := entity.NewSession(
+		"MOCK-SESSION-ID",
+		"MOCK-USER-ID",
+		"127.0.0.1",
+		"web",
+	)
+
+	tests := []struct {
+		name      string
+		repo      repository.Repository
+		userID    string
+		sessionID string
+		err       error
+	}{
+		{
+			name: "Success",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(session, nil)
+
+				repo.EXPECT().
+					RevokeSessionFamily(mock.Anything, session.FamilyID).
+					Return(nil)
+				return repo
+			}(),
+			userID:    "MOCK-USER-ID",
+			sessionID: session.ID,
+			err:       nil,
+		},
+		{
+			name: "Session Of Other User",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindSessionByID(mock.Anything, session.ID).
+					Return(session, nil)
+				return repo
+			}(),
+			userID:    "MOCK-OTHER-USER-ID",
+			sessionID: session.ID,
+			err:       errors.ErrResourceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo, km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+			err := srv.RevokeSession(ctx, tt.userID, tt.sessionID)
+			if tt.err != nil || err != nil {
+				if !errors.Is(tt.err, err) {
+					t.Errorf("RevokeSession() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestImpl_VerifyMFA(t *testing.T) {
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+	)
+	user.Status = entity.UserAccountStatusActive
+
+	mfaToken, _ := (&entity.Identity{
+		User:        user,
+		Session:     entity.NewSession("MOCK-SESSION-ID", user.ID, "127.0.0.1", "web"),
+		MFARequired: true,
+	}).NewMFAToken(km)
+
+	newFactor := func() *entity.TOTPFactor {
+		factor, _ := entity.NewTOTPFactor("MOCK-USER-ID")
+		factor.Confirm()
+		return factor
+	}
+
+	type args struct {
+		mfaToken string
+		code     func(factor *entity.TOTPFactor) string
+	}
+	tests := []struct {
+		name   string
+		factor *entity.TOTPFactor
+		repo   func(factor *entity.TOTPFactor) repository.Repository
+		args   args
+		err    error
+	}{
+		{
+			name:   "TOTP Code",
+			factor: newFactor(),
+			repo: func(factor *entity.TOTPFactor) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(user, nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(factor, nil)
+
+				repo.EXPECT().
+					UpdateTOTPCounter(mock.Anything, factor).
+					Return(nil)
+
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.MatchedBy(func(session *entity.Session) bool {
+						return session.UserID == "MOCK-USER-ID" && session.Platform == "web" &&
+							assert.ObjectsAreEqual([]string{oidc.AuthMethodPassword, oidc.AuthMethodOTP}, session.AuthMethods())
+					})).
+					Return(nil)
+				return repo
+			},
+			args: args{
+				mfaToken: mfaToken,
+				code: func(factor *entity.TOTPFactor) string {
+					code, _ := totp.GenerateCode(factor.Secret, totp.Counter(time.Now()))
+					return code
+				},
+			},
+			err: nil,
+		},
+		{
+			name:   "Replayed TOTP Code",
+			factor: newFactor(),
+			repo: func(factor *entity.TOTPFactor) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(user, nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(factor, nil)
+
+				repo.EXPECT().
+					UpdateTOTPCounter(mock.Anything, factor).
+					Return(errors.ErrConflict)
+				return repo
+			},
+			args: args{
+				mfaToken: mfaToken,
+				code: func(factor *entity.TOTPFactor) string {
+					code, _ := totp.GenerateCode(factor.Secret, totp.Counter(time.Now()))
+					return code
+				},
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name:   "Recovery Code",
+			factor: newFactor(),
+			repo: func(factor *entity.TOTPFactor) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(user, nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(factor, nil)
+
+				repo.EXPECT().
+					ConsumeRecoveryCode(mock.Anything, "MOCK-USER-ID", entity.HashRecoveryCode("abcd-efgh23")).
+					Return(nil)
+
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.Anything).
+					Return(nil)
+				return repo
+			},
+			args: args{
+				mfaToken: mfaToken,
+				code: func(factor *entity.TOTPFactor) string {
+					return "abcd-efgh23"
+				},
+			},
+			err: nil,
+		},
+		{
+			name:   "Wrong Code",
+			factor: newFactor(),
+			repo: func(factor *entity.TOTPFactor) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(user, nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(factor, nil)
+				return repo
+			},
+			args: args{
+				mfaToken: mfaToken,
+				code: func(factor *entity.TOTPFactor) string {
+					code, _ := totp.GenerateCode(factor.Secret, totp.Counter(time.Now())+10)
+					return code
+				},
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name:   "Access Token Is Not MFA Token",
+			factor: newFactor(),
+			repo: func(factor *entity.TOTPFactor) repository.Repository {
+				return mocks.NewRepository(t)
+			},
+			args: args{
+				mfaToken: func() string {
+					token, _ := (&entity.Identity{
+						User:    user,
+						Session: entity.NewSession("MOCK-SESSION-ID", user.ID, "127.0.0.1", "web"),
+					}).NewAccessToken(km)
+					return token
+				}(),
+				code: func(factor *entity.TOTPFactor) string {
+					return "123456"
+				},
+			},
+			err: errors.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo(tt.factor), km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+			actual, err := srv.VerifyMFA(ctx, tt.args.mfaToken, tt.args.code(tt.factor))
+			if tt.err != nil || err != nil {
+				if !errors.Is(tt.err, err) {
+					t.Errorf("VerifyMFA() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+
+			assert.Equal(t, "MOCK-USER-ID", actual.User.ID)
+			assert.False(t, actual.MFARequired)
+			assert.NotEqual(t, "MOCK-SESSION-ID", actual.Session.ID)
+		})
+	}
+}
+
+func TestImpl_ConfirmTOTP(t *testing.T) {
+	tests := []struct {
+		name   string
+		factor *entity.TOTPFactor
+		repo   func(factor *entity.TOTPFactor) repository.Repository
+		code   func(factor *entity.TOTPFactor) string
+		err    error
+	}{
+		{
+			name: "Success",
+			factor: func() *entity.TOTPFactor {
+				factor, _ := entity.NewTOTPFactor("MOCK-USER-ID")
+				return factor
+			}(),
+			repo: func(factor *entity.TOTPFactor) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(factor, nil)
+
+				repo.EXPECT().
+					ConfirmTOTPFactor(mock.Anything, factor, mock.MatchedBy(func(hashes []string) bool {
+						return len(hashes) == entity.RecoveryCodeCount
+					})).
+					Return(nil)
+				return repo
+			},
+			code: func(factor *entity.TOTPFactor) string {
+				code, _ := totp.GenerateCode(factor.Secret, totp.Counter(time.Now()))
+				return code
+			},
+			err: nil,
+		},
+		{
+			name: "Already Confirmed",
+			factor: func() *entity.TOTPFactor {
+				factor, _ := entity.NewTOTPFactor("MOCK-USER-ID")
+				factor.Confirm()
+				return factor
+			}(),
+			repo: func(factor *entity.TOTPFactor) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(factor, nil)
+				return repo
+			},
+			code: func(factor *entity.TOTPFactor) string {
+				code, _ := totp.GenerateCode(factor.Secret, totp.Counter(time.Now()))
+				return code
+			},
+			err: errors.ErrConflict,
+		},
+		{
+			name: "Wrong Code",
+			factor: func() *entity.TOTPFactor {
+				factor, _ := entity.NewTOTPFactor("MOCK-USER-ID")
+				return factor
+			}(),
+			repo: func(factor *entity.TOTPFactor) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(factor, nil)
+				return repo
+			},
+			code: func(factor *entity.TOTPFactor) string {
+				return "000000"
+			},
+			err: errors.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo(tt.factor), km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+			recoveryCodes, err := srv.ConfirmTOTP(ctx, "MOCK-USER-ID", tt.code(tt.factor))
+			if tt.err != nil || err != nil {
+				if !errors.Is(tt.err, err) {
+					t.Errorf("ConfirmTOTP() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+
+			assert.Len(t, recoveryCodes, entity.RecoveryCodeCount)
+			assert.True(t, tt.factor.IsConfirmed())
+		})
+	}
+}
+
+func TestImpl_FinishWebAuthnRegistration(t *testing.T) {
+	newChallenge := func(userID string, ceremony string) *entity.WebAuthnChallenge {
+		challenge, _ := entity.NewWebAuthnChallenge("MOCK-CHALLENGE-ID", userID, ceremony)
+		return challenge
+	}
+
+	tests := []struct {
+		name      string
+		challenge *entity.WebAuthnChallenge
+		repo      func(challenge *entity.WebAuthnChallenge) repository.Repository
+		err       error
+	}{
+		{
+			name:      "Success",
+			challenge: newChallenge("MOCK-USER-ID", entity.WebAuthnCeremonyRegistration),
+			repo: func(challenge *entity.WebAuthnChallenge) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					ConsumeWebAuthnChallenge(mock.Anything, "MOCK-CHALLENGE-ID").
+					Return(challenge, nil)
+
+				repo.EXPECT().
+					StoreWebAuthnCredential(mock.Anything, mock.MatchedBy(func(credential *entity.WebAuthnCredential) bool {
+						return credential.UserID == "MOCK-USER-ID" && credential.Name == "MacBook"
+					})).
+					Return(nil)
+				return repo
+			},
+			err: nil,
+		},
+		{
+			name:      "Challenge Of Other User",
+			challenge: newChallenge("OTHER-USER-ID", entity.WebAuthnCeremonyRegistration),
+			repo: func(challenge *entity.WebAuthnChallenge) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					ConsumeWebAuthnChallenge(mock.Anything, "MOCK-CHALLENGE-ID").
+					Return(challenge, nil)
+				return repo
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name:      "Challenge Of Authentication",
+			challenge: newChallenge("MOCK-USER-ID", entity.WebAuthnCeremonyAuthentication),
+			repo: func(challenge *entity.WebAuthnChallenge) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					ConsumeWebAuthnChallenge(mock.Anything, "MOCK-CHALLENGE-ID").
+					Return(challenge, nil)
+				return repo
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name:      "Challenge Already Consumed",
+			challenge: newChallenge("MOCK-USER-ID", entity.WebAuthnCeremonyRegistration),
+			repo: func(challenge *entity.WebAuthnChallenge) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					ConsumeWebAuthnChallenge(mock.Anything, "MOCK-CHALLENGE-ID").
+					Return(nil, errors.ErrResourceNotFound)
+				return repo
+			},
+			err: errors.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo(tt.challenge), km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+
+			authenticator := webauthntest.NewAuthenticator("localhost", "http://localhost:3000")
+			clientDataJSON, attestationObject := authenticator.Create(tt.challenge.Challenge, webauthn.AttestationFormatPacked)
+
+			credential, err := srv.FinishWebAuthnRegistration(
+				ctx,
+				"MOCK-USER-ID",
+				"MOCK-CHALLENGE-ID",
+				"MacBook",
+				&webauthn.AttestationResponse{
+					ClientDataJSON:    clientDataJSON,
+					AttestationObject: attestationObject,
+				},
+			)
+			if tt.err != nil || err != nil {
+				if !errors.Is(tt.err, err) {
+					t.Errorf("FinishWebAuthnRegistration() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+
+			assert.Equal(t, webauthn.URLEncodedBytes(authenticator.CredentialID).String(), credential.ID)
+			assert.Equal(t, authenticator.PublicKey(), credential.PublicKey)
+		})
+	}
+}
+
+func TestImpl_FinishWebAuthnSignin(t *testing.T) {
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+	)
+	user.Status = entity.UserAccountStatusActive
+
+	type fixture struct {
+		authenticator *webauthntest.Authenticator
+		credential    *entity.WebAuthnCredential
+		challenge     *entity.WebAuthnChallenge
+	}
+	newFixture := func(challengeUserID string) *fixture {
+		authenticator := webauthntest.NewAuthenticator("localhost", "http://localhost:3000")
+		challenge, _ := entity.NewWebAuthnChallenge("MOCK-CHALLENGE-ID", challengeUserID, entity.WebAuthnCeremonyAuthentication)
+		return &fixture{
+			authenticator: authenticator,
+			credential: &entity.WebAuthnCredential{
+				ID:        webauthn.URLEncodedBytes(authenticator.CredentialID).String(),
+				UserID:    "MOCK-USER-ID",
+				PublicKey: authenticator.PublicKey(),
+				SignCount: 0,
+			},
+			challenge: challenge,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		fixture    *fixture
+		repo       func(f *fixture) repository.Repository
+		userHandle []byte
+		err        error
+	}{
+		{
+			name:    "Discoverable Credential",
+			fixture: newFixture(""),
+			repo: func(f *fixture) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					ConsumeWebAuthnChallenge(mock.Anything, "MOCK-CHALLENGE-ID").
+					Return(f.challenge, nil)
+
+				repo.EXPECT().
+					FindWebAuthnCredential(mock.Anything, f.credential.ID).
+					Return(f.credential, nil)
+
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(user, nil)
+
+				repo.EXPECT().
+					UpdateWebAuthnSignCount(mock.Anything, mock.MatchedBy(func(credential *entity.WebAuthnCredential) bool {
+						return credential.SignCount == 1 && !credential.LastUsedAt.IsZero()
+					})).
+					Return(nil)
+
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.MatchedBy(func(session *entity.Session) bool {
+						return session.UserID == "MOCK-USER-ID" && session.AuthMethod == oidc.AuthMethodProofOfPossession
+					})).
+					Return(nil)
+				return repo
+			},
+			userHandle: []byte("MOCK-USER-ID"),
+			err:        nil,
+		},
+		{
+			name:    "User Handle Not Match",
+			fixture: newFixture(""),
+			repo: func(f *fixture) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					ConsumeWebAuthnChallenge(mock.Anything, "MOCK-CHALLENGE-ID").
+					Return(f.challenge, nil)
+
+				repo.EXPECT().
+					FindWebAuthnCredential(mock.Anything, f.credential.ID).
+					Return(f.credential, nil)
+				return repo
+			},
+			userHandle: []byte("OTHER-USER-ID"),
+			err:        errors.ErrUnauthorized,
+		},
+		{
+			name:    "Credential Not Allowed By Challenge",
+			fixture: newFixture("OTHER-USER-ID"),
+			repo: func(f *fixture) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					ConsumeWebAuthnChallenge(mock.Anything, "MOCK-CHALLENGE-ID").
+					Return(f.challenge, nil)
+
+				repo.EXPECT().
+					FindWebAuthnCredential(mock.Anything, f.credential.ID).
+					Return(f.credential, nil)
+				return repo
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name:    "Credential Not Registered",
+			fixture: newFixture(""),
+			repo: func(f *fixture) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					ConsumeWebAuthnChallenge(mock.Anything, "MOCK-CHALLENGE-ID").
+					Return(f.challenge, nil)
+
+				repo.EXPECT().
+					FindWebAuthnCredential(mock.Anything, f.credential.ID).
+					Return(nil, errors.ErrResourceNotFound)
+				return repo
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name:    "Concurrent Assertion",
+			fixture: newFixture("MOCK-USER-ID"),
+			repo: func(f *fixture) repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					ConsumeWebAuthnChallenge(mock.Anything, "MOCK-CHALLENGE-ID").
+					Return(f.challenge, nil)
+
+				repo.EXPECT().
+					FindWebAuthnCredential(mock.Anything, f.credential.ID).
+					Return(f.credential, nil)
+
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(user, nil)
+
+				repo.EXPECT().
+					UpdateWebAuthnSignCount(mock.Anything, mock.Anything).
+					Return(errors.ErrConflict)
+				return repo
+			},
+			err: errors.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo(tt.fixture), km, password.Default, totp.Config{Skew: 1}, rp, nil, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+
+			clientDataJSON, authenticatorData, signature := tt.fixture.authenticator.Get(tt.fixture.challenge.Challenge)
+			actual, err := srv.FinishWebAuthnSignin(
+				ctx,
+				"MOCK-CHALLENGE-ID",
+				tt.fixture.credential.ID,
+				&webauthn.AssertionResponse{
+					ClientDataJSON:    clientDataJSON,
+					AuthenticatorData: authenticatorData,
+					Signature:         signature,
+					UserHandle:        tt.userHandle,
+				},
+				&service.SigninOption{
+					IPAddress: "127.0.0.1",
+					Platform:  "web",
+				},
+			)
+			if tt.err != nil || err != nil {
+				if !errors.Is(tt.err, err) {
+					t.Errorf("FinishWebAuthnSignin() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+
+			assert.Equal(t, "MOCK-USER-ID", actual.User.ID)
+			assert.Equal(t, []string{oidc.AuthMethodProofOfPossession}, actual.Session.AuthMethods())
+		})
+	}
+}
+
+func TestImpl_FinishFederatedSignin(t *testing.T) {
+	user := &entity.User{ID: "MOCK-USER-ID", Username: "Username", Status: entity.UserAccountStatusActive}
+
+	tests := []struct {
+		name string
+		// link start linking provider to user instead of signin
+		link bool
+		// principal authenticated at callback
+		principal *authn.Principal
+		provider  string
+		repo      func(repo *mocks.Repository, state *entity.FederationState)
+		err       error
+	}{
+		{
+			name:     "Sign Up At First Signin",
+			provider: "mock",
+			repo: func(repo *mocks.Repository, state *entity.FederationState) {
+				repo.EXPECT().
+					ConsumeFederationState(mock.Anything, state.ID).
+					Return(state, nil)
+
+				repo.EXPECT().
+					FindExternalIdentity(mock.Anything, "mock", "MOCK-EXTERNAL-SUBJECT").
+					Return(nil, errors.ErrResourceNotFound)
+
+				var created *entity.User
+				repo.EXPECT().
+					StoreUser(mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+						created = user
+						return user.Email == "federated@example.com" && user.Nickname == "Federated User" &&
+							user.Password == "" && user.IsActive()
+					})).
+					Return(nil)
+
+				repo.EXPECT().
+					StoreExternalIdentity(mock.Anything, mock.MatchedBy(func(identity *entity.ExternalIdentity) bool {
+						return identity.UserID == created.ID && identity.Subject == "MOCK-EXTERNAL-SUBJECT"
+					})).
+					Return(nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, mock.Anything).
+					Return(nil, errors.ErrResourceNotFound)
+
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.MatchedBy(func(session *entity.Session) bool {
+						return session.UserID == created.ID && session.IdpProvider == "mock"
+					})).
+					Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:     "Linked Identity",
+			provider: "mock",
+			repo: func(repo *mocks.Repository, state *entity.FederationState) {
+				repo.EXPECT().
+					ConsumeFederationState(mock.Anything, state.ID).
+					Return(state, nil)
+
+				repo.EXPECT().
+					FindExternalIdentity(mock.Anything, "mock", "MOCK-EXTERNAL-SUBJECT").
+					Return(entity.NewExternalIdentity("mock", "MOCK-EXTERNAL-SUBJECT", "MOCK-USER-ID", "", ""), nil)
+
+				repo.EXPECT().
+					UpdateExternalIdentity(mock.Anything, mock.MatchedBy(func(identity *entity.ExternalIdentity) bool {
+						return identity.Email == "federated@example.com"
+					})).
+					Return(nil)
+
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(user, nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(nil, errors.ErrResourceNotFound)
+
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.MatchedBy(func(session *entity.Session) bool {
+						return session.UserID == "MOCK-USER-ID" && session.IdpProvider == "mock"
+					})).
+					Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:      "Link To Signed In User",
+			link:      true,
+			principal: &authn.Principal{UserID: "MOCK-USER-ID"},
+			provider:  "mock",
+			repo: func(repo *mocks.Repository, state *entity.FederationState) {
+				repo.EXPECT().
+					ConsumeFederationState(mock.Anything, state.ID).
+					Return(state, nil)
+
+				repo.EXPECT().
+					FindExternalIdentity(mock.Anything, "mock", "MOCK-EXTERNAL-SUBJECT").
+					Return(nil, errors.ErrResourceNotFound)
+
+				repo.EXPECT().
+					StoreExternalIdentity(mock.Anything, mock.MatchedBy(func(identity *entity.ExternalIdentity) bool {
+						return identity.UserID == "MOCK-USER-ID"
+					})).
+					Return(nil)
+
+				repo.EXPECT().
+					FindTOTPFactor(mock.Anything, "MOCK-USER-ID").
+					Return(nil, errors.ErrResourceNotFound)
+
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:      "Subject Linked To Other User",
+			link:      true,
+			principal: &authn.Principal{UserID: "MOCK-USER-ID"},
+			provider:  "mock",
+			repo: func(repo *mocks.Repository, state *entity.FederationState) {
+				repo.EXPECT().
+					ConsumeFederationState(mock.Anything, state.ID).
+					Return(state, nil)
+
+				repo.EXPECT().
+					FindExternalIdentity(mock.Anything, "mock", "MOCK-EXTERNAL-SUBJECT").
+					Return(entity.NewExternalIdentity("mock", "MOCK-EXTERNAL-SUBJECT", "OTHER-USER-ID", "", ""), nil)
+			},
+			err: errors.ErrConflict,
+		},
+		{
+			name:      "Link Finished By Other User",
+			link:      true,
+			principal: &authn.Principal{UserID: "OTHER-USER-ID"},
+			provider:  "mock",
+			repo: func(repo *mocks.Repository, state *entity.FederationState) {
+				repo.EXPECT().
+					ConsumeFederationState(mock.Anything, state.ID).
+					Return(state, nil)
+			},
+			err: errors.ErrForbidden,
+		},
+		{
+			name:     "Link Finished Without Signin",
+			link:     true,
+			provider: "mock",
+			repo: func(repo *mocks.Repository, state *entity.FederationState) {
+				repo.EXPECT().
+					ConsumeFederationState(mock.Anything, state.ID).
+					Return(state, nil)
+			},
+			err: errors.ErrForbidden,
+		},
+		{
+			name:     "State Already Used",
+			provider: "mock",
+			repo: func(repo *mocks.Repository, state *entity.FederationState) {
+				repo.EXPECT().
+					ConsumeFederationState(mock.Anything, state.ID).
+					Return(nil, errors.ErrResourceNotFound)
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name:     "State Of Other Provider",
+			provider: "other",
+			repo: func(repo *mocks.Repository, state *entity.FederationState) {
+				repo.EXPECT().
+					ConsumeFederationState(mock.Anything, state.ID).
+					Return(state, nil)
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name:     "Provider Not Configured",
+			provider: "unknown",
+			repo:     func(repo *mocks.Repository, state *entity.FederationState) {},
+			err:      errors.ErrResourceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := federationtest.NewProvider("MOCK-CLIENT-ID", "MOCK-CLIENT-SECRET")
+			defer op.Close()
+			other := federationtest.NewProvider("MOCK-CLIENT-ID", "MOCK-CLIENT-SECRET")
+			defer other.Close()
+
+			fed := federation.New(federation.Config{
+				Providers: []federation.ProviderConfig{
+					op.Config("mock", "http://localhost:3000/federation/mock/callback"),
+					other.Config("other", "http://localhost:3000/federation/other/callback"),
+				},
+			})
+
+			var state *entity.FederationState
+			repo := mocks.NewRepository(t)
+			repo.EXPECT().
+				StoreFederationState(mock.Anything, mock.Anything).
+				Run(func(ctx context.Context, s *entity.FederationState) {
+					state = s
+				}).
+				Return(nil)
+
+			srv := service.New(repo, km, password.Default, totp.Config{Skew: 1}, rp, fed, lockout.New(lockout.Config{}, lockout.NewMemoryStore()), mail.NewLogMailer(mail.Config{}), service.EmailVerificationConfig{}, service.PasswordResetConfig{}, nil, nil, nil, nil)
+
+			var (
+				authURL string
+				err     error
+			)
+			if tt.link {
+				repo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(user, nil)
+				authURL, err = srv.BeginFederatedLink(context.Background(), "mock", "MOCK-USER-ID")
+			} else {
+				authURL, err = srv.BeginFederatedSignin(context.Background(), "mock", "")
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "mock", state.Provider)
+
+			code, returnedState := op.Authorize(authURL)
+			assert.Equal(t, state.ID, returnedState)
+
+			tt.repo(repo, state)
+
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = authn.NewContext(ctx, tt.principal)
+			}
+
+			actual, err := srv.FinishFederatedSignin(ctx, tt.provider, returnedState, code, &service.SigninOption{
+				IPAddress: "127.0.0.1",
+				Platform:  "web",
+			})
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "mock", actual.Session.IdpProvider)
+			assert.Equal(t, []string{oidc.AuthMethodFederated}, actual.Session.AuthMethods())
+		})
+	}
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	ctx := audit.NewContext(context.Background(), audit.Client{IPAddress: "10.0.0.1"})
+	user, _ := entity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+		entity.WithTenant("MOCK-TENANT-ID"),
+	)
+	opt := &service.SigninOption{
+		IPAddress: "1.1.1.1",
+		Platform:  "ios",
+		Device:    entity.Device{Name: "iPhone", OSVersion: "17.0"},
+	}
+
+	next := mocks.NewIdentityService(t)
+	next.EXPECT().
+		Signin(mock.Anything, "Username", "wrong-password", opt).
+		Return(nil, errors.ErrUnauthorized)
+	next.EXPECT().
+		Signin(mock.Anything, "Username", "A12345678", opt).
+		Return(&entity.Identity{User: user, MFARequired: true}, nil)
+	next.EXPECT().
+		GetProfile(mock.Anything, "MOCK-USER-ID").
+		Return(user, nil)
+
+	var events []*audit.Event
+	recorder := audit.RecorderFunc(func(ctx context.Context, event *audit.Event) error {
+		events = append(events, event)
+		return nil
+	})
+	srv := service.AuditMiddleware(recorder)(next)
+
+	_, err := srv.Signin(ctx, "Username", "wrong-password", opt)
+	assert.True(t, errors.Is(err, errors.ErrUnauthorized))
+	_, err = srv.Signin(ctx, "Username", "A12345678", opt)
+	assert.NoError(t, err)
+
+	// read only action is not recorded
+	_, err = srv.GetProfile(ctx, "MOCK-USER-ID")
+	assert.NoError(t, err)
+
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, audit.ActionSignin, events[0].Action)
+		assert.Equal(t, "Username", events[0].Actor)
+		assert.Equal(t, audit.OutcomeFailure, events[0].Outcome)
+		assert.Equal(t, errors.ErrUnauthorized.Code, events[0].ErrorCode)
+		// ip address of option is not trusted, transport one is recorded
+		assert.Equal(t, "10.0.0.1", events[0].IPAddress)
+		assert.Equal(t, "ios", events[0].Platform)
+		assert.Equal(t, "iPhone 17.0", events[0].Device)
+
+		assert.Equal(t, "MOCK-USER-ID", events[1].Actor)
+		assert.Equal(t, "MOCK-USER-ID", events[1].Target)
+		assert.Equal(t, "MOCK-TENANT-ID", events[1].TenantID)
+		assert.Equal(t, audit.OutcomeChallenged, events[1].Outcome)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/webauthn"
 )
 
 type loggingMiddleware struct {
@@ -40,3 +41,264 @@ func (lm loggingMiddleware) Signup(ctx context.Context, username string, passwor
 	}()
 	return lm.next.Signup(ctx, username, password, opts)
 }
+
+func (lm loggingMiddleware) Refresh(ctx context.Context, refreshToken string, clientID string) (identity *entity.Identity, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "Refresh",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.Refresh(ctx, refreshToken, clientID)
+}
+
+func (lm loggingMiddleware) Introspect(ctx context.Context, token string) (introspection *entity.Introspection, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "Introspect",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.Introspect(ctx, token)
+}
+
+func (lm loggingMiddleware) Signout(ctx context.Context, sessionID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "Signout",
+		// 	"session_id", sessionID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.Signout(ctx, sessionID)
+}
+
+func (lm loggingMiddleware) SignoutAll(ctx context.Context, userID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "SignoutAll",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.SignoutAll(ctx, userID)
+}
+
+func (lm loggingMiddleware) ListSessions(ctx context.Context, userID string, sessionID string) (sessions []*entity.Session, current string, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListSessions",
+		// 	"user_id", userID,
+		// 	"session_id", sessionID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListSessions(ctx, userID, sessionID)
+}
+
+func (lm loggingMiddleware) RevokeSession(ctx context.Context, userID string, sessionID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "RevokeSession",
+		// 	"user_id", userID,
+		// 	"session_id", sessionID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.RevokeSession(ctx, userID, sessionID)
+}
+
+func (lm loggingMiddleware) EnrollTOTP(ctx context.Context, userID string) (enrollment *entity.TOTPEnrollment, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "EnrollTOTP",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.EnrollTOTP(ctx, userID)
+}
+
+func (lm loggingMiddleware) ConfirmTOTP(ctx context.Context, userID string, code string) (recoveryCodes []string, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ConfirmTOTP",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ConfirmTOTP(ctx, userID, code)
+}
+
+func (lm loggingMiddleware) VerifyMFA(ctx context.Context, mfaToken string, code string) (identity *entity.Identity, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "VerifyMFA",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.VerifyMFA(ctx, mfaToken, code)
+}
+
+func (lm loggingMiddleware) BeginWebAuthnRegistration(ctx context.Context, userID string) (options *entity.WebAuthnRegistrationOptions, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "BeginWebAuthnRegistration",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.BeginWebAuthnRegistration(ctx, userID)
+}
+
+func (lm loggingMiddleware) FinishWebAuthnRegistration(ctx context.Context, userID string, challengeID string, name string, resp *webauthn.AttestationResponse) (credential *entity.WebAuthnCredential, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "FinishWebAuthnRegistration",
+		// 	"user_id", userID,
+		// 	"challenge_id", challengeID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.FinishWebAuthnRegistration(ctx, userID, challengeID, name, resp)
+}
+
+func (lm loggingMiddleware) BeginWebAuthnSignin(ctx context.Context, username string, tenantName string) (options *entity.WebAuthnSigninOptions, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "BeginWebAuthnSignin",
+		// 	"username", username,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.BeginWebAuthnSignin(ctx, username, tenantName)
+}
+
+func (lm loggingMiddleware) FinishWebAuthnSignin(ctx context.Context, challengeID string, credentialID string, resp *webauthn.AssertionResponse, opt *SigninOption) (identity *entity.Identity, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "FinishWebAuthnSignin",
+		// 	"challenge_id", challengeID,
+		// 	"credential_id", credentialID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.FinishWebAuthnSignin(ctx, challengeID, credentialID, resp, opt)
+}
+
+func (lm loggingMiddleware) BeginFederatedSignin(ctx context.Context, provider string, tenantName string) (authURL string, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "BeginFederatedSignin",
+		// 	"provider", provider,
+		// 	"tenant", tenantName,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.BeginFederatedSignin(ctx, provider, tenantName)
+}
+
+func (lm loggingMiddleware) BeginFederatedLink(ctx context.Context, provider string, userID string) (authURL string, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "BeginFederatedLink",
+		// 	"provider", provider,
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.BeginFederatedLink(ctx, provider, userID)
+}
+
+func (lm loggingMiddleware) FinishFederatedSignin(ctx context.Context, provider string, state string, code string, opt *SigninOption) (identity *entity.Identity, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "FinishFederatedSignin",
+		// 	"provider", provider,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.FinishFederatedSignin(ctx, provider, state, code, opt)
+}
+
+func (lm loggingMiddleware) Unlock(ctx context.Context, userID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "Unlock",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.Unlock(ctx, userID)
+}
+
+func (lm loggingMiddleware) VerifyEmail(ctx context.Context, token string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "VerifyEmail",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.VerifyEmail(ctx, token)
+}
+
+func (lm loggingMiddleware) ResendVerification(ctx context.Context, username string, tenantName string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ResendVerification",
+		// 	"username", username,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ResendVerification(ctx, username, tenantName)
+}
+
+func (lm loggingMiddleware) RequestPasswordReset(ctx context.Context, email string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "RequestPasswordReset",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.RequestPasswordReset(ctx, email)
+}
+
+func (lm loggingMiddleware) ResetPassword(ctx context.Context, token string, newPassword string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ResetPassword",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ResetPassword(ctx, token, newPassword)
+}
+
+func (lm loggingMiddleware) GetProfile(ctx context.Context, userID string) (user *entity.User, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "GetProfile",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.GetProfile(ctx, userID)
+}
+
+func (lm loggingMiddleware) UpdateProfile(ctx context.Context, userID string, opt *UpdateProfileOption) (user *entity.User, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "UpdateProfile",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.UpdateProfile(ctx, userID, opt)
+}
+
+func (lm loggingMiddleware) ChangePassword(ctx context.Context, userID string, currentPassword string, newPassword string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ChangePassword",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ChangePassword(ctx, userID, currentPassword, newPassword)
+}
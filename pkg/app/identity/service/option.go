@@ -5,6 +5,7 @@ import (
 )
 
 type SigninOption struct {
+	Tenant      string // Tenant name of tenant user belong to, empty is default tenant
 	IPAddress   string
 	Platform    string
 	Device      entity.Device
@@ -12,6 +13,7 @@ type SigninOption struct {
 }
 
 type SignupOption struct {
+	Tenant    string        // Tenant name of tenant user sign up into, empty is default tenant
 	Nickname  string        // Nickname user nickname
 	FirstName string        // FirstName user first name
 	LastName  string        // LastName user last name
@@ -20,3 +22,12 @@ type SignupOption struct {
 	Platform  string        // Login platform
 	Device    entity.Device // Device login information
 }
+
+type UpdateProfileOption struct {
+	Version   int64   // Version of profile read by client, update on stale version is rejected
+	Nickname  *string // Nickname user nickname, nil is unchanged
+	FirstName *string // FirstName user first name, nil is unchanged
+	LastName  *string // LastName user last name, nil is unchanged
+	Email     *string // Email user email address, nil is unchanged, kept until confirmed when verification is required
+	Avatar    *string // Avatar user profile picture URL, nil is unchanged
+}
@@ -2,17 +2,38 @@ package grpc
 
 import (
 	"context"
+	"net"
 
 	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc/peer"
 
 	pb "github.com/karta0898098/iam/pb/identity"
 	"github.com/karta0898098/iam/pkg/app/identity/endpoints"
 	"github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/authn"
 )
 
 type grpcServer struct {
-	signin grpctransport.Handler
-	signup grpctransport.Handler
+	signin             grpctransport.Handler
+	signup             grpctransport.Handler
+	refresh            grpctransport.Handler
+	introspect         grpctransport.Handler
+	signout            grpctransport.Handler
+	signoutAll         grpctransport.Handler
+	listSessions       grpctransport.Handler
+	revokeSession      grpctransport.Handler
+	verifyMFA          grpctransport.Handler
+	enrollTOTP         grpctransport.Handler
+	confirmTOTP        grpctransport.Handler
+	verifyEmail        grpctransport.Handler
+	resendVerification grpctransport.Handler
+
+	requestPasswordReset grpctransport.Handler
+	resetPassword        grpctransport.Handler
+
+	getProfile     grpctransport.Handler
+	updateProfile  grpctransport.Handler
+	changePassword grpctransport.Handler
 }
 
 func (g *grpcServer) Signin(ctx context.Context, req *pb.SigninReq) (*pb.SigninResp, error) {
@@ -37,8 +58,171 @@ func (g *grpcServer) Signup(ctx context.Context, req *pb.SignupReq) (*pb.SignupR
 	return reply, nil
 }
 
+func (g *grpcServer) Refresh(ctx context.Context, req *pb.RefreshReq) (*pb.RefreshResp, error) {
+	_, rp, err := g.refresh.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.RefreshResp)
+	return reply, nil
+}
+
+func (g *grpcServer) Introspect(ctx context.Context, req *pb.IntrospectReq) (*pb.IntrospectResp, error) {
+	_, rp, err := g.introspect.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.IntrospectResp)
+	return reply, nil
+}
+
+func (g *grpcServer) Signout(ctx context.Context, req *pb.SignoutReq) (*pb.SignoutResp, error) {
+	_, rp, err := g.signout.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.SignoutResp)
+	return reply, nil
+}
+
+func (g *grpcServer) SignoutAll(ctx context.Context, req *pb.SignoutAllReq) (*pb.SignoutAllResp, error) {
+	_, rp, err := g.signoutAll.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.SignoutAllResp)
+	return reply, nil
+}
+
+func (g *grpcServer) ListSessions(ctx context.Context, req *pb.ListSessionsReq) (*pb.ListSessionsResp, error) {
+	_, rp, err := g.listSessions.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.ListSessionsResp)
+	return reply, nil
+}
+
+func (g *grpcServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionReq) (*pb.RevokeSessionResp, error) {
+	_, rp, err := g.revokeSession.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.RevokeSessionResp)
+	return reply, nil
+}
+
+func (g *grpcServer) VerifyMFA(ctx context.Context, req *pb.VerifyMFAReq) (*pb.VerifyMFAResp, error) {
+	_, rp, err := g.verifyMFA.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.VerifyMFAResp)
+	return reply, nil
+}
+
+func (g *grpcServer) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPReq) (*pb.EnrollTOTPResp, error) {
+	_, rp, err := g.enrollTOTP.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.EnrollTOTPResp)
+	return reply, nil
+}
+
+func (g *grpcServer) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPReq) (*pb.ConfirmTOTPResp, error) {
+	_, rp, err := g.confirmTOTP.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.ConfirmTOTPResp)
+	return reply, nil
+}
+
+func (g *grpcServer) VerifyEmail(ctx context.Context, req *pb.VerifyEmailReq) (*pb.VerifyEmailResp, error) {
+	_, rp, err := g.verifyEmail.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.VerifyEmailResp)
+	return reply, nil
+}
+
+func (g *grpcServer) ResendVerification(ctx context.Context, req *pb.ResendVerificationReq) (*pb.ResendVerificationResp, error) {
+	_, rp, err := g.resendVerification.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.ResendVerificationResp)
+	return reply, nil
+}
+
+func (g *grpcServer) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetReq) (*pb.RequestPasswordResetResp, error) {
+	_, rp, err := g.requestPasswordReset.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.RequestPasswordResetResp)
+	return reply, nil
+}
+
+func (g *grpcServer) ResetPassword(ctx context.Context, req *pb.ResetPasswordReq) (*pb.ResetPasswordResp, error) {
+	_, rp, err := g.resetPassword.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.ResetPasswordResp)
+	return reply, nil
+}
+
+func (g *grpcServer) GetProfile(ctx context.Context, req *pb.GetProfileReq) (*pb.GetProfileResp, error) {
+	_, rp, err := g.getProfile.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.GetProfileResp)
+	return reply, nil
+}
+
+func (g *grpcServer) UpdateProfile(ctx context.Context, req *pb.UpdateProfileReq) (*pb.UpdateProfileResp, error) {
+	_, rp, err := g.updateProfile.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.UpdateProfileResp)
+	return reply, nil
+}
+
+func (g *grpcServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordReq) (*pb.ChangePasswordResp, error) {
+	_, rp, err := g.changePassword.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.ChangePasswordResp)
+	return reply, nil
+}
+
 func MakeGRPCServer(endpoints endpoints.Endpoints) (req pb.IdentityServiceServer) {
-	options := []grpctransport.ServerOption{}
+	options := []grpctransport.ServerOption{
+		// bearer token of authorization metadata is authenticated by endpoint middleware
+		grpctransport.ServerBefore(authn.GRPCToContext()),
+	}
 
 	return &grpcServer{
 		signin: grpctransport.NewServer(
@@ -53,23 +237,123 @@ func MakeGRPCServer(endpoints endpoints.Endpoints) (req pb.IdentityServiceServer
 			encodeGRPCSignupResponse,
 			options...,
 		),
+		refresh: grpctransport.NewServer(
+			endpoints.RefreshEndpoint,
+			decodeGRPCRefreshRequest,
+			encodeGRPCRefreshResponse,
+			options...,
+		),
+		introspect: grpctransport.NewServer(
+			endpoints.IntrospectEndpoint,
+			decodeGRPCIntrospectRequest,
+			encodeGRPCIntrospectResponse,
+			options...,
+		),
+		signout: grpctransport.NewServer(
+			endpoints.SignoutEndpoint,
+			decodeGRPCSignoutRequest,
+			encodeGRPCSignoutResponse,
+			options...,
+		),
+		signoutAll: grpctransport.NewServer(
+			endpoints.SignoutAllEndpoint,
+			decodeGRPCSignoutAllRequest,
+			encodeGRPCSignoutAllResponse,
+			options...,
+		),
+		listSessions: grpctransport.NewServer(
+			endpoints.ListSessionsEndpoint,
+			decodeGRPCListSessionsRequest,
+			encodeGRPCListSessionsResponse,
+			options...,
+		),
+		revokeSession: grpctransport.NewServer(
+			endpoints.RevokeSessionEndpoint,
+			decodeGRPCRevokeSessionRequest,
+			encodeGRPCRevokeSessionResponse,
+			options...,
+		),
+		verifyMFA: grpctransport.NewServer(
+			endpoints.VerifyMFAEndpoint,
+			decodeGRPCVerifyMFARequest,
+			encodeGRPCVerifyMFAResponse,
+			options...,
+		),
+		enrollTOTP: grpctransport.NewServer(
+			endpoints.EnrollTOTPEndpoint,
+			decodeGRPCEnrollTOTPRequest,
+			encodeGRPCEnrollTOTPResponse,
+			options...,
+		),
+		confirmTOTP: grpctransport.NewServer(
+			endpoints.ConfirmTOTPEndpoint,
+			decodeGRPCConfirmTOTPRequest,
+			encodeGRPCConfirmTOTPResponse,
+			options...,
+		),
+		verifyEmail: grpctransport.NewServer(
+			endpoints.VerifyEmailEndpoint,
+			decodeGRPCVerifyEmailRequest,
+			encodeGRPCVerifyEmailResponse,
+			options...,
+		),
+		resendVerification: grpctransport.NewServer(
+			endpoints.ResendVerificationEndpoint,
+			decodeGRPCResendVerificationRequest,
+			encodeGRPCResendVerificationResponse,
+			options...,
+		),
+		requestPasswordReset: grpctransport.NewServer(
+			endpoints.RequestPasswordResetEndpoint,
+			decodeGRPCRequestPasswordResetRequest,
+			encodeGRPCRequestPasswordResetResponse,
+			options...,
+		),
+		resetPassword: grpctransport.NewServer(
+			endpoints.ResetPasswordEndpoint,
+			decodeGRPCResetPasswordRequest,
+			encodeGRPCResetPasswordResponse,
+			options...,
+		),
+		getProfile: grpctransport.NewServer(
+			endpoints.GetProfileEndpoint,
+			decodeGRPCGetProfileRequest,
+			encodeGRPCGetProfileResponse,
+			options...,
+		),
+		updateProfile: grpctransport.NewServer(
+			endpoints.UpdateProfileEndpoint,
+			decodeGRPCUpdateProfileRequest,
+			encodeGRPCUpdateProfileResponse,
+			options...,
+		),
+		changePassword: grpctransport.NewServer(
+			endpoints.ChangePasswordEndpoint,
+			decodeGRPCChangePasswordRequest,
+			encodeGRPCChangePasswordResponse,
+			options...,
+		),
 	}
 }
 
 // decodeGRPCSigninRequest is a transport/grpc.DecodeRequestFunc that converts a
 // gRPC request to a user-domain request. Primarily useful in a server.
-func decodeGRPCSigninRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+func decodeGRPCSigninRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*pb.SigninReq)
 
+	// failures are counted per ip address of peer, address in request is never trusted
 	return &endpoints.SigninRequest{
+		Tenant:    req.Tenant,
 		Username:  req.Username,
 		Password:  req.Password,
-		IPAddress: req.IPAddress,
+		IPAddress: peerIP(ctx),
 		Device: entity.Device{
 			Model:     req.Device.Model,
 			Name:      req.Device.Name,
 			OSVersion: req.Device.OSVersion,
 		},
+		Scope: req.Scope,
+		Nonce: req.Nonce,
 	}, nil
 }
 
@@ -81,6 +365,8 @@ func encodeGRPCSigninResponse(_ context.Context, grpcReply interface{}) (res int
 		AccessToken:  reply.AccessToken,
 		RefreshToken: reply.RefreshToken,
 		IDToken:      reply.IDToken,
+		MFARequired:  reply.MFARequired,
+		MFAToken:     reply.MFAToken,
 	}, nil
 }
 
@@ -98,6 +384,8 @@ func encodeGRPCSigninRequest(_ context.Context, request interface{}) (interface{
 			OSVersion: req.Device.OSVersion,
 		},
 		IdpProvider: req.IdpProvider,
+		Scope:       req.Scope,
+		Nonce:       req.Nonce,
 	}, nil
 }
 
@@ -107,6 +395,8 @@ func decodeGRPCSigninResponse(ctx context.Context, grpcReply interface{}) (respo
 		IDToken:      reply.IDToken,
 		AccessToken:  reply.AccessToken,
 		RefreshToken: reply.RefreshToken,
+		MFARequired:  reply.MFARequired,
+		MFAToken:     reply.MFAToken,
 	}, nil
 }
 
@@ -116,6 +406,7 @@ func decodeGRPCSignupRequest(ctx context.Context, grpcReq interface{}) (interfac
 	req := grpcReq.(*pb.SignupReq)
 
 	return &endpoints.SignupRequest{
+		Tenant:    req.Tenant,
 		Username:  req.Username,
 		Password:  req.Password,
 		Nickname:  req.Nickname,
@@ -123,12 +414,14 @@ func decodeGRPCSignupRequest(ctx context.Context, grpcReq interface{}) (interfac
 		LastName:  req.LastName,
 		Email:     req.Email,
 		Platform:  req.Platform,
-		IPAddress: req.IPAddress,
+		IPAddress: peerIP(ctx),
 		Device: entity.Device{
 			Model:     req.Device.Model,
 			Name:      req.Device.Name,
 			OSVersion: req.Device.OSVersion,
 		},
+		Scope: req.Scope,
+		Nonce: req.Nonce,
 	}, nil
 }
 
@@ -140,6 +433,8 @@ func encodeGRPCSignupResponse(_ context.Context, grpcReply interface{}) (res int
 		AccessToken:  reply.AccessToken,
 		RefreshToken: reply.RefreshToken,
 		IDToken:      reply.IDToken,
+
+		EmailVerificationRequired: reply.EmailVerificationRequired,
 	}, nil
 }
 
@@ -170,3 +465,380 @@ func decodeGRPCSignupResponse(ctx context.Context, grpcReply interface{}) (respo
 		RefreshToken: reply.RefreshToken,
 	}, nil
 }
+
+// decodeGRPCRefreshRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCRefreshRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.RefreshReq)
+
+	return &endpoints.RefreshRequest{
+		RefreshToken: req.RefreshToken,
+	}, nil
+}
+
+// encodeGRPCRefreshResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCRefreshResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.RefreshResponse)
+	return &pb.RefreshResp{
+		AccessToken:  reply.AccessToken,
+		RefreshToken: reply.RefreshToken,
+	}, nil
+}
+
+// encodeGRPCRefreshRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain Refresh request to a gRPC Refresh request. Primarily useful in a client.
+func encodeGRPCRefreshRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(*endpoints.RefreshRequest)
+	return &pb.RefreshReq{
+		RefreshToken: req.RefreshToken,
+	}, nil
+}
+
+// decodeGRPCRefreshResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC Refresh reply to a user-domain Refresh response. Primarily useful in a client.
+func decodeGRPCRefreshResponse(_ context.Context, grpcReply interface{}) (response interface{}, err error) {
+	reply := grpcReply.(*pb.RefreshResp)
+	return &endpoints.RefreshResponse{
+		AccessToken:  reply.AccessToken,
+		RefreshToken: reply.RefreshToken,
+	}, nil
+}
+
+// decodeGRPCIntrospectRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCIntrospectRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.IntrospectReq)
+
+	return &endpoints.IntrospectRequest{
+		Token: req.Token,
+	}, nil
+}
+
+// encodeGRPCIntrospectResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCIntrospectResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.IntrospectResponse)
+	return &pb.IntrospectResp{
+		Active:   reply.Active,
+		Sub:      reply.Sub,
+		Jti:      reply.Jti,
+		Exp:      reply.Exp,
+		Iat:      reply.Iat,
+		Platform: reply.Platform,
+		ClientID: reply.ClientID,
+		Scope:    reply.Scope,
+		Tenant:   reply.Tenant,
+	}, nil
+}
+
+// encodeGRPCIntrospectRequest is a transport/grpc.EncodeRequestFunc that converts a
+// user-domain Introspect request to a gRPC Introspect request. Primarily useful in a client.
+func encodeGRPCIntrospectRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(*endpoints.IntrospectRequest)
+	return &pb.IntrospectReq{
+		Token: req.Token,
+	}, nil
+}
+
+// decodeGRPCIntrospectResponse is a transport/grpc.DecodeResponseFunc that converts a
+// gRPC Introspect reply to a user-domain Introspect response. Primarily useful in a client.
+func decodeGRPCIntrospectResponse(_ context.Context, grpcReply interface{}) (response interface{}, err error) {
+	reply := grpcReply.(*pb.IntrospectResp)
+	return &endpoints.IntrospectResponse{
+		Active:   reply.Active,
+		Sub:      reply.Sub,
+		Jti:      reply.Jti,
+		Exp:      reply.Exp,
+		Iat:      reply.Iat,
+		Platform: reply.Platform,
+		ClientID: reply.ClientID,
+		Scope:    reply.Scope,
+		Tenant:   reply.Tenant,
+	}, nil
+}
+
+// decodeGRPCSignoutRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCSignoutRequest(ctx context.Context, _ interface{}) (interface{}, error) {
+	return &endpoints.SignoutRequest{}, nil
+}
+
+// encodeGRPCSignoutResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCSignoutResponse(_ context.Context, _ interface{}) (res interface{}, err error) {
+	return &pb.SignoutResp{}, nil
+}
+
+// decodeGRPCSignoutAllRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCSignoutAllRequest(ctx context.Context, _ interface{}) (interface{}, error) {
+	return &endpoints.SignoutAllRequest{}, nil
+}
+
+// encodeGRPCSignoutAllResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCSignoutAllResponse(_ context.Context, _ interface{}) (res interface{}, err error) {
+	return &pb.SignoutAllResp{}, nil
+}
+
+// decodeGRPCListSessionsRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCListSessionsRequest(ctx context.Context, _ interface{}) (interface{}, error) {
+	return &endpoints.ListSessionsRequest{}, nil
+}
+
+// encodeGRPCListSessionsResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCListSessionsResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.ListSessionsResponse)
+
+	sessions := make([]*pb.Session, 0, len(reply.Sessions))
+	for _, session := range reply.Sessions {
+		sessions = append(sessions, &pb.Session{
+			ID:        session.ID,
+			IPAddress: session.IPAddress,
+			Platform:  session.Platform,
+			Device: &pb.Device{
+				Model:     session.Device.Model,
+				Name:      session.Device.Name,
+				OSVersion: session.Device.OSVersion,
+			},
+			IdpProvider: session.IdpProvider,
+			ClientID:    session.ClientID,
+			CreatedAt:   session.CreatedAt,
+			LastSeenAt:  session.LastSeenAt,
+			Current:     session.Current,
+		})
+	}
+
+	return &pb.ListSessionsResp{
+		Sessions: sessions,
+	}, nil
+}
+
+// decodeGRPCRevokeSessionRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCRevokeSessionRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.RevokeSessionReq)
+
+	return &endpoints.RevokeSessionRequest{
+		SessionID: req.SessionID,
+	}, nil
+}
+
+// encodeGRPCRevokeSessionResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCRevokeSessionResponse(_ context.Context, _ interface{}) (res interface{}, err error) {
+	return &pb.RevokeSessionResp{}, nil
+}
+
+// decodeGRPCVerifyMFARequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCVerifyMFARequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.VerifyMFAReq)
+
+	return &endpoints.VerifyMFARequest{
+		MFAToken: req.MFAToken,
+		Code:     req.Code,
+		Scope:    req.Scope,
+		Nonce:    req.Nonce,
+	}, nil
+}
+
+// encodeGRPCVerifyMFAResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCVerifyMFAResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.VerifyMFAResponse)
+	return &pb.VerifyMFAResp{
+		AccessToken:  reply.AccessToken,
+		RefreshToken: reply.RefreshToken,
+		IDToken:      reply.IDToken,
+	}, nil
+}
+
+// decodeGRPCEnrollTOTPRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCEnrollTOTPRequest(ctx context.Context, _ interface{}) (interface{}, error) {
+	return &endpoints.EnrollTOTPRequest{}, nil
+}
+
+// encodeGRPCEnrollTOTPResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCEnrollTOTPResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.EnrollTOTPResponse)
+	return &pb.EnrollTOTPResp{
+		Secret: reply.Secret,
+		URI:    reply.URI,
+	}, nil
+}
+
+// decodeGRPCConfirmTOTPRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCConfirmTOTPRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ConfirmTOTPReq)
+
+	return &endpoints.ConfirmTOTPRequest{
+		Code: req.Code,
+	}, nil
+}
+
+// encodeGRPCConfirmTOTPResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCConfirmTOTPResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.ConfirmTOTPResponse)
+	return &pb.ConfirmTOTPResp{
+		RecoveryCodes: reply.RecoveryCodes,
+	}, nil
+}
+
+// decodeGRPCVerifyEmailRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCVerifyEmailRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.VerifyEmailReq)
+
+	return &endpoints.VerifyEmailRequest{
+		Token: req.Token,
+	}, nil
+}
+
+// encodeGRPCVerifyEmailResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCVerifyEmailResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	return &pb.VerifyEmailResp{}, nil
+}
+
+// decodeGRPCResendVerificationRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCResendVerificationRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ResendVerificationReq)
+
+	return &endpoints.ResendVerificationRequest{
+		Username: req.Username,
+	}, nil
+}
+
+// encodeGRPCResendVerificationResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCResendVerificationResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	return &pb.ResendVerificationResp{}, nil
+}
+
+// decodeGRPCRequestPasswordResetRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCRequestPasswordResetRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.RequestPasswordResetReq)
+
+	return &endpoints.RequestPasswordResetRequest{
+		Email: req.Email,
+	}, nil
+}
+
+// encodeGRPCRequestPasswordResetResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCRequestPasswordResetResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	return &pb.RequestPasswordResetResp{}, nil
+}
+
+// decodeGRPCResetPasswordRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCResetPasswordRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ResetPasswordReq)
+
+	return &endpoints.ResetPasswordRequest{
+		Token:    req.Token,
+		Password: req.Password,
+	}, nil
+}
+
+// encodeGRPCResetPasswordResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCResetPasswordResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	return &pb.ResetPasswordResp{}, nil
+}
+
+// decodeGRPCGetProfileRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCGetProfileRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	return &endpoints.GetProfileRequest{}, nil
+}
+
+// encodeGRPCGetProfileResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCGetProfileResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.GetProfileResponse)
+	return &pb.GetProfileResp{
+		Profile: encodeGRPCProfile(reply.Profile),
+	}, nil
+}
+
+// decodeGRPCUpdateProfileRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCUpdateProfileRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdateProfileReq)
+
+	return &endpoints.UpdateProfileRequest{
+		Version:   req.Version,
+		Nickname:  req.Nickname,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Email:     req.Email,
+		Avatar:    req.Avatar,
+	}, nil
+}
+
+// encodeGRPCUpdateProfileResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCUpdateProfileResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.UpdateProfileResponse)
+	return &pb.UpdateProfileResp{
+		Profile: encodeGRPCProfile(reply.Profile),
+	}, nil
+}
+
+// decodeGRPCChangePasswordRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCChangePasswordRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ChangePasswordReq)
+
+	return &endpoints.ChangePasswordRequest{
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}, nil
+}
+
+// encodeGRPCChangePasswordResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCChangePasswordResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	return &pb.ChangePasswordResp{}, nil
+}
+
+// encodeGRPCProfile convert profile to protobuf message
+func encodeGRPCProfile(profile *endpoints.Profile) *pb.Profile {
+	return &pb.Profile{
+		ID:        profile.ID,
+		Username:  profile.Username,
+		Nickname:  profile.Nickname,
+		FirstName: profile.FirstName,
+		LastName:  profile.LastName,
+		Email:     profile.Email,
+		Avatar:    profile.Avatar,
+		CreatedAt: profile.CreatedAt,
+		UpdatedAt: profile.UpdatedAt,
+		Version:   profile.Version,
+	}
+}
+
+// peerIP read ip address of connected client
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
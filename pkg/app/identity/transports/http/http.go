@@ -4,13 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/labstack/echo/v4"
 
 	"github.com/karta0898098/iam/pkg/app/identity/endpoints"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/clientip"
 	"github.com/karta0898098/iam/pkg/errors"
+	pkghttp "github.com/karta0898098/iam/pkg/http"
 )
 
 // MakeSignin signin endpoint
@@ -34,6 +39,9 @@ func decodeHTTPSigninRequest(ctx context.Context, r *http.Request) (interface{},
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
 	}
+
+	// failures are counted per ip address of connection, address in body is never trusted
+	req.IPAddress = remoteIP(r)
 	return &req, err
 }
 
@@ -79,6 +87,7 @@ func decodeHTTPSignupRequest(ctx context.Context, r *http.Request) (interface{},
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
 	}
+	req.IPAddress = remoteIP(r)
 	return &req, err
 }
 
@@ -104,3 +113,600 @@ func encodeHTTPSignupResponse(ctx context.Context, w http.ResponseWriter, respon
 
 	return json.NewEncoder(w).Encode(response)
 }
+
+// MakeRefresh make refresh token endpoint
+func MakeRefresh(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.RefreshEndpoint,
+		decodeHTTPRefreshRequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPRefreshRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPRefreshRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.RefreshRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeIntrospect make token introspect endpoint
+func MakeIntrospect(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.IntrospectEndpoint,
+		decodeHTTPIntrospectRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPIntrospectRequest is a transport/http.DecodeRequestFunc that decodes
+// introspect request from the HTTP request body. RFC 7662 clients post the token
+// as form value, so form-encoded body is accepted as well as JSON.
+// Bearer token authenticating the client is read by authn.HTTPToContext.
+func decodeHTTPIntrospectRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.IntrospectRequest
+
+	if strings.HasPrefix(r.Header.Get(echo.HeaderContentType), echo.MIMEApplicationForm) {
+		err := r.ParseForm()
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not form")
+		}
+		req.Token = r.PostForm.Get("token")
+		return &req, nil
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeSignout make signout endpoint
+func MakeSignout(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.SignoutEndpoint,
+		decodeHTTPSignoutRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPSignoutRequest is a transport/http.DecodeRequestFunc that decodes
+// signout request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPSignoutRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.SignoutRequest{}, nil
+}
+
+// MakeSignoutAll make signout all devices endpoint
+func MakeSignoutAll(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.SignoutAllEndpoint,
+		decodeHTTPSignoutAllRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPSignoutAllRequest is a transport/http.DecodeRequestFunc that decodes
+// signout all request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPSignoutAllRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.SignoutAllRequest{}, nil
+}
+
+// MakeRevokeSession make revoke session endpoint
+func MakeRevokeSession(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.RevokeSessionEndpoint,
+		decodeHTTPRevokeSessionRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPRevokeSessionRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPRevokeSessionRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.RevokeSessionRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeListSessions make list sessions of signed in user endpoint
+func MakeListSessions(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListSessionsEndpoint,
+		decodeHTTPListSessionsRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListSessionsRequest is a transport/http.DecodeRequestFunc that decodes
+// list sessions request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPListSessionsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListSessionsRequest{}, nil
+}
+
+// MakeJWKS make endpoint publish public keys
+func MakeJWKS(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.JWKSEndpoint,
+		decodeHTTPJWKSRequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPJWKSRequest is a transport/http.DecodeRequestFunc that decodes
+// jwks request, the request has no input. Primarily useful in a server.
+func decodeHTTPJWKSRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.JWKSRequest{}, nil
+}
+
+// MakeDiscovery make endpoint publish OpenID provider metadata
+func MakeDiscovery(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DiscoveryEndpoint,
+		decodeHTTPDiscoveryRequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPDiscoveryRequest is a transport/http.DecodeRequestFunc that decodes
+// openid configuration request, the request has no input. Primarily useful in a server.
+func decodeHTTPDiscoveryRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.DiscoveryRequest{}, nil
+}
+
+// MakeEnrollTOTP make enroll totp endpoint
+func MakeEnrollTOTP(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.EnrollTOTPEndpoint,
+		decodeHTTPEnrollTOTPRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPEnrollTOTPRequest is a transport/http.DecodeRequestFunc that decodes
+// enroll totp request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPEnrollTOTPRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.EnrollTOTPRequest{}, nil
+}
+
+// MakeConfirmTOTP make confirm totp endpoint
+func MakeConfirmTOTP(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ConfirmTOTPEndpoint,
+		decodeHTTPConfirmTOTPRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPConfirmTOTPRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPConfirmTOTPRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.ConfirmTOTPRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeVerifyMFA make verify mfa endpoint
+func MakeVerifyMFA(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.VerifyMFAEndpoint,
+		decodeHTTPVerifyMFARequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPVerifyMFARequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPVerifyMFARequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.VerifyMFARequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeWebAuthnRegisterBegin make begin passkey registration endpoint
+func MakeWebAuthnRegisterBegin(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.WebAuthnRegisterBeginEndpoint,
+		decodeHTTPWebAuthnRegisterBeginRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPWebAuthnRegisterBeginRequest is a transport/http.DecodeRequestFunc that decodes
+// begin passkey registration request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPWebAuthnRegisterBeginRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.WebAuthnRegisterBeginRequest{}, nil
+}
+
+// MakeWebAuthnRegisterFinish make finish passkey registration endpoint
+func MakeWebAuthnRegisterFinish(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.WebAuthnRegisterFinishEndpoint,
+		decodeHTTPWebAuthnRegisterFinishRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPWebAuthnRegisterFinishRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPWebAuthnRegisterFinishRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.WebAuthnRegisterFinishRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeWebAuthnSigninBegin make begin passwordless signin endpoint
+func MakeWebAuthnSigninBegin(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.WebAuthnSigninBeginEndpoint,
+		decodeHTTPWebAuthnSigninBeginRequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPWebAuthnSigninBeginRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPWebAuthnSigninBeginRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.WebAuthnSigninBeginRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeWebAuthnSigninFinish make finish passwordless signin endpoint
+func MakeWebAuthnSigninFinish(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.WebAuthnSigninFinishEndpoint,
+		decodeHTTPWebAuthnSigninFinishRequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPWebAuthnSigninFinishRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPWebAuthnSigninFinishRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.WebAuthnSigninFinishRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.IPAddress = remoteIP(r)
+	return &req, err
+}
+
+// MakeFederationSigninBegin make begin signin at upstream provider endpoint
+func MakeFederationSigninBegin(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.FederationSigninBeginEndpoint,
+		decodeHTTPFederationSigninBeginRequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPFederationSigninBeginRequest is a transport/http.DecodeRequestFunc that decodes
+// provider from the URL path and tenant from optional JSON body. Primarily useful in a server.
+func decodeHTTPFederationSigninBeginRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.FederationSigninBeginRequest
+	if r.ContentLength != 0 {
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+		}
+	}
+	req.Provider = pkghttp.PathParam(r, "provider")
+	return &req, nil
+}
+
+// MakeFederationLinkBegin make begin linking upstream provider endpoint
+func MakeFederationLinkBegin(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.FederationLinkBeginEndpoint,
+		decodeHTTPFederationLinkBeginRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPFederationLinkBeginRequest is a transport/http.DecodeRequestFunc that decodes
+// provider from the URL path. Primarily useful in a server.
+func decodeHTTPFederationLinkBeginRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.FederationLinkBeginRequest{
+		Provider: pkghttp.PathParam(r, "provider"),
+	}, nil
+}
+
+// MakeFederationSigninFinish make finish signin at upstream provider endpoint
+func MakeFederationSigninFinish(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.FederationSigninFinishEndpoint,
+		decodeHTTPFederationSigninFinishRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPFederationSigninFinishRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body, bearer token of user finishing link
+// is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPFederationSigninFinishRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.FederationSigninFinishRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.Provider = pkghttp.PathParam(r, "provider")
+	req.IPAddress = remoteIP(r)
+	return &req, nil
+}
+
+// MakeVerifyEmail make verify email endpoint
+func MakeVerifyEmail(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.VerifyEmailEndpoint,
+		decodeHTTPVerifyEmailRequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPVerifyEmailRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPVerifyEmailRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.VerifyEmailRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeResendVerification make resend verification mail endpoint
+func MakeResendVerification(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ResendVerificationEndpoint,
+		decodeHTTPResendVerificationRequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPResendVerificationRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPResendVerificationRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.ResendVerificationRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeRequestPasswordReset make request password reset endpoint
+func MakeRequestPasswordReset(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.RequestPasswordResetEndpoint,
+		decodeHTTPRequestPasswordResetRequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPRequestPasswordResetRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPRequestPasswordResetRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.RequestPasswordResetRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeResetPassword make reset password endpoint
+func MakeResetPassword(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ResetPasswordEndpoint,
+		decodeHTTPResetPasswordRequest,
+		encodeHTTPResponse,
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPResetPasswordRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPResetPasswordRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.ResetPasswordRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeGetProfile make get profile endpoint
+func MakeGetProfile(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.GetProfileEndpoint,
+		decodeHTTPGetProfileRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPGetProfileRequest is a transport/http.DecodeRequestFunc that decodes
+// get profile request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPGetProfileRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.GetProfileRequest{}, nil
+}
+
+// MakeUpdateProfile make update profile endpoint
+func MakeUpdateProfile(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.UpdateProfileEndpoint,
+		decodeHTTPUpdateProfileRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPUpdateProfileRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPUpdateProfileRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.UpdateProfileRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeChangePassword make change password endpoint
+func MakeChangePassword(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ChangePasswordEndpoint,
+		decodeHTTPChangePasswordRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPChangePasswordRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPChangePasswordRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.ChangePasswordRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, err
+}
+
+// MakeUnlockUser make unlock user endpoint
+func MakeUnlockUser(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.UnlockUserEndpoint,
+		decodeHTTPUnlockUserRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPUnlockUserRequest is a transport/http.DecodeRequestFunc that decodes
+// user id from the URL path. Primarily useful in a server.
+func decodeHTTPUnlockUserRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.UnlockUserRequest{
+		UserID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// remoteIP read client ip address put by client ip middleware, which only trust
+// proxy header from configured proxies, connection is used when it is absent
+func remoteIP(r *http.Request) string {
+	if ip := clientip.FromContext(r.Context()); ip != "" {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// encodeHTTPResponse is a transport/http.EncodeResponseFunc that encodes
+// response as JSON into the HTTP response body. Primarily useful in a server.
+func encodeHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	if headers, ok := response.(httptransport.Headerer); ok {
+		for k, values := range headers.Headers() {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+	code := http.StatusOK
+	if sc, ok := response.(httptransport.StatusCoder); ok {
+		code = sc.StatusCode()
+	}
+	w.WriteHeader(code)
+	if code == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
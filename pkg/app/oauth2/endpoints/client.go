@@ -0,0 +1,216 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/oauth2/entity"
+	"github.com/karta0898098/iam/pkg/app/oauth2/service"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+const (
+	// TokenEndpointAuthMethodNone public client
+	TokenEndpointAuthMethodNone = "none"
+	// TokenEndpointAuthMethodClientSecretBasic confidential client
+	TokenEndpointAuthMethodClientSecretBasic = "client_secret_basic"
+)
+
+// ClientMetadata define client registration follow RFC 7591 section 2
+type ClientMetadata struct {
+	ClientName              string   `json:"client_name"`
+	RedirectURIs            []string `json:"redirect_uris"`
+	GrantTypes              []string `json:"grant_types"`
+	Scope                   string   `json:"scope"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method"`
+}
+
+// ClientResponse define registered client
+// client secret only present when it is generated
+type ClientResponse struct {
+	ClientMetadata
+
+	ClientID         string `json:"client_id"`
+	ClientSecret     string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt int64  `json:"client_id_issued_at"`
+	UpdatedAt        int64  `json:"updated_at"`
+}
+
+// CreateClientRequest define create client request
+type CreateClientRequest struct {
+	ClientMetadata
+}
+
+// MakeCreateClientEndpoint make create client endpoint
+func MakeCreateClientEndpoint(svc service.OAuth2Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*CreateClientRequest)
+
+		client, secret, err := svc.CreateClient(ctx, newClientOption(&req.ClientMetadata))
+		if err != nil {
+			return nil, err
+		}
+
+		resp := newClientResponse(client)
+		resp.ClientSecret = secret
+
+		return resp, nil
+	}
+}
+
+// GetClientRequest define get client request
+type GetClientRequest struct {
+	ClientID string `json:"-"`
+}
+
+// MakeGetClientEndpoint make get client endpoint
+func MakeGetClientEndpoint(svc service.OAuth2Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*GetClientRequest)
+
+		client, err := svc.GetClient(ctx, req.ClientID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newClientResponse(client), nil
+	}
+}
+
+// ListClientsRequest define list clients request
+type ListClientsRequest struct {
+}
+
+// ListClientsResponse define list clients response
+type ListClientsResponse struct {
+	Clients []*ClientResponse `json:"clients"`
+}
+
+// MakeListClientsEndpoint make list clients endpoint
+func MakeListClientsEndpoint(svc service.OAuth2Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		clients, err := svc.ListClients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &ListClientsResponse{
+			Clients: make([]*ClientResponse, 0, len(clients)),
+		}
+		for _, client := range clients {
+			resp.Clients = append(resp.Clients, newClientResponse(client))
+		}
+
+		return resp, nil
+	}
+}
+
+// UpdateClientRequest define update client request
+type UpdateClientRequest struct {
+	ClientMetadata
+
+	ClientID string `json:"-"`
+}
+
+// MakeUpdateClientEndpoint make update client endpoint
+func MakeUpdateClientEndpoint(svc service.OAuth2Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UpdateClientRequest)
+
+		opt := newClientOption(&req.ClientMetadata)
+		if req.TokenEndpointAuthMethod == "" {
+			// keep client type when auth method is omitted
+			opt.Type = entity.ClientTypeUnknown
+		}
+
+		client, err := svc.UpdateClient(ctx, req.ClientID, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		return newClientResponse(client), nil
+	}
+}
+
+// ResetClientSecretRequest define reset client secret request
+type ResetClientSecretRequest struct {
+	ClientID string `json:"-"`
+}
+
+// MakeResetClientSecretEndpoint make reset client secret endpoint
+func MakeResetClientSecretEndpoint(svc service.OAuth2Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ResetClientSecretRequest)
+
+		client, secret, err := svc.ResetClientSecret(ctx, req.ClientID)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := newClientResponse(client)
+		resp.ClientSecret = secret
+
+		return resp, nil
+	}
+}
+
+// DeleteClientRequest define delete client request
+type DeleteClientRequest struct {
+	ClientID string `json:"-"`
+}
+
+// DeleteClientResponse define delete client response
+type DeleteClientResponse struct {
+}
+
+// MakeDeleteClientEndpoint make delete client endpoint
+func MakeDeleteClientEndpoint(svc service.OAuth2Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DeleteClientRequest)
+
+		err = svc.DeleteClient(ctx, req.ClientID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeleteClientResponse{}, nil
+	}
+}
+
+// newClientOption convert client metadata to service option
+func newClientOption(metadata *ClientMetadata) *service.ClientOption {
+	clientType := entity.ClientTypeConfidential
+	if metadata.TokenEndpointAuthMethod == TokenEndpointAuthMethodNone {
+		clientType = entity.ClientTypePublic
+	}
+
+	return &service.ClientOption{
+		Name:         metadata.ClientName,
+		Type:         clientType,
+		RedirectURIs: metadata.RedirectURIs,
+		Scopes:       oidc.ParseScopes(metadata.Scope),
+		GrantTypes:   metadata.GrantTypes,
+	}
+}
+
+// newClientResponse convert client to response
+func newClientResponse(client *entity.Client) *ClientResponse {
+	authMethod := TokenEndpointAuthMethodClientSecretBasic
+	if client.IsPublic() {
+		authMethod = TokenEndpointAuthMethodNone
+	}
+
+	return &ClientResponse{
+		ClientMetadata: ClientMetadata{
+			ClientName:              client.Name,
+			RedirectURIs:            client.RedirectURIs,
+			GrantTypes:              client.GrantTypes,
+			Scope:                   client.Scopes.String(),
+			TokenEndpointAuthMethod: authMethod,
+		},
+		ClientID:         client.ID,
+		ClientIDIssuedAt: client.CreatedAt.Unix(),
+		UpdatedAt:        client.UpdatedAt.Unix(),
+	}
+}
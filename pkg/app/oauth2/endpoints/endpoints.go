@@ -0,0 +1,343 @@
+package endpoints
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/go-kit/kit/endpoint"
+
+	identityendpoints "github.com/karta0898098/iam/pkg/app/identity/endpoints"
+	identity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	identitysvc "github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/app/oauth2/entity"
+	"github.com/karta0898098/iam/pkg/app/oauth2/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+)
+
+// Endpoints define OAuth 2.0 endpoints
+type Endpoints struct {
+	AuthorizeEndpoint         endpoint.Endpoint
+	ConsentEndpoint           endpoint.Endpoint
+	TokenEndpoint             endpoint.Endpoint
+	CreateClientEndpoint      endpoint.Endpoint
+	GetClientEndpoint         endpoint.Endpoint
+	ListClientsEndpoint       endpoint.Endpoint
+	UpdateClientEndpoint      endpoint.Endpoint
+	ResetClientSecretEndpoint endpoint.Endpoint
+	DeleteClientEndpoint      endpoint.Endpoint
+}
+
+// New endpoints
+func New(
+	svc service.OAuth2Service,
+	identitySvc identitysvc.IdentityService,
+	permissions authn.PermissionChecker,
+	km keys.KeyManager,
+	oidcConfig oidc.Config,
+	limiter *ratelimit.Limiter,
+) (ep Endpoints) {
+	authenticator := identityendpoints.NewAuthenticator(identitySvc)
+
+	authorizeEndpoint := MakeAuthorizeEndpoint(svc, oidcConfig)
+	authorizeEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("Authorize"),
+		identityendpoints.RateLimitMiddleware(limiter, "Authorize"),
+	)(authorizeEndpoint)
+	ep.AuthorizeEndpoint = authorizeEndpoint
+
+	consentEndpoint := MakeConsentEndpoint(svc)
+	consentEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("Consent"),
+		identityendpoints.RateLimitMiddleware(limiter, "Consent"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(consentEndpoint)
+	ep.ConsentEndpoint = consentEndpoint
+
+	tokenEndpoint := MakeTokenEndpoint(svc, km, oidcConfig)
+	tokenEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("Token"),
+		identityendpoints.RateLimitMiddleware(limiter, "Token"),
+	)(tokenEndpoint)
+	ep.TokenEndpoint = tokenEndpoint
+
+	createClientEndpoint := MakeCreateClientEndpoint(svc)
+	createClientEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("CreateClient"),
+		identityendpoints.RateLimitMiddleware(limiter, "CreateClient"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(createClientEndpoint)
+	ep.CreateClientEndpoint = createClientEndpoint
+
+	getClientEndpoint := MakeGetClientEndpoint(svc)
+	getClientEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("GetClient"),
+		identityendpoints.RateLimitMiddleware(limiter, "GetClient"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(getClientEndpoint)
+	ep.GetClientEndpoint = getClientEndpoint
+
+	listClientsEndpoint := MakeListClientsEndpoint(svc)
+	listClientsEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListClients"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListClients"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(listClientsEndpoint)
+	ep.ListClientsEndpoint = listClientsEndpoint
+
+	updateClientEndpoint := MakeUpdateClientEndpoint(svc)
+	updateClientEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("UpdateClient"),
+		identityendpoints.RateLimitMiddleware(limiter, "UpdateClient"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(updateClientEndpoint)
+	ep.UpdateClientEndpoint = updateClientEndpoint
+
+	resetClientSecretEndpoint := MakeResetClientSecretEndpoint(svc)
+	resetClientSecretEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ResetClientSecret"),
+		identityendpoints.RateLimitMiddleware(limiter, "ResetClientSecret"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(resetClientSecretEndpoint)
+	ep.ResetClientSecretEndpoint = resetClientSecretEndpoint
+
+	deleteClientEndpoint := MakeDeleteClientEndpoint(svc)
+	deleteClientEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("DeleteClient"),
+		identityendpoints.RateLimitMiddleware(limiter, "DeleteClient"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(deleteClientEndpoint)
+	ep.DeleteClientEndpoint = deleteClientEndpoint
+
+	return ep
+}
+
+// AuthorizeRequest define authorization request
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	Nonce               string `json:"nonce"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+}
+
+// RateLimitKeys implement ratelimit.Keyer
+func (r *AuthorizeRequest) RateLimitKeys() ratelimit.Keys {
+	return ratelimit.Keys{
+		ratelimit.KeyClientID: r.ClientID,
+	}
+}
+
+// AuthorizeResponse define where user agent should go next
+type AuthorizeResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// MakeAuthorizeEndpoint make endpoint verify authorization request
+// then send user agent to login page with the original request
+func MakeAuthorizeEndpoint(svc service.OAuth2Service, oidcConfig oidc.Config) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*AuthorizeRequest)
+
+		_, err = svc.ValidateAuthorizeRequest(ctx, newServiceAuthorizeRequest(req, ""))
+		if err != nil {
+			return redirectErrorResponse(err)
+		}
+
+		return &AuthorizeResponse{
+			RedirectTo: entity.AppendQuery(oidcConfig.LoginURL, req.toValues()),
+		}, nil
+	}
+}
+
+// ConsentRequest define authorization request submitted by signed in user
+type ConsentRequest struct {
+	AuthorizeRequest
+
+	// Consent approve or deny, empty means reuse previous consent
+	Consent string `json:"consent"`
+}
+
+// MakeConsentEndpoint make endpoint issue authorization code to signed in user
+func MakeConsentEndpoint(svc service.OAuth2Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ConsentRequest)
+
+		principal, err := authn.Authenticated(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// token delegated to client can not approve authorization on behalf of user
+		if principal.ClientID != "" {
+			return nil, errors.Wrapf(errors.ErrForbidden, "access token is issued to client=%v", principal.ClientID)
+		}
+
+		code, err := svc.Authorize(
+			ctx,
+			principal.UserID,
+			newServiceAuthorizeRequest(&req.AuthorizeRequest, req.Consent),
+		)
+		if err != nil {
+			return redirectErrorResponse(err)
+		}
+
+		params := url.Values{}
+		params.Set("code", code.Code)
+		if req.State != "" {
+			params.Set("state", req.State)
+		}
+
+		return &AuthorizeResponse{
+			RedirectTo: entity.AppendQuery(code.RedirectURI, params),
+		}, nil
+	}
+}
+
+// TokenRequest define access token request
+type TokenRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+	IPAddress    string
+}
+
+// RateLimitKeys implement ratelimit.Keyer
+func (r *TokenRequest) RateLimitKeys() ratelimit.Keys {
+	return ratelimit.Keys{
+		ratelimit.KeyClientID: r.ClientID,
+	}
+}
+
+// TokenResponse define access token response RFC 6749 section 5.1
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// MakeTokenEndpoint make token endpoint
+func MakeTokenEndpoint(svc service.OAuth2Service, km keys.KeyManager, oidcConfig oidc.Config) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*TokenRequest)
+
+		grant, err := svc.Token(ctx, &service.TokenRequest{
+			GrantType:    req.GrantType,
+			ClientID:     req.ClientID,
+			ClientSecret: req.ClientSecret,
+			Code:         req.Code,
+			RedirectURI:  req.RedirectURI,
+			CodeVerifier: req.CodeVerifier,
+			RefreshToken: req.RefreshToken,
+			Scopes:       oidc.ParseScopes(req.Scope),
+			IPAddress:    req.IPAddress,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		accessToken, err := grant.NewAccessToken(km)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &TokenResponse{
+			AccessToken: accessToken,
+			TokenType:   "Bearer",
+			ExpiresIn:   int64(identity.AccessTokenLifetime.Seconds()),
+			Scope:       grant.Scopes.String(),
+		}
+
+		if grant.IssueRefreshToken {
+			resp.RefreshToken, err = grant.NewRefreshToken(km)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// id token is only issued at authentication,
+		// nonce is absent in refresh grant then id token is omitted
+		if req.GrantType == entity.GrantTypeAuthorizationCode {
+			resp.IDToken, err = grant.NewIDToken(km, &identity.IDTokenOption{
+				Issuer:   strings.TrimSuffix(oidcConfig.Issuer, "/"),
+				Audience: grant.ClientID,
+				Nonce:    grant.Nonce,
+				Scopes:   grant.Scopes,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return resp, nil
+	}
+}
+
+// newServiceAuthorizeRequest convert endpoint request to service request
+func newServiceAuthorizeRequest(req *AuthorizeRequest, consent string) *service.AuthorizeRequest {
+	return &service.AuthorizeRequest{
+		ResponseType:        req.ResponseType,
+		ClientID:            req.ClientID,
+		RedirectURI:         req.RedirectURI,
+		Scopes:              oidc.ParseScopes(req.Scope),
+		State:               req.State,
+		Nonce:               req.Nonce,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		Consent:             consent,
+	}
+}
+
+// toValues encode authorization request to query parameters
+func (req *AuthorizeRequest) toValues() url.Values {
+	params := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+
+	set("response_type", req.ResponseType)
+	set("client_id", req.ClientID)
+	set("redirect_uri", req.RedirectURI)
+	set("scope", req.Scope)
+	set("state", req.State)
+	set("nonce", req.Nonce)
+	set("code_challenge", req.CodeChallenge)
+	set("code_challenge_method", req.CodeChallengeMethod)
+
+	return params
+}
+
+// redirectErrorResponse error after redirect uri verified is sent back to client
+func redirectErrorResponse(err error) (*AuthorizeResponse, error) {
+	redirectErr, ok := errors.Cause(err).(*entity.RedirectError)
+	if !ok {
+		return nil, err
+	}
+
+	return &AuthorizeResponse{
+		RedirectTo: redirectErr.Location(),
+	}, nil
+}
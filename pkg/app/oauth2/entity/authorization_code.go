@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+const (
+	// AuthorizationCodeLifetime RFC 6749 recommend maximum 10 minutes
+	AuthorizationCodeLifetime = 60 * time.Second
+
+	// CodeChallengeMethodS256 the only PKCE method accepted
+	CodeChallengeMethodS256 = "S256"
+)
+
+// AuthorizationCode define issued authorization code
+// only hash of code is stored, plaintext is returned to client once
+type AuthorizationCode struct {
+	// Code plaintext code, only available when issued
+	Code string
+	// CodeHash sha256 of code
+	CodeHash string
+
+	ClientID    string
+	UserID      string
+	RedirectURI string
+	Scopes      oidc.Scopes
+	Nonce       string
+
+	// CodeChallenge PKCE code challenge RFC 7636
+	CodeChallenge       string
+	CodeChallengeMethod string
+
+	// SessionID of session issued by exchanging code, revoked when code is replayed
+	SessionID string
+
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NewAuthorizationCode generate random authorization code
+func NewAuthorizationCode(
+	clientID string,
+	userID string,
+	redirectURI string,
+	scopes oidc.Scopes,
+	nonce string,
+	codeChallenge string,
+	codeChallengeMethod string,
+) (*AuthorizationCode, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "failed to generate authorization code err %v", err)
+	}
+
+	code := base64.RawURLEncoding.EncodeToString(b)
+	now := time.Now()
+
+	return &AuthorizationCode{
+		Code:                code,
+		CodeHash:            HashCode(code),
+		ClientID:            clientID,
+		UserID:              userID,
+		RedirectURI:         redirectURI,
+		Scopes:              scopes,
+		Nonce:               nonce,
+		CodeChallenge:       codeChallenge,
+		CodeChallengeMethod: codeChallengeMethod,
+		CreatedAt:           now,
+		ExpiresAt:           now.Add(AuthorizationCodeLifetime),
+	}, nil
+}
+
+// HashCode hash code for datastore lookup
+func HashCode(code string) string {
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
+
+// IsExpired code can not be exchanged
+func (c *AuthorizationCode) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+// VerifyCodeVerifier check PKCE code verifier
+// BASE64URL(SHA256(code_verifier)) must equal code challenge
+func (c *AuthorizationCode) VerifyCodeVerifier(verifier string) bool {
+	if c.CodeChallengeMethod != CodeChallengeMethodS256 || verifier == "" {
+		return false
+	}
+
+	h := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(h[:])
+
+	return subtle.ConstantTimeCompare([]byte(challenge), []byte(c.CodeChallenge)) == 1
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestAuthorizationCode_VerifyCodeVerifier(t *testing.T) {
+	// challenge is BASE64URL(SHA256(verifier)) of the matched verifier
+	code := &AuthorizationCode{
+		CodeChallenge:       "pz0Tm-6h65pbXCmrLoqFjjGz-T-rvhAQ-C-adeJwByg",
+		CodeChallengeMethod: CodeChallengeMethodS256,
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		verifier string
+		expected bool
+	}{
+		{
+			name:     "Match",
+			method:   CodeChallengeMethodS256,
+			verifier: "dBjftJeZ4CVP-mJ92K9Xl1Y2J6r2bRXAFW5XJWFK6Wc",
+			expected: true,
+		},
+		{
+			name:     "Mismatch",
+			method:   CodeChallengeMethodS256,
+			verifier: "dBjftJeZ4CVP-mJ92K9Xl1Y2J6r2bRXAFW5XJWFK6Wd",
+			expected: false,
+		},
+		{
+			name:     "Empty Verifier",
+			method:   CodeChallengeMethodS256,
+			verifier: "",
+			expected: false,
+		},
+		{
+			name:     "Plain Method",
+			method:   "plain",
+			verifier: "pz0Tm-6h65pbXCmrLoqFjjGz-T-rvhAQ-C-adeJwByg",
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := *code
+			c.CodeChallengeMethod = tt.method
+			if actual := c.VerifyCodeVerifier(tt.verifier); actual != tt.expected {
+				t.Errorf("VerifyCodeVerifier() = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/password"
+)
+
+const (
+	// ScopeAdmin grant access to administrative api
+	ScopeAdmin = oidc.ScopeAdmin
+)
+
+type ClientType int8
+
+const (
+	// ClientTypeUnknown unknown client
+	ClientTypeUnknown ClientType = iota
+	// ClientTypePublic client can not keep secret, like SPA or mobile app
+	ClientTypePublic
+	// ClientTypeConfidential client can authenticate with secret
+	ClientTypeConfidential
+)
+
+// ToString ..
+func (t ClientType) ToString() string {
+	switch t {
+	case ClientTypePublic:
+		return "public"
+	case ClientTypeConfidential:
+		return "confidential"
+	default:
+		return "unknown"
+	}
+}
+
+// Client define registered OAuth 2.0 client
+type Client struct {
+	// ID client_id
+	ID string
+	// Name display at consent page
+	Name string
+	// SecretHash hashed client secret, empty for public client
+	SecretHash string
+	// Type public or confidential
+	Type ClientType
+	// RedirectURIs registered redirect uri, must be exact match
+	RedirectURIs []string
+	// Scopes client allowed to request
+	Scopes oidc.Scopes
+	// GrantTypes client allowed to use at token endpoint
+	GrantTypes []string
+	// CreatedAt this client create time
+	CreatedAt time.Time
+	// UpdatedAt this client update time
+	UpdatedAt time.Time
+}
+
+// NewClient new client, secret is generated for confidential client
+// and only the plaintext returned here can be given to client owner
+func NewClient(
+	id string,
+	name string,
+	clientType ClientType,
+	redirectURIs []string,
+	scopes oidc.Scopes,
+	grantTypes []string,
+	hasher password.Hasher,
+) (client *Client, secret string, err error) {
+	now := time.Now()
+
+	client = &Client{
+		ID:           id,
+		Name:         name,
+		Type:         clientType,
+		RedirectURIs: redirectURIs,
+		Scopes:       scopes,
+		GrantTypes:   grantTypes,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	err = client.Validate()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if client.IsPublic() {
+		return client, "", nil
+	}
+
+	secret, err = client.ResetSecret(hasher)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, secret, nil
+}
+
+// Validate check client registration is consistent
+func (c *Client) Validate() error {
+	if c.Name == "" {
+		return errors.Wrap(errors.ErrInvalidInput, "client name is empty")
+	}
+
+	if c.Type != ClientTypePublic && c.Type != ClientTypeConfidential {
+		return errors.Wrapf(errors.ErrInvalidInput, "client type=%v is invalid", c.Type)
+	}
+
+	for _, grantType := range c.GrantTypes {
+		switch grantType {
+		case GrantTypeAuthorizationCode, GrantTypeRefreshToken:
+		case GrantTypeClientCredentials:
+			// public client can not authenticate itself
+			if c.IsPublic() {
+				return errors.Wrap(errors.ErrInvalidInput, "public client can not use client_credentials grant")
+			}
+		default:
+			return errors.Wrapf(errors.ErrInvalidInput, "grant type=%v is not supported", grantType)
+		}
+	}
+
+	if c.AllowGrantType(GrantTypeAuthorizationCode) && len(c.RedirectURIs) == 0 {
+		return errors.Wrap(errors.ErrInvalidInput, "authorization_code grant require redirect uri")
+	}
+
+	return nil
+}
+
+// ResetSecret generate new client secret and store its hash
+func (c *Client) ResetSecret(hasher password.Hasher) (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", errors.Wrapf(errors.ErrInternal, "failed to generate client secret err %v", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(b)
+
+	c.SecretHash, err = hasher.Hash(secret)
+	if err != nil {
+		return "", err
+	}
+	c.UpdatedAt = time.Now()
+
+	return secret, nil
+}
+
+// IsPublic client has no secret
+func (c *Client) IsPublic() bool {
+	return c.Type == ClientTypePublic
+}
+
+// HasRedirectURI redirect uri registered by client
+func (c *Client) HasRedirectURI(redirectURI string) bool {
+	for _, uri := range c.RedirectURIs {
+		if uri == redirectURI {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowScopes every requested scope is allowed for client
+func (c *Client) AllowScopes(scopes oidc.Scopes) bool {
+	for _, scope := range scopes {
+		if !c.Scopes.Has(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowGrantType client registered the grant type
+func (c *Client) AllowGrantType(grantType string) bool {
+	for _, v := range c.GrantTypes {
+		if v == grantType {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifySecret check client secret match stored hash
+func (c *Client) VerifySecret(secret string) bool {
+	if c.IsPublic() || c.SecretHash == "" {
+		return false
+	}
+
+	ok, err := password.Verify(secret, c.SecretHash)
+	return err == nil && ok
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+// Consent define scopes user granted to client
+type Consent struct {
+	UserID    string
+	ClientID  string
+	Scopes    oidc.Scopes
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewConsent new consent
+func NewConsent(userID string, clientID string, scopes oidc.Scopes) *Consent {
+	now := time.Now()
+	return &Consent{
+		UserID:    userID,
+		ClientID:  clientID,
+		Scopes:    scopes,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Covers every requested scope already granted
+func (c *Consent) Covers(scopes oidc.Scopes) bool {
+	for _, scope := range scopes {
+		if !c.Scopes.Has(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// Grant add scopes into consent
+func (c *Consent) Grant(scopes oidc.Scopes) {
+	for _, scope := range scopes {
+		if !c.Scopes.Has(scope) {
+			c.Scopes = append(c.Scopes, scope)
+		}
+	}
+	c.UpdatedAt = time.Now()
+}
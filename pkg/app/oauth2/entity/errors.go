@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// Error define OAuth 2.0 error response
+// see RFC 6749 section 4.1.2.1 and 5.2
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	Status      int    `json:"-"`
+}
+
+var (
+	ErrInvalidRequest          = &Error{Code: "invalid_request", Status: http.StatusBadRequest}
+	ErrInvalidClient           = &Error{Code: "invalid_client", Status: http.StatusUnauthorized}
+	ErrInvalidGrant            = &Error{Code: "invalid_grant", Status: http.StatusBadRequest}
+	ErrUnauthorizedClient      = &Error{Code: "unauthorized_client", Status: http.StatusBadRequest}
+	ErrUnsupportedGrantType    = &Error{Code: "unsupported_grant_type", Status: http.StatusBadRequest}
+	ErrUnsupportedResponseType = &Error{Code: "unsupported_response_type", Status: http.StatusBadRequest}
+	ErrInvalidScope            = &Error{Code: "invalid_scope", Status: http.StatusBadRequest}
+	ErrAccessDenied            = &Error{Code: "access_denied", Status: http.StatusForbidden}
+	ErrConsentRequired         = &Error{Code: "consent_required", Status: http.StatusForbidden}
+	ErrServerError             = &Error{Code: "server_error", Status: http.StatusInternalServerError}
+)
+
+// Error implement golang error
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// Is error has the same error code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// WithDescription set human-readable description
+func (e *Error) WithDescription(description string) *Error {
+	newErr := *e
+	newErr.Description = description
+	return &newErr
+}
+
+// RedirectError is an authorization error that should be
+// reported to client through redirect uri instead of user-agent
+type RedirectError struct {
+	Err         *Error
+	RedirectURI string
+	State       string
+}
+
+// NewRedirectError new redirect error of verified redirect uri
+func NewRedirectError(err *Error, redirectURI string, state string) *RedirectError {
+	return &RedirectError{
+		Err:         err,
+		RedirectURI: redirectURI,
+		State:       state,
+	}
+}
+
+// Error implement golang error
+func (e *RedirectError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap return the OAuth 2.0 error
+func (e *RedirectError) Unwrap() error {
+	return e.Err
+}
+
+// Location redirect uri carried error parameters
+func (e *RedirectError) Location() string {
+	params := url.Values{}
+	params.Set("error", e.Err.Code)
+	if e.Err.Description != "" {
+		params.Set("error_description", e.Err.Description)
+	}
+	if e.State != "" {
+		params.Set("state", e.State)
+	}
+	return AppendQuery(e.RedirectURI, params)
+}
+
+// AppendQuery append query parameters into redirect uri
+// keep the query component already registered
+func AppendQuery(redirectURI string, params url.Values) string {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
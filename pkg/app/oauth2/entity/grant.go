@@ -0,0 +1,30 @@
+package entity
+
+import (
+	identity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+const (
+	// GrantTypeAuthorizationCode RFC 6749 section 4.1
+	GrantTypeAuthorizationCode = "authorization_code"
+	// GrantTypeRefreshToken RFC 6749 section 6
+	GrantTypeRefreshToken = "refresh_token"
+	// GrantTypeClientCredentials RFC 6749 section 4.4
+	GrantTypeClientCredentials = "client_credentials"
+)
+
+// Grant define the result of token request
+type Grant struct {
+	// Identity user and session tokens issued for,
+	// user is nil when client acts on behalf of itself
+	*identity.Identity
+	// ClientID client tokens issued to
+	ClientID string
+	// Scopes granted scopes
+	Scopes oidc.Scopes
+	// Nonce from authorization request, put into id token
+	Nonce string
+	// IssueRefreshToken refresh token should be returned
+	IssueRefreshToken bool
+}
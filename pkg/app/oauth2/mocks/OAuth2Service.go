@@ -0,0 +1,538 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/oauth2/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/karta0898098/iam/pkg/app/oauth2/service"
+)
+
+// OAuth2Service is an autogenerated mock type for the OAuth2Service type
+type OAuth2Service struct {
+	mock.Mock
+}
+
+type OAuth2Service_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OAuth2Service) EXPECT() *OAuth2Service_Expecter {
+	return &OAuth2Service_Expecter{mock: &_m.Mock}
+}
+
+// Authorize provides a mock function with given fields: ctx, userID, req
+func (_m *OAuth2Service) Authorize(ctx context.Context, userID string, req *service.AuthorizeRequest) (*entity.AuthorizationCode, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *entity.AuthorizationCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.AuthorizeRequest) (*entity.AuthorizationCode, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.AuthorizeRequest) *entity.AuthorizationCode); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.AuthorizationCode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *service.AuthorizeRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OAuth2Service_Authorize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authorize'
+type OAuth2Service_Authorize_Call struct {
+	*mock.Call
+}
+
+// Authorize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - req *service.AuthorizeRequest
+func (_e *OAuth2Service_Expecter) Authorize(ctx interface{}, userID interface{}, req interface{}) *OAuth2Service_Authorize_Call {
+	return &OAuth2Service_Authorize_Call{Call: _e.mock.On("Authorize", ctx, userID, req)}
+}
+
+func (_c *OAuth2Service_Authorize_Call) Run(run func(ctx context.Context, userID string, req *service.AuthorizeRequest)) *OAuth2Service_Authorize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*service.AuthorizeRequest))
+	})
+	return _c
+}
+
+func (_c *OAuth2Service_Authorize_Call) Return(code *entity.AuthorizationCode, err error) *OAuth2Service_Authorize_Call {
+	_c.Call.Return(code, err)
+	return _c
+}
+
+func (_c *OAuth2Service_Authorize_Call) RunAndReturn(run func(context.Context, string, *service.AuthorizeRequest) (*entity.AuthorizationCode, error)) *OAuth2Service_Authorize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateClient provides a mock function with given fields: ctx, opt
+func (_m *OAuth2Service) CreateClient(ctx context.Context, opt *service.ClientOption) (*entity.Client, string, error) {
+	ret := _m.Called(ctx, opt)
+
+	var r0 *entity.Client
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.ClientOption) (*entity.Client, string, error)); ok {
+		return rf(ctx, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.ClientOption) *entity.Client); ok {
+		r0 = rf(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.ClientOption) string); ok {
+		r1 = rf(ctx, opt)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *service.ClientOption) error); ok {
+		r2 = rf(ctx, opt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// OAuth2Service_CreateClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateClient'
+type OAuth2Service_CreateClient_Call struct {
+	*mock.Call
+}
+
+// CreateClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *service.ClientOption
+func (_e *OAuth2Service_Expecter) CreateClient(ctx interface{}, opt interface{}) *OAuth2Service_CreateClient_Call {
+	return &OAuth2Service_CreateClient_Call{Call: _e.mock.On("CreateClient", ctx, opt)}
+}
+
+func (_c *OAuth2Service_CreateClient_Call) Run(run func(ctx context.Context, opt *service.ClientOption)) *OAuth2Service_CreateClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.ClientOption))
+	})
+	return _c
+}
+
+func (_c *OAuth2Service_CreateClient_Call) Return(client *entity.Client, secret string, err error) *OAuth2Service_CreateClient_Call {
+	_c.Call.Return(client, secret, err)
+	return _c
+}
+
+func (_c *OAuth2Service_CreateClient_Call) RunAndReturn(run func(context.Context, *service.ClientOption) (*entity.Client, string, error)) *OAuth2Service_CreateClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteClient provides a mock function with given fields: ctx, clientID
+func (_m *OAuth2Service) DeleteClient(ctx context.Context, clientID string) error {
+	ret := _m.Called(ctx, clientID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OAuth2Service_DeleteClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteClient'
+type OAuth2Service_DeleteClient_Call struct {
+	*mock.Call
+}
+
+// DeleteClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+func (_e *OAuth2Service_Expecter) DeleteClient(ctx interface{}, clientID interface{}) *OAuth2Service_DeleteClient_Call {
+	return &OAuth2Service_DeleteClient_Call{Call: _e.mock.On("DeleteClient", ctx, clientID)}
+}
+
+func (_c *OAuth2Service_DeleteClient_Call) Run(run func(ctx context.Context, clientID string)) *OAuth2Service_DeleteClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OAuth2Service_DeleteClient_Call) Return(err error) *OAuth2Service_DeleteClient_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OAuth2Service_DeleteClient_Call) RunAndReturn(run func(context.Context, string) error) *OAuth2Service_DeleteClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClient provides a mock function with given fields: ctx, clientID
+func (_m *OAuth2Service) GetClient(ctx context.Context, clientID string) (*entity.Client, error) {
+	ret := _m.Called(ctx, clientID)
+
+	var r0 *entity.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Client, error)); ok {
+		return rf(ctx, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Client); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OAuth2Service_GetClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClient'
+type OAuth2Service_GetClient_Call struct {
+	*mock.Call
+}
+
+// GetClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+func (_e *OAuth2Service_Expecter) GetClient(ctx interface{}, clientID interface{}) *OAuth2Service_GetClient_Call {
+	return &OAuth2Service_GetClient_Call{Call: _e.mock.On("GetClient", ctx, clientID)}
+}
+
+func (_c *OAuth2Service_GetClient_Call) Run(run func(ctx context.Context, clientID string)) *OAuth2Service_GetClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OAuth2Service_GetClient_Call) Return(client *entity.Client, err error) *OAuth2Service_GetClient_Call {
+	_c.Call.Return(client, err)
+	return _c
+}
+
+func (_c *OAuth2Service_GetClient_Call) RunAndReturn(run func(context.Context, string) (*entity.Client, error)) *OAuth2Service_GetClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListClients provides a mock function with given fields: ctx
+func (_m *OAuth2Service) ListClients(ctx context.Context) ([]*entity.Client, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Client, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Client); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OAuth2Service_ListClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListClients'
+type OAuth2Service_ListClients_Call struct {
+	*mock.Call
+}
+
+// ListClients is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *OAuth2Service_Expecter) ListClients(ctx interface{}) *OAuth2Service_ListClients_Call {
+	return &OAuth2Service_ListClients_Call{Call: _e.mock.On("ListClients", ctx)}
+}
+
+func (_c *OAuth2Service_ListClients_Call) Run(run func(ctx context.Context)) *OAuth2Service_ListClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *OAuth2Service_ListClients_Call) Return(clients []*entity.Client, err error) *OAuth2Service_ListClients_Call {
+	_c.Call.Return(clients, err)
+	return _c
+}
+
+func (_c *OAuth2Service_ListClients_Call) RunAndReturn(run func(context.Context) ([]*entity.Client, error)) *OAuth2Service_ListClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetClientSecret provides a mock function with given fields: ctx, clientID
+func (_m *OAuth2Service) ResetClientSecret(ctx context.Context, clientID string) (*entity.Client, string, error) {
+	ret := _m.Called(ctx, clientID)
+
+	var r0 *entity.Client
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Client, string, error)); ok {
+		return rf(ctx, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Client); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, clientID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, clientID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// OAuth2Service_ResetClientSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetClientSecret'
+type OAuth2Service_ResetClientSecret_Call struct {
+	*mock.Call
+}
+
+// ResetClientSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+func (_e *OAuth2Service_Expecter) ResetClientSecret(ctx interface{}, clientID interface{}) *OAuth2Service_ResetClientSecret_Call {
+	return &OAuth2Service_ResetClientSecret_Call{Call: _e.mock.On("ResetClientSecret", ctx, clientID)}
+}
+
+func (_c *OAuth2Service_ResetClientSecret_Call) Run(run func(ctx context.Context, clientID string)) *OAuth2Service_ResetClientSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OAuth2Service_ResetClientSecret_Call) Return(client *entity.Client, secret string, err error) *OAuth2Service_ResetClientSecret_Call {
+	_c.Call.Return(client, secret, err)
+	return _c
+}
+
+func (_c *OAuth2Service_ResetClientSecret_Call) RunAndReturn(run func(context.Context, string) (*entity.Client, string, error)) *OAuth2Service_ResetClientSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Token provides a mock function with given fields: ctx, req
+func (_m *OAuth2Service) Token(ctx context.Context, req *service.TokenRequest) (*entity.Grant, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *entity.Grant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.TokenRequest) (*entity.Grant, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.TokenRequest) *entity.Grant); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Grant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.TokenRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OAuth2Service_Token_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Token'
+type OAuth2Service_Token_Call struct {
+	*mock.Call
+}
+
+// Token is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *service.TokenRequest
+func (_e *OAuth2Service_Expecter) Token(ctx interface{}, req interface{}) *OAuth2Service_Token_Call {
+	return &OAuth2Service_Token_Call{Call: _e.mock.On("Token", ctx, req)}
+}
+
+func (_c *OAuth2Service_Token_Call) Run(run func(ctx context.Context, req *service.TokenRequest)) *OAuth2Service_Token_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.TokenRequest))
+	})
+	return _c
+}
+
+func (_c *OAuth2Service_Token_Call) Return(grant *entity.Grant, err error) *OAuth2Service_Token_Call {
+	_c.Call.Return(grant, err)
+	return _c
+}
+
+func (_c *OAuth2Service_Token_Call) RunAndReturn(run func(context.Context, *service.TokenRequest) (*entity.Grant, error)) *OAuth2Service_Token_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateClient provides a mock function with given fields: ctx, clientID, opt
+func (_m *OAuth2Service) UpdateClient(ctx context.Context, clientID string, opt *service.ClientOption) (*entity.Client, error) {
+	ret := _m.Called(ctx, clientID, opt)
+
+	var r0 *entity.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.ClientOption) (*entity.Client, error)); ok {
+		return rf(ctx, clientID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.ClientOption) *entity.Client); ok {
+		r0 = rf(ctx, clientID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *service.ClientOption) error); ok {
+		r1 = rf(ctx, clientID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OAuth2Service_UpdateClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateClient'
+type OAuth2Service_UpdateClient_Call struct {
+	*mock.Call
+}
+
+// UpdateClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+//   - opt *service.ClientOption
+func (_e *OAuth2Service_Expecter) UpdateClient(ctx interface{}, clientID interface{}, opt interface{}) *OAuth2Service_UpdateClient_Call {
+	return &OAuth2Service_UpdateClient_Call{Call: _e.mock.On("UpdateClient", ctx, clientID, opt)}
+}
+
+func (_c *OAuth2Service_UpdateClient_Call) Run(run func(ctx context.Context, clientID string, opt *service.ClientOption)) *OAuth2Service_UpdateClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*service.ClientOption))
+	})
+	return _c
+}
+
+func (_c *OAuth2Service_UpdateClient_Call) Return(client *entity.Client, err error) *OAuth2Service_UpdateClient_Call {
+	_c.Call.Return(client, err)
+	return _c
+}
+
+func (_c *OAuth2Service_UpdateClient_Call) RunAndReturn(run func(context.Context, string, *service.ClientOption) (*entity.Client, error)) *OAuth2Service_UpdateClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateAuthorizeRequest provides a mock function with given fields: ctx, req
+func (_m *OAuth2Service) ValidateAuthorizeRequest(ctx context.Context, req *service.AuthorizeRequest) (*entity.Client, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *entity.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.AuthorizeRequest) (*entity.Client, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.AuthorizeRequest) *entity.Client); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.AuthorizeRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OAuth2Service_ValidateAuthorizeRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateAuthorizeRequest'
+type OAuth2Service_ValidateAuthorizeRequest_Call struct {
+	*mock.Call
+}
+
+// ValidateAuthorizeRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *service.AuthorizeRequest
+func (_e *OAuth2Service_Expecter) ValidateAuthorizeRequest(ctx interface{}, req interface{}) *OAuth2Service_ValidateAuthorizeRequest_Call {
+	return &OAuth2Service_ValidateAuthorizeRequest_Call{Call: _e.mock.On("ValidateAuthorizeRequest", ctx, req)}
+}
+
+func (_c *OAuth2Service_ValidateAuthorizeRequest_Call) Run(run func(ctx context.Context, req *service.AuthorizeRequest)) *OAuth2Service_ValidateAuthorizeRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.AuthorizeRequest))
+	})
+	return _c
+}
+
+func (_c *OAuth2Service_ValidateAuthorizeRequest_Call) Return(client *entity.Client, err error) *OAuth2Service_ValidateAuthorizeRequest_Call {
+	_c.Call.Return(client, err)
+	return _c
+}
+
+func (_c *OAuth2Service_ValidateAuthorizeRequest_Call) RunAndReturn(run func(context.Context, *service.AuthorizeRequest) (*entity.Client, error)) *OAuth2Service_ValidateAuthorizeRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewOAuth2Service interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOAuth2Service creates a new instance of OAuth2Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOAuth2Service(t mockConstructorTestingTNewOAuth2Service) *OAuth2Service {
+	mock := &OAuth2Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,529 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/oauth2/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// ConsumeAuthorizationCode provides a mock function with given fields: ctx, codeHash, sessionID
+func (_m *Repository) ConsumeAuthorizationCode(ctx context.Context, codeHash string, sessionID string) (*entity.AuthorizationCode, error) {
+	ret := _m.Called(ctx, codeHash, sessionID)
+
+	var r0 *entity.AuthorizationCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.AuthorizationCode, error)); ok {
+		return rf(ctx, codeHash, sessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.AuthorizationCode); ok {
+		r0 = rf(ctx, codeHash, sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.AuthorizationCode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, codeHash, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ConsumeAuthorizationCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeAuthorizationCode'
+type Repository_ConsumeAuthorizationCode_Call struct {
+	*mock.Call
+}
+
+// ConsumeAuthorizationCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - codeHash string
+//   - sessionID string
+func (_e *Repository_Expecter) ConsumeAuthorizationCode(ctx interface{}, codeHash interface{}, sessionID interface{}) *Repository_ConsumeAuthorizationCode_Call {
+	return &Repository_ConsumeAuthorizationCode_Call{Call: _e.mock.On("ConsumeAuthorizationCode", ctx, codeHash, sessionID)}
+}
+
+func (_c *Repository_ConsumeAuthorizationCode_Call) Run(run func(ctx context.Context, codeHash string, sessionID string)) *Repository_ConsumeAuthorizationCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ConsumeAuthorizationCode_Call) Return(code *entity.AuthorizationCode, err error) *Repository_ConsumeAuthorizationCode_Call {
+	_c.Call.Return(code, err)
+	return _c
+}
+
+func (_c *Repository_ConsumeAuthorizationCode_Call) RunAndReturn(run func(context.Context, string, string) (*entity.AuthorizationCode, error)) *Repository_ConsumeAuthorizationCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteClient provides a mock function with given fields: ctx, clientID
+func (_m *Repository) DeleteClient(ctx context.Context, clientID string) error {
+	ret := _m.Called(ctx, clientID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteClient'
+type Repository_DeleteClient_Call struct {
+	*mock.Call
+}
+
+// DeleteClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+func (_e *Repository_Expecter) DeleteClient(ctx interface{}, clientID interface{}) *Repository_DeleteClient_Call {
+	return &Repository_DeleteClient_Call{Call: _e.mock.On("DeleteClient", ctx, clientID)}
+}
+
+func (_c *Repository_DeleteClient_Call) Run(run func(ctx context.Context, clientID string)) *Repository_DeleteClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteClient_Call) Return(err error) *Repository_DeleteClient_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteClient_Call) RunAndReturn(run func(context.Context, string) error) *Repository_DeleteClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAuthorizationCode provides a mock function with given fields: ctx, codeHash
+func (_m *Repository) FindAuthorizationCode(ctx context.Context, codeHash string) (*entity.AuthorizationCode, error) {
+	ret := _m.Called(ctx, codeHash)
+
+	var r0 *entity.AuthorizationCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.AuthorizationCode, error)); ok {
+		return rf(ctx, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.AuthorizationCode); ok {
+		r0 = rf(ctx, codeHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.AuthorizationCode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindAuthorizationCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAuthorizationCode'
+type Repository_FindAuthorizationCode_Call struct {
+	*mock.Call
+}
+
+// FindAuthorizationCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - codeHash string
+func (_e *Repository_Expecter) FindAuthorizationCode(ctx interface{}, codeHash interface{}) *Repository_FindAuthorizationCode_Call {
+	return &Repository_FindAuthorizationCode_Call{Call: _e.mock.On("FindAuthorizationCode", ctx, codeHash)}
+}
+
+func (_c *Repository_FindAuthorizationCode_Call) Run(run func(ctx context.Context, codeHash string)) *Repository_FindAuthorizationCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindAuthorizationCode_Call) Return(code *entity.AuthorizationCode, err error) *Repository_FindAuthorizationCode_Call {
+	_c.Call.Return(code, err)
+	return _c
+}
+
+func (_c *Repository_FindAuthorizationCode_Call) RunAndReturn(run func(context.Context, string) (*entity.AuthorizationCode, error)) *Repository_FindAuthorizationCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindClientByID provides a mock function with given fields: ctx, clientID
+func (_m *Repository) FindClientByID(ctx context.Context, clientID string) (*entity.Client, error) {
+	ret := _m.Called(ctx, clientID)
+
+	var r0 *entity.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Client, error)); ok {
+		return rf(ctx, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Client); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindClientByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindClientByID'
+type Repository_FindClientByID_Call struct {
+	*mock.Call
+}
+
+// FindClientByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+func (_e *Repository_Expecter) FindClientByID(ctx interface{}, clientID interface{}) *Repository_FindClientByID_Call {
+	return &Repository_FindClientByID_Call{Call: _e.mock.On("FindClientByID", ctx, clientID)}
+}
+
+func (_c *Repository_FindClientByID_Call) Run(run func(ctx context.Context, clientID string)) *Repository_FindClientByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindClientByID_Call) Return(client *entity.Client, err error) *Repository_FindClientByID_Call {
+	_c.Call.Return(client, err)
+	return _c
+}
+
+func (_c *Repository_FindClientByID_Call) RunAndReturn(run func(context.Context, string) (*entity.Client, error)) *Repository_FindClientByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindConsent provides a mock function with given fields: ctx, userID, clientID
+func (_m *Repository) FindConsent(ctx context.Context, userID string, clientID string) (*entity.Consent, error) {
+	ret := _m.Called(ctx, userID, clientID)
+
+	var r0 *entity.Consent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Consent, error)); ok {
+		return rf(ctx, userID, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Consent); ok {
+		r0 = rf(ctx, userID, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Consent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindConsent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindConsent'
+type Repository_FindConsent_Call struct {
+	*mock.Call
+}
+
+// FindConsent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - clientID string
+func (_e *Repository_Expecter) FindConsent(ctx interface{}, userID interface{}, clientID interface{}) *Repository_FindConsent_Call {
+	return &Repository_FindConsent_Call{Call: _e.mock.On("FindConsent", ctx, userID, clientID)}
+}
+
+func (_c *Repository_FindConsent_Call) Run(run func(ctx context.Context, userID string, clientID string)) *Repository_FindConsent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindConsent_Call) Return(consent *entity.Consent, err error) *Repository_FindConsent_Call {
+	_c.Call.Return(consent, err)
+	return _c
+}
+
+func (_c *Repository_FindConsent_Call) RunAndReturn(run func(context.Context, string, string) (*entity.Consent, error)) *Repository_FindConsent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListClients provides a mock function with given fields: ctx
+func (_m *Repository) ListClients(ctx context.Context) ([]*entity.Client, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Client, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Client); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListClients'
+type Repository_ListClients_Call struct {
+	*mock.Call
+}
+
+// ListClients is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListClients(ctx interface{}) *Repository_ListClients_Call {
+	return &Repository_ListClients_Call{Call: _e.mock.On("ListClients", ctx)}
+}
+
+func (_c *Repository_ListClients_Call) Run(run func(ctx context.Context)) *Repository_ListClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListClients_Call) Return(clients []*entity.Client, err error) *Repository_ListClients_Call {
+	_c.Call.Return(clients, err)
+	return _c
+}
+
+func (_c *Repository_ListClients_Call) RunAndReturn(run func(context.Context) ([]*entity.Client, error)) *Repository_ListClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreAuthorizationCode provides a mock function with given fields: ctx, code
+func (_m *Repository) StoreAuthorizationCode(ctx context.Context, code *entity.AuthorizationCode) error {
+	ret := _m.Called(ctx, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.AuthorizationCode) error); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreAuthorizationCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreAuthorizationCode'
+type Repository_StoreAuthorizationCode_Call struct {
+	*mock.Call
+}
+
+// StoreAuthorizationCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code *entity.AuthorizationCode
+func (_e *Repository_Expecter) StoreAuthorizationCode(ctx interface{}, code interface{}) *Repository_StoreAuthorizationCode_Call {
+	return &Repository_StoreAuthorizationCode_Call{Call: _e.mock.On("StoreAuthorizationCode", ctx, code)}
+}
+
+func (_c *Repository_StoreAuthorizationCode_Call) Run(run func(ctx context.Context, code *entity.AuthorizationCode)) *Repository_StoreAuthorizationCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.AuthorizationCode))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreAuthorizationCode_Call) Return(err error) *Repository_StoreAuthorizationCode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreAuthorizationCode_Call) RunAndReturn(run func(context.Context, *entity.AuthorizationCode) error) *Repository_StoreAuthorizationCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreClient provides a mock function with given fields: ctx, client
+func (_m *Repository) StoreClient(ctx context.Context, client *entity.Client) error {
+	ret := _m.Called(ctx, client)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Client) error); ok {
+		r0 = rf(ctx, client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreClient'
+type Repository_StoreClient_Call struct {
+	*mock.Call
+}
+
+// StoreClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - client *entity.Client
+func (_e *Repository_Expecter) StoreClient(ctx interface{}, client interface{}) *Repository_StoreClient_Call {
+	return &Repository_StoreClient_Call{Call: _e.mock.On("StoreClient", ctx, client)}
+}
+
+func (_c *Repository_StoreClient_Call) Run(run func(ctx context.Context, client *entity.Client)) *Repository_StoreClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Client))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreClient_Call) Return(err error) *Repository_StoreClient_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreClient_Call) RunAndReturn(run func(context.Context, *entity.Client) error) *Repository_StoreClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreConsent provides a mock function with given fields: ctx, consent
+func (_m *Repository) StoreConsent(ctx context.Context, consent *entity.Consent) error {
+	ret := _m.Called(ctx, consent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Consent) error); ok {
+		r0 = rf(ctx, consent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreConsent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreConsent'
+type Repository_StoreConsent_Call struct {
+	*mock.Call
+}
+
+// StoreConsent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - consent *entity.Consent
+func (_e *Repository_Expecter) StoreConsent(ctx interface{}, consent interface{}) *Repository_StoreConsent_Call {
+	return &Repository_StoreConsent_Call{Call: _e.mock.On("StoreConsent", ctx, consent)}
+}
+
+func (_c *Repository_StoreConsent_Call) Run(run func(ctx context.Context, consent *entity.Consent)) *Repository_StoreConsent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Consent))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreConsent_Call) Return(err error) *Repository_StoreConsent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreConsent_Call) RunAndReturn(run func(context.Context, *entity.Consent) error) *Repository_StoreConsent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateClient provides a mock function with given fields: ctx, client
+func (_m *Repository) UpdateClient(ctx context.Context, client *entity.Client) error {
+	ret := _m.Called(ctx, client)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Client) error); ok {
+		r0 = rf(ctx, client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateClient'
+type Repository_UpdateClient_Call struct {
+	*mock.Call
+}
+
+// UpdateClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - client *entity.Client
+func (_e *Repository_Expecter) UpdateClient(ctx interface{}, client interface{}) *Repository_UpdateClient_Call {
+	return &Repository_UpdateClient_Call{Call: _e.mock.On("UpdateClient", ctx, client)}
+}
+
+func (_c *Repository_UpdateClient_Call) Run(run func(ctx context.Context, client *entity.Client)) *Repository_UpdateClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Client))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateClient_Call) Return(err error) *Repository_UpdateClient_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateClient_Call) RunAndReturn(run func(context.Context, *entity.Client) error) *Repository_UpdateClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
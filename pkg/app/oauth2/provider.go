@@ -0,0 +1,15 @@
+package oauth2
+
+import (
+	"github.com/google/wire"
+
+	"github.com/karta0898098/iam/pkg/app/oauth2/endpoints"
+	"github.com/karta0898098/iam/pkg/app/oauth2/repository"
+	"github.com/karta0898098/iam/pkg/app/oauth2/service"
+)
+
+var DefaultProvider = wire.NewSet(
+	endpoints.New,
+	service.New,
+	repository.New,
+)
@@ -0,0 +1,447 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/karta0898098/iam/pkg/app/oauth2/entity"
+	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+// ClientDAO define oauth2 client dao
+type ClientDAO struct {
+	ID           string            `gorm:"column:id"`            // ID client_id
+	Name         string            `gorm:"column:name"`          // Name client display name
+	SecretHash   string            `gorm:"column:secret_hash"`   // SecretHash hashed client secret
+	Type         entity.ClientType `gorm:"column:client_type"`   // Type public or confidential
+	RedirectURIs string            `gorm:"column:redirect_uris"` // RedirectURIs space-delimited redirect uri
+	Scopes       string            `gorm:"column:scopes"`        // Scopes space-delimited allowed scopes
+	GrantTypes   string            `gorm:"column:grant_types"`   // GrantTypes space-delimited allowed grant types
+	CreatedAt    int64             `gorm:"column:created_at"`    // CreatedAt this client create time
+	UpdatedAt    int64             `gorm:"column:updated_at"`    // UpdatedAt this client update time
+}
+
+// TableName is ClientDAO implement table name for gorm
+func (c ClientDAO) TableName() string {
+	return "oauth2_clients"
+}
+
+// UnmarshalClientDAO unmarshal entity client to dao
+func UnmarshalClientDAO(client *entity.Client) *ClientDAO {
+	return &ClientDAO{
+		ID:           client.ID,
+		Name:         client.Name,
+		SecretHash:   client.SecretHash,
+		Type:         client.Type,
+		RedirectURIs: strings.Join(client.RedirectURIs, " "),
+		Scopes:       client.Scopes.String(),
+		GrantTypes:   strings.Join(client.GrantTypes, " "),
+		CreatedAt:    client.CreatedAt.UnixMilli(),
+		UpdatedAt:    client.UpdatedAt.UnixMilli(),
+	}
+}
+
+// UnmarshalClient unmarshal dao to entity client
+func UnmarshalClient(dao *ClientDAO) *entity.Client {
+	return &entity.Client{
+		ID:           dao.ID,
+		Name:         dao.Name,
+		SecretHash:   dao.SecretHash,
+		Type:         dao.Type,
+		RedirectURIs: strings.Fields(dao.RedirectURIs),
+		Scopes:       oidc.ParseScopes(dao.Scopes),
+		GrantTypes:   strings.Fields(dao.GrantTypes),
+		CreatedAt:    time.UnixMilli(dao.CreatedAt),
+		UpdatedAt:    time.UnixMilli(dao.UpdatedAt),
+	}
+}
+
+// AuthorizationCodeDAO define authorization code dao
+type AuthorizationCodeDAO struct {
+	CodeHash            string `gorm:"column:code_hash"`
+	ClientID            string `gorm:"column:client_id"`
+	UserID              string `gorm:"column:user_id"`
+	RedirectURI         string `gorm:"column:redirect_uri"`
+	Scopes              string `gorm:"column:scopes"`
+	Nonce               string `gorm:"column:nonce"`
+	CodeChallenge       string `gorm:"column:code_challenge"`
+	CodeChallengeMethod string `gorm:"column:code_challenge_method"`
+	SessionID           string `gorm:"column:session_id"`
+	CreatedAt           int64  `gorm:"column:created_at"`
+	ExpiresAt           int64  `gorm:"column:expires_at"`
+	UsedAt              int64  `gorm:"column:used_at"`
+}
+
+// TableName is AuthorizationCodeDAO implement table name for gorm
+func (c AuthorizationCodeDAO) TableName() string {
+	return "oauth2_authorization_codes"
+}
+
+// UnmarshalAuthorizationCodeDAO unmarshal entity authorization code to dao
+func UnmarshalAuthorizationCodeDAO(code *entity.AuthorizationCode) *AuthorizationCodeDAO {
+	return &AuthorizationCodeDAO{
+		CodeHash:            code.CodeHash,
+		ClientID:            code.ClientID,
+		UserID:              code.UserID,
+		RedirectURI:         code.RedirectURI,
+		Scopes:              code.Scopes.String(),
+		Nonce:               code.Nonce,
+		CodeChallenge:       code.CodeChallenge,
+		CodeChallengeMethod: code.CodeChallengeMethod,
+		SessionID:           code.SessionID,
+		CreatedAt:           code.CreatedAt.UnixMilli(),
+		ExpiresAt:           code.ExpiresAt.UnixMilli(),
+	}
+}
+
+// UnmarshalAuthorizationCode unmarshal dao to entity authorization code
+func UnmarshalAuthorizationCode(dao *AuthorizationCodeDAO) *entity.AuthorizationCode {
+	return &entity.AuthorizationCode{
+		CodeHash:            dao.CodeHash,
+		ClientID:            dao.ClientID,
+		UserID:              dao.UserID,
+		RedirectURI:         dao.RedirectURI,
+		Scopes:              oidc.ParseScopes(dao.Scopes),
+		Nonce:               dao.Nonce,
+		CodeChallenge:       dao.CodeChallenge,
+		CodeChallengeMethod: dao.CodeChallengeMethod,
+		SessionID:           dao.SessionID,
+		CreatedAt:           time.UnixMilli(dao.CreatedAt),
+		ExpiresAt:           time.UnixMilli(dao.ExpiresAt),
+	}
+}
+
+// ConsentDAO define consent dao
+type ConsentDAO struct {
+	UserID    string `gorm:"column:user_id"`
+	ClientID  string `gorm:"column:client_id"`
+	Scopes    string `gorm:"column:scopes"`
+	CreatedAt int64  `gorm:"column:created_at"`
+	UpdatedAt int64  `gorm:"column:updated_at"`
+}
+
+// TableName is ConsentDAO implement table name for gorm
+func (c ConsentDAO) TableName() string {
+	return "oauth2_consents"
+}
+
+// UnmarshalConsentDAO unmarshal entity consent to dao
+func UnmarshalConsentDAO(consent *entity.Consent) *ConsentDAO {
+	return &ConsentDAO{
+		UserID:    consent.UserID,
+		ClientID:  consent.ClientID,
+		Scopes:    consent.Scopes.String(),
+		CreatedAt: consent.CreatedAt.UnixMilli(),
+		UpdatedAt: consent.UpdatedAt.UnixMilli(),
+	}
+}
+
+// UnmarshalConsent unmarshal dao to entity consent
+func UnmarshalConsent(dao *ConsentDAO) *entity.Consent {
+	return &entity.Consent{
+		UserID:    dao.UserID,
+		ClientID:  dao.ClientID,
+		Scopes:    oidc.ParseScopes(dao.Scopes),
+		CreatedAt: time.UnixMilli(dao.CreatedAt),
+		UpdatedAt: time.UnixMilli(dao.UpdatedAt),
+	}
+}
+
+// Repository define oauth2 repository pattern
+type Repository interface {
+	// StoreClient register client
+	StoreClient(ctx context.Context, client *entity.Client) (err error)
+
+	// FindClientByID find registered client by client id
+	FindClientByID(ctx context.Context, clientID string) (client *entity.Client, err error)
+
+	// ListClients list registered clients order by create time
+	ListClients(ctx context.Context) (clients []*entity.Client, err error)
+
+	// UpdateClient update client registration and secret
+	UpdateClient(ctx context.Context, client *entity.Client) (err error)
+
+	// DeleteClient delete client and consents granted to it
+	DeleteClient(ctx context.Context, clientID string) (err error)
+
+	// StoreAuthorizationCode store issued authorization code
+	StoreAuthorizationCode(ctx context.Context, code *entity.AuthorizationCode) (err error)
+
+	// ConsumeAuthorizationCode find authorization code by hash and mark it used by session issued from it
+	// return ErrConflict when code already used
+	ConsumeAuthorizationCode(ctx context.Context, codeHash string, sessionID string) (code *entity.AuthorizationCode, err error)
+
+	// FindAuthorizationCode find authorization code by hash whether it is used or not
+	FindAuthorizationCode(ctx context.Context, codeHash string) (code *entity.AuthorizationCode, err error)
+
+	// FindConsent find scopes user granted to client
+	FindConsent(ctx context.Context, userID string, clientID string) (consent *entity.Consent, err error)
+
+	// StoreConsent create or update consent
+	StoreConsent(ctx context.Context, consent *entity.Consent) (err error)
+}
+
+// OAuth2Repository implement for Repository
+type OAuth2Repository struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+}
+
+// New Repository constructor
+func New(conn db.Connection) Repository {
+	return &OAuth2Repository{
+		readDB:  conn.ReadDB(),
+		writeDB: conn.WriteDB(),
+	}
+}
+
+// FindClientByID is SQL implement
+func (repo *OAuth2Repository) FindClientByID(ctx context.Context, clientID string) (client *entity.Client, err error) {
+	var (
+		dao ClientDAO
+	)
+
+	if clientID == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input client id is empty")
+	}
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Where("id = ?", clientID).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found client id=%v", clientID)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalClient(&dao), nil
+}
+
+// StoreClient is SQL implement
+func (repo *OAuth2Repository) StoreClient(ctx context.Context, client *entity.Client) (err error) {
+	dao := UnmarshalClientDAO(client)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to create client id=%v, err %v", client.ID, err)
+	}
+
+	return nil
+}
+
+// ListClients is SQL implement
+func (repo *OAuth2Repository) ListClients(ctx context.Context) (clients []*entity.Client, err error) {
+	var (
+		daos []ClientDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(ClientDAO{}).
+		Order("created_at").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	clients = make([]*entity.Client, 0, len(daos))
+	for i := range daos {
+		clients = append(clients, UnmarshalClient(&daos[i]))
+	}
+
+	return clients, nil
+}
+
+// UpdateClient is SQL implement
+func (repo *OAuth2Repository) UpdateClient(ctx context.Context, client *entity.Client) (err error) {
+	dao := UnmarshalClientDAO(client)
+
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(ClientDAO{}).
+		Where("id = ?", client.ID).
+		Updates(map[string]interface{}{
+			"name":          dao.Name,
+			"secret_hash":   dao.SecretHash,
+			"redirect_uris": dao.RedirectURIs,
+			"scopes":        dao.Scopes,
+			"grant_types":   dao.GrantTypes,
+			"updated_at":    dao.UpdatedAt,
+		})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update client id=%v, err %v", client.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "cant not found client id=%v", client.ID)
+	}
+
+	return nil
+}
+
+// DeleteClient is SQL implement
+func (repo *OAuth2Repository) DeleteClient(ctx context.Context, clientID string) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			result := tx.
+				Where("id = ?", clientID).
+				Delete(&ClientDAO{})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete client id=%v, err %v", clientID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "cant not found client id=%v", clientID)
+			}
+
+			err := tx.
+				Where("client_id = ?", clientID).
+				Delete(&ConsentDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete consents of client id=%v, err %v", clientID, err)
+			}
+
+			return nil
+		})
+}
+
+// StoreAuthorizationCode is SQL implement
+func (repo *OAuth2Repository) StoreAuthorizationCode(ctx context.Context, code *entity.AuthorizationCode) (err error) {
+	dao := UnmarshalAuthorizationCodeDAO(code)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to create authorization code of client=%v, err %v", code.ClientID, err)
+	}
+
+	return nil
+}
+
+// ConsumeAuthorizationCode is SQL implement
+// the code only can be consumed once, concurrent exchange will get ErrConflict
+func (repo *OAuth2Repository) ConsumeAuthorizationCode(ctx context.Context, codeHash string, sessionID string) (code *entity.AuthorizationCode, err error) {
+	var (
+		dao AuthorizationCodeDAO
+	)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Model(dao).
+				Where("code_hash = ?", codeHash).
+				First(&dao).
+				Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errors.Wrapf(errors.ErrResourceNotFound, "cant not found authorization code")
+				}
+				return errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+			}
+
+			result := tx.
+				Model(AuthorizationCodeDAO{}).
+				Where("code_hash = ? AND used_at = 0", codeHash).
+				UpdateColumns(map[string]interface{}{
+					"used_at":    time.Now().UnixMilli(),
+					"session_id": sessionID,
+				})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to consume authorization code, err %v", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrConflict, "authorization code of client=%v already used", dao.ClientID)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	dao.SessionID = sessionID
+	return UnmarshalAuthorizationCode(&dao), nil
+}
+
+// FindAuthorizationCode is SQL implement
+func (repo *OAuth2Repository) FindAuthorizationCode(ctx context.Context, codeHash string) (code *entity.AuthorizationCode, err error) {
+	var (
+		dao AuthorizationCodeDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Where("code_hash = ?", codeHash).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found authorization code")
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalAuthorizationCode(&dao), nil
+}
+
+// FindConsent is SQL implement
+func (repo *OAuth2Repository) FindConsent(ctx context.Context, userID string, clientID string) (consent *entity.Consent, err error) {
+	var (
+		dao ConsentDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Where("user_id = ? AND client_id = ?", userID, clientID).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found consent user=%v client=%v", userID, clientID)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalConsent(&dao), nil
+}
+
+// StoreConsent is SQL implement
+func (repo *OAuth2Repository) StoreConsent(ctx context.Context, consent *entity.Consent) (err error) {
+	dao := UnmarshalConsentDAO(consent)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "client_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"scopes", "updated_at"}),
+		}).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to store consent user=%v client=%v, err %v", consent.UserID, consent.ClientID, err)
+	}
+
+	return nil
+}
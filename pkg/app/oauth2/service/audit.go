@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/oauth2/entity"
+	"github.com/karta0898098/iam/pkg/audit"
+)
+
+type auditMiddleware struct {
+	next     OAuth2Service
+	recorder audit.Recorder
+}
+
+// AuditMiddleware record token grant and client changing action to audit log,
+// read only action is not recorded
+func AuditMiddleware(recorder audit.Recorder) Middleware {
+	return func(next OAuth2Service) OAuth2Service {
+		return auditMiddleware{next: next, recorder: recorder}
+	}
+}
+
+// grantEvent new event of token grant, actor is user tokens issued for
+// or the client itself when there is no user
+func grantEvent(req *TokenRequest, grant *entity.Grant, err error) *audit.Event {
+	if grant == nil {
+		return audit.NewEvent(audit.ActionTokenGrant, req.ClientID, req.ClientID, err)
+	}
+	if grant.Identity == nil || grant.User == nil {
+		return audit.NewEvent(audit.ActionTokenGrant, grant.ClientID, grant.ClientID, err)
+	}
+
+	event := audit.NewEvent(audit.ActionTokenGrant, grant.User.ID, grant.ClientID, err)
+	event.TenantID = grant.User.TenantID
+	return event
+}
+
+func (am auditMiddleware) ValidateAuthorizeRequest(ctx context.Context, req *AuthorizeRequest) (client *entity.Client, err error) {
+	return am.next.ValidateAuthorizeRequest(ctx, req)
+}
+
+func (am auditMiddleware) Authorize(ctx context.Context, userID string, req *AuthorizeRequest) (code *entity.AuthorizationCode, err error) {
+	return am.next.Authorize(ctx, userID, req)
+}
+
+func (am auditMiddleware) Token(ctx context.Context, req *TokenRequest) (grant *entity.Grant, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, grantEvent(req, grant, err).WithClient("", PlatformOAuth2, ""))
+	}()
+
+	return am.next.Token(ctx, req)
+}
+
+func (am auditMiddleware) CreateClient(ctx context.Context, opt *ClientOption) (client *entity.Client, secret string, err error) {
+	defer func() {
+		var clientID string
+		if client != nil {
+			clientID = client.ID
+		}
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionCreateClient, clientID, err))
+	}()
+
+	return am.next.CreateClient(ctx, opt)
+}
+
+func (am auditMiddleware) GetClient(ctx context.Context, clientID string) (client *entity.Client, err error) {
+	return am.next.GetClient(ctx, clientID)
+}
+
+func (am auditMiddleware) ListClients(ctx context.Context) (clients []*entity.Client, err error) {
+	return am.next.ListClients(ctx)
+}
+
+func (am auditMiddleware) UpdateClient(ctx context.Context, clientID string, opt *ClientOption) (client *entity.Client, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionUpdateClient, clientID, err))
+	}()
+
+	return am.next.UpdateClient(ctx, clientID, opt)
+}
+
+func (am auditMiddleware) ResetClientSecret(ctx context.Context, clientID string) (client *entity.Client, secret string, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionResetClientSecret, clientID, err))
+	}()
+
+	return am.next.ResetClientSecret(ctx, clientID)
+}
+
+func (am auditMiddleware) DeleteClient(ctx context.Context, clientID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionDeleteClient, clientID, err))
+	}()
+
+	return am.next.DeleteClient(ctx, clientID)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/oauth2/entity"
+)
+
+type loggingMiddleware struct {
+	next OAuth2Service `json:"-"`
+}
+
+// LoggingMiddleware takes a logger as a dependency
+// and returns a ServiceMiddleware.
+func LoggingMiddleware() Middleware {
+	return func(next OAuth2Service) OAuth2Service {
+		return loggingMiddleware{next}
+	}
+}
+
+func (lm loggingMiddleware) ValidateAuthorizeRequest(ctx context.Context, req *AuthorizeRequest) (client *entity.Client, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ValidateAuthorizeRequest",
+		// 	"client_id", req.ClientID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ValidateAuthorizeRequest(ctx, req)
+}
+
+func (lm loggingMiddleware) Authorize(ctx context.Context, userID string, req *AuthorizeRequest) (code *entity.AuthorizationCode, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "Authorize",
+		// 	"client_id", req.ClientID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.Authorize(ctx, userID, req)
+}
+
+func (lm loggingMiddleware) Token(ctx context.Context, req *TokenRequest) (grant *entity.Grant, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "Token",
+		// 	"grant_type", req.GrantType,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.Token(ctx, req)
+}
+
+func (lm loggingMiddleware) CreateClient(ctx context.Context, opt *ClientOption) (client *entity.Client, secret string, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "CreateClient",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.CreateClient(ctx, opt)
+}
+
+func (lm loggingMiddleware) GetClient(ctx context.Context, clientID string) (client *entity.Client, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "GetClient",
+		// 	"client_id", clientID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.GetClient(ctx, clientID)
+}
+
+func (lm loggingMiddleware) ListClients(ctx context.Context) (clients []*entity.Client, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListClients",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListClients(ctx)
+}
+
+func (lm loggingMiddleware) UpdateClient(ctx context.Context, clientID string, opt *ClientOption) (client *entity.Client, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "UpdateClient",
+		// 	"client_id", clientID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.UpdateClient(ctx, clientID, opt)
+}
+
+func (lm loggingMiddleware) ResetClientSecret(ctx context.Context, clientID string) (client *entity.Client, secret string, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ResetClientSecret",
+		// 	"client_id", clientID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ResetClientSecret(ctx, clientID)
+}
+
+func (lm loggingMiddleware) DeleteClient(ctx context.Context, clientID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "DeleteClient",
+		// 	"client_id", clientID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.DeleteClient(ctx, clientID)
+}
@@ -0,0 +1,587 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/xid"
+
+	identity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	identityrepo "github.com/karta0898098/iam/pkg/app/identity/repository"
+	identitysvc "github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/app/oauth2/entity"
+	"github.com/karta0898098/iam/pkg/app/oauth2/repository"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/password"
+)
+
+const (
+	// PlatformOAuth2 platform of session issued by token endpoint
+	PlatformOAuth2 = "oauth2"
+)
+
+var _ OAuth2Service = &Impl{}
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(OAuth2Service) OAuth2Service
+
+// OAuth2Service define OAuth 2.0 authorization server
+type OAuth2Service interface {
+	// ValidateAuthorizeRequest verify client, redirect uri and PKCE challenge
+	// before user sign in and consent
+	ValidateAuthorizeRequest(
+		ctx context.Context,
+		req *AuthorizeRequest,
+	) (client *entity.Client, err error)
+
+	// Authorize issue authorization code to user after consent
+	// error after redirect uri verified is returned as entity.RedirectError
+	Authorize(
+		ctx context.Context,
+		userID string,
+		req *AuthorizeRequest,
+	) (code *entity.AuthorizationCode, err error)
+
+	// Token exchange authorization grant to tokens
+	Token(
+		ctx context.Context,
+		req *TokenRequest,
+	) (grant *entity.Grant, err error)
+
+	// CreateClient register client, secret is only returned once
+	CreateClient(
+		ctx context.Context,
+		opt *ClientOption,
+	) (client *entity.Client, secret string, err error)
+
+	// GetClient get registered client
+	GetClient(
+		ctx context.Context,
+		clientID string,
+	) (client *entity.Client, err error)
+
+	// ListClients list registered clients
+	ListClients(
+		ctx context.Context,
+	) (clients []*entity.Client, err error)
+
+	// UpdateClient update client registration, client type can not be changed
+	UpdateClient(
+		ctx context.Context,
+		clientID string,
+		opt *ClientOption,
+	) (client *entity.Client, err error)
+
+	// ResetClientSecret generate new secret of confidential client
+	ResetClientSecret(
+		ctx context.Context,
+		clientID string,
+	) (client *entity.Client, secret string, err error)
+
+	// DeleteClient delete client and revoke tokens issued to it
+	DeleteClient(
+		ctx context.Context,
+		clientID string,
+	) (err error)
+}
+
+type Impl struct {
+	repo         repository.Repository
+	identityRepo identityrepo.Repository
+	identitySvc  identitysvc.IdentityService
+	keys         keys.KeyManager
+	hasher       password.Hasher
+	roles        identitysvc.RoleResolver
+	permissions  authn.PermissionChecker
+}
+
+func New(
+	repo repository.Repository,
+	identityRepo identityrepo.Repository,
+	identitySvc identitysvc.IdentityService,
+	km keys.KeyManager,
+	hasher password.Hasher,
+	roles identitysvc.RoleResolver,
+	permissions authn.PermissionChecker,
+	recorder audit.Recorder,
+) OAuth2Service {
+	var svc OAuth2Service
+	svc = &Impl{
+		repo:         repo,
+		identityRepo: identityRepo,
+		identitySvc:  identitySvc,
+		keys:         km,
+		hasher:       hasher,
+		roles:        roles,
+		permissions:  permissions,
+	}
+	svc = LoggingMiddleware()(svc)
+	if recorder != nil {
+		svc = AuditMiddleware(recorder)(svc)
+	}
+
+	return svc
+}
+
+func (srv *Impl) ValidateAuthorizeRequest(ctx context.Context, req *AuthorizeRequest) (client *entity.Client, err error) {
+	client, err = srv.repo.FindClientByID(ctx, req.ClientID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, errors.Wrapf(
+				entity.ErrInvalidRequest.WithDescription("unknown client_id"),
+				"client=%v not found", req.ClientID,
+			)
+		}
+		return nil, err
+	}
+
+	// redirect uri must be verified before any error redirect to client
+	if !client.HasRedirectURI(req.RedirectURI) {
+		return nil, errors.Wrapf(
+			entity.ErrInvalidRequest.WithDescription("redirect_uri is not registered"),
+			"client=%v redirect uri=%v is not registered", client.ID, req.RedirectURI,
+		)
+	}
+
+	if req.ResponseType != "code" {
+		return nil, errors.Wrapf(
+			entity.NewRedirectError(entity.ErrUnsupportedResponseType, req.RedirectURI, req.State),
+			"client=%v response type=%v", client.ID, req.ResponseType,
+		)
+	}
+
+	if !client.AllowScopes(req.Scopes) {
+		return nil, errors.Wrapf(
+			entity.NewRedirectError(entity.ErrInvalidScope, req.RedirectURI, req.State),
+			"client=%v scope=%v is not allowed", client.ID, req.Scopes.String(),
+		)
+	}
+
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != entity.CodeChallengeMethodS256 {
+		pkceErr := entity.ErrInvalidRequest.WithDescription("code_challenge with S256 method is required")
+		return nil, errors.Wrapf(
+			entity.NewRedirectError(pkceErr, req.RedirectURI, req.State),
+			"client=%v code challenge method=%v", client.ID, req.CodeChallengeMethod,
+		)
+	}
+
+	return client, nil
+}
+
+func (srv *Impl) Authorize(ctx context.Context, userID string, req *AuthorizeRequest) (code *entity.AuthorizationCode, err error) {
+	client, err := srv.ValidateAuthorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := srv.allowUserScopes(ctx, userID, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.Wrapf(
+			entity.NewRedirectError(entity.ErrInvalidScope, req.RedirectURI, req.State),
+			"user=%v is not granted permission of scope=%v", userID, req.Scopes.String(),
+		)
+	}
+
+	switch req.Consent {
+	case ConsentDeny:
+		return nil, errors.Wrapf(
+			entity.NewRedirectError(entity.ErrAccessDenied, req.RedirectURI, req.State),
+			"user=%v deny client=%v", userID, client.ID,
+		)
+	case ConsentApprove:
+		consent, err := srv.repo.FindConsent(ctx, userID, client.ID)
+		if err != nil {
+			if !errors.Is(err, errors.ErrResourceNotFound) {
+				return nil, err
+			}
+			consent = entity.NewConsent(userID, client.ID, nil)
+		}
+		consent.Grant(req.Scopes)
+
+		err = srv.repo.StoreConsent(ctx, consent)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		consent, err := srv.repo.FindConsent(ctx, userID, client.ID)
+		if err != nil && !errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, err
+		}
+		if consent == nil || !consent.Covers(req.Scopes) {
+			return nil, errors.Wrapf(
+				entity.NewRedirectError(entity.ErrConsentRequired, req.RedirectURI, req.State),
+				"user=%v has not granted scope=%v to client=%v", userID, req.Scopes.String(), client.ID,
+			)
+		}
+	}
+
+	code, err = entity.NewAuthorizationCode(
+		client.ID,
+		userID,
+		req.RedirectURI,
+		req.Scopes,
+		req.Nonce,
+		req.CodeChallenge,
+		req.CodeChallengeMethod,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.StoreAuthorizationCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return code, nil
+}
+
+func (srv *Impl) Token(ctx context.Context, req *TokenRequest) (grant *entity.Grant, err error) {
+	client, err := srv.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.GrantType {
+	case entity.GrantTypeAuthorizationCode,
+		entity.GrantTypeRefreshToken,
+		entity.GrantTypeClientCredentials:
+	default:
+		return nil, errors.Wrapf(
+			entity.ErrUnsupportedGrantType,
+			"client=%v grant type=%v", client.ID, req.GrantType,
+		)
+	}
+
+	if !client.AllowGrantType(req.GrantType) {
+		return nil, errors.Wrapf(
+			entity.ErrUnauthorizedClient,
+			"client=%v is not allowed grant type=%v", client.ID, req.GrantType,
+		)
+	}
+
+	switch req.GrantType {
+	case entity.GrantTypeAuthorizationCode:
+		return srv.exchangeAuthorizationCode(ctx, client, req)
+	case entity.GrantTypeRefreshToken:
+		return srv.exchangeRefreshToken(ctx, client, req)
+	default:
+		return srv.exchangeClientCredentials(ctx, client, req)
+	}
+}
+
+// authenticateClient confidential client must present secret,
+// public client is identified by client id only
+func (srv *Impl) authenticateClient(ctx context.Context, clientID string, secret string) (*entity.Client, error) {
+	if clientID == "" {
+		return nil, errors.Wrap(entity.ErrInvalidClient, "client id is empty")
+	}
+
+	client, err := srv.repo.FindClientByID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, errors.Wrapf(entity.ErrInvalidClient, "client=%v not found", clientID)
+		}
+		return nil, err
+	}
+
+	if !client.IsPublic() && !client.VerifySecret(secret) {
+		return nil, errors.Wrapf(entity.ErrInvalidClient, "client=%v secret mismatch", clientID)
+	}
+
+	return client, nil
+}
+
+// allowUserScopes scope allowed to client is granted to user only when user hold its permission,
+// iam:admin require admin permission while client_credentials grant is limited by client scopes only
+func (srv *Impl) allowUserScopes(ctx context.Context, userID string, scopes oidc.Scopes) (bool, error) {
+	if !scopes.Has(entity.ScopeAdmin) {
+		return true, nil
+	}
+	if srv.permissions == nil {
+		return false, nil
+	}
+	return srv.permissions.CheckPermission(ctx, userID, authn.PermissionAdmin)
+}
+
+// exchangeAuthorizationCode RFC 6749 section 4.1.3 with PKCE verification
+func (srv *Impl) exchangeAuthorizationCode(ctx context.Context, client *entity.Client, req *TokenRequest) (*entity.Grant, error) {
+	// session id is recorded on code before session issued so replay can revoke it
+	sessionID := xid.New().String()
+	code, err := srv.repo.ConsumeAuthorizationCode(ctx, entity.HashCode(req.Code), sessionID)
+	if err != nil {
+		if errors.Is(err, errors.ErrConflict) {
+			err = srv.revokeReplayedCode(ctx, entity.HashCode(req.Code))
+			if err != nil {
+				return nil, err
+			}
+			return nil, errors.Wrapf(entity.ErrInvalidGrant, "client=%v authorization code is replayed", client.ID)
+		}
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, errors.Wrapf(entity.ErrInvalidGrant, "client=%v authorization code invalid reason %v", client.ID, err)
+		}
+		return nil, err
+	}
+
+	if code.ClientID != client.ID ||
+		code.RedirectURI != req.RedirectURI ||
+		code.IsExpired() {
+		return nil, errors.Wrapf(
+			entity.ErrInvalidGrant,
+			"authorization code of client=%v is expired or not match client=%v",
+			code.ClientID, client.ID,
+		)
+	}
+
+	if !code.VerifyCodeVerifier(req.CodeVerifier) {
+		return nil, errors.Wrapf(
+			entity.ErrInvalidGrant.WithDescription("code_verifier mismatch"),
+			"client=%v code verifier mismatch", client.ID,
+		)
+	}
+
+	user, err := srv.identityRepo.FindUserByID(ctx, code.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		return nil, errors.Wrapf(
+			entity.ErrInvalidGrant,
+			"user=%s is not active status=%v",
+			user.ID, user.Status,
+		)
+	}
+
+	// permission may be revoked after code is issued
+	allowed, err := srv.allowUserScopes(ctx, user.ID, code.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.Wrapf(
+			entity.ErrInvalidScope,
+			"user=%v is not granted permission of scope=%v", user.ID, code.Scopes.String(),
+		)
+	}
+
+	session := identity.NewSession(
+		sessionID,
+		user.ID,
+		req.IPAddress,
+		PlatformOAuth2,
+		identity.WithClient(client.ID, code.Scopes.String()),
+		identity.WithSessionTenant(user.TenantID),
+	)
+
+	err = srv.identityRepo.StoreSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	// refresh grant is served by identity service which resolve roles itself
+	var roles []string
+	if srv.roles != nil {
+		roles, err = srv.roles.UserRoles(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &entity.Grant{
+		Identity: &identity.Identity{
+			User:    user,
+			Session: session,
+			Roles:   roles,
+		},
+		ClientID:          client.ID,
+		Scopes:            code.Scopes,
+		Nonce:             code.Nonce,
+		IssueRefreshToken: true,
+	}, nil
+}
+
+// revokeReplayedCode revoke tokens issued from authorization code used twice,
+// code may be stolen so every session refreshed from it is revoked, see RFC 6749 section 4.1.2
+func (srv *Impl) revokeReplayedCode(ctx context.Context, codeHash string) error {
+	code, err := srv.repo.FindAuthorizationCode(ctx, codeHash)
+	if err != nil {
+		return err
+	}
+	if code.SessionID == "" {
+		return nil
+	}
+
+	return srv.identityRepo.RevokeSessionFamily(ctx, code.SessionID)
+}
+
+// exchangeRefreshToken RFC 6749 section 6
+// refresh token must be issued to the authenticated client
+func (srv *Impl) exchangeRefreshToken(ctx context.Context, client *entity.Client, req *TokenRequest) (*entity.Grant, error) {
+	claims, err := identity.ParseToken(srv.keys, req.RefreshToken, identity.TokenTypeRefresh)
+	if err != nil {
+		return nil, errors.Wrapf(entity.ErrInvalidGrant, "client=%v refresh token invalid reason %v", client.ID, err)
+	}
+
+	if claims.ClientID != client.ID {
+		return nil, errors.Wrapf(
+			entity.ErrInvalidGrant,
+			"refresh token issued to client=%v not client=%v",
+			claims.ClientID, client.ID,
+		)
+	}
+
+	id, err := srv.identitySvc.Refresh(ctx, req.RefreshToken, client.ID)
+	if err != nil {
+		if errors.Is(err, errors.ErrUnauthorized) || errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, errors.Wrapf(entity.ErrInvalidGrant, "client=%v refresh failed reason %v", client.ID, err)
+		}
+		return nil, err
+	}
+
+	return &entity.Grant{
+		Identity:          id,
+		ClientID:          client.ID,
+		Scopes:            oidc.ParseScopes(id.Session.Scope),
+		IssueRefreshToken: true,
+	}, nil
+}
+
+// exchangeClientCredentials RFC 6749 section 4.4
+// client is the subject of session, refresh token is not issued
+func (srv *Impl) exchangeClientCredentials(ctx context.Context, client *entity.Client, req *TokenRequest) (*entity.Grant, error) {
+	if client.IsPublic() {
+		return nil, errors.Wrapf(entity.ErrUnauthorizedClient, "public client=%v can not use client credentials", client.ID)
+	}
+
+	scopes := req.Scopes
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+
+	if !client.AllowScopes(scopes) {
+		return nil, errors.Wrapf(
+			entity.ErrInvalidScope,
+			"client=%v scope=%v is not allowed", client.ID, scopes.String(),
+		)
+	}
+
+	session := identity.NewSession(
+		xid.New().String(),
+		client.ID,
+		req.IPAddress,
+		PlatformOAuth2,
+		identity.WithClient(client.ID, scopes.String()),
+	)
+
+	err := srv.identityRepo.StoreSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Grant{
+		Identity: &identity.Identity{
+			Session: session,
+		},
+		ClientID: client.ID,
+		Scopes:   scopes,
+	}, nil
+}
+
+func (srv *Impl) CreateClient(ctx context.Context, opt *ClientOption) (client *entity.Client, secret string, err error) {
+	client, secret, err = entity.NewClient(
+		xid.New().String(),
+		opt.Name,
+		opt.Type,
+		opt.RedirectURIs,
+		opt.Scopes,
+		opt.GrantTypes,
+		srv.hasher,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = srv.repo.StoreClient(ctx, client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, secret, nil
+}
+
+func (srv *Impl) GetClient(ctx context.Context, clientID string) (client *entity.Client, err error) {
+	return srv.repo.FindClientByID(ctx, clientID)
+}
+
+func (srv *Impl) ListClients(ctx context.Context) (clients []*entity.Client, err error) {
+	return srv.repo.ListClients(ctx)
+}
+
+func (srv *Impl) UpdateClient(ctx context.Context, clientID string, opt *ClientOption) (client *entity.Client, err error) {
+	client, err = srv.repo.FindClientByID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Type != entity.ClientTypeUnknown && opt.Type != client.Type {
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "client=%v type can not be changed", client.ID)
+	}
+
+	client.Name = opt.Name
+	client.RedirectURIs = opt.RedirectURIs
+	client.Scopes = opt.Scopes
+	client.GrantTypes = opt.GrantTypes
+	client.UpdatedAt = time.Now()
+
+	err = client.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.UpdateClient(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (srv *Impl) ResetClientSecret(ctx context.Context, clientID string) (client *entity.Client, secret string, err error) {
+	client, err = srv.repo.FindClientByID(ctx, clientID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if client.IsPublic() {
+		return nil, "", errors.Wrapf(errors.ErrInvalidInput, "public client=%v has no secret", client.ID)
+	}
+
+	secret, err = client.ResetSecret(srv.hasher)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = srv.repo.UpdateClient(ctx, client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, secret, nil
+}
+
+func (srv *Impl) DeleteClient(ctx context.Context, clientID string) (err error) {
+	err = srv.repo.DeleteClient(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	return srv.identityRepo.RevokeClientSessions(ctx, clientID)
+}
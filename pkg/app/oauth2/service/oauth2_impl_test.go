@@ -0,0 +1,705 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	identity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	identitymocks "github.com/karta0898098/iam/pkg/app/identity/mocks"
+	identityrepo "github.com/karta0898098/iam/pkg/app/identity/repository"
+	"github.com/karta0898098/iam/pkg/app/oauth2/entity"
+	"github.com/karta0898098/iam/pkg/app/oauth2/mocks"
+	"github.com/karta0898098/iam/pkg/app/oauth2/repository"
+	"github.com/karta0898098/iam/pkg/app/oauth2/service"
+	rbacmocks "github.com/karta0898098/iam/pkg/app/rbac/mocks"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/password"
+)
+
+var km, _ = keys.NewKeyManager(keys.Config{Ephemeral: true})
+
+const (
+	codeVerifier = "dBjftJeZ4CVP-mJ92K9Xl1Y2J6r2bRXAFW5XJWFK6Wc"
+	redirectURI  = "https://client.example.com/callback"
+)
+
+func codeChallenge(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+func newClient(clientType entity.ClientType, secret string) *entity.Client {
+	client := &entity.Client{
+		ID:           "MOCK-CLIENT-ID",
+		Name:         "Mock Client",
+		Type:         clientType,
+		RedirectURIs: []string{redirectURI},
+		Scopes:       oidc.Scopes{oidc.ScopeOpenID, oidc.ScopeProfile},
+		GrantTypes:   []string{entity.GrantTypeAuthorizationCode, entity.GrantTypeRefreshToken},
+	}
+	if secret != "" {
+		client.SecretHash, _ = password.Default.Hash(secret)
+	}
+	return client
+}
+
+func newAuthorizeRequest() *service.AuthorizeRequest {
+	return &service.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            "MOCK-CLIENT-ID",
+		RedirectURI:         redirectURI,
+		Scopes:              oidc.Scopes{oidc.ScopeOpenID},
+		State:               "MOCK-STATE",
+		CodeChallenge:       codeChallenge(codeVerifier),
+		CodeChallengeMethod: entity.CodeChallengeMethodS256,
+	}
+}
+
+func TestImpl_Authorize(t *testing.T) {
+	client := newClient(entity.ClientTypePublic, "")
+
+	tests := []struct {
+		name     string
+		repo     repository.Repository
+		req      func() *service.AuthorizeRequest
+		redirect bool
+		err      error
+	}{
+		{
+			name: "Approve",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, client.ID).
+					Return(client, nil)
+				repo.EXPECT().
+					FindConsent(mock.Anything, "MOCK-USER-ID", client.ID).
+					Return(nil, errors.ErrResourceNotFound)
+				repo.EXPECT().
+					StoreConsent(mock.Anything, mock.Anything).
+					Return(nil)
+				repo.EXPECT().
+					StoreAuthorizationCode(mock.Anything, mock.Anything).
+					Return(nil)
+				return repo
+			}(),
+			req: func() *service.AuthorizeRequest {
+				req := newAuthorizeRequest()
+				req.Consent = service.ConsentApprove
+				return req
+			},
+		},
+		{
+			name: "Previous Consent Covers Scope",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, client.ID).
+					Return(client, nil)
+				repo.EXPECT().
+					FindConsent(mock.Anything, "MOCK-USER-ID", client.ID).
+					Return(entity.NewConsent("MOCK-USER-ID", client.ID, oidc.Scopes{oidc.ScopeOpenID}), nil)
+				repo.EXPECT().
+					StoreAuthorizationCode(mock.Anything, mock.Anything).
+					Return(nil)
+				return repo
+			}(),
+			req: newAuthorizeRequest,
+		},
+		{
+			name: "Consent Required",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, client.ID).
+					Return(client, nil)
+				repo.EXPECT().
+					FindConsent(mock.Anything, "MOCK-USER-ID", client.ID).
+					Return(nil, errors.ErrResourceNotFound)
+				return repo
+			}(),
+			req:      newAuthorizeRequest,
+			redirect: true,
+			err:      entity.ErrConsentRequired,
+		},
+		{
+			name: "Deny",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, client.ID).
+					Return(client, nil)
+				return repo
+			}(),
+			req: func() *service.AuthorizeRequest {
+				req := newAuthorizeRequest()
+				req.Consent = service.ConsentDeny
+				return req
+			},
+			redirect: true,
+			err:      entity.ErrAccessDenied,
+		},
+		{
+			name: "Unregistered Redirect URI",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, client.ID).
+					Return(client, nil)
+				return repo
+			}(),
+			req: func() *service.AuthorizeRequest {
+				req := newAuthorizeRequest()
+				req.RedirectURI = "https://attacker.example.com/callback"
+				return req
+			},
+			redirect: false,
+			err:      entity.ErrInvalidRequest,
+		},
+		{
+			name: "Missing PKCE Challenge",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, client.ID).
+					Return(client, nil)
+				return repo
+			}(),
+			req: func() *service.AuthorizeRequest {
+				req := newAuthorizeRequest()
+				req.CodeChallenge = ""
+				req.CodeChallengeMethod = "plain"
+				return req
+			},
+			redirect: true,
+			err:      entity.ErrInvalidRequest,
+		},
+		{
+			name: "Scope Not Allowed",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, client.ID).
+					Return(client, nil)
+				return repo
+			}(),
+			req: func() *service.AuthorizeRequest {
+				req := newAuthorizeRequest()
+				req.Scopes = oidc.Scopes{oidc.ScopeOpenID, oidc.ScopeEmail}
+				return req
+			},
+			redirect: true,
+			err:      entity.ErrInvalidScope,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo, identitymocks.NewRepository(t), identitymocks.NewIdentityService(t), km, password.Default, nil, nil, nil)
+			actual, err := srv.Authorize(ctx, "MOCK-USER-ID", tt.req())
+			if tt.err != nil || err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Authorize() error = %v, wantErr %v", err, tt.err)
+				}
+				_, ok := errors.Cause(err).(*entity.RedirectError)
+				assert.Equal(t, tt.redirect, ok)
+				return
+			}
+
+			assert.NotEmpty(t, actual.Code)
+			assert.Equal(t, entity.HashCode(actual.Code), actual.CodeHash)
+			assert.Equal(t, "MOCK-USER-ID", actual.UserID)
+			assert.Equal(t, redirectURI, actual.RedirectURI)
+		})
+	}
+}
+
+func TestImpl_Authorize_AdminScope(t *testing.T) {
+	client := newClient(entity.ClientTypePublic, "")
+	client.Scopes = append(client.Scopes, entity.ScopeAdmin)
+
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		FindClientByID(mock.Anything, client.ID).
+		Return(client, nil)
+	repo.EXPECT().
+		FindConsent(mock.Anything, "MOCK-ADMIN-ID", client.ID).
+		Return(nil, errors.ErrResourceNotFound)
+	repo.EXPECT().
+		StoreConsent(mock.Anything, mock.Anything).
+		Return(nil)
+	repo.EXPECT().
+		StoreAuthorizationCode(mock.Anything, mock.Anything).
+		Return(nil)
+
+	permissions := rbacmocks.NewRBACService(t)
+	permissions.EXPECT().
+		CheckPermission(mock.Anything, "MOCK-USER-ID", authn.PermissionAdmin).
+		Return(false, nil)
+	permissions.EXPECT().
+		CheckPermission(mock.Anything, "MOCK-ADMIN-ID", authn.PermissionAdmin).
+		Return(true, nil)
+
+	srv := service.New(repo, identitymocks.NewRepository(t), identitymocks.NewIdentityService(t), km, password.Default, nil, permissions, nil)
+
+	req := newAuthorizeRequest()
+	req.Scopes = oidc.Scopes{oidc.ScopeOpenID, entity.ScopeAdmin}
+	req.Consent = service.ConsentApprove
+
+	// client allowing scope does not make ordinary user administrator
+	_, err := srv.Authorize(context.Background(), "MOCK-USER-ID", req)
+	assert.True(t, errors.Is(err, entity.ErrInvalidScope), err)
+
+	actual, err := srv.Authorize(context.Background(), "MOCK-ADMIN-ID", req)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "MOCK-ADMIN-ID", actual.UserID)
+	}
+}
+
+func TestImpl_Token(t *testing.T) {
+	user, _ := identity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+	)
+	publicClient := newClient(entity.ClientTypePublic, "")
+	confidentialClient := newClient(entity.ClientTypeConfidential, "MOCK-SECRET")
+	serviceClient := newClient(entity.ClientTypeConfidential, "MOCK-SECRET")
+	serviceClient.ID = "MOCK-SERVICE-CLIENT-ID"
+	serviceClient.Scopes = oidc.Scopes{"orders:read", "orders:write"}
+	serviceClient.GrantTypes = []string{entity.GrantTypeClientCredentials}
+
+	newCode := func(expiresAt time.Time) *entity.AuthorizationCode {
+		code, _ := entity.NewAuthorizationCode(
+			publicClient.ID,
+			user.ID,
+			redirectURI,
+			oidc.Scopes{oidc.ScopeOpenID},
+			"MOCK-NONCE",
+			codeChallenge(codeVerifier),
+			entity.CodeChallengeMethodS256,
+		)
+		code.ExpiresAt = expiresAt
+		return code
+	}
+
+	tests := []struct {
+		name         string
+		repo         repository.Repository
+		identityRepo identityrepo.Repository
+		req          *service.TokenRequest
+		check        func(t *testing.T, grant *entity.Grant)
+		err          error
+	}{
+		{
+			name: "Exchange Authorization Code",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, publicClient.ID).
+					Return(publicClient, nil)
+				repo.EXPECT().
+					ConsumeAuthorizationCode(mock.Anything, entity.HashCode("MOCK-CODE"), mock.Anything).
+					Return(newCode(time.Now().Add(time.Minute)), nil)
+				return repo
+			}(),
+			identityRepo: func() identityrepo.Repository {
+				repo := identitymocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.Anything).
+					Return(nil)
+				return repo
+			}(),
+			req: &service.TokenRequest{
+				GrantType:    entity.GrantTypeAuthorizationCode,
+				ClientID:     publicClient.ID,
+				Code:         "MOCK-CODE",
+				RedirectURI:  redirectURI,
+				CodeVerifier: codeVerifier,
+			},
+			check: func(t *testing.T, grant *entity.Grant) {
+				assert.Equal(t, user.ID, grant.User.ID)
+				assert.Equal(t, publicClient.ID, grant.Session.ClientID)
+				assert.Equal(t, "openid", grant.Session.Scope)
+				assert.Equal(t, "MOCK-NONCE", grant.Nonce)
+				assert.True(t, grant.IssueRefreshToken)
+			},
+		},
+		{
+			name: "Admin Scope Without Admin Permission",
+			repo: func() repository.Repository {
+				code := newCode(time.Now().Add(time.Minute))
+				code.Scopes = oidc.Scopes{oidc.ScopeOpenID, entity.ScopeAdmin}
+
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, publicClient.ID).
+					Return(publicClient, nil)
+				repo.EXPECT().
+					ConsumeAuthorizationCode(mock.Anything, entity.HashCode("MOCK-CODE"), mock.Anything).
+					Return(code, nil)
+				return repo
+			}(),
+			identityRepo: func() identityrepo.Repository {
+				repo := identitymocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserByID(mock.Anything, user.ID).
+					Return(user, nil)
+				return repo
+			}(),
+			req: &service.TokenRequest{
+				GrantType:    entity.GrantTypeAuthorizationCode,
+				ClientID:     publicClient.ID,
+				Code:         "MOCK-CODE",
+				RedirectURI:  redirectURI,
+				CodeVerifier: codeVerifier,
+			},
+			err: entity.ErrInvalidScope,
+		},
+		{
+			name: "Code Verifier Mismatch",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, publicClient.ID).
+					Return(publicClient, nil)
+				repo.EXPECT().
+					ConsumeAuthorizationCode(mock.Anything, entity.HashCode("MOCK-CODE"), mock.Anything).
+					Return(newCode(time.Now().Add(time.Minute)), nil)
+				return repo
+			}(),
+			identityRepo: identitymocks.NewRepository(t),
+			req: &service.TokenRequest{
+				GrantType:    entity.GrantTypeAuthorizationCode,
+				ClientID:     publicClient.ID,
+				Code:         "MOCK-CODE",
+				RedirectURI:  redirectURI,
+				CodeVerifier: "wrong-verifier",
+			},
+			err: entity.ErrInvalidGrant,
+		},
+		{
+			name: "Expired Authorization Code",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, publicClient.ID).
+					Return(publicClient, nil)
+				repo.EXPECT().
+					ConsumeAuthorizationCode(mock.Anything, entity.HashCode("MOCK-CODE"), mock.Anything).
+					Return(newCode(time.Now().Add(-time.Minute)), nil)
+				return repo
+			}(),
+			identityRepo: identitymocks.NewRepository(t),
+			req: &service.TokenRequest{
+				GrantType:    entity.GrantTypeAuthorizationCode,
+				ClientID:     publicClient.ID,
+				Code:         "MOCK-CODE",
+				RedirectURI:  redirectURI,
+				CodeVerifier: codeVerifier,
+			},
+			err: entity.ErrInvalidGrant,
+		},
+		{
+			name: "Reused Authorization Code",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, publicClient.ID).
+					Return(publicClient, nil)
+				repo.EXPECT().
+					ConsumeAuthorizationCode(mock.Anything, entity.HashCode("MOCK-CODE"), mock.Anything).
+					Return(nil, errors.ErrConflict)
+				code := newCode(time.Now().Add(time.Minute))
+				code.SessionID = "MOCK-SESSION-ID"
+				repo.EXPECT().
+					FindAuthorizationCode(mock.Anything, entity.HashCode("MOCK-CODE")).
+					Return(code, nil)
+				return repo
+			}(),
+			identityRepo: func() identityrepo.Repository {
+				// tokens issued from replayed code may be stolen
+				repo := identitymocks.NewRepository(t)
+				repo.EXPECT().
+					RevokeSessionFamily(mock.Anything, "MOCK-SESSION-ID").
+					Return(nil)
+				return repo
+			}(),
+			req: &service.TokenRequest{
+				GrantType:    entity.GrantTypeAuthorizationCode,
+				ClientID:     publicClient.ID,
+				Code:         "MOCK-CODE",
+				RedirectURI:  redirectURI,
+				CodeVerifier: codeVerifier,
+			},
+			err: entity.ErrInvalidGrant,
+		},
+		{
+			name: "Confidential Client Secret Mismatch",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, confidentialClient.ID).
+					Return(confidentialClient, nil)
+				return repo
+			}(),
+			identityRepo: identitymocks.NewRepository(t),
+			req: &service.TokenRequest{
+				GrantType:    entity.GrantTypeAuthorizationCode,
+				ClientID:     confidentialClient.ID,
+				ClientSecret: "WRONG-SECRET",
+				Code:         "MOCK-CODE",
+			},
+			err: entity.ErrInvalidClient,
+		},
+		{
+			name: "Client Credentials",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, serviceClient.ID).
+					Return(serviceClient, nil)
+				return repo
+			}(),
+			identityRepo: func() identityrepo.Repository {
+				repo := identitymocks.NewRepository(t)
+				repo.EXPECT().
+					StoreSession(mock.Anything, mock.Anything).
+					Return(nil)
+				return repo
+			}(),
+			req: &service.TokenRequest{
+				GrantType:    entity.GrantTypeClientCredentials,
+				ClientID:     serviceClient.ID,
+				ClientSecret: "MOCK-SECRET",
+				Scopes:       oidc.Scopes{"orders:read"},
+			},
+			check: func(t *testing.T, grant *entity.Grant) {
+				assert.Nil(t, grant.User)
+				assert.Equal(t, serviceClient.ID, grant.Session.UserID)
+				assert.Equal(t, serviceClient.ID, grant.Session.ClientID)
+				assert.Equal(t, "orders:read", grant.Session.Scope)
+				assert.False(t, grant.IssueRefreshToken)
+			},
+		},
+		{
+			name: "Client Credentials Scope Not Allowed",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, serviceClient.ID).
+					Return(serviceClient, nil)
+				return repo
+			}(),
+			identityRepo: identitymocks.NewRepository(t),
+			req: &service.TokenRequest{
+				GrantType:    entity.GrantTypeClientCredentials,
+				ClientID:     serviceClient.ID,
+				ClientSecret: "MOCK-SECRET",
+				Scopes:       oidc.Scopes{oidc.ScopeOpenID},
+			},
+			err: entity.ErrInvalidScope,
+		},
+		{
+			name: "Grant Type Not Registered",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, confidentialClient.ID).
+					Return(confidentialClient, nil)
+				return repo
+			}(),
+			identityRepo: identitymocks.NewRepository(t),
+			req: &service.TokenRequest{
+				GrantType:    entity.GrantTypeClientCredentials,
+				ClientID:     confidentialClient.ID,
+				ClientSecret: "MOCK-SECRET",
+			},
+			err: entity.ErrUnauthorizedClient,
+		},
+		{
+			name: "Unsupported Grant Type",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindClientByID(mock.Anything, publicClient.ID).
+					Return(publicClient, nil)
+				return repo
+			}(),
+			identityRepo: identitymocks.NewRepository(t),
+			req: &service.TokenRequest{
+				GrantType: "password",
+				ClientID:  publicClient.ID,
+			},
+			err: entity.ErrUnsupportedGrantType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo, tt.identityRepo, identitymocks.NewIdentityService(t), km, password.Default, nil, nil, nil)
+			actual, err := srv.Token(ctx, tt.req)
+			if tt.err != nil || err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Token() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+
+			tt.check(t, actual)
+		})
+	}
+}
+
+func TestImpl_CreateClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		repo       repository.Repository
+		opt        *service.ClientOption
+		withSecret bool
+		err        error
+	}{
+		{
+			name: "Confidential Client",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					StoreClient(mock.Anything, mock.Anything).
+					Return(nil)
+				return repo
+			}(),
+			opt: &service.ClientOption{
+				Name:       "Worker",
+				Type:       entity.ClientTypeConfidential,
+				Scopes:     oidc.Scopes{"orders:read"},
+				GrantTypes: []string{entity.GrantTypeClientCredentials},
+			},
+			withSecret: true,
+		},
+		{
+			name: "Public Client",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					StoreClient(mock.Anything, mock.Anything).
+					Return(nil)
+				return repo
+			}(),
+			opt: &service.ClientOption{
+				Name:         "SPA",
+				Type:         entity.ClientTypePublic,
+				RedirectURIs: []string{redirectURI},
+				Scopes:       oidc.Scopes{oidc.ScopeOpenID},
+				GrantTypes:   []string{entity.GrantTypeAuthorizationCode},
+			},
+			withSecret: false,
+		},
+		{
+			name: "Public Client Can Not Use Client Credentials",
+			repo: mocks.NewRepository(t),
+			opt: &service.ClientOption{
+				Name:       "SPA",
+				Type:       entity.ClientTypePublic,
+				GrantTypes: []string{entity.GrantTypeClientCredentials},
+			},
+			err: errors.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo, identitymocks.NewRepository(t), identitymocks.NewIdentityService(t), km, password.Default, nil, nil, nil)
+			client, secret, err := srv.CreateClient(ctx, tt.opt)
+			if tt.err != nil || err != nil {
+				if !errors.Is(tt.err, err) {
+					t.Errorf("CreateClient() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+
+			assert.NotEmpty(t, client.ID)
+			if tt.withSecret {
+				assert.NotEmpty(t, secret)
+				assert.True(t, client.VerifySecret(secret))
+			} else {
+				assert.Empty(t, secret)
+				assert.Empty(t, client.SecretHash)
+			}
+		})
+	}
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	ctx := audit.NewContext(context.Background(), audit.Client{IPAddress: "10.0.0.1"})
+	user, _ := identity.NewUser(
+		"MOCK-USER-ID",
+		"Username",
+		"A12345678",
+		identity.WithTenant("MOCK-TENANT-ID"),
+	)
+
+	failed := &service.TokenRequest{GrantType: entity.GrantTypeClientCredentials, ClientID: "MOCK-CLIENT-ID"}
+	granted := &service.TokenRequest{GrantType: entity.GrantTypeAuthorizationCode, ClientID: "MOCK-CLIENT-ID"}
+
+	next := mocks.NewOAuth2Service(t)
+	next.EXPECT().
+		Token(mock.Anything, failed).
+		Return(nil, errors.ErrUnauthorized)
+	next.EXPECT().
+		Token(mock.Anything, granted).
+		Return(&entity.Grant{Identity: &identity.Identity{User: user}, ClientID: "MOCK-CLIENT-ID"}, nil)
+	next.EXPECT().
+		DeleteClient(mock.Anything, "MOCK-CLIENT-ID").
+		Return(nil)
+	next.EXPECT().
+		ListClients(mock.Anything).
+		Return(nil, nil)
+
+	var events []*audit.Event
+	recorder := audit.RecorderFunc(func(ctx context.Context, event *audit.Event) error {
+		events = append(events, event)
+		return nil
+	})
+	srv := service.AuditMiddleware(recorder)(next)
+
+	_, err := srv.Token(ctx, failed)
+	assert.True(t, errors.Is(err, errors.ErrUnauthorized), err)
+	_, err = srv.Token(ctx, granted)
+	assert.NoError(t, err)
+
+	admin := authn.NewContext(ctx, &authn.Principal{UserID: "MOCK-ADMIN-ID"})
+	assert.NoError(t, srv.DeleteClient(admin, "MOCK-CLIENT-ID"))
+
+	// read only action is not recorded
+	_, err = srv.ListClients(admin)
+	assert.NoError(t, err)
+
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, audit.ActionTokenGrant, events[0].Action)
+		assert.Equal(t, "MOCK-CLIENT-ID", events[0].Actor)
+		assert.Equal(t, audit.OutcomeFailure, events[0].Outcome)
+		assert.Equal(t, "10.0.0.1", events[0].IPAddress)
+
+		assert.Equal(t, "MOCK-USER-ID", events[1].Actor)
+		assert.Equal(t, "MOCK-CLIENT-ID", events[1].Target)
+		assert.Equal(t, "MOCK-TENANT-ID", events[1].TenantID)
+		assert.Equal(t, audit.OutcomeSuccess, events[1].Outcome)
+
+		assert.Equal(t, audit.ActionDeleteClient, events[2].Action)
+		assert.Equal(t, "MOCK-ADMIN-ID", events[2].Actor)
+		assert.Equal(t, "MOCK-CLIENT-ID", events[2].Target)
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"github.com/karta0898098/iam/pkg/app/oauth2/entity"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+const (
+	// ConsentApprove user approve authorization request
+	ConsentApprove = "approve"
+	// ConsentDeny user deny authorization request
+	ConsentDeny = "deny"
+)
+
+// AuthorizeRequest define authorization request RFC 6749 section 4.1.1
+// with PKCE extension RFC 7636 section 4.3
+type AuthorizeRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scopes              oidc.Scopes
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	// Consent user decision, empty means reuse previous consent
+	Consent string
+}
+
+// TokenRequest define access token request RFC 6749 section 4.1.3 and 6
+type TokenRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+
+	// Code and CodeVerifier for authorization_code grant
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+
+	// RefreshToken for refresh_token grant
+	RefreshToken string
+
+	// Scopes requested by client_credentials grant, default is all allowed scopes
+	Scopes oidc.Scopes
+
+	// IPAddress where token requested
+	IPAddress string
+}
+
+// ClientOption define client registration
+type ClientOption struct {
+	Name         string
+	Type         entity.ClientType
+	RedirectURIs []string
+	Scopes       oidc.Scopes
+	GrantTypes   []string
+}
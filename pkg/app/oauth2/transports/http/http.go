@@ -0,0 +1,337 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/app/oauth2/endpoints"
+	"github.com/karta0898098/iam/pkg/app/oauth2/entity"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/clientip"
+	"github.com/karta0898098/iam/pkg/errors"
+	pkghttp "github.com/karta0898098/iam/pkg/http"
+)
+
+// MakeAuthorize make authorization endpoint
+func MakeAuthorize(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.AuthorizeEndpoint,
+		decodeHTTPAuthorizeRequest,
+		encodeHTTPRedirectResponse,
+		httptransport.ServerErrorEncoder(encodeHTTPError),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPAuthorizeRequest is a transport/http.DecodeRequestFunc that decodes
+// authorization request from the URL query. Primarily useful in a server.
+func decodeHTTPAuthorizeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := newAuthorizeRequest(r.URL.Query())
+	return &req, nil
+}
+
+// encodeHTTPRedirectResponse is a transport/http.EncodeResponseFunc that
+// redirect user agent to the location of response. Primarily useful in a server.
+func encodeHTTPRedirectResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(*endpoints.AuthorizeResponse)
+	w.Header().Set(echo.HeaderLocation, resp.RedirectTo)
+	w.WriteHeader(http.StatusFound)
+	return nil
+}
+
+// MakeConsent make endpoint signed in user submit authorization request
+func MakeConsent(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ConsentEndpoint,
+		decodeHTTPConsentRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(encodeHTTPError),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPConsentRequest is a transport/http.DecodeRequestFunc that decodes
+// consent request from the HTTP body.
+// login page may post the request as form value or JSON.
+func decodeHTTPConsentRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.ConsentRequest
+
+	if strings.HasPrefix(r.Header.Get(echo.HeaderContentType), echo.MIMEApplicationForm) {
+		err := r.ParseForm()
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not form")
+		}
+		req.AuthorizeRequest = newAuthorizeRequest(r.PostForm)
+		req.Consent = r.PostForm.Get("consent")
+	} else {
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+		}
+	}
+
+	return &req, nil
+}
+
+// MakeToken make token endpoint
+func MakeToken(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.TokenEndpoint,
+		decodeHTTPTokenRequest,
+		encodeHTTPTokenResponse,
+		httptransport.ServerErrorEncoder(encodeHTTPError),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPTokenRequest is a transport/http.DecodeRequestFunc that decodes
+// form-encoded token request, client credentials can be sent by
+// HTTP basic authentication or form value RFC 6749 section 2.3.1
+func decodeHTTPTokenRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	if !strings.HasPrefix(r.Header.Get(echo.HeaderContentType), echo.MIMEApplicationForm) {
+		return nil, errors.Wrap(
+			entity.ErrInvalidRequest.WithDescription("content type must be application/x-www-form-urlencoded"),
+			"input request is not form",
+		)
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		return nil, errors.Wrap(entity.ErrInvalidRequest, "input request is not form")
+	}
+
+	req := endpoints.TokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		ClientID:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scope:        r.PostForm.Get("scope"),
+		IPAddress:    remoteIP(r),
+	}
+
+	if username, password, ok := r.BasicAuth(); ok {
+		req.ClientID, err = url.QueryUnescape(username)
+		if err != nil {
+			return nil, errors.Wrap(entity.ErrInvalidClient, "client id is not url encoded")
+		}
+		req.ClientSecret, err = url.QueryUnescape(password)
+		if err != nil {
+			return nil, errors.Wrap(entity.ErrInvalidClient, "client secret is not url encoded")
+		}
+	}
+
+	return &req, nil
+}
+
+// encodeHTTPTokenResponse is a transport/http.EncodeResponseFunc that encodes
+// token response, tokens must not be cached RFC 6749 section 5.1
+func encodeHTTPTokenResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set(echo.HeaderCacheControl, "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	return encodeHTTPResponse(ctx, w, response)
+}
+
+// MakeCreateClient make create client endpoint
+func MakeCreateClient(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.CreateClientEndpoint,
+		decodeHTTPCreateClientRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPCreateClientRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPCreateClientRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.CreateClientRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, nil
+}
+
+// MakeGetClient make get client endpoint
+func MakeGetClient(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.GetClientEndpoint,
+		decodeHTTPGetClientRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPGetClientRequest is a transport/http.DecodeRequestFunc that decodes
+// client id from the URL path. Primarily useful in a server.
+func decodeHTTPGetClientRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.GetClientRequest{
+		ClientID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeListClients make list clients endpoint
+func MakeListClients(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListClientsEndpoint,
+		decodeHTTPListClientsRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListClientsRequest is a transport/http.DecodeRequestFunc that decodes
+// list clients request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPListClientsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListClientsRequest{}, nil
+}
+
+// MakeUpdateClient make update client endpoint
+func MakeUpdateClient(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.UpdateClientEndpoint,
+		decodeHTTPUpdateClientRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPUpdateClientRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPUpdateClientRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.UpdateClientRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.ClientID = pkghttp.PathParam(r, "id")
+	return &req, nil
+}
+
+// MakeResetClientSecret make reset client secret endpoint
+func MakeResetClientSecret(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ResetClientSecretEndpoint,
+		decodeHTTPResetClientSecretRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPResetClientSecretRequest is a transport/http.DecodeRequestFunc that decodes
+// client id from the URL path. Primarily useful in a server.
+func decodeHTTPResetClientSecretRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ResetClientSecretRequest{
+		ClientID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeDeleteClient make delete client endpoint
+func MakeDeleteClient(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DeleteClientEndpoint,
+		decodeHTTPDeleteClientRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPDeleteClientRequest is a transport/http.DecodeRequestFunc that decodes
+// client id from the URL path. Primarily useful in a server.
+func decodeHTTPDeleteClientRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.DeleteClientRequest{
+		ClientID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// newAuthorizeRequest read authorization request parameters
+func newAuthorizeRequest(values url.Values) endpoints.AuthorizeRequest {
+	return endpoints.AuthorizeRequest{
+		ResponseType:        values.Get("response_type"),
+		ClientID:            values.Get("client_id"),
+		RedirectURI:         values.Get("redirect_uri"),
+		Scope:               values.Get("scope"),
+		State:               values.Get("state"),
+		Nonce:               values.Get("nonce"),
+		CodeChallenge:       values.Get("code_challenge"),
+		CodeChallengeMethod: values.Get("code_challenge_method"),
+	}
+}
+
+// remoteIP read client ip address put by client ip middleware, which only trust
+// proxy header from configured proxies, connection is used when it is absent
+func remoteIP(r *http.Request) string {
+	if ip := clientip.FromContext(r.Context()); ip != "" {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// encodeHTTPResponse is a transport/http.EncodeResponseFunc that encodes
+// response as JSON into the HTTP response body. Primarily useful in a server.
+func encodeHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	if headers, ok := response.(httptransport.Headerer); ok {
+		for k, values := range headers.Headers() {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+	code := http.StatusOK
+	if sc, ok := response.(httptransport.StatusCoder); ok {
+		code = sc.StatusCode()
+	}
+	w.WriteHeader(code)
+	if code == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// encodeHTTPError encode OAuth 2.0 error response RFC 6749 section 5.2,
+// other error fallback to default error response
+func encodeHTTPError(ctx context.Context, err error, w http.ResponseWriter) {
+	oauthErr, ok := errors.Cause(err).(*entity.Error)
+	if !ok {
+		errors.ErrorResponse(ctx, err, w)
+		return
+	}
+
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	w.Header().Set(echo.HeaderCacheControl, "no-store")
+	if oauthErr.Status == http.StatusUnauthorized {
+		w.Header().Set(echo.HeaderWWWAuthenticate, "Basic")
+	}
+	w.WriteHeader(oauthErr.Status)
+	_ = json.NewEncoder(w).Encode(oauthErr)
+}
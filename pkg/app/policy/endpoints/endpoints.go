@@ -0,0 +1,186 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	identityendpoints "github.com/karta0898098/iam/pkg/app/identity/endpoints"
+	identitysvc "github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/app/policy/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/policy"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+)
+
+// Endpoints define policy-based authorization endpoints
+type Endpoints struct {
+	CreatePolicyEndpoint endpoint.Endpoint
+	GetPolicyEndpoint    endpoint.Endpoint
+	ListPoliciesEndpoint endpoint.Endpoint
+	UpdatePolicyEndpoint endpoint.Endpoint
+	DeletePolicyEndpoint endpoint.Endpoint
+
+	AttachPolicyEndpoint    endpoint.Endpoint
+	DetachPolicyEndpoint    endpoint.Endpoint
+	ListAttachmentsEndpoint endpoint.Endpoint
+
+	AuthorizeEndpoint endpoint.Endpoint
+}
+
+// New endpoints
+func New(
+	svc service.PolicyService,
+	identitySvc identitysvc.IdentityService,
+	permissions authn.PermissionChecker,
+	limiter *ratelimit.Limiter,
+) (ep Endpoints) {
+	authenticator := identityendpoints.NewAuthenticator(identitySvc)
+
+	createPolicyEndpoint := MakeCreatePolicyEndpoint(svc)
+	createPolicyEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("CreatePolicy"),
+		identityendpoints.RateLimitMiddleware(limiter, "CreatePolicy"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(createPolicyEndpoint)
+	ep.CreatePolicyEndpoint = createPolicyEndpoint
+
+	getPolicyEndpoint := MakeGetPolicyEndpoint(svc)
+	getPolicyEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("GetPolicy"),
+		identityendpoints.RateLimitMiddleware(limiter, "GetPolicy"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(getPolicyEndpoint)
+	ep.GetPolicyEndpoint = getPolicyEndpoint
+
+	listPoliciesEndpoint := MakeListPoliciesEndpoint(svc)
+	listPoliciesEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListPolicies"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListPolicies"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(listPoliciesEndpoint)
+	ep.ListPoliciesEndpoint = listPoliciesEndpoint
+
+	updatePolicyEndpoint := MakeUpdatePolicyEndpoint(svc)
+	updatePolicyEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("UpdatePolicy"),
+		identityendpoints.RateLimitMiddleware(limiter, "UpdatePolicy"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(updatePolicyEndpoint)
+	ep.UpdatePolicyEndpoint = updatePolicyEndpoint
+
+	deletePolicyEndpoint := MakeDeletePolicyEndpoint(svc)
+	deletePolicyEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("DeletePolicy"),
+		identityendpoints.RateLimitMiddleware(limiter, "DeletePolicy"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(deletePolicyEndpoint)
+	ep.DeletePolicyEndpoint = deletePolicyEndpoint
+
+	attachPolicyEndpoint := MakeAttachPolicyEndpoint(svc)
+	attachPolicyEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("AttachPolicy"),
+		identityendpoints.RateLimitMiddleware(limiter, "AttachPolicy"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(attachPolicyEndpoint)
+	ep.AttachPolicyEndpoint = attachPolicyEndpoint
+
+	detachPolicyEndpoint := MakeDetachPolicyEndpoint(svc)
+	detachPolicyEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("DetachPolicy"),
+		identityendpoints.RateLimitMiddleware(limiter, "DetachPolicy"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(detachPolicyEndpoint)
+	ep.DetachPolicyEndpoint = detachPolicyEndpoint
+
+	listAttachmentsEndpoint := MakeListAttachmentsEndpoint(svc)
+	listAttachmentsEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListAttachments"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListAttachments"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(listAttachmentsEndpoint)
+	ep.ListAttachmentsEndpoint = listAttachmentsEndpoint
+
+	// authorize is called by other services, they authenticate as client
+	// granted iam:authorize scope
+	authorizeEndpoint := MakeAuthorizeEndpoint(svc)
+	authorizeEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("Authorize"),
+		identityendpoints.RateLimitMiddleware(limiter, "Authorize"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewClientScopeMiddleware(oidc.ScopeAuthorize),
+	)(authorizeEndpoint)
+	ep.AuthorizeEndpoint = authorizeEndpoint
+
+	return ep
+}
+
+// AuthorizeRequest define authorize request
+type AuthorizeRequest struct {
+	UserID   string            `json:"user_id"`
+	Action   string            `json:"action"`
+	Resource string            `json:"resource"`
+	Context  map[string]string `json:"context"`
+}
+
+// MatchedStatement define statement decided the result
+type MatchedStatement struct {
+	PolicyID string `json:"policy_id"`
+	Sid      string `json:"sid"`
+	Index    int    `json:"index"`
+	Effect   string `json:"effect"`
+}
+
+// AuthorizeResponse define authorize response, effect is empty when
+// no statement matched and request is denied implicitly
+type AuthorizeResponse struct {
+	Allowed    bool                `json:"allowed"`
+	Effect     string              `json:"effect"`
+	Statements []*MatchedStatement `json:"statements"`
+}
+
+// newAuthorizeResponse convert decision to response
+func newAuthorizeResponse(decision *policy.Decision) *AuthorizeResponse {
+	resp := &AuthorizeResponse{
+		Allowed:    decision.Allowed,
+		Effect:     string(decision.Effect),
+		Statements: make([]*MatchedStatement, 0, len(decision.Statements)),
+	}
+	for _, statement := range decision.Statements {
+		resp.Statements = append(resp.Statements, &MatchedStatement{
+			PolicyID: statement.PolicyID,
+			Sid:      statement.Sid,
+			Index:    statement.Index,
+			Effect:   string(statement.Effect),
+		})
+	}
+	return resp
+}
+
+// MakeAuthorizeEndpoint make authorize endpoint
+func MakeAuthorizeEndpoint(svc service.PolicyService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*AuthorizeRequest)
+
+		decision, err := svc.Authorize(ctx, &service.AuthorizeOption{
+			UserID:   req.UserID,
+			Action:   req.Action,
+			Resource: req.Resource,
+			Context:  req.Context,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return newAuthorizeResponse(decision), nil
+	}
+}
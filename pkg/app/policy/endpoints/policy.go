@@ -0,0 +1,258 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/policy/entity"
+	"github.com/karta0898098/iam/pkg/app/policy/service"
+)
+
+// PolicyMetadata define policy name, description and JSON policy document
+type PolicyMetadata struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Document    json.RawMessage `json:"document"`
+}
+
+// PolicyResponse define policy
+type PolicyResponse struct {
+	PolicyMetadata
+
+	ID        string `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// newPolicyOption convert policy metadata to service option
+func newPolicyOption(metadata *PolicyMetadata) *service.PolicyOption {
+	return &service.PolicyOption{
+		Name:        metadata.Name,
+		Description: metadata.Description,
+		Document:    metadata.Document,
+	}
+}
+
+// newPolicyResponse convert policy to response
+func newPolicyResponse(p *entity.Policy) *PolicyResponse {
+	return &PolicyResponse{
+		PolicyMetadata: PolicyMetadata{
+			Name:        p.Name,
+			Description: p.Description,
+			Document:    json.RawMessage(p.Document.String()),
+		},
+		ID:        p.ID,
+		CreatedAt: p.CreatedAt.Unix(),
+		UpdatedAt: p.UpdatedAt.Unix(),
+	}
+}
+
+// ListPoliciesResponse define policies response
+type ListPoliciesResponse struct {
+	Policies []*PolicyResponse `json:"policies"`
+}
+
+// CreatePolicyRequest define create policy request
+type CreatePolicyRequest struct {
+	PolicyMetadata
+}
+
+// MakeCreatePolicyEndpoint make create policy endpoint
+func MakeCreatePolicyEndpoint(svc service.PolicyService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*CreatePolicyRequest)
+
+		p, err := svc.CreatePolicy(ctx, newPolicyOption(&req.PolicyMetadata))
+		if err != nil {
+			return nil, err
+		}
+
+		return newPolicyResponse(p), nil
+	}
+}
+
+// GetPolicyRequest define get policy request
+type GetPolicyRequest struct {
+	PolicyID string `json:"-"`
+}
+
+// MakeGetPolicyEndpoint make get policy endpoint
+func MakeGetPolicyEndpoint(svc service.PolicyService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*GetPolicyRequest)
+
+		p, err := svc.GetPolicy(ctx, req.PolicyID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newPolicyResponse(p), nil
+	}
+}
+
+// ListPoliciesRequest define list policies request
+type ListPoliciesRequest struct {
+}
+
+// MakeListPoliciesEndpoint make list policies endpoint
+func MakeListPoliciesEndpoint(svc service.PolicyService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		policies, err := svc.ListPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &ListPoliciesResponse{
+			Policies: make([]*PolicyResponse, 0, len(policies)),
+		}
+		for _, p := range policies {
+			resp.Policies = append(resp.Policies, newPolicyResponse(p))
+		}
+
+		return resp, nil
+	}
+}
+
+// UpdatePolicyRequest define update policy request
+type UpdatePolicyRequest struct {
+	PolicyMetadata
+
+	PolicyID string `json:"-"`
+}
+
+// MakeUpdatePolicyEndpoint make update policy endpoint
+func MakeUpdatePolicyEndpoint(svc service.PolicyService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UpdatePolicyRequest)
+
+		p, err := svc.UpdatePolicy(ctx, req.PolicyID, newPolicyOption(&req.PolicyMetadata))
+		if err != nil {
+			return nil, err
+		}
+
+		return newPolicyResponse(p), nil
+	}
+}
+
+// DeletePolicyRequest define delete policy request
+type DeletePolicyRequest struct {
+	PolicyID string `json:"-"`
+}
+
+// DeletePolicyResponse define delete policy response
+type DeletePolicyResponse struct {
+}
+
+// MakeDeletePolicyEndpoint make delete policy endpoint
+func MakeDeletePolicyEndpoint(svc service.PolicyService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DeletePolicyRequest)
+
+		err = svc.DeletePolicy(ctx, req.PolicyID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeletePolicyResponse{}, nil
+	}
+}
+
+// AttachmentResponse define principal policy attached to
+type AttachmentResponse struct {
+	PrincipalType string `json:"principal_type"`
+	PrincipalID   string `json:"principal_id"`
+	CreatedAt     int64  `json:"created_at"`
+}
+
+// ListAttachmentsResponse define attachments response
+type ListAttachmentsResponse struct {
+	Attachments []*AttachmentResponse `json:"attachments"`
+}
+
+// AttachPolicyRequest define attach policy request
+type AttachPolicyRequest struct {
+	PolicyID      string `json:"-"`
+	PrincipalType string `json:"principal_type"`
+	PrincipalID   string `json:"principal_id"`
+}
+
+// AttachPolicyResponse define attach policy response
+type AttachPolicyResponse struct {
+}
+
+// MakeAttachPolicyEndpoint make attach policy to user, group or role endpoint
+func MakeAttachPolicyEndpoint(svc service.PolicyService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*AttachPolicyRequest)
+
+		err = svc.AttachPolicy(ctx, req.PolicyID, entity.Principal{
+			Type: entity.PrincipalType(req.PrincipalType),
+			ID:   req.PrincipalID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &AttachPolicyResponse{}, nil
+	}
+}
+
+// DetachPolicyRequest define detach policy request
+type DetachPolicyRequest struct {
+	PolicyID      string `json:"-"`
+	PrincipalType string `json:"-"`
+	PrincipalID   string `json:"-"`
+}
+
+// DetachPolicyResponse define detach policy response
+type DetachPolicyResponse struct {
+}
+
+// MakeDetachPolicyEndpoint make detach policy from user, group or role endpoint
+func MakeDetachPolicyEndpoint(svc service.PolicyService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DetachPolicyRequest)
+
+		err = svc.DetachPolicy(ctx, req.PolicyID, entity.Principal{
+			Type: entity.PrincipalType(req.PrincipalType),
+			ID:   req.PrincipalID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &DetachPolicyResponse{}, nil
+	}
+}
+
+// ListAttachmentsRequest define list attachments request
+type ListAttachmentsRequest struct {
+	PolicyID string `json:"-"`
+}
+
+// MakeListAttachmentsEndpoint make list principals policy attached to endpoint
+func MakeListAttachmentsEndpoint(svc service.PolicyService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ListAttachmentsRequest)
+
+		attachments, err := svc.ListAttachments(ctx, req.PolicyID)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &ListAttachmentsResponse{
+			Attachments: make([]*AttachmentResponse, 0, len(attachments)),
+		}
+		for _, attachment := range attachments {
+			resp.Attachments = append(resp.Attachments, &AttachmentResponse{
+				PrincipalType: string(attachment.Principal.Type),
+				PrincipalID:   attachment.Principal.ID,
+				CreatedAt:     attachment.CreatedAt.Unix(),
+			})
+		}
+
+		return resp, nil
+	}
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/policy"
+)
+
+var (
+	nameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,127}$`)
+)
+
+// Policy define named policy document attached to principals
+type Policy struct {
+	// ID policy id
+	ID string
+	// Name unique policy name
+	Name string
+	// Description tell administrator what the policy is for
+	Description string
+	// Document statements of policy
+	Document *policy.Document
+	// CreatedAt this policy create time
+	CreatedAt time.Time
+	// UpdatedAt this policy update time
+	UpdatedAt time.Time
+}
+
+// NewPolicy new policy from parsed document
+func NewPolicy(id string, name string, description string, document *policy.Document) (*Policy, error) {
+	now := time.Now()
+
+	p := &Policy{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		Document:    document,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Validate check policy name and document
+func (p *Policy) Validate() error {
+	if !nameRegex.MatchString(p.Name) {
+		return errors.Wrapf(errors.ErrInvalidInput, "policy name=%v is invalid", p.Name)
+	}
+
+	if p.Document == nil {
+		return errors.Wrapf(errors.ErrInvalidInput, "policy document is required")
+	}
+
+	return p.Document.Validate()
+}
+
+// Update replace name, description and document of policy
+func (p *Policy) Update(name string, description string, document *policy.Document) error {
+	p.Name = name
+	p.Description = description
+	p.Document = document
+	p.UpdatedAt = time.Now()
+
+	return p.Validate()
+}
+
+// PrincipalType define kind of principal policy attach to
+type PrincipalType string
+
+const (
+	// PrincipalUser policy attach to user directly
+	PrincipalUser PrincipalType = "user"
+	// PrincipalGroup policy apply to every member of group
+	PrincipalGroup PrincipalType = "group"
+	// PrincipalRole policy apply to every user bound to role
+	PrincipalRole PrincipalType = "role"
+)
+
+// Validate check principal type is known
+func (t PrincipalType) Validate() error {
+	switch t {
+	case PrincipalUser, PrincipalGroup, PrincipalRole:
+		return nil
+	}
+	return errors.Wrapf(errors.ErrInvalidInput, "principal type=%v is invalid", t)
+}
+
+// Principal define user, group or role policy attach to
+type Principal struct {
+	Type PrincipalType
+	ID   string
+}
+
+// Attachment define policy attached to principal
+type Attachment struct {
+	PolicyID  string
+	Principal Principal
+	CreatedAt time.Time
+}
+
+// NewAttachment attach policy to principal
+func NewAttachment(policyID string, principal Principal) *Attachment {
+	return &Attachment{
+		PolicyID:  policyID,
+		Principal: principal,
+		CreatedAt: time.Now(),
+	}
+}
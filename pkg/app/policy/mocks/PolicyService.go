@@ -0,0 +1,503 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/policy/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	policy "github.com/karta0898098/iam/pkg/policy"
+
+	service "github.com/karta0898098/iam/pkg/app/policy/service"
+)
+
+// PolicyService is an autogenerated mock type for the PolicyService type
+type PolicyService struct {
+	mock.Mock
+}
+
+type PolicyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PolicyService) EXPECT() *PolicyService_Expecter {
+	return &PolicyService_Expecter{mock: &_m.Mock}
+}
+
+// AttachPolicy provides a mock function with given fields: ctx, policyID, principal
+func (_m *PolicyService) AttachPolicy(ctx context.Context, policyID string, principal entity.Principal) error {
+	ret := _m.Called(ctx, policyID, principal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Principal) error); ok {
+		r0 = rf(ctx, policyID, principal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PolicyService_AttachPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AttachPolicy'
+type PolicyService_AttachPolicy_Call struct {
+	*mock.Call
+}
+
+// AttachPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+//   - principal entity.Principal
+func (_e *PolicyService_Expecter) AttachPolicy(ctx interface{}, policyID interface{}, principal interface{}) *PolicyService_AttachPolicy_Call {
+	return &PolicyService_AttachPolicy_Call{Call: _e.mock.On("AttachPolicy", ctx, policyID, principal)}
+}
+
+func (_c *PolicyService_AttachPolicy_Call) Run(run func(ctx context.Context, policyID string, principal entity.Principal)) *PolicyService_AttachPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.Principal))
+	})
+	return _c
+}
+
+func (_c *PolicyService_AttachPolicy_Call) Return(err error) *PolicyService_AttachPolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *PolicyService_AttachPolicy_Call) RunAndReturn(run func(context.Context, string, entity.Principal) error) *PolicyService_AttachPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Authorize provides a mock function with given fields: ctx, opt
+func (_m *PolicyService) Authorize(ctx context.Context, opt *service.AuthorizeOption) (*policy.Decision, error) {
+	ret := _m.Called(ctx, opt)
+
+	var r0 *policy.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.AuthorizeOption) (*policy.Decision, error)); ok {
+		return rf(ctx, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.AuthorizeOption) *policy.Decision); ok {
+		r0 = rf(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*policy.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.AuthorizeOption) error); ok {
+		r1 = rf(ctx, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PolicyService_Authorize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authorize'
+type PolicyService_Authorize_Call struct {
+	*mock.Call
+}
+
+// Authorize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *service.AuthorizeOption
+func (_e *PolicyService_Expecter) Authorize(ctx interface{}, opt interface{}) *PolicyService_Authorize_Call {
+	return &PolicyService_Authorize_Call{Call: _e.mock.On("Authorize", ctx, opt)}
+}
+
+func (_c *PolicyService_Authorize_Call) Run(run func(ctx context.Context, opt *service.AuthorizeOption)) *PolicyService_Authorize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.AuthorizeOption))
+	})
+	return _c
+}
+
+func (_c *PolicyService_Authorize_Call) Return(decision *policy.Decision, err error) *PolicyService_Authorize_Call {
+	_c.Call.Return(decision, err)
+	return _c
+}
+
+func (_c *PolicyService_Authorize_Call) RunAndReturn(run func(context.Context, *service.AuthorizeOption) (*policy.Decision, error)) *PolicyService_Authorize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePolicy provides a mock function with given fields: ctx, opt
+func (_m *PolicyService) CreatePolicy(ctx context.Context, opt *service.PolicyOption) (*entity.Policy, error) {
+	ret := _m.Called(ctx, opt)
+
+	var r0 *entity.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.PolicyOption) (*entity.Policy, error)); ok {
+		return rf(ctx, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.PolicyOption) *entity.Policy); ok {
+		r0 = rf(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Policy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.PolicyOption) error); ok {
+		r1 = rf(ctx, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PolicyService_CreatePolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePolicy'
+type PolicyService_CreatePolicy_Call struct {
+	*mock.Call
+}
+
+// CreatePolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *service.PolicyOption
+func (_e *PolicyService_Expecter) CreatePolicy(ctx interface{}, opt interface{}) *PolicyService_CreatePolicy_Call {
+	return &PolicyService_CreatePolicy_Call{Call: _e.mock.On("CreatePolicy", ctx, opt)}
+}
+
+func (_c *PolicyService_CreatePolicy_Call) Run(run func(ctx context.Context, opt *service.PolicyOption)) *PolicyService_CreatePolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.PolicyOption))
+	})
+	return _c
+}
+
+func (_c *PolicyService_CreatePolicy_Call) Return(p *entity.Policy, err error) *PolicyService_CreatePolicy_Call {
+	_c.Call.Return(p, err)
+	return _c
+}
+
+func (_c *PolicyService_CreatePolicy_Call) RunAndReturn(run func(context.Context, *service.PolicyOption) (*entity.Policy, error)) *PolicyService_CreatePolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePolicy provides a mock function with given fields: ctx, policyID
+func (_m *PolicyService) DeletePolicy(ctx context.Context, policyID string) error {
+	ret := _m.Called(ctx, policyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, policyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PolicyService_DeletePolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePolicy'
+type PolicyService_DeletePolicy_Call struct {
+	*mock.Call
+}
+
+// DeletePolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+func (_e *PolicyService_Expecter) DeletePolicy(ctx interface{}, policyID interface{}) *PolicyService_DeletePolicy_Call {
+	return &PolicyService_DeletePolicy_Call{Call: _e.mock.On("DeletePolicy", ctx, policyID)}
+}
+
+func (_c *PolicyService_DeletePolicy_Call) Run(run func(ctx context.Context, policyID string)) *PolicyService_DeletePolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PolicyService_DeletePolicy_Call) Return(err error) *PolicyService_DeletePolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *PolicyService_DeletePolicy_Call) RunAndReturn(run func(context.Context, string) error) *PolicyService_DeletePolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DetachPolicy provides a mock function with given fields: ctx, policyID, principal
+func (_m *PolicyService) DetachPolicy(ctx context.Context, policyID string, principal entity.Principal) error {
+	ret := _m.Called(ctx, policyID, principal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Principal) error); ok {
+		r0 = rf(ctx, policyID, principal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PolicyService_DetachPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetachPolicy'
+type PolicyService_DetachPolicy_Call struct {
+	*mock.Call
+}
+
+// DetachPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+//   - principal entity.Principal
+func (_e *PolicyService_Expecter) DetachPolicy(ctx interface{}, policyID interface{}, principal interface{}) *PolicyService_DetachPolicy_Call {
+	return &PolicyService_DetachPolicy_Call{Call: _e.mock.On("DetachPolicy", ctx, policyID, principal)}
+}
+
+func (_c *PolicyService_DetachPolicy_Call) Run(run func(ctx context.Context, policyID string, principal entity.Principal)) *PolicyService_DetachPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.Principal))
+	})
+	return _c
+}
+
+func (_c *PolicyService_DetachPolicy_Call) Return(err error) *PolicyService_DetachPolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *PolicyService_DetachPolicy_Call) RunAndReturn(run func(context.Context, string, entity.Principal) error) *PolicyService_DetachPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPolicy provides a mock function with given fields: ctx, policyID
+func (_m *PolicyService) GetPolicy(ctx context.Context, policyID string) (*entity.Policy, error) {
+	ret := _m.Called(ctx, policyID)
+
+	var r0 *entity.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Policy, error)); ok {
+		return rf(ctx, policyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Policy); ok {
+		r0 = rf(ctx, policyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Policy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, policyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PolicyService_GetPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPolicy'
+type PolicyService_GetPolicy_Call struct {
+	*mock.Call
+}
+
+// GetPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+func (_e *PolicyService_Expecter) GetPolicy(ctx interface{}, policyID interface{}) *PolicyService_GetPolicy_Call {
+	return &PolicyService_GetPolicy_Call{Call: _e.mock.On("GetPolicy", ctx, policyID)}
+}
+
+func (_c *PolicyService_GetPolicy_Call) Run(run func(ctx context.Context, policyID string)) *PolicyService_GetPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PolicyService_GetPolicy_Call) Return(p *entity.Policy, err error) *PolicyService_GetPolicy_Call {
+	_c.Call.Return(p, err)
+	return _c
+}
+
+func (_c *PolicyService_GetPolicy_Call) RunAndReturn(run func(context.Context, string) (*entity.Policy, error)) *PolicyService_GetPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAttachments provides a mock function with given fields: ctx, policyID
+func (_m *PolicyService) ListAttachments(ctx context.Context, policyID string) ([]*entity.Attachment, error) {
+	ret := _m.Called(ctx, policyID)
+
+	var r0 []*entity.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Attachment, error)); ok {
+		return rf(ctx, policyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Attachment); ok {
+		r0 = rf(ctx, policyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, policyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PolicyService_ListAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAttachments'
+type PolicyService_ListAttachments_Call struct {
+	*mock.Call
+}
+
+// ListAttachments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+func (_e *PolicyService_Expecter) ListAttachments(ctx interface{}, policyID interface{}) *PolicyService_ListAttachments_Call {
+	return &PolicyService_ListAttachments_Call{Call: _e.mock.On("ListAttachments", ctx, policyID)}
+}
+
+func (_c *PolicyService_ListAttachments_Call) Run(run func(ctx context.Context, policyID string)) *PolicyService_ListAttachments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PolicyService_ListAttachments_Call) Return(attachments []*entity.Attachment, err error) *PolicyService_ListAttachments_Call {
+	_c.Call.Return(attachments, err)
+	return _c
+}
+
+func (_c *PolicyService_ListAttachments_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Attachment, error)) *PolicyService_ListAttachments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPolicies provides a mock function with given fields: ctx
+func (_m *PolicyService) ListPolicies(ctx context.Context) ([]*entity.Policy, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Policy, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Policy); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Policy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PolicyService_ListPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPolicies'
+type PolicyService_ListPolicies_Call struct {
+	*mock.Call
+}
+
+// ListPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *PolicyService_Expecter) ListPolicies(ctx interface{}) *PolicyService_ListPolicies_Call {
+	return &PolicyService_ListPolicies_Call{Call: _e.mock.On("ListPolicies", ctx)}
+}
+
+func (_c *PolicyService_ListPolicies_Call) Run(run func(ctx context.Context)) *PolicyService_ListPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PolicyService_ListPolicies_Call) Return(policies []*entity.Policy, err error) *PolicyService_ListPolicies_Call {
+	_c.Call.Return(policies, err)
+	return _c
+}
+
+func (_c *PolicyService_ListPolicies_Call) RunAndReturn(run func(context.Context) ([]*entity.Policy, error)) *PolicyService_ListPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePolicy provides a mock function with given fields: ctx, policyID, opt
+func (_m *PolicyService) UpdatePolicy(ctx context.Context, policyID string, opt *service.PolicyOption) (*entity.Policy, error) {
+	ret := _m.Called(ctx, policyID, opt)
+
+	var r0 *entity.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.PolicyOption) (*entity.Policy, error)); ok {
+		return rf(ctx, policyID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.PolicyOption) *entity.Policy); ok {
+		r0 = rf(ctx, policyID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Policy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *service.PolicyOption) error); ok {
+		r1 = rf(ctx, policyID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PolicyService_UpdatePolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePolicy'
+type PolicyService_UpdatePolicy_Call struct {
+	*mock.Call
+}
+
+// UpdatePolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+//   - opt *service.PolicyOption
+func (_e *PolicyService_Expecter) UpdatePolicy(ctx interface{}, policyID interface{}, opt interface{}) *PolicyService_UpdatePolicy_Call {
+	return &PolicyService_UpdatePolicy_Call{Call: _e.mock.On("UpdatePolicy", ctx, policyID, opt)}
+}
+
+func (_c *PolicyService_UpdatePolicy_Call) Run(run func(ctx context.Context, policyID string, opt *service.PolicyOption)) *PolicyService_UpdatePolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*service.PolicyOption))
+	})
+	return _c
+}
+
+func (_c *PolicyService_UpdatePolicy_Call) Return(p *entity.Policy, err error) *PolicyService_UpdatePolicy_Call {
+	_c.Call.Return(p, err)
+	return _c
+}
+
+func (_c *PolicyService_UpdatePolicy_Call) RunAndReturn(run func(context.Context, string, *service.PolicyOption) (*entity.Policy, error)) *PolicyService_UpdatePolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewPolicyService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPolicyService creates a new instance of PolicyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPolicyService(t mockConstructorTestingTNewPolicyService) *PolicyService {
+	mock := &PolicyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,528 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/policy/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteAttachment provides a mock function with given fields: ctx, policyID, principal
+func (_m *Repository) DeleteAttachment(ctx context.Context, policyID string, principal entity.Principal) error {
+	ret := _m.Called(ctx, policyID, principal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Principal) error); ok {
+		r0 = rf(ctx, policyID, principal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAttachment'
+type Repository_DeleteAttachment_Call struct {
+	*mock.Call
+}
+
+// DeleteAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+//   - principal entity.Principal
+func (_e *Repository_Expecter) DeleteAttachment(ctx interface{}, policyID interface{}, principal interface{}) *Repository_DeleteAttachment_Call {
+	return &Repository_DeleteAttachment_Call{Call: _e.mock.On("DeleteAttachment", ctx, policyID, principal)}
+}
+
+func (_c *Repository_DeleteAttachment_Call) Run(run func(ctx context.Context, policyID string, principal entity.Principal)) *Repository_DeleteAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.Principal))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteAttachment_Call) Return(err error) *Repository_DeleteAttachment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteAttachment_Call) RunAndReturn(run func(context.Context, string, entity.Principal) error) *Repository_DeleteAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePolicy provides a mock function with given fields: ctx, policyID
+func (_m *Repository) DeletePolicy(ctx context.Context, policyID string) error {
+	ret := _m.Called(ctx, policyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, policyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeletePolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePolicy'
+type Repository_DeletePolicy_Call struct {
+	*mock.Call
+}
+
+// DeletePolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+func (_e *Repository_Expecter) DeletePolicy(ctx interface{}, policyID interface{}) *Repository_DeletePolicy_Call {
+	return &Repository_DeletePolicy_Call{Call: _e.mock.On("DeletePolicy", ctx, policyID)}
+}
+
+func (_c *Repository_DeletePolicy_Call) Run(run func(ctx context.Context, policyID string)) *Repository_DeletePolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeletePolicy_Call) Return(err error) *Repository_DeletePolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeletePolicy_Call) RunAndReturn(run func(context.Context, string) error) *Repository_DeletePolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAttachedPolicies provides a mock function with given fields: ctx, principals
+func (_m *Repository) FindAttachedPolicies(ctx context.Context, principals []entity.Principal) ([]*entity.Policy, error) {
+	ret := _m.Called(ctx, principals)
+
+	var r0 []*entity.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Principal) ([]*entity.Policy, error)); ok {
+		return rf(ctx, principals)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Principal) []*entity.Policy); ok {
+		r0 = rf(ctx, principals)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Policy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.Principal) error); ok {
+		r1 = rf(ctx, principals)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindAttachedPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAttachedPolicies'
+type Repository_FindAttachedPolicies_Call struct {
+	*mock.Call
+}
+
+// FindAttachedPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principals []entity.Principal
+func (_e *Repository_Expecter) FindAttachedPolicies(ctx interface{}, principals interface{}) *Repository_FindAttachedPolicies_Call {
+	return &Repository_FindAttachedPolicies_Call{Call: _e.mock.On("FindAttachedPolicies", ctx, principals)}
+}
+
+func (_c *Repository_FindAttachedPolicies_Call) Run(run func(ctx context.Context, principals []entity.Principal)) *Repository_FindAttachedPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.Principal))
+	})
+	return _c
+}
+
+func (_c *Repository_FindAttachedPolicies_Call) Return(policies []*entity.Policy, err error) *Repository_FindAttachedPolicies_Call {
+	_c.Call.Return(policies, err)
+	return _c
+}
+
+func (_c *Repository_FindAttachedPolicies_Call) RunAndReturn(run func(context.Context, []entity.Principal) ([]*entity.Policy, error)) *Repository_FindAttachedPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPolicyByID provides a mock function with given fields: ctx, policyID
+func (_m *Repository) FindPolicyByID(ctx context.Context, policyID string) (*entity.Policy, error) {
+	ret := _m.Called(ctx, policyID)
+
+	var r0 *entity.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Policy, error)); ok {
+		return rf(ctx, policyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Policy); ok {
+		r0 = rf(ctx, policyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Policy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, policyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindPolicyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPolicyByID'
+type Repository_FindPolicyByID_Call struct {
+	*mock.Call
+}
+
+// FindPolicyByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+func (_e *Repository_Expecter) FindPolicyByID(ctx interface{}, policyID interface{}) *Repository_FindPolicyByID_Call {
+	return &Repository_FindPolicyByID_Call{Call: _e.mock.On("FindPolicyByID", ctx, policyID)}
+}
+
+func (_c *Repository_FindPolicyByID_Call) Run(run func(ctx context.Context, policyID string)) *Repository_FindPolicyByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindPolicyByID_Call) Return(p *entity.Policy, err error) *Repository_FindPolicyByID_Call {
+	_c.Call.Return(p, err)
+	return _c
+}
+
+func (_c *Repository_FindPolicyByID_Call) RunAndReturn(run func(context.Context, string) (*entity.Policy, error)) *Repository_FindPolicyByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPolicyByName provides a mock function with given fields: ctx, name
+func (_m *Repository) FindPolicyByName(ctx context.Context, name string) (*entity.Policy, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *entity.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Policy, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Policy); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Policy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindPolicyByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPolicyByName'
+type Repository_FindPolicyByName_Call struct {
+	*mock.Call
+}
+
+// FindPolicyByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *Repository_Expecter) FindPolicyByName(ctx interface{}, name interface{}) *Repository_FindPolicyByName_Call {
+	return &Repository_FindPolicyByName_Call{Call: _e.mock.On("FindPolicyByName", ctx, name)}
+}
+
+func (_c *Repository_FindPolicyByName_Call) Run(run func(ctx context.Context, name string)) *Repository_FindPolicyByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindPolicyByName_Call) Return(p *entity.Policy, err error) *Repository_FindPolicyByName_Call {
+	_c.Call.Return(p, err)
+	return _c
+}
+
+func (_c *Repository_FindPolicyByName_Call) RunAndReturn(run func(context.Context, string) (*entity.Policy, error)) *Repository_FindPolicyByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAttachments provides a mock function with given fields: ctx, policyID
+func (_m *Repository) ListAttachments(ctx context.Context, policyID string) ([]*entity.Attachment, error) {
+	ret := _m.Called(ctx, policyID)
+
+	var r0 []*entity.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Attachment, error)); ok {
+		return rf(ctx, policyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Attachment); ok {
+		r0 = rf(ctx, policyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, policyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAttachments'
+type Repository_ListAttachments_Call struct {
+	*mock.Call
+}
+
+// ListAttachments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+func (_e *Repository_Expecter) ListAttachments(ctx interface{}, policyID interface{}) *Repository_ListAttachments_Call {
+	return &Repository_ListAttachments_Call{Call: _e.mock.On("ListAttachments", ctx, policyID)}
+}
+
+func (_c *Repository_ListAttachments_Call) Run(run func(ctx context.Context, policyID string)) *Repository_ListAttachments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ListAttachments_Call) Return(attachments []*entity.Attachment, err error) *Repository_ListAttachments_Call {
+	_c.Call.Return(attachments, err)
+	return _c
+}
+
+func (_c *Repository_ListAttachments_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Attachment, error)) *Repository_ListAttachments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPolicies provides a mock function with given fields: ctx
+func (_m *Repository) ListPolicies(ctx context.Context) ([]*entity.Policy, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Policy, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Policy); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Policy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPolicies'
+type Repository_ListPolicies_Call struct {
+	*mock.Call
+}
+
+// ListPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListPolicies(ctx interface{}) *Repository_ListPolicies_Call {
+	return &Repository_ListPolicies_Call{Call: _e.mock.On("ListPolicies", ctx)}
+}
+
+func (_c *Repository_ListPolicies_Call) Run(run func(ctx context.Context)) *Repository_ListPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListPolicies_Call) Return(policies []*entity.Policy, err error) *Repository_ListPolicies_Call {
+	_c.Call.Return(policies, err)
+	return _c
+}
+
+func (_c *Repository_ListPolicies_Call) RunAndReturn(run func(context.Context) ([]*entity.Policy, error)) *Repository_ListPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreAttachment provides a mock function with given fields: ctx, attachment
+func (_m *Repository) StoreAttachment(ctx context.Context, attachment *entity.Attachment) error {
+	ret := _m.Called(ctx, attachment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Attachment) error); ok {
+		r0 = rf(ctx, attachment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreAttachment'
+type Repository_StoreAttachment_Call struct {
+	*mock.Call
+}
+
+// StoreAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - attachment *entity.Attachment
+func (_e *Repository_Expecter) StoreAttachment(ctx interface{}, attachment interface{}) *Repository_StoreAttachment_Call {
+	return &Repository_StoreAttachment_Call{Call: _e.mock.On("StoreAttachment", ctx, attachment)}
+}
+
+func (_c *Repository_StoreAttachment_Call) Run(run func(ctx context.Context, attachment *entity.Attachment)) *Repository_StoreAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Attachment))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreAttachment_Call) Return(err error) *Repository_StoreAttachment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreAttachment_Call) RunAndReturn(run func(context.Context, *entity.Attachment) error) *Repository_StoreAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StorePolicy provides a mock function with given fields: ctx, p
+func (_m *Repository) StorePolicy(ctx context.Context, p *entity.Policy) error {
+	ret := _m.Called(ctx, p)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Policy) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StorePolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StorePolicy'
+type Repository_StorePolicy_Call struct {
+	*mock.Call
+}
+
+// StorePolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *entity.Policy
+func (_e *Repository_Expecter) StorePolicy(ctx interface{}, p interface{}) *Repository_StorePolicy_Call {
+	return &Repository_StorePolicy_Call{Call: _e.mock.On("StorePolicy", ctx, p)}
+}
+
+func (_c *Repository_StorePolicy_Call) Run(run func(ctx context.Context, p *entity.Policy)) *Repository_StorePolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Policy))
+	})
+	return _c
+}
+
+func (_c *Repository_StorePolicy_Call) Return(err error) *Repository_StorePolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StorePolicy_Call) RunAndReturn(run func(context.Context, *entity.Policy) error) *Repository_StorePolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePolicy provides a mock function with given fields: ctx, p
+func (_m *Repository) UpdatePolicy(ctx context.Context, p *entity.Policy) error {
+	ret := _m.Called(ctx, p)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Policy) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdatePolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePolicy'
+type Repository_UpdatePolicy_Call struct {
+	*mock.Call
+}
+
+// UpdatePolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *entity.Policy
+func (_e *Repository_Expecter) UpdatePolicy(ctx interface{}, p interface{}) *Repository_UpdatePolicy_Call {
+	return &Repository_UpdatePolicy_Call{Call: _e.mock.On("UpdatePolicy", ctx, p)}
+}
+
+func (_c *Repository_UpdatePolicy_Call) Run(run func(ctx context.Context, p *entity.Policy)) *Repository_UpdatePolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Policy))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdatePolicy_Call) Return(err error) *Repository_UpdatePolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdatePolicy_Call) RunAndReturn(run func(context.Context, *entity.Policy) error) *Repository_UpdatePolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,15 @@
+package policy
+
+import (
+	"github.com/google/wire"
+
+	"github.com/karta0898098/iam/pkg/app/policy/endpoints"
+	"github.com/karta0898098/iam/pkg/app/policy/repository"
+	"github.com/karta0898098/iam/pkg/app/policy/service"
+)
+
+var DefaultProvider = wire.NewSet(
+	endpoints.New,
+	service.New,
+	repository.New,
+)
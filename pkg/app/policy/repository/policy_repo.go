@@ -0,0 +1,377 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/karta0898098/iam/pkg/app/policy/entity"
+	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/policy"
+)
+
+// PolicyDAO define policy dao
+type PolicyDAO struct {
+	ID          string `gorm:"column:id"`          // ID policy id
+	Name        string `gorm:"column:name"`        // Name unique policy name
+	Description string `gorm:"column:description"` // Description what the policy is for
+	Document    string `gorm:"column:document"`    // Document JSON policy document
+	CreatedAt   int64  `gorm:"column:created_at"`  // CreatedAt this policy create time
+	UpdatedAt   int64  `gorm:"column:updated_at"`  // UpdatedAt this policy update time
+}
+
+// TableName is PolicyDAO implement table name for gorm
+func (p PolicyDAO) TableName() string {
+	return "policies"
+}
+
+// UnmarshalPolicyDAO unmarshal entity policy to dao
+func UnmarshalPolicyDAO(p *entity.Policy) *PolicyDAO {
+	return &PolicyDAO{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Document:    p.Document.String(),
+		CreatedAt:   p.CreatedAt.UnixMilli(),
+		UpdatedAt:   p.UpdatedAt.UnixMilli(),
+	}
+}
+
+// UnmarshalPolicy unmarshal dao to entity policy
+func UnmarshalPolicy(dao *PolicyDAO) (*entity.Policy, error) {
+	document, err := policy.Parse([]byte(dao.Document))
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "stored document of policy id=%v is broken, err %v", dao.ID, err)
+	}
+
+	return &entity.Policy{
+		ID:          dao.ID,
+		Name:        dao.Name,
+		Description: dao.Description,
+		Document:    document,
+		CreatedAt:   time.UnixMilli(dao.CreatedAt),
+		UpdatedAt:   time.UnixMilli(dao.UpdatedAt),
+	}, nil
+}
+
+// AttachmentDAO define policy attachment dao
+type AttachmentDAO struct {
+	PolicyID      string `gorm:"column:policy_id"`      // PolicyID attached policy
+	PrincipalType string `gorm:"column:principal_type"` // PrincipalType user, group or role
+	PrincipalID   string `gorm:"column:principal_id"`   // PrincipalID id of principal
+	CreatedAt     int64  `gorm:"column:created_at"`     // CreatedAt this attachment create time
+}
+
+// TableName is AttachmentDAO implement table name for gorm
+func (a AttachmentDAO) TableName() string {
+	return "policy_attachments"
+}
+
+// UnmarshalAttachmentDAO unmarshal entity attachment to dao
+func UnmarshalAttachmentDAO(attachment *entity.Attachment) *AttachmentDAO {
+	return &AttachmentDAO{
+		PolicyID:      attachment.PolicyID,
+		PrincipalType: string(attachment.Principal.Type),
+		PrincipalID:   attachment.Principal.ID,
+		CreatedAt:     attachment.CreatedAt.UnixMilli(),
+	}
+}
+
+// UnmarshalAttachment unmarshal dao to entity attachment
+func UnmarshalAttachment(dao *AttachmentDAO) *entity.Attachment {
+	return &entity.Attachment{
+		PolicyID: dao.PolicyID,
+		Principal: entity.Principal{
+			Type: entity.PrincipalType(dao.PrincipalType),
+			ID:   dao.PrincipalID,
+		},
+		CreatedAt: time.UnixMilli(dao.CreatedAt),
+	}
+}
+
+// Repository define policy repository pattern
+type Repository interface {
+	// StorePolicy store policy
+	StorePolicy(ctx context.Context, p *entity.Policy) (err error)
+
+	// FindPolicyByID find policy by id
+	FindPolicyByID(ctx context.Context, policyID string) (p *entity.Policy, err error)
+
+	// FindPolicyByName find policy by unique name
+	FindPolicyByName(ctx context.Context, name string) (p *entity.Policy, err error)
+
+	// ListPolicies list all policies order by name
+	ListPolicies(ctx context.Context) (policies []*entity.Policy, err error)
+
+	// UpdatePolicy update name, description and document of policy
+	UpdatePolicy(ctx context.Context, p *entity.Policy) (err error)
+
+	// DeletePolicy delete policy and detach it from every principal
+	DeletePolicy(ctx context.Context, policyID string) (err error)
+
+	// StoreAttachment attach policy to principal, attach twice is no-op
+	StoreAttachment(ctx context.Context, attachment *entity.Attachment) (err error)
+
+	// DeleteAttachment detach policy from principal
+	DeleteAttachment(ctx context.Context, policyID string, principal entity.Principal) (err error)
+
+	// ListAttachments list principals policy attached to
+	ListAttachments(ctx context.Context, policyID string) (attachments []*entity.Attachment, err error)
+
+	// FindAttachedPolicies find distinct policies attached to any of principals
+	FindAttachedPolicies(ctx context.Context, principals []entity.Principal) (policies []*entity.Policy, err error)
+}
+
+// PolicyRepository implement for Repository
+type PolicyRepository struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+}
+
+// New Repository constructor
+func New(conn db.Connection) Repository {
+	return &PolicyRepository{
+		readDB:  conn.ReadDB(),
+		writeDB: conn.WriteDB(),
+	}
+}
+
+// StorePolicy is SQL implement
+func (repo *PolicyRepository) StorePolicy(ctx context.Context, p *entity.Policy) (err error) {
+	dao := UnmarshalPolicyDAO(p)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to create policy id=%v, err %v", p.ID, err)
+	}
+
+	return nil
+}
+
+// FindPolicyByID is SQL implement
+func (repo *PolicyRepository) FindPolicyByID(ctx context.Context, policyID string) (p *entity.Policy, err error) {
+	if policyID == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input policy id is empty")
+	}
+
+	return repo.findPolicy(ctx, "id = ?", policyID)
+}
+
+// FindPolicyByName is SQL implement
+func (repo *PolicyRepository) FindPolicyByName(ctx context.Context, name string) (p *entity.Policy, err error) {
+	if name == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input policy name is empty")
+	}
+
+	return repo.findPolicy(ctx, "name = ?", name)
+}
+
+// findPolicy find the only policy matched condition
+func (repo *PolicyRepository) findPolicy(ctx context.Context, query string, arg string) (*entity.Policy, error) {
+	var (
+		dao PolicyDAO
+	)
+
+	err := repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Where(query, arg).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found policy %v", arg)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalPolicy(&dao)
+}
+
+// ListPolicies is SQL implement
+func (repo *PolicyRepository) ListPolicies(ctx context.Context) (policies []*entity.Policy, err error) {
+	var (
+		daos []PolicyDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(PolicyDAO{}).
+		Order("name").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return unmarshalPolicies(daos)
+}
+
+// UpdatePolicy is SQL implement
+func (repo *PolicyRepository) UpdatePolicy(ctx context.Context, p *entity.Policy) (err error) {
+	dao := UnmarshalPolicyDAO(p)
+
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(PolicyDAO{}).
+		Where("id = ?", p.ID).
+		Updates(map[string]interface{}{
+			"name":        dao.Name,
+			"description": dao.Description,
+			"document":    dao.Document,
+			"updated_at":  dao.UpdatedAt,
+		})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update policy id=%v, err %v", p.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "cant not found policy id=%v", p.ID)
+	}
+
+	return nil
+}
+
+// DeletePolicy is SQL implement
+func (repo *PolicyRepository) DeletePolicy(ctx context.Context, policyID string) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Where("policy_id = ?", policyID).
+				Delete(&AttachmentDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to detach policy id=%v, err %v", policyID, err)
+			}
+
+			result := tx.
+				Where("id = ?", policyID).
+				Delete(&PolicyDAO{})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete policy id=%v, err %v", policyID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "cant not found policy id=%v", policyID)
+			}
+
+			return nil
+		})
+}
+
+// StoreAttachment is SQL implement
+func (repo *PolicyRepository) StoreAttachment(ctx context.Context, attachment *entity.Attachment) (err error) {
+	dao := UnmarshalAttachmentDAO(attachment)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to attach policy=%v to %v=%v, err %v", attachment.PolicyID, attachment.Principal.Type, attachment.Principal.ID, err)
+	}
+
+	return nil
+}
+
+// DeleteAttachment is SQL implement
+func (repo *PolicyRepository) DeleteAttachment(ctx context.Context, policyID string, principal entity.Principal) (err error) {
+	result := repo.writeDB.
+		WithContext(ctx).
+		Where("policy_id = ? AND principal_type = ? AND principal_id = ?", policyID, string(principal.Type), principal.ID).
+		Delete(&AttachmentDAO{})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to detach policy=%v from %v=%v, err %v", policyID, principal.Type, principal.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "policy=%v is not attached to %v=%v", policyID, principal.Type, principal.ID)
+	}
+
+	return nil
+}
+
+// ListAttachments is SQL implement
+func (repo *PolicyRepository) ListAttachments(ctx context.Context, policyID string) (attachments []*entity.Attachment, err error) {
+	var (
+		daos []AttachmentDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(AttachmentDAO{}).
+		Where("policy_id = ?", policyID).
+		Order("principal_type, principal_id").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	attachments = make([]*entity.Attachment, 0, len(daos))
+	for i := range daos {
+		attachments = append(attachments, UnmarshalAttachment(&daos[i]))
+	}
+
+	return attachments, nil
+}
+
+// FindAttachedPolicies is SQL implement
+func (repo *PolicyRepository) FindAttachedPolicies(ctx context.Context, principals []entity.Principal) (policies []*entity.Policy, err error) {
+	var (
+		daos []PolicyDAO
+	)
+
+	if len(principals) == 0 {
+		return []*entity.Policy{}, nil
+	}
+
+	ids := make(map[entity.PrincipalType][]string)
+	for _, principal := range principals {
+		ids[principal.Type] = append(ids[principal.Type], principal.ID)
+	}
+
+	attached := repo.readDB.
+		WithContext(ctx).
+		Model(AttachmentDAO{}).
+		Select("policy_id")
+	where := repo.readDB
+	for principalType, principalIDs := range ids {
+		where = where.Or("principal_type = ? AND principal_id IN ?", string(principalType), principalIDs)
+	}
+	attached = attached.Where(where)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(PolicyDAO{}).
+		Where("id IN (?)", attached).
+		Order("name").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return unmarshalPolicies(daos)
+}
+
+// unmarshalPolicies unmarshal daos to entity policies
+func unmarshalPolicies(daos []PolicyDAO) ([]*entity.Policy, error) {
+	policies := make([]*entity.Policy, 0, len(daos))
+	for i := range daos {
+		p, err := UnmarshalPolicy(&daos[i])
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
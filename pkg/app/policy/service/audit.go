@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/policy/entity"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/policy"
+)
+
+type auditMiddleware struct {
+	next     PolicyService
+	recorder audit.Recorder
+}
+
+// AuditMiddleware record action changing policy and its attachments to audit log,
+// read only action and authorization decision are not recorded
+func AuditMiddleware(recorder audit.Recorder) Middleware {
+	return func(next PolicyService) PolicyService {
+		return auditMiddleware{next: next, recorder: recorder}
+	}
+}
+
+// attachmentTarget target of attaching policy to principal
+func attachmentTarget(policyID string, principal entity.Principal) string {
+	return audit.TargetOf(policyID, string(principal.Type), principal.ID)
+}
+
+func (am auditMiddleware) CreatePolicy(ctx context.Context, opt *PolicyOption) (p *entity.Policy, err error) {
+	defer func() {
+		var policyID string
+		if p != nil {
+			policyID = p.ID
+		}
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionCreatePolicy, policyID, err))
+	}()
+
+	return am.next.CreatePolicy(ctx, opt)
+}
+
+func (am auditMiddleware) GetPolicy(ctx context.Context, policyID string) (p *entity.Policy, err error) {
+	return am.next.GetPolicy(ctx, policyID)
+}
+
+func (am auditMiddleware) ListPolicies(ctx context.Context) (policies []*entity.Policy, err error) {
+	return am.next.ListPolicies(ctx)
+}
+
+func (am auditMiddleware) UpdatePolicy(ctx context.Context, policyID string, opt *PolicyOption) (p *entity.Policy, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionUpdatePolicy, policyID, err))
+	}()
+
+	return am.next.UpdatePolicy(ctx, policyID, opt)
+}
+
+func (am auditMiddleware) DeletePolicy(ctx context.Context, policyID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionDeletePolicy, policyID, err))
+	}()
+
+	return am.next.DeletePolicy(ctx, policyID)
+}
+
+func (am auditMiddleware) AttachPolicy(ctx context.Context, policyID string, principal entity.Principal) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionAttachPolicy, attachmentTarget(policyID, principal), err))
+	}()
+
+	return am.next.AttachPolicy(ctx, policyID, principal)
+}
+
+func (am auditMiddleware) DetachPolicy(ctx context.Context, policyID string, principal entity.Principal) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionDetachPolicy, attachmentTarget(policyID, principal), err))
+	}()
+
+	return am.next.DetachPolicy(ctx, policyID, principal)
+}
+
+func (am auditMiddleware) ListAttachments(ctx context.Context, policyID string) (attachments []*entity.Attachment, err error) {
+	return am.next.ListAttachments(ctx, policyID)
+}
+
+func (am auditMiddleware) Authorize(ctx context.Context, opt *AuthorizeOption) (decision *policy.Decision, err error) {
+	return am.next.Authorize(ctx, opt)
+}
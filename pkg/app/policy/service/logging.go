@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/policy/entity"
+	"github.com/karta0898098/iam/pkg/policy"
+)
+
+type loggingMiddleware struct {
+	next PolicyService `json:"-"`
+}
+
+// LoggingMiddleware takes a logger as a dependency
+// and returns a ServiceMiddleware.
+func LoggingMiddleware() Middleware {
+	return func(next PolicyService) PolicyService {
+		return loggingMiddleware{next}
+	}
+}
+
+func (lm loggingMiddleware) CreatePolicy(ctx context.Context, opt *PolicyOption) (p *entity.Policy, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "CreatePolicy",
+		// 	"policy", opt.Name,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.CreatePolicy(ctx, opt)
+}
+
+func (lm loggingMiddleware) GetPolicy(ctx context.Context, policyID string) (p *entity.Policy, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "GetPolicy",
+		// 	"policy_id", policyID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.GetPolicy(ctx, policyID)
+}
+
+func (lm loggingMiddleware) ListPolicies(ctx context.Context) (policies []*entity.Policy, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListPolicies",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListPolicies(ctx)
+}
+
+func (lm loggingMiddleware) UpdatePolicy(ctx context.Context, policyID string, opt *PolicyOption) (p *entity.Policy, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "UpdatePolicy",
+		// 	"policy_id", policyID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.UpdatePolicy(ctx, policyID, opt)
+}
+
+func (lm loggingMiddleware) DeletePolicy(ctx context.Context, policyID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "DeletePolicy",
+		// 	"policy_id", policyID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.DeletePolicy(ctx, policyID)
+}
+
+func (lm loggingMiddleware) AttachPolicy(ctx context.Context, policyID string, principal entity.Principal) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "AttachPolicy",
+		// 	"policy_id", policyID,
+		// 	"principal_type", principal.Type,
+		// 	"principal_id", principal.ID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.AttachPolicy(ctx, policyID, principal)
+}
+
+func (lm loggingMiddleware) DetachPolicy(ctx context.Context, policyID string, principal entity.Principal) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "DetachPolicy",
+		// 	"policy_id", policyID,
+		// 	"principal_type", principal.Type,
+		// 	"principal_id", principal.ID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.DetachPolicy(ctx, policyID, principal)
+}
+
+func (lm loggingMiddleware) ListAttachments(ctx context.Context, policyID string) (attachments []*entity.Attachment, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListAttachments",
+		// 	"policy_id", policyID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListAttachments(ctx, policyID)
+}
+
+func (lm loggingMiddleware) Authorize(ctx context.Context, opt *AuthorizeOption) (decision *policy.Decision, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "Authorize",
+		// 	"user_id", opt.UserID,
+		// 	"action", opt.Action,
+		// 	"resource", opt.Resource,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.Authorize(ctx, opt)
+}
@@ -0,0 +1,18 @@
+package service
+
+// PolicyOption define policy name, description and raw JSON policy document
+type PolicyOption struct {
+	Name        string
+	Description string
+	Document    []byte
+}
+
+// AuthorizeOption define who request which action on which resource,
+// context carry resource attributes and iam:SourceIp of end user supplied by trusted client,
+// iam:UserId and iam:CurrentTime are always set by server from user id and clock
+type AuthorizeOption struct {
+	UserID   string
+	Action   string
+	Resource string
+	Context  map[string]string
+}
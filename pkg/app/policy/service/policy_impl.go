@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/xid"
+
+	identityrepo "github.com/karta0898098/iam/pkg/app/identity/repository"
+	"github.com/karta0898098/iam/pkg/app/policy/entity"
+	"github.com/karta0898098/iam/pkg/app/policy/repository"
+	rbacrepo "github.com/karta0898098/iam/pkg/app/rbac/repository"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/policy"
+)
+
+var _ PolicyService = &Impl{}
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(PolicyService) PolicyService
+
+// PolicyService define policy-based authorization
+type PolicyService interface {
+	// CreatePolicy create policy from JSON policy document
+	CreatePolicy(
+		ctx context.Context,
+		opt *PolicyOption,
+	) (p *entity.Policy, err error)
+
+	// GetPolicy get policy with its document
+	GetPolicy(
+		ctx context.Context,
+		policyID string,
+	) (p *entity.Policy, err error)
+
+	// ListPolicies list policies with their documents
+	ListPolicies(
+		ctx context.Context,
+	) (policies []*entity.Policy, err error)
+
+	// UpdatePolicy replace name, description and document of policy
+	UpdatePolicy(
+		ctx context.Context,
+		policyID string,
+		opt *PolicyOption,
+	) (p *entity.Policy, err error)
+
+	// DeletePolicy delete policy and detach it from every principal
+	DeletePolicy(
+		ctx context.Context,
+		policyID string,
+	) (err error)
+
+	// AttachPolicy attach policy to user, group or role
+	AttachPolicy(
+		ctx context.Context,
+		policyID string,
+		principal entity.Principal,
+	) (err error)
+
+	// DetachPolicy detach policy from user, group or role
+	DetachPolicy(
+		ctx context.Context,
+		policyID string,
+		principal entity.Principal,
+	) (err error)
+
+	// ListAttachments list principals policy attached to
+	ListAttachments(
+		ctx context.Context,
+		policyID string,
+	) (attachments []*entity.Attachment, err error)
+
+	// Authorize evaluate policies attached to user, groups of user
+	// and roles bound to user, explicit deny take precedence over allow
+	Authorize(
+		ctx context.Context,
+		opt *AuthorizeOption,
+	) (decision *policy.Decision, err error)
+}
+
+type Impl struct {
+	repo         repository.Repository
+	identityRepo identityrepo.Repository
+	rbacRepo     rbacrepo.Repository
+}
+
+func New(
+	repo repository.Repository,
+	identityRepo identityrepo.Repository,
+	rbacRepo rbacrepo.Repository,
+	recorder audit.Recorder,
+) PolicyService {
+	var svc PolicyService
+	svc = &Impl{
+		repo:         repo,
+		identityRepo: identityRepo,
+		rbacRepo:     rbacRepo,
+	}
+	svc = LoggingMiddleware()(svc)
+	if recorder != nil {
+		svc = AuditMiddleware(recorder)(svc)
+	}
+
+	return svc
+}
+
+func (srv *Impl) CreatePolicy(ctx context.Context, opt *PolicyOption) (p *entity.Policy, err error) {
+	document, err := policy.Parse(opt.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err = entity.NewPolicy(
+		xid.New().String(),
+		opt.Name,
+		opt.Description,
+		document,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.checkPolicyName(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.StorePolicy(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (srv *Impl) GetPolicy(ctx context.Context, policyID string) (p *entity.Policy, err error) {
+	return srv.repo.FindPolicyByID(ctx, policyID)
+}
+
+func (srv *Impl) ListPolicies(ctx context.Context) (policies []*entity.Policy, err error) {
+	return srv.repo.ListPolicies(ctx)
+}
+
+func (srv *Impl) UpdatePolicy(ctx context.Context, policyID string, opt *PolicyOption) (p *entity.Policy, err error) {
+	document, err := policy.Parse(opt.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err = srv.repo.FindPolicyByID(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Update(opt.Name, opt.Description, document)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.checkPolicyName(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.UpdatePolicy(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (srv *Impl) DeletePolicy(ctx context.Context, policyID string) (err error) {
+	return srv.repo.DeletePolicy(ctx, policyID)
+}
+
+// checkPolicyName policy name is unique
+func (srv *Impl) checkPolicyName(ctx context.Context, p *entity.Policy) error {
+	existing, err := srv.repo.FindPolicyByName(ctx, p.Name)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if existing.ID != p.ID {
+		return errors.Wrapf(errors.ErrConflict, "policy name=%v already used by policy=%v", p.Name, existing.ID)
+	}
+
+	return nil
+}
+
+func (srv *Impl) AttachPolicy(ctx context.Context, policyID string, principal entity.Principal) (err error) {
+	err = srv.checkPrincipal(ctx, principal)
+	if err != nil {
+		return err
+	}
+
+	_, err = srv.repo.FindPolicyByID(ctx, policyID)
+	if err != nil {
+		return err
+	}
+
+	return srv.repo.StoreAttachment(ctx, entity.NewAttachment(policyID, principal))
+}
+
+func (srv *Impl) DetachPolicy(ctx context.Context, policyID string, principal entity.Principal) (err error) {
+	err = principal.Type.Validate()
+	if err != nil {
+		return err
+	}
+
+	return srv.repo.DeleteAttachment(ctx, policyID, principal)
+}
+
+// checkPrincipal principal type is known and principal exist
+func (srv *Impl) checkPrincipal(ctx context.Context, principal entity.Principal) (err error) {
+	err = principal.Type.Validate()
+	if err != nil {
+		return err
+	}
+
+	switch principal.Type {
+	case entity.PrincipalUser:
+		_, err = srv.identityRepo.FindUserByID(ctx, principal.ID)
+	case entity.PrincipalGroup:
+		_, err = srv.rbacRepo.FindGroupByID(ctx, principal.ID)
+	case entity.PrincipalRole:
+		_, err = srv.rbacRepo.FindRoleByID(ctx, principal.ID)
+	}
+
+	return err
+}
+
+func (srv *Impl) ListAttachments(ctx context.Context, policyID string) (attachments []*entity.Attachment, err error) {
+	_, err = srv.repo.FindPolicyByID(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.repo.ListAttachments(ctx, policyID)
+}
+
+func (srv *Impl) Authorize(ctx context.Context, opt *AuthorizeOption) (decision *policy.Decision, err error) {
+	if opt.UserID == "" || opt.Action == "" || opt.Resource == "" {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "user id, action and resource are required")
+	}
+
+	principals, err := srv.principals(ctx, opt.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	attached, err := srv.repo.FindAttachedPolicies(ctx, principals)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]*policy.Policy, 0, len(attached))
+	for _, p := range attached {
+		policies = append(policies, &policy.Policy{
+			ID:       p.ID,
+			Document: p.Document,
+		})
+	}
+
+	// caller is client trusted by iam:authorize scope, so source ip of end user it supplies is kept,
+	// user id is always the one asked and current time is set by policy.Evaluate
+	reqContext := make(map[string]string, len(opt.Context)+1)
+	for key, value := range opt.Context {
+		reqContext[key] = value
+	}
+	reqContext[policy.KeyUserID] = opt.UserID
+
+	return policy.Evaluate(&policy.Request{
+		Action:   opt.Action,
+		Resource: opt.Resource,
+		Context:  reqContext,
+		Time:     time.Now(),
+	}, policies...), nil
+}
+
+// principals user itself, groups user belong to and roles bound to user
+func (srv *Impl) principals(ctx context.Context, userID string) ([]entity.Principal, error) {
+	groups, err := srv.rbacRepo.FindUserGroups(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := srv.rbacRepo.FindUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	principals := make([]entity.Principal, 0, 1+len(groups)+len(roles))
+	principals = append(principals, entity.Principal{Type: entity.PrincipalUser, ID: userID})
+	for _, group := range groups {
+		principals = append(principals, entity.Principal{Type: entity.PrincipalGroup, ID: group.ID})
+	}
+	for _, role := range roles {
+		principals = append(principals, entity.Principal{Type: entity.PrincipalRole, ID: role.ID})
+	}
+
+	return principals, nil
+}
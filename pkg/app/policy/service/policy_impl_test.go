@@ -0,0 +1,210 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	identitymocks "github.com/karta0898098/iam/pkg/app/identity/mocks"
+	"github.com/karta0898098/iam/pkg/app/policy/entity"
+	"github.com/karta0898098/iam/pkg/app/policy/mocks"
+	"github.com/karta0898098/iam/pkg/app/policy/repository"
+	"github.com/karta0898098/iam/pkg/app/policy/service"
+	rbacentity "github.com/karta0898098/iam/pkg/app/rbac/entity"
+	rbacmocks "github.com/karta0898098/iam/pkg/app/rbac/mocks"
+	"github.com/karta0898098/iam/pkg/clientip"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/policy"
+)
+
+func mustParse(t *testing.T, document string) *policy.Document {
+	d, err := policy.Parse([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestImpl_Authorize(t *testing.T) {
+	readDocuments := &entity.Policy{
+		ID: "MOCK-READ-ID",
+		Document: mustParse(t, `{"Version": "2023-06-01", "Statement": [
+			{"Sid": "Read", "Effect": "Allow", "Action": "documents:Get*", "Resource": "documents/*"}
+		]}`),
+	}
+	denySecret := &entity.Policy{
+		ID: "MOCK-DENY-ID",
+		Document: mustParse(t, `{"Version": "2023-06-01", "Statement": [
+			{"Sid": "DenySecret", "Effect": "Deny", "Action": "*", "Resource": "documents/secret-*"}
+		]}`),
+	}
+	ownDocuments := &entity.Policy{
+		ID: "MOCK-OWN-ID",
+		Document: mustParse(t, `{"Version": "2023-06-01", "Statement": [
+			{"Sid": "Own", "Effect": "Allow", "Action": "documents:Update", "Resource": "documents/*",
+			 "Condition": {"StringEquals": {"iam:UserId": "MOCK-USER-ID"}}}
+		]}`),
+	}
+
+	principals := []entity.Principal{
+		{Type: entity.PrincipalUser, ID: "MOCK-USER-ID"},
+		{Type: entity.PrincipalGroup, ID: "MOCK-GROUP-ID"},
+		{Type: entity.PrincipalRole, ID: "MOCK-ROLE-ID"},
+	}
+
+	tests := []struct {
+		name       string
+		repo       repository.Repository
+		opt        *service.AuthorizeOption
+		allowed    bool
+		effect     policy.Effect
+		statements []string
+		err        error
+	}{
+		{
+			name: "Allow By Group Policy",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindAttachedPolicies(mock.Anything, principals).
+					Return([]*entity.Policy{readDocuments, denySecret}, nil)
+				return repo
+			}(),
+			opt:        &service.AuthorizeOption{UserID: "MOCK-USER-ID", Action: "documents:GetDocument", Resource: "documents/1"},
+			allowed:    true,
+			effect:     policy.EffectAllow,
+			statements: []string{"MOCK-READ-ID/Read"},
+		},
+		{
+			name: "Explicit Deny By Role Policy",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindAttachedPolicies(mock.Anything, principals).
+					Return([]*entity.Policy{readDocuments, denySecret}, nil)
+				return repo
+			}(),
+			opt:        &service.AuthorizeOption{UserID: "MOCK-USER-ID", Action: "documents:GetDocument", Resource: "documents/secret-1"},
+			allowed:    false,
+			effect:     policy.EffectDeny,
+			statements: []string{"MOCK-DENY-ID/DenySecret"},
+		},
+		{
+			name: "User ID Not Spoofed By Context",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindAttachedPolicies(mock.Anything, principals).
+					Return([]*entity.Policy{ownDocuments}, nil)
+				return repo
+			}(),
+			opt: &service.AuthorizeOption{
+				UserID:   "MOCK-USER-ID",
+				Action:   "documents:Update",
+				Resource: "documents/1",
+				Context:  map[string]string{policy.KeyUserID: "MOCK-OTHER-ID"},
+			},
+			allowed:    true,
+			effect:     policy.EffectAllow,
+			statements: []string{"MOCK-OWN-ID/Own"},
+		},
+		{
+			name: "Implicit Deny",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindAttachedPolicies(mock.Anything, principals).
+					Return([]*entity.Policy{}, nil)
+				return repo
+			}(),
+			opt:     &service.AuthorizeOption{UserID: "MOCK-USER-ID", Action: "documents:GetDocument", Resource: "documents/1"},
+			allowed: false,
+		},
+		{
+			name: "Missing Action",
+			repo: mocks.NewRepository(t),
+			opt:  &service.AuthorizeOption{UserID: "MOCK-USER-ID", Resource: "documents/1"},
+			err:  errors.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			rbacRepo := rbacmocks.NewRepository(t)
+			if tt.err == nil {
+				rbacRepo.EXPECT().
+					FindUserGroups(mock.Anything, "MOCK-USER-ID").
+					Return([]*rbacentity.Group{{ID: "MOCK-GROUP-ID"}}, nil)
+				rbacRepo.EXPECT().
+					FindUserRoles(mock.Anything, "MOCK-USER-ID").
+					Return([]*rbacentity.Role{{ID: "MOCK-ROLE-ID"}}, nil)
+			}
+
+			srv := service.New(tt.repo, identitymocks.NewRepository(t), rbacRepo, nil)
+			actual, err := srv.Authorize(ctx, tt.opt)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.allowed, actual.Allowed)
+			assert.Equal(t, tt.effect, actual.Effect)
+
+			var statements []string
+			for _, statement := range actual.Statements {
+				statements = append(statements, statement.PolicyID+"/"+statement.Sid)
+			}
+			assert.Equal(t, tt.statements, statements)
+		})
+	}
+}
+
+func TestImpl_Authorize_SourceIP(t *testing.T) {
+	fromOffice := &entity.Policy{
+		ID: "MOCK-OFFICE-ID",
+		Document: mustParse(t, `{"Version": "2023-06-01", "Statement": [
+			{"Sid": "Office", "Effect": "Allow", "Action": "documents:Get*", "Resource": "documents/*",
+			 "Condition": {"IpAddress": {"iam:SourceIp": "10.0.0.0/8"}}}
+		]}`),
+	}
+
+	repo := mocks.NewRepository(t)
+	repo.EXPECT().
+		FindAttachedPolicies(mock.Anything, mock.Anything).
+		Return([]*entity.Policy{fromOffice}, nil)
+
+	rbacRepo := rbacmocks.NewRepository(t)
+	rbacRepo.EXPECT().
+		FindUserGroups(mock.Anything, "MOCK-USER-ID").
+		Return(nil, nil)
+	rbacRepo.EXPECT().
+		FindUserRoles(mock.Anything, "MOCK-USER-ID").
+		Return(nil, nil)
+
+	srv := service.New(repo, identitymocks.NewRepository(t), rbacRepo, nil)
+	opt := &service.AuthorizeOption{
+		UserID:   "MOCK-USER-ID",
+		Action:   "documents:GetDocument",
+		Resource: "documents/1",
+		Context:  map[string]string{policy.KeySourceIP: "10.1.2.3"},
+	}
+
+	// source ip of end user supplied by calling service is kept,
+	// ip address of calling service itself is not the user network
+	actual, err := srv.Authorize(clientip.NewContext(context.Background(), "203.0.113.1"), opt)
+	if assert.NoError(t, err) {
+		assert.True(t, actual.Allowed)
+	}
+
+	opt.Context = map[string]string{policy.KeySourceIP: "203.0.113.9"}
+	actual, err = srv.Authorize(clientip.NewContext(context.Background(), "10.9.8.7"), opt)
+	if assert.NoError(t, err) {
+		assert.False(t, actual.Allowed)
+	}
+}
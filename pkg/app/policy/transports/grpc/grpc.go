@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+
+	pb "github.com/karta0898098/iam/pb/policy"
+	"github.com/karta0898098/iam/pkg/app/policy/endpoints"
+	"github.com/karta0898098/iam/pkg/authn"
+)
+
+type grpcServer struct {
+	authorize grpctransport.Handler
+}
+
+func (g *grpcServer) Authorize(ctx context.Context, req *pb.AuthorizeReq) (*pb.AuthorizeResp, error) {
+	_, rp, err := g.authorize.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.AuthorizeResp)
+	return reply, nil
+}
+
+// MakeGRPCServer make policy grpc server
+func MakeGRPCServer(endpoints endpoints.Endpoints) (req pb.PolicyServiceServer) {
+	options := []grpctransport.ServerOption{
+		// bearer token of authorization metadata is authenticated by endpoint middleware
+		grpctransport.ServerBefore(authn.GRPCToContext()),
+	}
+
+	return &grpcServer{
+		authorize: grpctransport.NewServer(
+			endpoints.AuthorizeEndpoint,
+			decodeGRPCAuthorizeRequest,
+			encodeGRPCAuthorizeResponse,
+			options...,
+		),
+	}
+}
+
+// decodeGRPCAuthorizeRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.AuthorizeReq)
+
+	return &endpoints.AuthorizeRequest{
+		UserID:   req.UserID,
+		Action:   req.Action,
+		Resource: req.Resource,
+		Context:  req.Context,
+	}, nil
+}
+
+// encodeGRPCAuthorizeResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCAuthorizeResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.AuthorizeResponse)
+
+	statements := make([]*pb.MatchedStatement, 0, len(reply.Statements))
+	for _, statement := range reply.Statements {
+		statements = append(statements, &pb.MatchedStatement{
+			PolicyID: statement.PolicyID,
+			Sid:      statement.Sid,
+			Index:    int32(statement.Index),
+			Effect:   statement.Effect,
+		})
+	}
+
+	return &pb.AuthorizeResp{
+		Allowed:    reply.Allowed,
+		Effect:     reply.Effect,
+		Statements: statements,
+	}, nil
+}
@@ -0,0 +1,209 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/app/policy/endpoints"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	pkghttp "github.com/karta0898098/iam/pkg/http"
+)
+
+// MakeCreatePolicy make create policy endpoint
+func MakeCreatePolicy(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.CreatePolicyEndpoint,
+		decodeHTTPCreatePolicyRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPCreatePolicyRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPCreatePolicyRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.CreatePolicyRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, nil
+}
+
+// MakeGetPolicy make get policy endpoint
+func MakeGetPolicy(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.GetPolicyEndpoint,
+		decodeHTTPGetPolicyRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPGetPolicyRequest is a transport/http.DecodeRequestFunc that decodes
+// policy id from the URL path. Primarily useful in a server.
+func decodeHTTPGetPolicyRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.GetPolicyRequest{
+		PolicyID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeListPolicies make list policies endpoint
+func MakeListPolicies(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListPoliciesEndpoint,
+		decodeHTTPListPoliciesRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListPoliciesRequest is a transport/http.DecodeRequestFunc that decodes
+// list request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPListPoliciesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListPoliciesRequest{}, nil
+}
+
+// MakeUpdatePolicy make update policy endpoint
+func MakeUpdatePolicy(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.UpdatePolicyEndpoint,
+		decodeHTTPUpdatePolicyRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPUpdatePolicyRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPUpdatePolicyRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.UpdatePolicyRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.PolicyID = pkghttp.PathParam(r, "id")
+	return &req, nil
+}
+
+// MakeDeletePolicy make delete policy endpoint
+func MakeDeletePolicy(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DeletePolicyEndpoint,
+		decodeHTTPDeletePolicyRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPDeletePolicyRequest is a transport/http.DecodeRequestFunc that decodes
+// policy id from the URL path. Primarily useful in a server.
+func decodeHTTPDeletePolicyRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.DeletePolicyRequest{
+		PolicyID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeAttachPolicy make attach policy to user, group or role endpoint
+func MakeAttachPolicy(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.AttachPolicyEndpoint,
+		decodeHTTPAttachPolicyRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPAttachPolicyRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPAttachPolicyRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.AttachPolicyRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.PolicyID = pkghttp.PathParam(r, "id")
+	return &req, nil
+}
+
+// MakeDetachPolicy make detach policy from user, group or role endpoint
+func MakeDetachPolicy(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DetachPolicyEndpoint,
+		decodeHTTPDetachPolicyRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPDetachPolicyRequest is a transport/http.DecodeRequestFunc that decodes
+// policy id and principal from the URL path. Primarily useful in a server.
+func decodeHTTPDetachPolicyRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.DetachPolicyRequest{
+		PolicyID:      pkghttp.PathParam(r, "id"),
+		PrincipalType: pkghttp.PathParam(r, "principal_type"),
+		PrincipalID:   pkghttp.PathParam(r, "principal_id"),
+	}, nil
+}
+
+// MakeListAttachments make list principals policy attached to endpoint
+func MakeListAttachments(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListAttachmentsEndpoint,
+		decodeHTTPListAttachmentsRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListAttachmentsRequest is a transport/http.DecodeRequestFunc that decodes
+// policy id from the URL path. Primarily useful in a server.
+func decodeHTTPListAttachmentsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListAttachmentsRequest{
+		PolicyID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// encodeHTTPResponse is a transport/http.EncodeResponseFunc that encodes
+// response as JSON into the HTTP response body. Primarily useful in a server.
+func encodeHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	if headers, ok := response.(httptransport.Headerer); ok {
+		for k, values := range headers.Headers() {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+	code := http.StatusOK
+	if sc, ok := response.(httptransport.StatusCoder); ok {
+		code = sc.StatusCode()
+	}
+	w.WriteHeader(code)
+	if code == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,272 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	identityendpoints "github.com/karta0898098/iam/pkg/app/identity/endpoints"
+	identitysvc "github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/app/rbac/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+)
+
+// Endpoints define role-based access control endpoints
+type Endpoints struct {
+	CreatePermissionEndpoint endpoint.Endpoint
+	ListPermissionsEndpoint  endpoint.Endpoint
+	UpdatePermissionEndpoint endpoint.Endpoint
+	DeletePermissionEndpoint endpoint.Endpoint
+
+	CreateRoleEndpoint endpoint.Endpoint
+	GetRoleEndpoint    endpoint.Endpoint
+	ListRolesEndpoint  endpoint.Endpoint
+	UpdateRoleEndpoint endpoint.Endpoint
+	DeleteRoleEndpoint endpoint.Endpoint
+
+	AssignRoleEndpoint    endpoint.Endpoint
+	UnassignRoleEndpoint  endpoint.Endpoint
+	ListUserRolesEndpoint endpoint.Endpoint
+
+	CreateGroupEndpoint       endpoint.Endpoint
+	GetGroupEndpoint          endpoint.Endpoint
+	ListGroupsEndpoint        endpoint.Endpoint
+	UpdateGroupEndpoint       endpoint.Endpoint
+	DeleteGroupEndpoint       endpoint.Endpoint
+	AddGroupMemberEndpoint    endpoint.Endpoint
+	RemoveGroupMemberEndpoint endpoint.Endpoint
+	ListUserGroupsEndpoint    endpoint.Endpoint
+
+	CheckPermissionEndpoint endpoint.Endpoint
+}
+
+// New endpoints
+func New(
+	svc service.RBACService,
+	identitySvc identitysvc.IdentityService,
+	limiter *ratelimit.Limiter,
+) (ep Endpoints) {
+	authenticator := identityendpoints.NewAuthenticator(identitySvc)
+
+	createPermissionEndpoint := MakeCreatePermissionEndpoint(svc)
+	createPermissionEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("CreatePermission"),
+		identityendpoints.RateLimitMiddleware(limiter, "CreatePermission"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(createPermissionEndpoint)
+	ep.CreatePermissionEndpoint = createPermissionEndpoint
+
+	listPermissionsEndpoint := MakeListPermissionsEndpoint(svc)
+	listPermissionsEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListPermissions"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListPermissions"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(listPermissionsEndpoint)
+	ep.ListPermissionsEndpoint = listPermissionsEndpoint
+
+	updatePermissionEndpoint := MakeUpdatePermissionEndpoint(svc)
+	updatePermissionEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("UpdatePermission"),
+		identityendpoints.RateLimitMiddleware(limiter, "UpdatePermission"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(updatePermissionEndpoint)
+	ep.UpdatePermissionEndpoint = updatePermissionEndpoint
+
+	deletePermissionEndpoint := MakeDeletePermissionEndpoint(svc)
+	deletePermissionEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("DeletePermission"),
+		identityendpoints.RateLimitMiddleware(limiter, "DeletePermission"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(deletePermissionEndpoint)
+	ep.DeletePermissionEndpoint = deletePermissionEndpoint
+
+	createRoleEndpoint := MakeCreateRoleEndpoint(svc)
+	createRoleEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("CreateRole"),
+		identityendpoints.RateLimitMiddleware(limiter, "CreateRole"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(createRoleEndpoint)
+	ep.CreateRoleEndpoint = createRoleEndpoint
+
+	getRoleEndpoint := MakeGetRoleEndpoint(svc)
+	getRoleEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("GetRole"),
+		identityendpoints.RateLimitMiddleware(limiter, "GetRole"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(getRoleEndpoint)
+	ep.GetRoleEndpoint = getRoleEndpoint
+
+	listRolesEndpoint := MakeListRolesEndpoint(svc)
+	listRolesEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListRoles"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListRoles"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(listRolesEndpoint)
+	ep.ListRolesEndpoint = listRolesEndpoint
+
+	updateRoleEndpoint := MakeUpdateRoleEndpoint(svc)
+	updateRoleEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("UpdateRole"),
+		identityendpoints.RateLimitMiddleware(limiter, "UpdateRole"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(updateRoleEndpoint)
+	ep.UpdateRoleEndpoint = updateRoleEndpoint
+
+	deleteRoleEndpoint := MakeDeleteRoleEndpoint(svc)
+	deleteRoleEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("DeleteRole"),
+		identityendpoints.RateLimitMiddleware(limiter, "DeleteRole"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(deleteRoleEndpoint)
+	ep.DeleteRoleEndpoint = deleteRoleEndpoint
+
+	assignRoleEndpoint := MakeAssignRoleEndpoint(svc)
+	assignRoleEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("AssignRole"),
+		identityendpoints.RateLimitMiddleware(limiter, "AssignRole"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(assignRoleEndpoint)
+	ep.AssignRoleEndpoint = assignRoleEndpoint
+
+	unassignRoleEndpoint := MakeUnassignRoleEndpoint(svc)
+	unassignRoleEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("UnassignRole"),
+		identityendpoints.RateLimitMiddleware(limiter, "UnassignRole"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(unassignRoleEndpoint)
+	ep.UnassignRoleEndpoint = unassignRoleEndpoint
+
+	listUserRolesEndpoint := MakeListUserRolesEndpoint(svc)
+	listUserRolesEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListUserRoles"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListUserRoles"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(listUserRolesEndpoint)
+	ep.ListUserRolesEndpoint = listUserRolesEndpoint
+
+	createGroupEndpoint := MakeCreateGroupEndpoint(svc)
+	createGroupEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("CreateGroup"),
+		identityendpoints.RateLimitMiddleware(limiter, "CreateGroup"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(createGroupEndpoint)
+	ep.CreateGroupEndpoint = createGroupEndpoint
+
+	getGroupEndpoint := MakeGetGroupEndpoint(svc)
+	getGroupEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("GetGroup"),
+		identityendpoints.RateLimitMiddleware(limiter, "GetGroup"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(getGroupEndpoint)
+	ep.GetGroupEndpoint = getGroupEndpoint
+
+	listGroupsEndpoint := MakeListGroupsEndpoint(svc)
+	listGroupsEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListGroups"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListGroups"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(listGroupsEndpoint)
+	ep.ListGroupsEndpoint = listGroupsEndpoint
+
+	updateGroupEndpoint := MakeUpdateGroupEndpoint(svc)
+	updateGroupEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("UpdateGroup"),
+		identityendpoints.RateLimitMiddleware(limiter, "UpdateGroup"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(updateGroupEndpoint)
+	ep.UpdateGroupEndpoint = updateGroupEndpoint
+
+	deleteGroupEndpoint := MakeDeleteGroupEndpoint(svc)
+	deleteGroupEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("DeleteGroup"),
+		identityendpoints.RateLimitMiddleware(limiter, "DeleteGroup"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(deleteGroupEndpoint)
+	ep.DeleteGroupEndpoint = deleteGroupEndpoint
+
+	addGroupMemberEndpoint := MakeAddGroupMemberEndpoint(svc)
+	addGroupMemberEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("AddGroupMember"),
+		identityendpoints.RateLimitMiddleware(limiter, "AddGroupMember"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(addGroupMemberEndpoint)
+	ep.AddGroupMemberEndpoint = addGroupMemberEndpoint
+
+	removeGroupMemberEndpoint := MakeRemoveGroupMemberEndpoint(svc)
+	removeGroupMemberEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("RemoveGroupMember"),
+		identityendpoints.RateLimitMiddleware(limiter, "RemoveGroupMember"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(removeGroupMemberEndpoint)
+	ep.RemoveGroupMemberEndpoint = removeGroupMemberEndpoint
+
+	listUserGroupsEndpoint := MakeListUserGroupsEndpoint(svc)
+	listUserGroupsEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListUserGroups"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListUserGroups"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(svc),
+	)(listUserGroupsEndpoint)
+	ep.ListUserGroupsEndpoint = listUserGroupsEndpoint
+
+	// check permission is called by other services, they authenticate as client
+	// granted iam:check_permission scope
+	checkPermissionEndpoint := MakeCheckPermissionEndpoint(svc)
+	checkPermissionEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("CheckPermission"),
+		identityendpoints.RateLimitMiddleware(limiter, "CheckPermission"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewClientScopeMiddleware(oidc.ScopeCheckPermission),
+	)(checkPermissionEndpoint)
+	ep.CheckPermissionEndpoint = checkPermissionEndpoint
+
+	return ep
+}
+
+// CheckPermissionRequest define check permission request
+type CheckPermissionRequest struct {
+	UserID     string `json:"user_id"`
+	Permission string `json:"permission"`
+}
+
+// CheckPermissionResponse define check permission response
+type CheckPermissionResponse struct {
+	Allowed bool `json:"allowed"`
+}
+
+// MakeCheckPermissionEndpoint make check permission endpoint
+func MakeCheckPermissionEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*CheckPermissionRequest)
+
+		allowed, err := svc.CheckPermission(ctx, req.UserID, req.Permission)
+		if err != nil {
+			return nil, err
+		}
+
+		return &CheckPermissionResponse{
+			Allowed: allowed,
+		}, nil
+	}
+}
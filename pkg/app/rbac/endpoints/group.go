@@ -0,0 +1,227 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/rbac/entity"
+	"github.com/karta0898098/iam/pkg/app/rbac/service"
+)
+
+// GroupMetadata define group name and description
+type GroupMetadata struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GroupResponse define group
+type GroupResponse struct {
+	GroupMetadata
+
+	ID        string `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// newGroupOption convert group metadata to service option
+func newGroupOption(metadata *GroupMetadata) *service.GroupOption {
+	return &service.GroupOption{
+		Name:        metadata.Name,
+		Description: metadata.Description,
+	}
+}
+
+// newGroupResponse convert group to response
+func newGroupResponse(group *entity.Group) *GroupResponse {
+	return &GroupResponse{
+		GroupMetadata: GroupMetadata{
+			Name:        group.Name,
+			Description: group.Description,
+		},
+		ID:        group.ID,
+		CreatedAt: group.CreatedAt.Unix(),
+		UpdatedAt: group.UpdatedAt.Unix(),
+	}
+}
+
+// ListGroupsResponse define groups response
+type ListGroupsResponse struct {
+	Groups []*GroupResponse `json:"groups"`
+}
+
+// newListGroupsResponse convert groups to response
+func newListGroupsResponse(groups []*entity.Group) *ListGroupsResponse {
+	resp := &ListGroupsResponse{
+		Groups: make([]*GroupResponse, 0, len(groups)),
+	}
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, newGroupResponse(group))
+	}
+	return resp
+}
+
+// CreateGroupRequest define create group request
+type CreateGroupRequest struct {
+	GroupMetadata
+}
+
+// MakeCreateGroupEndpoint make create group endpoint
+func MakeCreateGroupEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*CreateGroupRequest)
+
+		group, err := svc.CreateGroup(ctx, newGroupOption(&req.GroupMetadata))
+		if err != nil {
+			return nil, err
+		}
+
+		return newGroupResponse(group), nil
+	}
+}
+
+// GetGroupRequest define get group request
+type GetGroupRequest struct {
+	GroupID string `json:"-"`
+}
+
+// MakeGetGroupEndpoint make get group endpoint
+func MakeGetGroupEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*GetGroupRequest)
+
+		group, err := svc.GetGroup(ctx, req.GroupID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newGroupResponse(group), nil
+	}
+}
+
+// ListGroupsRequest define list groups request
+type ListGroupsRequest struct {
+}
+
+// MakeListGroupsEndpoint make list groups endpoint
+func MakeListGroupsEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		groups, err := svc.ListGroups(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return newListGroupsResponse(groups), nil
+	}
+}
+
+// UpdateGroupRequest define update group request
+type UpdateGroupRequest struct {
+	GroupMetadata
+
+	GroupID string `json:"-"`
+}
+
+// MakeUpdateGroupEndpoint make update group endpoint
+func MakeUpdateGroupEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UpdateGroupRequest)
+
+		group, err := svc.UpdateGroup(ctx, req.GroupID, newGroupOption(&req.GroupMetadata))
+		if err != nil {
+			return nil, err
+		}
+
+		return newGroupResponse(group), nil
+	}
+}
+
+// DeleteGroupRequest define delete group request
+type DeleteGroupRequest struct {
+	GroupID string `json:"-"`
+}
+
+// DeleteGroupResponse define delete group response
+type DeleteGroupResponse struct {
+}
+
+// MakeDeleteGroupEndpoint make delete group endpoint
+func MakeDeleteGroupEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DeleteGroupRequest)
+
+		err = svc.DeleteGroup(ctx, req.GroupID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeleteGroupResponse{}, nil
+	}
+}
+
+// AddGroupMemberRequest define add user into group request
+type AddGroupMemberRequest struct {
+	GroupID string `json:"-"`
+	UserID  string `json:"user_id"`
+}
+
+// AddGroupMemberResponse define add user into group response
+type AddGroupMemberResponse struct {
+}
+
+// MakeAddGroupMemberEndpoint make add user into group endpoint
+func MakeAddGroupMemberEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*AddGroupMemberRequest)
+
+		err = svc.AddGroupMember(ctx, req.GroupID, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &AddGroupMemberResponse{}, nil
+	}
+}
+
+// RemoveGroupMemberRequest define remove user from group request
+type RemoveGroupMemberRequest struct {
+	GroupID string `json:"-"`
+	UserID  string `json:"-"`
+}
+
+// RemoveGroupMemberResponse define remove user from group response
+type RemoveGroupMemberResponse struct {
+}
+
+// MakeRemoveGroupMemberEndpoint make remove user from group endpoint
+func MakeRemoveGroupMemberEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*RemoveGroupMemberRequest)
+
+		err = svc.RemoveGroupMember(ctx, req.GroupID, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &RemoveGroupMemberResponse{}, nil
+	}
+}
+
+// ListUserGroupsRequest define list groups user belong to request
+type ListUserGroupsRequest struct {
+	UserID string `json:"-"`
+}
+
+// MakeListUserGroupsEndpoint make list groups user belong to endpoint
+func MakeListUserGroupsEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ListUserGroupsRequest)
+
+		groups, err := svc.ListUserGroups(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newListGroupsResponse(groups), nil
+	}
+}
@@ -0,0 +1,119 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/rbac/entity"
+	"github.com/karta0898098/iam/pkg/app/rbac/service"
+)
+
+// PermissionResponse define permission of catalog
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+}
+
+// newPermissionResponse convert permission to response
+func newPermissionResponse(permission *entity.Permission) *PermissionResponse {
+	return &PermissionResponse{
+		Name:        permission.Name,
+		Description: permission.Description,
+		CreatedAt:   permission.CreatedAt.Unix(),
+		UpdatedAt:   permission.UpdatedAt.Unix(),
+	}
+}
+
+// CreatePermissionRequest define create permission request
+type CreatePermissionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// MakeCreatePermissionEndpoint make create permission endpoint
+func MakeCreatePermissionEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*CreatePermissionRequest)
+
+		permission, err := svc.CreatePermission(ctx, req.Name, req.Description)
+		if err != nil {
+			return nil, err
+		}
+
+		return newPermissionResponse(permission), nil
+	}
+}
+
+// ListPermissionsRequest define list permissions request
+type ListPermissionsRequest struct {
+}
+
+// ListPermissionsResponse define list permissions response
+type ListPermissionsResponse struct {
+	Permissions []*PermissionResponse `json:"permissions"`
+}
+
+// MakeListPermissionsEndpoint make list permissions endpoint
+func MakeListPermissionsEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		permissions, err := svc.ListPermissions(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &ListPermissionsResponse{
+			Permissions: make([]*PermissionResponse, 0, len(permissions)),
+		}
+		for _, permission := range permissions {
+			resp.Permissions = append(resp.Permissions, newPermissionResponse(permission))
+		}
+
+		return resp, nil
+	}
+}
+
+// UpdatePermissionRequest define update permission request
+type UpdatePermissionRequest struct {
+	Name        string `json:"-"`
+	Description string `json:"description"`
+}
+
+// MakeUpdatePermissionEndpoint make update permission endpoint
+func MakeUpdatePermissionEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UpdatePermissionRequest)
+
+		permission, err := svc.UpdatePermission(ctx, req.Name, req.Description)
+		if err != nil {
+			return nil, err
+		}
+
+		return newPermissionResponse(permission), nil
+	}
+}
+
+// DeletePermissionRequest define delete permission request
+type DeletePermissionRequest struct {
+	Name string `json:"-"`
+}
+
+// DeletePermissionResponse define delete permission response
+type DeletePermissionResponse struct {
+}
+
+// MakeDeletePermissionEndpoint make delete permission endpoint
+func MakeDeletePermissionEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DeletePermissionRequest)
+
+		err = svc.DeletePermission(ctx, req.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeletePermissionResponse{}, nil
+	}
+}
@@ -0,0 +1,230 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/rbac/entity"
+	"github.com/karta0898098/iam/pkg/app/rbac/service"
+)
+
+// RoleMetadata define role name, description and granted permissions
+type RoleMetadata struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// RoleResponse define role
+type RoleResponse struct {
+	RoleMetadata
+
+	ID        string `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// newRoleOption convert role metadata to service option
+func newRoleOption(metadata *RoleMetadata) *service.RoleOption {
+	return &service.RoleOption{
+		Name:        metadata.Name,
+		Description: metadata.Description,
+		Permissions: metadata.Permissions,
+	}
+}
+
+// newRoleResponse convert role to response
+func newRoleResponse(role *entity.Role) *RoleResponse {
+	return &RoleResponse{
+		RoleMetadata: RoleMetadata{
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: role.Permissions,
+		},
+		ID:        role.ID,
+		CreatedAt: role.CreatedAt.Unix(),
+		UpdatedAt: role.UpdatedAt.Unix(),
+	}
+}
+
+// ListRolesResponse define roles response
+type ListRolesResponse struct {
+	Roles []*RoleResponse `json:"roles"`
+}
+
+// newListRolesResponse convert roles to response
+func newListRolesResponse(roles []*entity.Role) *ListRolesResponse {
+	resp := &ListRolesResponse{
+		Roles: make([]*RoleResponse, 0, len(roles)),
+	}
+	for _, role := range roles {
+		resp.Roles = append(resp.Roles, newRoleResponse(role))
+	}
+	return resp
+}
+
+// CreateRoleRequest define create role request
+type CreateRoleRequest struct {
+	RoleMetadata
+}
+
+// MakeCreateRoleEndpoint make create role endpoint
+func MakeCreateRoleEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*CreateRoleRequest)
+
+		role, err := svc.CreateRole(ctx, newRoleOption(&req.RoleMetadata))
+		if err != nil {
+			return nil, err
+		}
+
+		return newRoleResponse(role), nil
+	}
+}
+
+// GetRoleRequest define get role request
+type GetRoleRequest struct {
+	RoleID string `json:"-"`
+}
+
+// MakeGetRoleEndpoint make get role endpoint
+func MakeGetRoleEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*GetRoleRequest)
+
+		role, err := svc.GetRole(ctx, req.RoleID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newRoleResponse(role), nil
+	}
+}
+
+// ListRolesRequest define list roles request
+type ListRolesRequest struct {
+}
+
+// MakeListRolesEndpoint make list roles endpoint
+func MakeListRolesEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		roles, err := svc.ListRoles(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return newListRolesResponse(roles), nil
+	}
+}
+
+// UpdateRoleRequest define update role request
+type UpdateRoleRequest struct {
+	RoleMetadata
+
+	RoleID string `json:"-"`
+}
+
+// MakeUpdateRoleEndpoint make update role endpoint
+func MakeUpdateRoleEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UpdateRoleRequest)
+
+		role, err := svc.UpdateRole(ctx, req.RoleID, newRoleOption(&req.RoleMetadata))
+		if err != nil {
+			return nil, err
+		}
+
+		return newRoleResponse(role), nil
+	}
+}
+
+// DeleteRoleRequest define delete role request
+type DeleteRoleRequest struct {
+	RoleID string `json:"-"`
+}
+
+// DeleteRoleResponse define delete role response
+type DeleteRoleResponse struct {
+}
+
+// MakeDeleteRoleEndpoint make delete role endpoint
+func MakeDeleteRoleEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DeleteRoleRequest)
+
+		err = svc.DeleteRole(ctx, req.RoleID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeleteRoleResponse{}, nil
+	}
+}
+
+// AssignRoleRequest define bind role to user request
+type AssignRoleRequest struct {
+	UserID string `json:"-"`
+	RoleID string `json:"role_id"`
+}
+
+// AssignRoleResponse define bind role to user response
+type AssignRoleResponse struct {
+}
+
+// MakeAssignRoleEndpoint make bind role to user endpoint
+func MakeAssignRoleEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*AssignRoleRequest)
+
+		err = svc.AssignRole(ctx, req.UserID, req.RoleID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &AssignRoleResponse{}, nil
+	}
+}
+
+// UnassignRoleRequest define unbind role from user request
+type UnassignRoleRequest struct {
+	UserID string `json:"-"`
+	RoleID string `json:"-"`
+}
+
+// UnassignRoleResponse define unbind role from user response
+type UnassignRoleResponse struct {
+}
+
+// MakeUnassignRoleEndpoint make unbind role from user endpoint
+func MakeUnassignRoleEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UnassignRoleRequest)
+
+		err = svc.UnassignRole(ctx, req.UserID, req.RoleID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &UnassignRoleResponse{}, nil
+	}
+}
+
+// ListUserRolesRequest define list roles bound to user request
+type ListUserRolesRequest struct {
+	UserID string `json:"-"`
+}
+
+// MakeListUserRolesEndpoint make list roles bound to user endpoint
+func MakeListUserRolesEndpoint(svc service.RBACService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ListUserRolesRequest)
+
+		roles, err := svc.ListUserRoles(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newListRolesResponse(roles), nil
+	}
+}
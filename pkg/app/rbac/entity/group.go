@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// Group define named set of users, policies can be attached to it
+type Group struct {
+	// ID group id
+	ID string
+	// Name unique group name
+	Name string
+	// Description tell administrator what the group is for
+	Description string
+	// CreatedAt this group create time
+	CreatedAt time.Time
+	// UpdatedAt this group update time
+	UpdatedAt time.Time
+}
+
+// NewGroup new group without member
+func NewGroup(id string, name string, description string) (*Group, error) {
+	now := time.Now()
+
+	group := &Group{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err := group.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// Validate check group name
+func (g *Group) Validate() error {
+	if !nameRegex.MatchString(g.Name) {
+		return errors.Wrapf(errors.ErrInvalidInput, "group name=%v is invalid", g.Name)
+	}
+	return nil
+}
+
+// Update replace name and description of group
+func (g *Group) Update(name string, description string) error {
+	g.Name = name
+	g.Description = description
+	g.UpdatedAt = time.Now()
+
+	return g.Validate()
+}
+
+// GroupMember define user belong to group
+type GroupMember struct {
+	GroupID   string
+	UserID    string
+	CreatedAt time.Time
+}
+
+// NewGroupMember add user into group
+func NewGroupMember(groupID string, userID string) *GroupMember {
+	return &GroupMember{
+		GroupID:   groupID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+const (
+	// Wildcard segment of permission match any resource or action
+	Wildcard = "*"
+
+	// PermissionLengthMax column size of permission
+	PermissionLengthMax = 128
+)
+
+var (
+	permissionSegmentRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+)
+
+// Permission define action allowed on resource,
+// it is written as "resource:action", like "documents:read"
+// and "*" segment grant every resource or action, like "documents:*"
+type Permission struct {
+	// Name resource:action string
+	Name string
+	// Description tell administrator what the permission grant
+	Description string
+	// CreatedAt this permission create time
+	CreatedAt time.Time
+	// UpdatedAt this permission update time
+	UpdatedAt time.Time
+}
+
+// NewPermission new permission of catalog
+func NewPermission(name string, description string) (*Permission, error) {
+	err := ValidatePermission(name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Permission{
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// ValidatePermission check permission is "resource:action"
+// and every segment is lowercase name or wildcard
+func ValidatePermission(permission string) error {
+	if permission == "" || len(permission) > PermissionLengthMax {
+		return errors.Wrapf(errors.ErrInvalidInput, "permission length must be 1 to %v", PermissionLengthMax)
+	}
+
+	segments := strings.Split(permission, ":")
+	if len(segments) != 2 {
+		return errors.Wrapf(errors.ErrInvalidInput, "permission=%v is not resource:action", permission)
+	}
+
+	for _, segment := range segments {
+		if segment != Wildcard && !permissionSegmentRegex.MatchString(segment) {
+			return errors.Wrapf(errors.ErrInvalidInput, "permission=%v segment=%v is invalid", permission, segment)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCheckedPermission permission being checked must be concrete,
+// wildcard only can be granted
+func ValidateCheckedPermission(permission string) error {
+	err := ValidatePermission(permission)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(permission, Wildcard) {
+		return errors.Wrapf(errors.ErrInvalidInput, "permission=%v being checked can not contain wildcard", permission)
+	}
+
+	return nil
+}
+
+// MatchPermission granted permission cover the checked one,
+// wildcard segment of granted permission match any segment
+func MatchPermission(granted string, permission string) bool {
+	grantedSegments := strings.Split(granted, ":")
+	segments := strings.Split(permission, ":")
+	if len(grantedSegments) != len(segments) {
+		return false
+	}
+
+	for i := range segments {
+		if grantedSegments[i] != Wildcard && grantedSegments[i] != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
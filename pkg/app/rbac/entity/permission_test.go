@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestMatchPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		granted    string
+		permission string
+		expected   bool
+	}{
+		{
+			name:       "Exact",
+			granted:    "documents:read",
+			permission: "documents:read",
+			expected:   true,
+		},
+		{
+			name:       "Action Wildcard",
+			granted:    "documents:*",
+			permission: "documents:delete",
+			expected:   true,
+		},
+		{
+			name:       "Resource Wildcard",
+			granted:    "*:read",
+			permission: "invoices:read",
+			expected:   true,
+		},
+		{
+			name:       "Every Permission",
+			granted:    "*:*",
+			permission: "invoices:write",
+			expected:   true,
+		},
+		{
+			name:       "Other Action",
+			granted:    "documents:read",
+			permission: "documents:write",
+			expected:   false,
+		},
+		{
+			name:       "Other Resource",
+			granted:    "documents:*",
+			permission: "invoices:read",
+			expected:   false,
+		},
+		{
+			name:       "Prefix Is Not Wildcard",
+			granted:    "documents:read",
+			permission: "documents:read.all",
+			expected:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := MatchPermission(tt.granted, tt.permission); actual != tt.expected {
+				t.Errorf("MatchPermission() = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidatePermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission string
+		wantErr    bool
+	}{
+		{
+			name:       "Valid",
+			permission: "documents:read",
+			wantErr:    false,
+		},
+		{
+			name:       "Wildcard",
+			permission: "*:read",
+			wantErr:    false,
+		},
+		{
+			name:       "Missing Action",
+			permission: "documents",
+			wantErr:    true,
+		},
+		{
+			name:       "Too Many Segments",
+			permission: "documents:read:all",
+			wantErr:    true,
+		},
+		{
+			name:       "Partial Wildcard",
+			permission: "documents:re*",
+			wantErr:    true,
+		},
+		{
+			name:       "Uppercase",
+			permission: "Documents:read",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePermission(tt.permission); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePermission() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
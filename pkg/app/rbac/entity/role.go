@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+var (
+	nameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+)
+
+// Role define named set of permissions bound to users
+type Role struct {
+	// ID role id
+	ID string
+	// Name unique role name, it is embedded into access token
+	Name string
+	// Description tell administrator what the role is for
+	Description string
+	// Permissions granted to user bound to this role
+	Permissions []string
+	// CreatedAt this role create time
+	CreatedAt time.Time
+	// UpdatedAt this role update time
+	UpdatedAt time.Time
+}
+
+// NewRole new role granting permissions
+func NewRole(id string, name string, description string, permissions []string) (*Role, error) {
+	now := time.Now()
+
+	role := &Role{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		Permissions: normalizePermissions(permissions),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err := role.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// Validate check role name and permissions
+func (r *Role) Validate() error {
+	if !nameRegex.MatchString(r.Name) {
+		return errors.Wrapf(errors.ErrInvalidInput, "role name=%v is invalid", r.Name)
+	}
+
+	for _, permission := range r.Permissions {
+		err := ValidatePermission(permission)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update replace name, description and permissions of role
+func (r *Role) Update(name string, description string, permissions []string) error {
+	r.Name = name
+	r.Description = description
+	r.Permissions = normalizePermissions(permissions)
+	r.UpdatedAt = time.Now()
+
+	return r.Validate()
+}
+
+// Allows any permission of role cover the checked one
+func (r *Role) Allows(permission string) bool {
+	for _, granted := range r.Permissions {
+		if MatchPermission(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePermissions sort and remove duplicated permissions
+func normalizePermissions(permissions []string) []string {
+	result := make([]string, 0, len(permissions))
+	seen := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+		result = append(result, permission)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// RoleBinding define role bound to user
+type RoleBinding struct {
+	UserID    string
+	RoleID    string
+	CreatedAt time.Time
+}
+
+// NewRoleBinding bind role to user
+func NewRoleBinding(userID string, roleID string) *RoleBinding {
+	return &RoleBinding{
+		UserID:    userID,
+		RoleID:    roleID,
+		CreatedAt: time.Now(),
+	}
+}
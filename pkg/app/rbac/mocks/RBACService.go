@@ -0,0 +1,1170 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/rbac/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/karta0898098/iam/pkg/app/rbac/service"
+)
+
+// RBACService is an autogenerated mock type for the RBACService type
+type RBACService struct {
+	mock.Mock
+}
+
+type RBACService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RBACService) EXPECT() *RBACService_Expecter {
+	return &RBACService_Expecter{mock: &_m.Mock}
+}
+
+// AddGroupMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *RBACService) AddGroupMember(ctx context.Context, groupID string, userID string) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RBACService_AddGroupMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddGroupMember'
+type RBACService_AddGroupMember_Call struct {
+	*mock.Call
+}
+
+// AddGroupMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - userID string
+func (_e *RBACService_Expecter) AddGroupMember(ctx interface{}, groupID interface{}, userID interface{}) *RBACService_AddGroupMember_Call {
+	return &RBACService_AddGroupMember_Call{Call: _e.mock.On("AddGroupMember", ctx, groupID, userID)}
+}
+
+func (_c *RBACService_AddGroupMember_Call) Run(run func(ctx context.Context, groupID string, userID string)) *RBACService_AddGroupMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_AddGroupMember_Call) Return(err error) *RBACService_AddGroupMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RBACService_AddGroupMember_Call) RunAndReturn(run func(context.Context, string, string) error) *RBACService_AddGroupMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignRole provides a mock function with given fields: ctx, userID, roleID
+func (_m *RBACService) AssignRole(ctx context.Context, userID string, roleID string) error {
+	ret := _m.Called(ctx, userID, roleID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RBACService_AssignRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignRole'
+type RBACService_AssignRole_Call struct {
+	*mock.Call
+}
+
+// AssignRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - roleID string
+func (_e *RBACService_Expecter) AssignRole(ctx interface{}, userID interface{}, roleID interface{}) *RBACService_AssignRole_Call {
+	return &RBACService_AssignRole_Call{Call: _e.mock.On("AssignRole", ctx, userID, roleID)}
+}
+
+func (_c *RBACService_AssignRole_Call) Run(run func(ctx context.Context, userID string, roleID string)) *RBACService_AssignRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_AssignRole_Call) Return(err error) *RBACService_AssignRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RBACService_AssignRole_Call) RunAndReturn(run func(context.Context, string, string) error) *RBACService_AssignRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckPermission provides a mock function with given fields: ctx, userID, permission
+func (_m *RBACService) CheckPermission(ctx context.Context, userID string, permission string) (bool, error) {
+	ret := _m.Called(ctx, userID, permission)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, permission)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, permission)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, permission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_CheckPermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckPermission'
+type RBACService_CheckPermission_Call struct {
+	*mock.Call
+}
+
+// CheckPermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - permission string
+func (_e *RBACService_Expecter) CheckPermission(ctx interface{}, userID interface{}, permission interface{}) *RBACService_CheckPermission_Call {
+	return &RBACService_CheckPermission_Call{Call: _e.mock.On("CheckPermission", ctx, userID, permission)}
+}
+
+func (_c *RBACService_CheckPermission_Call) Run(run func(ctx context.Context, userID string, permission string)) *RBACService_CheckPermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_CheckPermission_Call) Return(allowed bool, err error) *RBACService_CheckPermission_Call {
+	_c.Call.Return(allowed, err)
+	return _c
+}
+
+func (_c *RBACService_CheckPermission_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *RBACService_CheckPermission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGroup provides a mock function with given fields: ctx, opt
+func (_m *RBACService) CreateGroup(ctx context.Context, opt *service.GroupOption) (*entity.Group, error) {
+	ret := _m.Called(ctx, opt)
+
+	var r0 *entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.GroupOption) (*entity.Group, error)); ok {
+		return rf(ctx, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.GroupOption) *entity.Group); ok {
+		r0 = rf(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.GroupOption) error); ok {
+		r1 = rf(ctx, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_CreateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGroup'
+type RBACService_CreateGroup_Call struct {
+	*mock.Call
+}
+
+// CreateGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *service.GroupOption
+func (_e *RBACService_Expecter) CreateGroup(ctx interface{}, opt interface{}) *RBACService_CreateGroup_Call {
+	return &RBACService_CreateGroup_Call{Call: _e.mock.On("CreateGroup", ctx, opt)}
+}
+
+func (_c *RBACService_CreateGroup_Call) Run(run func(ctx context.Context, opt *service.GroupOption)) *RBACService_CreateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.GroupOption))
+	})
+	return _c
+}
+
+func (_c *RBACService_CreateGroup_Call) Return(group *entity.Group, err error) *RBACService_CreateGroup_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *RBACService_CreateGroup_Call) RunAndReturn(run func(context.Context, *service.GroupOption) (*entity.Group, error)) *RBACService_CreateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePermission provides a mock function with given fields: ctx, name, description
+func (_m *RBACService) CreatePermission(ctx context.Context, name string, description string) (*entity.Permission, error) {
+	ret := _m.Called(ctx, name, description)
+
+	var r0 *entity.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Permission, error)); ok {
+		return rf(ctx, name, description)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Permission); ok {
+		r0 = rf(ctx, name, description)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, description)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_CreatePermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePermission'
+type RBACService_CreatePermission_Call struct {
+	*mock.Call
+}
+
+// CreatePermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - description string
+func (_e *RBACService_Expecter) CreatePermission(ctx interface{}, name interface{}, description interface{}) *RBACService_CreatePermission_Call {
+	return &RBACService_CreatePermission_Call{Call: _e.mock.On("CreatePermission", ctx, name, description)}
+}
+
+func (_c *RBACService_CreatePermission_Call) Run(run func(ctx context.Context, name string, description string)) *RBACService_CreatePermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_CreatePermission_Call) Return(permission *entity.Permission, err error) *RBACService_CreatePermission_Call {
+	_c.Call.Return(permission, err)
+	return _c
+}
+
+func (_c *RBACService_CreatePermission_Call) RunAndReturn(run func(context.Context, string, string) (*entity.Permission, error)) *RBACService_CreatePermission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRole provides a mock function with given fields: ctx, opt
+func (_m *RBACService) CreateRole(ctx context.Context, opt *service.RoleOption) (*entity.Role, error) {
+	ret := _m.Called(ctx, opt)
+
+	var r0 *entity.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.RoleOption) (*entity.Role, error)); ok {
+		return rf(ctx, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.RoleOption) *entity.Role); ok {
+		r0 = rf(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.RoleOption) error); ok {
+		r1 = rf(ctx, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_CreateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRole'
+type RBACService_CreateRole_Call struct {
+	*mock.Call
+}
+
+// CreateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *service.RoleOption
+func (_e *RBACService_Expecter) CreateRole(ctx interface{}, opt interface{}) *RBACService_CreateRole_Call {
+	return &RBACService_CreateRole_Call{Call: _e.mock.On("CreateRole", ctx, opt)}
+}
+
+func (_c *RBACService_CreateRole_Call) Run(run func(ctx context.Context, opt *service.RoleOption)) *RBACService_CreateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.RoleOption))
+	})
+	return _c
+}
+
+func (_c *RBACService_CreateRole_Call) Return(role *entity.Role, err error) *RBACService_CreateRole_Call {
+	_c.Call.Return(role, err)
+	return _c
+}
+
+func (_c *RBACService_CreateRole_Call) RunAndReturn(run func(context.Context, *service.RoleOption) (*entity.Role, error)) *RBACService_CreateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, groupID
+func (_m *RBACService) DeleteGroup(ctx context.Context, groupID string) error {
+	ret := _m.Called(ctx, groupID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RBACService_DeleteGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGroup'
+type RBACService_DeleteGroup_Call struct {
+	*mock.Call
+}
+
+// DeleteGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+func (_e *RBACService_Expecter) DeleteGroup(ctx interface{}, groupID interface{}) *RBACService_DeleteGroup_Call {
+	return &RBACService_DeleteGroup_Call{Call: _e.mock.On("DeleteGroup", ctx, groupID)}
+}
+
+func (_c *RBACService_DeleteGroup_Call) Run(run func(ctx context.Context, groupID string)) *RBACService_DeleteGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_DeleteGroup_Call) Return(err error) *RBACService_DeleteGroup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RBACService_DeleteGroup_Call) RunAndReturn(run func(context.Context, string) error) *RBACService_DeleteGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePermission provides a mock function with given fields: ctx, name
+func (_m *RBACService) DeletePermission(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RBACService_DeletePermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePermission'
+type RBACService_DeletePermission_Call struct {
+	*mock.Call
+}
+
+// DeletePermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *RBACService_Expecter) DeletePermission(ctx interface{}, name interface{}) *RBACService_DeletePermission_Call {
+	return &RBACService_DeletePermission_Call{Call: _e.mock.On("DeletePermission", ctx, name)}
+}
+
+func (_c *RBACService_DeletePermission_Call) Run(run func(ctx context.Context, name string)) *RBACService_DeletePermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_DeletePermission_Call) Return(err error) *RBACService_DeletePermission_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RBACService_DeletePermission_Call) RunAndReturn(run func(context.Context, string) error) *RBACService_DeletePermission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRole provides a mock function with given fields: ctx, roleID
+func (_m *RBACService) DeleteRole(ctx context.Context, roleID string) error {
+	ret := _m.Called(ctx, roleID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RBACService_DeleteRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRole'
+type RBACService_DeleteRole_Call struct {
+	*mock.Call
+}
+
+// DeleteRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleID string
+func (_e *RBACService_Expecter) DeleteRole(ctx interface{}, roleID interface{}) *RBACService_DeleteRole_Call {
+	return &RBACService_DeleteRole_Call{Call: _e.mock.On("DeleteRole", ctx, roleID)}
+}
+
+func (_c *RBACService_DeleteRole_Call) Run(run func(ctx context.Context, roleID string)) *RBACService_DeleteRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_DeleteRole_Call) Return(err error) *RBACService_DeleteRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RBACService_DeleteRole_Call) RunAndReturn(run func(context.Context, string) error) *RBACService_DeleteRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroup provides a mock function with given fields: ctx, groupID
+func (_m *RBACService) GetGroup(ctx context.Context, groupID string) (*entity.Group, error) {
+	ret := _m.Called(ctx, groupID)
+
+	var r0 *entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Group, error)); ok {
+		return rf(ctx, groupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Group); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_GetGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroup'
+type RBACService_GetGroup_Call struct {
+	*mock.Call
+}
+
+// GetGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+func (_e *RBACService_Expecter) GetGroup(ctx interface{}, groupID interface{}) *RBACService_GetGroup_Call {
+	return &RBACService_GetGroup_Call{Call: _e.mock.On("GetGroup", ctx, groupID)}
+}
+
+func (_c *RBACService_GetGroup_Call) Run(run func(ctx context.Context, groupID string)) *RBACService_GetGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_GetGroup_Call) Return(group *entity.Group, err error) *RBACService_GetGroup_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *RBACService_GetGroup_Call) RunAndReturn(run func(context.Context, string) (*entity.Group, error)) *RBACService_GetGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRole provides a mock function with given fields: ctx, roleID
+func (_m *RBACService) GetRole(ctx context.Context, roleID string) (*entity.Role, error) {
+	ret := _m.Called(ctx, roleID)
+
+	var r0 *entity.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Role, error)); ok {
+		return rf(ctx, roleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Role); ok {
+		r0 = rf(ctx, roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_GetRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRole'
+type RBACService_GetRole_Call struct {
+	*mock.Call
+}
+
+// GetRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleID string
+func (_e *RBACService_Expecter) GetRole(ctx interface{}, roleID interface{}) *RBACService_GetRole_Call {
+	return &RBACService_GetRole_Call{Call: _e.mock.On("GetRole", ctx, roleID)}
+}
+
+func (_c *RBACService_GetRole_Call) Run(run func(ctx context.Context, roleID string)) *RBACService_GetRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_GetRole_Call) Return(role *entity.Role, err error) *RBACService_GetRole_Call {
+	_c.Call.Return(role, err)
+	return _c
+}
+
+func (_c *RBACService_GetRole_Call) RunAndReturn(run func(context.Context, string) (*entity.Role, error)) *RBACService_GetRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGroups provides a mock function with given fields: ctx
+func (_m *RBACService) ListGroups(ctx context.Context) ([]*entity.Group, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Group, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Group); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_ListGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGroups'
+type RBACService_ListGroups_Call struct {
+	*mock.Call
+}
+
+// ListGroups is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RBACService_Expecter) ListGroups(ctx interface{}) *RBACService_ListGroups_Call {
+	return &RBACService_ListGroups_Call{Call: _e.mock.On("ListGroups", ctx)}
+}
+
+func (_c *RBACService_ListGroups_Call) Run(run func(ctx context.Context)) *RBACService_ListGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RBACService_ListGroups_Call) Return(groups []*entity.Group, err error) *RBACService_ListGroups_Call {
+	_c.Call.Return(groups, err)
+	return _c
+}
+
+func (_c *RBACService_ListGroups_Call) RunAndReturn(run func(context.Context) ([]*entity.Group, error)) *RBACService_ListGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPermissions provides a mock function with given fields: ctx
+func (_m *RBACService) ListPermissions(ctx context.Context) ([]*entity.Permission, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Permission, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Permission); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_ListPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPermissions'
+type RBACService_ListPermissions_Call struct {
+	*mock.Call
+}
+
+// ListPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RBACService_Expecter) ListPermissions(ctx interface{}) *RBACService_ListPermissions_Call {
+	return &RBACService_ListPermissions_Call{Call: _e.mock.On("ListPermissions", ctx)}
+}
+
+func (_c *RBACService_ListPermissions_Call) Run(run func(ctx context.Context)) *RBACService_ListPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RBACService_ListPermissions_Call) Return(permissions []*entity.Permission, err error) *RBACService_ListPermissions_Call {
+	_c.Call.Return(permissions, err)
+	return _c
+}
+
+func (_c *RBACService_ListPermissions_Call) RunAndReturn(run func(context.Context) ([]*entity.Permission, error)) *RBACService_ListPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoles provides a mock function with given fields: ctx
+func (_m *RBACService) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_ListRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoles'
+type RBACService_ListRoles_Call struct {
+	*mock.Call
+}
+
+// ListRoles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RBACService_Expecter) ListRoles(ctx interface{}) *RBACService_ListRoles_Call {
+	return &RBACService_ListRoles_Call{Call: _e.mock.On("ListRoles", ctx)}
+}
+
+func (_c *RBACService_ListRoles_Call) Run(run func(ctx context.Context)) *RBACService_ListRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RBACService_ListRoles_Call) Return(roles []*entity.Role, err error) *RBACService_ListRoles_Call {
+	_c.Call.Return(roles, err)
+	return _c
+}
+
+func (_c *RBACService_ListRoles_Call) RunAndReturn(run func(context.Context) ([]*entity.Role, error)) *RBACService_ListRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserGroups provides a mock function with given fields: ctx, userID
+func (_m *RBACService) ListUserGroups(ctx context.Context, userID string) ([]*entity.Group, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Group, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Group); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_ListUserGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserGroups'
+type RBACService_ListUserGroups_Call struct {
+	*mock.Call
+}
+
+// ListUserGroups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *RBACService_Expecter) ListUserGroups(ctx interface{}, userID interface{}) *RBACService_ListUserGroups_Call {
+	return &RBACService_ListUserGroups_Call{Call: _e.mock.On("ListUserGroups", ctx, userID)}
+}
+
+func (_c *RBACService_ListUserGroups_Call) Run(run func(ctx context.Context, userID string)) *RBACService_ListUserGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_ListUserGroups_Call) Return(groups []*entity.Group, err error) *RBACService_ListUserGroups_Call {
+	_c.Call.Return(groups, err)
+	return _c
+}
+
+func (_c *RBACService_ListUserGroups_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Group, error)) *RBACService_ListUserGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserRoles provides a mock function with given fields: ctx, userID
+func (_m *RBACService) ListUserRoles(ctx context.Context, userID string) ([]*entity.Role, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*entity.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Role, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Role); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_ListUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserRoles'
+type RBACService_ListUserRoles_Call struct {
+	*mock.Call
+}
+
+// ListUserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *RBACService_Expecter) ListUserRoles(ctx interface{}, userID interface{}) *RBACService_ListUserRoles_Call {
+	return &RBACService_ListUserRoles_Call{Call: _e.mock.On("ListUserRoles", ctx, userID)}
+}
+
+func (_c *RBACService_ListUserRoles_Call) Run(run func(ctx context.Context, userID string)) *RBACService_ListUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_ListUserRoles_Call) Return(roles []*entity.Role, err error) *RBACService_ListUserRoles_Call {
+	_c.Call.Return(roles, err)
+	return _c
+}
+
+func (_c *RBACService_ListUserRoles_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Role, error)) *RBACService_ListUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveGroupMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *RBACService) RemoveGroupMember(ctx context.Context, groupID string, userID string) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RBACService_RemoveGroupMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveGroupMember'
+type RBACService_RemoveGroupMember_Call struct {
+	*mock.Call
+}
+
+// RemoveGroupMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - userID string
+func (_e *RBACService_Expecter) RemoveGroupMember(ctx interface{}, groupID interface{}, userID interface{}) *RBACService_RemoveGroupMember_Call {
+	return &RBACService_RemoveGroupMember_Call{Call: _e.mock.On("RemoveGroupMember", ctx, groupID, userID)}
+}
+
+func (_c *RBACService_RemoveGroupMember_Call) Run(run func(ctx context.Context, groupID string, userID string)) *RBACService_RemoveGroupMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_RemoveGroupMember_Call) Return(err error) *RBACService_RemoveGroupMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RBACService_RemoveGroupMember_Call) RunAndReturn(run func(context.Context, string, string) error) *RBACService_RemoveGroupMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnassignRole provides a mock function with given fields: ctx, userID, roleID
+func (_m *RBACService) UnassignRole(ctx context.Context, userID string, roleID string) error {
+	ret := _m.Called(ctx, userID, roleID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RBACService_UnassignRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnassignRole'
+type RBACService_UnassignRole_Call struct {
+	*mock.Call
+}
+
+// UnassignRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - roleID string
+func (_e *RBACService_Expecter) UnassignRole(ctx interface{}, userID interface{}, roleID interface{}) *RBACService_UnassignRole_Call {
+	return &RBACService_UnassignRole_Call{Call: _e.mock.On("UnassignRole", ctx, userID, roleID)}
+}
+
+func (_c *RBACService_UnassignRole_Call) Run(run func(ctx context.Context, userID string, roleID string)) *RBACService_UnassignRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_UnassignRole_Call) Return(err error) *RBACService_UnassignRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RBACService_UnassignRole_Call) RunAndReturn(run func(context.Context, string, string) error) *RBACService_UnassignRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateGroup provides a mock function with given fields: ctx, groupID, opt
+func (_m *RBACService) UpdateGroup(ctx context.Context, groupID string, opt *service.GroupOption) (*entity.Group, error) {
+	ret := _m.Called(ctx, groupID, opt)
+
+	var r0 *entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.GroupOption) (*entity.Group, error)); ok {
+		return rf(ctx, groupID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.GroupOption) *entity.Group); ok {
+		r0 = rf(ctx, groupID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *service.GroupOption) error); ok {
+		r1 = rf(ctx, groupID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_UpdateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGroup'
+type RBACService_UpdateGroup_Call struct {
+	*mock.Call
+}
+
+// UpdateGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - opt *service.GroupOption
+func (_e *RBACService_Expecter) UpdateGroup(ctx interface{}, groupID interface{}, opt interface{}) *RBACService_UpdateGroup_Call {
+	return &RBACService_UpdateGroup_Call{Call: _e.mock.On("UpdateGroup", ctx, groupID, opt)}
+}
+
+func (_c *RBACService_UpdateGroup_Call) Run(run func(ctx context.Context, groupID string, opt *service.GroupOption)) *RBACService_UpdateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*service.GroupOption))
+	})
+	return _c
+}
+
+func (_c *RBACService_UpdateGroup_Call) Return(group *entity.Group, err error) *RBACService_UpdateGroup_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *RBACService_UpdateGroup_Call) RunAndReturn(run func(context.Context, string, *service.GroupOption) (*entity.Group, error)) *RBACService_UpdateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePermission provides a mock function with given fields: ctx, name, description
+func (_m *RBACService) UpdatePermission(ctx context.Context, name string, description string) (*entity.Permission, error) {
+	ret := _m.Called(ctx, name, description)
+
+	var r0 *entity.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Permission, error)); ok {
+		return rf(ctx, name, description)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Permission); ok {
+		r0 = rf(ctx, name, description)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, description)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_UpdatePermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePermission'
+type RBACService_UpdatePermission_Call struct {
+	*mock.Call
+}
+
+// UpdatePermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - description string
+func (_e *RBACService_Expecter) UpdatePermission(ctx interface{}, name interface{}, description interface{}) *RBACService_UpdatePermission_Call {
+	return &RBACService_UpdatePermission_Call{Call: _e.mock.On("UpdatePermission", ctx, name, description)}
+}
+
+func (_c *RBACService_UpdatePermission_Call) Run(run func(ctx context.Context, name string, description string)) *RBACService_UpdatePermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_UpdatePermission_Call) Return(permission *entity.Permission, err error) *RBACService_UpdatePermission_Call {
+	_c.Call.Return(permission, err)
+	return _c
+}
+
+func (_c *RBACService_UpdatePermission_Call) RunAndReturn(run func(context.Context, string, string) (*entity.Permission, error)) *RBACService_UpdatePermission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRole provides a mock function with given fields: ctx, roleID, opt
+func (_m *RBACService) UpdateRole(ctx context.Context, roleID string, opt *service.RoleOption) (*entity.Role, error) {
+	ret := _m.Called(ctx, roleID, opt)
+
+	var r0 *entity.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.RoleOption) (*entity.Role, error)); ok {
+		return rf(ctx, roleID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.RoleOption) *entity.Role); ok {
+		r0 = rf(ctx, roleID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *service.RoleOption) error); ok {
+		r1 = rf(ctx, roleID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type RBACService_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleID string
+//   - opt *service.RoleOption
+func (_e *RBACService_Expecter) UpdateRole(ctx interface{}, roleID interface{}, opt interface{}) *RBACService_UpdateRole_Call {
+	return &RBACService_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, roleID, opt)}
+}
+
+func (_c *RBACService_UpdateRole_Call) Run(run func(ctx context.Context, roleID string, opt *service.RoleOption)) *RBACService_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*service.RoleOption))
+	})
+	return _c
+}
+
+func (_c *RBACService_UpdateRole_Call) Return(role *entity.Role, err error) *RBACService_UpdateRole_Call {
+	_c.Call.Return(role, err)
+	return _c
+}
+
+func (_c *RBACService_UpdateRole_Call) RunAndReturn(run func(context.Context, string, *service.RoleOption) (*entity.Role, error)) *RBACService_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserRoles provides a mock function with given fields: ctx, userID
+func (_m *RBACService) UserRoles(ctx context.Context, userID string) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_UserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserRoles'
+type RBACService_UserRoles_Call struct {
+	*mock.Call
+}
+
+// UserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *RBACService_Expecter) UserRoles(ctx interface{}, userID interface{}) *RBACService_UserRoles_Call {
+	return &RBACService_UserRoles_Call{Call: _e.mock.On("UserRoles", ctx, userID)}
+}
+
+func (_c *RBACService_UserRoles_Call) Run(run func(ctx context.Context, userID string)) *RBACService_UserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_UserRoles_Call) Return(roles []string, err error) *RBACService_UserRoles_Call {
+	_c.Call.Return(roles, err)
+	return _c
+}
+
+func (_c *RBACService_UserRoles_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *RBACService_UserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewRBACService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRBACService creates a new instance of RBACService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRBACService(t mockConstructorTestingTNewRBACService) *RBACService {
+	mock := &RBACService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,1146 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/rbac/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, groupID
+func (_m *Repository) DeleteGroup(ctx context.Context, groupID string) error {
+	ret := _m.Called(ctx, groupID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGroup'
+type Repository_DeleteGroup_Call struct {
+	*mock.Call
+}
+
+// DeleteGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+func (_e *Repository_Expecter) DeleteGroup(ctx interface{}, groupID interface{}) *Repository_DeleteGroup_Call {
+	return &Repository_DeleteGroup_Call{Call: _e.mock.On("DeleteGroup", ctx, groupID)}
+}
+
+func (_c *Repository_DeleteGroup_Call) Run(run func(ctx context.Context, groupID string)) *Repository_DeleteGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteGroup_Call) Return(err error) *Repository_DeleteGroup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteGroup_Call) RunAndReturn(run func(context.Context, string) error) *Repository_DeleteGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteGroupMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *Repository) DeleteGroupMember(ctx context.Context, groupID string, userID string) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteGroupMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGroupMember'
+type Repository_DeleteGroupMember_Call struct {
+	*mock.Call
+}
+
+// DeleteGroupMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - userID string
+func (_e *Repository_Expecter) DeleteGroupMember(ctx interface{}, groupID interface{}, userID interface{}) *Repository_DeleteGroupMember_Call {
+	return &Repository_DeleteGroupMember_Call{Call: _e.mock.On("DeleteGroupMember", ctx, groupID, userID)}
+}
+
+func (_c *Repository_DeleteGroupMember_Call) Run(run func(ctx context.Context, groupID string, userID string)) *Repository_DeleteGroupMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteGroupMember_Call) Return(err error) *Repository_DeleteGroupMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteGroupMember_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_DeleteGroupMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePermission provides a mock function with given fields: ctx, name
+func (_m *Repository) DeletePermission(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeletePermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePermission'
+type Repository_DeletePermission_Call struct {
+	*mock.Call
+}
+
+// DeletePermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *Repository_Expecter) DeletePermission(ctx interface{}, name interface{}) *Repository_DeletePermission_Call {
+	return &Repository_DeletePermission_Call{Call: _e.mock.On("DeletePermission", ctx, name)}
+}
+
+func (_c *Repository_DeletePermission_Call) Run(run func(ctx context.Context, name string)) *Repository_DeletePermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeletePermission_Call) Return(err error) *Repository_DeletePermission_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeletePermission_Call) RunAndReturn(run func(context.Context, string) error) *Repository_DeletePermission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRole provides a mock function with given fields: ctx, roleID
+func (_m *Repository) DeleteRole(ctx context.Context, roleID string) error {
+	ret := _m.Called(ctx, roleID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRole'
+type Repository_DeleteRole_Call struct {
+	*mock.Call
+}
+
+// DeleteRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleID string
+func (_e *Repository_Expecter) DeleteRole(ctx interface{}, roleID interface{}) *Repository_DeleteRole_Call {
+	return &Repository_DeleteRole_Call{Call: _e.mock.On("DeleteRole", ctx, roleID)}
+}
+
+func (_c *Repository_DeleteRole_Call) Run(run func(ctx context.Context, roleID string)) *Repository_DeleteRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteRole_Call) Return(err error) *Repository_DeleteRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteRole_Call) RunAndReturn(run func(context.Context, string) error) *Repository_DeleteRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRoleBinding provides a mock function with given fields: ctx, userID, roleID
+func (_m *Repository) DeleteRoleBinding(ctx context.Context, userID string, roleID string) error {
+	ret := _m.Called(ctx, userID, roleID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteRoleBinding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRoleBinding'
+type Repository_DeleteRoleBinding_Call struct {
+	*mock.Call
+}
+
+// DeleteRoleBinding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - roleID string
+func (_e *Repository_Expecter) DeleteRoleBinding(ctx interface{}, userID interface{}, roleID interface{}) *Repository_DeleteRoleBinding_Call {
+	return &Repository_DeleteRoleBinding_Call{Call: _e.mock.On("DeleteRoleBinding", ctx, userID, roleID)}
+}
+
+func (_c *Repository_DeleteRoleBinding_Call) Run(run func(ctx context.Context, userID string, roleID string)) *Repository_DeleteRoleBinding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteRoleBinding_Call) Return(err error) *Repository_DeleteRoleBinding_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteRoleBinding_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_DeleteRoleBinding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindGroupByID provides a mock function with given fields: ctx, groupID
+func (_m *Repository) FindGroupByID(ctx context.Context, groupID string) (*entity.Group, error) {
+	ret := _m.Called(ctx, groupID)
+
+	var r0 *entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Group, error)); ok {
+		return rf(ctx, groupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Group); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindGroupByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindGroupByID'
+type Repository_FindGroupByID_Call struct {
+	*mock.Call
+}
+
+// FindGroupByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+func (_e *Repository_Expecter) FindGroupByID(ctx interface{}, groupID interface{}) *Repository_FindGroupByID_Call {
+	return &Repository_FindGroupByID_Call{Call: _e.mock.On("FindGroupByID", ctx, groupID)}
+}
+
+func (_c *Repository_FindGroupByID_Call) Run(run func(ctx context.Context, groupID string)) *Repository_FindGroupByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindGroupByID_Call) Return(group *entity.Group, err error) *Repository_FindGroupByID_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *Repository_FindGroupByID_Call) RunAndReturn(run func(context.Context, string) (*entity.Group, error)) *Repository_FindGroupByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindGroupByName provides a mock function with given fields: ctx, name
+func (_m *Repository) FindGroupByName(ctx context.Context, name string) (*entity.Group, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Group, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Group); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindGroupByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindGroupByName'
+type Repository_FindGroupByName_Call struct {
+	*mock.Call
+}
+
+// FindGroupByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *Repository_Expecter) FindGroupByName(ctx interface{}, name interface{}) *Repository_FindGroupByName_Call {
+	return &Repository_FindGroupByName_Call{Call: _e.mock.On("FindGroupByName", ctx, name)}
+}
+
+func (_c *Repository_FindGroupByName_Call) Run(run func(ctx context.Context, name string)) *Repository_FindGroupByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindGroupByName_Call) Return(group *entity.Group, err error) *Repository_FindGroupByName_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *Repository_FindGroupByName_Call) RunAndReturn(run func(context.Context, string) (*entity.Group, error)) *Repository_FindGroupByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPermissions provides a mock function with given fields: ctx, names
+func (_m *Repository) FindPermissions(ctx context.Context, names []string) ([]*entity.Permission, error) {
+	ret := _m.Called(ctx, names)
+
+	var r0 []*entity.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*entity.Permission, error)); ok {
+		return rf(ctx, names)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*entity.Permission); ok {
+		r0 = rf(ctx, names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPermissions'
+type Repository_FindPermissions_Call struct {
+	*mock.Call
+}
+
+// FindPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - names []string
+func (_e *Repository_Expecter) FindPermissions(ctx interface{}, names interface{}) *Repository_FindPermissions_Call {
+	return &Repository_FindPermissions_Call{Call: _e.mock.On("FindPermissions", ctx, names)}
+}
+
+func (_c *Repository_FindPermissions_Call) Run(run func(ctx context.Context, names []string)) *Repository_FindPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindPermissions_Call) Return(permissions []*entity.Permission, err error) *Repository_FindPermissions_Call {
+	_c.Call.Return(permissions, err)
+	return _c
+}
+
+func (_c *Repository_FindPermissions_Call) RunAndReturn(run func(context.Context, []string) ([]*entity.Permission, error)) *Repository_FindPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRoleByID provides a mock function with given fields: ctx, roleID
+func (_m *Repository) FindRoleByID(ctx context.Context, roleID string) (*entity.Role, error) {
+	ret := _m.Called(ctx, roleID)
+
+	var r0 *entity.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Role, error)); ok {
+		return rf(ctx, roleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Role); ok {
+		r0 = rf(ctx, roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindRoleByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRoleByID'
+type Repository_FindRoleByID_Call struct {
+	*mock.Call
+}
+
+// FindRoleByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleID string
+func (_e *Repository_Expecter) FindRoleByID(ctx interface{}, roleID interface{}) *Repository_FindRoleByID_Call {
+	return &Repository_FindRoleByID_Call{Call: _e.mock.On("FindRoleByID", ctx, roleID)}
+}
+
+func (_c *Repository_FindRoleByID_Call) Run(run func(ctx context.Context, roleID string)) *Repository_FindRoleByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindRoleByID_Call) Return(role *entity.Role, err error) *Repository_FindRoleByID_Call {
+	_c.Call.Return(role, err)
+	return _c
+}
+
+func (_c *Repository_FindRoleByID_Call) RunAndReturn(run func(context.Context, string) (*entity.Role, error)) *Repository_FindRoleByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRoleByName provides a mock function with given fields: ctx, name
+func (_m *Repository) FindRoleByName(ctx context.Context, name string) (*entity.Role, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *entity.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Role, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Role); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindRoleByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRoleByName'
+type Repository_FindRoleByName_Call struct {
+	*mock.Call
+}
+
+// FindRoleByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *Repository_Expecter) FindRoleByName(ctx interface{}, name interface{}) *Repository_FindRoleByName_Call {
+	return &Repository_FindRoleByName_Call{Call: _e.mock.On("FindRoleByName", ctx, name)}
+}
+
+func (_c *Repository_FindRoleByName_Call) Run(run func(ctx context.Context, name string)) *Repository_FindRoleByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindRoleByName_Call) Return(role *entity.Role, err error) *Repository_FindRoleByName_Call {
+	_c.Call.Return(role, err)
+	return _c
+}
+
+func (_c *Repository_FindRoleByName_Call) RunAndReturn(run func(context.Context, string) (*entity.Role, error)) *Repository_FindRoleByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUserGroups provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindUserGroups(ctx context.Context, userID string) ([]*entity.Group, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Group, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Group); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUserGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserGroups'
+type Repository_FindUserGroups_Call struct {
+	*mock.Call
+}
+
+// FindUserGroups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindUserGroups(ctx interface{}, userID interface{}) *Repository_FindUserGroups_Call {
+	return &Repository_FindUserGroups_Call{Call: _e.mock.On("FindUserGroups", ctx, userID)}
+}
+
+func (_c *Repository_FindUserGroups_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindUserGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUserGroups_Call) Return(groups []*entity.Group, err error) *Repository_FindUserGroups_Call {
+	_c.Call.Return(groups, err)
+	return _c
+}
+
+func (_c *Repository_FindUserGroups_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Group, error)) *Repository_FindUserGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUserRoles provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindUserRoles(ctx context.Context, userID string) ([]*entity.Role, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*entity.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Role, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Role); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserRoles'
+type Repository_FindUserRoles_Call struct {
+	*mock.Call
+}
+
+// FindUserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindUserRoles(ctx interface{}, userID interface{}) *Repository_FindUserRoles_Call {
+	return &Repository_FindUserRoles_Call{Call: _e.mock.On("FindUserRoles", ctx, userID)}
+}
+
+func (_c *Repository_FindUserRoles_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUserRoles_Call) Return(roles []*entity.Role, err error) *Repository_FindUserRoles_Call {
+	_c.Call.Return(roles, err)
+	return _c
+}
+
+func (_c *Repository_FindUserRoles_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Role, error)) *Repository_FindUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGroups provides a mock function with given fields: ctx
+func (_m *Repository) ListGroups(ctx context.Context) ([]*entity.Group, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Group, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Group); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGroups'
+type Repository_ListGroups_Call struct {
+	*mock.Call
+}
+
+// ListGroups is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListGroups(ctx interface{}) *Repository_ListGroups_Call {
+	return &Repository_ListGroups_Call{Call: _e.mock.On("ListGroups", ctx)}
+}
+
+func (_c *Repository_ListGroups_Call) Run(run func(ctx context.Context)) *Repository_ListGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListGroups_Call) Return(groups []*entity.Group, err error) *Repository_ListGroups_Call {
+	_c.Call.Return(groups, err)
+	return _c
+}
+
+func (_c *Repository_ListGroups_Call) RunAndReturn(run func(context.Context) ([]*entity.Group, error)) *Repository_ListGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPermissions provides a mock function with given fields: ctx
+func (_m *Repository) ListPermissions(ctx context.Context) ([]*entity.Permission, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Permission, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Permission); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPermissions'
+type Repository_ListPermissions_Call struct {
+	*mock.Call
+}
+
+// ListPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListPermissions(ctx interface{}) *Repository_ListPermissions_Call {
+	return &Repository_ListPermissions_Call{Call: _e.mock.On("ListPermissions", ctx)}
+}
+
+func (_c *Repository_ListPermissions_Call) Run(run func(ctx context.Context)) *Repository_ListPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListPermissions_Call) Return(permissions []*entity.Permission, err error) *Repository_ListPermissions_Call {
+	_c.Call.Return(permissions, err)
+	return _c
+}
+
+func (_c *Repository_ListPermissions_Call) RunAndReturn(run func(context.Context) ([]*entity.Permission, error)) *Repository_ListPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoles provides a mock function with given fields: ctx
+func (_m *Repository) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoles'
+type Repository_ListRoles_Call struct {
+	*mock.Call
+}
+
+// ListRoles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListRoles(ctx interface{}) *Repository_ListRoles_Call {
+	return &Repository_ListRoles_Call{Call: _e.mock.On("ListRoles", ctx)}
+}
+
+func (_c *Repository_ListRoles_Call) Run(run func(ctx context.Context)) *Repository_ListRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListRoles_Call) Return(roles []*entity.Role, err error) *Repository_ListRoles_Call {
+	_c.Call.Return(roles, err)
+	return _c
+}
+
+func (_c *Repository_ListRoles_Call) RunAndReturn(run func(context.Context) ([]*entity.Role, error)) *Repository_ListRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreGroup provides a mock function with given fields: ctx, group
+func (_m *Repository) StoreGroup(ctx context.Context, group *entity.Group) error {
+	ret := _m.Called(ctx, group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Group) error); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreGroup'
+type Repository_StoreGroup_Call struct {
+	*mock.Call
+}
+
+// StoreGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group *entity.Group
+func (_e *Repository_Expecter) StoreGroup(ctx interface{}, group interface{}) *Repository_StoreGroup_Call {
+	return &Repository_StoreGroup_Call{Call: _e.mock.On("StoreGroup", ctx, group)}
+}
+
+func (_c *Repository_StoreGroup_Call) Run(run func(ctx context.Context, group *entity.Group)) *Repository_StoreGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Group))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreGroup_Call) Return(err error) *Repository_StoreGroup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreGroup_Call) RunAndReturn(run func(context.Context, *entity.Group) error) *Repository_StoreGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreGroupMember provides a mock function with given fields: ctx, member
+func (_m *Repository) StoreGroupMember(ctx context.Context, member *entity.GroupMember) error {
+	ret := _m.Called(ctx, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.GroupMember) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreGroupMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreGroupMember'
+type Repository_StoreGroupMember_Call struct {
+	*mock.Call
+}
+
+// StoreGroupMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *entity.GroupMember
+func (_e *Repository_Expecter) StoreGroupMember(ctx interface{}, member interface{}) *Repository_StoreGroupMember_Call {
+	return &Repository_StoreGroupMember_Call{Call: _e.mock.On("StoreGroupMember", ctx, member)}
+}
+
+func (_c *Repository_StoreGroupMember_Call) Run(run func(ctx context.Context, member *entity.GroupMember)) *Repository_StoreGroupMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.GroupMember))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreGroupMember_Call) Return(err error) *Repository_StoreGroupMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreGroupMember_Call) RunAndReturn(run func(context.Context, *entity.GroupMember) error) *Repository_StoreGroupMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StorePermission provides a mock function with given fields: ctx, permission
+func (_m *Repository) StorePermission(ctx context.Context, permission *entity.Permission) error {
+	ret := _m.Called(ctx, permission)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Permission) error); ok {
+		r0 = rf(ctx, permission)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StorePermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StorePermission'
+type Repository_StorePermission_Call struct {
+	*mock.Call
+}
+
+// StorePermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - permission *entity.Permission
+func (_e *Repository_Expecter) StorePermission(ctx interface{}, permission interface{}) *Repository_StorePermission_Call {
+	return &Repository_StorePermission_Call{Call: _e.mock.On("StorePermission", ctx, permission)}
+}
+
+func (_c *Repository_StorePermission_Call) Run(run func(ctx context.Context, permission *entity.Permission)) *Repository_StorePermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Permission))
+	})
+	return _c
+}
+
+func (_c *Repository_StorePermission_Call) Return(err error) *Repository_StorePermission_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StorePermission_Call) RunAndReturn(run func(context.Context, *entity.Permission) error) *Repository_StorePermission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreRole provides a mock function with given fields: ctx, role
+func (_m *Repository) StoreRole(ctx context.Context, role *entity.Role) error {
+	ret := _m.Called(ctx, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Role) error); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreRole'
+type Repository_StoreRole_Call struct {
+	*mock.Call
+}
+
+// StoreRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - role *entity.Role
+func (_e *Repository_Expecter) StoreRole(ctx interface{}, role interface{}) *Repository_StoreRole_Call {
+	return &Repository_StoreRole_Call{Call: _e.mock.On("StoreRole", ctx, role)}
+}
+
+func (_c *Repository_StoreRole_Call) Run(run func(ctx context.Context, role *entity.Role)) *Repository_StoreRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Role))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreRole_Call) Return(err error) *Repository_StoreRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreRole_Call) RunAndReturn(run func(context.Context, *entity.Role) error) *Repository_StoreRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreRoleBinding provides a mock function with given fields: ctx, binding
+func (_m *Repository) StoreRoleBinding(ctx context.Context, binding *entity.RoleBinding) error {
+	ret := _m.Called(ctx, binding)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.RoleBinding) error); ok {
+		r0 = rf(ctx, binding)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreRoleBinding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreRoleBinding'
+type Repository_StoreRoleBinding_Call struct {
+	*mock.Call
+}
+
+// StoreRoleBinding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - binding *entity.RoleBinding
+func (_e *Repository_Expecter) StoreRoleBinding(ctx interface{}, binding interface{}) *Repository_StoreRoleBinding_Call {
+	return &Repository_StoreRoleBinding_Call{Call: _e.mock.On("StoreRoleBinding", ctx, binding)}
+}
+
+func (_c *Repository_StoreRoleBinding_Call) Run(run func(ctx context.Context, binding *entity.RoleBinding)) *Repository_StoreRoleBinding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.RoleBinding))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreRoleBinding_Call) Return(err error) *Repository_StoreRoleBinding_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreRoleBinding_Call) RunAndReturn(run func(context.Context, *entity.RoleBinding) error) *Repository_StoreRoleBinding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateGroup provides a mock function with given fields: ctx, group
+func (_m *Repository) UpdateGroup(ctx context.Context, group *entity.Group) error {
+	ret := _m.Called(ctx, group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Group) error); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGroup'
+type Repository_UpdateGroup_Call struct {
+	*mock.Call
+}
+
+// UpdateGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group *entity.Group
+func (_e *Repository_Expecter) UpdateGroup(ctx interface{}, group interface{}) *Repository_UpdateGroup_Call {
+	return &Repository_UpdateGroup_Call{Call: _e.mock.On("UpdateGroup", ctx, group)}
+}
+
+func (_c *Repository_UpdateGroup_Call) Run(run func(ctx context.Context, group *entity.Group)) *Repository_UpdateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Group))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateGroup_Call) Return(err error) *Repository_UpdateGroup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateGroup_Call) RunAndReturn(run func(context.Context, *entity.Group) error) *Repository_UpdateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePermission provides a mock function with given fields: ctx, permission
+func (_m *Repository) UpdatePermission(ctx context.Context, permission *entity.Permission) error {
+	ret := _m.Called(ctx, permission)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Permission) error); ok {
+		r0 = rf(ctx, permission)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdatePermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePermission'
+type Repository_UpdatePermission_Call struct {
+	*mock.Call
+}
+
+// UpdatePermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - permission *entity.Permission
+func (_e *Repository_Expecter) UpdatePermission(ctx interface{}, permission interface{}) *Repository_UpdatePermission_Call {
+	return &Repository_UpdatePermission_Call{Call: _e.mock.On("UpdatePermission", ctx, permission)}
+}
+
+func (_c *Repository_UpdatePermission_Call) Run(run func(ctx context.Context, permission *entity.Permission)) *Repository_UpdatePermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Permission))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdatePermission_Call) Return(err error) *Repository_UpdatePermission_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdatePermission_Call) RunAndReturn(run func(context.Context, *entity.Permission) error) *Repository_UpdatePermission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRole provides a mock function with given fields: ctx, role
+func (_m *Repository) UpdateRole(ctx context.Context, role *entity.Role) error {
+	ret := _m.Called(ctx, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Role) error); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type Repository_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - role *entity.Role
+func (_e *Repository_Expecter) UpdateRole(ctx interface{}, role interface{}) *Repository_UpdateRole_Call {
+	return &Repository_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, role)}
+}
+
+func (_c *Repository_UpdateRole_Call) Run(run func(ctx context.Context, role *entity.Role)) *Repository_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Role))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateRole_Call) Return(err error) *Repository_UpdateRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateRole_Call) RunAndReturn(run func(context.Context, *entity.Role) error) *Repository_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
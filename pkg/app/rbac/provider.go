@@ -0,0 +1,17 @@
+package rbac
+
+import (
+	"github.com/google/wire"
+
+	"github.com/karta0898098/iam/pkg/app/rbac/endpoints"
+	"github.com/karta0898098/iam/pkg/app/rbac/repository"
+	"github.com/karta0898098/iam/pkg/app/rbac/service"
+)
+
+var DefaultProvider = wire.NewSet(
+	endpoints.New,
+	service.New,
+	service.NewRoleResolver,
+	service.NewPermissionChecker,
+	repository.New,
+)
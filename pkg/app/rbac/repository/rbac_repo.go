@@ -0,0 +1,839 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/karta0898098/iam/pkg/app/rbac/entity"
+	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// PermissionDAO define permission dao
+type PermissionDAO struct {
+	Name        string `gorm:"column:name"`        // Name resource:action string
+	Description string `gorm:"column:description"` // Description what the permission grant
+	CreatedAt   int64  `gorm:"column:created_at"`  // CreatedAt this permission create time
+	UpdatedAt   int64  `gorm:"column:updated_at"`  // UpdatedAt this permission update time
+}
+
+// TableName is PermissionDAO implement table name for gorm
+func (p PermissionDAO) TableName() string {
+	return "permissions"
+}
+
+// UnmarshalPermissionDAO unmarshal entity permission to dao
+func UnmarshalPermissionDAO(permission *entity.Permission) *PermissionDAO {
+	return &PermissionDAO{
+		Name:        permission.Name,
+		Description: permission.Description,
+		CreatedAt:   permission.CreatedAt.UnixMilli(),
+		UpdatedAt:   permission.UpdatedAt.UnixMilli(),
+	}
+}
+
+// UnmarshalPermission unmarshal dao to entity permission
+func UnmarshalPermission(dao *PermissionDAO) *entity.Permission {
+	return &entity.Permission{
+		Name:        dao.Name,
+		Description: dao.Description,
+		CreatedAt:   time.UnixMilli(dao.CreatedAt),
+		UpdatedAt:   time.UnixMilli(dao.UpdatedAt),
+	}
+}
+
+// RoleDAO define role dao
+type RoleDAO struct {
+	ID          string `gorm:"column:id"`          // ID role id
+	Name        string `gorm:"column:name"`        // Name unique role name
+	Description string `gorm:"column:description"` // Description what the role is for
+	CreatedAt   int64  `gorm:"column:created_at"`  // CreatedAt this role create time
+	UpdatedAt   int64  `gorm:"column:updated_at"`  // UpdatedAt this role update time
+}
+
+// TableName is RoleDAO implement table name for gorm
+func (r RoleDAO) TableName() string {
+	return "roles"
+}
+
+// UnmarshalRoleDAO unmarshal entity role to dao
+func UnmarshalRoleDAO(role *entity.Role) *RoleDAO {
+	return &RoleDAO{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		CreatedAt:   role.CreatedAt.UnixMilli(),
+		UpdatedAt:   role.UpdatedAt.UnixMilli(),
+	}
+}
+
+// UnmarshalRole unmarshal dao and granted permissions to entity role
+func UnmarshalRole(dao *RoleDAO, permissions []string) *entity.Role {
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return &entity.Role{
+		ID:          dao.ID,
+		Name:        dao.Name,
+		Description: dao.Description,
+		Permissions: permissions,
+		CreatedAt:   time.UnixMilli(dao.CreatedAt),
+		UpdatedAt:   time.UnixMilli(dao.UpdatedAt),
+	}
+}
+
+// RolePermissionDAO define permission granted to role
+type RolePermissionDAO struct {
+	RoleID     string `gorm:"column:role_id"`
+	Permission string `gorm:"column:permission"`
+}
+
+// TableName is RolePermissionDAO implement table name for gorm
+func (r RolePermissionDAO) TableName() string {
+	return "role_permissions"
+}
+
+// UserRoleDAO define role bound to user
+type UserRoleDAO struct {
+	UserID    string `gorm:"column:user_id"`
+	RoleID    string `gorm:"column:role_id"`
+	CreatedAt int64  `gorm:"column:created_at"`
+}
+
+// TableName is UserRoleDAO implement table name for gorm
+func (u UserRoleDAO) TableName() string {
+	return "user_roles"
+}
+
+// UnmarshalUserRoleDAO unmarshal entity role binding to dao
+func UnmarshalUserRoleDAO(binding *entity.RoleBinding) *UserRoleDAO {
+	return &UserRoleDAO{
+		UserID:    binding.UserID,
+		RoleID:    binding.RoleID,
+		CreatedAt: binding.CreatedAt.UnixMilli(),
+	}
+}
+
+// GroupDAO define group dao
+type GroupDAO struct {
+	ID          string `gorm:"column:id"`          // ID group id
+	Name        string `gorm:"column:name"`        // Name unique group name
+	Description string `gorm:"column:description"` // Description what the group is for
+	CreatedAt   int64  `gorm:"column:created_at"`  // CreatedAt this group create time
+	UpdatedAt   int64  `gorm:"column:updated_at"`  // UpdatedAt this group update time
+}
+
+// TableName is GroupDAO implement table name for gorm
+func (g GroupDAO) TableName() string {
+	return "groups"
+}
+
+// UnmarshalGroupDAO unmarshal entity group to dao
+func UnmarshalGroupDAO(group *entity.Group) *GroupDAO {
+	return &GroupDAO{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		CreatedAt:   group.CreatedAt.UnixMilli(),
+		UpdatedAt:   group.UpdatedAt.UnixMilli(),
+	}
+}
+
+// UnmarshalGroup unmarshal dao to entity group
+func UnmarshalGroup(dao *GroupDAO) *entity.Group {
+	return &entity.Group{
+		ID:          dao.ID,
+		Name:        dao.Name,
+		Description: dao.Description,
+		CreatedAt:   time.UnixMilli(dao.CreatedAt),
+		UpdatedAt:   time.UnixMilli(dao.UpdatedAt),
+	}
+}
+
+// GroupMemberDAO define user belong to group
+type GroupMemberDAO struct {
+	GroupID   string `gorm:"column:group_id"`
+	UserID    string `gorm:"column:user_id"`
+	CreatedAt int64  `gorm:"column:created_at"`
+}
+
+// TableName is GroupMemberDAO implement table name for gorm
+func (g GroupMemberDAO) TableName() string {
+	return "group_members"
+}
+
+// UnmarshalGroupMemberDAO unmarshal entity group member to dao
+func UnmarshalGroupMemberDAO(member *entity.GroupMember) *GroupMemberDAO {
+	return &GroupMemberDAO{
+		GroupID:   member.GroupID,
+		UserID:    member.UserID,
+		CreatedAt: member.CreatedAt.UnixMilli(),
+	}
+}
+
+// Repository define rbac repository pattern
+type Repository interface {
+	// StorePermission add permission into catalog
+	StorePermission(ctx context.Context, permission *entity.Permission) (err error)
+
+	// FindPermissions find permissions of catalog by name, unknown name is skipped
+	FindPermissions(ctx context.Context, names []string) (permissions []*entity.Permission, err error)
+
+	// ListPermissions list permissions of catalog order by name
+	ListPermissions(ctx context.Context) (permissions []*entity.Permission, err error)
+
+	// UpdatePermission update permission description
+	UpdatePermission(ctx context.Context, permission *entity.Permission) (err error)
+
+	// DeletePermission delete permission and revoke it from every role
+	DeletePermission(ctx context.Context, name string) (err error)
+
+	// StoreRole create role with its permissions
+	StoreRole(ctx context.Context, role *entity.Role) (err error)
+
+	// FindRoleByID find role with its permissions
+	FindRoleByID(ctx context.Context, roleID string) (role *entity.Role, err error)
+
+	// FindRoleByName find role with its permissions by unique name
+	FindRoleByName(ctx context.Context, name string) (role *entity.Role, err error)
+
+	// ListRoles list roles with their permissions order by name
+	ListRoles(ctx context.Context) (roles []*entity.Role, err error)
+
+	// UpdateRole update role and replace its permissions
+	UpdateRole(ctx context.Context, role *entity.Role) (err error)
+
+	// DeleteRole delete role and unbind it from every user
+	DeleteRole(ctx context.Context, roleID string) (err error)
+
+	// StoreRoleBinding bind role to user, binding twice is no-op
+	StoreRoleBinding(ctx context.Context, binding *entity.RoleBinding) (err error)
+
+	// DeleteRoleBinding unbind role from user
+	DeleteRoleBinding(ctx context.Context, userID string, roleID string) (err error)
+
+	// FindUserRoles find roles with their permissions bound to user
+	FindUserRoles(ctx context.Context, userID string) (roles []*entity.Role, err error)
+
+	// StoreGroup create group
+	StoreGroup(ctx context.Context, group *entity.Group) (err error)
+
+	// FindGroupByID find group by id
+	FindGroupByID(ctx context.Context, groupID string) (group *entity.Group, err error)
+
+	// FindGroupByName find group by unique name
+	FindGroupByName(ctx context.Context, name string) (group *entity.Group, err error)
+
+	// ListGroups list groups order by name
+	ListGroups(ctx context.Context) (groups []*entity.Group, err error)
+
+	// UpdateGroup update group name and description
+	UpdateGroup(ctx context.Context, group *entity.Group) (err error)
+
+	// DeleteGroup delete group and its memberships
+	DeleteGroup(ctx context.Context, groupID string) (err error)
+
+	// StoreGroupMember add user into group, adding twice is no-op
+	StoreGroupMember(ctx context.Context, member *entity.GroupMember) (err error)
+
+	// DeleteGroupMember remove user from group
+	DeleteGroupMember(ctx context.Context, groupID string, userID string) (err error)
+
+	// FindUserGroups find groups user belong to
+	FindUserGroups(ctx context.Context, userID string) (groups []*entity.Group, err error)
+}
+
+// RBACRepository implement for Repository
+type RBACRepository struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+}
+
+// New Repository constructor
+func New(conn db.Connection) Repository {
+	return &RBACRepository{
+		readDB:  conn.ReadDB(),
+		writeDB: conn.WriteDB(),
+	}
+}
+
+// StorePermission is SQL implement
+func (repo *RBACRepository) StorePermission(ctx context.Context, permission *entity.Permission) (err error) {
+	dao := UnmarshalPermissionDAO(permission)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to create permission=%v, err %v", permission.Name, err)
+	}
+
+	return nil
+}
+
+// FindPermissions is SQL implement
+func (repo *RBACRepository) FindPermissions(ctx context.Context, names []string) (permissions []*entity.Permission, err error) {
+	var (
+		daos []PermissionDAO
+	)
+
+	if len(names) == 0 {
+		return []*entity.Permission{}, nil
+	}
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(PermissionDAO{}).
+		Where("name IN ?", names).
+		Order("name").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	permissions = make([]*entity.Permission, 0, len(daos))
+	for i := range daos {
+		permissions = append(permissions, UnmarshalPermission(&daos[i]))
+	}
+
+	return permissions, nil
+}
+
+// ListPermissions is SQL implement
+func (repo *RBACRepository) ListPermissions(ctx context.Context) (permissions []*entity.Permission, err error) {
+	var (
+		daos []PermissionDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(PermissionDAO{}).
+		Order("name").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	permissions = make([]*entity.Permission, 0, len(daos))
+	for i := range daos {
+		permissions = append(permissions, UnmarshalPermission(&daos[i]))
+	}
+
+	return permissions, nil
+}
+
+// UpdatePermission is SQL implement
+func (repo *RBACRepository) UpdatePermission(ctx context.Context, permission *entity.Permission) (err error) {
+	dao := UnmarshalPermissionDAO(permission)
+
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(PermissionDAO{}).
+		Where("name = ?", permission.Name).
+		Updates(map[string]interface{}{
+			"description": dao.Description,
+			"updated_at":  dao.UpdatedAt,
+		})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update permission=%v, err %v", permission.Name, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "cant not found permission=%v", permission.Name)
+	}
+
+	return nil
+}
+
+// DeletePermission is SQL implement
+func (repo *RBACRepository) DeletePermission(ctx context.Context, name string) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Where("permission = ?", name).
+				Delete(&RolePermissionDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to revoke permission=%v from roles, err %v", name, err)
+			}
+
+			result := tx.
+				Where("name = ?", name).
+				Delete(&PermissionDAO{})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete permission=%v, err %v", name, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "cant not found permission=%v", name)
+			}
+
+			return nil
+		})
+}
+
+// StoreRole is SQL implement
+func (repo *RBACRepository) StoreRole(ctx context.Context, role *entity.Role) (err error) {
+	dao := UnmarshalRoleDAO(role)
+
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Model(dao).
+				Create(dao).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to create role id=%v, err %v", role.ID, err)
+			}
+
+			return storeRolePermissions(tx, role)
+		})
+}
+
+// FindRoleByID is SQL implement
+func (repo *RBACRepository) FindRoleByID(ctx context.Context, roleID string) (role *entity.Role, err error) {
+	if roleID == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input role id is empty")
+	}
+
+	return repo.findRole(ctx, "id = ?", roleID)
+}
+
+// FindRoleByName is SQL implement
+func (repo *RBACRepository) FindRoleByName(ctx context.Context, name string) (role *entity.Role, err error) {
+	if name == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input role name is empty")
+	}
+
+	return repo.findRole(ctx, "name = ?", name)
+}
+
+// findRole find the only role matched condition
+func (repo *RBACRepository) findRole(ctx context.Context, query string, arg string) (*entity.Role, error) {
+	var (
+		dao RoleDAO
+	)
+
+	err := repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Where(query, arg).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found role %v", arg)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	roles, err := repo.withPermissions(ctx, []RoleDAO{dao})
+	if err != nil {
+		return nil, err
+	}
+
+	return roles[0], nil
+}
+
+// ListRoles is SQL implement
+func (repo *RBACRepository) ListRoles(ctx context.Context) (roles []*entity.Role, err error) {
+	var (
+		daos []RoleDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(RoleDAO{}).
+		Order("name").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return repo.withPermissions(ctx, daos)
+}
+
+// UpdateRole is SQL implement
+func (repo *RBACRepository) UpdateRole(ctx context.Context, role *entity.Role) (err error) {
+	dao := UnmarshalRoleDAO(role)
+
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			result := tx.
+				Model(RoleDAO{}).
+				Where("id = ?", role.ID).
+				Updates(map[string]interface{}{
+					"name":        dao.Name,
+					"description": dao.Description,
+					"updated_at":  dao.UpdatedAt,
+				})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to update role id=%v, err %v", role.ID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "cant not found role id=%v", role.ID)
+			}
+
+			err := tx.
+				Where("role_id = ?", role.ID).
+				Delete(&RolePermissionDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete permissions of role id=%v, err %v", role.ID, err)
+			}
+
+			return storeRolePermissions(tx, role)
+		})
+}
+
+// DeleteRole is SQL implement
+func (repo *RBACRepository) DeleteRole(ctx context.Context, roleID string) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Where("role_id = ?", roleID).
+				Delete(&UserRoleDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to unbind role id=%v from users, err %v", roleID, err)
+			}
+
+			err = tx.
+				Where("role_id = ?", roleID).
+				Delete(&RolePermissionDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete permissions of role id=%v, err %v", roleID, err)
+			}
+
+			result := tx.
+				Where("id = ?", roleID).
+				Delete(&RoleDAO{})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete role id=%v, err %v", roleID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "cant not found role id=%v", roleID)
+			}
+
+			return nil
+		})
+}
+
+// StoreRoleBinding is SQL implement
+func (repo *RBACRepository) StoreRoleBinding(ctx context.Context, binding *entity.RoleBinding) (err error) {
+	dao := UnmarshalUserRoleDAO(binding)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to bind role=%v to user=%v, err %v", binding.RoleID, binding.UserID, err)
+	}
+
+	return nil
+}
+
+// DeleteRoleBinding is SQL implement
+func (repo *RBACRepository) DeleteRoleBinding(ctx context.Context, userID string, roleID string) (err error) {
+	result := repo.writeDB.
+		WithContext(ctx).
+		Where("user_id = ? AND role_id = ?", userID, roleID).
+		Delete(&UserRoleDAO{})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to unbind role=%v from user=%v, err %v", roleID, userID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "role=%v is not bound to user=%v", roleID, userID)
+	}
+
+	return nil
+}
+
+// FindUserRoles is SQL implement
+func (repo *RBACRepository) FindUserRoles(ctx context.Context, userID string) (roles []*entity.Role, err error) {
+	var (
+		daos []RoleDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(RoleDAO{}).
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return repo.withPermissions(ctx, daos)
+}
+
+// StoreGroup is SQL implement
+func (repo *RBACRepository) StoreGroup(ctx context.Context, group *entity.Group) (err error) {
+	dao := UnmarshalGroupDAO(group)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to create group id=%v, err %v", group.ID, err)
+	}
+
+	return nil
+}
+
+// FindGroupByID is SQL implement
+func (repo *RBACRepository) FindGroupByID(ctx context.Context, groupID string) (group *entity.Group, err error) {
+	if groupID == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input group id is empty")
+	}
+
+	return repo.findGroup(ctx, "id = ?", groupID)
+}
+
+// FindGroupByName is SQL implement
+func (repo *RBACRepository) FindGroupByName(ctx context.Context, name string) (group *entity.Group, err error) {
+	if name == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input group name is empty")
+	}
+
+	return repo.findGroup(ctx, "name = ?", name)
+}
+
+// findGroup find the only group matched condition
+func (repo *RBACRepository) findGroup(ctx context.Context, query string, arg string) (*entity.Group, error) {
+	var (
+		dao GroupDAO
+	)
+
+	err := repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Where(query, arg).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found group %v", arg)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalGroup(&dao), nil
+}
+
+// ListGroups is SQL implement
+func (repo *RBACRepository) ListGroups(ctx context.Context) (groups []*entity.Group, err error) {
+	var (
+		daos []GroupDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(GroupDAO{}).
+		Order("name").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	groups = make([]*entity.Group, 0, len(daos))
+	for i := range daos {
+		groups = append(groups, UnmarshalGroup(&daos[i]))
+	}
+
+	return groups, nil
+}
+
+// UpdateGroup is SQL implement
+func (repo *RBACRepository) UpdateGroup(ctx context.Context, group *entity.Group) (err error) {
+	dao := UnmarshalGroupDAO(group)
+
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(GroupDAO{}).
+		Where("id = ?", group.ID).
+		Updates(map[string]interface{}{
+			"name":        dao.Name,
+			"description": dao.Description,
+			"updated_at":  dao.UpdatedAt,
+		})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update group id=%v, err %v", group.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "cant not found group id=%v", group.ID)
+	}
+
+	return nil
+}
+
+// DeleteGroup is SQL implement
+func (repo *RBACRepository) DeleteGroup(ctx context.Context, groupID string) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Where("group_id = ?", groupID).
+				Delete(&GroupMemberDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete members of group id=%v, err %v", groupID, err)
+			}
+
+			result := tx.
+				Where("id = ?", groupID).
+				Delete(&GroupDAO{})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete group id=%v, err %v", groupID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "cant not found group id=%v", groupID)
+			}
+
+			return nil
+		})
+}
+
+// StoreGroupMember is SQL implement
+func (repo *RBACRepository) StoreGroupMember(ctx context.Context, member *entity.GroupMember) (err error) {
+	dao := UnmarshalGroupMemberDAO(member)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to add user=%v into group=%v, err %v", member.UserID, member.GroupID, err)
+	}
+
+	return nil
+}
+
+// DeleteGroupMember is SQL implement
+func (repo *RBACRepository) DeleteGroupMember(ctx context.Context, groupID string, userID string) (err error) {
+	result := repo.writeDB.
+		WithContext(ctx).
+		Where("group_id = ? AND user_id = ?", groupID, userID).
+		Delete(&GroupMemberDAO{})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to remove user=%v from group=%v, err %v", userID, groupID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "user=%v is not member of group=%v", userID, groupID)
+	}
+
+	return nil
+}
+
+// FindUserGroups is SQL implement
+func (repo *RBACRepository) FindUserGroups(ctx context.Context, userID string) (groups []*entity.Group, err error) {
+	var (
+		daos []GroupDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(GroupDAO{}).
+		Joins("JOIN group_members ON group_members.group_id = groups.id").
+		Where("group_members.user_id = ?", userID).
+		Order("groups.name").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	groups = make([]*entity.Group, 0, len(daos))
+	for i := range daos {
+		groups = append(groups, UnmarshalGroup(&daos[i]))
+	}
+
+	return groups, nil
+}
+
+// withPermissions load permissions of roles in one query
+func (repo *RBACRepository) withPermissions(ctx context.Context, daos []RoleDAO) ([]*entity.Role, error) {
+	var (
+		grants []RolePermissionDAO
+	)
+
+	roles := make([]*entity.Role, 0, len(daos))
+	if len(daos) == 0 {
+		return roles, nil
+	}
+
+	roleIDs := make([]string, 0, len(daos))
+	for i := range daos {
+		roleIDs = append(roleIDs, daos[i].ID)
+	}
+
+	err := repo.readDB.
+		WithContext(ctx).
+		Model(RolePermissionDAO{}).
+		Where("role_id IN ?", roleIDs).
+		Order("permission").
+		Find(&grants).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	permissions := make(map[string][]string, len(daos))
+	for _, grant := range grants {
+		permissions[grant.RoleID] = append(permissions[grant.RoleID], grant.Permission)
+	}
+
+	for i := range daos {
+		roles = append(roles, UnmarshalRole(&daos[i], permissions[daos[i].ID]))
+	}
+
+	return roles, nil
+}
+
+// storeRolePermissions insert permissions granted to role
+func storeRolePermissions(tx *gorm.DB, role *entity.Role) error {
+	if len(role.Permissions) == 0 {
+		return nil
+	}
+
+	grants := make([]RolePermissionDAO, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		grants = append(grants, RolePermissionDAO{
+			RoleID:     role.ID,
+			Permission: permission,
+		})
+	}
+
+	err := tx.
+		Model(RolePermissionDAO{}).
+		Create(&grants).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to grant permissions to role id=%v, err %v", role.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/rbac/entity"
+	"github.com/karta0898098/iam/pkg/audit"
+)
+
+type auditMiddleware struct {
+	next     RBACService
+	recorder audit.Recorder
+}
+
+// AuditMiddleware record action changing permission, role, group and their bindings to audit log,
+// read only action and permission check are not recorded
+func AuditMiddleware(recorder audit.Recorder) Middleware {
+	return func(next RBACService) RBACService {
+		return auditMiddleware{next: next, recorder: recorder}
+	}
+}
+
+func (am auditMiddleware) CreatePermission(ctx context.Context, name string, description string) (permission *entity.Permission, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionCreatePermission, name, err))
+	}()
+
+	return am.next.CreatePermission(ctx, name, description)
+}
+
+func (am auditMiddleware) ListPermissions(ctx context.Context) (permissions []*entity.Permission, err error) {
+	return am.next.ListPermissions(ctx)
+}
+
+func (am auditMiddleware) UpdatePermission(ctx context.Context, name string, description string) (permission *entity.Permission, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionUpdatePermission, name, err))
+	}()
+
+	return am.next.UpdatePermission(ctx, name, description)
+}
+
+func (am auditMiddleware) DeletePermission(ctx context.Context, name string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionDeletePermission, name, err))
+	}()
+
+	return am.next.DeletePermission(ctx, name)
+}
+
+func (am auditMiddleware) CreateRole(ctx context.Context, opt *RoleOption) (role *entity.Role, err error) {
+	defer func() {
+		var roleID string
+		if role != nil {
+			roleID = role.ID
+		}
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionCreateRole, roleID, err))
+	}()
+
+	return am.next.CreateRole(ctx, opt)
+}
+
+func (am auditMiddleware) GetRole(ctx context.Context, roleID string) (role *entity.Role, err error) {
+	return am.next.GetRole(ctx, roleID)
+}
+
+func (am auditMiddleware) ListRoles(ctx context.Context) (roles []*entity.Role, err error) {
+	return am.next.ListRoles(ctx)
+}
+
+func (am auditMiddleware) UpdateRole(ctx context.Context, roleID string, opt *RoleOption) (role *entity.Role, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionUpdateRole, roleID, err))
+	}()
+
+	return am.next.UpdateRole(ctx, roleID, opt)
+}
+
+func (am auditMiddleware) DeleteRole(ctx context.Context, roleID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionDeleteRole, roleID, err))
+	}()
+
+	return am.next.DeleteRole(ctx, roleID)
+}
+
+func (am auditMiddleware) AssignRole(ctx context.Context, userID string, roleID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionAssignRole, audit.TargetOf(roleID, userID), err))
+	}()
+
+	return am.next.AssignRole(ctx, userID, roleID)
+}
+
+func (am auditMiddleware) UnassignRole(ctx context.Context, userID string, roleID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionUnassignRole, audit.TargetOf(roleID, userID), err))
+	}()
+
+	return am.next.UnassignRole(ctx, userID, roleID)
+}
+
+func (am auditMiddleware) ListUserRoles(ctx context.Context, userID string) (roles []*entity.Role, err error) {
+	return am.next.ListUserRoles(ctx, userID)
+}
+
+func (am auditMiddleware) CheckPermission(ctx context.Context, userID string, permission string) (allowed bool, err error) {
+	return am.next.CheckPermission(ctx, userID, permission)
+}
+
+func (am auditMiddleware) UserRoles(ctx context.Context, userID string) (roles []string, err error) {
+	return am.next.UserRoles(ctx, userID)
+}
+
+func (am auditMiddleware) CreateGroup(ctx context.Context, opt *GroupOption) (group *entity.Group, err error) {
+	defer func() {
+		var groupID string
+		if group != nil {
+			groupID = group.ID
+		}
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionCreateGroup, groupID, err))
+	}()
+
+	return am.next.CreateGroup(ctx, opt)
+}
+
+func (am auditMiddleware) GetGroup(ctx context.Context, groupID string) (group *entity.Group, err error) {
+	return am.next.GetGroup(ctx, groupID)
+}
+
+func (am auditMiddleware) ListGroups(ctx context.Context) (groups []*entity.Group, err error) {
+	return am.next.ListGroups(ctx)
+}
+
+func (am auditMiddleware) UpdateGroup(ctx context.Context, groupID string, opt *GroupOption) (group *entity.Group, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionUpdateGroup, groupID, err))
+	}()
+
+	return am.next.UpdateGroup(ctx, groupID, opt)
+}
+
+func (am auditMiddleware) DeleteGroup(ctx context.Context, groupID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionDeleteGroup, groupID, err))
+	}()
+
+	return am.next.DeleteGroup(ctx, groupID)
+}
+
+func (am auditMiddleware) AddGroupMember(ctx context.Context, groupID string, userID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionAddGroupMember, audit.TargetOf(groupID, userID), err))
+	}()
+
+	return am.next.AddGroupMember(ctx, groupID, userID)
+}
+
+func (am auditMiddleware) RemoveGroupMember(ctx context.Context, groupID string, userID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionRemoveGroupMember, audit.TargetOf(groupID, userID), err))
+	}()
+
+	return am.next.RemoveGroupMember(ctx, groupID, userID)
+}
+
+func (am auditMiddleware) ListUserGroups(ctx context.Context, userID string) (groups []*entity.Group, err error) {
+	return am.next.ListUserGroups(ctx, userID)
+}
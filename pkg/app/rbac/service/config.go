@@ -0,0 +1,26 @@
+package service
+
+import (
+	identitysvc "github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/authn"
+)
+
+// Config for role-based access control
+type Config struct {
+	// TokenRoles embed role names of user into access token as roles claim
+	TokenRoles bool `mapstructure:"token_roles"`
+}
+
+// NewRoleResolver resolve roles embedded into access token,
+// it is nil when roles are not embedded
+func NewRoleResolver(config Config, svc RBACService) identitysvc.RoleResolver {
+	if !config.TokenRoles {
+		return nil
+	}
+	return svc
+}
+
+// NewPermissionChecker rbac service check permission of user for other modules
+func NewPermissionChecker(svc RBACService) authn.PermissionChecker {
+	return svc
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/rbac/entity"
+)
+
+type loggingMiddleware struct {
+	next RBACService `json:"-"`
+}
+
+// LoggingMiddleware takes a logger as a dependency
+// and returns a ServiceMiddleware.
+func LoggingMiddleware() Middleware {
+	return func(next RBACService) RBACService {
+		return loggingMiddleware{next}
+	}
+}
+
+func (lm loggingMiddleware) CreatePermission(ctx context.Context, name string, description string) (permission *entity.Permission, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "CreatePermission",
+		// 	"permission", name,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.CreatePermission(ctx, name, description)
+}
+
+func (lm loggingMiddleware) ListPermissions(ctx context.Context) (permissions []*entity.Permission, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListPermissions",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListPermissions(ctx)
+}
+
+func (lm loggingMiddleware) UpdatePermission(ctx context.Context, name string, description string) (permission *entity.Permission, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "UpdatePermission",
+		// 	"permission", name,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.UpdatePermission(ctx, name, description)
+}
+
+func (lm loggingMiddleware) DeletePermission(ctx context.Context, name string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "DeletePermission",
+		// 	"permission", name,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.DeletePermission(ctx, name)
+}
+
+func (lm loggingMiddleware) CreateRole(ctx context.Context, opt *RoleOption) (role *entity.Role, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "CreateRole",
+		// 	"name", opt.Name,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.CreateRole(ctx, opt)
+}
+
+func (lm loggingMiddleware) GetRole(ctx context.Context, roleID string) (role *entity.Role, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "GetRole",
+		// 	"role_id", roleID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.GetRole(ctx, roleID)
+}
+
+func (lm loggingMiddleware) ListRoles(ctx context.Context) (roles []*entity.Role, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListRoles",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListRoles(ctx)
+}
+
+func (lm loggingMiddleware) UpdateRole(ctx context.Context, roleID string, opt *RoleOption) (role *entity.Role, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "UpdateRole",
+		// 	"role_id", roleID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.UpdateRole(ctx, roleID, opt)
+}
+
+func (lm loggingMiddleware) DeleteRole(ctx context.Context, roleID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "DeleteRole",
+		// 	"role_id", roleID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.DeleteRole(ctx, roleID)
+}
+
+func (lm loggingMiddleware) AssignRole(ctx context.Context, userID string, roleID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "AssignRole",
+		// 	"user_id", userID,
+		// 	"role_id", roleID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.AssignRole(ctx, userID, roleID)
+}
+
+func (lm loggingMiddleware) UnassignRole(ctx context.Context, userID string, roleID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "UnassignRole",
+		// 	"user_id", userID,
+		// 	"role_id", roleID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.UnassignRole(ctx, userID, roleID)
+}
+
+func (lm loggingMiddleware) ListUserRoles(ctx context.Context, userID string) (roles []*entity.Role, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListUserRoles",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListUserRoles(ctx, userID)
+}
+
+func (lm loggingMiddleware) CheckPermission(ctx context.Context, userID string, permission string) (allowed bool, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "CheckPermission",
+		// 	"user_id", userID,
+		// 	"permission", permission,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.CheckPermission(ctx, userID, permission)
+}
+
+func (lm loggingMiddleware) UserRoles(ctx context.Context, userID string) (roles []string, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "UserRoles",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.UserRoles(ctx, userID)
+}
+
+func (lm loggingMiddleware) CreateGroup(ctx context.Context, opt *GroupOption) (group *entity.Group, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "CreateGroup",
+		// 	"name", opt.Name,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.CreateGroup(ctx, opt)
+}
+
+func (lm loggingMiddleware) GetGroup(ctx context.Context, groupID string) (group *entity.Group, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "GetGroup",
+		// 	"group_id", groupID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.GetGroup(ctx, groupID)
+}
+
+func (lm loggingMiddleware) ListGroups(ctx context.Context) (groups []*entity.Group, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListGroups",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListGroups(ctx)
+}
+
+func (lm loggingMiddleware) UpdateGroup(ctx context.Context, groupID string, opt *GroupOption) (group *entity.Group, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "UpdateGroup",
+		// 	"group_id", groupID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.UpdateGroup(ctx, groupID, opt)
+}
+
+func (lm loggingMiddleware) DeleteGroup(ctx context.Context, groupID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "DeleteGroup",
+		// 	"group_id", groupID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.DeleteGroup(ctx, groupID)
+}
+
+func (lm loggingMiddleware) AddGroupMember(ctx context.Context, groupID string, userID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "AddGroupMember",
+		// 	"group_id", groupID,
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.AddGroupMember(ctx, groupID, userID)
+}
+
+func (lm loggingMiddleware) RemoveGroupMember(ctx context.Context, groupID string, userID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "RemoveGroupMember",
+		// 	"group_id", groupID,
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.RemoveGroupMember(ctx, groupID, userID)
+}
+
+func (lm loggingMiddleware) ListUserGroups(ctx context.Context, userID string) (groups []*entity.Group, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListUserGroups",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListUserGroups(ctx, userID)
+}
@@ -0,0 +1,15 @@
+package service
+
+// RoleOption define role name, description and granted permissions,
+// permission with wildcard must be registered in catalog as well
+type RoleOption struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// GroupOption define group name and description
+type GroupOption struct {
+	Name        string
+	Description string
+}
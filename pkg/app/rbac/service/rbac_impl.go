@@ -0,0 +1,512 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	identityrepo "github.com/karta0898098/iam/pkg/app/identity/repository"
+	"github.com/karta0898098/iam/pkg/app/rbac/entity"
+	"github.com/karta0898098/iam/pkg/app/rbac/repository"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+var _ RBACService = &Impl{}
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(RBACService) RBACService
+
+// RBACService define role-based access control
+type RBACService interface {
+	// CreatePermission add permission into catalog
+	CreatePermission(
+		ctx context.Context,
+		name string,
+		description string,
+	) (permission *entity.Permission, err error)
+
+	// ListPermissions list permissions of catalog
+	ListPermissions(
+		ctx context.Context,
+	) (permissions []*entity.Permission, err error)
+
+	// UpdatePermission update permission description, name can not be changed
+	UpdatePermission(
+		ctx context.Context,
+		name string,
+		description string,
+	) (permission *entity.Permission, err error)
+
+	// DeletePermission delete permission and revoke it from every role
+	DeletePermission(
+		ctx context.Context,
+		name string,
+	) (err error)
+
+	// CreateRole create role, granted permissions must be in catalog
+	CreateRole(
+		ctx context.Context,
+		opt *RoleOption,
+	) (role *entity.Role, err error)
+
+	// GetRole get role with its permissions
+	GetRole(
+		ctx context.Context,
+		roleID string,
+	) (role *entity.Role, err error)
+
+	// ListRoles list roles with their permissions
+	ListRoles(
+		ctx context.Context,
+	) (roles []*entity.Role, err error)
+
+	// UpdateRole replace name, description and permissions of role
+	UpdateRole(
+		ctx context.Context,
+		roleID string,
+		opt *RoleOption,
+	) (role *entity.Role, err error)
+
+	// DeleteRole delete role and unbind it from every user
+	DeleteRole(
+		ctx context.Context,
+		roleID string,
+	) (err error)
+
+	// AssignRole bind role to user
+	AssignRole(
+		ctx context.Context,
+		userID string,
+		roleID string,
+	) (err error)
+
+	// UnassignRole unbind role from user
+	UnassignRole(
+		ctx context.Context,
+		userID string,
+		roleID string,
+	) (err error)
+
+	// ListUserRoles list roles bound to user
+	ListUserRoles(
+		ctx context.Context,
+		userID string,
+	) (roles []*entity.Role, err error)
+
+	// CheckPermission any role bound to user grant the permission
+	CheckPermission(
+		ctx context.Context,
+		userID string,
+		permission string,
+	) (allowed bool, err error)
+
+	// UserRoles role names of user embedded into access token
+	UserRoles(
+		ctx context.Context,
+		userID string,
+	) (roles []string, err error)
+
+	// CreateGroup create group without member
+	CreateGroup(
+		ctx context.Context,
+		opt *GroupOption,
+	) (group *entity.Group, err error)
+
+	// GetGroup get group
+	GetGroup(
+		ctx context.Context,
+		groupID string,
+	) (group *entity.Group, err error)
+
+	// ListGroups list groups
+	ListGroups(
+		ctx context.Context,
+	) (groups []*entity.Group, err error)
+
+	// UpdateGroup replace name and description of group
+	UpdateGroup(
+		ctx context.Context,
+		groupID string,
+		opt *GroupOption,
+	) (group *entity.Group, err error)
+
+	// DeleteGroup delete group and its memberships
+	DeleteGroup(
+		ctx context.Context,
+		groupID string,
+	) (err error)
+
+	// AddGroupMember add user into group
+	AddGroupMember(
+		ctx context.Context,
+		groupID string,
+		userID string,
+	) (err error)
+
+	// RemoveGroupMember remove user from group
+	RemoveGroupMember(
+		ctx context.Context,
+		groupID string,
+		userID string,
+	) (err error)
+
+	// ListUserGroups list groups user belong to
+	ListUserGroups(
+		ctx context.Context,
+		userID string,
+	) (groups []*entity.Group, err error)
+}
+
+type Impl struct {
+	repo         repository.Repository
+	identityRepo identityrepo.Repository
+}
+
+func New(
+	repo repository.Repository,
+	identityRepo identityrepo.Repository,
+	recorder audit.Recorder,
+) RBACService {
+	var svc RBACService
+	svc = &Impl{
+		repo:         repo,
+		identityRepo: identityRepo,
+	}
+	svc = LoggingMiddleware()(svc)
+	if recorder != nil {
+		svc = AuditMiddleware(recorder)(svc)
+	}
+
+	return svc
+}
+
+func (srv *Impl) CreatePermission(ctx context.Context, name string, description string) (permission *entity.Permission, err error) {
+	permission, err = entity.NewPermission(name, description)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := srv.repo.FindPermissions(ctx, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) != 0 {
+		return nil, errors.Wrapf(errors.ErrConflict, "permission=%v already exist", name)
+	}
+
+	err = srv.repo.StorePermission(ctx, permission)
+	if err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+func (srv *Impl) ListPermissions(ctx context.Context) (permissions []*entity.Permission, err error) {
+	return srv.repo.ListPermissions(ctx)
+}
+
+func (srv *Impl) UpdatePermission(ctx context.Context, name string, description string) (permission *entity.Permission, err error) {
+	permissions, err := srv.repo.FindPermissions(ctx, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	if len(permissions) == 0 {
+		return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found permission=%v", name)
+	}
+
+	permission = permissions[0]
+	permission.Description = description
+	permission.UpdatedAt = time.Now()
+
+	err = srv.repo.UpdatePermission(ctx, permission)
+	if err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+func (srv *Impl) DeletePermission(ctx context.Context, name string) (err error) {
+	return srv.repo.DeletePermission(ctx, name)
+}
+
+func (srv *Impl) CreateRole(ctx context.Context, opt *RoleOption) (role *entity.Role, err error) {
+	role, err = entity.NewRole(
+		xid.New().String(),
+		opt.Name,
+		opt.Description,
+		opt.Permissions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.checkRoleName(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.checkPermissions(ctx, role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.StoreRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (srv *Impl) GetRole(ctx context.Context, roleID string) (role *entity.Role, err error) {
+	return srv.repo.FindRoleByID(ctx, roleID)
+}
+
+func (srv *Impl) ListRoles(ctx context.Context) (roles []*entity.Role, err error) {
+	return srv.repo.ListRoles(ctx)
+}
+
+func (srv *Impl) UpdateRole(ctx context.Context, roleID string, opt *RoleOption) (role *entity.Role, err error) {
+	role, err = srv.repo.FindRoleByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = role.Update(opt.Name, opt.Description, opt.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.checkRoleName(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.checkPermissions(ctx, role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.UpdateRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (srv *Impl) DeleteRole(ctx context.Context, roleID string) (err error) {
+	return srv.repo.DeleteRole(ctx, roleID)
+}
+
+// checkRoleName role name is unique, it identify role in access token
+func (srv *Impl) checkRoleName(ctx context.Context, role *entity.Role) error {
+	existing, err := srv.repo.FindRoleByName(ctx, role.Name)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if existing.ID != role.ID {
+		return errors.Wrapf(errors.ErrConflict, "role name=%v already used by role=%v", role.Name, existing.ID)
+	}
+
+	return nil
+}
+
+// checkPermissions granted permissions must be registered in catalog
+func (srv *Impl) checkPermissions(ctx context.Context, permissions []string) error {
+	found, err := srv.repo.FindPermissions(ctx, permissions)
+	if err != nil {
+		return err
+	}
+
+	if len(found) == len(permissions) {
+		return nil
+	}
+
+	registered := make(map[string]bool, len(found))
+	for _, permission := range found {
+		registered[permission.Name] = true
+	}
+
+	var unknown []string
+	for _, permission := range permissions {
+		if !registered[permission] {
+			unknown = append(unknown, permission)
+		}
+	}
+
+	return errors.Wrapf(errors.ErrInvalidInput, "permissions=%v are not registered", strings.Join(unknown, ","))
+}
+
+func (srv *Impl) AssignRole(ctx context.Context, userID string, roleID string) (err error) {
+	_, err = srv.identityRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = srv.repo.FindRoleByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	return srv.repo.StoreRoleBinding(ctx, entity.NewRoleBinding(userID, roleID))
+}
+
+func (srv *Impl) UnassignRole(ctx context.Context, userID string, roleID string) (err error) {
+	return srv.repo.DeleteRoleBinding(ctx, userID, roleID)
+}
+
+func (srv *Impl) ListUserRoles(ctx context.Context, userID string) (roles []*entity.Role, err error) {
+	_, err = srv.identityRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.repo.FindUserRoles(ctx, userID)
+}
+
+func (srv *Impl) CheckPermission(ctx context.Context, userID string, permission string) (allowed bool, err error) {
+	err = entity.ValidateCheckedPermission(permission)
+	if err != nil {
+		return false, err
+	}
+
+	roles, err := srv.repo.FindUserRoles(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range roles {
+		if role.Allows(permission) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (srv *Impl) UserRoles(ctx context.Context, userID string) (roles []string, err error) {
+	bound, err := srv.repo.FindUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles = make([]string, 0, len(bound))
+	for _, role := range bound {
+		roles = append(roles, role.Name)
+	}
+
+	return roles, nil
+}
+
+func (srv *Impl) CreateGroup(ctx context.Context, opt *GroupOption) (group *entity.Group, err error) {
+	group, err = entity.NewGroup(
+		xid.New().String(),
+		opt.Name,
+		opt.Description,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.checkGroupName(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.StoreGroup(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (srv *Impl) GetGroup(ctx context.Context, groupID string) (group *entity.Group, err error) {
+	return srv.repo.FindGroupByID(ctx, groupID)
+}
+
+func (srv *Impl) ListGroups(ctx context.Context) (groups []*entity.Group, err error) {
+	return srv.repo.ListGroups(ctx)
+}
+
+func (srv *Impl) UpdateGroup(ctx context.Context, groupID string, opt *GroupOption) (group *entity.Group, err error) {
+	group, err = srv.repo.FindGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = group.Update(opt.Name, opt.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.checkGroupName(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.UpdateGroup(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (srv *Impl) DeleteGroup(ctx context.Context, groupID string) (err error) {
+	return srv.repo.DeleteGroup(ctx, groupID)
+}
+
+// checkGroupName group name is unique
+func (srv *Impl) checkGroupName(ctx context.Context, group *entity.Group) error {
+	existing, err := srv.repo.FindGroupByName(ctx, group.Name)
+	if err != nil {
+		if errors.Is(err, errors.ErrResourceNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if existing.ID != group.ID {
+		return errors.Wrapf(errors.ErrConflict, "group name=%v already used by group=%v", group.Name, existing.ID)
+	}
+
+	return nil
+}
+
+func (srv *Impl) AddGroupMember(ctx context.Context, groupID string, userID string) (err error) {
+	_, err = srv.identityRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = srv.repo.FindGroupByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	return srv.repo.StoreGroupMember(ctx, entity.NewGroupMember(groupID, userID))
+}
+
+func (srv *Impl) RemoveGroupMember(ctx context.Context, groupID string, userID string) (err error) {
+	return srv.repo.DeleteGroupMember(ctx, groupID, userID)
+}
+
+func (srv *Impl) ListUserGroups(ctx context.Context, userID string) (groups []*entity.Group, err error) {
+	_, err = srv.identityRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.repo.FindUserGroups(ctx, userID)
+}
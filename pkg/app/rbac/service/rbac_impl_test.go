@@ -0,0 +1,232 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	identitymocks "github.com/karta0898098/iam/pkg/app/identity/mocks"
+	"github.com/karta0898098/iam/pkg/app/rbac/entity"
+	"github.com/karta0898098/iam/pkg/app/rbac/mocks"
+	"github.com/karta0898098/iam/pkg/app/rbac/repository"
+	"github.com/karta0898098/iam/pkg/app/rbac/service"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+func TestImpl_CheckPermission(t *testing.T) {
+	roles := []*entity.Role{
+		{ID: "MOCK-EDITOR-ID", Name: "editor", Permissions: []string{"documents:*"}},
+		{ID: "MOCK-AUDITOR-ID", Name: "auditor", Permissions: []string{"*:read"}},
+	}
+
+	tests := []struct {
+		name       string
+		repo       repository.Repository
+		permission string
+		expected   bool
+		err        error
+	}{
+		{
+			name: "Action Wildcard",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserRoles(mock.Anything, "MOCK-USER-ID").
+					Return(roles, nil)
+				return repo
+			}(),
+			permission: "documents:delete",
+			expected:   true,
+		},
+		{
+			name: "Resource Wildcard",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserRoles(mock.Anything, "MOCK-USER-ID").
+					Return(roles, nil)
+				return repo
+			}(),
+			permission: "invoices:read",
+			expected:   true,
+		},
+		{
+			name: "Not Granted",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserRoles(mock.Anything, "MOCK-USER-ID").
+					Return(roles, nil)
+				return repo
+			}(),
+			permission: "invoices:write",
+			expected:   false,
+		},
+		{
+			name: "No Role",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindUserRoles(mock.Anything, "MOCK-USER-ID").
+					Return([]*entity.Role{}, nil)
+				return repo
+			}(),
+			permission: "documents:read",
+			expected:   false,
+		},
+		{
+			name:       "Wildcard Checked",
+			repo:       mocks.NewRepository(t),
+			permission: "documents:*",
+			err:        errors.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo, identitymocks.NewRepository(t), nil)
+			actual, err := srv.CheckPermission(ctx, "MOCK-USER-ID", tt.permission)
+			if tt.err != nil || err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("CheckPermission() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestImpl_CreateRole(t *testing.T) {
+	tests := []struct {
+		name string
+		repo repository.Repository
+		opt  *service.RoleOption
+		err  error
+	}{
+		{
+			name: "Success",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindRoleByName(mock.Anything, "editor").
+					Return(nil, errors.ErrResourceNotFound)
+				repo.EXPECT().
+					FindPermissions(mock.Anything, []string{"documents:read", "documents:write"}).
+					Return([]*entity.Permission{{Name: "documents:read"}, {Name: "documents:write"}}, nil)
+				repo.EXPECT().
+					StoreRole(mock.Anything, mock.MatchedBy(func(role *entity.Role) bool {
+						return role.Name == "editor" && len(role.Permissions) == 2
+					})).
+					Return(nil)
+				return repo
+			}(),
+			opt: &service.RoleOption{
+				Name:        "editor",
+				Permissions: []string{"documents:write", "documents:read", "documents:read"},
+			},
+		},
+		{
+			name: "Name Used",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindRoleByName(mock.Anything, "editor").
+					Return(&entity.Role{ID: "MOCK-ROLE-ID", Name: "editor"}, nil)
+				return repo
+			}(),
+			opt: &service.RoleOption{
+				Name:        "editor",
+				Permissions: []string{"documents:read"},
+			},
+			err: errors.ErrConflict,
+		},
+		{
+			name: "Permission Not Registered",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindRoleByName(mock.Anything, "editor").
+					Return(nil, errors.ErrResourceNotFound)
+				repo.EXPECT().
+					FindPermissions(mock.Anything, []string{"documents:delete", "documents:read"}).
+					Return([]*entity.Permission{{Name: "documents:read"}}, nil)
+				return repo
+			}(),
+			opt: &service.RoleOption{
+				Name:        "editor",
+				Permissions: []string{"documents:read", "documents:delete"},
+			},
+			err: errors.ErrInvalidInput,
+		},
+		{
+			name: "Invalid Permission",
+			repo: mocks.NewRepository(t),
+			opt: &service.RoleOption{
+				Name:        "editor",
+				Permissions: []string{"documents"},
+			},
+			err: errors.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := service.New(tt.repo, identitymocks.NewRepository(t), nil)
+			actual, err := srv.CreateRole(ctx, tt.opt)
+			if tt.err != nil || err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("CreateRole() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+			assert.NotEmpty(t, actual.ID)
+			assert.Equal(t, []string{"documents:read", "documents:write"}, actual.Permissions)
+		})
+	}
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	ctx := authn.NewContext(context.Background(), &authn.Principal{UserID: "MOCK-ADMIN-ID"})
+
+	next := mocks.NewRBACService(t)
+	next.EXPECT().
+		AssignRole(mock.Anything, "MOCK-USER-ID", "MOCK-ROLE-ID").
+		Return(nil)
+	next.EXPECT().
+		RemoveGroupMember(mock.Anything, "MOCK-GROUP-ID", "MOCK-USER-ID").
+		Return(errors.ErrResourceNotFound)
+	next.EXPECT().
+		CheckPermission(mock.Anything, "MOCK-USER-ID", "documents:read").
+		Return(true, nil)
+
+	var events []*audit.Event
+	recorder := audit.RecorderFunc(func(ctx context.Context, event *audit.Event) error {
+		events = append(events, event)
+		return nil
+	})
+	srv := service.AuditMiddleware(recorder)(next)
+
+	assert.NoError(t, srv.AssignRole(ctx, "MOCK-USER-ID", "MOCK-ROLE-ID"))
+	err := srv.RemoveGroupMember(ctx, "MOCK-GROUP-ID", "MOCK-USER-ID")
+	assert.True(t, errors.Is(err, errors.ErrResourceNotFound), err)
+
+	// permission check is not recorded
+	_, err = srv.CheckPermission(ctx, "MOCK-USER-ID", "documents:read")
+	assert.NoError(t, err)
+
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, audit.ActionAssignRole, events[0].Action)
+		assert.Equal(t, "MOCK-ADMIN-ID", events[0].Actor)
+		assert.Equal(t, "MOCK-ROLE-ID/MOCK-USER-ID", events[0].Target)
+		assert.Equal(t, audit.OutcomeSuccess, events[0].Outcome)
+
+		assert.Equal(t, audit.ActionRemoveGroupMember, events[1].Action)
+		assert.Equal(t, "MOCK-GROUP-ID/MOCK-USER-ID", events[1].Target)
+		assert.Equal(t, audit.OutcomeFailure, events[1].Outcome)
+	}
+}
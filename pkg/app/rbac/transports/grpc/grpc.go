@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+
+	pb "github.com/karta0898098/iam/pb/rbac"
+	"github.com/karta0898098/iam/pkg/app/rbac/endpoints"
+	"github.com/karta0898098/iam/pkg/authn"
+)
+
+type grpcServer struct {
+	checkPermission grpctransport.Handler
+}
+
+func (g *grpcServer) CheckPermission(ctx context.Context, req *pb.CheckPermissionReq) (*pb.CheckPermissionResp, error) {
+	_, rp, err := g.checkPermission.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := (rp).(*pb.CheckPermissionResp)
+	return reply, nil
+}
+
+// MakeGRPCServer make rbac grpc server
+func MakeGRPCServer(endpoints endpoints.Endpoints) (req pb.RBACServiceServer) {
+	options := []grpctransport.ServerOption{
+		// bearer token of authorization metadata is authenticated by endpoint middleware
+		grpctransport.ServerBefore(authn.GRPCToContext()),
+	}
+
+	return &grpcServer{
+		checkPermission: grpctransport.NewServer(
+			endpoints.CheckPermissionEndpoint,
+			decodeGRPCCheckPermissionRequest,
+			encodeGRPCCheckPermissionResponse,
+			options...,
+		),
+	}
+}
+
+// decodeGRPCCheckPermissionRequest is a transport/grpc.DecodeRequestFunc that converts a
+// gRPC request to a user-domain request. Primarily useful in a server.
+func decodeGRPCCheckPermissionRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.CheckPermissionReq)
+
+	return &endpoints.CheckPermissionRequest{
+		UserID:     req.UserID,
+		Permission: req.Permission,
+	}, nil
+}
+
+// encodeGRPCCheckPermissionResponse is a transport/grpc.EncodeResponseFunc that converts a
+// user-domain response to a gRPC reply. Primarily useful in a server.
+func encodeGRPCCheckPermissionResponse(_ context.Context, grpcReply interface{}) (res interface{}, err error) {
+	reply := grpcReply.(*endpoints.CheckPermissionResponse)
+	return &pb.CheckPermissionResp{
+		Allowed: reply.Allowed,
+	}, nil
+}
@@ -0,0 +1,463 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/app/rbac/endpoints"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	pkghttp "github.com/karta0898098/iam/pkg/http"
+)
+
+// MakeCreatePermission make create permission endpoint
+func MakeCreatePermission(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.CreatePermissionEndpoint,
+		decodeHTTPCreatePermissionRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPCreatePermissionRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPCreatePermissionRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.CreatePermissionRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, nil
+}
+
+// MakeListPermissions make list permissions endpoint
+func MakeListPermissions(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListPermissionsEndpoint,
+		decodeHTTPListPermissionsRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListPermissionsRequest is a transport/http.DecodeRequestFunc that decodes
+// list request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPListPermissionsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListPermissionsRequest{}, nil
+}
+
+// MakeUpdatePermission make update permission endpoint
+func MakeUpdatePermission(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.UpdatePermissionEndpoint,
+		decodeHTTPUpdatePermissionRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPUpdatePermissionRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPUpdatePermissionRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.UpdatePermissionRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.Name = pkghttp.PathParam(r, "name")
+	return &req, nil
+}
+
+// MakeDeletePermission make delete permission endpoint
+func MakeDeletePermission(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DeletePermissionEndpoint,
+		decodeHTTPDeletePermissionRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPDeletePermissionRequest is a transport/http.DecodeRequestFunc that decodes
+// permission name from the URL path. Primarily useful in a server.
+func decodeHTTPDeletePermissionRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.DeletePermissionRequest{
+		Name: pkghttp.PathParam(r, "name"),
+	}, nil
+}
+
+// MakeCreateRole make create role endpoint
+func MakeCreateRole(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.CreateRoleEndpoint,
+		decodeHTTPCreateRoleRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPCreateRoleRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPCreateRoleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.CreateRoleRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, nil
+}
+
+// MakeGetRole make get role endpoint
+func MakeGetRole(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.GetRoleEndpoint,
+		decodeHTTPGetRoleRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPGetRoleRequest is a transport/http.DecodeRequestFunc that decodes
+// role id from the URL path. Primarily useful in a server.
+func decodeHTTPGetRoleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.GetRoleRequest{
+		RoleID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeListRoles make list roles endpoint
+func MakeListRoles(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListRolesEndpoint,
+		decodeHTTPListRolesRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListRolesRequest is a transport/http.DecodeRequestFunc that decodes
+// list request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPListRolesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListRolesRequest{}, nil
+}
+
+// MakeUpdateRole make update role endpoint
+func MakeUpdateRole(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.UpdateRoleEndpoint,
+		decodeHTTPUpdateRoleRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPUpdateRoleRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPUpdateRoleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.UpdateRoleRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.RoleID = pkghttp.PathParam(r, "id")
+	return &req, nil
+}
+
+// MakeDeleteRole make delete role endpoint
+func MakeDeleteRole(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DeleteRoleEndpoint,
+		decodeHTTPDeleteRoleRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPDeleteRoleRequest is a transport/http.DecodeRequestFunc that decodes
+// role id from the URL path. Primarily useful in a server.
+func decodeHTTPDeleteRoleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.DeleteRoleRequest{
+		RoleID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeAssignRole make bind role to user endpoint
+func MakeAssignRole(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.AssignRoleEndpoint,
+		decodeHTTPAssignRoleRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPAssignRoleRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPAssignRoleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.AssignRoleRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.UserID = pkghttp.PathParam(r, "id")
+	return &req, nil
+}
+
+// MakeUnassignRole make unbind role from user endpoint
+func MakeUnassignRole(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.UnassignRoleEndpoint,
+		decodeHTTPUnassignRoleRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPUnassignRoleRequest is a transport/http.DecodeRequestFunc that decodes
+// user id and role id from the URL path. Primarily useful in a server.
+func decodeHTTPUnassignRoleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.UnassignRoleRequest{
+		UserID: pkghttp.PathParam(r, "id"),
+		RoleID: pkghttp.PathParam(r, "role_id"),
+	}, nil
+}
+
+// MakeListUserRoles make list roles bound to user endpoint
+func MakeListUserRoles(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListUserRolesEndpoint,
+		decodeHTTPListUserRolesRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListUserRolesRequest is a transport/http.DecodeRequestFunc that decodes
+// user id from the URL path. Primarily useful in a server.
+func decodeHTTPListUserRolesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListUserRolesRequest{
+		UserID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeCreateGroup make create group endpoint
+func MakeCreateGroup(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.CreateGroupEndpoint,
+		decodeHTTPCreateGroupRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPCreateGroupRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPCreateGroupRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.CreateGroupRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, nil
+}
+
+// MakeGetGroup make get group endpoint
+func MakeGetGroup(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.GetGroupEndpoint,
+		decodeHTTPGetGroupRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPGetGroupRequest is a transport/http.DecodeRequestFunc that decodes
+// group id from the URL path. Primarily useful in a server.
+func decodeHTTPGetGroupRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.GetGroupRequest{
+		GroupID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeListGroups make list groups endpoint
+func MakeListGroups(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListGroupsEndpoint,
+		decodeHTTPListGroupsRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListGroupsRequest is a transport/http.DecodeRequestFunc that decodes
+// list request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPListGroupsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListGroupsRequest{}, nil
+}
+
+// MakeUpdateGroup make update group endpoint
+func MakeUpdateGroup(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.UpdateGroupEndpoint,
+		decodeHTTPUpdateGroupRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPUpdateGroupRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPUpdateGroupRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.UpdateGroupRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.GroupID = pkghttp.PathParam(r, "id")
+	return &req, nil
+}
+
+// MakeDeleteGroup make delete group endpoint
+func MakeDeleteGroup(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DeleteGroupEndpoint,
+		decodeHTTPDeleteGroupRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPDeleteGroupRequest is a transport/http.DecodeRequestFunc that decodes
+// group id from the URL path. Primarily useful in a server.
+func decodeHTTPDeleteGroupRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.DeleteGroupRequest{
+		GroupID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeAddGroupMember make add user into group endpoint
+func MakeAddGroupMember(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.AddGroupMemberEndpoint,
+		decodeHTTPAddGroupMemberRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPAddGroupMemberRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPAddGroupMemberRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.AddGroupMemberRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.GroupID = pkghttp.PathParam(r, "id")
+	return &req, nil
+}
+
+// MakeRemoveGroupMember make remove user from group endpoint
+func MakeRemoveGroupMember(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.RemoveGroupMemberEndpoint,
+		decodeHTTPRemoveGroupMemberRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPRemoveGroupMemberRequest is a transport/http.DecodeRequestFunc that decodes
+// group id and user id from the URL path. Primarily useful in a server.
+func decodeHTTPRemoveGroupMemberRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.RemoveGroupMemberRequest{
+		GroupID: pkghttp.PathParam(r, "id"),
+		UserID:  pkghttp.PathParam(r, "user_id"),
+	}, nil
+}
+
+// MakeListUserGroups make list groups user belong to endpoint
+func MakeListUserGroups(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListUserGroupsEndpoint,
+		decodeHTTPListUserGroupsRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListUserGroupsRequest is a transport/http.DecodeRequestFunc that decodes
+// user id from the URL path. Primarily useful in a server.
+func decodeHTTPListUserGroupsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListUserGroupsRequest{
+		UserID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// encodeHTTPResponse is a transport/http.EncodeResponseFunc that encodes
+// response as JSON into the HTTP response body. Primarily useful in a server.
+func encodeHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	if headers, ok := response.(httptransport.Headerer); ok {
+		for k, values := range headers.Headers() {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+	code := http.StatusOK
+	if sc, ok := response.(httptransport.StatusCoder); ok {
+		code = sc.StatusCode()
+	}
+	w.WriteHeader(code)
+	if code == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
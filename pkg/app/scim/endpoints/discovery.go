@@ -0,0 +1,31 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/scim/entity"
+	"github.com/karta0898098/iam/pkg/app/scim/service"
+	"github.com/karta0898098/iam/pkg/scim"
+)
+
+// MakeGetServiceProviderConfigEndpoint make get service provider config endpoint
+func MakeGetServiceProviderConfigEndpoint(config service.Config) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		return scim.NewServiceProviderConfig(service.CountMax, config.BaseURL()+"/ServiceProviderConfig"), nil
+	}
+}
+
+// MakeListResourceTypesEndpoint make list resource types endpoint
+func MakeListResourceTypesEndpoint(config service.Config) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		url := config.BaseURL() + "/ResourceTypes/"
+		resources := []interface{}{
+			scim.NewResourceType(entity.ResourceTypeUser, "/Users", scim.SchemaUser, "User Account", url+entity.ResourceTypeUser),
+			scim.NewResourceType(entity.ResourceTypeGroup, "/Groups", scim.SchemaGroup, "Group", url+entity.ResourceTypeGroup),
+		}
+
+		return scim.NewListResponse(int64(len(resources)), 1, resources), nil
+	}
+}
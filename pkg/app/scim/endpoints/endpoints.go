@@ -0,0 +1,159 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/go-kit/kit/endpoint"
+
+	identityendpoints "github.com/karta0898098/iam/pkg/app/identity/endpoints"
+	"github.com/karta0898098/iam/pkg/app/scim/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/tenant"
+)
+
+// ClientID is client id of principal authenticated by SCIM token
+const ClientID = "scim"
+
+// Endpoints define SCIM 2.0 provisioning endpoints
+type Endpoints struct {
+	ListUsersEndpoint                endpoint.Endpoint
+	GetUserEndpoint                  endpoint.Endpoint
+	CreateUserEndpoint               endpoint.Endpoint
+	ReplaceUserEndpoint              endpoint.Endpoint
+	PatchUserEndpoint                endpoint.Endpoint
+	DeleteUserEndpoint               endpoint.Endpoint
+	ListGroupsEndpoint               endpoint.Endpoint
+	GetGroupEndpoint                 endpoint.Endpoint
+	CreateGroupEndpoint              endpoint.Endpoint
+	ReplaceGroupEndpoint             endpoint.Endpoint
+	PatchGroupEndpoint               endpoint.Endpoint
+	DeleteGroupEndpoint              endpoint.Endpoint
+	GetServiceProviderConfigEndpoint endpoint.Endpoint
+	ListResourceTypesEndpoint        endpoint.Endpoint
+}
+
+// New endpoints, every endpoint require bearer token of SCIM config
+func New(
+	svc service.SCIMService,
+	config service.Config,
+) (ep Endpoints) {
+	authenticator := NewAuthenticator(config)
+
+	listUsersEndpoint := MakeListUsersEndpoint(svc)
+	listUsersEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListUsers"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(listUsersEndpoint)
+	ep.ListUsersEndpoint = listUsersEndpoint
+
+	getUserEndpoint := MakeGetUserEndpoint(svc)
+	getUserEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("GetUser"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(getUserEndpoint)
+	ep.GetUserEndpoint = getUserEndpoint
+
+	createUserEndpoint := MakeCreateUserEndpoint(svc)
+	createUserEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("CreateUser"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(createUserEndpoint)
+	ep.CreateUserEndpoint = createUserEndpoint
+
+	replaceUserEndpoint := MakeReplaceUserEndpoint(svc)
+	replaceUserEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ReplaceUser"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(replaceUserEndpoint)
+	ep.ReplaceUserEndpoint = replaceUserEndpoint
+
+	patchUserEndpoint := MakePatchUserEndpoint(svc)
+	patchUserEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("PatchUser"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(patchUserEndpoint)
+	ep.PatchUserEndpoint = patchUserEndpoint
+
+	deleteUserEndpoint := MakeDeleteUserEndpoint(svc)
+	deleteUserEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("DeleteUser"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(deleteUserEndpoint)
+	ep.DeleteUserEndpoint = deleteUserEndpoint
+
+	listGroupsEndpoint := MakeListGroupsEndpoint(svc)
+	listGroupsEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListGroups"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(listGroupsEndpoint)
+	ep.ListGroupsEndpoint = listGroupsEndpoint
+
+	getGroupEndpoint := MakeGetGroupEndpoint(svc)
+	getGroupEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("GetGroup"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(getGroupEndpoint)
+	ep.GetGroupEndpoint = getGroupEndpoint
+
+	createGroupEndpoint := MakeCreateGroupEndpoint(svc)
+	createGroupEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("CreateGroup"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(createGroupEndpoint)
+	ep.CreateGroupEndpoint = createGroupEndpoint
+
+	replaceGroupEndpoint := MakeReplaceGroupEndpoint(svc)
+	replaceGroupEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ReplaceGroup"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(replaceGroupEndpoint)
+	ep.ReplaceGroupEndpoint = replaceGroupEndpoint
+
+	patchGroupEndpoint := MakePatchGroupEndpoint(svc)
+	patchGroupEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("PatchGroup"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(patchGroupEndpoint)
+	ep.PatchGroupEndpoint = patchGroupEndpoint
+
+	deleteGroupEndpoint := MakeDeleteGroupEndpoint(svc)
+	deleteGroupEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("DeleteGroup"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(deleteGroupEndpoint)
+	ep.DeleteGroupEndpoint = deleteGroupEndpoint
+
+	getServiceProviderConfigEndpoint := MakeGetServiceProviderConfigEndpoint(config)
+	getServiceProviderConfigEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("GetServiceProviderConfig"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(getServiceProviderConfigEndpoint)
+	ep.GetServiceProviderConfigEndpoint = getServiceProviderConfigEndpoint
+
+	listResourceTypesEndpoint := MakeListResourceTypesEndpoint(config)
+	listResourceTypesEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListResourceTypes"),
+		authn.NewEndpointMiddleware(authenticator),
+	)(listResourceTypesEndpoint)
+	ep.ListResourceTypesEndpoint = listResourceTypesEndpoint
+
+	return ep
+}
+
+// NewAuthenticator authenticate provisioning client by bearer tokens of config,
+// client provision users of default tenant
+func NewAuthenticator(config service.Config) authn.Authenticator {
+	return authn.AuthenticatorFunc(func(ctx context.Context, token string) (*authn.Principal, error) {
+		for _, t := range config.Tokens {
+			if t != "" && subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+				return &authn.Principal{
+					ClientID: ClientID,
+					TenantID: tenant.Default,
+				}, nil
+			}
+		}
+		return nil, errors.Wrap(errors.ErrUnauthorized, "scim token is not valid")
+	})
+}
@@ -0,0 +1,127 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/scim/entity"
+	"github.com/karta0898098/iam/pkg/app/scim/service"
+	"github.com/karta0898098/iam/pkg/scim"
+)
+
+// GroupRequest define create or replace group request
+type GroupRequest struct {
+	ID      string
+	IfMatch string
+	Group   *entity.Group
+}
+
+// GroupResponse define group resource response, version of group is sent as ETag
+type GroupResponse struct {
+	*entity.Group
+	status int
+}
+
+// Headers is GroupResponse implement httptransport.Headerer
+func (r *GroupResponse) Headers() http.Header {
+	return resourceHeaders(r.Group.Meta, r.status)
+}
+
+// StatusCode is GroupResponse implement httptransport.StatusCoder
+func (r *GroupResponse) StatusCode() int {
+	return r.status
+}
+
+// MakeListGroupsEndpoint make list groups endpoint
+func MakeListGroupsEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ListRequest)
+
+		return svc.ListGroups(ctx, &service.ListOption{
+			Filter:     req.Filter,
+			StartIndex: req.StartIndex,
+			Count:      req.Count,
+		})
+	}
+}
+
+// MakeGetGroupEndpoint make get group endpoint
+func MakeGetGroupEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*GetRequest)
+
+		group, err := svc.GetGroup(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.IfNoneMatch != "" && scim.MatchETag(req.IfNoneMatch, group.Meta.Version) {
+			return &GroupResponse{Group: group, status: http.StatusNotModified}, nil
+		}
+		return &GroupResponse{Group: group, status: http.StatusOK}, nil
+	}
+}
+
+// MakeCreateGroupEndpoint make create group endpoint
+func MakeCreateGroupEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*GroupRequest)
+
+		group, err := svc.CreateGroup(ctx, req.Group)
+		if err != nil {
+			return nil, err
+		}
+
+		return &GroupResponse{Group: group, status: http.StatusCreated}, nil
+	}
+}
+
+// MakeReplaceGroupEndpoint make replace group endpoint
+func MakeReplaceGroupEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*GroupRequest)
+
+		group, err := svc.ReplaceGroup(ctx, req.ID, req.Group, &service.WriteOption{
+			IfMatch: req.IfMatch,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &GroupResponse{Group: group, status: http.StatusOK}, nil
+	}
+}
+
+// MakePatchGroupEndpoint make patch group endpoint
+func MakePatchGroupEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*PatchRequest)
+
+		group, err := svc.PatchGroup(ctx, req.ID, req.Patch, &service.WriteOption{
+			IfMatch: req.IfMatch,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &GroupResponse{Group: group, status: http.StatusOK}, nil
+	}
+}
+
+// MakeDeleteGroupEndpoint make delete group endpoint
+func MakeDeleteGroupEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DeleteRequest)
+
+		err = svc.DeleteGroup(ctx, req.ID, &service.WriteOption{
+			IfMatch: req.IfMatch,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeleteResponse{}, nil
+	}
+}
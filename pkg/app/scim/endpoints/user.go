@@ -0,0 +1,177 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/scim/entity"
+	"github.com/karta0898098/iam/pkg/app/scim/service"
+	"github.com/karta0898098/iam/pkg/scim"
+)
+
+// ListRequest define list resources request, see RFC 7644 section 3.4.2
+type ListRequest struct {
+	Filter     string
+	StartIndex int
+	Count      *int
+}
+
+// GetRequest define get resource request,
+// resource is not sent again when If-None-Match contain its version
+type GetRequest struct {
+	ID          string
+	IfNoneMatch string
+}
+
+// PatchRequest define patch resource request
+type PatchRequest struct {
+	ID      string
+	IfMatch string
+	Patch   *scim.PatchRequest
+}
+
+// DeleteRequest define delete resource request
+type DeleteRequest struct {
+	ID      string
+	IfMatch string
+}
+
+// DeleteResponse define delete resource response
+type DeleteResponse struct {
+}
+
+// StatusCode is DeleteResponse implement httptransport.StatusCoder
+func (r *DeleteResponse) StatusCode() int {
+	return http.StatusNoContent
+}
+
+// UserRequest define create or replace user request
+type UserRequest struct {
+	ID      string
+	IfMatch string
+	User    *entity.User
+}
+
+// UserResponse define user resource response, version of user is sent as ETag
+type UserResponse struct {
+	*entity.User
+	status int
+}
+
+// Headers is UserResponse implement httptransport.Headerer
+func (r *UserResponse) Headers() http.Header {
+	return resourceHeaders(r.User.Meta, r.status)
+}
+
+// StatusCode is UserResponse implement httptransport.StatusCoder
+func (r *UserResponse) StatusCode() int {
+	return r.status
+}
+
+// resourceHeaders ETag of resource and Location of resource created
+func resourceHeaders(meta *scim.Meta, status int) http.Header {
+	headers := http.Header{}
+	if meta == nil {
+		return headers
+	}
+
+	headers.Set("ETag", meta.Version)
+	if status == http.StatusCreated {
+		headers.Set("Location", meta.Location)
+	}
+	return headers
+}
+
+// MakeListUsersEndpoint make list users endpoint
+func MakeListUsersEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ListRequest)
+
+		return svc.ListUsers(ctx, &service.ListOption{
+			Filter:     req.Filter,
+			StartIndex: req.StartIndex,
+			Count:      req.Count,
+		})
+	}
+}
+
+// MakeGetUserEndpoint make get user endpoint
+func MakeGetUserEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*GetRequest)
+
+		user, err := svc.GetUser(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.IfNoneMatch != "" && scim.MatchETag(req.IfNoneMatch, user.Meta.Version) {
+			return &UserResponse{User: user, status: http.StatusNotModified}, nil
+		}
+		return &UserResponse{User: user, status: http.StatusOK}, nil
+	}
+}
+
+// MakeCreateUserEndpoint make create user endpoint
+func MakeCreateUserEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UserRequest)
+
+		user, err := svc.CreateUser(ctx, req.User)
+		if err != nil {
+			return nil, err
+		}
+
+		return &UserResponse{User: user, status: http.StatusCreated}, nil
+	}
+}
+
+// MakeReplaceUserEndpoint make replace user endpoint
+func MakeReplaceUserEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UserRequest)
+
+		user, err := svc.ReplaceUser(ctx, req.ID, req.User, &service.WriteOption{
+			IfMatch: req.IfMatch,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &UserResponse{User: user, status: http.StatusOK}, nil
+	}
+}
+
+// MakePatchUserEndpoint make patch user endpoint
+func MakePatchUserEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*PatchRequest)
+
+		user, err := svc.PatchUser(ctx, req.ID, req.Patch, &service.WriteOption{
+			IfMatch: req.IfMatch,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &UserResponse{User: user, status: http.StatusOK}, nil
+	}
+}
+
+// MakeDeleteUserEndpoint make delete user endpoint
+func MakeDeleteUserEndpoint(svc service.SCIMService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DeleteRequest)
+
+		err = svc.DeleteUser(ctx, req.ID, &service.WriteOption{
+			IfMatch: req.IfMatch,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeleteResponse{}, nil
+	}
+}
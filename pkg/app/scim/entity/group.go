@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"encoding/json"
+
+	rbac "github.com/karta0898098/iam/pkg/app/rbac/entity"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/scim"
+)
+
+// ResourceTypeGroup is resource type of group
+const ResourceTypeGroup = "Group"
+
+// Group is SCIM group resource mapped onto group of rbac, see RFC 7643 section 4.2,
+// display name follow group name rule of rbac
+type Group struct {
+	Schemas     []string   `json:"schemas"`
+	ID          string     `json:"id,omitempty"`
+	DisplayName string     `json:"displayName"`
+	Members     []Member   `json:"members"`
+	Meta        *scim.Meta `json:"meta,omitempty"`
+}
+
+// Member is user belong to group
+type Member struct {
+	// Value is user id
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+// NewGroup convert group of rbac and its members to resource, location is url of the resource
+func NewGroup(group *rbac.Group, members []Member, location string) *Group {
+	if members == nil {
+		members = make([]Member, 0)
+	}
+
+	return &Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          group.ID,
+		DisplayName: group.Name,
+		Members:     members,
+		Meta:        scim.NewMeta(ResourceTypeGroup, location, group.CreatedAt, group.UpdatedAt),
+	}
+}
+
+// Validate check schema and required attributes
+func (g *Group) Validate() error {
+	err := scim.CheckSchema(g.Schemas, scim.SchemaGroup)
+	if err != nil {
+		return err
+	}
+	if g.DisplayName == "" {
+		return errors.Wrap(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidValue), "displayName is required")
+	}
+	return nil
+}
+
+// MemberIDs user id of members without duplication
+func (g *Group) MemberIDs() []string {
+	ids := make([]string, 0, len(g.Members))
+	seen := map[string]bool{}
+	for _, member := range g.Members {
+		if member.Value == "" || seen[member.Value] {
+			continue
+		}
+		seen[member.Value] = true
+		ids = append(ids, member.Value)
+	}
+	return ids
+}
+
+// Patch apply patch operation, attribute not mapped onto group is ignored
+func (g *Group) Patch(op scim.PatchOperation) error {
+	if op.Path == "" {
+		attributes, err := attributesOf(op.Value)
+		if err != nil {
+			return err
+		}
+		for name, value := range attributes {
+			if scim.IsExtension(name) {
+				continue
+			}
+			path, err := scim.ParsePath(name)
+			if err != nil {
+				return err
+			}
+			err = g.patch(op.Op, path, value)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if scim.IsExtension(op.Path) {
+		return nil
+	}
+	path, err := scim.ParsePath(op.Path)
+	if err != nil {
+		return err
+	}
+	return g.patch(op.Op, path, op.Value)
+}
+
+func (g *Group) patch(op string, path *scim.Path, value json.RawMessage) error {
+	switch path.Attribute {
+	case "displayname":
+		if op == scim.PatchRemove {
+			return errors.Wrap(scim.WithType(errors.ErrInvalidInput, scim.TypeMutability), "displayName is required")
+		}
+		name, err := scim.String(value)
+		if err != nil {
+			return err
+		}
+		g.DisplayName = name
+	case "members":
+		return g.patchMembers(op, path, value)
+	}
+	return nil
+}
+
+// patchMembers add, replace or remove members,
+// remove without filter remove members listed in value or all members when value is absent
+func (g *Group) patchMembers(op string, path *scim.Path, value json.RawMessage) error {
+	if path.SubAttribute != "" {
+		return errors.Wrapf(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidPath), "path %v of members is not supported", path.String())
+	}
+
+	switch op {
+	case scim.PatchAdd, scim.PatchReplace:
+		var members []Member
+		if err := unmarshalValue(value, &members); err != nil {
+			return err
+		}
+		if op == scim.PatchReplace {
+			g.Members = nil
+		}
+		g.Members = append(g.Members, members...)
+	case scim.PatchRemove:
+		var removed []Member
+		if len(value) > 0 && string(value) != "null" {
+			if err := unmarshalValue(value, &removed); err != nil {
+				return err
+			}
+		}
+
+		kept := g.Members[:0]
+		for _, member := range g.Members {
+			remove := path.Filter == nil && len(removed) == 0
+			if path.Filter != nil {
+				remove = path.Filter.Match(map[string]interface{}{
+					"value":   member.Value,
+					"display": member.Display,
+					"type":    member.Type,
+				})
+			}
+			for _, r := range removed {
+				if r.Value == member.Value {
+					remove = true
+				}
+			}
+			if !remove {
+				kept = append(kept, member)
+			}
+		}
+		g.Members = kept
+	}
+	return nil
+}
@@ -0,0 +1,423 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+
+	identity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/scim"
+)
+
+// ResourceTypeUser is resource type of user
+const ResourceTypeUser = "User"
+
+// User is SCIM user resource mapped onto user of identity, see RFC 7643 section 4.1
+type User struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	Name        *Name        `json:"name,omitempty"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Emails      []MultiValue `json:"emails,omitempty"`
+	Photos      []MultiValue `json:"photos,omitempty"`
+	Active      *bool        `json:"active,omitempty"`
+	// Password is write only, it is never returned
+	Password string     `json:"password,omitempty"`
+	Meta     *scim.Meta `json:"meta,omitempty"`
+}
+
+// Name is components of user's name
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// MultiValue is value of multi-valued attribute such as emails
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// attributes of value for evaluating value filter
+func (v MultiValue) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"value":   v.Value,
+		"display": v.Display,
+		"type":    v.Type,
+		"primary": v.Primary,
+	}
+}
+
+// NewUser convert user of identity to resource, location is url of the resource
+func NewUser(user *identity.User, externalID string, location string) *User {
+	active := user.IsActive()
+	resource := &User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          user.ID,
+		ExternalID:  externalID,
+		UserName:    user.Username,
+		DisplayName: user.Nickname,
+		Active:      &active,
+		Meta:        scim.NewMeta(ResourceTypeUser, location, user.CreatedAt, user.UpdatedAt),
+	}
+
+	if user.FirstName != "" || user.LastName != "" {
+		resource.Name = &Name{
+			Formatted:  strings.TrimSpace(user.FirstName + " " + user.LastName),
+			FamilyName: user.LastName,
+			GivenName:  user.FirstName,
+		}
+	}
+	if user.Email != "" {
+		resource.Emails = []MultiValue{{Value: user.Email, Type: "work", Primary: true}}
+	}
+	if user.Avatar != "" {
+		resource.Photos = []MultiValue{{Value: user.Avatar, Type: "photo", Primary: true}}
+	}
+
+	return resource
+}
+
+// Validate check schema and required attributes
+func (u *User) Validate() error {
+	err := scim.CheckSchema(u.Schemas, scim.SchemaUser)
+	if err != nil {
+		return err
+	}
+	if u.UserName == "" {
+		return errors.Wrap(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidValue), "userName is required")
+	}
+	if len(u.UserName) > identity.ProvisionedUsernameLengthMax {
+		return errors.Wrapf(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidValue), "userName is longer than %d", identity.ProvisionedUsernameLengthMax)
+	}
+	return nil
+}
+
+// IsActive user can sign in, absent active is true
+func (u *User) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// GivenName of name
+func (u *User) GivenName() string {
+	if u.Name == nil {
+		return ""
+	}
+	return u.Name.GivenName
+}
+
+// FamilyName of name
+func (u *User) FamilyName() string {
+	if u.Name == nil {
+		return ""
+	}
+	return u.Name.FamilyName
+}
+
+// Email is primary email or the first one, user of identity has only one email
+func (u *User) Email() string {
+	return primary(u.Emails)
+}
+
+// Photo is primary photo or the first one
+func (u *User) Photo() string {
+	return primary(u.Photos)
+}
+
+func primary(values []MultiValue) string {
+	for _, v := range values {
+		if v.Primary {
+			return v.Value
+		}
+	}
+	if len(values) > 0 {
+		return values[0].Value
+	}
+	return ""
+}
+
+// Patch apply patch operation, attribute not mapped onto user and extension attribute are ignored
+// so provider pushing full profile does not fail
+func (u *User) Patch(op scim.PatchOperation) error {
+	if op.Path == "" {
+		attributes, err := attributesOf(op.Value)
+		if err != nil {
+			return err
+		}
+		for name, value := range attributes {
+			if scim.IsExtension(name) {
+				continue
+			}
+			path, err := scim.ParsePath(name)
+			if err != nil {
+				return err
+			}
+			err = u.patch(op.Op, path, value)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if scim.IsExtension(op.Path) {
+		return nil
+	}
+	path, err := scim.ParsePath(op.Path)
+	if err != nil {
+		return err
+	}
+	return u.patch(op.Op, path, op.Value)
+}
+
+func (u *User) patch(op string, path *scim.Path, value json.RawMessage) error {
+	remove := op == scim.PatchRemove
+
+	var err error
+	switch path.Attribute {
+	case "username":
+		if remove {
+			return errors.Wrap(scim.WithType(errors.ErrInvalidInput, scim.TypeMutability), "userName is required")
+		}
+		u.UserName, err = scim.String(value)
+	case "externalid":
+		u.ExternalID, err = patchString(remove, value)
+	case "displayname":
+		u.DisplayName, err = patchString(remove, value)
+	case "active":
+		if remove {
+			u.Active = nil
+			return nil
+		}
+		var active bool
+		active, err = scim.Bool(value)
+		u.Active = &active
+	case "password":
+		u.Password, err = patchString(remove, value)
+	case "name":
+		err = u.patchName(op, path.SubAttribute, value)
+	case "emails":
+		err = patchMultiValue(&u.Emails, op, path, value)
+	case "photos":
+		err = patchMultiValue(&u.Photos, op, path, value)
+	}
+	return err
+}
+
+func (u *User) patchName(op string, sub string, value json.RawMessage) error {
+	if u.Name == nil {
+		u.Name = &Name{}
+	}
+
+	if sub == "" {
+		switch op {
+		case scim.PatchRemove:
+			u.Name = nil
+		case scim.PatchReplace:
+			u.Name = &Name{}
+			return unmarshalValue(value, u.Name)
+		case scim.PatchAdd:
+			// add merge sub attributes into existing name
+			var name Name
+			err := unmarshalValue(value, &name)
+			if err != nil {
+				return err
+			}
+			if name.GivenName != "" {
+				u.Name.GivenName = name.GivenName
+			}
+			if name.FamilyName != "" {
+				u.Name.FamilyName = name.FamilyName
+			}
+			if name.Formatted != "" {
+				u.Name.Formatted = name.Formatted
+			}
+		}
+		return nil
+	}
+
+	var err error
+	switch sub {
+	case "givenname":
+		u.Name.GivenName, err = patchString(op == scim.PatchRemove, value)
+	case "familyname":
+		u.Name.FamilyName, err = patchString(op == scim.PatchRemove, value)
+	case "formatted":
+		u.Name.Formatted, err = patchString(op == scim.PatchRemove, value)
+	}
+	return err
+}
+
+// patchMultiValue apply operation to values selected by value filter of path,
+// values are replaced by filter on type is created when nothing is selected
+func patchMultiValue(values *[]MultiValue, op string, path *scim.Path, value json.RawMessage) error {
+	if path.Filter == nil && path.SubAttribute == "" {
+		switch op {
+		case scim.PatchRemove:
+			*values = nil
+		case scim.PatchReplace:
+			*values = nil
+			return addMultiValues(values, value)
+		case scim.PatchAdd:
+			return addMultiValues(values, value)
+		}
+		return nil
+	}
+
+	selected := make([]int, 0, len(*values))
+	for i, v := range *values {
+		if path.Filter == nil || path.Filter.Match(v.attributes()) {
+			selected = append(selected, i)
+		}
+	}
+
+	if op == scim.PatchRemove {
+		if path.SubAttribute != "" {
+			for _, i := range selected {
+				_ = setSubAttribute(&(*values)[i], path.SubAttribute, json.RawMessage("null"))
+			}
+			return nil
+		}
+
+		kept := (*values)[:0]
+		for i, v := range *values {
+			if !contains(selected, i) {
+				kept = append(kept, v)
+			}
+		}
+		*values = kept
+		return nil
+	}
+
+	if len(selected) == 0 {
+		created := MultiValue{Type: filterType(path.Filter)}
+		if path.SubAttribute == "" {
+			err := unmarshalValue(value, &created)
+			if err != nil {
+				return err
+			}
+		} else {
+			err := setSubAttribute(&created, path.SubAttribute, value)
+			if err != nil {
+				return err
+			}
+		}
+		*values = append(*values, created)
+		return nil
+	}
+
+	for _, i := range selected {
+		var err error
+		if path.SubAttribute == "" {
+			err = unmarshalValue(value, &(*values)[i])
+		} else {
+			err = setSubAttribute(&(*values)[i], path.SubAttribute, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addMultiValues append value, it is array or single object
+func addMultiValues(values *[]MultiValue, value json.RawMessage) error {
+	var added []MultiValue
+	if err := json.Unmarshal(value, &added); err != nil {
+		var single MultiValue
+		if err := unmarshalValue(value, &single); err != nil {
+			return err
+		}
+		added = []MultiValue{single}
+	}
+
+	for _, v := range added {
+		if v.Primary {
+			for i := range *values {
+				(*values)[i].Primary = false
+			}
+		}
+
+		replaced := false
+		for i := range *values {
+			if (*values)[i].Value == v.Value {
+				(*values)[i] = v
+				replaced = true
+			}
+		}
+		if !replaced {
+			*values = append(*values, v)
+		}
+	}
+	return nil
+}
+
+func setSubAttribute(v *MultiValue, sub string, value json.RawMessage) error {
+	var err error
+	switch sub {
+	case "value":
+		v.Value, err = scim.String(value)
+	case "display":
+		v.Display, err = scim.String(value)
+	case "type":
+		v.Type, err = scim.String(value)
+	case "primary":
+		if string(value) == "null" {
+			v.Primary = false
+			return nil
+		}
+		v.Primary, err = scim.Bool(value)
+	default:
+		err = errors.Wrapf(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidPath), "sub attribute %v is not supported", sub)
+	}
+	return err
+}
+
+// filterType is type of filter type eq "work", empty for other filters
+func filterType(f *scim.Filter) string {
+	if f != nil && f.Op == scim.OpEqual && f.Path == "type" {
+		if s, ok := f.Value.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// attributesOf decode object value of operation without path
+func attributesOf(value json.RawMessage) (map[string]json.RawMessage, error) {
+	var attributes map[string]json.RawMessage
+	err := json.Unmarshal(value, &attributes)
+	if err != nil {
+		return nil, errors.Wrap(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidValue), "value of operation without path is not object")
+	}
+	return attributes, nil
+}
+
+func patchString(remove bool, value json.RawMessage) (string, error) {
+	if remove {
+		return "", nil
+	}
+	return scim.String(value)
+}
+
+func unmarshalValue(value json.RawMessage, v interface{}) error {
+	err := json.Unmarshal(value, v)
+	if err != nil {
+		return errors.Wrapf(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidValue), "value %s is invalid", value)
+	}
+	return nil
+}
+
+func contains(indexes []int, i int) bool {
+	for _, index := range indexes {
+		if index == i {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,326 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	identityentity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	entity "github.com/karta0898098/iam/pkg/app/scim/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	rbacentity "github.com/karta0898098/iam/pkg/app/rbac/entity"
+
+	scim "github.com/karta0898098/iam/pkg/scim"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// FindExternalIDs provides a mock function with given fields: ctx, userIDs
+func (_m *Repository) FindExternalIDs(ctx context.Context, userIDs []string) (map[string]string, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]string, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]string); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindExternalIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindExternalIDs'
+type Repository_FindExternalIDs_Call struct {
+	*mock.Call
+}
+
+// FindExternalIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *Repository_Expecter) FindExternalIDs(ctx interface{}, userIDs interface{}) *Repository_FindExternalIDs_Call {
+	return &Repository_FindExternalIDs_Call{Call: _e.mock.On("FindExternalIDs", ctx, userIDs)}
+}
+
+func (_c *Repository_FindExternalIDs_Call) Run(run func(ctx context.Context, userIDs []string)) *Repository_FindExternalIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindExternalIDs_Call) Return(externalIDs map[string]string, err error) *Repository_FindExternalIDs_Call {
+	_c.Call.Return(externalIDs, err)
+	return _c
+}
+
+func (_c *Repository_FindExternalIDs_Call) RunAndReturn(run func(context.Context, []string) (map[string]string, error)) *Repository_FindExternalIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindGroupMembers provides a mock function with given fields: ctx, groupIDs
+func (_m *Repository) FindGroupMembers(ctx context.Context, groupIDs []string) (map[string][]entity.Member, error) {
+	ret := _m.Called(ctx, groupIDs)
+
+	var r0 map[string][]entity.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string][]entity.Member, error)); ok {
+		return rf(ctx, groupIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string][]entity.Member); ok {
+		r0 = rf(ctx, groupIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]entity.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, groupIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindGroupMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindGroupMembers'
+type Repository_FindGroupMembers_Call struct {
+	*mock.Call
+}
+
+// FindGroupMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupIDs []string
+func (_e *Repository_Expecter) FindGroupMembers(ctx interface{}, groupIDs interface{}) *Repository_FindGroupMembers_Call {
+	return &Repository_FindGroupMembers_Call{Call: _e.mock.On("FindGroupMembers", ctx, groupIDs)}
+}
+
+func (_c *Repository_FindGroupMembers_Call) Run(run func(ctx context.Context, groupIDs []string)) *Repository_FindGroupMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindGroupMembers_Call) Return(members map[string][]entity.Member, err error) *Repository_FindGroupMembers_Call {
+	_c.Call.Return(members, err)
+	return _c
+}
+
+func (_c *Repository_FindGroupMembers_Call) RunAndReturn(run func(context.Context, []string) (map[string][]entity.Member, error)) *Repository_FindGroupMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindGroups provides a mock function with given fields: ctx, filter, offset, limit
+func (_m *Repository) FindGroups(ctx context.Context, filter *scim.Filter, offset int, limit int) ([]*rbacentity.Group, int64, error) {
+	ret := _m.Called(ctx, filter, offset, limit)
+
+	var r0 []*rbacentity.Group
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *scim.Filter, int, int) ([]*rbacentity.Group, int64, error)); ok {
+		return rf(ctx, filter, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *scim.Filter, int, int) []*rbacentity.Group); ok {
+		r0 = rf(ctx, filter, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*rbacentity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *scim.Filter, int, int) int64); ok {
+		r1 = rf(ctx, filter, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *scim.Filter, int, int) error); ok {
+		r2 = rf(ctx, filter, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Repository_FindGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindGroups'
+type Repository_FindGroups_Call struct {
+	*mock.Call
+}
+
+// FindGroups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *scim.Filter
+//   - offset int
+//   - limit int
+func (_e *Repository_Expecter) FindGroups(ctx interface{}, filter interface{}, offset interface{}, limit interface{}) *Repository_FindGroups_Call {
+	return &Repository_FindGroups_Call{Call: _e.mock.On("FindGroups", ctx, filter, offset, limit)}
+}
+
+func (_c *Repository_FindGroups_Call) Run(run func(ctx context.Context, filter *scim.Filter, offset int, limit int)) *Repository_FindGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*scim.Filter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_FindGroups_Call) Return(groups []*rbacentity.Group, total int64, err error) *Repository_FindGroups_Call {
+	_c.Call.Return(groups, total, err)
+	return _c
+}
+
+func (_c *Repository_FindGroups_Call) RunAndReturn(run func(context.Context, *scim.Filter, int, int) ([]*rbacentity.Group, int64, error)) *Repository_FindGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUsers provides a mock function with given fields: ctx, filter, offset, limit
+func (_m *Repository) FindUsers(ctx context.Context, filter *scim.Filter, offset int, limit int) ([]*identityentity.User, int64, error) {
+	ret := _m.Called(ctx, filter, offset, limit)
+
+	var r0 []*identityentity.User
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *scim.Filter, int, int) ([]*identityentity.User, int64, error)); ok {
+		return rf(ctx, filter, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *scim.Filter, int, int) []*identityentity.User); ok {
+		r0 = rf(ctx, filter, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*identityentity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *scim.Filter, int, int) int64); ok {
+		r1 = rf(ctx, filter, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *scim.Filter, int, int) error); ok {
+		r2 = rf(ctx, filter, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Repository_FindUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUsers'
+type Repository_FindUsers_Call struct {
+	*mock.Call
+}
+
+// FindUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *scim.Filter
+//   - offset int
+//   - limit int
+func (_e *Repository_Expecter) FindUsers(ctx interface{}, filter interface{}, offset interface{}, limit interface{}) *Repository_FindUsers_Call {
+	return &Repository_FindUsers_Call{Call: _e.mock.On("FindUsers", ctx, filter, offset, limit)}
+}
+
+func (_c *Repository_FindUsers_Call) Run(run func(ctx context.Context, filter *scim.Filter, offset int, limit int)) *Repository_FindUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*scim.Filter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUsers_Call) Return(users []*identityentity.User, total int64, err error) *Repository_FindUsers_Call {
+	_c.Call.Return(users, total, err)
+	return _c
+}
+
+func (_c *Repository_FindUsers_Call) RunAndReturn(run func(context.Context, *scim.Filter, int, int) ([]*identityentity.User, int64, error)) *Repository_FindUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceExternalID provides a mock function with given fields: ctx, user, externalID
+func (_m *Repository) ReplaceExternalID(ctx context.Context, user *identityentity.User, externalID string) error {
+	ret := _m.Called(ctx, user, externalID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *identityentity.User, string) error); ok {
+		r0 = rf(ctx, user, externalID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_ReplaceExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceExternalID'
+type Repository_ReplaceExternalID_Call struct {
+	*mock.Call
+}
+
+// ReplaceExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *identityentity.User
+//   - externalID string
+func (_e *Repository_Expecter) ReplaceExternalID(ctx interface{}, user interface{}, externalID interface{}) *Repository_ReplaceExternalID_Call {
+	return &Repository_ReplaceExternalID_Call{Call: _e.mock.On("ReplaceExternalID", ctx, user, externalID)}
+}
+
+func (_c *Repository_ReplaceExternalID_Call) Run(run func(ctx context.Context, user *identityentity.User, externalID string)) *Repository_ReplaceExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*identityentity.User), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ReplaceExternalID_Call) Return(err error) *Repository_ReplaceExternalID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_ReplaceExternalID_Call) RunAndReturn(run func(context.Context, *identityentity.User, string) error) *Repository_ReplaceExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
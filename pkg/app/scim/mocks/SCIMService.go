@@ -0,0 +1,688 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/scim/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	scim "github.com/karta0898098/iam/pkg/scim"
+
+	service "github.com/karta0898098/iam/pkg/app/scim/service"
+)
+
+// SCIMService is an autogenerated mock type for the SCIMService type
+type SCIMService struct {
+	mock.Mock
+}
+
+type SCIMService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SCIMService) EXPECT() *SCIMService_Expecter {
+	return &SCIMService_Expecter{mock: &_m.Mock}
+}
+
+// CreateGroup provides a mock function with given fields: ctx, group
+func (_m *SCIMService) CreateGroup(ctx context.Context, group *entity.Group) (*entity.Group, error) {
+	ret := _m.Called(ctx, group)
+
+	var r0 *entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Group) (*entity.Group, error)); ok {
+		return rf(ctx, group)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Group) *entity.Group); ok {
+		r0 = rf(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Group) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SCIMService_CreateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGroup'
+type SCIMService_CreateGroup_Call struct {
+	*mock.Call
+}
+
+// CreateGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group *entity.Group
+func (_e *SCIMService_Expecter) CreateGroup(ctx interface{}, group interface{}) *SCIMService_CreateGroup_Call {
+	return &SCIMService_CreateGroup_Call{Call: _e.mock.On("CreateGroup", ctx, group)}
+}
+
+func (_c *SCIMService_CreateGroup_Call) Run(run func(ctx context.Context, group *entity.Group)) *SCIMService_CreateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Group))
+	})
+	return _c
+}
+
+func (_c *SCIMService_CreateGroup_Call) Return(created *entity.Group, err error) *SCIMService_CreateGroup_Call {
+	_c.Call.Return(created, err)
+	return _c
+}
+
+func (_c *SCIMService_CreateGroup_Call) RunAndReturn(run func(context.Context, *entity.Group) (*entity.Group, error)) *SCIMService_CreateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *SCIMService) CreateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) (*entity.User, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) *entity.User); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SCIMService_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type SCIMService_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *entity.User
+func (_e *SCIMService_Expecter) CreateUser(ctx interface{}, user interface{}) *SCIMService_CreateUser_Call {
+	return &SCIMService_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
+}
+
+func (_c *SCIMService_CreateUser_Call) Run(run func(ctx context.Context, user *entity.User)) *SCIMService_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.User))
+	})
+	return _c
+}
+
+func (_c *SCIMService_CreateUser_Call) Return(created *entity.User, err error) *SCIMService_CreateUser_Call {
+	_c.Call.Return(created, err)
+	return _c
+}
+
+func (_c *SCIMService_CreateUser_Call) RunAndReturn(run func(context.Context, *entity.User) (*entity.User, error)) *SCIMService_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, groupID, opt
+func (_m *SCIMService) DeleteGroup(ctx context.Context, groupID string, opt *service.WriteOption) error {
+	ret := _m.Called(ctx, groupID, opt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.WriteOption) error); ok {
+		r0 = rf(ctx, groupID, opt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SCIMService_DeleteGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGroup'
+type SCIMService_DeleteGroup_Call struct {
+	*mock.Call
+}
+
+// DeleteGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - opt *service.WriteOption
+func (_e *SCIMService_Expecter) DeleteGroup(ctx interface{}, groupID interface{}, opt interface{}) *SCIMService_DeleteGroup_Call {
+	return &SCIMService_DeleteGroup_Call{Call: _e.mock.On("DeleteGroup", ctx, groupID, opt)}
+}
+
+func (_c *SCIMService_DeleteGroup_Call) Run(run func(ctx context.Context, groupID string, opt *service.WriteOption)) *SCIMService_DeleteGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*service.WriteOption))
+	})
+	return _c
+}
+
+func (_c *SCIMService_DeleteGroup_Call) Return(err error) *SCIMService_DeleteGroup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *SCIMService_DeleteGroup_Call) RunAndReturn(run func(context.Context, string, *service.WriteOption) error) *SCIMService_DeleteGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function with given fields: ctx, userID, opt
+func (_m *SCIMService) DeleteUser(ctx context.Context, userID string, opt *service.WriteOption) error {
+	ret := _m.Called(ctx, userID, opt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.WriteOption) error); ok {
+		r0 = rf(ctx, userID, opt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SCIMService_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type SCIMService_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - opt *service.WriteOption
+func (_e *SCIMService_Expecter) DeleteUser(ctx interface{}, userID interface{}, opt interface{}) *SCIMService_DeleteUser_Call {
+	return &SCIMService_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, userID, opt)}
+}
+
+func (_c *SCIMService_DeleteUser_Call) Run(run func(ctx context.Context, userID string, opt *service.WriteOption)) *SCIMService_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*service.WriteOption))
+	})
+	return _c
+}
+
+func (_c *SCIMService_DeleteUser_Call) Return(err error) *SCIMService_DeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *SCIMService_DeleteUser_Call) RunAndReturn(run func(context.Context, string, *service.WriteOption) error) *SCIMService_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroup provides a mock function with given fields: ctx, groupID
+func (_m *SCIMService) GetGroup(ctx context.Context, groupID string) (*entity.Group, error) {
+	ret := _m.Called(ctx, groupID)
+
+	var r0 *entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Group, error)); ok {
+		return rf(ctx, groupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Group); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SCIMService_GetGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroup'
+type SCIMService_GetGroup_Call struct {
+	*mock.Call
+}
+
+// GetGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+func (_e *SCIMService_Expecter) GetGroup(ctx interface{}, groupID interface{}) *SCIMService_GetGroup_Call {
+	return &SCIMService_GetGroup_Call{Call: _e.mock.On("GetGroup", ctx, groupID)}
+}
+
+func (_c *SCIMService_GetGroup_Call) Run(run func(ctx context.Context, groupID string)) *SCIMService_GetGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SCIMService_GetGroup_Call) Return(group *entity.Group, err error) *SCIMService_GetGroup_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *SCIMService_GetGroup_Call) RunAndReturn(run func(context.Context, string) (*entity.Group, error)) *SCIMService_GetGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *SCIMService) GetUser(ctx context.Context, userID string) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SCIMService_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type SCIMService_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *SCIMService_Expecter) GetUser(ctx interface{}, userID interface{}) *SCIMService_GetUser_Call {
+	return &SCIMService_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+}
+
+func (_c *SCIMService_GetUser_Call) Run(run func(ctx context.Context, userID string)) *SCIMService_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SCIMService_GetUser_Call) Return(user *entity.User, err error) *SCIMService_GetUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *SCIMService_GetUser_Call) RunAndReturn(run func(context.Context, string) (*entity.User, error)) *SCIMService_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGroups provides a mock function with given fields: ctx, opt
+func (_m *SCIMService) ListGroups(ctx context.Context, opt *service.ListOption) (*scim.ListResponse, error) {
+	ret := _m.Called(ctx, opt)
+
+	var r0 *scim.ListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.ListOption) (*scim.ListResponse, error)); ok {
+		return rf(ctx, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.ListOption) *scim.ListResponse); ok {
+		r0 = rf(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scim.ListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.ListOption) error); ok {
+		r1 = rf(ctx, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SCIMService_ListGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGroups'
+type SCIMService_ListGroups_Call struct {
+	*mock.Call
+}
+
+// ListGroups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *service.ListOption
+func (_e *SCIMService_Expecter) ListGroups(ctx interface{}, opt interface{}) *SCIMService_ListGroups_Call {
+	return &SCIMService_ListGroups_Call{Call: _e.mock.On("ListGroups", ctx, opt)}
+}
+
+func (_c *SCIMService_ListGroups_Call) Run(run func(ctx context.Context, opt *service.ListOption)) *SCIMService_ListGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.ListOption))
+	})
+	return _c
+}
+
+func (_c *SCIMService_ListGroups_Call) Return(resp *scim.ListResponse, err error) *SCIMService_ListGroups_Call {
+	_c.Call.Return(resp, err)
+	return _c
+}
+
+func (_c *SCIMService_ListGroups_Call) RunAndReturn(run func(context.Context, *service.ListOption) (*scim.ListResponse, error)) *SCIMService_ListGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function with given fields: ctx, opt
+func (_m *SCIMService) ListUsers(ctx context.Context, opt *service.ListOption) (*scim.ListResponse, error) {
+	ret := _m.Called(ctx, opt)
+
+	var r0 *scim.ListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.ListOption) (*scim.ListResponse, error)); ok {
+		return rf(ctx, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.ListOption) *scim.ListResponse); ok {
+		r0 = rf(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scim.ListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.ListOption) error); ok {
+		r1 = rf(ctx, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SCIMService_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type SCIMService_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *service.ListOption
+func (_e *SCIMService_Expecter) ListUsers(ctx interface{}, opt interface{}) *SCIMService_ListUsers_Call {
+	return &SCIMService_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, opt)}
+}
+
+func (_c *SCIMService_ListUsers_Call) Run(run func(ctx context.Context, opt *service.ListOption)) *SCIMService_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.ListOption))
+	})
+	return _c
+}
+
+func (_c *SCIMService_ListUsers_Call) Return(resp *scim.ListResponse, err error) *SCIMService_ListUsers_Call {
+	_c.Call.Return(resp, err)
+	return _c
+}
+
+func (_c *SCIMService_ListUsers_Call) RunAndReturn(run func(context.Context, *service.ListOption) (*scim.ListResponse, error)) *SCIMService_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchGroup provides a mock function with given fields: ctx, groupID, req, opt
+func (_m *SCIMService) PatchGroup(ctx context.Context, groupID string, req *scim.PatchRequest, opt *service.WriteOption) (*entity.Group, error) {
+	ret := _m.Called(ctx, groupID, req, opt)
+
+	var r0 *entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *scim.PatchRequest, *service.WriteOption) (*entity.Group, error)); ok {
+		return rf(ctx, groupID, req, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *scim.PatchRequest, *service.WriteOption) *entity.Group); ok {
+		r0 = rf(ctx, groupID, req, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *scim.PatchRequest, *service.WriteOption) error); ok {
+		r1 = rf(ctx, groupID, req, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SCIMService_PatchGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchGroup'
+type SCIMService_PatchGroup_Call struct {
+	*mock.Call
+}
+
+// PatchGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - req *scim.PatchRequest
+//   - opt *service.WriteOption
+func (_e *SCIMService_Expecter) PatchGroup(ctx interface{}, groupID interface{}, req interface{}, opt interface{}) *SCIMService_PatchGroup_Call {
+	return &SCIMService_PatchGroup_Call{Call: _e.mock.On("PatchGroup", ctx, groupID, req, opt)}
+}
+
+func (_c *SCIMService_PatchGroup_Call) Run(run func(ctx context.Context, groupID string, req *scim.PatchRequest, opt *service.WriteOption)) *SCIMService_PatchGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*scim.PatchRequest), args[3].(*service.WriteOption))
+	})
+	return _c
+}
+
+func (_c *SCIMService_PatchGroup_Call) Return(patched *entity.Group, err error) *SCIMService_PatchGroup_Call {
+	_c.Call.Return(patched, err)
+	return _c
+}
+
+func (_c *SCIMService_PatchGroup_Call) RunAndReturn(run func(context.Context, string, *scim.PatchRequest, *service.WriteOption) (*entity.Group, error)) *SCIMService_PatchGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchUser provides a mock function with given fields: ctx, userID, req, opt
+func (_m *SCIMService) PatchUser(ctx context.Context, userID string, req *scim.PatchRequest, opt *service.WriteOption) (*entity.User, error) {
+	ret := _m.Called(ctx, userID, req, opt)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *scim.PatchRequest, *service.WriteOption) (*entity.User, error)); ok {
+		return rf(ctx, userID, req, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *scim.PatchRequest, *service.WriteOption) *entity.User); ok {
+		r0 = rf(ctx, userID, req, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *scim.PatchRequest, *service.WriteOption) error); ok {
+		r1 = rf(ctx, userID, req, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SCIMService_PatchUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchUser'
+type SCIMService_PatchUser_Call struct {
+	*mock.Call
+}
+
+// PatchUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - req *scim.PatchRequest
+//   - opt *service.WriteOption
+func (_e *SCIMService_Expecter) PatchUser(ctx interface{}, userID interface{}, req interface{}, opt interface{}) *SCIMService_PatchUser_Call {
+	return &SCIMService_PatchUser_Call{Call: _e.mock.On("PatchUser", ctx, userID, req, opt)}
+}
+
+func (_c *SCIMService_PatchUser_Call) Run(run func(ctx context.Context, userID string, req *scim.PatchRequest, opt *service.WriteOption)) *SCIMService_PatchUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*scim.PatchRequest), args[3].(*service.WriteOption))
+	})
+	return _c
+}
+
+func (_c *SCIMService_PatchUser_Call) Return(patched *entity.User, err error) *SCIMService_PatchUser_Call {
+	_c.Call.Return(patched, err)
+	return _c
+}
+
+func (_c *SCIMService_PatchUser_Call) RunAndReturn(run func(context.Context, string, *scim.PatchRequest, *service.WriteOption) (*entity.User, error)) *SCIMService_PatchUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceGroup provides a mock function with given fields: ctx, groupID, group, opt
+func (_m *SCIMService) ReplaceGroup(ctx context.Context, groupID string, group *entity.Group, opt *service.WriteOption) (*entity.Group, error) {
+	ret := _m.Called(ctx, groupID, group, opt)
+
+	var r0 *entity.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.Group, *service.WriteOption) (*entity.Group, error)); ok {
+		return rf(ctx, groupID, group, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.Group, *service.WriteOption) *entity.Group); ok {
+		r0 = rf(ctx, groupID, group, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *entity.Group, *service.WriteOption) error); ok {
+		r1 = rf(ctx, groupID, group, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SCIMService_ReplaceGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceGroup'
+type SCIMService_ReplaceGroup_Call struct {
+	*mock.Call
+}
+
+// ReplaceGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - group *entity.Group
+//   - opt *service.WriteOption
+func (_e *SCIMService_Expecter) ReplaceGroup(ctx interface{}, groupID interface{}, group interface{}, opt interface{}) *SCIMService_ReplaceGroup_Call {
+	return &SCIMService_ReplaceGroup_Call{Call: _e.mock.On("ReplaceGroup", ctx, groupID, group, opt)}
+}
+
+func (_c *SCIMService_ReplaceGroup_Call) Run(run func(ctx context.Context, groupID string, group *entity.Group, opt *service.WriteOption)) *SCIMService_ReplaceGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*entity.Group), args[3].(*service.WriteOption))
+	})
+	return _c
+}
+
+func (_c *SCIMService_ReplaceGroup_Call) Return(replaced *entity.Group, err error) *SCIMService_ReplaceGroup_Call {
+	_c.Call.Return(replaced, err)
+	return _c
+}
+
+func (_c *SCIMService_ReplaceGroup_Call) RunAndReturn(run func(context.Context, string, *entity.Group, *service.WriteOption) (*entity.Group, error)) *SCIMService_ReplaceGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceUser provides a mock function with given fields: ctx, userID, user, opt
+func (_m *SCIMService) ReplaceUser(ctx context.Context, userID string, user *entity.User, opt *service.WriteOption) (*entity.User, error) {
+	ret := _m.Called(ctx, userID, user, opt)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.User, *service.WriteOption) (*entity.User, error)); ok {
+		return rf(ctx, userID, user, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.User, *service.WriteOption) *entity.User); ok {
+		r0 = rf(ctx, userID, user, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *entity.User, *service.WriteOption) error); ok {
+		r1 = rf(ctx, userID, user, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SCIMService_ReplaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceUser'
+type SCIMService_ReplaceUser_Call struct {
+	*mock.Call
+}
+
+// ReplaceUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - user *entity.User
+//   - opt *service.WriteOption
+func (_e *SCIMService_Expecter) ReplaceUser(ctx interface{}, userID interface{}, user interface{}, opt interface{}) *SCIMService_ReplaceUser_Call {
+	return &SCIMService_ReplaceUser_Call{Call: _e.mock.On("ReplaceUser", ctx, userID, user, opt)}
+}
+
+func (_c *SCIMService_ReplaceUser_Call) Run(run func(ctx context.Context, userID string, user *entity.User, opt *service.WriteOption)) *SCIMService_ReplaceUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*entity.User), args[3].(*service.WriteOption))
+	})
+	return _c
+}
+
+func (_c *SCIMService_ReplaceUser_Call) Return(replaced *entity.User, err error) *SCIMService_ReplaceUser_Call {
+	_c.Call.Return(replaced, err)
+	return _c
+}
+
+func (_c *SCIMService_ReplaceUser_Call) RunAndReturn(run func(context.Context, string, *entity.User, *service.WriteOption) (*entity.User, error)) *SCIMService_ReplaceUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewSCIMService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSCIMService creates a new instance of SCIMService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSCIMService(t mockConstructorTestingTNewSCIMService) *SCIMService {
+	mock := &SCIMService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,15 @@
+package scim
+
+import (
+	"github.com/google/wire"
+
+	"github.com/karta0898098/iam/pkg/app/scim/endpoints"
+	"github.com/karta0898098/iam/pkg/app/scim/repository"
+	"github.com/karta0898098/iam/pkg/app/scim/service"
+)
+
+var DefaultProvider = wire.NewSet(
+	endpoints.New,
+	service.New,
+	repository.New,
+)
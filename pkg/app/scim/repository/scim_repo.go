@@ -0,0 +1,467 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	identity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	identityrepo "github.com/karta0898098/iam/pkg/app/identity/repository"
+	rbac "github.com/karta0898098/iam/pkg/app/rbac/entity"
+	rbacrepo "github.com/karta0898098/iam/pkg/app/rbac/repository"
+	"github.com/karta0898098/iam/pkg/app/scim/entity"
+	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/scim"
+	"github.com/karta0898098/iam/pkg/tenant"
+)
+
+// ProviderSCIM is provider of identity which subject is external id given by provisioning client
+const ProviderSCIM = "scim"
+
+// Repository define scim repository pattern, users and groups are owned by identity and rbac,
+// this repository only answer queries which those repositories can't express
+type Repository interface {
+	// FindUsers find page of users match filter in tenant of context, deleted users are excluded,
+	// nil filter match all users
+	FindUsers(ctx context.Context, filter *scim.Filter, offset int, limit int) (users []*identity.User, total int64, err error)
+
+	// FindExternalIDs find external id of users, users without external id are absent from result
+	FindExternalIDs(ctx context.Context, userIDs []string) (externalIDs map[string]string, err error)
+
+	// ReplaceExternalID replace external id of user, empty external id unlink it,
+	// return ErrConflict when external id belong to another user
+	ReplaceExternalID(ctx context.Context, user *identity.User, externalID string) (err error)
+
+	// FindGroups find page of groups match filter, nil filter match all groups
+	FindGroups(ctx context.Context, filter *scim.Filter, offset int, limit int) (groups []*rbac.Group, total int64, err error)
+
+	// FindGroupMembers find members of groups keyed by group id
+	FindGroupMembers(ctx context.Context, groupIDs []string) (members map[string][]entity.Member, err error)
+}
+
+// SCIMRepository implement for Repository
+type SCIMRepository struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+}
+
+// New Repository constructor
+func New(conn db.Connection) Repository {
+	return &SCIMRepository{
+		readDB:  conn.ReadDB(),
+		writeDB: conn.WriteDB(),
+	}
+}
+
+// FindUsers is SQL implement
+func (repo *SCIMRepository) FindUsers(ctx context.Context, filter *scim.Filter, offset int, limit int) (users []*identity.User, total int64, err error) {
+	var (
+		daos []identityrepo.UserDAO
+	)
+
+	tx := repo.readDB.
+		WithContext(ctx).
+		Model(identityrepo.UserDAO{}).
+		Scopes(tenant.Scope(ctx, "users.tenant_id")).
+		Where("users.status <> ?", identity.UserAccountStatusDeleted)
+
+	if filter != nil {
+		query, args, err := where(filter, userAttributes)
+		if err != nil {
+			return nil, 0, err
+		}
+		tx = tx.Where(query, args...)
+	}
+
+	// count and page share the same filters
+	tx = tx.Session(&gorm.Session{})
+
+	err = tx.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+	// count of zero ask for total only
+	if limit == 0 {
+		return make([]*identity.User, 0), total, nil
+	}
+
+	err = tx.
+		Order("users.created_at, users.id").
+		Offset(offset).
+		Limit(limit).
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	users = make([]*identity.User, 0, len(daos))
+	for i := range daos {
+		users = append(users, identityrepo.UnmarshalUser(&daos[i]))
+	}
+
+	return users, total, nil
+}
+
+// FindExternalIDs is SQL implement
+func (repo *SCIMRepository) FindExternalIDs(ctx context.Context, userIDs []string) (externalIDs map[string]string, err error) {
+	var (
+		daos []identityrepo.ExternalIdentityDAO
+	)
+
+	externalIDs = make(map[string]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return externalIDs, nil
+	}
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(identityrepo.ExternalIdentityDAO{}).
+		Where("provider = ? AND user_id IN ?", ProviderSCIM, userIDs).
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	for _, dao := range daos {
+		externalIDs[dao.UserID] = dao.Subject
+	}
+
+	return externalIDs, nil
+}
+
+// ReplaceExternalID is SQL implement
+func (repo *SCIMRepository) ReplaceExternalID(ctx context.Context, user *identity.User, externalID string) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := tx.
+				Where("provider = ? AND user_id = ?", ProviderSCIM, user.ID).
+				Delete(identityrepo.ExternalIdentityDAO{}).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to unlink external id of user=%v, err %v", user.ID, err)
+			}
+			if externalID == "" {
+				return nil
+			}
+
+			dao := identityrepo.UnmarshalExternalIdentityDAO(identity.NewExternalIdentity(ProviderSCIM, externalID, user.ID, user.TenantID, user.Email))
+			result := tx.
+				Model(dao).
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(dao)
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to link external id of user=%v, err %v", user.ID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(scim.WithType(errors.ErrConflict, scim.TypeUniqueness), "externalId %v already belong to another user", externalID)
+			}
+
+			return nil
+		})
+}
+
+// FindGroups is SQL implement
+func (repo *SCIMRepository) FindGroups(ctx context.Context, filter *scim.Filter, offset int, limit int) (groups []*rbac.Group, total int64, err error) {
+	var (
+		daos []rbacrepo.GroupDAO
+	)
+
+	tx := repo.readDB.
+		WithContext(ctx).
+		Model(rbacrepo.GroupDAO{})
+
+	if filter != nil {
+		query, args, err := where(filter, groupAttributes)
+		if err != nil {
+			return nil, 0, err
+		}
+		tx = tx.Where(query, args...)
+	}
+
+	// count and page share the same filters
+	tx = tx.Session(&gorm.Session{})
+
+	err = tx.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+	// count of zero ask for total only
+	if limit == 0 {
+		return make([]*rbac.Group, 0), total, nil
+	}
+
+	err = tx.
+		Order("groups.created_at, groups.id").
+		Offset(offset).
+		Limit(limit).
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	groups = make([]*rbac.Group, 0, len(daos))
+	for i := range daos {
+		groups = append(groups, rbacrepo.UnmarshalGroup(&daos[i]))
+	}
+
+	return groups, total, nil
+}
+
+// memberRow is member of group joined with its username
+type memberRow struct {
+	GroupID  string `gorm:"column:group_id"`
+	UserID   string `gorm:"column:user_id"`
+	Username string `gorm:"column:username"`
+}
+
+// FindGroupMembers is SQL implement
+func (repo *SCIMRepository) FindGroupMembers(ctx context.Context, groupIDs []string) (members map[string][]entity.Member, err error) {
+	var (
+		rows []memberRow
+	)
+
+	members = make(map[string][]entity.Member, len(groupIDs))
+	if len(groupIDs) == 0 {
+		return members, nil
+	}
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(rbacrepo.GroupMemberDAO{}).
+		Select("group_members.group_id, group_members.user_id, users.username").
+		Joins("LEFT JOIN users ON users.id = group_members.user_id").
+		Where("group_members.group_id IN ?", groupIDs).
+		Order("group_members.created_at, group_members.user_id").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	for _, row := range rows {
+		members[row.GroupID] = append(members[row.GroupID], entity.Member{
+			Value:   row.UserID,
+			Display: row.Username,
+			Type:    entity.ResourceTypeUser,
+		})
+	}
+
+	return members, nil
+}
+
+// attributeKind decide how attribute of filter is compared in SQL
+type attributeKind int
+
+const (
+	kindString     attributeKind = iota // kindString case insensitive string column
+	kindCaseExact                       // kindCaseExact case sensitive string column
+	kindTime                            // kindTime unix milli column compared with RFC3339 value
+	kindActive                          // kindActive boolean derived from user status
+	kindEmailType                       // kindEmailType type of email which is always work
+	kindExternalID                      // kindExternalID subject of scim identity
+	kindMember                          // kindMember user id in group members
+)
+
+// attribute is column which attribute of filter is compared with
+type attribute struct {
+	column string
+	kind   attributeKind
+}
+
+var userAttributes = map[string]attribute{
+	"id":                {column: "users.id", kind: kindCaseExact},
+	"username":          {column: "users.username", kind: kindString},
+	"displayname":       {column: "users.nickname", kind: kindString},
+	"name.givenname":    {column: "users.first_name", kind: kindString},
+	"name.familyname":   {column: "users.last_name", kind: kindString},
+	"emails":            {column: "users.email", kind: kindString},
+	"emails.value":      {column: "users.email", kind: kindString},
+	"emails.type":       {column: "users.email", kind: kindEmailType},
+	"active":            {column: "users.status", kind: kindActive},
+	"externalid":        {column: "users.id", kind: kindExternalID},
+	"meta.created":      {column: "users.created_at", kind: kindTime},
+	"meta.lastmodified": {column: "users.updated_at", kind: kindTime},
+}
+
+var groupAttributes = map[string]attribute{
+	"id":                {column: "groups.id", kind: kindCaseExact},
+	"displayname":       {column: "groups.name", kind: kindString},
+	"members":           {column: "groups.id", kind: kindMember},
+	"members.value":     {column: "groups.id", kind: kindMember},
+	"meta.created":      {column: "groups.created_at", kind: kindTime},
+	"meta.lastmodified": {column: "groups.updated_at", kind: kindTime},
+}
+
+var comparisons = map[scim.Operator]string{
+	scim.OpEqual:          "=",
+	scim.OpNotEqual:       "<>",
+	scim.OpGreater:        ">",
+	scim.OpGreaterOrEqual: ">=",
+	scim.OpLess:           "<",
+	scim.OpLessOrEqual:    "<=",
+}
+
+// where translate filter to SQL condition over attributes
+func where(filter *scim.Filter, attributes map[string]attribute) (query string, args []interface{}, err error) {
+	switch filter.Op {
+	case scim.OpAnd, scim.OpOr:
+		left, leftArgs, err := where(filter.Filters[0], attributes)
+		if err != nil {
+			return "", nil, err
+		}
+		right, rightArgs, err := where(filter.Filters[1], attributes)
+		if err != nil {
+			return "", nil, err
+		}
+		return "(" + left + " " + strings.ToUpper(string(filter.Op)) + " " + right + ")", append(leftArgs, rightArgs...), nil
+	case scim.OpNot:
+		query, args, err := where(filter.Filters[0], attributes)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + query, args, nil
+	}
+
+	attr, ok := attributes[filter.Path]
+	if !ok {
+		return "", nil, invalidFilter("attribute %v is not filterable", filter.Path)
+	}
+
+	switch attr.kind {
+	case kindString, kindCaseExact:
+		return compareString(attr.column, attr.kind == kindCaseExact, filter)
+	case kindTime:
+		return compareTime(attr.column, filter)
+	case kindActive:
+		return compareActive(attr.column, filter)
+	case kindEmailType:
+		return compareEmailType(attr.column, filter)
+	case kindExternalID:
+		return subquery(attr.column, "SELECT user_id FROM identities WHERE provider = ?", "subject", []interface{}{ProviderSCIM}, filter)
+	case kindMember:
+		return subquery(attr.column, "SELECT group_id FROM group_members", "user_id", nil, filter)
+	}
+
+	return "", nil, invalidFilter("attribute %v is not filterable", filter.Path)
+}
+
+func compareString(column string, caseExact bool, filter *scim.Filter) (query string, args []interface{}, err error) {
+	if filter.Op == scim.OpPresent {
+		return "(" + column + " IS NOT NULL AND " + column + " <> '')", nil, nil
+	}
+
+	value, ok := filter.Value.(string)
+	if !ok {
+		return "", nil, invalidFilter("attribute %v must compare with string", filter.Path)
+	}
+	if !caseExact {
+		column = "LOWER(" + column + ")"
+		value = strings.ToLower(value)
+	}
+
+	switch filter.Op {
+	case scim.OpContains:
+		return "(" + column + " LIKE ?)", []interface{}{"%" + escapeLike(value) + "%"}, nil
+	case scim.OpStartsWith:
+		return "(" + column + " LIKE ?)", []interface{}{escapeLike(value) + "%"}, nil
+	case scim.OpEndsWith:
+		return "(" + column + " LIKE ?)", []interface{}{"%" + escapeLike(value)}, nil
+	}
+
+	return "(" + column + " " + comparisons[filter.Op] + " ?)", []interface{}{value}, nil
+}
+
+func compareTime(column string, filter *scim.Filter) (query string, args []interface{}, err error) {
+	if filter.Op == scim.OpPresent {
+		return "(1 = 1)", nil, nil
+	}
+
+	comparison, ok := comparisons[filter.Op]
+	if !ok {
+		return "", nil, invalidFilter("operator %v is not supported by attribute %v", filter.Op, filter.Path)
+	}
+	value, _ := filter.Value.(string)
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", nil, invalidFilter("attribute %v must compare with RFC3339 date time", filter.Path)
+	}
+
+	return "(" + column + " " + comparison + " ?)", []interface{}{t.UnixMilli()}, nil
+}
+
+func compareActive(column string, filter *scim.Filter) (query string, args []interface{}, err error) {
+	if filter.Op == scim.OpPresent {
+		return "(1 = 1)", nil, nil
+	}
+
+	value, ok := filter.Value.(bool)
+	if !ok || (filter.Op != scim.OpEqual && filter.Op != scim.OpNotEqual) {
+		return "", nil, invalidFilter("attribute %v only support eq and ne with boolean", filter.Path)
+	}
+	if filter.Op == scim.OpNotEqual {
+		value = !value
+	}
+	if value {
+		return "(" + column + " = ?)", []interface{}{identity.UserAccountStatusActive}, nil
+	}
+
+	return "(" + column + " <> ?)", []interface{}{identity.UserAccountStatusActive}, nil
+}
+
+// compareEmailType evaluate filter against type of stored email, which is always work
+func compareEmailType(column string, filter *scim.Filter) (query string, args []interface{}, err error) {
+	matched := filter.Op == scim.OpPresent ||
+		(&scim.Filter{Op: filter.Op, Path: "type", Value: filter.Value}).Match(map[string]interface{}{"type": "work"})
+	if !matched {
+		return "(1 = 0)", nil, nil
+	}
+
+	return "(" + column + " IS NOT NULL AND " + column + " <> '')", nil, nil
+}
+
+// subquery compare column of multi-valued attribute stored in another table,
+// ne is negation of eq since any value of attribute is allowed to match
+func subquery(column string, query string, valueColumn string, args []interface{}, filter *scim.Filter) (string, []interface{}, error) {
+	condition := ""
+	negate := false
+	if filter.Op != scim.OpPresent {
+		inner := *filter
+		if inner.Op == scim.OpNotEqual {
+			inner.Op = scim.OpEqual
+			negate = true
+		}
+
+		cond, condArgs, err := compareString(valueColumn, true, &inner)
+		if err != nil {
+			return "", nil, err
+		}
+		if strings.Contains(query, " WHERE ") {
+			condition = " AND " + cond
+		} else {
+			condition = " WHERE " + cond
+		}
+		args = append(args, condArgs...)
+	}
+
+	if negate {
+		return "(" + column + " NOT IN (" + query + condition + "))", args, nil
+	}
+
+	return "(" + column + " IN (" + query + condition + "))", args, nil
+}
+
+func invalidFilter(format string, args ...interface{}) error {
+	return errors.Wrapf(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidFilter), format, args...)
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
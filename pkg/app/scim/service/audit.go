@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/scim/entity"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/scim"
+)
+
+type auditMiddleware struct {
+	next     SCIMService
+	recorder audit.Recorder
+}
+
+// AuditMiddleware record user and group provisioned by identity provider to audit log,
+// read only action is not recorded
+func AuditMiddleware(recorder audit.Recorder) Middleware {
+	return func(next SCIMService) SCIMService {
+		return auditMiddleware{next: next, recorder: recorder}
+	}
+}
+
+func (am auditMiddleware) ListUsers(ctx context.Context, opt *ListOption) (resp *scim.ListResponse, err error) {
+	return am.next.ListUsers(ctx, opt)
+}
+
+func (am auditMiddleware) GetUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	return am.next.GetUser(ctx, userID)
+}
+
+func (am auditMiddleware) CreateUser(ctx context.Context, user *entity.User) (created *entity.User, err error) {
+	defer func() {
+		var userID string
+		if created != nil {
+			userID = created.ID
+		}
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionSCIMCreateUser, userID, err))
+	}()
+
+	return am.next.CreateUser(ctx, user)
+}
+
+func (am auditMiddleware) ReplaceUser(ctx context.Context, userID string, user *entity.User, opt *WriteOption) (replaced *entity.User, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionSCIMReplaceUser, userID, err))
+	}()
+
+	return am.next.ReplaceUser(ctx, userID, user, opt)
+}
+
+func (am auditMiddleware) PatchUser(ctx context.Context, userID string, req *scim.PatchRequest, opt *WriteOption) (patched *entity.User, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionSCIMPatchUser, userID, err))
+	}()
+
+	return am.next.PatchUser(ctx, userID, req, opt)
+}
+
+func (am auditMiddleware) DeleteUser(ctx context.Context, userID string, opt *WriteOption) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionSCIMDeleteUser, userID, err))
+	}()
+
+	return am.next.DeleteUser(ctx, userID, opt)
+}
+
+func (am auditMiddleware) ListGroups(ctx context.Context, opt *ListOption) (resp *scim.ListResponse, err error) {
+	return am.next.ListGroups(ctx, opt)
+}
+
+func (am auditMiddleware) GetGroup(ctx context.Context, groupID string) (group *entity.Group, err error) {
+	return am.next.GetGroup(ctx, groupID)
+}
+
+func (am auditMiddleware) CreateGroup(ctx context.Context, group *entity.Group) (created *entity.Group, err error) {
+	defer func() {
+		var groupID string
+		if created != nil {
+			groupID = created.ID
+		}
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionSCIMCreateGroup, groupID, err))
+	}()
+
+	return am.next.CreateGroup(ctx, group)
+}
+
+func (am auditMiddleware) ReplaceGroup(ctx context.Context, groupID string, group *entity.Group, opt *WriteOption) (replaced *entity.Group, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionSCIMReplaceGroup, groupID, err))
+	}()
+
+	return am.next.ReplaceGroup(ctx, groupID, group, opt)
+}
+
+func (am auditMiddleware) PatchGroup(ctx context.Context, groupID string, req *scim.PatchRequest, opt *WriteOption) (patched *entity.Group, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionSCIMPatchGroup, groupID, err))
+	}()
+
+	return am.next.PatchGroup(ctx, groupID, req, opt)
+}
+
+func (am auditMiddleware) DeleteGroup(ctx context.Context, groupID string, opt *WriteOption) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, audit.NewPrincipalEvent(ctx, audit.ActionSCIMDeleteGroup, groupID, err))
+	}()
+
+	return am.next.DeleteGroup(ctx, groupID, opt)
+}
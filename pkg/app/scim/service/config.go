@@ -0,0 +1,23 @@
+package service
+
+import (
+	"strings"
+)
+
+// Config for SCIM provisioning
+type Config struct {
+	// Tokens are bearer tokens of provisioning clients such as HR system or IdP,
+	// SCIM endpoints reject every request when it is empty
+	Tokens []string `mapstructure:"tokens"`
+	// URL is base url of SCIM endpoints used as location of resources, default is /scim/v2
+	URL string `mapstructure:"url"`
+}
+
+// BaseURL is url of SCIM endpoints without trailing slash
+func (c Config) BaseURL() string {
+	url := strings.TrimSuffix(c.URL, "/")
+	if url == "" {
+		return "/scim/v2"
+	}
+	return url
+}
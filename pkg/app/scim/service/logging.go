@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/scim/entity"
+	"github.com/karta0898098/iam/pkg/scim"
+)
+
+type loggingMiddleware struct {
+	next SCIMService `json:"-"`
+}
+
+// LoggingMiddleware takes a logger as a dependency
+// and returns a ServiceMiddleware.
+func LoggingMiddleware() Middleware {
+	return func(next SCIMService) SCIMService {
+		return loggingMiddleware{next}
+	}
+}
+
+func (lm loggingMiddleware) ListUsers(ctx context.Context, opt *ListOption) (resp *scim.ListResponse, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListUsers",
+		// 	"filter", opt.Filter,
+		// 	"start_index", opt.StartIndex,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListUsers(ctx, opt)
+}
+
+func (lm loggingMiddleware) GetUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "GetUser",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.GetUser(ctx, userID)
+}
+
+func (lm loggingMiddleware) CreateUser(ctx context.Context, user *entity.User) (created *entity.User, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "CreateUser",
+		// 	"username", user.UserName,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.CreateUser(ctx, user)
+}
+
+func (lm loggingMiddleware) ReplaceUser(ctx context.Context, userID string, user *entity.User, opt *WriteOption) (replaced *entity.User, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ReplaceUser",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ReplaceUser(ctx, userID, user, opt)
+}
+
+func (lm loggingMiddleware) PatchUser(ctx context.Context, userID string, req *scim.PatchRequest, opt *WriteOption) (patched *entity.User, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "PatchUser",
+		// 	"user_id", userID,
+		// 	"operations", len(req.Operations),
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.PatchUser(ctx, userID, req, opt)
+}
+
+func (lm loggingMiddleware) DeleteUser(ctx context.Context, userID string, opt *WriteOption) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "DeleteUser",
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.DeleteUser(ctx, userID, opt)
+}
+
+func (lm loggingMiddleware) ListGroups(ctx context.Context, opt *ListOption) (resp *scim.ListResponse, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListGroups",
+		// 	"filter", opt.Filter,
+		// 	"start_index", opt.StartIndex,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListGroups(ctx, opt)
+}
+
+func (lm loggingMiddleware) GetGroup(ctx context.Context, groupID string) (group *entity.Group, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "GetGroup",
+		// 	"group_id", groupID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.GetGroup(ctx, groupID)
+}
+
+func (lm loggingMiddleware) CreateGroup(ctx context.Context, group *entity.Group) (created *entity.Group, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "CreateGroup",
+		// 	"display_name", group.DisplayName,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.CreateGroup(ctx, group)
+}
+
+func (lm loggingMiddleware) ReplaceGroup(ctx context.Context, groupID string, group *entity.Group, opt *WriteOption) (replaced *entity.Group, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ReplaceGroup",
+		// 	"group_id", groupID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ReplaceGroup(ctx, groupID, group, opt)
+}
+
+func (lm loggingMiddleware) PatchGroup(ctx context.Context, groupID string, req *scim.PatchRequest, opt *WriteOption) (patched *entity.Group, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "PatchGroup",
+		// 	"group_id", groupID,
+		// 	"operations", len(req.Operations),
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.PatchGroup(ctx, groupID, req, opt)
+}
+
+func (lm loggingMiddleware) DeleteGroup(ctx context.Context, groupID string, opt *WriteOption) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "DeleteGroup",
+		// 	"group_id", groupID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.DeleteGroup(ctx, groupID, opt)
+}
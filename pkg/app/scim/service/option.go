@@ -0,0 +1,24 @@
+package service
+
+const (
+	// CountDefault resources of page when count is not given
+	CountDefault = 100
+	// CountMax max resources of page, see maxResults of service provider config
+	CountMax = 200
+)
+
+// ListOption define filter and page of listing resources, see RFC 7644 section 3.4.2
+type ListOption struct {
+	// Filter is SCIM filter expression, empty filter match all resources
+	Filter string
+	// StartIndex is 1-based index of the first resource
+	StartIndex int
+	// Count is max resources of page, nil use CountDefault
+	Count *int
+}
+
+// WriteOption define precondition of modifying resource
+type WriteOption struct {
+	// IfMatch is If-Match header, resource is modified only when its version match
+	IfMatch string
+}
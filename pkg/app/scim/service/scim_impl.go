@@ -0,0 +1,690 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rs/xid"
+
+	identity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	identityrepo "github.com/karta0898098/iam/pkg/app/identity/repository"
+	rbac "github.com/karta0898098/iam/pkg/app/rbac/entity"
+	rbacsvc "github.com/karta0898098/iam/pkg/app/rbac/service"
+	"github.com/karta0898098/iam/pkg/app/scim/entity"
+	"github.com/karta0898098/iam/pkg/app/scim/repository"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/password"
+	"github.com/karta0898098/iam/pkg/scim"
+	"github.com/karta0898098/iam/pkg/tenant"
+)
+
+var _ SCIMService = &Impl{}
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(SCIMService) SCIMService
+
+// SCIMService define SCIM 2.0 provisioning of users and groups, see RFC 7644
+type SCIMService interface {
+	// ListUsers list page of users match filter, deleted users are excluded
+	ListUsers(
+		ctx context.Context,
+		opt *ListOption,
+	) (resp *scim.ListResponse, err error)
+
+	// GetUser get user
+	GetUser(
+		ctx context.Context,
+		userID string,
+	) (user *entity.User, err error)
+
+	// CreateUser create user, user without password can only sign in through federation or directory
+	CreateUser(
+		ctx context.Context,
+		user *entity.User,
+	) (created *entity.User, err error)
+
+	// ReplaceUser replace attributes of user, userName is immutable
+	ReplaceUser(
+		ctx context.Context,
+		userID string,
+		user *entity.User,
+		opt *WriteOption,
+	) (replaced *entity.User, err error)
+
+	// PatchUser apply patch operations to user
+	PatchUser(
+		ctx context.Context,
+		userID string,
+		req *scim.PatchRequest,
+		opt *WriteOption,
+	) (patched *entity.User, err error)
+
+	// DeleteUser mark user deleted and revoke sessions of user
+	DeleteUser(
+		ctx context.Context,
+		userID string,
+		opt *WriteOption,
+	) (err error)
+
+	// ListGroups list page of groups match filter
+	ListGroups(
+		ctx context.Context,
+		opt *ListOption,
+	) (resp *scim.ListResponse, err error)
+
+	// GetGroup get group with its members
+	GetGroup(
+		ctx context.Context,
+		groupID string,
+	) (group *entity.Group, err error)
+
+	// CreateGroup create group with members
+	CreateGroup(
+		ctx context.Context,
+		group *entity.Group,
+	) (created *entity.Group, err error)
+
+	// ReplaceGroup replace display name and members of group
+	ReplaceGroup(
+		ctx context.Context,
+		groupID string,
+		group *entity.Group,
+		opt *WriteOption,
+	) (replaced *entity.Group, err error)
+
+	// PatchGroup apply patch operations to group
+	PatchGroup(
+		ctx context.Context,
+		groupID string,
+		req *scim.PatchRequest,
+		opt *WriteOption,
+	) (patched *entity.Group, err error)
+
+	// DeleteGroup delete group and its memberships
+	DeleteGroup(
+		ctx context.Context,
+		groupID string,
+		opt *WriteOption,
+	) (err error)
+}
+
+type Impl struct {
+	repo         repository.Repository
+	identityRepo identityrepo.Repository
+	rbacSvc      rbacsvc.RBACService
+	hasher       password.Hasher
+	url          string
+}
+
+func New(
+	repo repository.Repository,
+	identityRepo identityrepo.Repository,
+	rbacSvc rbacsvc.RBACService,
+	hasher password.Hasher,
+	config Config,
+	recorder audit.Recorder,
+) SCIMService {
+	var svc SCIMService
+	svc = &Impl{
+		repo:         repo,
+		identityRepo: identityRepo,
+		rbacSvc:      rbacSvc,
+		hasher:       hasher,
+		url:          config.BaseURL(),
+	}
+	svc = LoggingMiddleware()(svc)
+	if recorder != nil {
+		svc = AuditMiddleware(recorder)(svc)
+	}
+
+	return svc
+}
+
+func (srv *Impl) ListUsers(ctx context.Context, opt *ListOption) (resp *scim.ListResponse, err error) {
+	filter, startIndex, count, err := parseListOption(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	users, total, err := srv.repo.FindUsers(ctx, filter, startIndex-1, count)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	externalIDs, err := srv.repo.FindExternalIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, srv.userResource(user, externalIDs[user.ID]))
+	}
+
+	return scim.NewListResponse(total, startIndex, resources), nil
+}
+
+func (srv *Impl) GetUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	found, externalID, err := srv.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.userResource(found, externalID), nil
+}
+
+func (srv *Impl) CreateUser(ctx context.Context, user *entity.User) (created *entity.User, err error) {
+	err = user.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = srv.identityRepo.FindUserByUsername(ctx, user.UserName)
+	if err == nil {
+		return nil, errors.Wrapf(scim.WithType(errors.ErrConflict, scim.TypeUniqueness), "userName %v is already taken", user.UserName)
+	}
+	if !errors.Is(err, errors.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	if user.ExternalID != "" {
+		_, err = srv.identityRepo.FindExternalIdentity(ctx, repository.ProviderSCIM, user.ExternalID)
+		if err == nil {
+			return nil, errors.Wrapf(scim.WithType(errors.ErrConflict, scim.TypeUniqueness), "externalId %v already belong to another user", user.ExternalID)
+		}
+		if !errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, err
+		}
+	}
+
+	opts := append(profileOptions(user), identity.WithTenant(tenant.ID(ctx)), identity.WithPasswordHasher(srv.hasher))
+
+	var newUser *identity.User
+	if user.Password != "" {
+		newUser, err = identity.NewUser(xid.New().String(), user.UserName, user.Password, opts...)
+	} else {
+		newUser, err = identity.NewDirectoryUser(xid.New().String(), user.UserName, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		err = newUser.Suspend()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = srv.identityRepo.StoreUser(ctx, newUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.ExternalID != "" {
+		err = srv.repo.ReplaceExternalID(ctx, newUser, user.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return srv.userResource(newUser, user.ExternalID), nil
+}
+
+func (srv *Impl) ReplaceUser(ctx context.Context, userID string, user *entity.User, opt *WriteOption) (replaced *entity.User, err error) {
+	err = user.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	found, externalID, err := srv.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkETag(opt, scim.ETag(found.UpdatedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.saveUser(ctx, found, externalID, user)
+}
+
+func (srv *Impl) PatchUser(ctx context.Context, userID string, req *scim.PatchRequest, opt *WriteOption) (patched *entity.User, err error) {
+	err = req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	found, externalID, err := srv.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkETag(opt, scim.ETag(found.UpdatedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	// patch the resource then save it as a replacement
+	user := srv.userResource(found, externalID)
+	for _, op := range req.Operations {
+		err = user.Patch(op)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return srv.saveUser(ctx, found, externalID, user)
+}
+
+func (srv *Impl) DeleteUser(ctx context.Context, userID string, opt *WriteOption) (err error) {
+	user, _, err := srv.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = checkETag(opt, scim.ETag(user.UpdatedAt))
+	if err != nil {
+		return err
+	}
+
+	from := user.Status
+	err = user.MarkDeleted()
+	if err != nil {
+		return err
+	}
+
+	err = srv.identityRepo.UpdateUserStatus(ctx, user, from)
+	if err != nil {
+		return err
+	}
+
+	err = srv.identityRepo.RevokeUserSessions(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	// deleted user keep username, but external id can be provisioned again
+	return srv.repo.ReplaceExternalID(ctx, user, "")
+}
+
+// findUser find user which is not deleted and its external id
+func (srv *Impl) findUser(ctx context.Context, userID string) (user *identity.User, externalID string, err error) {
+	user, err = srv.identityRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if user.IsDeleted() {
+		return nil, "", errors.Wrapf(errors.ErrResourceNotFound, "user=%v is deleted", userID)
+	}
+
+	externalIDs, err := srv.repo.FindExternalIDs(ctx, []string{user.ID})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, externalIDs[user.ID], nil
+}
+
+// saveUser write attributes of resource to user
+func (srv *Impl) saveUser(ctx context.Context, user *identity.User, externalID string, resource *entity.User) (*entity.User, error) {
+	if resource.UserName != user.Username {
+		return nil, errors.Wrapf(scim.WithType(errors.ErrInvalidInput, scim.TypeMutability), "userName of user=%v is immutable", user.ID)
+	}
+
+	version := user.Version
+	if resource.Password != "" {
+		err := user.SetPassword(srv.hasher, resource.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := user.UpdateProfile(profileOptions(resource)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.identityRepo.UpdateUser(ctx, user, version)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !resource.IsActive() && user.IsActive():
+		err = user.Suspend()
+		if err != nil {
+			return nil, err
+		}
+		err = srv.identityRepo.UpdateUserStatus(ctx, user, identity.UserAccountStatusActive)
+		if err != nil {
+			return nil, err
+		}
+		err = srv.identityRepo.RevokeUserSessions(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+	case resource.IsActive() && user.IsSuspended():
+		err = user.Reactivate()
+		if err != nil {
+			return nil, err
+		}
+		err = srv.identityRepo.UpdateUserStatus(ctx, user, identity.UserAccountStatusSuspend)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resource.ExternalID != externalID {
+		err = srv.repo.ReplaceExternalID(ctx, user, resource.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return srv.userResource(user, resource.ExternalID), nil
+}
+
+func (srv *Impl) userResource(user *identity.User, externalID string) *entity.User {
+	return entity.NewUser(user, externalID, srv.url+"/Users/"+user.ID)
+}
+
+// profileOptions map attributes of resource onto profile of user, absent attribute clear it
+func profileOptions(user *entity.User) []identity.NewUserOption {
+	opts := []identity.NewUserOption{
+		identity.WithNickname(user.DisplayName),
+		identity.WithFirstName(user.GivenName()),
+		identity.WithLastName(user.FamilyName()),
+		identity.WithAvatar(user.Photo()),
+	}
+
+	if email := user.Email(); email != "" {
+		opts = append(opts, identity.WithEmail(email))
+	} else {
+		opts = append(opts, withoutEmail)
+	}
+
+	return opts
+}
+
+// withoutEmail clear email since identity.WithEmail require email
+func withoutEmail(p *identity.User) error {
+	p.Email = ""
+	return nil
+}
+
+func (srv *Impl) ListGroups(ctx context.Context, opt *ListOption) (resp *scim.ListResponse, err error) {
+	filter, startIndex, count, err := parseListOption(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, total, err := srv.repo.FindGroups(ctx, filter, startIndex-1, count)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := make([]string, 0, len(groups))
+	for _, group := range groups {
+		groupIDs = append(groupIDs, group.ID)
+	}
+	members, err := srv.repo.FindGroupMembers(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, 0, len(groups))
+	for _, group := range groups {
+		resources = append(resources, srv.groupResource(group, members[group.ID]))
+	}
+
+	return scim.NewListResponse(total, startIndex, resources), nil
+}
+
+func (srv *Impl) GetGroup(ctx context.Context, groupID string) (group *entity.Group, err error) {
+	found, members, err := srv.findGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.groupResource(found, members), nil
+}
+
+func (srv *Impl) CreateGroup(ctx context.Context, group *entity.Group) (created *entity.Group, err error) {
+	err = group.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs := group.MemberIDs()
+	err = srv.checkMembers(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	newGroup, err := srv.rbacSvc.CreateGroup(ctx, &rbacsvc.GroupOption{
+		Name: group.DisplayName,
+	})
+	if err != nil {
+		return nil, uniqueness(err)
+	}
+
+	for _, userID := range memberIDs {
+		err = srv.rbacSvc.AddGroupMember(ctx, newGroup.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return srv.loadGroup(ctx, newGroup)
+}
+
+func (srv *Impl) ReplaceGroup(ctx context.Context, groupID string, group *entity.Group, opt *WriteOption) (replaced *entity.Group, err error) {
+	err = group.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	found, members, err := srv.findGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkETag(opt, scim.ETag(found.UpdatedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.saveGroup(ctx, found, members, group)
+}
+
+func (srv *Impl) PatchGroup(ctx context.Context, groupID string, req *scim.PatchRequest, opt *WriteOption) (patched *entity.Group, err error) {
+	err = req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	found, members, err := srv.findGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkETag(opt, scim.ETag(found.UpdatedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	// patch the resource then save it as a replacement
+	group := srv.groupResource(found, members)
+	for _, op := range req.Operations {
+		err = group.Patch(op)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return srv.saveGroup(ctx, found, members, group)
+}
+
+func (srv *Impl) DeleteGroup(ctx context.Context, groupID string, opt *WriteOption) (err error) {
+	group, err := srv.rbacSvc.GetGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	err = checkETag(opt, scim.ETag(group.UpdatedAt))
+	if err != nil {
+		return err
+	}
+
+	return srv.rbacSvc.DeleteGroup(ctx, groupID)
+}
+
+// findGroup find group and its members
+func (srv *Impl) findGroup(ctx context.Context, groupID string) (group *rbac.Group, members []entity.Member, err error) {
+	group, err = srv.rbacSvc.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found, err := srv.repo.FindGroupMembers(ctx, []string{group.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return group, found[group.ID], nil
+}
+
+// loadGroup read members of group saved
+func (srv *Impl) loadGroup(ctx context.Context, group *rbac.Group) (*entity.Group, error) {
+	members, err := srv.repo.FindGroupMembers(ctx, []string{group.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.groupResource(group, members[group.ID]), nil
+}
+
+// saveGroup write display name of resource to group and apply difference of members
+func (srv *Impl) saveGroup(ctx context.Context, group *rbac.Group, members []entity.Member, resource *entity.Group) (*entity.Group, error) {
+	current := make(map[string]bool, len(members))
+	for _, member := range members {
+		current[member.Value] = true
+	}
+
+	wanted := resource.MemberIDs()
+	added := make([]string, 0, len(wanted))
+	for _, userID := range wanted {
+		if current[userID] {
+			delete(current, userID)
+			continue
+		}
+		added = append(added, userID)
+	}
+
+	err := srv.checkMembers(ctx, added)
+	if err != nil {
+		return nil, err
+	}
+
+	// update group even if name is unchanged so version of group follow its members
+	updated, err := srv.rbacSvc.UpdateGroup(ctx, group.ID, &rbacsvc.GroupOption{
+		Name:        resource.DisplayName,
+		Description: group.Description,
+	})
+	if err != nil {
+		return nil, uniqueness(err)
+	}
+
+	for _, userID := range added {
+		err = srv.rbacSvc.AddGroupMember(ctx, group.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, member := range members {
+		if !current[member.Value] {
+			continue
+		}
+		err = srv.rbacSvc.RemoveGroupMember(ctx, group.ID, member.Value)
+		if err != nil && !errors.Is(err, errors.ErrResourceNotFound) {
+			return nil, err
+		}
+	}
+
+	return srv.loadGroup(ctx, updated)
+}
+
+// checkMembers users to be added exist, so group is not left half provisioned
+func (srv *Impl) checkMembers(ctx context.Context, userIDs []string) error {
+	for _, userID := range userIDs {
+		user, err := srv.identityRepo.FindUserByID(ctx, userID)
+		if errors.Is(err, errors.ErrResourceNotFound) || (err == nil && user.IsDeleted()) {
+			return errors.Wrapf(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidValue), "member %v is not a user", userID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (srv *Impl) groupResource(group *rbac.Group, members []entity.Member) *entity.Group {
+	for i := range members {
+		members[i].Ref = srv.url + "/Users/" + members[i].Value
+	}
+	return entity.NewGroup(group, members, srv.url+"/Groups/"+group.ID)
+}
+
+// uniqueness tag conflict of group name with scimType
+func uniqueness(err error) error {
+	if errors.Is(err, errors.ErrConflict) {
+		return errors.Wrap(scim.WithType(errors.ErrConflict, scim.TypeUniqueness), err.Error())
+	}
+	return err
+}
+
+// parseListOption parse filter and normalize page, startIndex less than 1 is 1
+// and count is capped to CountMax as RFC 7644 allow returning fewer results
+func parseListOption(opt *ListOption) (filter *scim.Filter, startIndex int, count int, err error) {
+	if opt.Filter != "" {
+		filter, err = scim.ParseFilter(opt.Filter)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	startIndex = opt.StartIndex
+	if startIndex < 1 {
+		startIndex = 1
+	}
+
+	count = CountDefault
+	if opt.Count != nil {
+		count = *opt.Count
+	}
+	if count < 0 {
+		count = 0
+	}
+	if count > CountMax {
+		count = CountMax
+	}
+
+	return filter, startIndex, count, nil
+}
+
+// checkETag resource is modified only when If-Match contain its version
+func checkETag(opt *WriteOption, etag string) error {
+	if opt == nil || opt.IfMatch == "" {
+		return nil
+	}
+	if !scim.MatchETag(opt.IfMatch, etag) {
+		return errors.Wrapf(errors.ErrPreconditionFailed, "version %v does not match %v", etag, opt.IfMatch)
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	identity "github.com/karta0898098/iam/pkg/app/identity/entity"
+	identitymocks "github.com/karta0898098/iam/pkg/app/identity/mocks"
+	rbac "github.com/karta0898098/iam/pkg/app/rbac/entity"
+	rbacmocks "github.com/karta0898098/iam/pkg/app/rbac/mocks"
+	rbacsvc "github.com/karta0898098/iam/pkg/app/rbac/service"
+	"github.com/karta0898098/iam/pkg/app/scim/entity"
+	scimmocks "github.com/karta0898098/iam/pkg/app/scim/mocks"
+	"github.com/karta0898098/iam/pkg/app/scim/repository"
+	"github.com/karta0898098/iam/pkg/app/scim/service"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/password"
+	"github.com/karta0898098/iam/pkg/scim"
+)
+
+var modifiedAt = time.UnixMilli(1700000000000)
+
+func newUser(status identity.UserAccountStatus) *identity.User {
+	return &identity.User{
+		ID:        "MOCK-USER-ID",
+		Username:  "bjensen",
+		Nickname:  "Babs",
+		Email:     "bjensen@example.com",
+		Status:    status,
+		Version:   3,
+		CreatedAt: modifiedAt,
+		UpdatedAt: modifiedAt,
+	}
+}
+
+func patch(ops ...scim.PatchOperation) *scim.PatchRequest {
+	return &scim.PatchRequest{Schemas: []string{scim.SchemaPatchOp}, Operations: ops}
+}
+
+func TestImpl_CreateUser(t *testing.T) {
+	newResource := func() *entity.User {
+		return &entity.User{
+			Schemas:    []string{scim.SchemaUser},
+			ExternalID: "701984",
+			UserName:   "bjensen",
+			Name:       &entity.Name{GivenName: "Barbara", FamilyName: "Jensen"},
+			Emails:     []entity.MultiValue{{Value: "BJensen@example.com", Type: "work", Primary: true}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		resource *entity.User
+		setup    func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository)
+		err      error
+	}{
+		{
+			name:     "Create With External ID",
+			resource: newResource(),
+			setup: func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository) {
+				identityRepo.EXPECT().
+					FindUserByUsername(mock.Anything, "bjensen").
+					Return(nil, errors.ErrResourceNotFound)
+				identityRepo.EXPECT().
+					FindExternalIdentity(mock.Anything, repository.ProviderSCIM, "701984").
+					Return(nil, errors.ErrResourceNotFound)
+				identityRepo.EXPECT().
+					StoreUser(mock.Anything, mock.MatchedBy(func(user *identity.User) bool {
+						return user.Username == "bjensen" && user.FirstName == "Barbara" && user.Email == "bjensen@example.com" && user.Password == ""
+					})).
+					Return(nil)
+				repo.EXPECT().
+					ReplaceExternalID(mock.Anything, mock.Anything, "701984").
+					Return(nil)
+			},
+		},
+		{
+			name:     "UserName Taken",
+			resource: newResource(),
+			setup: func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository) {
+				identityRepo.EXPECT().
+					FindUserByUsername(mock.Anything, "bjensen").
+					Return(newUser(identity.UserAccountStatusActive), nil)
+			},
+			err: errors.ErrConflict,
+		},
+		{
+			name:     "External ID Taken",
+			resource: newResource(),
+			setup: func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository) {
+				identityRepo.EXPECT().
+					FindUserByUsername(mock.Anything, "bjensen").
+					Return(nil, errors.ErrResourceNotFound)
+				identityRepo.EXPECT().
+					FindExternalIdentity(mock.Anything, repository.ProviderSCIM, "701984").
+					Return(identity.NewExternalIdentity(repository.ProviderSCIM, "701984", "OTHER-USER-ID", "", ""), nil)
+			},
+			err: errors.ErrConflict,
+		},
+		{
+			name: "Email UserName",
+			resource: func() *entity.User {
+				resource := newResource()
+				resource.UserName = "barbara.jensen@engineering.example.com"
+				return resource
+			}(),
+			setup: func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository) {
+				identityRepo.EXPECT().
+					FindUserByUsername(mock.Anything, "barbara.jensen@engineering.example.com").
+					Return(nil, errors.ErrResourceNotFound)
+				identityRepo.EXPECT().
+					FindExternalIdentity(mock.Anything, repository.ProviderSCIM, "701984").
+					Return(nil, errors.ErrResourceNotFound)
+				identityRepo.EXPECT().
+					StoreUser(mock.Anything, mock.MatchedBy(func(user *identity.User) bool {
+						return user.Username == "barbara.jensen@engineering.example.com"
+					})).
+					Return(nil)
+				repo.EXPECT().
+					ReplaceExternalID(mock.Anything, mock.Anything, "701984").
+					Return(nil)
+			},
+		},
+		{
+			name: "UserName Too Long",
+			resource: func() *entity.User {
+				resource := newResource()
+				resource.UserName = strings.Repeat("b", identity.ProvisionedUsernameLengthMax+1)
+				return resource
+			}(),
+			setup: func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository) {},
+			err:   errors.ErrInvalidInput,
+		},
+		{
+			name:     "Missing Schema",
+			resource: &entity.User{UserName: "bjensen"},
+			setup:    func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository) {},
+			err:      errors.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identityRepo := identitymocks.NewRepository(t)
+			repo := scimmocks.NewRepository(t)
+			tt.setup(identityRepo, repo)
+
+			srv := service.New(repo, identityRepo, rbacmocks.NewRBACService(t), password.Default, service.Config{}, nil)
+			user, err := srv.CreateUser(context.Background(), tt.resource)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, "701984", user.ExternalID)
+				assert.Equal(t, "/scim/v2/Users/"+user.ID, user.Meta.Location)
+				assert.True(t, user.IsActive())
+			}
+		})
+	}
+}
+
+func TestImpl_PatchUser(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   *scim.PatchRequest
+		opt   *service.WriteOption
+		setup func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository)
+		err   error
+	}{
+		{
+			name: "Deactivate",
+			req:  patch(scim.PatchOperation{Op: scim.PatchReplace, Path: "active", Value: json.RawMessage(`false`)}),
+			opt:  &service.WriteOption{IfMatch: scim.ETag(modifiedAt)},
+			setup: func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository) {
+				identityRepo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(newUser(identity.UserAccountStatusActive), nil)
+				repo.EXPECT().
+					FindExternalIDs(mock.Anything, []string{"MOCK-USER-ID"}).
+					Return(map[string]string{"MOCK-USER-ID": "701984"}, nil)
+				identityRepo.EXPECT().
+					UpdateUser(mock.Anything, mock.Anything, int64(3)).
+					Return(nil)
+				identityRepo.EXPECT().
+					UpdateUserStatus(mock.Anything, mock.MatchedBy(func(user *identity.User) bool {
+						return user.IsSuspended()
+					}), identity.UserAccountStatus(identity.UserAccountStatusActive)).
+					Return(nil)
+				identityRepo.EXPECT().
+					RevokeUserSessions(mock.Anything, "MOCK-USER-ID").
+					Return(nil)
+			},
+		},
+		{
+			name: "Version Mismatch",
+			req:  patch(scim.PatchOperation{Op: scim.PatchReplace, Path: "active", Value: json.RawMessage(`false`)}),
+			opt:  &service.WriteOption{IfMatch: `W/"1"`},
+			setup: func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository) {
+				identityRepo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(newUser(identity.UserAccountStatusActive), nil)
+				repo.EXPECT().
+					FindExternalIDs(mock.Anything, []string{"MOCK-USER-ID"}).
+					Return(map[string]string{}, nil)
+			},
+			err: errors.ErrPreconditionFailed,
+		},
+		{
+			name: "UserName Immutable",
+			req:  patch(scim.PatchOperation{Op: scim.PatchReplace, Path: "userName", Value: json.RawMessage(`"babs"`)}),
+			opt:  &service.WriteOption{},
+			setup: func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository) {
+				identityRepo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(newUser(identity.UserAccountStatusActive), nil)
+				repo.EXPECT().
+					FindExternalIDs(mock.Anything, []string{"MOCK-USER-ID"}).
+					Return(map[string]string{}, nil)
+			},
+			err: errors.ErrInvalidInput,
+		},
+		{
+			name: "Deleted User",
+			req:  patch(scim.PatchOperation{Op: scim.PatchReplace, Path: "displayName", Value: json.RawMessage(`"B"`)}),
+			opt:  &service.WriteOption{},
+			setup: func(identityRepo *identitymocks.Repository, repo *scimmocks.Repository) {
+				identityRepo.EXPECT().
+					FindUserByID(mock.Anything, "MOCK-USER-ID").
+					Return(newUser(identity.UserAccountStatusDeleted), nil)
+			},
+			err: errors.ErrResourceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identityRepo := identitymocks.NewRepository(t)
+			repo := scimmocks.NewRepository(t)
+			tt.setup(identityRepo, repo)
+
+			srv := service.New(repo, identityRepo, rbacmocks.NewRBACService(t), password.Default, service.Config{}, nil)
+			user, err := srv.PatchUser(context.Background(), "MOCK-USER-ID", tt.req, tt.opt)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.False(t, user.IsActive())
+				assert.Equal(t, "701984", user.ExternalID)
+			}
+		})
+	}
+}
+
+func TestImpl_PatchGroup(t *testing.T) {
+	group := &rbac.Group{ID: "MOCK-GROUP-ID", Name: "engineering", Description: "kept", CreatedAt: modifiedAt, UpdatedAt: modifiedAt}
+
+	identityRepo := identitymocks.NewRepository(t)
+	identityRepo.EXPECT().
+		FindUserByID(mock.Anything, "NEW-USER-ID").
+		Return(&identity.User{ID: "NEW-USER-ID", Status: identity.UserAccountStatusActive}, nil)
+
+	repo := scimmocks.NewRepository(t)
+	repo.EXPECT().
+		FindGroupMembers(mock.Anything, []string{"MOCK-GROUP-ID"}).
+		Return(map[string][]entity.Member{"MOCK-GROUP-ID": {{Value: "OLD-USER-ID"}}}, nil).
+		Once()
+	repo.EXPECT().
+		FindGroupMembers(mock.Anything, []string{"MOCK-GROUP-ID"}).
+		Return(map[string][]entity.Member{"MOCK-GROUP-ID": {{Value: "NEW-USER-ID"}}}, nil).
+		Once()
+
+	rbacSvc := rbacmocks.NewRBACService(t)
+	rbacSvc.EXPECT().
+		GetGroup(mock.Anything, "MOCK-GROUP-ID").
+		Return(group, nil)
+	rbacSvc.EXPECT().
+		UpdateGroup(mock.Anything, "MOCK-GROUP-ID", &rbacsvc.GroupOption{Name: "engineering", Description: "kept"}).
+		Return(group, nil)
+	rbacSvc.EXPECT().
+		AddGroupMember(mock.Anything, "MOCK-GROUP-ID", "NEW-USER-ID").
+		Return(nil)
+	rbacSvc.EXPECT().
+		RemoveGroupMember(mock.Anything, "MOCK-GROUP-ID", "OLD-USER-ID").
+		Return(nil)
+
+	srv := service.New(repo, identityRepo, rbacSvc, password.Default, service.Config{URL: "https://iam.example.com/scim/v2/"}, nil)
+	patched, err := srv.PatchGroup(context.Background(), "MOCK-GROUP-ID", patch(
+		scim.PatchOperation{Op: scim.PatchAdd, Path: "members", Value: json.RawMessage(`[{"value":"NEW-USER-ID"}]`)},
+		scim.PatchOperation{Op: scim.PatchRemove, Path: `members[value eq "OLD-USER-ID"]`},
+	), &service.WriteOption{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"NEW-USER-ID"}, patched.MemberIDs())
+		assert.Equal(t, "https://iam.example.com/scim/v2/Users/NEW-USER-ID", patched.Members[0].Ref)
+		assert.Equal(t, "https://iam.example.com/scim/v2/Groups/MOCK-GROUP-ID", patched.Meta.Location)
+	}
+}
+
+func TestImpl_ListUsers(t *testing.T) {
+	t.Run("Page", func(t *testing.T) {
+		repo := scimmocks.NewRepository(t)
+		repo.EXPECT().
+			FindUsers(mock.Anything, mock.MatchedBy(func(filter *scim.Filter) bool {
+				return filter.Op == scim.OpEqual && filter.Path == "username" && filter.Value == "bjensen"
+			}), 10, service.CountMax).
+			Return([]*identity.User{newUser(identity.UserAccountStatusActive)}, 11, nil)
+		repo.EXPECT().
+			FindExternalIDs(mock.Anything, []string{"MOCK-USER-ID"}).
+			Return(map[string]string{}, nil)
+
+		count := 1000
+		srv := service.New(repo, identitymocks.NewRepository(t), rbacmocks.NewRBACService(t), password.Default, service.Config{}, nil)
+		resp, err := srv.ListUsers(context.Background(), &service.ListOption{
+			Filter:     `userName eq "bjensen"`,
+			StartIndex: 11,
+			Count:      &count,
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(11), resp.TotalResults)
+			assert.Equal(t, 11, resp.StartIndex)
+			assert.Equal(t, 1, resp.ItemsPerPage)
+		}
+	})
+
+	t.Run("Invalid Filter", func(t *testing.T) {
+		srv := service.New(scimmocks.NewRepository(t), identitymocks.NewRepository(t), rbacmocks.NewRBACService(t), password.Default, service.Config{}, nil)
+		_, err := srv.ListUsers(context.Background(), &service.ListOption{Filter: `userName eq`})
+		assert.True(t, errors.Is(err, errors.ErrInvalidInput), err)
+	})
+}
@@ -0,0 +1,316 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/app/scim/endpoints"
+	"github.com/karta0898098/iam/pkg/app/scim/entity"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	pkghttp "github.com/karta0898098/iam/pkg/http"
+	"github.com/karta0898098/iam/pkg/scim"
+)
+
+// MakeListUsers make list users endpoint
+func MakeListUsers(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListUsersEndpoint,
+		decodeHTTPListRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeGetUser make get user endpoint
+func MakeGetUser(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.GetUserEndpoint,
+		decodeHTTPGetRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeCreateUser make create user endpoint
+func MakeCreateUser(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.CreateUserEndpoint,
+		decodeHTTPUserRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeReplaceUser make replace user endpoint
+func MakeReplaceUser(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ReplaceUserEndpoint,
+		decodeHTTPUserRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakePatchUser make patch user endpoint
+func MakePatchUser(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.PatchUserEndpoint,
+		decodeHTTPPatchRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeDeleteUser make delete user endpoint
+func MakeDeleteUser(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DeleteUserEndpoint,
+		decodeHTTPDeleteRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeListGroups make list groups endpoint
+func MakeListGroups(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListGroupsEndpoint,
+		decodeHTTPListRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeGetGroup make get group endpoint
+func MakeGetGroup(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.GetGroupEndpoint,
+		decodeHTTPGetRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeCreateGroup make create group endpoint
+func MakeCreateGroup(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.CreateGroupEndpoint,
+		decodeHTTPGroupRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeReplaceGroup make replace group endpoint
+func MakeReplaceGroup(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ReplaceGroupEndpoint,
+		decodeHTTPGroupRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakePatchGroup make patch group endpoint
+func MakePatchGroup(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.PatchGroupEndpoint,
+		decodeHTTPPatchRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeDeleteGroup make delete group endpoint
+func MakeDeleteGroup(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DeleteGroupEndpoint,
+		decodeHTTPDeleteRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeGetServiceProviderConfig make get service provider config endpoint
+func MakeGetServiceProviderConfig(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.GetServiceProviderConfigEndpoint,
+		decodeHTTPEmptyRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// MakeListResourceTypes make list resource types endpoint
+func MakeListResourceTypes(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListResourceTypesEndpoint,
+		decodeHTTPEmptyRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(scim.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListRequest is a transport/http.DecodeRequestFunc that decodes
+// filter and page from the URL query. Primarily useful in a server.
+func decodeHTTPListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	values := r.URL.Query()
+	req := &endpoints.ListRequest{
+		Filter: values.Get("filter"),
+	}
+
+	if value := values.Get("startIndex"); value != "" {
+		startIndex, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.Wrapf(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidValue), "query startIndex=%v is not integer", value)
+		}
+		req.StartIndex = startIndex
+	}
+	if value := values.Get("count"); value != "" {
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.Wrapf(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidValue), "query count=%v is not integer", value)
+		}
+		req.Count = &count
+	}
+
+	return req, nil
+}
+
+// decodeHTTPGetRequest is a transport/http.DecodeRequestFunc that decodes
+// resource id from the URL path. Primarily useful in a server.
+func decodeHTTPGetRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.GetRequest{
+		ID:          pkghttp.PathParam(r, "id"),
+		IfNoneMatch: r.Header.Get("If-None-Match"),
+	}, nil
+}
+
+// decodeHTTPUserRequest is a transport/http.DecodeRequestFunc that decodes
+// user resource from the HTTP request body. Primarily useful in a server.
+func decodeHTTPUserRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var user entity.User
+	err := decodeBody(r, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &endpoints.UserRequest{
+		ID:      pkghttp.PathParam(r, "id"),
+		IfMatch: r.Header.Get("If-Match"),
+		User:    &user,
+	}, nil
+}
+
+// decodeHTTPGroupRequest is a transport/http.DecodeRequestFunc that decodes
+// group resource from the HTTP request body. Primarily useful in a server.
+func decodeHTTPGroupRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var group entity.Group
+	err := decodeBody(r, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &endpoints.GroupRequest{
+		ID:      pkghttp.PathParam(r, "id"),
+		IfMatch: r.Header.Get("If-Match"),
+		Group:   &group,
+	}, nil
+}
+
+// decodeHTTPPatchRequest is a transport/http.DecodeRequestFunc that decodes
+// patch operations from the HTTP request body. Primarily useful in a server.
+func decodeHTTPPatchRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var patch scim.PatchRequest
+	err := decodeBody(r, &patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &endpoints.PatchRequest{
+		ID:      pkghttp.PathParam(r, "id"),
+		IfMatch: r.Header.Get("If-Match"),
+		Patch:   &patch,
+	}, nil
+}
+
+// decodeHTTPDeleteRequest is a transport/http.DecodeRequestFunc that decodes
+// resource id from the URL path. Primarily useful in a server.
+func decodeHTTPDeleteRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.DeleteRequest{
+		ID:      pkghttp.PathParam(r, "id"),
+		IfMatch: r.Header.Get("If-Match"),
+	}, nil
+}
+
+// decodeHTTPEmptyRequest is a transport/http.DecodeRequestFunc for endpoint without input.
+// Primarily useful in a server.
+func decodeHTTPEmptyRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+// decodeBody decode JSON body of request, malformed body is invalidSyntax
+func decodeBody(r *http.Request, v interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		return errors.Wrap(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidSyntax), "input request is not json")
+	}
+	return nil
+}
+
+// encodeHTTPResponse is a transport/http.EncodeResponseFunc that encodes
+// response as SCIM JSON into the HTTP response body. Primarily useful in a server.
+func encodeHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set(echo.HeaderContentType, scim.MediaType)
+	if headers, ok := response.(httptransport.Headerer); ok {
+		for k, values := range headers.Headers() {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+	code := http.StatusOK
+	if sc, ok := response.(httptransport.StatusCoder); ok {
+		code = sc.StatusCode()
+	}
+	w.WriteHeader(code)
+	if code == http.StatusNoContent || code == http.StatusNotModified {
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,118 @@
+package endpoints
+
+import (
+	"github.com/go-kit/kit/endpoint"
+
+	identityendpoints "github.com/karta0898098/iam/pkg/app/identity/endpoints"
+	identitysvc "github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/app/tenant/service"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/ratelimit"
+)
+
+// Endpoints define tenant endpoints
+type Endpoints struct {
+	CreateTenantEndpoint endpoint.Endpoint
+	GetTenantEndpoint    endpoint.Endpoint
+	ListTenantsEndpoint  endpoint.Endpoint
+	UpdateTenantEndpoint endpoint.Endpoint
+	DeleteTenantEndpoint endpoint.Endpoint
+
+	AddMemberEndpoint    endpoint.Endpoint
+	UpdateMemberEndpoint endpoint.Endpoint
+	RemoveMemberEndpoint endpoint.Endpoint
+	ListMembersEndpoint  endpoint.Endpoint
+}
+
+// New endpoints
+func New(
+	svc service.TenantService,
+	identitySvc identitysvc.IdentityService,
+	permissions authn.PermissionChecker,
+	limiter *ratelimit.Limiter,
+) (ep Endpoints) {
+	authenticator := identityendpoints.NewAuthenticator(identitySvc)
+
+	createTenantEndpoint := MakeCreateTenantEndpoint(svc)
+	createTenantEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("CreateTenant"),
+		identityendpoints.RateLimitMiddleware(limiter, "CreateTenant"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(createTenantEndpoint)
+	ep.CreateTenantEndpoint = createTenantEndpoint
+
+	getTenantEndpoint := MakeGetTenantEndpoint(svc)
+	getTenantEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("GetTenant"),
+		identityendpoints.RateLimitMiddleware(limiter, "GetTenant"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(getTenantEndpoint)
+	ep.GetTenantEndpoint = getTenantEndpoint
+
+	listTenantsEndpoint := MakeListTenantsEndpoint(svc)
+	listTenantsEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListTenants"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListTenants"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(listTenantsEndpoint)
+	ep.ListTenantsEndpoint = listTenantsEndpoint
+
+	updateTenantEndpoint := MakeUpdateTenantEndpoint(svc)
+	updateTenantEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("UpdateTenant"),
+		identityendpoints.RateLimitMiddleware(limiter, "UpdateTenant"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(updateTenantEndpoint)
+	ep.UpdateTenantEndpoint = updateTenantEndpoint
+
+	deleteTenantEndpoint := MakeDeleteTenantEndpoint(svc)
+	deleteTenantEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("DeleteTenant"),
+		identityendpoints.RateLimitMiddleware(limiter, "DeleteTenant"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(deleteTenantEndpoint)
+	ep.DeleteTenantEndpoint = deleteTenantEndpoint
+
+	addMemberEndpoint := MakeAddMemberEndpoint(svc)
+	addMemberEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("AddMember"),
+		identityendpoints.RateLimitMiddleware(limiter, "AddMember"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(addMemberEndpoint)
+	ep.AddMemberEndpoint = addMemberEndpoint
+
+	updateMemberEndpoint := MakeUpdateMemberEndpoint(svc)
+	updateMemberEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("UpdateMember"),
+		identityendpoints.RateLimitMiddleware(limiter, "UpdateMember"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(updateMemberEndpoint)
+	ep.UpdateMemberEndpoint = updateMemberEndpoint
+
+	removeMemberEndpoint := MakeRemoveMemberEndpoint(svc)
+	removeMemberEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("RemoveMember"),
+		identityendpoints.RateLimitMiddleware(limiter, "RemoveMember"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(removeMemberEndpoint)
+	ep.RemoveMemberEndpoint = removeMemberEndpoint
+
+	listMembersEndpoint := MakeListMembersEndpoint(svc)
+	listMembersEndpoint = endpoint.Chain(
+		identityendpoints.LoggingMiddleware("ListMembers"),
+		identityendpoints.RateLimitMiddleware(limiter, "ListMembers"),
+		authn.NewEndpointMiddleware(authenticator),
+		authn.NewAdminMiddleware(permissions),
+	)(listMembersEndpoint)
+	ep.ListMembersEndpoint = listMembersEndpoint
+
+	return ep
+}
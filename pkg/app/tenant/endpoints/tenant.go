@@ -0,0 +1,274 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	"github.com/karta0898098/iam/pkg/app/tenant/entity"
+	"github.com/karta0898098/iam/pkg/app/tenant/service"
+)
+
+// TenantResponse define tenant
+type TenantResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+}
+
+// newTenantResponse convert tenant to response
+func newTenantResponse(tenant *entity.Tenant) *TenantResponse {
+	return &TenantResponse{
+		ID:          tenant.ID,
+		Name:        tenant.Name,
+		DisplayName: tenant.DisplayName,
+		CreatedAt:   tenant.CreatedAt.Unix(),
+		UpdatedAt:   tenant.UpdatedAt.Unix(),
+	}
+}
+
+// ListTenantsResponse define tenants response
+type ListTenantsResponse struct {
+	Tenants []*TenantResponse `json:"tenants"`
+}
+
+// newListTenantsResponse convert tenants to response
+func newListTenantsResponse(tenants []*entity.Tenant) *ListTenantsResponse {
+	resp := &ListTenantsResponse{
+		Tenants: make([]*TenantResponse, 0, len(tenants)),
+	}
+	for _, tenant := range tenants {
+		resp.Tenants = append(resp.Tenants, newTenantResponse(tenant))
+	}
+	return resp
+}
+
+// MemberResponse define member of tenant
+type MemberResponse struct {
+	TenantID  string `json:"tenant_id"`
+	UserID    string `json:"user_id"`
+	Role      string `json:"role"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// newMemberResponse convert member to response
+func newMemberResponse(member *entity.Member) *MemberResponse {
+	return &MemberResponse{
+		TenantID:  member.TenantID,
+		UserID:    member.UserID,
+		Role:      string(member.Role),
+		CreatedAt: member.CreatedAt.Unix(),
+		UpdatedAt: member.UpdatedAt.Unix(),
+	}
+}
+
+// ListMembersResponse define members response
+type ListMembersResponse struct {
+	Members []*MemberResponse `json:"members"`
+}
+
+// newListMembersResponse convert members to response
+func newListMembersResponse(members []*entity.Member) *ListMembersResponse {
+	resp := &ListMembersResponse{
+		Members: make([]*MemberResponse, 0, len(members)),
+	}
+	for _, member := range members {
+		resp.Members = append(resp.Members, newMemberResponse(member))
+	}
+	return resp
+}
+
+// CreateTenantRequest define create tenant request
+type CreateTenantRequest struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+// MakeCreateTenantEndpoint make create tenant endpoint
+func MakeCreateTenantEndpoint(svc service.TenantService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*CreateTenantRequest)
+
+		tenant, err := svc.CreateTenant(ctx, &service.TenantOption{
+			Name:        req.Name,
+			DisplayName: req.DisplayName,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return newTenantResponse(tenant), nil
+	}
+}
+
+// GetTenantRequest define get tenant request
+type GetTenantRequest struct {
+	TenantID string `json:"-"`
+}
+
+// MakeGetTenantEndpoint make get tenant endpoint
+func MakeGetTenantEndpoint(svc service.TenantService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*GetTenantRequest)
+
+		tenant, err := svc.GetTenant(ctx, req.TenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newTenantResponse(tenant), nil
+	}
+}
+
+// ListTenantsRequest define list tenants request
+type ListTenantsRequest struct {
+}
+
+// MakeListTenantsEndpoint make list tenants endpoint
+func MakeListTenantsEndpoint(svc service.TenantService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		tenants, err := svc.ListTenants(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return newListTenantsResponse(tenants), nil
+	}
+}
+
+// UpdateTenantRequest define update tenant request, name of tenant is immutable
+type UpdateTenantRequest struct {
+	TenantID    string `json:"-"`
+	DisplayName string `json:"display_name"`
+}
+
+// MakeUpdateTenantEndpoint make update tenant endpoint
+func MakeUpdateTenantEndpoint(svc service.TenantService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UpdateTenantRequest)
+
+		tenant, err := svc.UpdateTenant(ctx, req.TenantID, &service.TenantOption{
+			DisplayName: req.DisplayName,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return newTenantResponse(tenant), nil
+	}
+}
+
+// DeleteTenantRequest define delete tenant request
+type DeleteTenantRequest struct {
+	TenantID string `json:"-"`
+}
+
+// DeleteTenantResponse define delete tenant response
+type DeleteTenantResponse struct {
+}
+
+// MakeDeleteTenantEndpoint make delete tenant endpoint
+func MakeDeleteTenantEndpoint(svc service.TenantService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DeleteTenantRequest)
+
+		err = svc.DeleteTenant(ctx, req.TenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeleteTenantResponse{}, nil
+	}
+}
+
+// AddMemberRequest define add user into tenant request
+type AddMemberRequest struct {
+	TenantID string `json:"-"`
+	UserID   string `json:"user_id"`
+	Role     string `json:"role"`
+}
+
+// MakeAddMemberEndpoint make add user into tenant endpoint
+func MakeAddMemberEndpoint(svc service.TenantService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*AddMemberRequest)
+
+		member, err := svc.AddMember(ctx, req.TenantID, req.UserID, &service.MemberOption{
+			Role: entity.MemberRole(req.Role),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return newMemberResponse(member), nil
+	}
+}
+
+// UpdateMemberRequest define change role of member request
+type UpdateMemberRequest struct {
+	TenantID string `json:"-"`
+	UserID   string `json:"-"`
+	Role     string `json:"role"`
+}
+
+// MakeUpdateMemberEndpoint make change role of member endpoint
+func MakeUpdateMemberEndpoint(svc service.TenantService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UpdateMemberRequest)
+
+		member, err := svc.UpdateMember(ctx, req.TenantID, req.UserID, &service.MemberOption{
+			Role: entity.MemberRole(req.Role),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return newMemberResponse(member), nil
+	}
+}
+
+// RemoveMemberRequest define remove user from tenant request
+type RemoveMemberRequest struct {
+	TenantID string `json:"-"`
+	UserID   string `json:"-"`
+}
+
+// RemoveMemberResponse define remove user from tenant response
+type RemoveMemberResponse struct {
+}
+
+// MakeRemoveMemberEndpoint make remove user from tenant endpoint
+func MakeRemoveMemberEndpoint(svc service.TenantService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*RemoveMemberRequest)
+
+		err = svc.RemoveMember(ctx, req.TenantID, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &RemoveMemberResponse{}, nil
+	}
+}
+
+// ListMembersRequest define list members of tenant request
+type ListMembersRequest struct {
+	TenantID string `json:"-"`
+}
+
+// MakeListMembersEndpoint make list members of tenant endpoint
+func MakeListMembersEndpoint(svc service.TenantService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ListMembersRequest)
+
+		members, err := svc.ListMembers(ctx, req.TenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newListMembersResponse(members), nil
+	}
+}
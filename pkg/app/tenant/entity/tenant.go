@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+var (
+	// nameRegex tenant name is sent by signin and signup, keep it url safe
+	nameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,63}$`)
+)
+
+const (
+	DisplayNameLengthMax = 255
+)
+
+// Tenant define organization own a set of users,
+// username is unique inside tenant only
+type Tenant struct {
+	// ID tenant id
+	ID string
+	// Name unique tenant name used by signin and signup
+	Name string
+	// DisplayName human readable organization name
+	DisplayName string
+	// CreatedAt this tenant create time
+	CreatedAt time.Time
+	// UpdatedAt this tenant update time
+	UpdatedAt time.Time
+}
+
+// NewTenant new tenant without member
+func NewTenant(id string, name string, displayName string) (*Tenant, error) {
+	now := time.Now()
+
+	tenant := &Tenant{
+		ID:          id,
+		Name:        name,
+		DisplayName: displayName,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err := tenant.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+// Validate check tenant name and display name
+func (t *Tenant) Validate() error {
+	if !nameRegex.MatchString(t.Name) {
+		return errors.Wrapf(errors.ErrInvalidInput, "tenant name=%v is invalid", t.Name)
+	}
+
+	if len(t.DisplayName) > DisplayNameLengthMax {
+		return errors.Wrap(errors.ErrInvalidInput, "input display name length too many")
+	}
+
+	return nil
+}
+
+// Update replace display name of tenant, name can not be changed
+// since client sign in with it
+func (t *Tenant) Update(displayName string) error {
+	t.DisplayName = displayName
+	t.UpdatedAt = time.Now()
+
+	return t.Validate()
+}
+
+// MemberRole define what member can do in tenant
+type MemberRole string
+
+const (
+	// MemberRoleOwner can manage tenant and every member
+	MemberRoleOwner MemberRole = "owner"
+	// MemberRoleAdmin can manage members except owners
+	MemberRoleAdmin MemberRole = "admin"
+	// MemberRoleMember is role of user signed up into tenant
+	MemberRoleMember MemberRole = "member"
+)
+
+// Validate check member role is known
+func (r MemberRole) Validate() error {
+	switch r {
+	case MemberRoleOwner, MemberRoleAdmin, MemberRoleMember:
+		return nil
+	}
+	return errors.Wrapf(errors.ErrInvalidInput, "member role=%v is invalid", r)
+}
+
+// Member define user belong to tenant with role
+type Member struct {
+	TenantID  string
+	UserID    string
+	Role      MemberRole
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewMember add user into tenant with role
+func NewMember(tenantID string, userID string, role MemberRole) (*Member, error) {
+	err := role.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Member{
+		TenantID:  tenantID,
+		UserID:    userID,
+		Role:      role,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ChangeRole replace role of member
+func (m *Member) ChangeRole(role MemberRole) error {
+	err := role.Validate()
+	if err != nil {
+		return err
+	}
+
+	m.Role = role
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsOwner member is owner of tenant
+func (m *Member) IsOwner() bool {
+	return m.Role == MemberRoleOwner
+}
@@ -0,0 +1,572 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/tenant/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteMember provides a mock function with given fields: ctx, tenantID, userID
+func (_m *Repository) DeleteMember(ctx context.Context, tenantID string, userID string) error {
+	ret := _m.Called(ctx, tenantID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenantID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMember'
+type Repository_DeleteMember_Call struct {
+	*mock.Call
+}
+
+// DeleteMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - userID string
+func (_e *Repository_Expecter) DeleteMember(ctx interface{}, tenantID interface{}, userID interface{}) *Repository_DeleteMember_Call {
+	return &Repository_DeleteMember_Call{Call: _e.mock.On("DeleteMember", ctx, tenantID, userID)}
+}
+
+func (_c *Repository_DeleteMember_Call) Run(run func(ctx context.Context, tenantID string, userID string)) *Repository_DeleteMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteMember_Call) Return(err error) *Repository_DeleteMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteMember_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_DeleteMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTenant provides a mock function with given fields: ctx, tenantID
+func (_m *Repository) DeleteTenant(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTenant'
+type Repository_DeleteTenant_Call struct {
+	*mock.Call
+}
+
+// DeleteTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *Repository_Expecter) DeleteTenant(ctx interface{}, tenantID interface{}) *Repository_DeleteTenant_Call {
+	return &Repository_DeleteTenant_Call{Call: _e.mock.On("DeleteTenant", ctx, tenantID)}
+}
+
+func (_c *Repository_DeleteTenant_Call) Run(run func(ctx context.Context, tenantID string)) *Repository_DeleteTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteTenant_Call) Return(err error) *Repository_DeleteTenant_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteTenant_Call) RunAndReturn(run func(context.Context, string) error) *Repository_DeleteTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindMember provides a mock function with given fields: ctx, tenantID, userID
+func (_m *Repository) FindMember(ctx context.Context, tenantID string, userID string) (*entity.Member, error) {
+	ret := _m.Called(ctx, tenantID, userID)
+
+	var r0 *entity.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Member, error)); ok {
+		return rf(ctx, tenantID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Member); ok {
+		r0 = rf(ctx, tenantID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindMember'
+type Repository_FindMember_Call struct {
+	*mock.Call
+}
+
+// FindMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - userID string
+func (_e *Repository_Expecter) FindMember(ctx interface{}, tenantID interface{}, userID interface{}) *Repository_FindMember_Call {
+	return &Repository_FindMember_Call{Call: _e.mock.On("FindMember", ctx, tenantID, userID)}
+}
+
+func (_c *Repository_FindMember_Call) Run(run func(ctx context.Context, tenantID string, userID string)) *Repository_FindMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindMember_Call) Return(member *entity.Member, err error) *Repository_FindMember_Call {
+	_c.Call.Return(member, err)
+	return _c
+}
+
+func (_c *Repository_FindMember_Call) RunAndReturn(run func(context.Context, string, string) (*entity.Member, error)) *Repository_FindMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTenantByID provides a mock function with given fields: ctx, tenantID
+func (_m *Repository) FindTenantByID(ctx context.Context, tenantID string) (*entity.Tenant, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *entity.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Tenant, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Tenant); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindTenantByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTenantByID'
+type Repository_FindTenantByID_Call struct {
+	*mock.Call
+}
+
+// FindTenantByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *Repository_Expecter) FindTenantByID(ctx interface{}, tenantID interface{}) *Repository_FindTenantByID_Call {
+	return &Repository_FindTenantByID_Call{Call: _e.mock.On("FindTenantByID", ctx, tenantID)}
+}
+
+func (_c *Repository_FindTenantByID_Call) Run(run func(ctx context.Context, tenantID string)) *Repository_FindTenantByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindTenantByID_Call) Return(tenant *entity.Tenant, err error) *Repository_FindTenantByID_Call {
+	_c.Call.Return(tenant, err)
+	return _c
+}
+
+func (_c *Repository_FindTenantByID_Call) RunAndReturn(run func(context.Context, string) (*entity.Tenant, error)) *Repository_FindTenantByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTenantByName provides a mock function with given fields: ctx, name
+func (_m *Repository) FindTenantByName(ctx context.Context, name string) (*entity.Tenant, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *entity.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Tenant, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Tenant); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindTenantByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTenantByName'
+type Repository_FindTenantByName_Call struct {
+	*mock.Call
+}
+
+// FindTenantByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *Repository_Expecter) FindTenantByName(ctx interface{}, name interface{}) *Repository_FindTenantByName_Call {
+	return &Repository_FindTenantByName_Call{Call: _e.mock.On("FindTenantByName", ctx, name)}
+}
+
+func (_c *Repository_FindTenantByName_Call) Run(run func(ctx context.Context, name string)) *Repository_FindTenantByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindTenantByName_Call) Return(tenant *entity.Tenant, err error) *Repository_FindTenantByName_Call {
+	_c.Call.Return(tenant, err)
+	return _c
+}
+
+func (_c *Repository_FindTenantByName_Call) RunAndReturn(run func(context.Context, string) (*entity.Tenant, error)) *Repository_FindTenantByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMembers provides a mock function with given fields: ctx, tenantID
+func (_m *Repository) ListMembers(ctx context.Context, tenantID string) ([]*entity.Member, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 []*entity.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Member, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Member); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMembers'
+type Repository_ListMembers_Call struct {
+	*mock.Call
+}
+
+// ListMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *Repository_Expecter) ListMembers(ctx interface{}, tenantID interface{}) *Repository_ListMembers_Call {
+	return &Repository_ListMembers_Call{Call: _e.mock.On("ListMembers", ctx, tenantID)}
+}
+
+func (_c *Repository_ListMembers_Call) Run(run func(ctx context.Context, tenantID string)) *Repository_ListMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ListMembers_Call) Return(members []*entity.Member, err error) *Repository_ListMembers_Call {
+	_c.Call.Return(members, err)
+	return _c
+}
+
+func (_c *Repository_ListMembers_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Member, error)) *Repository_ListMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTenants provides a mock function with given fields: ctx
+func (_m *Repository) ListTenants(ctx context.Context) ([]*entity.Tenant, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Tenant, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Tenant); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListTenants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTenants'
+type Repository_ListTenants_Call struct {
+	*mock.Call
+}
+
+// ListTenants is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListTenants(ctx interface{}) *Repository_ListTenants_Call {
+	return &Repository_ListTenants_Call{Call: _e.mock.On("ListTenants", ctx)}
+}
+
+func (_c *Repository_ListTenants_Call) Run(run func(ctx context.Context)) *Repository_ListTenants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListTenants_Call) Return(tenants []*entity.Tenant, err error) *Repository_ListTenants_Call {
+	_c.Call.Return(tenants, err)
+	return _c
+}
+
+func (_c *Repository_ListTenants_Call) RunAndReturn(run func(context.Context) ([]*entity.Tenant, error)) *Repository_ListTenants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreMember provides a mock function with given fields: ctx, member
+func (_m *Repository) StoreMember(ctx context.Context, member *entity.Member) error {
+	ret := _m.Called(ctx, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Member) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreMember'
+type Repository_StoreMember_Call struct {
+	*mock.Call
+}
+
+// StoreMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *entity.Member
+func (_e *Repository_Expecter) StoreMember(ctx interface{}, member interface{}) *Repository_StoreMember_Call {
+	return &Repository_StoreMember_Call{Call: _e.mock.On("StoreMember", ctx, member)}
+}
+
+func (_c *Repository_StoreMember_Call) Run(run func(ctx context.Context, member *entity.Member)) *Repository_StoreMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Member))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreMember_Call) Return(err error) *Repository_StoreMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreMember_Call) RunAndReturn(run func(context.Context, *entity.Member) error) *Repository_StoreMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreTenant provides a mock function with given fields: ctx, tenant
+func (_m *Repository) StoreTenant(ctx context.Context, tenant *entity.Tenant) error {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Tenant) error); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_StoreTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreTenant'
+type Repository_StoreTenant_Call struct {
+	*mock.Call
+}
+
+// StoreTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant *entity.Tenant
+func (_e *Repository_Expecter) StoreTenant(ctx interface{}, tenant interface{}) *Repository_StoreTenant_Call {
+	return &Repository_StoreTenant_Call{Call: _e.mock.On("StoreTenant", ctx, tenant)}
+}
+
+func (_c *Repository_StoreTenant_Call) Run(run func(ctx context.Context, tenant *entity.Tenant)) *Repository_StoreTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Tenant))
+	})
+	return _c
+}
+
+func (_c *Repository_StoreTenant_Call) Return(err error) *Repository_StoreTenant_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StoreTenant_Call) RunAndReturn(run func(context.Context, *entity.Tenant) error) *Repository_StoreTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMember provides a mock function with given fields: ctx, member
+func (_m *Repository) UpdateMember(ctx context.Context, member *entity.Member) error {
+	ret := _m.Called(ctx, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Member) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMember'
+type Repository_UpdateMember_Call struct {
+	*mock.Call
+}
+
+// UpdateMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *entity.Member
+func (_e *Repository_Expecter) UpdateMember(ctx interface{}, member interface{}) *Repository_UpdateMember_Call {
+	return &Repository_UpdateMember_Call{Call: _e.mock.On("UpdateMember", ctx, member)}
+}
+
+func (_c *Repository_UpdateMember_Call) Run(run func(ctx context.Context, member *entity.Member)) *Repository_UpdateMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Member))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateMember_Call) Return(err error) *Repository_UpdateMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateMember_Call) RunAndReturn(run func(context.Context, *entity.Member) error) *Repository_UpdateMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTenant provides a mock function with given fields: ctx, tenant
+func (_m *Repository) UpdateTenant(ctx context.Context, tenant *entity.Tenant) error {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Tenant) error); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTenant'
+type Repository_UpdateTenant_Call struct {
+	*mock.Call
+}
+
+// UpdateTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant *entity.Tenant
+func (_e *Repository_Expecter) UpdateTenant(ctx interface{}, tenant interface{}) *Repository_UpdateTenant_Call {
+	return &Repository_UpdateTenant_Call{Call: _e.mock.On("UpdateTenant", ctx, tenant)}
+}
+
+func (_c *Repository_UpdateTenant_Call) Run(run func(ctx context.Context, tenant *entity.Tenant)) *Repository_UpdateTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Tenant))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateTenant_Call) Return(err error) *Repository_UpdateTenant_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateTenant_Call) RunAndReturn(run func(context.Context, *entity.Tenant) error) *Repository_UpdateTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,613 @@
+// Code generated by mockery v2.26.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/karta0898098/iam/pkg/app/tenant/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/karta0898098/iam/pkg/app/tenant/service"
+)
+
+// TenantService is an autogenerated mock type for the TenantService type
+type TenantService struct {
+	mock.Mock
+}
+
+type TenantService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TenantService) EXPECT() *TenantService_Expecter {
+	return &TenantService_Expecter{mock: &_m.Mock}
+}
+
+// AddMember provides a mock function with given fields: ctx, tenantID, userID, opt
+func (_m *TenantService) AddMember(ctx context.Context, tenantID string, userID string, opt *service.MemberOption) (*entity.Member, error) {
+	ret := _m.Called(ctx, tenantID, userID, opt)
+
+	var r0 *entity.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *service.MemberOption) (*entity.Member, error)); ok {
+		return rf(ctx, tenantID, userID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *service.MemberOption) *entity.Member); ok {
+		r0 = rf(ctx, tenantID, userID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *service.MemberOption) error); ok {
+		r1 = rf(ctx, tenantID, userID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantService_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type TenantService_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - userID string
+//   - opt *service.MemberOption
+func (_e *TenantService_Expecter) AddMember(ctx interface{}, tenantID interface{}, userID interface{}, opt interface{}) *TenantService_AddMember_Call {
+	return &TenantService_AddMember_Call{Call: _e.mock.On("AddMember", ctx, tenantID, userID, opt)}
+}
+
+func (_c *TenantService_AddMember_Call) Run(run func(ctx context.Context, tenantID string, userID string, opt *service.MemberOption)) *TenantService_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*service.MemberOption))
+	})
+	return _c
+}
+
+func (_c *TenantService_AddMember_Call) Return(member *entity.Member, err error) *TenantService_AddMember_Call {
+	_c.Call.Return(member, err)
+	return _c
+}
+
+func (_c *TenantService_AddMember_Call) RunAndReturn(run func(context.Context, string, string, *service.MemberOption) (*entity.Member, error)) *TenantService_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTenant provides a mock function with given fields: ctx, opt
+func (_m *TenantService) CreateTenant(ctx context.Context, opt *service.TenantOption) (*entity.Tenant, error) {
+	ret := _m.Called(ctx, opt)
+
+	var r0 *entity.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *service.TenantOption) (*entity.Tenant, error)); ok {
+		return rf(ctx, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *service.TenantOption) *entity.Tenant); ok {
+		r0 = rf(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *service.TenantOption) error); ok {
+		r1 = rf(ctx, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantService_CreateTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTenant'
+type TenantService_CreateTenant_Call struct {
+	*mock.Call
+}
+
+// CreateTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *service.TenantOption
+func (_e *TenantService_Expecter) CreateTenant(ctx interface{}, opt interface{}) *TenantService_CreateTenant_Call {
+	return &TenantService_CreateTenant_Call{Call: _e.mock.On("CreateTenant", ctx, opt)}
+}
+
+func (_c *TenantService_CreateTenant_Call) Run(run func(ctx context.Context, opt *service.TenantOption)) *TenantService_CreateTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*service.TenantOption))
+	})
+	return _c
+}
+
+func (_c *TenantService_CreateTenant_Call) Return(tenant *entity.Tenant, err error) *TenantService_CreateTenant_Call {
+	_c.Call.Return(tenant, err)
+	return _c
+}
+
+func (_c *TenantService_CreateTenant_Call) RunAndReturn(run func(context.Context, *service.TenantOption) (*entity.Tenant, error)) *TenantService_CreateTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTenant provides a mock function with given fields: ctx, tenantID
+func (_m *TenantService) DeleteTenant(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TenantService_DeleteTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTenant'
+type TenantService_DeleteTenant_Call struct {
+	*mock.Call
+}
+
+// DeleteTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *TenantService_Expecter) DeleteTenant(ctx interface{}, tenantID interface{}) *TenantService_DeleteTenant_Call {
+	return &TenantService_DeleteTenant_Call{Call: _e.mock.On("DeleteTenant", ctx, tenantID)}
+}
+
+func (_c *TenantService_DeleteTenant_Call) Run(run func(ctx context.Context, tenantID string)) *TenantService_DeleteTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TenantService_DeleteTenant_Call) Return(err error) *TenantService_DeleteTenant_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TenantService_DeleteTenant_Call) RunAndReturn(run func(context.Context, string) error) *TenantService_DeleteTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenant provides a mock function with given fields: ctx, tenantID
+func (_m *TenantService) GetTenant(ctx context.Context, tenantID string) (*entity.Tenant, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *entity.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Tenant, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Tenant); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantService_GetTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenant'
+type TenantService_GetTenant_Call struct {
+	*mock.Call
+}
+
+// GetTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *TenantService_Expecter) GetTenant(ctx interface{}, tenantID interface{}) *TenantService_GetTenant_Call {
+	return &TenantService_GetTenant_Call{Call: _e.mock.On("GetTenant", ctx, tenantID)}
+}
+
+func (_c *TenantService_GetTenant_Call) Run(run func(ctx context.Context, tenantID string)) *TenantService_GetTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TenantService_GetTenant_Call) Return(tenant *entity.Tenant, err error) *TenantService_GetTenant_Call {
+	_c.Call.Return(tenant, err)
+	return _c
+}
+
+func (_c *TenantService_GetTenant_Call) RunAndReturn(run func(context.Context, string) (*entity.Tenant, error)) *TenantService_GetTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JoinTenant provides a mock function with given fields: ctx, tenantID, userID
+func (_m *TenantService) JoinTenant(ctx context.Context, tenantID string, userID string) error {
+	ret := _m.Called(ctx, tenantID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenantID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TenantService_JoinTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JoinTenant'
+type TenantService_JoinTenant_Call struct {
+	*mock.Call
+}
+
+// JoinTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - userID string
+func (_e *TenantService_Expecter) JoinTenant(ctx interface{}, tenantID interface{}, userID interface{}) *TenantService_JoinTenant_Call {
+	return &TenantService_JoinTenant_Call{Call: _e.mock.On("JoinTenant", ctx, tenantID, userID)}
+}
+
+func (_c *TenantService_JoinTenant_Call) Run(run func(ctx context.Context, tenantID string, userID string)) *TenantService_JoinTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TenantService_JoinTenant_Call) Return(err error) *TenantService_JoinTenant_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TenantService_JoinTenant_Call) RunAndReturn(run func(context.Context, string, string) error) *TenantService_JoinTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMembers provides a mock function with given fields: ctx, tenantID
+func (_m *TenantService) ListMembers(ctx context.Context, tenantID string) ([]*entity.Member, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 []*entity.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Member, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Member); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantService_ListMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMembers'
+type TenantService_ListMembers_Call struct {
+	*mock.Call
+}
+
+// ListMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *TenantService_Expecter) ListMembers(ctx interface{}, tenantID interface{}) *TenantService_ListMembers_Call {
+	return &TenantService_ListMembers_Call{Call: _e.mock.On("ListMembers", ctx, tenantID)}
+}
+
+func (_c *TenantService_ListMembers_Call) Run(run func(ctx context.Context, tenantID string)) *TenantService_ListMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TenantService_ListMembers_Call) Return(members []*entity.Member, err error) *TenantService_ListMembers_Call {
+	_c.Call.Return(members, err)
+	return _c
+}
+
+func (_c *TenantService_ListMembers_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Member, error)) *TenantService_ListMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTenants provides a mock function with given fields: ctx
+func (_m *TenantService) ListTenants(ctx context.Context) ([]*entity.Tenant, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Tenant, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Tenant); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantService_ListTenants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTenants'
+type TenantService_ListTenants_Call struct {
+	*mock.Call
+}
+
+// ListTenants is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *TenantService_Expecter) ListTenants(ctx interface{}) *TenantService_ListTenants_Call {
+	return &TenantService_ListTenants_Call{Call: _e.mock.On("ListTenants", ctx)}
+}
+
+func (_c *TenantService_ListTenants_Call) Run(run func(ctx context.Context)) *TenantService_ListTenants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *TenantService_ListTenants_Call) Return(tenants []*entity.Tenant, err error) *TenantService_ListTenants_Call {
+	_c.Call.Return(tenants, err)
+	return _c
+}
+
+func (_c *TenantService_ListTenants_Call) RunAndReturn(run func(context.Context) ([]*entity.Tenant, error)) *TenantService_ListTenants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function with given fields: ctx, tenantID, userID
+func (_m *TenantService) RemoveMember(ctx context.Context, tenantID string, userID string) error {
+	ret := _m.Called(ctx, tenantID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenantID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TenantService_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type TenantService_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - userID string
+func (_e *TenantService_Expecter) RemoveMember(ctx interface{}, tenantID interface{}, userID interface{}) *TenantService_RemoveMember_Call {
+	return &TenantService_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, tenantID, userID)}
+}
+
+func (_c *TenantService_RemoveMember_Call) Run(run func(ctx context.Context, tenantID string, userID string)) *TenantService_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TenantService_RemoveMember_Call) Return(err error) *TenantService_RemoveMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TenantService_RemoveMember_Call) RunAndReturn(run func(context.Context, string, string) error) *TenantService_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveTenant provides a mock function with given fields: ctx, name
+func (_m *TenantService) ResolveTenant(ctx context.Context, name string) (string, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantService_ResolveTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveTenant'
+type TenantService_ResolveTenant_Call struct {
+	*mock.Call
+}
+
+// ResolveTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *TenantService_Expecter) ResolveTenant(ctx interface{}, name interface{}) *TenantService_ResolveTenant_Call {
+	return &TenantService_ResolveTenant_Call{Call: _e.mock.On("ResolveTenant", ctx, name)}
+}
+
+func (_c *TenantService_ResolveTenant_Call) Run(run func(ctx context.Context, name string)) *TenantService_ResolveTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TenantService_ResolveTenant_Call) Return(tenantID string, err error) *TenantService_ResolveTenant_Call {
+	_c.Call.Return(tenantID, err)
+	return _c
+}
+
+func (_c *TenantService_ResolveTenant_Call) RunAndReturn(run func(context.Context, string) (string, error)) *TenantService_ResolveTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMember provides a mock function with given fields: ctx, tenantID, userID, opt
+func (_m *TenantService) UpdateMember(ctx context.Context, tenantID string, userID string, opt *service.MemberOption) (*entity.Member, error) {
+	ret := _m.Called(ctx, tenantID, userID, opt)
+
+	var r0 *entity.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *service.MemberOption) (*entity.Member, error)); ok {
+		return rf(ctx, tenantID, userID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *service.MemberOption) *entity.Member); ok {
+		r0 = rf(ctx, tenantID, userID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *service.MemberOption) error); ok {
+		r1 = rf(ctx, tenantID, userID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantService_UpdateMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMember'
+type TenantService_UpdateMember_Call struct {
+	*mock.Call
+}
+
+// UpdateMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - userID string
+//   - opt *service.MemberOption
+func (_e *TenantService_Expecter) UpdateMember(ctx interface{}, tenantID interface{}, userID interface{}, opt interface{}) *TenantService_UpdateMember_Call {
+	return &TenantService_UpdateMember_Call{Call: _e.mock.On("UpdateMember", ctx, tenantID, userID, opt)}
+}
+
+func (_c *TenantService_UpdateMember_Call) Run(run func(ctx context.Context, tenantID string, userID string, opt *service.MemberOption)) *TenantService_UpdateMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*service.MemberOption))
+	})
+	return _c
+}
+
+func (_c *TenantService_UpdateMember_Call) Return(member *entity.Member, err error) *TenantService_UpdateMember_Call {
+	_c.Call.Return(member, err)
+	return _c
+}
+
+func (_c *TenantService_UpdateMember_Call) RunAndReturn(run func(context.Context, string, string, *service.MemberOption) (*entity.Member, error)) *TenantService_UpdateMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTenant provides a mock function with given fields: ctx, tenantID, opt
+func (_m *TenantService) UpdateTenant(ctx context.Context, tenantID string, opt *service.TenantOption) (*entity.Tenant, error) {
+	ret := _m.Called(ctx, tenantID, opt)
+
+	var r0 *entity.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.TenantOption) (*entity.Tenant, error)); ok {
+		return rf(ctx, tenantID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *service.TenantOption) *entity.Tenant); ok {
+		r0 = rf(ctx, tenantID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *service.TenantOption) error); ok {
+		r1 = rf(ctx, tenantID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantService_UpdateTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTenant'
+type TenantService_UpdateTenant_Call struct {
+	*mock.Call
+}
+
+// UpdateTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - opt *service.TenantOption
+func (_e *TenantService_Expecter) UpdateTenant(ctx interface{}, tenantID interface{}, opt interface{}) *TenantService_UpdateTenant_Call {
+	return &TenantService_UpdateTenant_Call{Call: _e.mock.On("UpdateTenant", ctx, tenantID, opt)}
+}
+
+func (_c *TenantService_UpdateTenant_Call) Run(run func(ctx context.Context, tenantID string, opt *service.TenantOption)) *TenantService_UpdateTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*service.TenantOption))
+	})
+	return _c
+}
+
+func (_c *TenantService_UpdateTenant_Call) Return(tenant *entity.Tenant, err error) *TenantService_UpdateTenant_Call {
+	_c.Call.Return(tenant, err)
+	return _c
+}
+
+func (_c *TenantService_UpdateTenant_Call) RunAndReturn(run func(context.Context, string, *service.TenantOption) (*entity.Tenant, error)) *TenantService_UpdateTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewTenantService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTenantService creates a new instance of TenantService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTenantService(t mockConstructorTestingTNewTenantService) *TenantService {
+	mock := &TenantService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
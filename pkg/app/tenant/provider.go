@@ -0,0 +1,16 @@
+package tenant
+
+import (
+	"github.com/google/wire"
+
+	"github.com/karta0898098/iam/pkg/app/tenant/endpoints"
+	"github.com/karta0898098/iam/pkg/app/tenant/repository"
+	"github.com/karta0898098/iam/pkg/app/tenant/service"
+)
+
+var DefaultProvider = wire.NewSet(
+	endpoints.New,
+	service.New,
+	service.NewTenantDirectory,
+	repository.New,
+)
@@ -0,0 +1,367 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/karta0898098/iam/pkg/app/tenant/entity"
+	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// TenantDAO define tenant dao
+type TenantDAO struct {
+	ID          string `gorm:"column:id"`           // ID tenant id
+	Name        string `gorm:"column:name"`         // Name unique tenant name
+	DisplayName string `gorm:"column:display_name"` // DisplayName human readable organization name
+	CreatedAt   int64  `gorm:"column:created_at"`   // CreatedAt this tenant create time
+	UpdatedAt   int64  `gorm:"column:updated_at"`   // UpdatedAt this tenant update time
+}
+
+// TableName is TenantDAO implement table name for gorm
+func (t TenantDAO) TableName() string {
+	return "tenants"
+}
+
+// UnmarshalTenantDAO unmarshal entity tenant to dao
+func UnmarshalTenantDAO(tenant *entity.Tenant) *TenantDAO {
+	return &TenantDAO{
+		ID:          tenant.ID,
+		Name:        tenant.Name,
+		DisplayName: tenant.DisplayName,
+		CreatedAt:   tenant.CreatedAt.UnixMilli(),
+		UpdatedAt:   tenant.UpdatedAt.UnixMilli(),
+	}
+}
+
+// UnmarshalTenant unmarshal dao to entity tenant
+func UnmarshalTenant(dao *TenantDAO) *entity.Tenant {
+	return &entity.Tenant{
+		ID:          dao.ID,
+		Name:        dao.Name,
+		DisplayName: dao.DisplayName,
+		CreatedAt:   time.UnixMilli(dao.CreatedAt),
+		UpdatedAt:   time.UnixMilli(dao.UpdatedAt),
+	}
+}
+
+// MemberDAO define tenant member dao
+type MemberDAO struct {
+	TenantID  string `gorm:"column:tenant_id"`  // TenantID tenant user belong to
+	UserID    string `gorm:"column:user_id"`    // UserID member user id
+	Role      string `gorm:"column:role"`       // Role owner, admin or member
+	CreatedAt int64  `gorm:"column:created_at"` // CreatedAt this member join time
+	UpdatedAt int64  `gorm:"column:updated_at"` // UpdatedAt this member role change time
+}
+
+// TableName is MemberDAO implement table name for gorm
+func (m MemberDAO) TableName() string {
+	return "tenant_members"
+}
+
+// UnmarshalMemberDAO unmarshal entity member to dao
+func UnmarshalMemberDAO(member *entity.Member) *MemberDAO {
+	return &MemberDAO{
+		TenantID:  member.TenantID,
+		UserID:    member.UserID,
+		Role:      string(member.Role),
+		CreatedAt: member.CreatedAt.UnixMilli(),
+		UpdatedAt: member.UpdatedAt.UnixMilli(),
+	}
+}
+
+// UnmarshalMember unmarshal dao to entity member
+func UnmarshalMember(dao *MemberDAO) *entity.Member {
+	return &entity.Member{
+		TenantID:  dao.TenantID,
+		UserID:    dao.UserID,
+		Role:      entity.MemberRole(dao.Role),
+		CreatedAt: time.UnixMilli(dao.CreatedAt),
+		UpdatedAt: time.UnixMilli(dao.UpdatedAt),
+	}
+}
+
+// Repository define tenant repository pattern
+type Repository interface {
+	// StoreTenant store tenant
+	StoreTenant(ctx context.Context, tenant *entity.Tenant) (err error)
+
+	// FindTenantByID find tenant by id
+	FindTenantByID(ctx context.Context, tenantID string) (tenant *entity.Tenant, err error)
+
+	// FindTenantByName find tenant by unique name
+	FindTenantByName(ctx context.Context, name string) (tenant *entity.Tenant, err error)
+
+	// ListTenants list all tenants order by name
+	ListTenants(ctx context.Context) (tenants []*entity.Tenant, err error)
+
+	// UpdateTenant update display name of tenant
+	UpdateTenant(ctx context.Context, tenant *entity.Tenant) (err error)
+
+	// DeleteTenant delete tenant without member
+	DeleteTenant(ctx context.Context, tenantID string) (err error)
+
+	// StoreMember add user into tenant
+	StoreMember(ctx context.Context, member *entity.Member) (err error)
+
+	// FindMember find member of tenant
+	FindMember(ctx context.Context, tenantID string, userID string) (member *entity.Member, err error)
+
+	// ListMembers list members of tenant order by join time
+	ListMembers(ctx context.Context, tenantID string) (members []*entity.Member, err error)
+
+	// UpdateMember update role of member
+	UpdateMember(ctx context.Context, member *entity.Member) (err error)
+
+	// DeleteMember remove user from tenant
+	DeleteMember(ctx context.Context, tenantID string, userID string) (err error)
+}
+
+// TenantRepository implement for Repository
+type TenantRepository struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+}
+
+// New Repository constructor
+func New(conn db.Connection) Repository {
+	return &TenantRepository{
+		readDB:  conn.ReadDB(),
+		writeDB: conn.WriteDB(),
+	}
+}
+
+// StoreTenant is SQL implement
+func (repo *TenantRepository) StoreTenant(ctx context.Context, tenant *entity.Tenant) (err error) {
+	dao := UnmarshalTenantDAO(tenant)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to create tenant id=%v, err %v", tenant.ID, err)
+	}
+
+	return nil
+}
+
+// FindTenantByID is SQL implement
+func (repo *TenantRepository) FindTenantByID(ctx context.Context, tenantID string) (tenant *entity.Tenant, err error) {
+	if tenantID == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input tenant id is empty")
+	}
+
+	return repo.findTenant(ctx, "id = ?", tenantID)
+}
+
+// FindTenantByName is SQL implement
+func (repo *TenantRepository) FindTenantByName(ctx context.Context, name string) (tenant *entity.Tenant, err error) {
+	if name == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "repo: input tenant name is empty")
+	}
+
+	return repo.findTenant(ctx, "name = ?", name)
+}
+
+// findTenant find the only tenant matched condition
+func (repo *TenantRepository) findTenant(ctx context.Context, query string, arg string) (*entity.Tenant, error) {
+	var (
+		dao TenantDAO
+	)
+
+	err := repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Where(query, arg).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "cant not found tenant %v", arg)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalTenant(&dao), nil
+}
+
+// ListTenants is SQL implement
+func (repo *TenantRepository) ListTenants(ctx context.Context) (tenants []*entity.Tenant, err error) {
+	var (
+		daos []TenantDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(TenantDAO{}).
+		Order("name").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	tenants = make([]*entity.Tenant, 0, len(daos))
+	for i := range daos {
+		tenants = append(tenants, UnmarshalTenant(&daos[i]))
+	}
+
+	return tenants, nil
+}
+
+// UpdateTenant is SQL implement
+func (repo *TenantRepository) UpdateTenant(ctx context.Context, tenant *entity.Tenant) (err error) {
+	dao := UnmarshalTenantDAO(tenant)
+
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(TenantDAO{}).
+		Where("id = ?", tenant.ID).
+		Updates(map[string]interface{}{
+			"display_name": dao.DisplayName,
+			"updated_at":   dao.UpdatedAt,
+		})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update tenant id=%v, err %v", tenant.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "cant not found tenant id=%v", tenant.ID)
+	}
+
+	return nil
+}
+
+// DeleteTenant is SQL implement
+func (repo *TenantRepository) DeleteTenant(ctx context.Context, tenantID string) (err error) {
+	return repo.writeDB.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			var count int64
+			err := tx.
+				Model(MemberDAO{}).
+				Where("tenant_id = ?", tenantID).
+				Count(&count).
+				Error
+			if err != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to count members of tenant id=%v, err %v", tenantID, err)
+			}
+			if count != 0 {
+				return errors.Wrapf(errors.ErrConflict, "tenant id=%v still has %v members", tenantID, count)
+			}
+
+			result := tx.
+				Where("id = ?", tenantID).
+				Delete(&TenantDAO{})
+			if result.Error != nil {
+				return errors.Wrapf(errors.ErrInternal, "failed to delete tenant id=%v, err %v", tenantID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.Wrapf(errors.ErrResourceNotFound, "cant not found tenant id=%v", tenantID)
+			}
+
+			return nil
+		})
+}
+
+// StoreMember is SQL implement
+func (repo *TenantRepository) StoreMember(ctx context.Context, member *entity.Member) (err error) {
+	dao := UnmarshalMemberDAO(member)
+
+	err = repo.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Create(dao).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to add user=%v into tenant=%v, err %v", member.UserID, member.TenantID, err)
+	}
+
+	return nil
+}
+
+// FindMember is SQL implement
+func (repo *TenantRepository) FindMember(ctx context.Context, tenantID string, userID string) (member *entity.Member, err error) {
+	var (
+		dao MemberDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(dao).
+		Where("tenant_id = ? AND user_id = ?", tenantID, userID).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrapf(errors.ErrResourceNotFound, "user=%v is not member of tenant=%v", userID, tenantID)
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalMember(&dao), nil
+}
+
+// ListMembers is SQL implement
+func (repo *TenantRepository) ListMembers(ctx context.Context, tenantID string) (members []*entity.Member, err error) {
+	var (
+		daos []MemberDAO
+	)
+
+	err = repo.readDB.
+		WithContext(ctx).
+		Model(MemberDAO{}).
+		Where("tenant_id = ?", tenantID).
+		Order("created_at").
+		Find(&daos).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	members = make([]*entity.Member, 0, len(daos))
+	for i := range daos {
+		members = append(members, UnmarshalMember(&daos[i]))
+	}
+
+	return members, nil
+}
+
+// UpdateMember is SQL implement
+func (repo *TenantRepository) UpdateMember(ctx context.Context, member *entity.Member) (err error) {
+	result := repo.writeDB.
+		WithContext(ctx).
+		Model(MemberDAO{}).
+		Where("tenant_id = ? AND user_id = ?", member.TenantID, member.UserID).
+		Updates(map[string]interface{}{
+			"role":       string(member.Role),
+			"updated_at": member.UpdatedAt.UnixMilli(),
+		})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to update user=%v of tenant=%v, err %v", member.UserID, member.TenantID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "user=%v is not member of tenant=%v", member.UserID, member.TenantID)
+	}
+
+	return nil
+}
+
+// DeleteMember is SQL implement
+func (repo *TenantRepository) DeleteMember(ctx context.Context, tenantID string, userID string) (err error) {
+	result := repo.writeDB.
+		WithContext(ctx).
+		Where("tenant_id = ? AND user_id = ?", tenantID, userID).
+		Delete(&MemberDAO{})
+	if result.Error != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to remove user=%v from tenant=%v, err %v", userID, tenantID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(errors.ErrResourceNotFound, "user=%v is not member of tenant=%v", userID, tenantID)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/tenant/entity"
+	"github.com/karta0898098/iam/pkg/audit"
+)
+
+type auditMiddleware struct {
+	next     TenantService
+	recorder audit.Recorder
+}
+
+// AuditMiddleware record action changing tenant and its members to audit log,
+// read only action and joining tenant at signup are not recorded
+func AuditMiddleware(recorder audit.Recorder) Middleware {
+	return func(next TenantService) TenantService {
+		return auditMiddleware{next: next, recorder: recorder}
+	}
+}
+
+// tenantEvent new event of action administrator apply to tenant, event belong to the tenant
+func tenantEvent(ctx context.Context, action audit.Action, tenantID string, target string, err error) *audit.Event {
+	event := audit.NewPrincipalEvent(ctx, action, target, err)
+	event.TenantID = tenantID
+	return event
+}
+
+func (am auditMiddleware) CreateTenant(ctx context.Context, opt *TenantOption) (tenant *entity.Tenant, err error) {
+	defer func() {
+		var tenantID string
+		if tenant != nil {
+			tenantID = tenant.ID
+		}
+		audit.Emit(ctx, am.recorder, tenantEvent(ctx, audit.ActionCreateTenant, tenantID, tenantID, err))
+	}()
+
+	return am.next.CreateTenant(ctx, opt)
+}
+
+func (am auditMiddleware) GetTenant(ctx context.Context, tenantID string) (tenant *entity.Tenant, err error) {
+	return am.next.GetTenant(ctx, tenantID)
+}
+
+func (am auditMiddleware) ListTenants(ctx context.Context) (tenants []*entity.Tenant, err error) {
+	return am.next.ListTenants(ctx)
+}
+
+func (am auditMiddleware) UpdateTenant(ctx context.Context, tenantID string, opt *TenantOption) (tenant *entity.Tenant, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, tenantEvent(ctx, audit.ActionUpdateTenant, tenantID, tenantID, err))
+	}()
+
+	return am.next.UpdateTenant(ctx, tenantID, opt)
+}
+
+func (am auditMiddleware) DeleteTenant(ctx context.Context, tenantID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, tenantEvent(ctx, audit.ActionDeleteTenant, tenantID, tenantID, err))
+	}()
+
+	return am.next.DeleteTenant(ctx, tenantID)
+}
+
+func (am auditMiddleware) AddMember(ctx context.Context, tenantID string, userID string, opt *MemberOption) (member *entity.Member, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, tenantEvent(ctx, audit.ActionAddTenantMember, tenantID, userID, err))
+	}()
+
+	return am.next.AddMember(ctx, tenantID, userID, opt)
+}
+
+func (am auditMiddleware) UpdateMember(ctx context.Context, tenantID string, userID string, opt *MemberOption) (member *entity.Member, err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, tenantEvent(ctx, audit.ActionUpdateTenantMember, tenantID, userID, err))
+	}()
+
+	return am.next.UpdateMember(ctx, tenantID, userID, opt)
+}
+
+func (am auditMiddleware) RemoveMember(ctx context.Context, tenantID string, userID string) (err error) {
+	defer func() {
+		audit.Emit(ctx, am.recorder, tenantEvent(ctx, audit.ActionRemoveTenantMember, tenantID, userID, err))
+	}()
+
+	return am.next.RemoveMember(ctx, tenantID, userID)
+}
+
+func (am auditMiddleware) ListMembers(ctx context.Context, tenantID string) (members []*entity.Member, err error) {
+	return am.next.ListMembers(ctx, tenantID)
+}
+
+func (am auditMiddleware) ResolveTenant(ctx context.Context, name string) (tenantID string, err error) {
+	return am.next.ResolveTenant(ctx, name)
+}
+
+func (am auditMiddleware) JoinTenant(ctx context.Context, tenantID string, userID string) (err error) {
+	return am.next.JoinTenant(ctx, tenantID, userID)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+
+	"github.com/karta0898098/iam/pkg/app/tenant/entity"
+)
+
+type loggingMiddleware struct {
+	next TenantService `json:"-"`
+}
+
+// LoggingMiddleware takes a logger as a dependency
+// and returns a ServiceMiddleware.
+func LoggingMiddleware() Middleware {
+	return func(next TenantService) TenantService {
+		return loggingMiddleware{next}
+	}
+}
+
+func (lm loggingMiddleware) CreateTenant(ctx context.Context, opt *TenantOption) (tenant *entity.Tenant, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "CreateTenant",
+		// 	"tenant", opt.Name,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.CreateTenant(ctx, opt)
+}
+
+func (lm loggingMiddleware) GetTenant(ctx context.Context, tenantID string) (tenant *entity.Tenant, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "GetTenant",
+		// 	"tenant_id", tenantID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.GetTenant(ctx, tenantID)
+}
+
+func (lm loggingMiddleware) ListTenants(ctx context.Context) (tenants []*entity.Tenant, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListTenants",
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListTenants(ctx)
+}
+
+func (lm loggingMiddleware) UpdateTenant(ctx context.Context, tenantID string, opt *TenantOption) (tenant *entity.Tenant, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "UpdateTenant",
+		// 	"tenant_id", tenantID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.UpdateTenant(ctx, tenantID, opt)
+}
+
+func (lm loggingMiddleware) DeleteTenant(ctx context.Context, tenantID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "DeleteTenant",
+		// 	"tenant_id", tenantID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.DeleteTenant(ctx, tenantID)
+}
+
+func (lm loggingMiddleware) AddMember(ctx context.Context, tenantID string, userID string, opt *MemberOption) (member *entity.Member, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "AddMember",
+		// 	"tenant_id", tenantID,
+		// 	"user_id", userID,
+		// 	"role", opt.Role,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.AddMember(ctx, tenantID, userID, opt)
+}
+
+func (lm loggingMiddleware) UpdateMember(ctx context.Context, tenantID string, userID string, opt *MemberOption) (member *entity.Member, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "UpdateMember",
+		// 	"tenant_id", tenantID,
+		// 	"user_id", userID,
+		// 	"role", opt.Role,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.UpdateMember(ctx, tenantID, userID, opt)
+}
+
+func (lm loggingMiddleware) RemoveMember(ctx context.Context, tenantID string, userID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "RemoveMember",
+		// 	"tenant_id", tenantID,
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.RemoveMember(ctx, tenantID, userID)
+}
+
+func (lm loggingMiddleware) ListMembers(ctx context.Context, tenantID string) (members []*entity.Member, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ListMembers",
+		// 	"tenant_id", tenantID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ListMembers(ctx, tenantID)
+}
+
+func (lm loggingMiddleware) ResolveTenant(ctx context.Context, name string) (tenantID string, err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "ResolveTenant",
+		// 	"tenant", name,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.ResolveTenant(ctx, name)
+}
+
+func (lm loggingMiddleware) JoinTenant(ctx context.Context, tenantID string, userID string) (err error) {
+	defer func() {
+		// lm.logger.Log(
+		// 	"method", "JoinTenant",
+		// 	"tenant_id", tenantID,
+		// 	"user_id", userID,
+		// 	"err", err,
+		// )
+	}()
+	return lm.next.JoinTenant(ctx, tenantID, userID)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"github.com/karta0898098/iam/pkg/app/tenant/entity"
+)
+
+// TenantOption define tenant name and display name,
+// name is ignored by update since client sign in with it
+type TenantOption struct {
+	Name        string
+	DisplayName string
+}
+
+// MemberOption define role of user in tenant
+type MemberOption struct {
+	Role entity.MemberRole
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rs/xid"
+
+	identityrepo "github.com/karta0898098/iam/pkg/app/identity/repository"
+	identitysvc "github.com/karta0898098/iam/pkg/app/identity/service"
+	"github.com/karta0898098/iam/pkg/app/tenant/entity"
+	"github.com/karta0898098/iam/pkg/app/tenant/repository"
+	"github.com/karta0898098/iam/pkg/audit"
+	"github.com/karta0898098/iam/pkg/errors"
+	pkgtenant "github.com/karta0898098/iam/pkg/tenant"
+)
+
+var _ TenantService = &Impl{}
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(TenantService) TenantService
+
+// TenantService define organizations own users and their members
+type TenantService interface {
+	// CreateTenant create tenant without member
+	CreateTenant(
+		ctx context.Context,
+		opt *TenantOption,
+	) (tenant *entity.Tenant, err error)
+
+	// GetTenant get tenant
+	GetTenant(
+		ctx context.Context,
+		tenantID string,
+	) (tenant *entity.Tenant, err error)
+
+	// ListTenants list tenants
+	ListTenants(
+		ctx context.Context,
+	) (tenants []*entity.Tenant, err error)
+
+	// UpdateTenant replace display name of tenant
+	UpdateTenant(
+		ctx context.Context,
+		tenantID string,
+		opt *TenantOption,
+	) (tenant *entity.Tenant, err error)
+
+	// DeleteTenant delete tenant, tenant still has member can not be deleted
+	DeleteTenant(
+		ctx context.Context,
+		tenantID string,
+	) (err error)
+
+	// AddMember add user of tenant as member with role
+	AddMember(
+		ctx context.Context,
+		tenantID string,
+		userID string,
+		opt *MemberOption,
+	) (member *entity.Member, err error)
+
+	// UpdateMember change role of member
+	UpdateMember(
+		ctx context.Context,
+		tenantID string,
+		userID string,
+		opt *MemberOption,
+	) (member *entity.Member, err error)
+
+	// RemoveMember remove member from tenant, the last owner can not be removed
+	RemoveMember(
+		ctx context.Context,
+		tenantID string,
+		userID string,
+	) (err error)
+
+	// ListMembers list members of tenant
+	ListMembers(
+		ctx context.Context,
+		tenantID string,
+	) (members []*entity.Member, err error)
+
+	// ResolveTenant find id of tenant by name for signin and signup
+	ResolveTenant(
+		ctx context.Context,
+		name string,
+	) (tenantID string, err error)
+
+	// JoinTenant add user signed up into tenant as member
+	JoinTenant(
+		ctx context.Context,
+		tenantID string,
+		userID string,
+	) (err error)
+}
+
+type Impl struct {
+	repo         repository.Repository
+	identityRepo identityrepo.Repository
+}
+
+func New(
+	repo repository.Repository,
+	identityRepo identityrepo.Repository,
+	recorder audit.Recorder,
+) TenantService {
+	var svc TenantService
+	svc = &Impl{
+		repo:         repo,
+		identityRepo: identityRepo,
+	}
+	svc = LoggingMiddleware()(svc)
+	if recorder != nil {
+		svc = AuditMiddleware(recorder)(svc)
+	}
+
+	return svc
+}
+
+// NewTenantDirectory resolve tenant of signin and signup
+func NewTenantDirectory(svc TenantService) identitysvc.TenantDirectory {
+	return svc
+}
+
+func (srv *Impl) CreateTenant(ctx context.Context, opt *TenantOption) (tenant *entity.Tenant, err error) {
+	tenant, err = entity.NewTenant(
+		xid.New().String(),
+		opt.Name,
+		opt.DisplayName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := srv.repo.FindTenantByName(ctx, tenant.Name)
+	if err != nil && !errors.Is(err, errors.ErrResourceNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.Wrapf(errors.ErrConflict, "tenant name=%v already used by tenant=%v", tenant.Name, existing.ID)
+	}
+
+	err = srv.repo.StoreTenant(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+func (srv *Impl) GetTenant(ctx context.Context, tenantID string) (tenant *entity.Tenant, err error) {
+	return srv.repo.FindTenantByID(ctx, tenantID)
+}
+
+func (srv *Impl) ListTenants(ctx context.Context) (tenants []*entity.Tenant, err error) {
+	return srv.repo.ListTenants(ctx)
+}
+
+func (srv *Impl) UpdateTenant(ctx context.Context, tenantID string, opt *TenantOption) (tenant *entity.Tenant, err error) {
+	tenant, err = srv.repo.FindTenantByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tenant.Update(opt.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.UpdateTenant(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+func (srv *Impl) DeleteTenant(ctx context.Context, tenantID string) (err error) {
+	return srv.repo.DeleteTenant(ctx, tenantID)
+}
+
+func (srv *Impl) AddMember(ctx context.Context, tenantID string, userID string, opt *MemberOption) (member *entity.Member, err error) {
+	_, err = srv.repo.FindTenantByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// only user signed up into the tenant can be its member
+	_, err = srv.identityRepo.FindUserByID(pkgtenant.NewContext(ctx, tenantID), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err = entity.NewMember(tenantID, userID, opt.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := srv.repo.FindMember(ctx, tenantID, userID)
+	if err != nil && !errors.Is(err, errors.ErrResourceNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.Wrapf(errors.ErrConflict, "user=%v already member of tenant=%v", userID, tenantID)
+	}
+
+	err = srv.repo.StoreMember(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (srv *Impl) UpdateMember(ctx context.Context, tenantID string, userID string, opt *MemberOption) (member *entity.Member, err error) {
+	member, err = srv.repo.FindMember(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.IsOwner() && opt.Role != entity.MemberRoleOwner {
+		err = srv.checkLastOwner(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = member.ChangeRole(opt.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.repo.UpdateMember(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (srv *Impl) RemoveMember(ctx context.Context, tenantID string, userID string) (err error) {
+	member, err := srv.repo.FindMember(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+
+	if member.IsOwner() {
+		err = srv.checkLastOwner(ctx, tenantID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return srv.repo.DeleteMember(ctx, tenantID, userID)
+}
+
+// checkLastOwner tenant must keep at least one owner
+func (srv *Impl) checkLastOwner(ctx context.Context, tenantID string) error {
+	members, err := srv.repo.ListMembers(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	owners := 0
+	for _, member := range members {
+		if member.IsOwner() {
+			owners++
+		}
+	}
+
+	if owners <= 1 {
+		return errors.Wrapf(errors.ErrConflict, "tenant=%v must keep at least one owner", tenantID)
+	}
+
+	return nil
+}
+
+func (srv *Impl) ListMembers(ctx context.Context, tenantID string) (members []*entity.Member, err error) {
+	_, err = srv.repo.FindTenantByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.repo.ListMembers(ctx, tenantID)
+}
+
+func (srv *Impl) ResolveTenant(ctx context.Context, name string) (tenantID string, err error) {
+	tenant, err := srv.repo.FindTenantByName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	return tenant.ID, nil
+}
+
+func (srv *Impl) JoinTenant(ctx context.Context, tenantID string, userID string) (err error) {
+	member, err := entity.NewMember(tenantID, userID, entity.MemberRoleMember)
+	if err != nil {
+		return err
+	}
+
+	return srv.repo.StoreMember(ctx, member)
+}
@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	identitymocks "github.com/karta0898098/iam/pkg/app/identity/mocks"
+	"github.com/karta0898098/iam/pkg/app/tenant/entity"
+	"github.com/karta0898098/iam/pkg/app/tenant/mocks"
+	"github.com/karta0898098/iam/pkg/app/tenant/repository"
+	"github.com/karta0898098/iam/pkg/app/tenant/service"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+func TestImpl_RemoveMember(t *testing.T) {
+	owner := &entity.Member{TenantID: "MOCK-TENANT-ID", UserID: "MOCK-OWNER-ID", Role: entity.MemberRoleOwner}
+	otherOwner := &entity.Member{TenantID: "MOCK-TENANT-ID", UserID: "MOCK-OTHER-OWNER-ID", Role: entity.MemberRoleOwner}
+	member := &entity.Member{TenantID: "MOCK-TENANT-ID", UserID: "MOCK-MEMBER-ID", Role: entity.MemberRoleMember}
+
+	tests := []struct {
+		name   string
+		repo   repository.Repository
+		userID string
+		err    error
+	}{
+		{
+			name: "Remove Member",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindMember(mock.Anything, "MOCK-TENANT-ID", member.UserID).
+					Return(member, nil)
+				repo.EXPECT().
+					DeleteMember(mock.Anything, "MOCK-TENANT-ID", member.UserID).
+					Return(nil)
+				return repo
+			}(),
+			userID: member.UserID,
+		},
+		{
+			name: "Remove Owner With Another Owner",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindMember(mock.Anything, "MOCK-TENANT-ID", owner.UserID).
+					Return(owner, nil)
+				repo.EXPECT().
+					ListMembers(mock.Anything, "MOCK-TENANT-ID").
+					Return([]*entity.Member{owner, otherOwner, member}, nil)
+				repo.EXPECT().
+					DeleteMember(mock.Anything, "MOCK-TENANT-ID", owner.UserID).
+					Return(nil)
+				return repo
+			}(),
+			userID: owner.UserID,
+		},
+		{
+			name: "Remove Last Owner",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindMember(mock.Anything, "MOCK-TENANT-ID", owner.UserID).
+					Return(owner, nil)
+				repo.EXPECT().
+					ListMembers(mock.Anything, "MOCK-TENANT-ID").
+					Return([]*entity.Member{owner, member}, nil)
+				return repo
+			}(),
+			userID: owner.UserID,
+			err:    errors.ErrConflict,
+		},
+		{
+			name: "Member Not Found",
+			repo: func() repository.Repository {
+				repo := mocks.NewRepository(t)
+				repo.EXPECT().
+					FindMember(mock.Anything, "MOCK-TENANT-ID", "MOCK-UNKNOWN-ID").
+					Return(nil, errors.ErrResourceNotFound)
+				return repo
+			}(),
+			userID: "MOCK-UNKNOWN-ID",
+			err:    errors.ErrResourceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			srv := service.New(tt.repo, identitymocks.NewRepository(t), nil)
+			err := srv.RemoveMember(ctx, "MOCK-TENANT-ID", tt.userID)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
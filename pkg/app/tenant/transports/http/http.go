@@ -0,0 +1,233 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/app/tenant/endpoints"
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	pkghttp "github.com/karta0898098/iam/pkg/http"
+)
+
+// MakeCreateTenant make create tenant endpoint
+func MakeCreateTenant(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.CreateTenantEndpoint,
+		decodeHTTPCreateTenantRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPCreateTenantRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPCreateTenantRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.CreateTenantRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	return &req, nil
+}
+
+// MakeGetTenant make get tenant endpoint
+func MakeGetTenant(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.GetTenantEndpoint,
+		decodeHTTPGetTenantRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPGetTenantRequest is a transport/http.DecodeRequestFunc that decodes
+// tenant id from the URL path. Primarily useful in a server.
+func decodeHTTPGetTenantRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.GetTenantRequest{
+		TenantID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeListTenants make list tenants endpoint
+func MakeListTenants(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListTenantsEndpoint,
+		decodeHTTPListTenantsRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListTenantsRequest is a transport/http.DecodeRequestFunc that decodes
+// list request without body, bearer token is read by authn.HTTPToContext. Primarily useful in a server.
+func decodeHTTPListTenantsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListTenantsRequest{}, nil
+}
+
+// MakeUpdateTenant make update tenant endpoint
+func MakeUpdateTenant(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.UpdateTenantEndpoint,
+		decodeHTTPUpdateTenantRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPUpdateTenantRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPUpdateTenantRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.UpdateTenantRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.TenantID = pkghttp.PathParam(r, "id")
+	return &req, nil
+}
+
+// MakeDeleteTenant make delete tenant endpoint
+func MakeDeleteTenant(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.DeleteTenantEndpoint,
+		decodeHTTPDeleteTenantRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPDeleteTenantRequest is a transport/http.DecodeRequestFunc that decodes
+// tenant id from the URL path. Primarily useful in a server.
+func decodeHTTPDeleteTenantRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.DeleteTenantRequest{
+		TenantID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// MakeAddMember make add user into tenant endpoint
+func MakeAddMember(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.AddMemberEndpoint,
+		decodeHTTPAddMemberRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPAddMemberRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPAddMemberRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.AddMemberRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.TenantID = pkghttp.PathParam(r, "id")
+	return &req, nil
+}
+
+// MakeUpdateMember make change role of member endpoint
+func MakeUpdateMember(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.UpdateMemberEndpoint,
+		decodeHTTPUpdateMemberRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPUpdateMemberRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded request from the HTTP request body. Primarily useful in a server.
+func decodeHTTPUpdateMemberRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req endpoints.UpdateMemberRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "input request is not json")
+	}
+	req.TenantID = pkghttp.PathParam(r, "id")
+	req.UserID = pkghttp.PathParam(r, "user_id")
+	return &req, nil
+}
+
+// MakeRemoveMember make remove user from tenant endpoint
+func MakeRemoveMember(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.RemoveMemberEndpoint,
+		decodeHTTPRemoveMemberRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPRemoveMemberRequest is a transport/http.DecodeRequestFunc that decodes
+// tenant id and user id from the URL path. Primarily useful in a server.
+func decodeHTTPRemoveMemberRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.RemoveMemberRequest{
+		TenantID: pkghttp.PathParam(r, "id"),
+		UserID:   pkghttp.PathParam(r, "user_id"),
+	}, nil
+}
+
+// MakeListMembers make list members of tenant endpoint
+func MakeListMembers(endpoints endpoints.Endpoints) http.Handler {
+	return httptransport.NewServer(
+		endpoints.ListMembersEndpoint,
+		decodeHTTPListMembersRequest,
+		encodeHTTPResponse,
+		httptransport.ServerBefore(authn.HTTPToContext()),
+		httptransport.ServerErrorEncoder(errors.ErrorResponse),
+		httptransport.ServerErrorHandler(errors.NewLoggingErrorHandle()),
+	)
+}
+
+// decodeHTTPListMembersRequest is a transport/http.DecodeRequestFunc that decodes
+// tenant id from the URL path. Primarily useful in a server.
+func decodeHTTPListMembersRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ListMembersRequest{
+		TenantID: pkghttp.PathParam(r, "id"),
+	}, nil
+}
+
+// encodeHTTPResponse is a transport/http.EncodeResponseFunc that encodes
+// response as JSON into the HTTP response body. Primarily useful in a server.
+func encodeHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	if headers, ok := response.(httptransport.Headerer); ok {
+		for k, values := range headers.Headers() {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+	code := http.StatusOK
+	if sc, ok := response.(httptransport.StatusCoder); ok {
+		code = sc.StatusCode()
+	}
+	w.WriteHeader(code)
+	if code == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,351 @@
+// Package audit define event of authentication and administrative action.
+// Events are appended to a hash chain, every event carry hash of the previous one
+// so modifying or removing a recorded event break every hash after it.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/karta0898098/iam/pkg/authn"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/tenant"
+)
+
+// Action is what actor did
+type Action string
+
+const (
+	ActionSignin               Action = "user.signin"
+	ActionSignup               Action = "user.signup"
+	ActionRefresh              Action = "session.refresh"
+	ActionSignout              Action = "session.signout"
+	ActionSignoutAll           Action = "session.signout_all"
+	ActionRevokeSession        Action = "session.revoke"
+	ActionEnrollTOTP           Action = "mfa.totp.enroll"
+	ActionVerifyMFA            Action = "mfa.verify"
+	ActionRegisterWebAuthn     Action = "mfa.webauthn.register"
+	ActionWebAuthnSignin       Action = "user.signin.webauthn"
+	ActionFederatedSignin      Action = "user.signin.federated"
+	ActionVerifyEmail          Action = "user.email.verify"
+	ActionRequestPasswordReset Action = "user.password.reset_request"
+	ActionResetPassword        Action = "user.password.reset"
+	ActionChangePassword       Action = "user.password.change"
+	ActionUpdateProfile        Action = "user.profile.update"
+	ActionUnlockUser           Action = "admin.user.unlock"
+	ActionSuspendUser          Action = "admin.user.suspend"
+	ActionReactivateUser       Action = "admin.user.reactivate"
+	ActionDeleteUser           Action = "admin.user.delete"
+	ActionTokenGrant           Action = "oauth2.token"
+	ActionCreateClient         Action = "admin.client.create"
+	ActionUpdateClient         Action = "admin.client.update"
+	ActionResetClientSecret    Action = "admin.client.reset_secret"
+	ActionDeleteClient         Action = "admin.client.delete"
+	ActionCreatePermission     Action = "admin.permission.create"
+	ActionUpdatePermission     Action = "admin.permission.update"
+	ActionDeletePermission     Action = "admin.permission.delete"
+	ActionCreateRole           Action = "admin.role.create"
+	ActionUpdateRole           Action = "admin.role.update"
+	ActionDeleteRole           Action = "admin.role.delete"
+	ActionAssignRole           Action = "admin.role.assign"
+	ActionUnassignRole         Action = "admin.role.unassign"
+	ActionCreateGroup          Action = "admin.group.create"
+	ActionUpdateGroup          Action = "admin.group.update"
+	ActionDeleteGroup          Action = "admin.group.delete"
+	ActionAddGroupMember       Action = "admin.group.member.add"
+	ActionRemoveGroupMember    Action = "admin.group.member.remove"
+	ActionCreatePolicy         Action = "admin.policy.create"
+	ActionUpdatePolicy         Action = "admin.policy.update"
+	ActionDeletePolicy         Action = "admin.policy.delete"
+	ActionAttachPolicy         Action = "admin.policy.attach"
+	ActionDetachPolicy         Action = "admin.policy.detach"
+	ActionCreateTenant         Action = "admin.tenant.create"
+	ActionUpdateTenant         Action = "admin.tenant.update"
+	ActionDeleteTenant         Action = "admin.tenant.delete"
+	ActionAddTenantMember      Action = "admin.tenant.member.add"
+	ActionUpdateTenantMember   Action = "admin.tenant.member.update"
+	ActionRemoveTenantMember   Action = "admin.tenant.member.remove"
+	ActionSCIMCreateUser       Action = "scim.user.create"
+	ActionSCIMReplaceUser      Action = "scim.user.replace"
+	ActionSCIMPatchUser        Action = "scim.user.patch"
+	ActionSCIMDeleteUser       Action = "scim.user.delete"
+	ActionSCIMCreateGroup      Action = "scim.group.create"
+	ActionSCIMReplaceGroup     Action = "scim.group.replace"
+	ActionSCIMPatchGroup       Action = "scim.group.patch"
+	ActionSCIMDeleteGroup      Action = "scim.group.delete"
+)
+
+const (
+	valueLengthMax     = 255
+	platformLengthMax  = 32
+	ipAddressLengthMax = 64
+)
+
+// Outcome is result of action
+type Outcome string
+
+const (
+	// OutcomeSuccess action succeeded
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure action failed, error code tell why
+	OutcomeFailure Outcome = "failure"
+	// OutcomeChallenged password is verified but second factor is pending
+	OutcomeChallenged Outcome = "challenged"
+)
+
+// Event is one recorded action
+type Event struct {
+	// Seq is position of event in chain, starting from 1
+	Seq      int64
+	TenantID string
+	// Actor is user id or client id performing action, username or email when caller is not identified
+	Actor  string
+	Action Action
+	// Target is id of user, session or other resource the action apply to,
+	// ids joined by TargetOf when action apply to a pair e.g. role binding
+	Target    string
+	IPAddress string
+	Platform  string
+	Device    string
+	Outcome   Outcome
+	// ErrorCode is code of exception when action failed
+	ErrorCode int
+	CreatedAt time.Time
+	// PrevHash is hash of previous event, empty for the first event
+	PrevHash string
+	// Hash cover every field above
+	Hash string
+}
+
+// NewEvent new event of action, outcome and error code follow err
+func NewEvent(action Action, actor string, target string, err error) *Event {
+	event := &Event{
+		Actor:     actor,
+		Action:    action,
+		Target:    target,
+		Outcome:   OutcomeSuccess,
+		CreatedAt: time.Now(),
+	}
+
+	if err != nil {
+		event.Outcome = OutcomeFailure
+		event.ErrorCode = errors.ErrInternal.Code
+		if exception := errors.TryConvert(err); exception != nil {
+			event.ErrorCode = exception.Code
+		}
+	}
+
+	return event
+}
+
+// NewPrincipalEvent new event of action authenticated caller apply to target,
+// actor is user id of caller or client id when client acts on behalf of itself
+func NewPrincipalEvent(ctx context.Context, action Action, target string, err error) *Event {
+	var actor string
+	if principal, ok := authn.FromContext(ctx); ok {
+		actor = principal.UserID
+		if actor == "" {
+			actor = principal.ClientID
+		}
+	}
+	return NewEvent(action, actor, target, err)
+}
+
+// TargetOf join ids of resources action apply to, e.g. role and user of role binding
+func TargetOf(ids ...string) string {
+	return strings.Join(ids, "/")
+}
+
+// WithClient set ip address, platform and device of caller, empty value is kept
+func (e *Event) WithClient(ip string, platform string, device string) *Event {
+	if ip != "" {
+		e.IPAddress = ip
+	}
+	if platform != "" {
+		e.Platform = platform
+	}
+	if device != "" {
+		e.Device = device
+	}
+	return e
+}
+
+// content is field of event covered by hash, order of fields is part of the format
+type content struct {
+	Seq       int64   `json:"seq"`
+	PrevHash  string  `json:"prev_hash"`
+	TenantID  string  `json:"tenant_id"`
+	Actor     string  `json:"actor"`
+	Action    Action  `json:"action"`
+	Target    string  `json:"target"`
+	IPAddress string  `json:"ip_address"`
+	Platform  string  `json:"platform"`
+	Device    string  `json:"device"`
+	Outcome   Outcome `json:"outcome"`
+	ErrorCode int     `json:"error_code"`
+	CreatedAt int64   `json:"created_at"`
+}
+
+// ComputeHash hex sha256 of event content, time is hashed in millisecond as it is stored
+func (e *Event) ComputeHash() string {
+	b, _ := json.Marshal(content{
+		Seq:       e.Seq,
+		PrevHash:  e.PrevHash,
+		TenantID:  e.TenantID,
+		Actor:     e.Actor,
+		Action:    e.Action,
+		Target:    e.Target,
+		IPAddress: e.IPAddress,
+		Platform:  e.Platform,
+		Device:    e.Device,
+		Outcome:   e.Outcome,
+		ErrorCode: e.ErrorCode,
+		CreatedAt: e.CreatedAt.UnixMilli(),
+	})
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// Chain append event after prev, nil prev make event the first of chain
+func (e *Event) Chain(prev *Event) {
+	e.Seq = 1
+	e.PrevHash = ""
+	if prev != nil {
+		e.Seq = prev.Seq + 1
+		e.PrevHash = prev.Hash
+	}
+	e.Hash = e.ComputeHash()
+}
+
+// Verify check events ordered by seq form a chain after prev,
+// nil prev means events start from the first of chain
+func Verify(prev *Event, events []*Event) error {
+	for _, event := range events {
+		seq, prevHash := int64(1), ""
+		if prev != nil {
+			seq, prevHash = prev.Seq+1, prev.Hash
+		}
+
+		if event.Seq != seq || event.PrevHash != prevHash {
+			return errors.Wrapf(errors.ErrConflict, "audit event seq=%v is not chained after seq=%v", event.Seq, seq-1)
+		}
+		if event.Hash != event.ComputeHash() {
+			return errors.Wrapf(errors.ErrConflict, "audit event seq=%v is modified", event.Seq)
+		}
+		prev = event
+	}
+	return nil
+}
+
+// Recorder append event to audit log
+type Recorder interface {
+	// Record chain event after the latest one and store it
+	Record(ctx context.Context, event *Event) (err error)
+}
+
+// RecorderFunc is function implement Recorder
+type RecorderFunc func(ctx context.Context, event *Event) error
+
+// Record implement Recorder
+func (f RecorderFunc) Record(ctx context.Context, event *Event) error {
+	return f(ctx, event)
+}
+
+// Emit record event, failure is logged instead of failing the audited action.
+// Tenant and client of request fill what the event does not know, user agent is taken as device
+func Emit(ctx context.Context, recorder Recorder, event *Event) {
+	if event.TenantID == tenant.Default {
+		event.TenantID = tenant.ID(ctx)
+	}
+	if client, ok := ClientFromContext(ctx); ok {
+		if event.IPAddress == "" {
+			event.IPAddress = client.IPAddress
+		}
+		if event.Device == "" {
+			event.Device = client.UserAgent
+		}
+	}
+
+	// caller controlled values are cut to fit columns of audit log
+	event.Actor = truncate(event.Actor, valueLengthMax)
+	event.Target = truncate(event.Target, valueLengthMax)
+	event.Device = truncate(event.Device, valueLengthMax)
+	event.Platform = truncate(event.Platform, platformLengthMax)
+	event.IPAddress = truncate(event.IPAddress, ipAddressLengthMax)
+
+	// event of request whose client has gone is still recorded
+	err := recorder.Record(detachedContext{ctx}, event)
+	if err != nil {
+		log.Ctx(ctx).Error().
+			Err(err).
+			Str("action", string(event.Action)).
+			Str("actor", event.Actor).
+			Msg("failed to record audit event")
+	}
+}
+
+// detachedContext keep values of parent context but never canceled
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// Client is caller of request known by transport
+type Client struct {
+	IPAddress string
+	UserAgent string
+}
+
+type clientKey struct{}
+
+// NewContext return context carry client of request
+func NewContext(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// ClientFromContext read client put by NewContext
+func ClientFromContext(ctx context.Context) (client Client, ok bool) {
+	client, ok = ctx.Value(clientKey{}).(Client)
+	return client, ok
+}
+
+// DeviceName join non-empty parts describing device, e.g. name, model and os version
+func DeviceName(parts ...string) string {
+	names := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			names = append(names, part)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	// do not split multi-byte character
+	for max > 0 && !utf8.RuneStart(value[max]) {
+		max--
+	}
+	return value[:max]
+}
@@ -0,0 +1,124 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/tenant"
+)
+
+func newChain(n int) []*Event {
+	events := make([]*Event, 0, n)
+	var prev *Event
+	for i := 0; i < n; i++ {
+		event := NewEvent(ActionSignin, "MOCK-USER-ID", "MOCK-USER-ID", nil).WithClient("127.0.0.1", "ios", "iPhone")
+		event.CreatedAt = time.UnixMilli(1700000000000 + int64(i))
+		event.Chain(prev)
+		events = append(events, event)
+		prev = event
+	}
+	return events
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(events []*Event) []*Event
+		err    bool
+	}{
+		{
+			name:   "Intact",
+			tamper: func(events []*Event) []*Event { return events },
+		},
+		{
+			name: "Modified",
+			tamper: func(events []*Event) []*Event {
+				events[1].Outcome = OutcomeFailure
+				return events
+			},
+			err: true,
+		},
+		{
+			name: "Rehashed After Modified",
+			tamper: func(events []*Event) []*Event {
+				events[1].Actor = "OTHER-USER-ID"
+				events[1].Hash = events[1].ComputeHash()
+				return events
+			},
+			err: true,
+		},
+		{
+			name: "Removed",
+			tamper: func(events []*Event) []*Event {
+				return append(events[:1], events[2:]...)
+			},
+			err: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(nil, tt.tamper(newChain(3)))
+			if tt.err {
+				assert.True(t, errors.Is(err, errors.ErrConflict), err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEvent_Chain(t *testing.T) {
+	events := newChain(2)
+
+	assert.Equal(t, int64(1), events[0].Seq)
+	assert.Empty(t, events[0].PrevHash)
+	assert.Equal(t, int64(2), events[1].Seq)
+	assert.Equal(t, events[0].Hash, events[1].PrevHash)
+	assert.NoError(t, Verify(events[0], events[1:]))
+}
+
+func TestNewEvent(t *testing.T) {
+	event := NewEvent(ActionSignin, "alice", "", errors.Wrap(errors.ErrUnauthorized, "wrong password"))
+	assert.Equal(t, OutcomeFailure, event.Outcome)
+	assert.Equal(t, errors.ErrUnauthorized.Code, event.ErrorCode)
+
+	event = NewEvent(ActionSignin, "alice", "", context.Canceled)
+	assert.Equal(t, errors.ErrInternal.Code, event.ErrorCode)
+}
+
+func TestEmit(t *testing.T) {
+	var recorded *Event
+	recorder := RecorderFunc(func(ctx context.Context, event *Event) error {
+		recorded = event
+		return errors.ErrInternal
+	})
+
+	ctx := tenant.NewContext(context.Background(), "MOCK-TENANT-ID")
+	ctx = NewContext(ctx, Client{IPAddress: "10.0.0.1", UserAgent: "curl/8.0"})
+
+	// failure of recorder is not returned to audited action
+	Emit(ctx, recorder, NewEvent(ActionChangePassword, "MOCK-USER-ID", "MOCK-USER-ID", nil).WithClient("127.0.0.1", "", ""))
+	if assert.NotNil(t, recorded) {
+		assert.Equal(t, "MOCK-TENANT-ID", recorded.TenantID)
+		assert.Equal(t, "127.0.0.1", recorded.IPAddress)
+		assert.Equal(t, "curl/8.0", recorded.Device)
+	}
+}
+
+func TestEmit_CanceledRequest(t *testing.T) {
+	var recordErr error
+	recorder := RecorderFunc(func(ctx context.Context, event *Event) error {
+		recordErr = ctx.Err()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(tenant.NewContext(context.Background(), "MOCK-TENANT-ID"))
+	cancel()
+
+	Emit(ctx, recorder, NewEvent(ActionChangePassword, "MOCK-USER-ID", "MOCK-USER-ID", nil))
+	assert.NoError(t, recordErr)
+}
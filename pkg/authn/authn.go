@@ -0,0 +1,129 @@
+// Package authn authenticate access token issued by IAM and put Principal into context.
+// Echo middleware and grpc interceptor live in pkg/http/middleware and pkg/grpc,
+// go-kit service use HTTPToContext or GRPCToContext with NewEndpointMiddleware.
+package authn
+
+import (
+	"context"
+	"strings"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/oidc"
+	"github.com/karta0898098/iam/pkg/tenant"
+)
+
+const (
+	// bearerScheme authorization scheme of access token defined by RFC 6750
+	bearerScheme = "Bearer "
+
+	// PermissionAdmin rbac permission user must be granted to use administrative api
+	PermissionAdmin = "iam:admin"
+)
+
+// Principal define authenticated caller of request
+type Principal struct {
+	// UserID subject of access token
+	UserID string
+	// SessionID session the access token belong to
+	SessionID string
+	// ClientID oauth2 client token issued to, empty when issued by signin
+	ClientID string
+	// TenantID tenant user belong to, empty is default tenant
+	TenantID string
+	// Scopes granted to access token
+	Scopes oidc.Scopes
+}
+
+// HasScope check principal is granted scope
+func (p *Principal) HasScope(scope string) bool {
+	return p.Scopes.Has(scope)
+}
+
+// IsClient principal is client authenticated by client_credentials grant,
+// subject of such token is the client itself
+func (p *Principal) IsClient() bool {
+	return p.ClientID != "" && p.UserID == p.ClientID
+}
+
+// PermissionChecker check user is granted rbac permission
+type PermissionChecker interface {
+	// CheckPermission any role bound to user grant the permission
+	CheckPermission(ctx context.Context, userID string, permission string) (allowed bool, err error)
+}
+
+// Authenticator verify signature, expiry and session status of access token
+type Authenticator interface {
+	// Authenticate return principal of access token,
+	// ErrUnauthorized is returned when token is not active
+	Authenticate(ctx context.Context, token string) (principal *Principal, err error)
+}
+
+// AuthenticatorFunc is function implement Authenticator
+type AuthenticatorFunc func(ctx context.Context, token string) (*Principal, error)
+
+// Authenticate implement Authenticator
+func (f AuthenticatorFunc) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	return f(ctx, token)
+}
+
+type principalKey struct{}
+
+type tokenKey struct{}
+
+// NewContext return context carry principal
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext read principal put by authn middleware
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// Authenticated read principal put by authn middleware,
+// ErrUnauthorized is returned when request is not authenticated
+func Authenticated(ctx context.Context) (*Principal, error) {
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return nil, errors.Wrap(errors.ErrUnauthorized, "request is not authenticated")
+	}
+	return principal, nil
+}
+
+// ContextWithToken return context carry access token
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// TokenFromContext read access token put by HTTPToContext or GRPCToContext
+func TokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(tokenKey{}).(string)
+	return token
+}
+
+// ParseBearer extract token from authorization value,
+// empty string will be returned when value is not bearer scheme
+func ParseBearer(authorization string) string {
+	if len(authorization) > len(bearerScheme) && strings.EqualFold(authorization[:len(bearerScheme)], bearerScheme) {
+		return strings.TrimSpace(authorization[len(bearerScheme):])
+	}
+	return ""
+}
+
+// Authenticate verify token and return context carry its principal
+func Authenticate(ctx context.Context, authenticator Authenticator, token string) (context.Context, error) {
+	if token == "" {
+		return ctx, errors.Wrap(errors.ErrUnauthorized, "access token is empty")
+	}
+
+	principal, err := authenticator.Authenticate(ctx, token)
+	if err != nil {
+		return ctx, err
+	}
+
+	// query of user is scoped to tenant of caller
+	ctx = tenant.NewContext(ctx, principal.TenantID)
+
+	return NewContext(ctx, principal), nil
+}
@@ -0,0 +1,229 @@
+package authn
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+func newTestAuthenticator(calls *int) Authenticator {
+	return AuthenticatorFunc(func(ctx context.Context, token string) (*Principal, error) {
+		*calls++
+		if token != "valid" {
+			return nil, errors.Wrap(errors.ErrUnauthorized, "access token is not active")
+		}
+		return &Principal{
+			UserID:    "MOCK-USER-ID",
+			SessionID: "MOCK-SESSION-ID",
+			Scopes:    oidc.Scopes{oidc.ScopeOpenID},
+		}, nil
+	})
+}
+
+func principalEndpoint(ctx context.Context, request interface{}) (interface{}, error) {
+	return Authenticated(ctx)
+}
+
+func TestParseBearer(t *testing.T) {
+	assert.Equal(t, "token", ParseBearer("Bearer token"))
+	assert.Equal(t, "token", ParseBearer("bearer  token"))
+	assert.Equal(t, "", ParseBearer("Basic dXNlcjpwYXNz"))
+	assert.Equal(t, "", ParseBearer("Bearer "))
+	assert.Equal(t, "", ParseBearer(""))
+}
+
+func TestNewEndpointMiddleware(t *testing.T) {
+	tests := []struct {
+		name  string
+		ctx   func() context.Context
+		calls int
+		err   error
+	}{
+		{
+			name: "HTTP Token",
+			ctx: func() context.Context {
+				r, _ := http.NewRequest(http.MethodGet, "/profile", nil)
+				r.Header.Set("Authorization", "Bearer valid")
+				return HTTPToContext()(context.Background(), r)
+			},
+			calls: 1,
+		},
+		{
+			name: "GRPC Token",
+			ctx: func() context.Context {
+				return GRPCToContext()(context.Background(), metadata.Pairs("authorization", "Bearer valid"))
+			},
+			calls: 1,
+		},
+		{
+			name: "Invalid Token",
+			ctx: func() context.Context {
+				return ContextWithToken(context.Background(), "invalid")
+			},
+			calls: 1,
+			err:   errors.ErrUnauthorized,
+		},
+		{
+			name: "Missing Token",
+			ctx: func() context.Context {
+				return context.Background()
+			},
+			calls: 0,
+			err:   errors.ErrUnauthorized,
+		},
+		{
+			name: "Already Authenticated",
+			ctx: func() context.Context {
+				return NewContext(context.Background(), &Principal{UserID: "MOCK-USER-ID"})
+			},
+			calls: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			e := NewEndpointMiddleware(newTestAuthenticator(&calls))(principalEndpoint)
+
+			resp, err := e(tt.ctx(), nil)
+			assert.Equal(t, tt.calls, calls)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "MOCK-USER-ID", resp.(*Principal).UserID)
+		})
+	}
+}
+
+func TestNewOptionalEndpointMiddleware(t *testing.T) {
+	calls := 0
+	e := NewOptionalEndpointMiddleware(newTestAuthenticator(&calls))(func(ctx context.Context, request interface{}) (interface{}, error) {
+		principal, _ := FromContext(ctx)
+		return principal, nil
+	})
+
+	// anonymous caller is passed without principal
+	resp, err := e(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, 0, calls)
+
+	resp, err = e(ContextWithToken(context.Background(), "valid"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "MOCK-USER-ID", resp.(*Principal).UserID)
+
+	// token presented is still verified
+	_, err = e(ContextWithToken(context.Background(), "invalid"), nil)
+	assert.True(t, errors.Is(err, errors.ErrUnauthorized), err)
+}
+
+func TestNewScopeMiddleware(t *testing.T) {
+	ctx := NewContext(context.Background(), &Principal{
+		UserID: "MOCK-USER-ID",
+		Scopes: oidc.Scopes{oidc.ScopeOpenID, oidc.ScopeAdmin},
+	})
+
+	_, err := NewScopeMiddleware(oidc.ScopeAdmin)(principalEndpoint)(ctx, nil)
+	assert.NoError(t, err)
+
+	_, err = NewScopeMiddleware("iam:other")(principalEndpoint)(ctx, nil)
+	assert.True(t, errors.Is(err, errors.ErrForbidden))
+
+	_, err = NewScopeMiddleware(oidc.ScopeAdmin)(principalEndpoint)(context.Background(), nil)
+	assert.True(t, errors.Is(err, errors.ErrUnauthorized))
+}
+
+type permissionCheckerFunc func(ctx context.Context, userID string, permission string) (bool, error)
+
+func (f permissionCheckerFunc) CheckPermission(ctx context.Context, userID string, permission string) (bool, error) {
+	return f(ctx, userID, permission)
+}
+
+func TestNewAdminMiddleware(t *testing.T) {
+	checker := permissionCheckerFunc(func(ctx context.Context, userID string, permission string) (bool, error) {
+		return userID == "MOCK-ADMIN-ID" && permission == PermissionAdmin, nil
+	})
+	middleware := NewAdminMiddleware(checker)
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		err       error
+	}{
+		{
+			name:      "Admin User",
+			principal: &Principal{UserID: "MOCK-ADMIN-ID", ClientID: "MOCK-CLIENT-ID", Scopes: oidc.Scopes{oidc.ScopeAdmin}},
+		},
+		{
+			name:      "Ordinary User Granted Scope By Client",
+			principal: &Principal{UserID: "MOCK-USER-ID", ClientID: "MOCK-CLIENT-ID", Scopes: oidc.Scopes{oidc.ScopeAdmin}},
+			err:       errors.ErrForbidden,
+		},
+		{
+			name:      "Client Credentials",
+			principal: &Principal{UserID: "MOCK-CLIENT-ID", ClientID: "MOCK-CLIENT-ID", Scopes: oidc.Scopes{oidc.ScopeAdmin}},
+		},
+		{
+			name:      "Admin User Without Scope",
+			principal: &Principal{UserID: "MOCK-ADMIN-ID", ClientID: "MOCK-CLIENT-ID", Scopes: oidc.Scopes{oidc.ScopeOpenID}},
+			err:       errors.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := middleware(principalEndpoint)(NewContext(context.Background(), tt.principal), nil)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewIntrospectionMiddleware(t *testing.T) {
+	middleware := NewIntrospectionMiddleware()
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		err       error
+	}{
+		{
+			name:      "Client Credentials",
+			principal: &Principal{UserID: "MOCK-CLIENT-ID", ClientID: "MOCK-CLIENT-ID", Scopes: oidc.Scopes{oidc.ScopeIntrospect}},
+		},
+		{
+			name:      "Client Without Scope",
+			principal: &Principal{UserID: "MOCK-CLIENT-ID", ClientID: "MOCK-CLIENT-ID", Scopes: oidc.Scopes{oidc.ScopeAdmin}},
+			err:       errors.ErrForbidden,
+		},
+		{
+			name:      "User Granted Scope By Client",
+			principal: &Principal{UserID: "MOCK-USER-ID", ClientID: "MOCK-CLIENT-ID", Scopes: oidc.Scopes{oidc.ScopeIntrospect}},
+			err:       errors.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := middleware(principalEndpoint)(NewContext(context.Background(), tt.principal), nil)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	// caller not authenticated can not introspect
+	_, err := middleware(principalEndpoint)(context.Background(), nil)
+	assert.True(t, errors.Is(err, errors.ErrUnauthorized), err)
+}
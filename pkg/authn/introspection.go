@@ -0,0 +1,44 @@
+package authn
+
+import (
+	"context"
+
+	pb "github.com/karta0898098/iam/pb/identity"
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+// IntrospectionAuthenticator authenticate token with Introspect rpc of IAM,
+// service outside IAM use it to verify token without signing keys.
+// IAM only answer client granted iam:introspect scope, so connection of client
+// must carry its access token, e.g. by grpc.WithPerRPCCredentials
+type IntrospectionAuthenticator struct {
+	client pb.IdentityServiceClient
+}
+
+// NewIntrospectionAuthenticator IntrospectionAuthenticator constructor
+func NewIntrospectionAuthenticator(client pb.IdentityServiceClient) Authenticator {
+	return &IntrospectionAuthenticator{
+		client: client,
+	}
+}
+
+// Authenticate implement Authenticator
+func (a *IntrospectionAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	resp, err := a.client.Introspect(ctx, &pb.IntrospectReq{Token: token})
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "failed to introspect access token err %v", err)
+	}
+
+	if !resp.Active {
+		return nil, errors.Wrap(errors.ErrUnauthorized, "access token is not active")
+	}
+
+	return &Principal{
+		UserID:    resp.Sub,
+		SessionID: resp.Jti,
+		ClientID:  resp.ClientID,
+		TenantID:  resp.Tenant,
+		Scopes:    oidc.ParseScopes(resp.Scope),
+	}, nil
+}
@@ -0,0 +1,151 @@
+package authn
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+// HTTPToContext move bearer token of authorization header into context,
+// use it as go-kit http ServerBefore option
+func HTTPToContext() httptransport.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		token := ParseBearer(r.Header.Get("Authorization"))
+		if token == "" {
+			return ctx
+		}
+		return ContextWithToken(ctx, token)
+	}
+}
+
+// GRPCToContext move bearer token of authorization metadata into context,
+// use it as go-kit grpc ServerBefore option
+func GRPCToContext() grpctransport.ServerRequestFunc {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		for _, authorization := range md.Get("authorization") {
+			if token := ParseBearer(authorization); token != "" {
+				return ContextWithToken(ctx, token)
+			}
+		}
+		return ctx
+	}
+}
+
+// NewEndpointMiddleware authenticate token put by HTTPToContext or GRPCToContext,
+// request already authenticated by echo middleware or grpc interceptor is passed
+func NewEndpointMiddleware(authenticator Authenticator) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			if _, ok := FromContext(ctx); ok {
+				return next(ctx, request)
+			}
+
+			ctx, err = Authenticate(ctx, authenticator, TokenFromContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// NewOptionalEndpointMiddleware authenticate token put by HTTPToContext or GRPCToContext when there is one,
+// request without token is passed unauthenticated so endpoint serve anonymous caller as well
+func NewOptionalEndpointMiddleware(authenticator Authenticator) endpoint.Middleware {
+	required := NewEndpointMiddleware(authenticator)
+
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		authenticated := required(next)
+
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			if TokenFromContext(ctx) == "" {
+				return next(ctx, request)
+			}
+			return authenticated(ctx, request)
+		}
+	}
+}
+
+// NewScopeMiddleware reject principal not granted scope,
+// it must be chained after authentication
+func NewScopeMiddleware(scope string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			principal, err := Authenticated(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if !principal.HasScope(scope) {
+				return nil, errors.Wrapf(errors.ErrForbidden, "subject=%v is not granted scope=%v", principal.UserID, scope)
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// NewAdminMiddleware reject principal not allowed to use administrative api,
+// iam:admin scope is required and user must be granted admin permission as well
+// while client authenticated by client_credentials is checked by scope only,
+// it must be chained after authentication
+func NewAdminMiddleware(checker PermissionChecker) endpoint.Middleware {
+	scope := NewScopeMiddleware(oidc.ScopeAdmin)
+
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return scope(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			principal, err := Authenticated(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if !principal.IsClient() {
+				allowed, err := checker.CheckPermission(ctx, principal.UserID, PermissionAdmin)
+				if err != nil {
+					return nil, err
+				}
+				if !allowed {
+					return nil, errors.Wrapf(errors.ErrForbidden, "user=%v is not granted permission=%v", principal.UserID, PermissionAdmin)
+				}
+			}
+
+			return next(ctx, request)
+		})
+	}
+}
+
+// NewClientScopeMiddleware reject caller other than client acting on behalf of itself
+// granted scope, it guard rpc called by other services and must be chained after authentication
+func NewClientScopeMiddleware(scope string) endpoint.Middleware {
+	scoped := NewScopeMiddleware(scope)
+
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return scoped(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			principal, err := Authenticated(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if !principal.IsClient() {
+				return nil, errors.Wrapf(errors.ErrForbidden, "user=%v can not call rpc of scope=%v, only client can", principal.UserID, scope)
+			}
+
+			return next(ctx, request)
+		})
+	}
+}
+
+// NewIntrospectionMiddleware reject caller other than client granted iam:introspect scope,
+// RFC 7662 section 2.1 require protected resource to authenticate before introspecting token.
+// it must be chained after authentication
+func NewIntrospectionMiddleware() endpoint.Middleware {
+	return NewClientScopeMiddleware(oidc.ScopeIntrospect)
+}
@@ -0,0 +1,21 @@
+// Package clientip carry ip address of client in context.
+// It is put by echo middleware from connection or trusted proxy header and by grpc interceptor
+// from peer, address supplied in request body must never be used for security decision.
+package clientip
+
+import (
+	"context"
+)
+
+type clientIPKey struct{}
+
+// NewContext return context carry ip address of client
+func NewContext(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// FromContext read ip address put by NewContext, empty is returned when absent
+func FromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
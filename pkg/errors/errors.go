@@ -12,8 +12,9 @@ var (
 	ErrUnauthorized       = &Exception{Code: 401001, Message: "The request unauthorized", Status: http.StatusUnauthorized, GRPCCode: codes.PermissionDenied}
 	ErrForbidden          = &Exception{Code: 403001, Message: "Forbidden.", Status: http.StatusForbidden, GRPCCode: codes.PermissionDenied}
 	ErrPageNotFound       = &Exception{Code: 404001, Message: "Page not found.", Status: http.StatusNotFound, GRPCCode: codes.NotFound}
-	ErrResourceNotFound   = &Exception{Code: 404002, Message: "The specified resource does not exist.", Status: http.StatusNotFound}
+	ErrResourceNotFound   = &Exception{Code: 404002, Message: "The specified resource does not exist.", Status: http.StatusNotFound, GRPCCode: codes.NotFound}
 	ErrConflict           = &Exception{Code: 409001, Message: "The request conflict.", Status: http.StatusConflict, GRPCCode: codes.AlreadyExists}
-	ErrTooManyRequests    = &Exception{Code: 429001, Message: "Too Many Requests", Status: http.StatusTooManyRequests, GRPCCode: codes.PermissionDenied}
+	ErrPreconditionFailed = &Exception{Code: 412001, Message: "The condition specified in the request is not met.", Status: http.StatusPreconditionFailed, GRPCCode: codes.FailedPrecondition}
+	ErrTooManyRequests    = &Exception{Code: 429001, Message: "Too Many Requests", Status: http.StatusTooManyRequests, GRPCCode: codes.ResourceExhausted}
 	ErrInternal           = &Exception{Code: 500001, Message: "Serve occur error.", Status: http.StatusInternalServerError, GRPCCode: codes.Internal}
 )
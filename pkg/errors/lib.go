@@ -3,9 +3,11 @@ package errors
 import (
 	"context"
 	"encoding/json"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
@@ -122,6 +124,38 @@ func (e *Exception) WithDetails(details ...Detail) *Exception {
 	return &newErr
 }
 
+// DetailTypeRetryInfo detail type tell client when to retry
+const DetailTypeRetryInfo = "RetryInfo"
+
+// WithRetryAfter set retry info detail, duration is rounded up to second
+func (e *Exception) WithRetryAfter(d time.Duration) *Exception {
+	seconds := int64((d + time.Second - 1) / time.Second)
+	return e.WithDetails(Detail{
+		Type:     DetailTypeRetryInfo,
+		Metadata: map[string]interface{}{"retry_after": seconds},
+	})
+}
+
+// RetryAfter duration of retry info detail, 0 if there is none
+func (e *Exception) RetryAfter() time.Duration {
+	for _, detail := range e.Details {
+		if detail.Type != DetailTypeRetryInfo {
+			continue
+		}
+		seconds, ok := detail.Metadata["retry_after"].(int64)
+		if ok {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 0
+}
+
+// RetryAfterSeconds format duration as delay-seconds of Retry-After header,
+// partial second is rounded up so client never retry too early
+func RetryAfterSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
+
 type View struct {
 	Code    int      `json:"code"`
 	Info    string   `json:"info"`
@@ -151,6 +185,9 @@ func ErrorResponse(ctx context.Context, err error, w http.ResponseWriter) {
 	switch val := Cause(err).(type) {
 	case *Exception:
 		code, response = val.ToViewModel()
+		if retryAfter := val.RetryAfter(); retryAfter > 0 {
+			w.Header().Set("Retry-After", RetryAfterSeconds(retryAfter))
+		}
 	default:
 		code, response = New(err.Error()).ToViewModel()
 	}
@@ -0,0 +1,22 @@
+package federation
+
+// Config for upstream identity providers
+type Config struct {
+	Providers []ProviderConfig `mapstructure:"providers"`
+}
+
+// ProviderConfig for one upstream OpenID provider
+type ProviderConfig struct {
+	// Name identify provider in path and is recorded as idp provider of session, e.g. google
+	Name string `mapstructure:"name"`
+	// Issuer of provider, metadata is discovered from {issuer}/.well-known/openid-configuration
+	Issuer string `mapstructure:"issuer"`
+	// ClientID and ClientSecret registered at provider,
+	// client secret is sent by client_secret_basic, keep empty for public client
+	ClientID     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"client_secret"`
+	// RedirectURL receive authorization response, it must be registered at provider
+	RedirectURL string `mapstructure:"redirect_url"`
+	// Scopes requested, default is openid, profile and email
+	Scopes []string `mapstructure:"scopes"`
+}
@@ -0,0 +1,391 @@
+package federation
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+const (
+	// Timeout of each request to provider
+	Timeout = 10 * time.Second
+	// StateLifetime how long user can complete signin at provider
+	StateLifetime = 10 * 60 * time.Second
+
+	// randomSize bytes of state, nonce and code verifier
+	randomSize = 32
+	// maxResponseSize limit body read from provider
+	maxResponseSize = 1 << 20
+
+	discoveryPath = "/.well-known/openid-configuration"
+)
+
+// Federation hold configured upstream providers
+type Federation struct {
+	providers map[string]*Provider
+}
+
+// New new federation from config, nil federation has no provider
+func New(config Config) *Federation {
+	client := &http.Client{Timeout: Timeout}
+
+	f := &Federation{providers: make(map[string]*Provider)}
+	for _, c := range config.Providers {
+		f.providers[c.Name] = NewProvider(c, client)
+	}
+	return f
+}
+
+// Provider find provider by name
+func (f *Federation) Provider(name string) (*Provider, error) {
+	if f == nil {
+		return nil, errors.Wrapf(errors.ErrResourceNotFound, "federation: provider=%v not configured", name)
+	}
+
+	p, ok := f.providers[name]
+	if !ok {
+		return nil, errors.Wrapf(errors.ErrResourceNotFound, "federation: provider=%v not configured", name)
+	}
+	return p, nil
+}
+
+// Token is token response of provider
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	IDToken      string `json:"id_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in,omitempty"`
+
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// Claims of ID token issued by provider
+type Claims struct {
+	jwt.RegisteredClaims
+	Nonce             string `json:"nonce,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     bool   `json:"email_verified,omitempty"`
+	Name              string `json:"name,omitempty"`
+	GivenName         string `json:"given_name,omitempty"`
+	FamilyName        string `json:"family_name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+}
+
+// Provider is relying party of one upstream OpenID provider,
+// metadata and keys are fetched lazily and cached
+type Provider struct {
+	config ProviderConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *oidc.Discovery
+	keys      map[string]*keys.Key
+}
+
+// NewProvider new provider
+func NewProvider(config ProviderConfig, client *http.Client) *Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail}
+	}
+	return &Provider{
+		config: config,
+		client: client,
+		keys:   make(map[string]*keys.Key),
+	}
+}
+
+// Name of provider
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// AuthCodeURL build authorization request user agent is redirected to,
+// code verifier is kept by caller and sent on Exchange as PKCE required
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, verifier string) (string, error) {
+	discovery, err := p.Discovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.Wrapf(errors.ErrInternal, "federation: provider=%v invalid authorization endpoint err %v", p.config.Name, err)
+	}
+
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// Exchange exchange authorization code for token at token endpoint
+func (p *Provider) Exchange(ctx context.Context, code string, verifier string) (*Token, error) {
+	discovery, err := p.Discovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", verifier)
+	form.Set("client_id", p.config.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "federation: provider=%v build token request err %v", p.config.Name, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		// RFC 6749 section 2.3.1 credentials are form encoded before basic auth
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var token Token
+	status, err := p.do(req, &token)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK || token.Error != "" {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"federation: provider=%v token endpoint responded status=%v error=%v description=%v",
+			p.config.Name, status, token.Error, token.ErrorDescription,
+		)
+	}
+	if token.IDToken == "" {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "federation: provider=%v token response has no id_token", p.config.Name)
+	}
+
+	return &token, nil
+}
+
+// VerifyIDToken verify signature by provider JWKS, then issuer, audience, expiry and nonce
+func (p *Provider) VerifyIDToken(ctx context.Context, raw string, nonce string) (*Claims, error) {
+	discovery, err := p.Discovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	_, err = jwt.ParseWithClaims(raw, &claims, func(token *jwt.Token) (interface{}, error) {
+		return p.keyfunc(ctx, token)
+	})
+	if err != nil {
+		// error of keyfunc is wrapped by jwt, keep internal error when provider is unreachable
+		var verr *jwt.ValidationError
+		if errors.As(err, &verr) && errors.Is(verr.Inner, errors.ErrInternal) {
+			return nil, verr.Inner
+		}
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "federation: provider=%v invalid id token err %v", p.config.Name, err)
+	}
+
+	if !claims.VerifyIssuer(discovery.Issuer, true) {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "federation: provider=%v id token iss=%v not match", p.config.Name, claims.Issuer)
+	}
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "federation: provider=%v id token aud=%v not include client", p.config.Name, claims.Audience)
+	}
+	if claims.ExpiresAt == nil {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "federation: provider=%v id token has no exp", p.config.Name)
+	}
+	if claims.Subject == "" {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "federation: provider=%v id token has no sub", p.config.Name)
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "federation: provider=%v id token nonce not match", p.config.Name)
+	}
+
+	return &claims, nil
+}
+
+// Authenticate exchange code and verify ID token of response
+func (p *Provider) Authenticate(ctx context.Context, code string, verifier string, nonce string) (*Claims, error) {
+	token, err := p.Exchange(ctx, code, verifier)
+	if err != nil {
+		return nil, err
+	}
+	return p.VerifyIDToken(ctx, token.IDToken, nonce)
+}
+
+// Discovery fetch provider metadata once, issuer of metadata must be configured issuer
+func (p *Provider) Discovery(ctx context.Context) (*oidc.Discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	issuer := strings.TrimSuffix(p.config.Issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+discoveryPath, nil)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "federation: provider=%v build discovery request err %v", p.config.Name, err)
+	}
+
+	var discovery oidc.Discovery
+	status, err := p.do(req, &discovery)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.Wrapf(errors.ErrInternal, "federation: provider=%v discovery responded status=%v", p.config.Name, status)
+	}
+	// OpenID Connect Discovery 1.0 section 4.3
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, errors.Wrapf(errors.ErrInternal, "federation: provider=%v discovery issuer=%v not match", p.config.Name, discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.Wrapf(errors.ErrInternal, "federation: provider=%v discovery missing endpoint", p.config.Name)
+	}
+
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+// keyfunc lookup key by kid, keys are fetched again when kid is unknown
+// so key rotation of provider is picked up
+func (p *Provider) keyfunc(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, err := p.key(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	// same as keys.KeyManager, token must be signed by the algorithm of key
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"federation: token alg=%v not match key alg=%v",
+			token.Method.Alg(), key.Method.Alg(),
+		)
+	}
+
+	return key.PublicKey, nil
+}
+
+func (p *Provider) key(ctx context.Context, kid string) (*keys.Key, error) {
+	p.mu.Lock()
+	key, ok := p.keys[kid]
+	p.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	if err := p.fetchKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, ok = p.keys[kid]
+	if !ok {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "federation: provider=%v unknown key id=%v", p.config.Name, kid)
+	}
+	return key, nil
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) error {
+	discovery, err := p.Discovery(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JWKSURI, nil)
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "federation: provider=%v build jwks request err %v", p.config.Name, err)
+	}
+
+	var jwks keys.JSONWebKeySet
+	status, err := p.do(req, &jwks)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return errors.Wrapf(errors.ErrInternal, "federation: provider=%v jwks responded status=%v", p.config.Name, status)
+	}
+
+	fetched := make(map[string]*keys.Key)
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// key provider can not parse is skipped, other keys still can be used
+		key, err := keys.ParseJSONWebKey(jwk)
+		if err != nil {
+			continue
+		}
+		fetched[key.ID] = key
+	}
+
+	p.mu.Lock()
+	p.keys = fetched
+	p.mu.Unlock()
+
+	return nil
+}
+
+// do send request and decode json body, status is returned for caller to decide
+func (p *Provider) do(req *http.Request, v interface{}) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrapf(errors.ErrInternal, "federation: provider=%v request %v err %v", p.config.Name, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, errors.Wrapf(errors.ErrInternal, "federation: provider=%v read %v err %v", p.config.Name, req.URL.Path, err)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return 0, errors.Wrapf(errors.ErrInternal, "federation: provider=%v decode %v err %v", p.config.Name, req.URL.Path, err)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// NewRandom new random string used as state, nonce and PKCE code verifier
+func NewRandom() (string, error) {
+	b := make([]byte, randomSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrapf(errors.ErrInternal, "federation: failed to generate random err %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge derive S256 code challenge from code verifier, see RFC 7636 section 4.2
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
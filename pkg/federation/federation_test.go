@@ -0,0 +1,178 @@
+package federation_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/federation"
+	"github.com/karta0898098/iam/pkg/federation/federationtest"
+)
+
+const redirectURL = "http://localhost:3000/callback"
+
+func TestProvider_Authenticate(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(claims jwt.MapClaims)
+		secret   string
+		verifier func(verifier string) string
+		nonce    func(nonce string) string
+		err      error
+	}{
+		{
+			name: "Success",
+			err:  nil,
+		},
+		{
+			name: "Nonce Not Match",
+			nonce: func(nonce string) string {
+				return "MOCK-NONCE"
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name: "Code Verifier Not Match",
+			verifier: func(verifier string) string {
+				return verifier + "X"
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name:   "Invalid Client Secret",
+			secret: "MOCK-WRONG-SECRET",
+			err:    errors.ErrUnauthorized,
+		},
+		{
+			name: "Audience Not Match",
+			mutate: func(claims jwt.MapClaims) {
+				claims["aud"] = "MOCK-OTHER-CLIENT"
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name: "Issuer Not Match",
+			mutate: func(claims jwt.MapClaims) {
+				claims["iss"] = "https://evil.example.com"
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name: "Expired",
+			mutate: func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+			},
+			err: errors.ErrUnauthorized,
+		},
+		{
+			name: "No Subject",
+			mutate: func(claims jwt.MapClaims) {
+				delete(claims, "sub")
+			},
+			err: errors.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := federationtest.NewProvider("MOCK-CLIENT-ID", "MOCK-CLIENT-SECRET")
+			defer op.Close()
+			op.Mutate = tt.mutate
+
+			config := op.Config("mock", redirectURL)
+			if tt.secret != "" {
+				config.ClientSecret = tt.secret
+			}
+			f := federation.New(federation.Config{Providers: []federation.ProviderConfig{config}})
+
+			p, err := f.Provider("mock")
+			assert.NoError(t, err)
+
+			state, _ := federation.NewRandom()
+			nonce, _ := federation.NewRandom()
+			verifier, _ := federation.NewRandom()
+
+			authURL, err := p.AuthCodeURL(context.Background(), state, nonce, verifier)
+			assert.NoError(t, err)
+
+			u, _ := url.Parse(authURL)
+			assert.Equal(t, op.Issuer()+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+			assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+			assert.Equal(t, "openid profile email", u.Query().Get("scope"))
+
+			code, returnedState := op.Authorize(authURL)
+			assert.Equal(t, state, returnedState)
+
+			if tt.verifier != nil {
+				verifier = tt.verifier(verifier)
+			}
+			if tt.nonce != nil {
+				nonce = tt.nonce(nonce)
+			}
+
+			claims, err := p.Authenticate(context.Background(), code, verifier, nonce)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, op.User.Subject, claims.Subject)
+			assert.Equal(t, op.User.Email, claims.Email)
+			assert.True(t, claims.EmailVerified)
+
+			// authorization code is single use
+			_, err = p.Authenticate(context.Background(), code, verifier, nonce)
+			assert.True(t, errors.Is(err, errors.ErrUnauthorized))
+		})
+	}
+}
+
+func TestProvider_KeyRotation(t *testing.T) {
+	op := federationtest.NewProvider("MOCK-CLIENT-ID", "")
+	defer op.Close()
+
+	p := federation.NewProvider(op.Config("mock", redirectURL), op.Server.Client())
+
+	authenticate := func() error {
+		nonce, _ := federation.NewRandom()
+		verifier, _ := federation.NewRandom()
+
+		authURL, err := p.AuthCodeURL(context.Background(), "MOCK-STATE", nonce, verifier)
+		assert.NoError(t, err)
+
+		code, _ := op.Authorize(authURL)
+		_, err = p.Authenticate(context.Background(), code, verifier, nonce)
+		return err
+	}
+
+	assert.NoError(t, authenticate())
+
+	// unknown kid make provider fetch jwks again
+	op.Rotate()
+	assert.NoError(t, authenticate())
+}
+
+func TestFederation_Provider(t *testing.T) {
+	_, err := federation.New(federation.Config{}).Provider("mock")
+	assert.True(t, errors.Is(err, errors.ErrResourceNotFound))
+
+	var f *federation.Federation
+	_, err = f.Provider("mock")
+	assert.True(t, errors.Is(err, errors.ErrResourceNotFound))
+}
+
+func TestProvider_DiscoveryIssuerNotMatch(t *testing.T) {
+	op := federationtest.NewProvider("MOCK-CLIENT-ID", "")
+	defer op.Close()
+
+	config := op.Config("mock", redirectURL)
+	config.Issuer = op.Issuer() + "/tenant"
+
+	p := federation.NewProvider(config, op.Server.Client())
+	_, err := p.AuthCodeURL(context.Background(), "MOCK-STATE", "MOCK-NONCE", "MOCK-VERIFIER")
+	assert.True(t, errors.Is(err, errors.ErrInternal))
+}
@@ -0,0 +1,245 @@
+// Package federationtest provide stand-in OpenID provider for testing federation
+package federationtest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/xid"
+
+	"github.com/karta0898098/iam/pkg/federation"
+	"github.com/karta0898098/iam/pkg/keys"
+	"github.com/karta0898098/iam/pkg/oidc"
+)
+
+// User signed in at provider when authorization request is approved
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// grant is authorization code issued to client
+type grant struct {
+	user          User
+	nonce         string
+	redirectURI   string
+	codeChallenge string
+}
+
+// Provider is OpenID provider serving discovery, jwks and token endpoint by httptest server,
+// authorization endpoint is simulated by Authorize since it needs user agent
+type Provider struct {
+	Server       *httptest.Server
+	ClientID     string
+	ClientSecret string
+	// User approve next authorization request
+	User User
+	// Mutate change claims of ID token before sign, used to forge invalid token
+	Mutate func(claims jwt.MapClaims)
+
+	mu     sync.Mutex
+	key    *keys.Key
+	grants map[string]grant
+}
+
+// NewProvider start provider, Close must be called when test done
+func NewProvider(clientID string, clientSecret string) *Provider {
+	p := &Provider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		User: User{
+			Subject:       "MOCK-EXTERNAL-SUBJECT",
+			Email:         "federated@example.com",
+			EmailVerified: true,
+			Name:          "Federated User",
+		},
+		grants: make(map[string]grant),
+	}
+	p.Rotate()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/.well-known/jwks.json", p.jwks)
+	mux.HandleFunc("/token", p.token)
+	p.Server = httptest.NewServer(mux)
+
+	return p
+}
+
+// Close shutdown server
+func (p *Provider) Close() {
+	p.Server.Close()
+}
+
+// Issuer of provider
+func (p *Provider) Issuer() string {
+	return p.Server.URL
+}
+
+// Config of relying party registered at provider
+func (p *Provider) Config(name string, redirectURL string) federation.ProviderConfig {
+	return federation.ProviderConfig{
+		Name:         name,
+		Issuer:       p.Issuer(),
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURL:  redirectURL,
+	}
+}
+
+// Rotate replace signing key, token signed before can no longer be verified
+func (p *Provider) Rotate() {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.key = &keys.Key{
+		ID:         xid.New().String(),
+		Method:     jwt.SigningMethodES256,
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.Public(),
+	}
+}
+
+// Authorize approve authorization request as User,
+// it returns code and state which are delivered to redirect uri
+func (p *Provider) Authorize(authURL string) (code string, state string) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		panic(err)
+	}
+	query := u.Query()
+
+	code = xid.New().String()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.grants[code] = grant{
+		user:          p.User,
+		nonce:         query.Get("nonce"),
+		redirectURI:   query.Get("redirect_uri"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+
+	return code, query.Get("state")
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, &oidc.Discovery{
+		Issuer:                           p.Issuer(),
+		AuthorizationEndpoint:            p.Issuer() + "/authorize",
+		TokenEndpoint:                    p.Issuer() + "/token",
+		JWKSURI:                          p.Issuer() + "/.well-known/jwks.json",
+		ResponseTypesSupported:           []string{"code"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{jwt.SigningMethodES256.Alg()},
+		CodeChallengeMethodsSupported:    []string{"S256"},
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, _ *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, &keys.JSONWebKeySet{Keys: []keys.JSONWebKey{keys.NewJSONWebKey(p.key)}})
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, "invalid_request")
+		return
+	}
+
+	// public client has no secret and identify itself by client_id parameter
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID = r.PostForm.Get("client_id")
+	}
+	if clientID != p.ClientID || clientSecret != p.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	g, ok := p.grants[r.PostForm.Get("code")]
+	delete(p.grants, r.PostForm.Get("code"))
+	key := p.key
+	p.mu.Unlock()
+
+	if r.PostForm.Get("grant_type") != "authorization_code" || !ok {
+		writeError(w, "invalid_grant")
+		return
+	}
+	if r.PostForm.Get("redirect_uri") != g.redirectURI || federation.CodeChallenge(r.PostForm.Get("code_verifier")) != g.codeChallenge {
+		writeError(w, "invalid_grant")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.Issuer(),
+		"sub":            g.user.Subject,
+		"aud":            p.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          g.nonce,
+		"email":          g.user.Email,
+		"email_verified": g.user.EmailVerified,
+		"name":           g.user.Name,
+	}
+	if p.Mutate != nil {
+		p.Mutate(claims)
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	idToken, err := token.SignedString(key.PrivateKey)
+	if err != nil {
+		writeError(w, "server_error")
+		return
+	}
+
+	accessToken := make([]byte, 16)
+	_, _ = rand.Read(accessToken)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": base64.RawURLEncoding.EncodeToString(accessToken),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func writeError(w http.ResponseWriter, code string) {
+	status := http.StatusBadRequest
+	if strings.HasPrefix(code, "server") {
+		status = http.StatusInternalServerError
+	}
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
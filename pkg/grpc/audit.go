@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/karta0898098/iam/pkg/audit"
+)
+
+// UnaryServerAuditInterceptor put peer ip address and user agent of caller into context for audit log
+func UnaryServerAuditInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		client := audit.Client{
+			IPAddress: peerIP(ctx),
+		}
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if userAgent := md.Get("user-agent"); len(userAgent) > 0 {
+				client.UserAgent = userAgent[0]
+			}
+		}
+
+		return handler(audit.NewContext(ctx, client), req)
+	}
+}
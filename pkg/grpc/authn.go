@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/karta0898098/iam/pkg/authn"
+)
+
+// UnaryServerAuthnInterceptor authenticate bearer token of authorization metadata
+// and put principal into context, only listed full methods are authenticated,
+// every method is authenticated when none listed
+func UnaryServerAuthnInterceptor(authenticator authn.Authenticator, methods ...string) grpc.UnaryServerInterceptor {
+	protected := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		protected[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if len(protected) > 0 && !protected[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		ctx, err = authn.Authenticate(ctx, authenticator, incomingBearer(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// incomingBearer read bearer token of incoming authorization metadata
+func incomingBearer(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, authorization := range md.Get("authorization") {
+		if token := authn.ParseBearer(authorization); token != "" {
+			return token
+		}
+	}
+	return ""
+}
@@ -0,0 +1,16 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/karta0898098/iam/pkg/clientip"
+)
+
+// UnaryServerClientIPInterceptor put peer ip address into context
+func UnaryServerClientIPInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		return handler(clientip.NewContext(ctx, peerIP(ctx)), req)
+	}
+}
@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// UnaryServerErrorInterceptor convert exception to grpc status,
+// retry info is sent as retry-after header in seconds
+func UnaryServerErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		resp, err = handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		exception := errors.TryConvert(err)
+		if exception == nil {
+			return resp, err
+		}
+
+		if retryAfter := exception.RetryAfter(); retryAfter > 0 {
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", errors.RetryAfterSeconds(retryAfter)))
+		}
+
+		code := exception.GRPCCode
+		if code == codes.OK {
+			code = codes.Unknown
+		}
+		return resp, status.Error(code, exception.Message)
+	}
+}
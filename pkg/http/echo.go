@@ -1,8 +1,11 @@
 package http
 
 import (
+	"net"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 
 	"github.com/karta0898098/iam/pkg/errors"
 )
@@ -10,6 +13,9 @@ import (
 type Config struct {
 	Mode string `mapstructure:"mode"`
 	Port string `mapstructure:"port"`
+	// TrustedProxies CIDR of reverse proxies whose X-Forwarded-For is trusted,
+	// ip address of connection is used when it is empty
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 // NewEcho http handler
@@ -30,10 +36,34 @@ func NewEcho(config Config) *echo.Echo {
 		e.HidePort = false
 	}
 
+	e.IPExtractor = NewIPExtractor(config.TrustedProxies)
 	e.HTTPErrorHandler = EchoErrorHandler
 	return e
 }
 
+// NewIPExtractor read client ip address from X-Forwarded-For appended by trusted proxies,
+// header is ignored and connection is used when no proxy is trusted
+func NewIPExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range trustedProxies {
+		_, block, err := net.ParseCIDR(proxy)
+		if err != nil {
+			log.Warn().Msgf("trusted proxy=%v is not CIDR and is ignored", proxy)
+			continue
+		}
+		options = append(options, echo.TrustIPRange(block))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
 // EchoErrorHandler error handle for echo
 func EchoErrorHandler(err error, c echo.Context) {
 	if err == nil {
@@ -48,6 +78,9 @@ func EchoErrorHandler(err error, c echo.Context) {
 
 	e := errors.TryConvert(err)
 	if e != nil {
+		if retryAfter := e.RetryAfter(); retryAfter > 0 {
+			c.Response().Header().Set("Retry-After", errors.RetryAfterSeconds(retryAfter))
+		}
 
 		code, resp := e.ToViewModel()
 		_ = c.JSON(code, resp)
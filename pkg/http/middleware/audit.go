@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/audit"
+)
+
+// NewAuditMiddleware put ip address and user agent of caller into context for audit log
+func NewAuditMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			ctx := audit.NewContext(req.Context(), audit.Client{
+				IPAddress: c.RealIP(),
+				UserAgent: req.UserAgent(),
+			})
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/authn"
+)
+
+// NewAuthnMiddleware authenticate bearer token of authorization header
+// and put principal into request context, use authn.FromContext to read it
+func NewAuthnMiddleware(authenticator authn.Authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			ctx, err := authn.Authenticate(
+				req.Context(),
+				authenticator,
+				authn.ParseBearer(req.Header.Get(echo.HeaderAuthorization)),
+			)
+			if err != nil {
+				return err
+			}
+
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
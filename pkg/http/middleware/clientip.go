@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/clientip"
+)
+
+// NewClientIPMiddleware put ip address of client into context,
+// it is read by echo IPExtractor so proxy header is only trusted from configured proxies
+func NewClientIPMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(clientip.NewContext(req.Context(), c.RealIP())))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/karta0898098/iam/pkg/ratelimit"
+)
+
+// NewRateLimitMiddleware limit requests of route "METHOD /path" per ip address,
+// client id of basic authorization is counted too
+func NewRateLimitMiddleware(limiter *ratelimit.Limiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			keys := ratelimit.Keys{
+				ratelimit.KeyIP: c.RealIP(),
+			}
+			if clientID, _, ok := req.BasicAuth(); ok {
+				keys[ratelimit.KeyClientID] = clientID
+			}
+
+			err := limiter.Allow(req.Context(), req.Method+" "+c.Path(), keys)
+			if err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
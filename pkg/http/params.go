@@ -0,0 +1,39 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type pathParamsKey struct{}
+
+// WrapHandler wraps http.Handler into echo.HandlerFunc like echo.WrapHandler,
+// and carry echo path parameters into request context
+func WrapHandler(h http.Handler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		names := c.ParamNames()
+		values := c.ParamValues()
+
+		params := make(map[string]string, len(names))
+		for i, name := range names {
+			if i < len(values) {
+				params[name] = values[i]
+			}
+		}
+
+		ctx := context.WithValue(c.Request().Context(), pathParamsKey{}, params)
+		h.ServeHTTP(c.Response(), c.Request().WithContext(ctx))
+		return nil
+	}
+}
+
+// PathParam read path parameter carried by WrapHandler
+func PathParam(r *http.Request, name string) string {
+	params, ok := r.Context().Value(pathParamsKey{}).(map[string]string)
+	if !ok {
+		return ""
+	}
+	return params[name]
+}
@@ -0,0 +1,24 @@
+package keys
+
+// Config for key manager load signing keys
+type Config struct {
+	// Active is the kid of key used to sign new token,
+	// other keys are only used to verify token signed before rotation
+	Active string `mapstructure:"active"`
+	// Keys all keys can be used to verify token
+	Keys []KeyConfig `mapstructure:"keys"`
+	// Ephemeral allow generating key at startup when keys are empty, only for local development
+	// since token signed by ephemeral key can not be verified after restart or by other replica
+	Ephemeral bool `mapstructure:"ephemeral"`
+}
+
+// KeyConfig define where to load key
+// only one of PEM or File should be set
+type KeyConfig struct {
+	// ID unique key id put into token kid header
+	ID string `mapstructure:"id"`
+	// PEM inline PEM encoded private or public key
+	PEM string `mapstructure:"pem"`
+	// File path of PEM encoded private or public key
+	File string `mapstructure:"file"`
+}
@@ -0,0 +1,196 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// JSONWebKeySet define RFC 7517 JWK set
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey define RFC 7517 public JWK
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+
+	// RSA public key
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC or OKP public key
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// NewJSONWebKey convert public part of key to JWK
+func NewJSONWebKey(key *Key) JSONWebKey {
+	jwk := JSONWebKey{
+		Kid: key.ID,
+		Use: "sig",
+		Alg: key.Method.Alg(),
+	}
+
+	switch k := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encode(k.N.Bytes())
+		jwk.E = encode(big.NewInt(int64(k.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = k.Curve.Params().Name
+		jwk.X = encode(padding(k.X.Bytes(), size))
+		jwk.Y = encode(padding(k.Y.Bytes(), size))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encode(k)
+	}
+
+	return jwk
+}
+
+// ParseJSONWebKey convert public JWK to verification key,
+// it is the reverse of NewJSONWebKey and used to verify token issued by other party
+func ParseJSONWebKey(jwk JSONWebKey) (*Key, error) {
+	var (
+		parsed interface{}
+		err    error
+	)
+
+	switch jwk.Kty {
+	case "RSA":
+		parsed, err = parseRSA(jwk)
+	case "EC":
+		parsed, err = parseEC(jwk)
+	case "OKP":
+		parsed, err = parseOKP(jwk)
+	default:
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: unsupported jwk kty=%v", jwk.Kty)
+	}
+	if err != nil {
+		return nil, errors.WithMessagef(err, "keys: jwk kid=%v", jwk.Kid)
+	}
+
+	key, err := newKey(parsed)
+	if err != nil {
+		return nil, err
+	}
+	key.ID = jwk.Kid
+
+	// RSA key can sign with different hash, alg of jwk tell which one is used
+	if jwk.Kty == "RSA" && jwk.Alg != "" {
+		method := jwt.GetSigningMethod(jwk.Alg)
+		if method == nil || !(strings.HasPrefix(jwk.Alg, "RS") || strings.HasPrefix(jwk.Alg, "PS")) {
+			return nil, errors.Wrapf(errors.ErrInternal, "keys: jwk kid=%v alg=%v not match kty=RSA", jwk.Kid, jwk.Alg)
+		}
+		key.Method = method
+	}
+
+	return key, nil
+}
+
+func parseRSA(jwk JSONWebKey) (*rsa.PublicKey, error) {
+	n, err := decode(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decode(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+		return nil, errors.Wrap(errors.ErrInternal, "keys: invalid rsa modulus or exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+func parseEC(jwk JSONWebKey) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch jwk.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: unsupported curve %v", jwk.Crv)
+	}
+
+	x, err := decode(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decode(jwk.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+	if !curve.IsOnCurve(key.X, key.Y) {
+		return nil, errors.Wrap(errors.ErrInternal, "keys: ec point is not on curve")
+	}
+
+	return key, nil
+}
+
+func parseOKP(jwk JSONWebKey) (ed25519.PublicKey, error) {
+	if jwk.Crv != "Ed25519" {
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: unsupported curve %v", jwk.Crv)
+	}
+
+	x, err := decode(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+	if len(x) != ed25519.PublicKeySize {
+		return nil, errors.Wrap(errors.ErrInternal, "keys: invalid ed25519 public key size")
+	}
+
+	return ed25519.PublicKey(x), nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: invalid base64url value err %v", err)
+	}
+	return b, nil
+}
+
+// padding left pad coordinate to curve size as RFC 7518 section 6.2.1.2 required
+func padding(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
@@ -0,0 +1,267 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// KeyManager manage asymmetric keys for signing and verifying token
+type KeyManager interface {
+	// Sign sign claims with active key, token header carry kid of the key
+	Sign(claims jwt.Claims) (token string, err error)
+
+	// Keyfunc lookup verification key by token kid header,
+	// it is used as jwt.Keyfunc when parsing token
+	Keyfunc(token *jwt.Token) (key interface{}, err error)
+
+	// JWKS public keys can be used to verify token
+	JWKS() *JSONWebKeySet
+}
+
+// Key define a loaded key
+type Key struct {
+	// ID key id
+	ID string
+	// Method signing method decided by key type
+	Method jwt.SigningMethod
+	// PrivateKey is nil when key is kept for verification only
+	PrivateKey crypto.Signer
+	// PublicKey used to verify token
+	PublicKey crypto.PublicKey
+}
+
+// manager implement for KeyManager
+type manager struct {
+	active *Key
+	keys   map[string]*Key
+	jwks   *JSONWebKeySet
+}
+
+// NewKeyManager load keys from config
+// when no key is configured an ephemeral key is generated only if config allow it,
+// token signed by ephemeral key can not be verified after restart
+func NewKeyManager(config Config) (KeyManager, error) {
+	m := &manager{
+		keys: make(map[string]*Key),
+		jwks: &JSONWebKeySet{Keys: make([]JSONWebKey, 0)},
+	}
+
+	if len(config.Keys) == 0 {
+		if !config.Ephemeral {
+			return nil, errors.Wrap(errors.ErrInternal, "keys: no signing key configured")
+		}
+
+		key, err := generateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		log.Warn().Msgf("keys: no signing key configured, use ephemeral key kid=%v", key.ID)
+
+		m.add(key)
+		m.active = key
+		return m, nil
+	}
+
+	for _, c := range config.Keys {
+		key, err := loadKey(c)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := m.keys[key.ID]; ok {
+			return nil, errors.Wrapf(errors.ErrInternal, "keys: duplicate key id=%v", key.ID)
+		}
+
+		m.add(key)
+	}
+
+	active, ok := m.keys[config.Active]
+	if !ok {
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: active key id=%v not configured", config.Active)
+	}
+
+	if active.PrivateKey == nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: active key id=%v has no private key", config.Active)
+	}
+
+	m.active = active
+
+	return m, nil
+}
+
+func (m *manager) add(key *Key) {
+	m.keys[key.ID] = key
+	m.jwks.Keys = append(m.jwks.Keys, NewJSONWebKey(key))
+}
+
+// Sign is KeyManager implement
+func (m *manager) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(m.active.Method, claims)
+	token.Header["kid"] = m.active.ID
+
+	signed, err := token.SignedString(m.active.PrivateKey)
+	if err != nil {
+		return "", errors.Wrapf(errors.ErrInternal, "keys: failed to sign token with kid=%v err %v", m.active.ID, err)
+	}
+
+	return signed, nil
+}
+
+// Keyfunc is KeyManager implement
+func (m *manager) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := m.keys[kid]
+	if !ok {
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "keys: unknown key id=%v", kid)
+	}
+
+	// token must be signed by the algorithm of key,
+	// otherwise attacker can forge token with other algorithm
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"keys: token alg=%v not match key alg=%v",
+			token.Method.Alg(), key.Method.Alg(),
+		)
+	}
+
+	return key.PublicKey, nil
+}
+
+// JWKS is KeyManager implement
+func (m *manager) JWKS() *JSONWebKeySet {
+	return m.jwks
+}
+
+// loadKey read PEM from config and parse it
+func loadKey(config KeyConfig) (*Key, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if config.ID == "" {
+		return nil, errors.Wrap(errors.ErrInternal, "keys: key id is empty")
+	}
+
+	switch {
+	case config.PEM != "":
+		data = []byte(config.PEM)
+	case config.File != "":
+		data, err = os.ReadFile(config.File)
+		if err != nil {
+			return nil, errors.Wrapf(errors.ErrInternal, "keys: failed to read key id=%v file=%v err %v", config.ID, config.File, err)
+		}
+	default:
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: key id=%v has no pem or file", config.ID)
+	}
+
+	key, err := ParsePEM(data)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "keys: key id=%v", config.ID)
+	}
+	key.ID = config.ID
+
+	return key, nil
+}
+
+// ParsePEM parse PEM encoded RSA, ECDSA or Ed25519 key,
+// private key can sign and verify, public key can only verify
+func ParsePEM(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Wrap(errors.ErrInternal, "keys: input is not PEM encoded")
+	}
+
+	var (
+		parsed interface{}
+		err    error
+	)
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: unsupported PEM type %v", block.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: failed to parse PEM type=%v err %v", block.Type, err)
+	}
+
+	return newKey(parsed)
+}
+
+// newKey decide signing method by key type
+func newKey(parsed interface{}) (*Key, error) {
+	key := &Key{}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.PrivateKey, key.PublicKey = k, k.Public()
+	case *ecdsa.PrivateKey:
+		key.PrivateKey, key.PublicKey = k, k.Public()
+	case ed25519.PrivateKey:
+		key.PrivateKey, key.PublicKey = k, k.Public()
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		key.PublicKey = k
+	default:
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: unsupported key type %T", parsed)
+	}
+
+	switch k := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			key.Method = jwt.SigningMethodES256
+		case elliptic.P384():
+			key.Method = jwt.SigningMethodES384
+		case elliptic.P521():
+			key.Method = jwt.SigningMethodES512
+		default:
+			return nil, errors.Wrapf(errors.ErrInternal, "keys: unsupported curve %v", k.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	}
+
+	return key, nil
+}
+
+// generateKey generate ephemeral ES256 key
+func generateKey() (*Key, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "keys: failed to generate key err %v", err)
+	}
+
+	key, err := newKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	key.ID = xid.New().String()
+
+	return key, nil
+}
@@ -0,0 +1,258 @@
+package keys_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/keys"
+)
+
+func encodePrivateKey(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func encodePublicKey(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestKeyManager_SignAndVerify(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		name string
+		pem  string
+		alg  string
+		kty  string
+	}{
+		{
+			name: "RSA",
+			pem:  encodePrivateKey(t, rsaKey),
+			alg:  "RS256",
+			kty:  "RSA",
+		},
+		{
+			name: "ECDSA",
+			pem:  encodePrivateKey(t, ecKey),
+			alg:  "ES256",
+			kty:  "EC",
+		},
+		{
+			name: "Ed25519",
+			pem:  encodePrivateKey(t, edKey),
+			alg:  "EdDSA",
+			kty:  "OKP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			km, err := keys.NewKeyManager(keys.Config{
+				Active: "kid",
+				Keys:   []keys.KeyConfig{{ID: "kid", PEM: tt.pem}},
+			})
+			assert.NoError(t, err)
+
+			token, err := km.Sign(&jwt.RegisteredClaims{
+				Subject:   "MOCK-USER-ID",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			})
+			assert.NoError(t, err)
+
+			var claims jwt.RegisteredClaims
+			parsed, err := jwt.ParseWithClaims(token, &claims, km.Keyfunc)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.alg, parsed.Method.Alg())
+			assert.Equal(t, "kid", parsed.Header["kid"])
+			assert.Equal(t, "MOCK-USER-ID", claims.Subject)
+
+			jwks := km.JWKS()
+			assert.Len(t, jwks.Keys, 1)
+			assert.Equal(t, "kid", jwks.Keys[0].Kid)
+			assert.Equal(t, tt.alg, jwks.Keys[0].Alg)
+			assert.Equal(t, tt.kty, jwks.Keys[0].Kty)
+		})
+	}
+}
+
+func TestKeyManager_Rotation(t *testing.T) {
+	oldKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	newKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	before, err := keys.NewKeyManager(keys.Config{
+		Active: "old",
+		Keys:   []keys.KeyConfig{{ID: "old", PEM: encodePrivateKey(t, oldKey)}},
+	})
+	assert.NoError(t, err)
+
+	token, err := before.Sign(&jwt.RegisteredClaims{Subject: "MOCK-USER-ID"})
+	assert.NoError(t, err)
+
+	// old key only keep public part for verification after rotation
+	after, err := keys.NewKeyManager(keys.Config{
+		Active: "new",
+		Keys: []keys.KeyConfig{
+			{ID: "new", PEM: encodePrivateKey(t, newKey)},
+			{ID: "old", PEM: encodePublicKey(t, oldKey.Public())},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, after.JWKS().Keys, 2)
+
+	_, err = jwt.Parse(token, after.Keyfunc)
+	assert.NoError(t, err)
+
+	rotated, err := after.Sign(&jwt.RegisteredClaims{Subject: "MOCK-USER-ID"})
+	assert.NoError(t, err)
+
+	parsed, err := jwt.Parse(rotated, after.Keyfunc)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", parsed.Header["kid"])
+
+	// token signed by new key is unknown before rotation
+	_, err = jwt.Parse(rotated, before.Keyfunc)
+	assert.Error(t, err)
+}
+
+func TestKeyManager_InvalidConfig(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	tests := []struct {
+		name   string
+		config keys.Config
+	}{
+		{
+			name:   "No Key Outside Local Development",
+			config: keys.Config{},
+		},
+		{
+			name: "Active Key Not Configured",
+			config: keys.Config{
+				Active: "missing",
+				Keys:   []keys.KeyConfig{{ID: "kid", PEM: encodePrivateKey(t, ecKey)}},
+			},
+		},
+		{
+			name: "Active Key Without Private Key",
+			config: keys.Config{
+				Active: "kid",
+				Keys:   []keys.KeyConfig{{ID: "kid", PEM: encodePublicKey(t, ecKey.Public())}},
+			},
+		},
+		{
+			name: "Duplicate Key ID",
+			config: keys.Config{
+				Active: "kid",
+				Keys: []keys.KeyConfig{
+					{ID: "kid", PEM: encodePrivateKey(t, ecKey)},
+					{ID: "kid", PEM: encodePrivateKey(t, ecKey)},
+				},
+			},
+		},
+		{
+			name: "Not PEM",
+			config: keys.Config{
+				Active: "kid",
+				Keys:   []keys.KeyConfig{{ID: "kid", PEM: "not pem"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := keys.NewKeyManager(tt.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKeyManager_RejectAlgorithmConfusion(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	km, err := keys.NewKeyManager(keys.Config{
+		Active: "kid",
+		Keys:   []keys.KeyConfig{{ID: "kid", PEM: encodePrivateKey(t, ecKey)}},
+	})
+	assert.NoError(t, err)
+
+	// forge HS256 token use public key bytes as shared secret
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.RegisteredClaims{Subject: "MOCK-USER-ID"})
+	forged.Header["kid"] = "kid"
+	token, _ := forged.SignedString([]byte(encodePublicKey(t, ecKey.Public())))
+
+	_, err = jwt.Parse(token, km.Keyfunc)
+	assert.Error(t, err)
+}
+
+func TestParseJSONWebKey(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		name string
+		pem  string
+		alg  string
+	}{
+		{
+			name: "RSA",
+			pem:  encodePrivateKey(t, rsaKey),
+			alg:  "RS256",
+		},
+		{
+			name: "ECDSA",
+			pem:  encodePrivateKey(t, ecKey),
+			alg:  "ES384",
+		},
+		{
+			name: "Ed25519",
+			pem:  encodePrivateKey(t, edKey),
+			alg:  "EdDSA",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			km, err := keys.NewKeyManager(keys.Config{
+				Active: "kid",
+				Keys:   []keys.KeyConfig{{ID: "kid", PEM: tt.pem}},
+			})
+			assert.NoError(t, err)
+
+			key, err := keys.ParseJSONWebKey(km.JWKS().Keys[0])
+			assert.NoError(t, err)
+			assert.Equal(t, "kid", key.ID)
+			assert.Equal(t, tt.alg, key.Method.Alg())
+			assert.Nil(t, key.PrivateKey)
+
+			token, err := km.Sign(&jwt.RegisteredClaims{Subject: "MOCK-USER-ID"})
+			assert.NoError(t, err)
+
+			_, err = jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+				return key.PublicKey, nil
+			})
+			assert.NoError(t, err)
+		})
+	}
+
+	_, err := keys.ParseJSONWebKey(keys.JSONWebKey{Kty: "oct", Kid: "kid"})
+	assert.Error(t, err)
+
+	_, err = keys.ParseJSONWebKey(keys.JSONWebKey{Kty: "EC", Kid: "kid", Crv: "P-256", X: "AQ", Y: "AQ"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,229 @@
+// Package ber encode and decode the subset of ASN.1 BER (X.690) used by LDAP,
+// only single byte tag and definite length are supported
+package ber
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+const (
+	// class and form bits of tag
+	ClassUniversal   = 0x00
+	ClassApplication = 0x40
+	ClassContext     = 0x80
+	Constructed      = 0x20
+
+	// universal tags
+	TagBoolean     = 0x01
+	TagInteger     = 0x02
+	TagOctetString = 0x04
+	TagNull        = 0x05
+	TagEnumerated  = 0x0a
+	TagSequence    = Constructed | 0x10
+	TagSet         = Constructed | 0x11
+
+	// MaxSize bound length of untrusted element
+	MaxSize = 16 << 20
+	// maxDepth bound nesting of untrusted element
+	maxDepth = 32
+)
+
+// Packet is BER element, Value is content of primitive element
+// and Children are elements of constructed element
+type Packet struct {
+	Tag      byte
+	Value    []byte
+	Children []*Packet
+}
+
+// NewConstructed new constructed element, tag should carry Constructed bit
+func NewConstructed(tag byte, children ...*Packet) *Packet {
+	return &Packet{Tag: tag | Constructed, Children: children}
+}
+
+// NewString new primitive element of string content
+func NewString(tag byte, s string) *Packet {
+	return &Packet{Tag: tag, Value: []byte(s)}
+}
+
+// NewInteger new primitive element of two's complement integer content
+func NewInteger(tag byte, n int64) *Packet {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(n))
+
+	// minimal encoding drop leading byte which only repeat sign of next byte
+	for len(b) > 1 && ((b[0] == 0x00 && b[1]&0x80 == 0) || (b[0] == 0xff && b[1]&0x80 != 0)) {
+		b = b[1:]
+	}
+	return &Packet{Tag: tag, Value: b}
+}
+
+// NewBoolean new primitive element of boolean content
+func NewBoolean(tag byte, v bool) *Packet {
+	if v {
+		return &Packet{Tag: tag, Value: []byte{0xff}}
+	}
+	return &Packet{Tag: tag, Value: []byte{0x00}}
+}
+
+// IsConstructed element has children
+func (p *Packet) IsConstructed() bool {
+	return p.Tag&Constructed != 0
+}
+
+// String content of primitive element
+func (p *Packet) String() string {
+	return string(p.Value)
+}
+
+// Int decode content as two's complement integer
+func (p *Packet) Int() (int64, error) {
+	if len(p.Value) == 0 || len(p.Value) > 8 {
+		return 0, errors.Wrapf(errors.ErrInvalidInput, "ber: invalid integer length=%v", len(p.Value))
+	}
+
+	var n int64
+	if p.Value[0]&0x80 != 0 {
+		n = -1
+	}
+	for _, b := range p.Value {
+		n = n<<8 | int64(b)
+	}
+	return n, nil
+}
+
+// Bool decode content as boolean, any non zero byte is true
+func (p *Packet) Bool() bool {
+	return len(p.Value) == 1 && p.Value[0] != 0
+}
+
+// Bytes encode element
+func (p *Packet) Bytes() []byte {
+	content := p.Value
+	if p.IsConstructed() {
+		content = nil
+		for _, child := range p.Children {
+			content = append(content, child.Bytes()...)
+		}
+	}
+
+	b := []byte{p.Tag}
+	b = append(b, encodeLength(len(content))...)
+	return append(b, content...)
+}
+
+func encodeLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+
+	var b []byte
+	for ; n > 0; n >>= 8 {
+		b = append([]byte{byte(n)}, b...)
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+// Read read one element from stream
+func Read(r io.Reader) (*Packet, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	length := int(header[1])
+	if header[1]&0x80 != 0 {
+		size := int(header[1] & 0x7f)
+		if size == 0 || size > 4 {
+			return nil, errors.Wrapf(errors.ErrInvalidInput, "ber: unsupported length of %v bytes", size)
+		}
+
+		b := make([]byte, size)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+
+		length = 0
+		for _, v := range b {
+			length = length<<8 | int(v)
+		}
+	}
+	if length > MaxSize {
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "ber: element length=%v too large", length)
+	}
+
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, err
+	}
+
+	return newPacket(header[0], content, 0)
+}
+
+// Parse decode single element of data
+func Parse(data []byte) (*Packet, error) {
+	p, rest, err := parse(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "ber: trailing bytes after element")
+	}
+	return p, nil
+}
+
+func parse(data []byte, depth int) (*Packet, []byte, error) {
+	if len(data) < 2 {
+		return nil, nil, errors.Wrap(errors.ErrInvalidInput, "ber: unexpected end of element")
+	}
+
+	tag := data[0]
+	length := int(data[1])
+	off := 2
+	if data[1]&0x80 != 0 {
+		size := int(data[1] & 0x7f)
+		if size == 0 || size > 4 || len(data) < off+size {
+			return nil, nil, errors.Wrap(errors.ErrInvalidInput, "ber: invalid length")
+		}
+
+		length = 0
+		for _, v := range data[off : off+size] {
+			length = length<<8 | int(v)
+		}
+		off += size
+	}
+	if length < 0 || length > len(data)-off {
+		return nil, nil, errors.Wrap(errors.ErrInvalidInput, "ber: element length exceed data")
+	}
+
+	p, err := newPacket(tag, data[off:off+length], depth)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, data[off+length:], nil
+}
+
+func newPacket(tag byte, content []byte, depth int) (*Packet, error) {
+	p := &Packet{Tag: tag}
+	if tag&Constructed == 0 {
+		p.Value = content
+		return p, nil
+	}
+
+	if depth > maxDepth {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "ber: element nested too deep")
+	}
+
+	for len(content) > 0 {
+		child, rest, err := parse(content, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		p.Children = append(p.Children, child)
+		content = rest
+	}
+	return p, nil
+}
@@ -0,0 +1,47 @@
+package ldap
+
+import "time"
+
+// Config for LDAP or Active Directory password verification
+type Config struct {
+	// URL of directory, e.g. ldap://ldap.example.com:389 or ldaps://ldap.example.com:636,
+	// empty disable directory
+	URL string `mapstructure:"url"`
+	// StartTLS upgrade ldap:// connection to TLS before bind
+	StartTLS bool `mapstructure:"start_tls"`
+	// InsecureSkipVerify skip verifying certificate of directory, only for testing
+	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+	// Timeout of each connection, default is 10s
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// UserDNTemplate bind user directly, %s is replaced by escaped username,
+	// e.g. uid=%s,ou=people,dc=example,dc=com
+	UserDNTemplate string `mapstructure:"user_dn_template"`
+
+	// BindDN and BindPassword of service account searching user when dn template is empty,
+	// both empty search anonymously
+	BindDN       string `mapstructure:"bind_dn"`
+	BindPassword string `mapstructure:"bind_password"`
+	// BaseDN user is searched under
+	BaseDN string `mapstructure:"base_dn"`
+	// UserFilter find user by username, %s is replaced by escaped username,
+	// e.g. (&(objectClass=person)(uid=%s)) or (sAMAccountName=%s) for Active Directory
+	UserFilter string `mapstructure:"user_filter"`
+
+	// Tenant name of tenant directory users sign in to and are signed up into,
+	// empty is default tenant, signin to other tenants is rejected
+	Tenant string `mapstructure:"tenant"`
+
+	// Attributes map directory attributes to user profile
+	Attributes AttributeMap `mapstructure:"attributes"`
+}
+
+// AttributeMap define attribute names of user profile
+type AttributeMap struct {
+	// Email default is mail
+	Email string `mapstructure:"email"`
+	// FirstName default is givenName
+	FirstName string `mapstructure:"first_name"`
+	// LastName default is sn
+	LastName string `mapstructure:"last_name"`
+}
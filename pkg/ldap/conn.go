@@ -0,0 +1,266 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/ldap/ber"
+)
+
+// protocol operations of RFC 4511 section 4.2
+const (
+	ApplicationBindRequest           = ber.ClassApplication | ber.Constructed | 0
+	ApplicationBindResponse          = ber.ClassApplication | ber.Constructed | 1
+	ApplicationUnbindRequest         = ber.ClassApplication | 2
+	ApplicationSearchRequest         = ber.ClassApplication | ber.Constructed | 3
+	ApplicationSearchResultEntry     = ber.ClassApplication | ber.Constructed | 4
+	ApplicationSearchResultDone      = ber.ClassApplication | ber.Constructed | 5
+	ApplicationSearchResultReference = ber.ClassApplication | ber.Constructed | 19
+	ApplicationExtendedRequest       = ber.ClassApplication | ber.Constructed | 23
+	ApplicationExtendedResponse      = ber.ClassApplication | ber.Constructed | 24
+
+	// AuthenticationSimple is simple password of bind request
+	AuthenticationSimple = ber.ClassContext | 0
+	// ExtendedRequestName is oid of extended request
+	ExtendedRequestName = ber.ClassContext | 0
+
+	// search scope
+	ScopeBaseObject   = 0
+	ScopeSingleLevel  = 1
+	ScopeWholeSubtree = 2
+
+	// result codes
+	ResultSuccess            = 0
+	ResultProtocolError      = 2
+	ResultSizeLimitExceeded  = 4
+	ResultNoSuchObject       = 32
+	ResultInvalidCredentials = 49
+	ResultInsufficientAccess = 50
+	ResultUnwillingToPerform = 53
+
+	// OIDStartTLS is extended request upgrading connection to TLS, see RFC 4511 section 4.14
+	OIDStartTLS = "1.3.6.1.4.1.1466.20037"
+
+	protocolVersion = 3
+	defaultTimeout  = 10 * time.Second
+)
+
+// conn is LDAP connection doing one operation at a time
+type conn struct {
+	net.Conn
+	id int64
+}
+
+// dial connect to directory, ldaps:// and StartTLS connection is TLS before bind
+func dial(ctx context.Context, config Config) (*conn, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "ldap: invalid url err %v", err)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		switch u.Scheme {
+		case "ldap":
+			host = net.JoinHostPort(u.Hostname(), "389")
+		case "ldaps":
+			host = net.JoinHostPort(u.Hostname(), "636")
+		}
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	dialer := &net.Dialer{Deadline: deadline}
+	c, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "ldap: failed to connect %v err %v", host, err)
+	}
+	_ = c.SetDeadline(deadline)
+
+	tlsConfig := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: config.InsecureSkipVerify, // nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	lc := &conn{Conn: c}
+	switch {
+	case u.Scheme == "ldaps":
+		lc.Conn = tls.Client(c, tlsConfig)
+	case u.Scheme == "ldap" && config.StartTLS:
+		err = lc.startTLS(tlsConfig)
+	case u.Scheme != "ldap":
+		err = errors.Wrapf(errors.ErrInternal, "ldap: unsupported url scheme %v", u.Scheme)
+	}
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+
+	return lc, nil
+}
+
+// roundTrip send operation and read responses until response of final tag
+func (c *conn) roundTrip(op *ber.Packet, final byte) ([]*ber.Packet, error) {
+	c.id++
+	message := ber.NewConstructed(ber.TagSequence, ber.NewInteger(ber.TagInteger, c.id), op)
+	if _, err := c.Write(message.Bytes()); err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "ldap: failed to send request err %v", err)
+	}
+
+	var responses []*ber.Packet
+	for {
+		p, err := ber.Read(c)
+		if err != nil {
+			return nil, errors.Wrapf(errors.ErrInternal, "ldap: failed to read response err %v", err)
+		}
+		if p.Tag != ber.TagSequence || len(p.Children) < 2 {
+			return nil, errors.Wrap(errors.ErrInternal, "ldap: malformed response message")
+		}
+
+		id, err := p.Children[0].Int()
+		if err != nil {
+			return nil, errors.WithMessage(err, "ldap: malformed message id")
+		}
+		// unsolicited notification is sent before server close connection
+		if id == 0 {
+			return nil, errors.Wrap(errors.ErrInternal, "ldap: connection closed by server")
+		}
+		if id != c.id {
+			continue
+		}
+
+		responses = append(responses, p.Children[1])
+		if p.Children[1].Tag == final {
+			return responses, nil
+		}
+	}
+}
+
+// bind authenticate connection by simple bind
+func (c *conn) bind(dn string, password string) error {
+	op := ber.NewConstructed(
+		ApplicationBindRequest,
+		ber.NewInteger(ber.TagInteger, protocolVersion),
+		ber.NewString(ber.TagOctetString, dn),
+		ber.NewString(AuthenticationSimple, password),
+	)
+
+	responses, err := c.roundTrip(op, ApplicationBindResponse)
+	if err != nil {
+		return err
+	}
+	_, err = result(responses[len(responses)-1])
+	return err
+}
+
+// search find entries, referral is not followed
+func (c *conn) search(base string, scope int64, filter string, attributes []string) ([]*Entry, error) {
+	compiled, err := CompileFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := ber.NewConstructed(ber.TagSequence)
+	for _, attr := range attributes {
+		attrs.Children = append(attrs.Children, ber.NewString(ber.TagOctetString, attr))
+	}
+
+	op := ber.NewConstructed(
+		ApplicationSearchRequest,
+		ber.NewString(ber.TagOctetString, base),
+		ber.NewInteger(ber.TagEnumerated, scope),
+		ber.NewInteger(ber.TagEnumerated, 0), // never deref aliases
+		// one more than expected entry tells username is ambiguous
+		ber.NewInteger(ber.TagInteger, 2),
+		ber.NewInteger(ber.TagInteger, int64(defaultTimeout/time.Second)),
+		ber.NewBoolean(ber.TagBoolean, false),
+		compiled,
+		attrs,
+	)
+
+	responses, err := c.roundTrip(op, ApplicationSearchResultDone)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := result(responses[len(responses)-1])
+	if err != nil && code != ResultSizeLimitExceeded {
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0, len(responses)-1)
+	for _, p := range responses[:len(responses)-1] {
+		if p.Tag != ApplicationSearchResultEntry {
+			continue
+		}
+		entry, err := parseEntry(p)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// startTLS upgrade connection to TLS
+func (c *conn) startTLS(config *tls.Config) error {
+	op := ber.NewConstructed(ApplicationExtendedRequest, ber.NewString(ExtendedRequestName, OIDStartTLS))
+
+	responses, err := c.roundTrip(op, ApplicationExtendedResponse)
+	if err != nil {
+		return err
+	}
+	if _, err := result(responses[len(responses)-1]); err != nil {
+		return errors.WithMessage(err, "ldap: start tls")
+	}
+
+	tlsConn := tls.Client(c.Conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		return errors.Wrapf(errors.ErrInternal, "ldap: tls handshake err %v", err)
+	}
+	c.Conn = tlsConn
+	return nil
+}
+
+// Close unbind and close connection
+func (c *conn) Close() error {
+	c.id++
+	message := ber.NewConstructed(ber.TagSequence, ber.NewInteger(ber.TagInteger, c.id), &ber.Packet{Tag: ApplicationUnbindRequest})
+	_, _ = c.Write(message.Bytes())
+	return c.Conn.Close()
+}
+
+// result check LDAPResult of response and return its code,
+// invalid credentials is ErrUnauthorized and others are ErrInternal
+func result(p *ber.Packet) (int64, error) {
+	if len(p.Children) < 3 {
+		return 0, errors.Wrap(errors.ErrInternal, "ldap: malformed result")
+	}
+
+	code, err := p.Children[0].Int()
+	if err != nil {
+		return 0, errors.WithMessage(err, "ldap: malformed result code")
+	}
+
+	switch code {
+	case ResultSuccess:
+		return code, nil
+	case ResultInvalidCredentials:
+		return code, errors.Wrapf(errors.ErrUnauthorized, "ldap: invalid credentials %v", p.Children[2].String())
+	default:
+		return code, errors.Wrapf(errors.ErrInternal, "ldap: result code %v %v", code, p.Children[2].String())
+	}
+}
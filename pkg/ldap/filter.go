@@ -0,0 +1,218 @@
+package ldap
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/ldap/ber"
+)
+
+// filter choices of RFC 4511 section 4.5.1.7
+const (
+	FilterAnd            = ber.ClassContext | ber.Constructed | 0
+	FilterOr             = ber.ClassContext | ber.Constructed | 1
+	FilterNot            = ber.ClassContext | ber.Constructed | 2
+	FilterEqualityMatch  = ber.ClassContext | ber.Constructed | 3
+	FilterSubstrings     = ber.ClassContext | ber.Constructed | 4
+	FilterGreaterOrEqual = ber.ClassContext | ber.Constructed | 5
+	FilterLessOrEqual    = ber.ClassContext | ber.Constructed | 6
+	FilterPresent        = ber.ClassContext | 7
+	FilterApproxMatch    = ber.ClassContext | ber.Constructed | 8
+
+	// substring choices
+	SubstringInitial = ber.ClassContext | 0
+	SubstringAny     = ber.ClassContext | 1
+	SubstringFinal   = ber.ClassContext | 2
+)
+
+// EscapeFilter escape value put into filter, see RFC 4515 section 3
+func EscapeFilter(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '*', '(', ')', '\\', 0:
+			b.WriteString(`\` + hex.EncodeToString([]byte{c}))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// EscapeDN escape value put into attribute value of DN, see RFC 4514 section 2.4
+func EscapeDN(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == ',' || c == '+' || c == '"' || c == '\\' || c == '<' || c == '>' || c == ';' || c == '=':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c == 0:
+			b.WriteString(`\00`)
+		case (c == ' ' || c == '#') && i == 0, c == ' ' && i == len(value)-1:
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// CompileFilter compile string representation of filter (RFC 4515) to BER,
+// extensible match is not supported
+func CompileFilter(filter string) (*ber.Packet, error) {
+	p, rest, err := compileFilter(filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "ldap: unexpected %q after filter", rest)
+	}
+	return p, nil
+}
+
+func compileFilter(filter string, depth int) (*ber.Packet, string, error) {
+	if depth > 16 {
+		return nil, "", errors.Wrap(errors.ErrInvalidInput, "ldap: filter nested too deep")
+	}
+	if !strings.HasPrefix(filter, "(") {
+		return nil, "", errors.Wrapf(errors.ErrInvalidInput, "ldap: filter %q not start with (", filter)
+	}
+	filter = filter[1:]
+
+	if filter == "" {
+		return nil, "", errors.Wrap(errors.ErrInvalidInput, "ldap: unexpected end of filter")
+	}
+
+	switch filter[0] {
+	case '&', '|':
+		tag := byte(FilterAnd)
+		if filter[0] == '|' {
+			tag = FilterOr
+		}
+
+		p := ber.NewConstructed(tag)
+		rest := filter[1:]
+		for strings.HasPrefix(rest, "(") {
+			child, r, err := compileFilter(rest, depth+1)
+			if err != nil {
+				return nil, "", err
+			}
+			p.Children = append(p.Children, child)
+			rest = r
+		}
+		if !strings.HasPrefix(rest, ")") {
+			return nil, "", errors.Wrap(errors.ErrInvalidInput, "ldap: filter set not closed")
+		}
+		return p, rest[1:], nil
+	case '!':
+		child, rest, err := compileFilter(filter[1:], depth+1)
+		if err != nil {
+			return nil, "", err
+		}
+		if !strings.HasPrefix(rest, ")") {
+			return nil, "", errors.Wrap(errors.ErrInvalidInput, "ldap: not filter not closed")
+		}
+		return ber.NewConstructed(FilterNot, child), rest[1:], nil
+	}
+
+	end := strings.IndexByte(filter, ')')
+	if end < 0 {
+		return nil, "", errors.Wrap(errors.ErrInvalidInput, "ldap: filter item not closed")
+	}
+	p, err := compileItem(filter[:end])
+	if err != nil {
+		return nil, "", err
+	}
+	return p, filter[end+1:], nil
+}
+
+// compileItem compile simple, present or substring item without parentheses
+func compileItem(item string) (*ber.Packet, error) {
+	eq := strings.IndexByte(item, '=')
+	if eq <= 0 {
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "ldap: invalid filter item %q", item)
+	}
+
+	attr, value := item[:eq], item[eq+1:]
+	tag := byte(FilterEqualityMatch)
+	switch attr[len(attr)-1] {
+	case '>':
+		tag, attr = FilterGreaterOrEqual, attr[:len(attr)-1]
+	case '<':
+		tag, attr = FilterLessOrEqual, attr[:len(attr)-1]
+	case '~':
+		tag, attr = FilterApproxMatch, attr[:len(attr)-1]
+	case ':':
+		return nil, errors.Wrap(errors.ErrInvalidInput, "ldap: extensible match filter is not supported")
+	}
+	if attr == "" {
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "ldap: invalid filter item %q", item)
+	}
+
+	if tag == FilterEqualityMatch && value == "*" {
+		return ber.NewString(FilterPresent, attr), nil
+	}
+
+	if tag == FilterEqualityMatch && strings.Contains(value, "*") {
+		parts := strings.Split(value, "*")
+		substrings := ber.NewConstructed(ber.TagSequence)
+		for i, part := range parts {
+			if part == "" {
+				continue
+			}
+			unescaped, err := unescapeFilter(part)
+			if err != nil {
+				return nil, err
+			}
+
+			choice := byte(SubstringAny)
+			switch i {
+			case 0:
+				choice = SubstringInitial
+			case len(parts) - 1:
+				choice = SubstringFinal
+			}
+			substrings.Children = append(substrings.Children, ber.NewString(choice, unescaped))
+		}
+		return ber.NewConstructed(FilterSubstrings, ber.NewString(ber.TagOctetString, attr), substrings), nil
+	}
+
+	unescaped, err := unescapeFilter(value)
+	if err != nil {
+		return nil, err
+	}
+	return ber.NewConstructed(
+		tag,
+		ber.NewString(ber.TagOctetString, attr),
+		ber.NewString(ber.TagOctetString, unescaped),
+	), nil
+}
+
+// unescapeFilter decode \XX escaped byte of filter value
+func unescapeFilter(value string) (string, error) {
+	if !strings.Contains(value, `\`) {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i+3 > len(value) {
+			return "", errors.Wrapf(errors.ErrInvalidInput, "ldap: invalid escape in filter value %q", value)
+		}
+		decoded, err := hex.DecodeString(value[i+1 : i+3])
+		if err != nil {
+			return "", errors.Wrapf(errors.ErrInvalidInput, "ldap: invalid escape in filter value %q", value)
+		}
+		b.Write(decoded)
+		i += 2
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,147 @@
+// Package ldap verify password of user by LDAP v3 simple bind (RFC 4511),
+// it works with OpenLDAP and Active Directory
+package ldap
+
+import (
+	"context"
+	"strings"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/ldap/ber"
+)
+
+// Entry is directory entry of user
+type Entry struct {
+	DN string
+	// Attributes key is lower case attribute name
+	Attributes map[string][]string
+}
+
+// Get first value of attribute, name is case insensitive
+func (e *Entry) Get(name string) string {
+	values := e.Attributes[strings.ToLower(name)]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// parseEntry decode SearchResultEntry
+func parseEntry(p *ber.Packet) (*Entry, error) {
+	if len(p.Children) < 2 {
+		return nil, errors.Wrap(errors.ErrInternal, "ldap: malformed search result entry")
+	}
+
+	entry := &Entry{
+		DN:         p.Children[0].String(),
+		Attributes: map[string][]string{},
+	}
+	for _, attr := range p.Children[1].Children {
+		if len(attr.Children) < 2 {
+			return nil, errors.Wrap(errors.ErrInternal, "ldap: malformed attribute")
+		}
+		name := strings.ToLower(attr.Children[0].String())
+		for _, value := range attr.Children[1].Children {
+			entry.Attributes[name] = append(entry.Attributes[name], value.String())
+		}
+	}
+
+	return entry, nil
+}
+
+// Directory authenticate user against LDAP directory
+type Directory struct {
+	config Config
+}
+
+// New directory, nil if url is not configured
+func New(config Config) *Directory {
+	if config.URL == "" {
+		return nil
+	}
+	if config.UserFilter == "" {
+		config.UserFilter = "(uid=%s)"
+	}
+	if config.Attributes.Email == "" {
+		config.Attributes.Email = "mail"
+	}
+	if config.Attributes.FirstName == "" {
+		config.Attributes.FirstName = "givenName"
+	}
+	if config.Attributes.LastName == "" {
+		config.Attributes.LastName = "sn"
+	}
+
+	return &Directory{config: config}
+}
+
+// Attributes of user profile
+func (d *Directory) Attributes() AttributeMap {
+	return d.config.Attributes
+}
+
+// Tenant name of tenant directory users belong to, empty is default tenant
+func (d *Directory) Tenant() string {
+	return d.config.Tenant
+}
+
+// Authenticate bind as user and return entry of user,
+// ErrUnauthorized if user not exist or password is wrong
+func (d *Directory) Authenticate(ctx context.Context, username string, password string) (*Entry, error) {
+	// bind with empty password is unauthenticated bind which always success,
+	// see RFC 4513 section 5.1.2
+	if username == "" || password == "" {
+		return nil, errors.Wrap(errors.ErrUnauthorized, "ldap: empty username or password")
+	}
+
+	c, err := dial(ctx, d.config)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	attributes := []string{d.config.Attributes.Email, d.config.Attributes.FirstName, d.config.Attributes.LastName}
+
+	if d.config.UserDNTemplate != "" {
+		dn := strings.ReplaceAll(d.config.UserDNTemplate, "%s", EscapeDN(username))
+		if err := c.bind(dn, password); err != nil {
+			return nil, err
+		}
+
+		entries, err := c.search(dn, ScopeBaseObject, "(objectClass=*)", attributes)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) != 1 {
+			return nil, errors.Wrapf(errors.ErrInternal, "ldap: cant not read entry %v", dn)
+		}
+		return entries[0], nil
+	}
+
+	if err := c.bind(d.config.BindDN, d.config.BindPassword); err != nil {
+		// wrong password of service account is configuration problem, not user's
+		if errors.Is(err, errors.ErrUnauthorized) {
+			return nil, errors.Wrapf(errors.ErrInternal, "ldap: service account bind failed %v", err)
+		}
+		return nil, err
+	}
+
+	filter := strings.ReplaceAll(d.config.UserFilter, "%s", EscapeFilter(username))
+	entries, err := c.search(d.config.BaseDN, ScopeWholeSubtree, filter, attributes)
+	if err != nil {
+		return nil, err
+	}
+	switch len(entries) {
+	case 0:
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "ldap: cant not found user %v", username)
+	case 1:
+	default:
+		return nil, errors.Wrapf(errors.ErrUnauthorized, "ldap: username %v is ambiguous", username)
+	}
+
+	if err := c.bind(entries[0].DN, password); err != nil {
+		return nil, err
+	}
+
+	return entries[0], nil
+}
@@ -0,0 +1,198 @@
+package ldap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/ldap"
+	"github.com/karta0898098/iam/pkg/ldap/ldaptest"
+)
+
+func newServer() *ldaptest.Server {
+	s := ldaptest.NewServer()
+	s.AddEntry("cn=admin,dc=example,dc=com", map[string][]string{
+		"objectClass":  {"person"},
+		"userPassword": {"MOCK-ADMIN-PASSWORD"},
+	})
+	s.AddEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass":  {"person"},
+		"uid":          {"alice"},
+		"mail":         {"alice@example.com"},
+		"givenName":    {"Alice"},
+		"sn":           {"Liddell"},
+		"userPassword": {"MOCK-PASSWORD"},
+	})
+	s.AddEntry("uid=bob,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass":  {"person"},
+		"uid":          {"bob"},
+		"cn":           {"duplicate"},
+		"userPassword": {"MOCK-PASSWORD"},
+	})
+	s.AddEntry("uid=carol,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass":  {"person"},
+		"uid":          {"carol"},
+		"cn":           {"duplicate"},
+		"userPassword": {"MOCK-PASSWORD"},
+	})
+	return s
+}
+
+func TestDirectory_Authenticate(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+
+	search := ldap.Config{
+		URL:          s.URL(),
+		BindDN:       "cn=admin,dc=example,dc=com",
+		BindPassword: "MOCK-ADMIN-PASSWORD",
+		BaseDN:       "ou=people,dc=example,dc=com",
+		UserFilter:   "(&(objectClass=person)(|(uid=%s)(cn=%s)))",
+	}
+	template := ldap.Config{
+		URL:            s.URL(),
+		UserDNTemplate: "uid=%s,ou=people,dc=example,dc=com",
+	}
+
+	tests := []struct {
+		name     string
+		config   ldap.Config
+		username string
+		password string
+		dn       string
+		err      error
+	}{
+		{
+			name:     "Search Success",
+			config:   search,
+			username: "alice",
+			password: "MOCK-PASSWORD",
+			dn:       "uid=alice,ou=people,dc=example,dc=com",
+		},
+		{
+			name:     "Template Success",
+			config:   template,
+			username: "alice",
+			password: "MOCK-PASSWORD",
+			dn:       "uid=alice,ou=people,dc=example,dc=com",
+		},
+		{
+			name:     "Search Wrong Password",
+			config:   search,
+			username: "alice",
+			password: "MOCK-WRONG-PASSWORD",
+			err:      errors.ErrUnauthorized,
+		},
+		{
+			name:     "Template Wrong Password",
+			config:   template,
+			username: "alice",
+			password: "MOCK-WRONG-PASSWORD",
+			err:      errors.ErrUnauthorized,
+		},
+		{
+			name:     "Empty Password",
+			config:   template,
+			username: "alice",
+			password: "",
+			err:      errors.ErrUnauthorized,
+		},
+		{
+			name:     "User Not Found",
+			config:   search,
+			username: "mallory",
+			password: "MOCK-PASSWORD",
+			err:      errors.ErrUnauthorized,
+		},
+		{
+			name:     "Filter Injection",
+			config:   search,
+			username: "*",
+			password: "MOCK-PASSWORD",
+			err:      errors.ErrUnauthorized,
+		},
+		{
+			name:     "Ambiguous Username",
+			config:   search,
+			username: "duplicate",
+			password: "MOCK-PASSWORD",
+			err:      errors.ErrUnauthorized,
+		},
+		{
+			name: "Service Account Wrong Password",
+			config: ldap.Config{
+				URL:          s.URL(),
+				BindDN:       "cn=admin,dc=example,dc=com",
+				BindPassword: "MOCK-WRONG-PASSWORD",
+				BaseDN:       "ou=people,dc=example,dc=com",
+			},
+			username: "alice",
+			password: "MOCK-PASSWORD",
+			err:      errors.ErrInternal,
+		},
+		{
+			name: "StartTLS Not Supported",
+			config: ldap.Config{
+				URL:            s.URL(),
+				StartTLS:       true,
+				UserDNTemplate: "uid=%s,ou=people,dc=example,dc=com",
+			},
+			username: "alice",
+			password: "MOCK-PASSWORD",
+			err:      errors.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := ldap.New(tt.config).Authenticate(context.Background(), tt.username, tt.password)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.dn, entry.DN)
+			assert.Equal(t, "alice@example.com", entry.Get("mail"))
+			assert.Equal(t, "Alice", entry.Get("givenName"))
+			assert.Equal(t, "Liddell", entry.Get("SN"))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	assert.Nil(t, ldap.New(ldap.Config{}))
+
+	d := ldap.New(ldap.Config{URL: "ldap://localhost"})
+	assert.Equal(t, ldap.AttributeMap{Email: "mail", FirstName: "givenName", LastName: "sn"}, d.Attributes())
+}
+
+func TestCompileFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		err    bool
+	}{
+		{filter: "(uid=alice)"},
+		{filter: "uid=alice", err: true},
+		{filter: "(&(objectClass=person)(|(uid=a*b*c)(!(cn=x))))"},
+		{filter: "(uid>=a)"},
+		{filter: "(cn=\\28x\\29)"},
+		{filter: "(uid:dn:=alice)", err: true},
+		{filter: "(&(uid=alice)", err: true},
+		{filter: "(uid=\\2)", err: true},
+		{filter: "(=alice)", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			_, err := ldap.CompileFilter(tt.filter)
+			assert.Equal(t, tt.err, err != nil, err)
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, `\2a\29\28\5c\00`, ldap.EscapeFilter("*)(\\\x00"))
+	assert.Equal(t, `\,\+\"\\\<\>\;\=`, ldap.EscapeDN(`,+"\<>;=`))
+	assert.Equal(t, `\ a\ `, ldap.EscapeDN(" a "))
+	assert.Equal(t, `\#a`, ldap.EscapeDN("#a"))
+}
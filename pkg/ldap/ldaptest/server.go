@@ -0,0 +1,319 @@
+// Package ldaptest provide in-process LDAP server for testing directory authentication
+package ldaptest
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/karta0898098/iam/pkg/ldap"
+	"github.com/karta0898098/iam/pkg/ldap/ber"
+)
+
+// Server is LDAP server supporting simple bind and search,
+// entries are kept in memory and userPassword attribute is compared in plain text
+type Server struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu      sync.Mutex
+	entries map[string]*ldap.Entry
+	conns   map[net.Conn]struct{}
+}
+
+// NewServer start server on loopback, Close must be called when test done
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("ldaptest: failed to listen " + err.Error())
+	}
+
+	s := &Server{
+		listener: listener,
+		entries:  map[string]*ldap.Entry{},
+		conns:    map[net.Conn]struct{}{},
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s
+}
+
+// URL of server
+func (s *Server) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// AddEntry add or replace entry, attribute names are case insensitive
+func (s *Server) AddEntry(dn string, attributes map[string][]string) {
+	entry := &ldap.Entry{DN: dn, Attributes: map[string][]string{}}
+	for name, values := range attributes {
+		entry.Attributes[strings.ToLower(name)] = values
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[strings.ToLower(dn)] = entry
+}
+
+// Close stop server and close remaining connections
+func (s *Server) Close() {
+	_ = s.listener.Close()
+	s.mu.Lock()
+	for c := range s.conns {
+		_ = c.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[c] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(c)
+
+			s.mu.Lock()
+			delete(s.conns, c)
+			s.mu.Unlock()
+			_ = c.Close()
+		}()
+	}
+}
+
+// handle serve requests of connection until unbind or error
+func (s *Server) handle(c net.Conn) {
+	for {
+		p, err := ber.Read(c)
+		if err != nil || len(p.Children) < 2 {
+			return
+		}
+		id, err := p.Children[0].Int()
+		if err != nil {
+			return
+		}
+
+		op := p.Children[1]
+		var responses []*ber.Packet
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			responses = []*ber.Packet{s.bind(op)}
+		case ldap.ApplicationSearchRequest:
+			responses = s.search(op)
+		case ldap.ApplicationExtendedRequest:
+			responses = []*ber.Packet{result(ldap.ApplicationExtendedResponse, ldap.ResultProtocolError, "extended operation is not supported")}
+		default:
+			return
+		}
+
+		for _, response := range responses {
+			message := ber.NewConstructed(ber.TagSequence, ber.NewInteger(ber.TagInteger, id), response)
+			if _, err := c.Write(message.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// bind check password of entry, empty name and password is anonymous bind
+func (s *Server) bind(op *ber.Packet) *ber.Packet {
+	if len(op.Children) < 3 || op.Children[2].Tag != ldap.AuthenticationSimple {
+		return result(ldap.ApplicationBindResponse, ldap.ResultProtocolError, "only simple bind is supported")
+	}
+
+	name, password := op.Children[1].String(), op.Children[2].String()
+	if name == "" && password == "" {
+		return result(ldap.ApplicationBindResponse, ldap.ResultSuccess, "")
+	}
+
+	s.mu.Lock()
+	entry, ok := s.entries[strings.ToLower(name)]
+	s.mu.Unlock()
+	if ok && password != "" {
+		for _, value := range entry.Attributes["userpassword"] {
+			if value == password {
+				return result(ldap.ApplicationBindResponse, ldap.ResultSuccess, "")
+			}
+		}
+	}
+
+	return result(ldap.ApplicationBindResponse, ldap.ResultInvalidCredentials, "invalid credentials")
+}
+
+// search find entries in scope matching filter, userPassword is never returned
+func (s *Server) search(op *ber.Packet) []*ber.Packet {
+	if len(op.Children) < 8 {
+		return []*ber.Packet{result(ldap.ApplicationSearchResultDone, ldap.ResultProtocolError, "malformed search request")}
+	}
+
+	base := strings.ToLower(op.Children[0].String())
+	scope, _ := op.Children[1].Int()
+	sizeLimit, _ := op.Children[3].Int()
+	filter := op.Children[6]
+	var attributes []string
+	for _, attr := range op.Children[7].Children {
+		attributes = append(attributes, strings.ToLower(attr.String()))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[base]; !ok && scope == ldap.ScopeBaseObject {
+		return []*ber.Packet{result(ldap.ApplicationSearchResultDone, ldap.ResultNoSuchObject, "no such object")}
+	}
+
+	var responses []*ber.Packet
+	for dn, entry := range s.entries {
+		if !inScope(dn, base, scope) || !match(filter, entry) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(responses)) == sizeLimit {
+			return append(responses, result(ldap.ApplicationSearchResultDone, ldap.ResultSizeLimitExceeded, "size limit exceeded"))
+		}
+		responses = append(responses, encodeEntry(entry, attributes))
+	}
+
+	return append(responses, result(ldap.ApplicationSearchResultDone, ldap.ResultSuccess, ""))
+}
+
+// inScope dn is within scope of base, both are lower case
+func inScope(dn string, base string, scope int64) bool {
+	switch scope {
+	case ldap.ScopeBaseObject:
+		return dn == base
+	case ldap.ScopeSingleLevel:
+		i := strings.IndexByte(dn, ',')
+		return i >= 0 && dn[i+1:] == base
+	default:
+		return base == "" || dn == base || strings.HasSuffix(dn, ","+base)
+	}
+}
+
+// match evaluate filter against entry, every entry has objectClass
+func match(filter *ber.Packet, entry *ldap.Entry) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !match(child, entry) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if match(child, entry) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return len(filter.Children) == 1 && !match(filter.Children[0], entry)
+	case ldap.FilterPresent:
+		name := strings.ToLower(filter.String())
+		return name == "objectclass" || len(entry.Attributes[name]) > 0
+	}
+
+	if len(filter.Children) < 2 {
+		return false
+	}
+	values := entry.Attributes[strings.ToLower(filter.Children[0].String())]
+	assertion := strings.ToLower(filter.Children[1].String())
+
+	for _, value := range values {
+		value = strings.ToLower(value)
+		switch filter.Tag {
+		case ldap.FilterEqualityMatch, ldap.FilterApproxMatch:
+			if value == assertion {
+				return true
+			}
+		case ldap.FilterGreaterOrEqual:
+			if value >= assertion {
+				return true
+			}
+		case ldap.FilterLessOrEqual:
+			if value <= assertion {
+				return true
+			}
+		case ldap.FilterSubstrings:
+			if matchSubstrings(filter.Children[1].Children, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchSubstrings(parts []*ber.Packet, value string) bool {
+	for _, part := range parts {
+		s := strings.ToLower(part.String())
+		switch part.Tag {
+		case ldap.SubstringInitial:
+			if !strings.HasPrefix(value, s) {
+				return false
+			}
+			value = value[len(s):]
+		case ldap.SubstringAny:
+			i := strings.Index(value, s)
+			if i < 0 {
+				return false
+			}
+			value = value[i+len(s):]
+		case ldap.SubstringFinal:
+			if !strings.HasSuffix(value, s) {
+				return false
+			}
+			value = ""
+		}
+	}
+	return true
+}
+
+// encodeEntry encode SearchResultEntry of requested attributes, empty request return all
+func encodeEntry(entry *ldap.Entry, attributes []string) *ber.Packet {
+	attrs := ber.NewConstructed(ber.TagSequence)
+	for name, values := range entry.Attributes {
+		if name == "userpassword" || (len(attributes) > 0 && !contains(attributes, name)) {
+			continue
+		}
+
+		set := ber.NewConstructed(ber.TagSet)
+		for _, value := range values {
+			set.Children = append(set.Children, ber.NewString(ber.TagOctetString, value))
+		}
+		attrs.Children = append(attrs.Children, ber.NewConstructed(ber.TagSequence, ber.NewString(ber.TagOctetString, name), set))
+	}
+
+	return ber.NewConstructed(ldap.ApplicationSearchResultEntry, ber.NewString(ber.TagOctetString, entry.DN), attrs)
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// result encode LDAPResult
+func result(tag byte, code int64, message string) *ber.Packet {
+	return ber.NewConstructed(
+		tag,
+		ber.NewInteger(ber.TagEnumerated, code),
+		ber.NewString(ber.TagOctetString, ""),
+		ber.NewString(ber.TagOctetString, message),
+	)
+}
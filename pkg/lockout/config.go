@@ -0,0 +1,21 @@
+package lockout
+
+import (
+	"time"
+)
+
+// Config for brute-force protection of signin
+type Config struct {
+	// MaxUsernameFailures is failures of one username before it is locked
+	MaxUsernameFailures int `mapstructure:"max_username_failures"`
+	// MaxIPFailures is failures from one ip address before it is locked,
+	// ip threshold should be higher since many users may share one address
+	MaxIPFailures int `mapstructure:"max_ip_failures"`
+	// Window is how long failures are remembered after last failure or lockout
+	Window time.Duration `mapstructure:"window"`
+	// BaseDuration is lockout of first time threshold reached,
+	// it is doubled by every further failure
+	BaseDuration time.Duration `mapstructure:"base_duration"`
+	// MaxDuration is upper bound of progressive lockout
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+}
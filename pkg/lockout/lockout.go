@@ -0,0 +1,170 @@
+package lockout
+
+import (
+	"context"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+const (
+	defaultMaxUsernameFailures = 5
+	defaultMaxIPFailures       = 50
+	defaultWindow              = 15 * time.Minute
+	defaultBaseDuration        = time.Minute
+	defaultMaxDuration         = time.Hour
+
+	usernameKeyPrefix = "username:"
+	ipKeyPrefix       = "ip:"
+)
+
+// Record define failure counter of one key
+type Record struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   time.Time
+}
+
+// IsLocked key is locked at the time
+func (r *Record) IsLocked(now time.Time) bool {
+	return now.Before(r.LockedUntil)
+}
+
+// Store persist failure counters
+type Store interface {
+	// Get record of key, key never failed return empty record
+	Get(ctx context.Context, key string) (record *Record, err error)
+
+	// Increment add one failure to key atomically, counter restart from one
+	// when last failure and lockout of key are both older than window
+	Increment(ctx context.Context, key string, now time.Time, window time.Duration) (record *Record, err error)
+
+	// Lock key until the time
+	Lock(ctx context.Context, key string, until time.Time) (err error)
+
+	// Reset clear failures and lockout of key
+	Reset(ctx context.Context, key string) (err error)
+}
+
+// Guard count signin failures per username and ip address,
+// lock them progressively once threshold reached
+type Guard struct {
+	config Config
+	store  Store
+	now    func() time.Time
+}
+
+// New guard from config
+func New(config Config, store Store) *Guard {
+	if config.MaxUsernameFailures <= 0 {
+		config.MaxUsernameFailures = defaultMaxUsernameFailures
+	}
+	if config.MaxIPFailures <= 0 {
+		config.MaxIPFailures = defaultMaxIPFailures
+	}
+	if config.Window <= 0 {
+		config.Window = defaultWindow
+	}
+	if config.BaseDuration <= 0 {
+		config.BaseDuration = defaultBaseDuration
+	}
+	if config.MaxDuration < config.BaseDuration {
+		config.MaxDuration = defaultMaxDuration
+		if config.MaxDuration < config.BaseDuration {
+			config.MaxDuration = config.BaseDuration
+		}
+	}
+
+	return &Guard{
+		config: config,
+		store:  store,
+		now:    time.Now,
+	}
+}
+
+// Check username and ip address are not locked,
+// locked one return ErrTooManyRequests with retry after hint
+func (g *Guard) Check(ctx context.Context, username string, ip string) error {
+	now := g.now()
+
+	for _, key := range keys(username, ip) {
+		record, err := g.store.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if record.IsLocked(now) {
+			return errors.Wrapf(
+				errors.ErrTooManyRequests.WithRetryAfter(record.LockedUntil.Sub(now)),
+				"lockout: %v is locked until %v",
+				key, record.LockedUntil,
+			)
+		}
+	}
+
+	return nil
+}
+
+// Fail record failed signin of username from ip address,
+// key reached threshold is locked and lockout doubles on every further failure
+func (g *Guard) Fail(ctx context.Context, username string, ip string) error {
+	now := g.now()
+
+	for _, key := range keys(username, ip) {
+		record, err := g.store.Increment(ctx, key, now, g.config.Window)
+		if err != nil {
+			return err
+		}
+
+		threshold := g.config.MaxUsernameFailures
+		if key == ipKeyPrefix+ip {
+			threshold = g.config.MaxIPFailures
+		}
+		if record.Failures < threshold {
+			continue
+		}
+
+		err = g.store.Lock(ctx, key, now.Add(g.duration(record.Failures-threshold)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Succeed reset failures of username after successful signin,
+// ip address counter is kept so one valid account can not be used
+// to clear failures of guessing others
+func (g *Guard) Succeed(ctx context.Context, username string) error {
+	return g.store.Reset(ctx, usernameKeyPrefix+username)
+}
+
+// Unlock clear failures and lockout of username
+func (g *Guard) Unlock(ctx context.Context, username string) error {
+	return g.store.Reset(ctx, usernameKeyPrefix+username)
+}
+
+// duration of lockout after n failures beyond threshold
+func (g *Guard) duration(n int) time.Duration {
+	d := g.config.BaseDuration
+	for i := 0; i < n; i++ {
+		d *= 2
+		if d >= g.config.MaxDuration {
+			return g.config.MaxDuration
+		}
+	}
+	return d
+}
+
+func keys(username string, ip string) []string {
+	list := make([]string, 0, 2)
+	if username != "" {
+		list = append(list, usernameKeyPrefix+username)
+	}
+	if ip != "" {
+		list = append(list, ipKeyPrefix+ip)
+	}
+	return list
+}
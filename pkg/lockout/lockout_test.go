@@ -0,0 +1,118 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+func newTestGuard(now *time.Time) *Guard {
+	g := New(Config{
+		MaxUsernameFailures: 3,
+		MaxIPFailures:       5,
+		Window:              15 * time.Minute,
+		BaseDuration:        time.Minute,
+		MaxDuration:         4 * time.Minute,
+	}, NewMemoryStore())
+	g.now = func() time.Time { return *now }
+	return g
+}
+
+func TestGuard_Fail(t *testing.T) {
+	tests := []struct {
+		name       string
+		failures   int
+		locked     bool
+		retryAfter time.Duration
+	}{
+		{name: "Below Threshold", failures: 2, locked: false},
+		{name: "Reach Threshold", failures: 3, locked: true, retryAfter: time.Minute},
+		{name: "Doubled", failures: 4, locked: true, retryAfter: 2 * time.Minute},
+		{name: "Capped", failures: 7, locked: true, retryAfter: 4 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Unix(1700000000, 0)
+			g := newTestGuard(&now)
+
+			for i := 0; i < tt.failures; i++ {
+				assert.NoError(t, g.Fail(ctx, "Username", "127.0.0.1"))
+			}
+
+			err := g.Check(ctx, "Username", "10.0.0.1")
+			if !tt.locked {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, errors.ErrTooManyRequests))
+			assert.Equal(t, tt.retryAfter, errors.TryConvert(err).RetryAfter())
+		})
+	}
+}
+
+func TestGuard_Check(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	g := newTestGuard(&now)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, g.Fail(ctx, "Username", "127.0.0.1"))
+	}
+	assert.True(t, errors.Is(g.Check(ctx, "Username", ""), errors.ErrTooManyRequests))
+
+	// lockout expired
+	now = now.Add(time.Minute)
+	assert.NoError(t, g.Check(ctx, "Username", ""))
+
+	// failure soon after lockout continue to grow
+	assert.NoError(t, g.Fail(ctx, "Username", "127.0.0.1"))
+	err := g.Check(ctx, "Username", "")
+	assert.Equal(t, 2*time.Minute, errors.TryConvert(err).RetryAfter())
+
+	// failures are forgotten after window
+	now = now.Add(2*time.Minute + 15*time.Minute + time.Second)
+	assert.NoError(t, g.Fail(ctx, "Username", "127.0.0.1"))
+	assert.NoError(t, g.Check(ctx, "Username", ""))
+}
+
+func TestGuard_IPAddress(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	g := newTestGuard(&now)
+
+	// spread over usernames only ip address reach threshold
+	for _, username := range []string{"a", "b", "c", "d", "e"} {
+		assert.NoError(t, g.Fail(ctx, username, "127.0.0.1"))
+	}
+
+	assert.NoError(t, g.Check(ctx, "f", "10.0.0.1"))
+	assert.True(t, errors.Is(g.Check(ctx, "f", "127.0.0.1"), errors.ErrTooManyRequests))
+
+	// success of one account does not clear ip address
+	assert.NoError(t, g.Succeed(ctx, "f"))
+	assert.True(t, errors.Is(g.Check(ctx, "f", "127.0.0.1"), errors.ErrTooManyRequests))
+}
+
+func TestGuard_Unlock(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	g := newTestGuard(&now)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, g.Fail(ctx, "Username", "127.0.0.1"))
+	}
+	assert.Error(t, g.Check(ctx, "Username", ""))
+
+	assert.NoError(t, g.Unlock(ctx, "Username"))
+	assert.NoError(t, g.Check(ctx, "Username", ""))
+
+	// counter restart after unlock
+	assert.NoError(t, g.Fail(ctx, "Username", "127.0.0.1"))
+	assert.NoError(t, g.Check(ctx, "Username", ""))
+}
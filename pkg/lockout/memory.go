@@ -0,0 +1,82 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keep counters in process memory, it is not shared
+// between instances and only suitable for tests or single node
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+// NewMemoryStore new empty memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]Record),
+	}
+}
+
+// Get is memory implement
+func (s *MemoryStore) Get(ctx context.Context, key string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		record = Record{Key: key}
+	}
+	return &record, nil
+}
+
+// Increment is memory implement
+func (s *MemoryStore) Increment(ctx context.Context, key string, now time.Time, window time.Duration) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		record = Record{Key: key}
+	}
+
+	lastActivity := record.LastFailureAt
+	if record.LockedUntil.After(lastActivity) {
+		lastActivity = record.LockedUntil
+	}
+	if now.Sub(lastActivity) > window {
+		record.Failures = 0
+	}
+
+	record.Failures++
+	record.LastFailureAt = now
+	s.records[key] = record
+
+	return &record, nil
+}
+
+// Lock is memory implement
+func (s *MemoryStore) Lock(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		record = Record{Key: key}
+	}
+	record.LockedUntil = until
+	s.records[key] = record
+
+	return nil
+}
+
+// Reset is memory implement
+func (s *MemoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
@@ -0,0 +1,140 @@
+package lockout
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// RecordDAO define failure counter row
+type RecordDAO struct {
+	Key           string `gorm:"column:key"`
+	Failures      int    `gorm:"column:failures"`
+	LastFailureAt int64  `gorm:"column:last_failure_at"`
+	LockedUntil   int64  `gorm:"column:locked_until"`
+}
+
+// TableName is RecordDAO implement table name for gorm
+func (r RecordDAO) TableName() string {
+	return "lockout_records"
+}
+
+// UnmarshalRecord unmarshal dao to record
+func UnmarshalRecord(dao *RecordDAO) *Record {
+	record := &Record{
+		Key:      dao.Key,
+		Failures: dao.Failures,
+	}
+	if dao.LastFailureAt != 0 {
+		record.LastFailureAt = time.UnixMilli(dao.LastFailureAt)
+	}
+	if dao.LockedUntil != 0 {
+		record.LockedUntil = time.UnixMilli(dao.LockedUntil)
+	}
+	return record
+}
+
+// SQLStore keep counters in lockout_records table shared by all instances
+type SQLStore struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+}
+
+// NewSQLStore SQLStore constructor
+func NewSQLStore(conn db.Connection) Store {
+	return &SQLStore{
+		readDB:  conn.ReadDB(),
+		writeDB: conn.WriteDB(),
+	}
+}
+
+// Get is SQL implement
+func (s *SQLStore) Get(ctx context.Context, key string) (*Record, error) {
+	var (
+		dao RecordDAO
+	)
+
+	// read from write db, lockout must be seen right after it is set
+	err := s.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Where("key = ?", key).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &Record{Key: key}, nil
+		}
+		return nil, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return UnmarshalRecord(&dao), nil
+}
+
+// Increment is SQL implement, counter is increased by upsert in one statement
+// so concurrent failures are never lost
+func (s *SQLStore) Increment(ctx context.Context, key string, now time.Time, window time.Duration) (*Record, error) {
+	dao := &RecordDAO{
+		Key:           key,
+		Failures:      1,
+		LastFailureAt: now.UnixMilli(),
+	}
+
+	err := s.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "key"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"failures": gorm.Expr(
+						"CASE WHEN GREATEST(lockout_records.last_failure_at, lockout_records.locked_until) < ? THEN 1 ELSE lockout_records.failures + 1 END",
+						now.Add(-window).UnixMilli(),
+					),
+					"last_failure_at": now.UnixMilli(),
+				}),
+			},
+			clause.Returning{},
+		).
+		Create(dao).
+		Error
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "failed to increment lockout failures of key=%v, err %v", key, err)
+	}
+
+	return UnmarshalRecord(dao), nil
+}
+
+// Lock is SQL implement
+func (s *SQLStore) Lock(ctx context.Context, key string, until time.Time) error {
+	err := s.writeDB.
+		WithContext(ctx).
+		Model(RecordDAO{}).
+		Where("key = ?", key).
+		UpdateColumn("locked_until", until.UnixMilli()).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to lock key=%v, err %v", key, err)
+	}
+
+	return nil
+}
+
+// Reset is SQL implement
+func (s *SQLStore) Reset(ctx context.Context, key string) error {
+	err := s.writeDB.
+		WithContext(ctx).
+		Where("key = ?", key).
+		Delete(&RecordDAO{}).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to reset lockout of key=%v, err %v", key, err)
+	}
+
+	return nil
+}
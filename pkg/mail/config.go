@@ -0,0 +1,25 @@
+package mail
+
+// Config for mail delivery
+type Config struct {
+	// Driver is smtp, file or log, default is log when Local is set
+	Driver string `mapstructure:"driver"`
+	// Local allow log and file driver, only for local development
+	// since mails written by them are never delivered to users
+	Local bool `mapstructure:"local"`
+	// From is sender address of every mail
+	From string `mapstructure:"from"`
+	// SMTP server of smtp driver
+	SMTP SMTPConfig `mapstructure:"smtp"`
+	// Dir is where file driver write mails
+	Dir string `mapstructure:"dir"`
+}
+
+// SMTPConfig define smtp server
+type SMTPConfig struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+	// Username and Password are used for PLAIN auth, empty skip auth
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
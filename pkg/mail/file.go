@@ -0,0 +1,48 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// FileMailer write every mail as .eml file into directory,
+// useful to read mails in local development and tests
+type FileMailer struct {
+	config Config
+}
+
+// NewFileMailer new file mailer, directory is created if not exist
+func NewFileMailer(config Config) (*FileMailer, error) {
+	if config.Dir == "" {
+		config.Dir = filepath.Join(os.TempDir(), "iam-mail")
+	}
+
+	err := os.MkdirAll(config.Dir, 0o700)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "mail: failed to create dir=%v err %v", config.Dir, err)
+	}
+
+	return &FileMailer{
+		config: config,
+	}, nil
+}
+
+// Send is file implement
+func (m *FileMailer) Send(ctx context.Context, msg *Message) error {
+	now := time.Now()
+	name := strconv.FormatInt(now.UnixNano(), 10) + "-" + xid.New().String() + ".eml"
+
+	err := os.WriteFile(filepath.Join(m.config.Dir, name), encode(m.config.From, msg, now), 0o600)
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "mail: failed to write mail to %v err %v", msg.To, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LogMailer write mail into application log instead of delivering it,
+// mail content may contain secret link so it is only for local development
+type LogMailer struct {
+	config Config
+}
+
+// NewLogMailer new log mailer
+func NewLogMailer(config Config) *LogMailer {
+	return &LogMailer{
+		config: config,
+	}
+}
+
+// Send is log implement
+func (m *LogMailer) Send(ctx context.Context, msg *Message) error {
+	log.Ctx(ctx).
+		Info().
+		Str("from", m.config.From).
+		Str("to", msg.To).
+		Str("subject", msg.Subject).
+		Str("text", msg.Text).
+		Msg("mail is not delivered by log driver")
+	return nil
+}
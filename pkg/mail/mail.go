@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+const (
+	// DriverSMTP deliver mail through smtp server
+	DriverSMTP = "smtp"
+	// DriverFile write mail into directory
+	DriverFile = "file"
+	// DriverLog write mail into application log
+	DriverLog = "log"
+)
+
+// Message define plain text mail
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+}
+
+// Mailer deliver mail
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) (err error)
+}
+
+// New mailer selected by config driver
+func New(config Config) (Mailer, error) {
+	if config.Driver != DriverSMTP && !config.Local {
+		return nil, errors.Wrapf(errors.ErrInternal, "mail: driver=%q never deliver mail, use smtp driver", config.Driver)
+	}
+
+	switch config.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(config), nil
+	case DriverFile:
+		return NewFileMailer(config)
+	case DriverLog, "":
+		return NewLogMailer(config), nil
+	default:
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "mail: driver=%v is not supported", config.Driver)
+	}
+}
+
+// encode message as RFC 5322 mail
+func encode(from string, msg *Message, date time.Time) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Text, "\n", "\r\n"))
+
+	return b.Bytes()
+}
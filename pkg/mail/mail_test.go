@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := t.TempDir()
+	mailer, err := New(Config{Driver: DriverFile, Local: true, From: "IAM <no-reply@localhost>", Dir: dir})
+	assert.NoError(t, err)
+
+	err = mailer.Send(context.Background(), &Message{
+		To:      "mock@gmail.com",
+		Subject: "Verify your email address",
+		Text:    "line 1\nline 2",
+	})
+	assert.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	b, err := os.ReadFile(dir + "/" + entries[0].Name())
+	assert.NoError(t, err)
+
+	content := string(b)
+	assert.True(t, strings.HasPrefix(content, "From: IAM <no-reply@localhost>\r\nTo: mock@gmail.com\r\n"))
+	assert.Contains(t, content, "Subject: Verify your email address\r\n")
+	assert.True(t, strings.HasSuffix(content, "\r\n\r\nline 1\r\nline 2"))
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{Driver: "pigeon", Local: true})
+	assert.Error(t, err)
+
+	_, err = New(Config{})
+	assert.Error(t, err)
+
+	_, err = New(Config{Driver: DriverFile, Dir: t.TempDir()})
+	assert.Error(t, err)
+
+	mailer, err := New(Config{Local: true})
+	assert.NoError(t, err)
+	assert.IsType(t, &LogMailer{}, mailer)
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// SMTPMailer deliver mail through smtp server
+type SMTPMailer struct {
+	config Config
+}
+
+// NewSMTPMailer new smtp mailer
+func NewSMTPMailer(config Config) *SMTPMailer {
+	return &SMTPMailer{
+		config: config,
+	}
+}
+
+// Send is smtp implement
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	var (
+		auth smtp.Auth
+	)
+
+	if m.config.SMTP.Username != "" {
+		auth = smtp.PlainAuth("", m.config.SMTP.Username, m.config.SMTP.Password, m.config.SMTP.Host)
+	}
+
+	addr := net.JoinHostPort(m.config.SMTP.Host, strconv.Itoa(m.config.SMTP.Port))
+	err := smtp.SendMail(addr, auth, m.config.From, []string{msg.To}, encode(m.config.From, msg, time.Now()))
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "mail: failed to send mail to %v through %v err %v", msg.To, addr, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package oidc
+
+// Config for OpenID Connect provider
+type Config struct {
+	// Issuer is the iss claim of token and the base URL of discovery document
+	Issuer string `mapstructure:"issuer"`
+	// Audience is the aud claim of id token issued by direct signin
+	Audience string `mapstructure:"audience"`
+	// LoginURL is the page user sign in and consent authorization request,
+	// authorization endpoint redirect user agent to it with original query
+	LoginURL string `mapstructure:"login_url"`
+}
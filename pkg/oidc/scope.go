@@ -0,0 +1,56 @@
+package oidc
+
+import (
+	"strings"
+)
+
+const (
+	// ScopeOpenID request id token
+	ScopeOpenID = "openid"
+	// ScopeProfile request default profile claims
+	ScopeProfile = "profile"
+	// ScopeEmail request email claims
+	ScopeEmail = "email"
+	// ScopeAdmin grant access to administrative api
+	ScopeAdmin = "iam:admin"
+	// ScopeIntrospect grant client introspecting token of other principal
+	ScopeIntrospect = "iam:introspect"
+	// ScopeCheckPermission grant client checking rbac permission of user
+	ScopeCheckPermission = "iam:check_permission"
+	// ScopeAuthorize grant client evaluating policies of user
+	ScopeAuthorize = "iam:authorize"
+)
+
+const (
+	// AuthMethodPassword amr value of password authentication
+	AuthMethodPassword = "pwd"
+	// AuthMethodFederated amr value of external identity provider authentication
+	AuthMethodFederated = "fed"
+	// AuthMethodProofOfPossession amr value of passkey authentication
+	AuthMethodProofOfPossession = "pop"
+	// AuthMethodOTP amr value of one-time password second factor
+	AuthMethodOTP = "otp"
+)
+
+// Scopes space-delimited scope list defined by RFC 6749 section 3.3
+type Scopes []string
+
+// ParseScopes split scope string by space
+func ParseScopes(scope string) Scopes {
+	return strings.Fields(scope)
+}
+
+// Has check scope is requested
+func (s Scopes) Has(scope string) bool {
+	for _, v := range s {
+		if v == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// String join scopes by space
+func (s Scopes) String() string {
+	return strings.Join(s, " ")
+}
@@ -0,0 +1,67 @@
+package password
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+type bcryptHasher struct {
+	cost int
+}
+
+// NewBcrypt bcrypt hasher, zero cost means bcrypt.DefaultCost
+// bcrypt hash keep its native modular crypt format $2a$<cost>$<salt+hash>
+func NewBcrypt(cost int) Hasher {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	return &bcryptHasher{cost: cost}
+}
+
+// Hash is Hasher implement
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", errors.Wrapf(errors.ErrInternal, "password: failed to bcrypt password err %v", err)
+	}
+	return string(hashed), nil
+}
+
+// Verify is Hasher implement
+func (h *bcryptHasher) Verify(password string, encoded string) (bool, error) {
+	return Verify(password, encoded)
+}
+
+// NeedsRehash is Hasher implement
+func (h *bcryptHasher) NeedsRehash(encoded string) bool {
+	if !isBcrypt(encoded) {
+		return true
+	}
+
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return true
+	}
+
+	return cost != h.cost
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+func verifyBcrypt(password string, encoded string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		return false, errors.Wrapf(errors.ErrInternal, "password: invalid bcrypt hash err %v", err)
+	}
+	return true, nil
+}
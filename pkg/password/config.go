@@ -0,0 +1,18 @@
+package password
+
+const (
+	// AlgorithmArgon2id hash password with argon2id
+	AlgorithmArgon2id = "argon2id"
+	// AlgorithmBcrypt hash password with bcrypt
+	AlgorithmBcrypt = "bcrypt"
+)
+
+// Config for choose the algorithm hashing new password
+type Config struct {
+	// Algorithm argon2id or bcrypt, default is argon2id
+	Algorithm string `mapstructure:"algorithm"`
+	// Argon2id parameters
+	Argon2id Argon2idParams `mapstructure:"argon2id"`
+	// BcryptCost bcrypt cost
+	BcryptCost int `mapstructure:"bcrypt_cost"`
+}
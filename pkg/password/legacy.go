@@ -0,0 +1,22 @@
+package password
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// isLegacySHA256 hash produced before adaptive hashing,
+// it is unsalted sha256 hex digest
+func isLegacySHA256(encoded string) bool {
+	if len(encoded) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(encoded)
+	return err == nil
+}
+
+func verifyLegacySHA256(password string, encoded string) bool {
+	h := sha256.Sum256([]byte(password))
+	return subtle.ConstantTimeCompare([]byte(hex.EncodeToString(h[:])), []byte(encoded)) == 1
+}
@@ -0,0 +1,56 @@
+package password
+
+import (
+	"strings"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// Hasher hash and verify password
+// encoded hash carry algorithm and parameters so
+// hash produced by old setting still can be verified
+type Hasher interface {
+	// Hash encode password to PHC string format
+	Hash(password string) (encoded string, err error)
+
+	// Verify check password match encoded hash
+	Verify(password string, encoded string) (ok bool, err error)
+
+	// NeedsRehash report encoded hash is not produced by
+	// this hasher algorithm with current parameters
+	NeedsRehash(encoded string) bool
+}
+
+// Default hasher use argon2id with default parameters
+var Default Hasher = NewArgon2id(DefaultArgon2idParams)
+
+// New hasher from config
+func New(config Config) (Hasher, error) {
+	switch config.Algorithm {
+	case "", AlgorithmArgon2id:
+		params := config.Argon2id
+		if params == (Argon2idParams{}) {
+			params = DefaultArgon2idParams
+		}
+		return NewArgon2id(params), nil
+	case AlgorithmBcrypt:
+		return NewBcrypt(config.BcryptCost), nil
+	default:
+		return nil, errors.Wrapf(errors.ErrInternal, "password: unsupported algorithm %v", config.Algorithm)
+	}
+}
+
+// Verify check password match encoded hash of any supported format,
+// include legacy unsalted sha256 hex digest
+func Verify(password string, encoded string) (bool, error) {
+	switch {
+	case strings.HasPrefix(encoded, "$"+AlgorithmArgon2id+"$"):
+		return verifyArgon2id(password, encoded)
+	case isBcrypt(encoded):
+		return verifyBcrypt(password, encoded)
+	case isLegacySHA256(encoded):
+		return verifyLegacySHA256(password, encoded), nil
+	default:
+		return false, errors.Wrap(errors.ErrInternal, "password: unknown hash format")
+	}
+}
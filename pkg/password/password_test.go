@@ -0,0 +1,138 @@
+package password_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/password"
+)
+
+// cheapArgon2idParams keep test fast
+var cheapArgon2idParams = password.Argon2idParams{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHasher_HashAndVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		hasher password.Hasher
+		prefix string
+	}{
+		{
+			name:   "Argon2id",
+			hasher: password.NewArgon2id(cheapArgon2idParams),
+			prefix: "$argon2id$v=19$m=1024,t=1,p=1$",
+		},
+		{
+			name:   "Bcrypt",
+			hasher: password.NewBcrypt(4),
+			prefix: "$2a$04$",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.hasher.Hash("A12345678")
+			assert.NoError(t, err)
+			assert.Contains(t, encoded, tt.prefix)
+
+			ok, err := password.Verify("A12345678", encoded)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = password.Verify("WrongPassword", encoded)
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			// same password get different hash since salt is random
+			other, _ := tt.hasher.Hash("A12345678")
+			assert.NotEqual(t, encoded, other)
+
+			assert.False(t, tt.hasher.NeedsRehash(encoded))
+		})
+	}
+}
+
+func TestVerify_LegacySHA256(t *testing.T) {
+	// sha256 hex digest of A12345678
+	legacy := "3b4e266a89805c9d020f9aca6638ad63e8701fc8c75c0ca1952d14054d1f10cf"
+
+	ok, err := password.Verify("A12345678", legacy)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = password.Verify("WrongPassword", legacy)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.True(t, password.NewArgon2id(cheapArgon2idParams).NeedsRehash(legacy))
+	assert.True(t, password.NewBcrypt(4).NeedsRehash(legacy))
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	argon2id, _ := password.NewArgon2id(cheapArgon2idParams).Hash("A12345678")
+	bcrypt, _ := password.NewBcrypt(4).Hash("A12345678")
+
+	stronger := cheapArgon2idParams
+	stronger.Iterations = 2
+
+	tests := []struct {
+		name     string
+		hasher   password.Hasher
+		encoded  string
+		expected bool
+	}{
+		{
+			name:     "Argon2id Parameters Changed",
+			hasher:   password.NewArgon2id(stronger),
+			encoded:  argon2id,
+			expected: true,
+		},
+		{
+			name:     "Bcrypt Cost Changed",
+			hasher:   password.NewBcrypt(5),
+			encoded:  bcrypt,
+			expected: true,
+		},
+		{
+			name:     "Bcrypt To Argon2id",
+			hasher:   password.NewArgon2id(cheapArgon2idParams),
+			encoded:  bcrypt,
+			expected: true,
+		},
+		{
+			name:     "Argon2id To Bcrypt",
+			hasher:   password.NewBcrypt(4),
+			encoded:  argon2id,
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.hasher.NeedsRehash(tt.encoded))
+		})
+	}
+}
+
+func TestVerify_UnknownFormat(t *testing.T) {
+	_, err := password.Verify("A12345678", "plaintext")
+	assert.Error(t, err)
+
+	_, err = password.Verify("A12345678", "$argon2id$v=19$m=bad$salt$key")
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	_, err := password.New(password.Config{Algorithm: "md5"})
+	assert.Error(t, err)
+
+	hasher, err := password.New(password.Config{Algorithm: password.AlgorithmBcrypt, BcryptCost: 4})
+	assert.NoError(t, err)
+
+	encoded, _ := hasher.Hash("A12345678")
+	assert.Contains(t, encoded, "$2a$04$")
+}
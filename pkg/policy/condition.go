@@ -0,0 +1,140 @@
+package policy
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// KeySourceIP condition key of client ip address
+	KeySourceIP = "iam:SourceIp"
+	// KeyCurrentTime condition key of evaluation time in RFC 3339
+	KeyCurrentTime = "iam:CurrentTime"
+	// KeyUserID condition key of user being authorized
+	KeyUserID = "iam:UserId"
+	// KeyResourcePrefix prefix of resource attribute condition key,
+	// like "resource:owner", attributes are supplied by caller
+	KeyResourcePrefix = "resource:"
+)
+
+// operator compare the request value with one accepted value of condition
+type operator func(actual string, expected string) bool
+
+var operators = map[string]operator{
+	"StringEquals":           func(a, e string) bool { return a == e },
+	"StringNotEquals":        func(a, e string) bool { return a != e },
+	"StringEqualsIgnoreCase": func(a, e string) bool { return strings.EqualFold(a, e) },
+	"StringLike":             func(a, e string) bool { return Match(e, a) },
+	"StringNotLike":          func(a, e string) bool { return !Match(e, a) },
+	"IpAddress":              ipAddress,
+	"NotIpAddress":           func(a, e string) bool { return !ipAddress(a, e) },
+	"DateGreaterThan":        func(a, e string) bool { return compareDate(a, e, func(c int) bool { return c > 0 }) },
+	"DateLessThan":           func(a, e string) bool { return compareDate(a, e, func(c int) bool { return c < 0 }) },
+	"NumericEquals":          func(a, e string) bool { return compareNumber(a, e, func(c int) bool { return c == 0 }) },
+	"NumericLessThan":        func(a, e string) bool { return compareNumber(a, e, func(c int) bool { return c < 0 }) },
+	"NumericGreaterThan":     func(a, e string) bool { return compareNumber(a, e, func(c int) bool { return c > 0 }) },
+	"Bool":                   func(a, e string) bool { return strings.EqualFold(a, e) },
+}
+
+// negated operators must hold for every accepted value instead of any
+var negated = map[string]bool{
+	"StringNotEquals": true,
+	"StringNotLike":   true,
+	"NotIpAddress":    true,
+}
+
+// matchCondition every operator and key of condition hold for request context,
+// key absent from context match negated operator only, so caller omitting key
+// can not escape Deny statement like StringNotEquals
+func matchCondition(condition Condition, context map[string]string) bool {
+	for name, keys := range condition {
+		op := operators[name]
+		for key, values := range keys {
+			actual, ok := context[key]
+			if !ok {
+				if !negated[name] {
+					return false
+				}
+				continue
+			}
+
+			if !matchValues(op, negated[name], actual, values) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchValues(op operator, negated bool, actual string, values Values) bool {
+	for _, expected := range values {
+		matched := op(actual, expected)
+		if negated && !matched {
+			return false
+		}
+		if !negated && matched {
+			return true
+		}
+	}
+	return negated
+}
+
+// ipAddress ip address is in CIDR block or equal to single address
+func ipAddress(actual string, expected string) bool {
+	ip := net.ParseIP(actual)
+	if ip == nil {
+		return false
+	}
+
+	if !strings.Contains(expected, "/") {
+		return ip.Equal(net.ParseIP(expected))
+	}
+
+	_, block, err := net.ParseCIDR(expected)
+	if err != nil {
+		return false
+	}
+	return block.Contains(ip)
+}
+
+func compareDate(actual string, expected string, accept func(int) bool) bool {
+	a, err := time.Parse(time.RFC3339, actual)
+	if err != nil {
+		return false
+	}
+	e, err := time.Parse(time.RFC3339, expected)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case a.After(e):
+		return accept(1)
+	case a.Before(e):
+		return accept(-1)
+	default:
+		return accept(0)
+	}
+}
+
+func compareNumber(actual string, expected string, accept func(int) bool) bool {
+	a, err := strconv.ParseFloat(actual, 64)
+	if err != nil {
+		return false
+	}
+	e, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case a > e:
+		return accept(1)
+	case a < e:
+		return accept(-1)
+	default:
+		return accept(0)
+	}
+}
@@ -0,0 +1,134 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+const (
+	// Version2023 the only supported policy language version
+	Version2023 = "2023-06-01"
+)
+
+// Effect of statement, explicit deny always win over allow
+type Effect string
+
+const (
+	// EffectAllow statement allow matched request
+	EffectAllow Effect = "Allow"
+	// EffectDeny statement deny matched request
+	EffectDeny Effect = "Deny"
+)
+
+// Values is JSON string or array of string
+type Values []string
+
+// UnmarshalJSON accept a single string as one element array
+func (v *Values) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*v = Values{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return errors.Wrap(errors.ErrInvalidInput, "value must be string or array of string")
+	}
+	*v = multiple
+
+	return nil
+}
+
+// Condition operator to condition key to accepted values,
+// every operator and key must match, any value of key can match
+type Condition map[string]map[string]Values
+
+// Statement define one allow or deny rule
+type Statement struct {
+	// Sid optional statement id returned in decision for debugging
+	Sid string `json:"Sid,omitempty"`
+	// Effect Allow or Deny
+	Effect Effect `json:"Effect"`
+	// Action patterns like "documents:Read" or "documents:*"
+	Action Values `json:"Action"`
+	// Resource patterns like "documents/123" or "documents/*"
+	Resource Values `json:"Resource"`
+	// Condition restrict statement to request context
+	Condition Condition `json:"Condition,omitempty"`
+}
+
+// Document define JSON policy document
+type Document struct {
+	Version   string      `json:"Version"`
+	Statement []Statement `json:"Statement"`
+}
+
+// Parse decode and validate JSON policy document,
+// unknown field is rejected to catch typo of policy author
+func Parse(data []byte) (*Document, error) {
+	var document Document
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&document)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "policy document is invalid reason %v", err)
+	}
+
+	err = document.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+// Validate check document is evaluable
+func (d *Document) Validate() error {
+	if d.Version != Version2023 {
+		return errors.Wrapf(errors.ErrInvalidInput, "policy version=%v is not supported", d.Version)
+	}
+
+	if len(d.Statement) == 0 {
+		return errors.Wrap(errors.ErrInvalidInput, "policy has no statement")
+	}
+
+	for i, statement := range d.Statement {
+		if statement.Effect != EffectAllow && statement.Effect != EffectDeny {
+			return errors.Wrapf(errors.ErrInvalidInput, "statement[%v] effect=%v is invalid", i, statement.Effect)
+		}
+
+		if len(statement.Action) == 0 || len(statement.Resource) == 0 {
+			return errors.Wrapf(errors.ErrInvalidInput, "statement[%v] action and resource are required", i)
+		}
+
+		for _, action := range statement.Action {
+			if action != "*" && !strings.Contains(action, ":") {
+				return errors.Wrapf(errors.ErrInvalidInput, "statement[%v] action=%v is not service:action", i, action)
+			}
+		}
+
+		for operator, keys := range statement.Condition {
+			if _, ok := operators[operator]; !ok {
+				return errors.Wrapf(errors.ErrInvalidInput, "statement[%v] condition operator=%v is not supported", i, operator)
+			}
+			for key, values := range keys {
+				if len(values) == 0 {
+					return errors.Wrapf(errors.ErrInvalidInput, "statement[%v] condition key=%v has no value", i, key)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// String encode document as JSON
+func (d *Document) String() string {
+	b, _ := json.Marshal(d)
+	return string(b)
+}
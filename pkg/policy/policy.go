@@ -0,0 +1,155 @@
+// Package policy evaluate IAM-style JSON policy documents.
+//
+// A request is allowed when any statement allow it and no statement deny it,
+// explicit deny always win and request matched no statement is implicitly denied.
+package policy
+
+import (
+	"strings"
+	"time"
+)
+
+// Policy define named document being evaluated
+type Policy struct {
+	ID       string
+	Document *Document
+}
+
+// Request define action on resource being authorized
+type Request struct {
+	// Action like "documents:Read"
+	Action string
+	// Resource like "documents/123"
+	Resource string
+	// Context condition keys like "iam:SourceIp" and "resource:owner"
+	Context map[string]string
+	// Time evaluation time as "iam:CurrentTime", it always replace the key of context,
+	// default is now
+	Time time.Time
+}
+
+// MatchedStatement statement matched request, returned for debugging
+type MatchedStatement struct {
+	PolicyID string
+	Sid      string
+	// Index of statement in policy document
+	Index  int
+	Effect Effect
+}
+
+// Decision result of evaluation
+type Decision struct {
+	// Allowed request is allowed
+	Allowed bool
+	// Effect Allow, Deny or empty when request is implicitly denied
+	Effect Effect
+	// Statements matched request, deny statements decide the result when present
+	Statements []MatchedStatement
+}
+
+// Evaluate policies against request with explicit-deny precedence
+func Evaluate(req *Request, policies ...*Policy) *Decision {
+	context := make(map[string]string, len(req.Context)+1)
+	for key, value := range req.Context {
+		context[key] = value
+	}
+
+	// time is controlled by server, otherwise caller escape date condition
+	now := req.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	context[KeyCurrentTime] = now.UTC().Format(time.RFC3339)
+
+	var (
+		allows []MatchedStatement
+		denies []MatchedStatement
+	)
+
+	for _, policy := range policies {
+		for i, statement := range policy.Document.Statement {
+			if !matchStatement(&statement, req, context) {
+				continue
+			}
+
+			matched := MatchedStatement{
+				PolicyID: policy.ID,
+				Sid:      statement.Sid,
+				Index:    i,
+				Effect:   statement.Effect,
+			}
+			if statement.Effect == EffectDeny {
+				denies = append(denies, matched)
+			} else {
+				allows = append(allows, matched)
+			}
+		}
+	}
+
+	switch {
+	case len(denies) != 0:
+		return &Decision{Allowed: false, Effect: EffectDeny, Statements: denies}
+	case len(allows) != 0:
+		return &Decision{Allowed: true, Effect: EffectAllow, Statements: allows}
+	default:
+		return &Decision{Allowed: false}
+	}
+}
+
+func matchStatement(statement *Statement, req *Request, context map[string]string) bool {
+	if !matchAny(statement.Action, req.Action, true) {
+		return false
+	}
+
+	if !matchAny(statement.Resource, req.Resource, false) {
+		return false
+	}
+
+	return matchCondition(statement.Condition, context)
+}
+
+func matchAny(patterns Values, value string, ignoreCase bool) bool {
+	for _, pattern := range patterns {
+		if ignoreCase {
+			if Match(strings.ToLower(pattern), strings.ToLower(value)) {
+				return true
+			}
+			continue
+		}
+
+		if Match(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match glob pattern, "*" match any sequence and "?" match one character
+func Match(pattern string, value string) bool {
+	p, v := 0, 0
+	star, mark := -1, 0
+
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]):
+			p++
+			v++
+		case p < len(pattern) && pattern[p] == '*':
+			star, mark = p, v
+			p++
+		case star != -1:
+			// backtrack, let the last star consume one more character
+			p = star + 1
+			mark++
+			v = mark
+		default:
+			return false
+		}
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
@@ -0,0 +1,286 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const documentsPolicy = `{
+	"Version": "2023-06-01",
+	"Statement": [
+		{
+			"Sid": "ReadDocuments",
+			"Effect": "Allow",
+			"Action": ["documents:Get*", "documents:List*"],
+			"Resource": "documents/*"
+		},
+		{
+			"Sid": "WriteOwnDocumentsFromOffice",
+			"Effect": "Allow",
+			"Action": "documents:Update",
+			"Resource": "documents/*",
+			"Condition": {
+				"IpAddress": {"iam:SourceIp": ["10.0.0.0/8", "192.168.1.1"]},
+				"StringEquals": {"resource:owner": "MOCK-USER-ID"}
+			}
+		},
+		{
+			"Sid": "DenySecret",
+			"Effect": "Deny",
+			"Action": "documents:*",
+			"Resource": "documents/secret-*"
+		}
+	]
+}`
+
+const maintenancePolicy = `{
+	"Version": "2023-06-01",
+	"Statement": [
+		{
+			"Sid": "DenyDuringMaintenance",
+			"Effect": "Deny",
+			"Action": "*",
+			"Resource": "*",
+			"Condition": {
+				"DateGreaterThan": {"iam:CurrentTime": "2026-10-18T00:00:00Z"},
+				"DateLessThan": {"iam:CurrentTime": "2026-10-18T02:00:00Z"}
+			}
+		}
+	]
+}`
+
+func TestEvaluate(t *testing.T) {
+	documents, err := Parse([]byte(documentsPolicy))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		req        *Request
+		allowed    bool
+		effect     Effect
+		statements []string
+	}{
+		{
+			name:       "Allow Wildcard Action",
+			req:        &Request{Action: "documents:GetDocument", Resource: "documents/123"},
+			allowed:    true,
+			effect:     EffectAllow,
+			statements: []string{"ReadDocuments"},
+		},
+		{
+			name:       "Action Ignore Case",
+			req:        &Request{Action: "Documents:listDocuments", Resource: "documents/123"},
+			allowed:    true,
+			effect:     EffectAllow,
+			statements: []string{"ReadDocuments"},
+		},
+		{
+			name:       "Explicit Deny Win",
+			req:        &Request{Action: "documents:GetDocument", Resource: "documents/secret-1"},
+			allowed:    false,
+			effect:     EffectDeny,
+			statements: []string{"DenySecret"},
+		},
+		{
+			name:    "Implicit Deny",
+			req:     &Request{Action: "documents:Delete", Resource: "documents/123"},
+			allowed: false,
+		},
+		{
+			name: "Condition Match",
+			req: &Request{
+				Action:   "documents:Update",
+				Resource: "documents/123",
+				Context: map[string]string{
+					KeySourceIP:      "10.1.2.3",
+					"resource:owner": "MOCK-USER-ID",
+				},
+			},
+			allowed:    true,
+			effect:     EffectAllow,
+			statements: []string{"WriteOwnDocumentsFromOffice"},
+		},
+		{
+			name: "Condition IP Mismatch",
+			req: &Request{
+				Action:   "documents:Update",
+				Resource: "documents/123",
+				Context: map[string]string{
+					KeySourceIP:      "172.16.0.1",
+					"resource:owner": "MOCK-USER-ID",
+				},
+			},
+			allowed: false,
+		},
+		{
+			name: "Condition Key Absent",
+			req: &Request{
+				Action:   "documents:Update",
+				Resource: "documents/123",
+				Context: map[string]string{
+					KeySourceIP: "192.168.1.1",
+				},
+			},
+			allowed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Time = now
+			actual := Evaluate(tt.req, &Policy{ID: "documents", Document: documents})
+			assert.Equal(t, tt.allowed, actual.Allowed)
+			assert.Equal(t, tt.effect, actual.Effect)
+
+			var sids []string
+			for _, statement := range actual.Statements {
+				assert.Equal(t, "documents", statement.PolicyID)
+				sids = append(sids, statement.Sid)
+			}
+			assert.Equal(t, tt.statements, sids)
+		})
+	}
+}
+
+func TestEvaluate_TimeWindow(t *testing.T) {
+	documents, _ := Parse([]byte(documentsPolicy))
+	maintenance, err := Parse([]byte(maintenancePolicy))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	policies := []*Policy{
+		{ID: "documents", Document: documents},
+		{ID: "maintenance", Document: maintenance},
+	}
+
+	req := &Request{
+		Action:   "documents:GetDocument",
+		Resource: "documents/123",
+		Time:     time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC),
+	}
+	actual := Evaluate(req, policies...)
+	assert.False(t, actual.Allowed)
+	assert.Equal(t, "maintenance", actual.Statements[0].PolicyID)
+
+	// time supplied by caller can not move request out of window
+	req.Context = map[string]string{KeyCurrentTime: "2026-10-18T03:00:00Z"}
+	actual = Evaluate(req, policies...)
+	assert.False(t, actual.Allowed)
+
+	req.Time = time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)
+	actual = Evaluate(req, policies...)
+	assert.True(t, actual.Allowed)
+}
+
+const outsideOfficePolicy = `{
+	"Version": "2023-06-01",
+	"Statement": [
+		{
+			"Sid": "DenyOutsideOffice",
+			"Effect": "Deny",
+			"Action": "documents:*",
+			"Resource": "*",
+			"Condition": {
+				"NotIpAddress": {"iam:SourceIp": "10.0.0.0/8"}
+			}
+		},
+		{
+			"Sid": "DenyOtherDepartment",
+			"Effect": "Deny",
+			"Action": "documents:*",
+			"Resource": "*",
+			"Condition": {
+				"StringNotEquals": {"resource:department": "sales"}
+			}
+		}
+	]
+}`
+
+func TestEvaluate_NegatedConditionKeyAbsent(t *testing.T) {
+	documents, _ := Parse([]byte(documentsPolicy))
+	outsideOffice, err := Parse([]byte(outsideOfficePolicy))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	policies := []*Policy{
+		{ID: "documents", Document: documents},
+		{ID: "outside-office", Document: outsideOffice},
+	}
+
+	// caller omitting key can not escape deny
+	actual := Evaluate(&Request{Action: "documents:GetDocument", Resource: "documents/123"}, policies...)
+	assert.False(t, actual.Allowed)
+	assert.Equal(t, EffectDeny, actual.Effect)
+	assert.Len(t, actual.Statements, 2)
+
+	actual = Evaluate(&Request{
+		Action:   "documents:GetDocument",
+		Resource: "documents/123",
+		Context: map[string]string{
+			KeySourceIP:           "10.1.2.3",
+			"resource:department": "sales",
+		},
+	}, policies...)
+	assert.True(t, actual.Allowed)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		wantErr  bool
+	}{
+		{
+			name:     "Valid",
+			document: documentsPolicy,
+			wantErr:  false,
+		},
+		{
+			name:     "Unknown Version",
+			document: `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "*", "Resource": "*"}]}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid Effect",
+			document: `{"Version": "2023-06-01", "Statement": [{"Effect": "Permit", "Action": "*", "Resource": "*"}]}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Missing Resource",
+			document: `{"Version": "2023-06-01", "Statement": [{"Effect": "Allow", "Action": "*"}]}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Unknown Operator",
+			document: `{"Version": "2023-06-01", "Statement": [{"Effect": "Allow", "Action": "*", "Resource": "*", "Condition": {"Like": {"a": "b"}}}]}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Unknown Field",
+			document: `{"Version": "2023-06-01", "Statement": [{"Effect": "Allow", "Actions": "*", "Resource": "*"}]}`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.document)); (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	assert.True(t, Match("documents/*", "documents/a/b"))
+	assert.True(t, Match("*", ""))
+	assert.True(t, Match("doc?ments/*-1", "documents/secret-1"))
+	assert.False(t, Match("documents/*-1", "documents/secret-2"))
+	assert.False(t, Match("documents", "documents/1"))
+}
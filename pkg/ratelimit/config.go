@@ -0,0 +1,34 @@
+package ratelimit
+
+import (
+	"time"
+)
+
+const (
+	// StoreMemory keep counters in process memory
+	StoreMemory = "memory"
+	// StoreSQL keep counters in database shared by all instances
+	StoreSQL = "sql"
+)
+
+// Config for request rate limiting
+type Config struct {
+	// Store is memory or sql, default is memory
+	Store string `mapstructure:"store"`
+	// Rules are all applied, request is rejected when any of them exceeded
+	Rules []Rule `mapstructure:"rules"`
+}
+
+// Rule limit requests of route per key
+type Rule struct {
+	// Route is echo "METHOD /path", grpc full method "/package.Service/Method"
+	// or go-kit endpoint name, request pass all three layers so route of
+	// each layer is counted separately
+	Route string `mapstructure:"route"`
+	// Key is ip, username or client_id
+	Key string `mapstructure:"key"`
+	// Limit is requests allowed in window
+	Limit int `mapstructure:"limit"`
+	// Window is length of sliding window
+	Window time.Duration `mapstructure:"window"`
+}
@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type counter struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// MemoryStore keep counters in process memory, every instance
+// count its own requests so limit is per instance
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]counter
+}
+
+// NewMemoryStore new empty memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters: make(map[string]counter),
+	}
+}
+
+// Increment is memory implement
+func (s *MemoryStore) Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.counters[key]
+	c.count++
+	c.expiresAt = expiresAt
+	s.counters[key] = c
+
+	return c.count, nil
+}
+
+// Get is memory implement
+func (s *MemoryStore) Get(ctx context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.counters[key].count, nil
+}
+
+// Purge is memory implement
+func (s *MemoryStore) Purge(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, c := range s.counters {
+		if !now.Before(c.expiresAt) {
+			delete(s.counters, key)
+		}
+	}
+	return nil
+}
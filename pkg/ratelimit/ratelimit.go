@@ -0,0 +1,160 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+const (
+	// KeyIP limit per client ip address
+	KeyIP = "ip"
+	// KeyUsername limit per username
+	KeyUsername = "username"
+	// KeyClientID limit per oauth2 client
+	KeyClientID = "client_id"
+
+	// purgeInterval how often expired counters are removed from store
+	purgeInterval = time.Minute
+)
+
+// Keys identify who send the request, map key is KeyIP, KeyUsername or KeyClientID
+type Keys map[string]string
+
+// Keyer is implemented by request carry rate limit keys,
+// it let endpoint middleware limit by username or client id,
+// ip address is never taken from request but from transport
+type Keyer interface {
+	RateLimitKeys() Keys
+}
+
+// Store persist request counters of fixed windows
+type Store interface {
+	// Increment add one request to counter of key and return the new count,
+	// counter can be purged after expiresAt
+	Increment(ctx context.Context, key string, expiresAt time.Time) (count int64, err error)
+
+	// Get count of key, key never incremented return 0
+	Get(ctx context.Context, key string) (count int64, err error)
+
+	// Purge remove counters expired before the time
+	Purge(ctx context.Context, now time.Time) (err error)
+}
+
+// NewStore create store selected by config
+func NewStore(config Config, conn db.Connection) Store {
+	if config.Store == StoreSQL {
+		return NewSQLStore(conn)
+	}
+	return NewMemoryStore()
+}
+
+// Limiter apply sliding window rules, count of previous window
+// is weighted by how much it still overlap the sliding window
+type Limiter struct {
+	rules []Rule
+	store Store
+	now   func() time.Time
+
+	mu        sync.Mutex
+	lastPurge time.Time
+}
+
+// New limiter from config, rules without limit or window are ignored
+func New(config Config, store Store) *Limiter {
+	rules := make([]Rule, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		if rule.Limit <= 0 || rule.Window <= 0 {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	return &Limiter{
+		rules: rules,
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Allow count request of route and check rules matching route and keys,
+// exceeded one return ErrTooManyRequests with retry after hint
+func (l *Limiter) Allow(ctx context.Context, route string, keys Keys) error {
+	if len(l.rules) == 0 {
+		return nil
+	}
+
+	now := l.now()
+	l.purge(ctx, now)
+
+	for _, rule := range l.rules {
+		if rule.Route != route {
+			continue
+		}
+
+		value := keys[rule.Key]
+		if value == "" {
+			continue
+		}
+
+		err := l.allow(ctx, rule, route, value, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *Limiter) allow(ctx context.Context, rule Rule, route string, value string, now time.Time) error {
+	start := now.Truncate(rule.Window)
+	prefix := strings.Join([]string{route, rule.Key, value, rule.Window.String()}, "|") + "|"
+
+	current, err := l.store.Increment(ctx, prefix+strconv.FormatInt(start.UnixMilli(), 10), start.Add(2*rule.Window))
+	if err != nil {
+		return err
+	}
+
+	previous, err := l.store.Get(ctx, prefix+strconv.FormatInt(start.Add(-rule.Window).UnixMilli(), 10))
+	if err != nil {
+		return err
+	}
+
+	overlap := float64(rule.Window-now.Sub(start)) / float64(rule.Window)
+	if float64(previous)*overlap+float64(current) <= float64(rule.Limit) {
+		return nil
+	}
+
+	return errors.Wrapf(
+		errors.ErrTooManyRequests.WithRetryAfter(start.Add(rule.Window).Sub(now)),
+		"ratelimit: %v of route=%v exceed %v requests per %v",
+		rule.Key, route, rule.Limit, rule.Window,
+	)
+}
+
+// purge expired counters at most once per interval,
+// failed to purge should not reject the request
+func (l *Limiter) purge(ctx context.Context, now time.Time) {
+	l.mu.Lock()
+	if now.Sub(l.lastPurge) < purgeInterval {
+		l.mu.Unlock()
+		return
+	}
+	l.lastPurge = now
+	l.mu.Unlock()
+
+	err := l.store.Purge(ctx, now)
+	if err != nil {
+		log.Ctx(ctx).
+			Warn().
+			Err(err).
+			Msg("failed to purge rate limit counters")
+	}
+}
@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+func newTestLimiter(now *time.Time, rules ...Rule) *Limiter {
+	l := New(Config{Rules: rules}, NewMemoryStore())
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	rule := Rule{Route: "Signin", Key: KeyUsername, Limit: 3, Window: time.Minute}
+
+	tests := []struct {
+		name     string
+		route    string
+		keys     Keys
+		requests int
+		err      error
+	}{
+		{
+			name:     "Within Limit",
+			route:    "Signin",
+			keys:     Keys{KeyUsername: "Username"},
+			requests: 3,
+			err:      nil,
+		},
+		{
+			name:     "Exceed Limit",
+			route:    "Signin",
+			keys:     Keys{KeyUsername: "Username"},
+			requests: 4,
+			err:      errors.ErrTooManyRequests,
+		},
+		{
+			name:     "Other Route",
+			route:    "Signup",
+			keys:     Keys{KeyUsername: "Username"},
+			requests: 10,
+			err:      nil,
+		},
+		{
+			name:     "Key Not Present",
+			route:    "Signin",
+			keys:     Keys{KeyIP: "127.0.0.1"},
+			requests: 10,
+			err:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Unix(1700000000, 0)
+			l := newTestLimiter(&now, rule)
+
+			var err error
+			for i := 0; i < tt.requests; i++ {
+				err = l.Allow(ctx, tt.route, tt.keys)
+			}
+
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.err))
+			assert.Greater(t, errors.TryConvert(err).RetryAfter(), time.Duration(0))
+		})
+	}
+}
+
+func TestLimiter_SlidingWindow(t *testing.T) {
+	ctx := context.Background()
+	// start of a window
+	now := time.Unix(1700000000, 0).Truncate(time.Minute)
+	l := newTestLimiter(&now, Rule{Route: "Signin", Key: KeyIP, Limit: 4, Window: time.Minute})
+	keys := Keys{KeyIP: "127.0.0.1"}
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, l.Allow(ctx, "Signin", keys))
+	}
+
+	// half of previous window still overlap, 4*0.5 + 3 > 4
+	now = now.Add(90 * time.Second)
+	assert.NoError(t, l.Allow(ctx, "Signin", keys))
+	assert.NoError(t, l.Allow(ctx, "Signin", keys))
+	err := l.Allow(ctx, "Signin", keys)
+	assert.True(t, errors.Is(err, errors.ErrTooManyRequests))
+	assert.Equal(t, 30*time.Second, errors.TryConvert(err).RetryAfter())
+
+	// other ip address is not affected
+	assert.NoError(t, l.Allow(ctx, "Signin", Keys{KeyIP: "10.0.0.1"}))
+
+	// previous window no longer overlap
+	now = now.Add(90 * time.Second)
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, l.Allow(ctx, "Signin", Keys{KeyIP: "127.0.0.1"}))
+	}
+}
+
+func TestMemoryStore_Purge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	s := NewMemoryStore()
+
+	_, _ = s.Increment(ctx, "expired", now)
+	_, _ = s.Increment(ctx, "alive", now.Add(time.Minute))
+
+	assert.NoError(t, s.Purge(ctx, now))
+
+	count, _ := s.Get(ctx, "expired")
+	assert.Equal(t, int64(0), count)
+	count, _ = s.Get(ctx, "alive")
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/karta0898098/iam/pkg/db"
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// CounterDAO define request counter row of one window
+type CounterDAO struct {
+	Key       string `gorm:"column:key"`
+	Count     int64  `gorm:"column:count"`
+	ExpiresAt int64  `gorm:"column:expires_at"`
+}
+
+// TableName is CounterDAO implement table name for gorm
+func (c CounterDAO) TableName() string {
+	return "ratelimit_counters"
+}
+
+// SQLStore keep counters in ratelimit_counters table shared by all instances
+type SQLStore struct {
+	readDB  *gorm.DB
+	writeDB *gorm.DB
+}
+
+// NewSQLStore SQLStore constructor
+func NewSQLStore(conn db.Connection) Store {
+	return &SQLStore{
+		readDB:  conn.ReadDB(),
+		writeDB: conn.WriteDB(),
+	}
+}
+
+// Increment is SQL implement, counter is increased by upsert in one statement
+// so concurrent requests are never lost
+func (s *SQLStore) Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error) {
+	dao := &CounterDAO{
+		Key:       key,
+		Count:     1,
+		ExpiresAt: expiresAt.UnixMilli(),
+	}
+
+	err := s.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "key"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"count": gorm.Expr("ratelimit_counters.count + 1"),
+				}),
+			},
+			clause.Returning{},
+		).
+		Create(dao).
+		Error
+	if err != nil {
+		return 0, errors.Wrapf(errors.ErrInternal, "failed to increment rate limit counter key=%v, err %v", key, err)
+	}
+
+	return dao.Count, nil
+}
+
+// Get is SQL implement
+func (s *SQLStore) Get(ctx context.Context, key string) (int64, error) {
+	var (
+		dao CounterDAO
+	)
+
+	// read from write db, counter of previous window is still changing
+	err := s.writeDB.
+		WithContext(ctx).
+		Model(dao).
+		Where("key = ?", key).
+		First(&dao).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, errors.Wrapf(errors.ErrInternal, "reason : db occur error %v", err)
+	}
+
+	return dao.Count, nil
+}
+
+// Purge is SQL implement
+func (s *SQLStore) Purge(ctx context.Context, now time.Time) error {
+	err := s.writeDB.
+		WithContext(ctx).
+		Where("expires_at <= ?", now.UnixMilli()).
+		Delete(&CounterDAO{}).
+		Error
+	if err != nil {
+		return errors.Wrapf(errors.ErrInternal, "failed to purge rate limit counters, err %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package scim
+
+// Supported tell client whether a feature is supported
+type Supported struct {
+	Supported bool `json:"supported"`
+}
+
+// FilterSupported is filter feature of service provider
+type FilterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+// BulkSupported is bulk feature of service provider
+type BulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+// AuthenticationScheme is scheme client authenticate with
+type AuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary,omitempty"`
+}
+
+// ServiceProviderConfig describe features of service provider, see RFC 7643 section 5
+type ServiceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 Supported              `json:"patch"`
+	Bulk                  BulkSupported          `json:"bulk"`
+	Filter                FilterSupported        `json:"filter"`
+	ChangePassword        Supported              `json:"changePassword"`
+	Sort                  Supported              `json:"sort"`
+	ETag                  Supported              `json:"etag"`
+	AuthenticationSchemes []AuthenticationScheme `json:"authenticationSchemes"`
+	Meta                  *Meta                  `json:"meta,omitempty"`
+}
+
+// NewServiceProviderConfig new config of service provider supporting patch, filter and etag,
+// authenticated by bearer token
+func NewServiceProviderConfig(maxResults int, location string) *ServiceProviderConfig {
+	return &ServiceProviderConfig{
+		Schemas:        []string{SchemaServiceProviderConfig},
+		Patch:          Supported{Supported: true},
+		Filter:         FilterSupported{Supported: true, MaxResults: maxResults},
+		ChangePassword: Supported{Supported: true},
+		ETag:           Supported{Supported: true},
+		AuthenticationSchemes: []AuthenticationScheme{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "OAuth Bearer Token",
+				Description: "Authentication scheme using the OAuth Bearer Token Standard",
+				Primary:     true,
+			},
+		},
+		Meta: &Meta{ResourceType: "ServiceProviderConfig", Location: location},
+	}
+}
+
+// ResourceType describe endpoint and schema of resource, see RFC 7643 section 6
+type ResourceType struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint"`
+	Description string   `json:"description"`
+	Schema      string   `json:"schema"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// NewResourceType new resource type, endpoint is path relative to base url of service provider
+func NewResourceType(name string, endpoint string, schema string, description string, location string) *ResourceType {
+	return &ResourceType{
+		Schemas:     []string{SchemaResourceType},
+		ID:          name,
+		Name:        name,
+		Endpoint:    endpoint,
+		Description: description,
+		Schema:      schema,
+		Meta:        &Meta{ResourceType: "ResourceType", Location: location},
+	}
+}
@@ -0,0 +1,494 @@
+package scim
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// Operator of filter, see RFC 7644 section 3.4.2.2
+type Operator string
+
+const (
+	OpEqual          Operator = "eq"
+	OpNotEqual       Operator = "ne"
+	OpContains       Operator = "co"
+	OpStartsWith     Operator = "sw"
+	OpEndsWith       Operator = "ew"
+	OpPresent        Operator = "pr"
+	OpGreater        Operator = "gt"
+	OpGreaterOrEqual Operator = "ge"
+	OpLess           Operator = "lt"
+	OpLessOrEqual    Operator = "le"
+	OpAnd            Operator = "and"
+	OpOr             Operator = "or"
+	OpNot            Operator = "not"
+)
+
+// maxFilterDepth limit nested parentheses of filter
+const maxFilterDepth = 32
+
+// Filter is parsed filter expression,
+// comparison has Path and Value, and, or and not have Filters
+type Filter struct {
+	Op Operator
+	// Path is lower case attribute path without schema, e.g. name.givenname
+	Path string
+	// Value is string, float64, bool or nil
+	Value interface{}
+	// Filters are operands of logical operator
+	Filters []*Filter
+}
+
+// ParseFilter parse filter expression, e.g. userName eq "bjensen" and (emails co "example.com"),
+// value path emails[type eq "work"] is flattened to emails.type since it is compared as attribute
+func ParseFilter(filter string) (*Filter, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, invalidFilter("unexpected %q", p.peek().text)
+	}
+
+	return f, nil
+}
+
+// Match evaluate filter against attributes keyed by lower case path,
+// string is compared case insensitively
+func (f *Filter) Match(attributes map[string]interface{}) bool {
+	switch f.Op {
+	case OpAnd:
+		for _, child := range f.Filters {
+			if !child.Match(attributes) {
+				return false
+			}
+		}
+		return true
+	case OpOr:
+		for _, child := range f.Filters {
+			if child.Match(attributes) {
+				return true
+			}
+		}
+		return false
+	case OpNot:
+		return len(f.Filters) == 1 && !f.Filters[0].Match(attributes)
+	}
+
+	value, ok := attributes[f.Path]
+	if f.Op == OpPresent {
+		return ok && value != nil && value != ""
+	}
+
+	switch actual := value.(type) {
+	case string:
+		expected, ok := f.Value.(string)
+		if !ok {
+			return false
+		}
+		return compareString(f.Op, strings.ToLower(actual), strings.ToLower(expected))
+	case bool:
+		expected, ok := f.Value.(bool)
+		if !ok {
+			return false
+		}
+		return (f.Op == OpEqual && actual == expected) || (f.Op == OpNotEqual && actual != expected)
+	case nil:
+		return (f.Op == OpEqual && f.Value == nil) || (f.Op == OpNotEqual && f.Value != nil)
+	}
+	return false
+}
+
+func compareString(op Operator, actual string, expected string) bool {
+	switch op {
+	case OpEqual:
+		return actual == expected
+	case OpNotEqual:
+		return actual != expected
+	case OpContains:
+		return strings.Contains(actual, expected)
+	case OpStartsWith:
+		return strings.HasPrefix(actual, expected)
+	case OpEndsWith:
+		return strings.HasSuffix(actual, expected)
+	case OpGreater:
+		return actual > expected
+	case OpGreaterOrEqual:
+		return actual >= expected
+	case OpLess:
+		return actual < expected
+	case OpLessOrEqual:
+		return actual <= expected
+	}
+	return false
+}
+
+// Path is attribute path of patch operation,
+// e.g. members[value eq "2819c223"] or emails[type eq "work"].value
+type Path struct {
+	// Attribute is lower case attribute name
+	Attribute string
+	// Filter select values of multi-valued attribute, its paths are relative to attribute
+	Filter *Filter
+	// SubAttribute is lower case sub attribute name
+	SubAttribute string
+}
+
+// ParsePath parse attribute path of patch operation, see RFC 7644 section 3.5.2
+func ParsePath(path string) (*Path, error) {
+	path = trimSchema(strings.TrimSpace(path))
+	if path == "" {
+		return nil, invalidPath("path is empty")
+	}
+
+	p := &Path{}
+	if open := strings.IndexByte(path, '['); open >= 0 {
+		closing := strings.LastIndexByte(path, ']')
+		if closing < open {
+			return nil, invalidPath("path %q miss ]", path)
+		}
+
+		filter, err := ParseFilter(path[open+1 : closing])
+		if err != nil {
+			return nil, invalidPath("path %q has invalid filter", path)
+		}
+		p.Filter = filter
+
+		rest := path[closing+1:]
+		if rest != "" {
+			if rest[0] != '.' {
+				return nil, invalidPath("path %q is invalid", path)
+			}
+			p.SubAttribute = rest[1:]
+		}
+		path = path[:open]
+	} else if dot := strings.IndexByte(path, '.'); dot >= 0 {
+		path, p.SubAttribute = path[:dot], path[dot+1:]
+	}
+
+	p.Attribute = strings.ToLower(path)
+	p.SubAttribute = strings.ToLower(p.SubAttribute)
+	if !isAttributeName(p.Attribute) || (p.SubAttribute != "" && !isAttributeName(p.SubAttribute)) {
+		return nil, invalidPath("path %q is invalid", path)
+	}
+
+	return p, nil
+}
+
+// String is full path of attribute without filter, e.g. name.givenname
+func (p *Path) String() string {
+	if p.SubAttribute == "" {
+		return p.Attribute
+	}
+	return p.Attribute + "." + p.SubAttribute
+}
+
+// IsExtension tell path is attribute of extension schema such as enterprise user,
+// core schema prefix is not extension
+func IsExtension(path string) bool {
+	return strings.HasPrefix(strings.ToLower(trimSchema(strings.TrimSpace(path))), "urn:")
+}
+
+// trimSchema remove schema URI of core resource before attribute name
+func trimSchema(path string) string {
+	for _, schema := range []string{SchemaUser, SchemaGroup} {
+		if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+			return path[len(schema)+1:]
+		}
+	}
+	return path
+}
+
+// isAttributeName check ATTRNAME of RFC 7643 section 2.1, $ref is allowed
+func isAttributeName(name string) bool {
+	if name == "$ref" {
+		return true
+	}
+	if name == "" || !isAlpha(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		c := name[i]
+		if !isAlpha(c) && !('0' <= c && c <= '9') && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlpha(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// isAttributePath check attribute path with optional sub attribute
+func isAttributePath(path string) bool {
+	for _, name := range strings.SplitN(path, ".", 2) {
+		if !isAttributeName(name) {
+			return false
+		}
+	}
+	return true
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize split filter into words, JSON strings and parentheses or brackets
+func tokenize(filter string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(filter); {
+		c := filter[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			tokens = append(tokens, token{kind: tokenPunct, text: string(c)})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(filter) && filter[end] != '"'; end++ {
+				if filter[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(filter) {
+				return nil, invalidFilter("string is not terminated")
+			}
+
+			var s string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &s); err != nil {
+				return nil, invalidFilter("string %v is invalid", filter[i:end+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: s})
+			i = end + 1
+		default:
+			end := i
+			for end < len(filter) && !strings.ContainsRune(" \t\n\r()[]\"", rune(filter[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: filter[i:end]})
+			i = end
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, invalidFilter("filter is empty")
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *filterParser) peek() token {
+	if p.done() {
+		return token{kind: tokenPunct}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+// keyword tell next token is word equal to keyword case insensitively
+func (p *filterParser) keyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func (p *filterParser) expect(punct string) error {
+	t := p.next()
+	if t.kind != tokenPunct || t.text != punct {
+		return invalidFilter("expect %v", punct)
+	}
+	return nil
+}
+
+// parseOr parse operands joined by or, or has lowest precedence
+func (p *filterParser) parseOr(depth int) (*Filter, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword(string(OpOr)) {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &Filter{Op: OpOr, Filters: []*Filter{left, right}}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd(depth int) (*Filter, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword(string(OpAnd)) {
+		p.next()
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &Filter{Op: OpAnd, Filters: []*Filter{left, right}}
+	}
+	return left, nil
+}
+
+// parseUnary parse not, parenthesized filter or attribute expression
+func (p *filterParser) parseUnary(depth int) (*Filter, error) {
+	if depth > maxFilterDepth {
+		return nil, invalidFilter("filter is nested too deep")
+	}
+
+	not := false
+	if p.keyword(string(OpNot)) {
+		p.next()
+		not = true
+		if p.peek().text != "(" {
+			return nil, invalidFilter("expect ( after not")
+		}
+	}
+
+	var (
+		f   *Filter
+		err error
+	)
+	if t := p.peek(); t.kind == tokenPunct && t.text == "(" {
+		p.next()
+		f, err = p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	} else {
+		f, err = p.parseAttribute(depth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if not {
+		return &Filter{Op: OpNot, Filters: []*Filter{f}}, nil
+	}
+	return f, nil
+}
+
+// parseAttribute parse attrPath pr, attrPath op value or valuePath
+func (p *filterParser) parseAttribute(depth int) (*Filter, error) {
+	t := p.next()
+	if t.kind != tokenWord {
+		return nil, invalidFilter("expect attribute path")
+	}
+	path := strings.ToLower(trimSchema(t.text))
+	if !isAttributePath(path) {
+		return nil, invalidFilter("attribute path %q is invalid", t.text)
+	}
+
+	if t := p.peek(); t.kind == tokenPunct && t.text == "[" {
+		p.next()
+		sub, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		return prefix(sub, path), nil
+	}
+
+	t = p.next()
+	if t.kind != tokenWord {
+		return nil, invalidFilter("expect operator after %v", path)
+	}
+	op := Operator(strings.ToLower(t.text))
+	switch op {
+	case OpPresent:
+		return &Filter{Op: op, Path: path}, nil
+	case OpEqual, OpNotEqual, OpContains, OpStartsWith, OpEndsWith,
+		OpGreater, OpGreaterOrEqual, OpLess, OpLessOrEqual:
+	default:
+		return nil, invalidFilter("operator %q is not supported", t.text)
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{Op: op, Path: path, Value: value}, nil
+}
+
+// parseValue parse compValue, it is string, number, true, false or null
+func (p *filterParser) parseValue() (interface{}, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return t.text, nil
+	case tokenWord:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, invalidFilter("value %q is invalid", t.text)
+		}
+		return n, nil
+	}
+	return nil, invalidFilter("expect value")
+}
+
+// prefix qualify paths of value filter with its attribute
+func prefix(f *Filter, attribute string) *Filter {
+	if f.Path != "" {
+		f.Path = attribute + "." + f.Path
+	}
+	for _, child := range f.Filters {
+		prefix(child, attribute)
+	}
+	return f
+}
+
+func invalidFilter(format string, args ...interface{}) error {
+	return errors.Wrapf(WithType(errors.ErrInvalidInput, TypeInvalidFilter), "scim: "+format, args...)
+}
+
+func invalidPath(format string, args ...interface{}) error {
+	return errors.Wrapf(WithType(errors.ErrInvalidInput, TypeInvalidPath), "scim: "+format, args...)
+}
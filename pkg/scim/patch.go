@@ -0,0 +1,110 @@
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// operations of patch request
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+)
+
+// PatchRequest modify attributes of resource, see RFC 7644 section 3.5.2
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is one operation of patch request,
+// value without path is object of attributes to add or replace
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Validate check schema and operations, op is normalized to lower case
+// since some providers send Add, Replace and Remove
+func (r *PatchRequest) Validate() error {
+	if !hasSchema(r.Schemas, SchemaPatchOp) {
+		return errors.Wrapf(WithType(errors.ErrInvalidInput, TypeInvalidSyntax), "scim: patch request miss schema %v", SchemaPatchOp)
+	}
+	if len(r.Operations) == 0 {
+		return errors.Wrap(WithType(errors.ErrInvalidInput, TypeInvalidValue), "scim: patch request has no operation")
+	}
+
+	for i := range r.Operations {
+		op := &r.Operations[i]
+		op.Op = strings.ToLower(op.Op)
+
+		switch op.Op {
+		case PatchAdd, PatchReplace:
+			if len(op.Value) == 0 {
+				return errors.Wrapf(WithType(errors.ErrInvalidInput, TypeInvalidValue), "scim: %v operation miss value", op.Op)
+			}
+		case PatchRemove:
+			if op.Path == "" {
+				return errors.Wrap(WithType(errors.ErrInvalidInput, TypeNoTarget), "scim: remove operation miss path")
+			}
+		default:
+			return errors.Wrapf(WithType(errors.ErrInvalidInput, TypeInvalidSyntax), "scim: patch operation %q is not supported", op.Op)
+		}
+	}
+
+	return nil
+}
+
+// hasSchema schemas contain schema, schema URI is case insensitive
+func hasSchema(schemas []string, schema string) bool {
+	for _, s := range schemas {
+		if strings.EqualFold(s, schema) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckSchema check resource declare its core schema
+func CheckSchema(schemas []string, schema string) error {
+	if !hasSchema(schemas, schema) {
+		return errors.Wrapf(WithType(errors.ErrInvalidInput, TypeInvalidSyntax), "scim: resource miss schema %v", schema)
+	}
+	return nil
+}
+
+// String decode string value, null is empty string
+func String(value json.RawMessage) (string, error) {
+	var s *string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return "", errors.Wrapf(WithType(errors.ErrInvalidInput, TypeInvalidValue), "scim: value %s is not string", value)
+	}
+	if s == nil {
+		return "", nil
+	}
+	return *s, nil
+}
+
+// Bool decode boolean value, string "True" and "False" are accepted
+// since some providers send boolean as string
+func Bool(value json.RawMessage) (bool, error) {
+	var v interface{}
+	if err := json.Unmarshal(value, &v); err == nil {
+		switch b := v.(type) {
+		case bool:
+			return b, nil
+		case string:
+			switch strings.ToLower(b) {
+			case "true":
+				return true, nil
+			case "false":
+				return false, nil
+			}
+		}
+	}
+	return false, errors.Wrapf(WithType(errors.ErrInvalidInput, TypeInvalidValue), "scim: value %s is not boolean", value)
+}
@@ -0,0 +1,141 @@
+// Package scim implement protocol parts of SCIM 2.0 (RFC 7643, RFC 7644),
+// they are filter, patch operation, list response, entity tag and error response
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// MediaType is content type of SCIM request and response
+const MediaType = "application/scim+json"
+
+// schema URIs of resources and messages
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// scimType of error response, see RFC 7644 section 3.12
+const (
+	TypeInvalidFilter = "invalidFilter"
+	TypeTooMany       = "tooMany"
+	TypeUniqueness    = "uniqueness"
+	TypeMutability    = "mutability"
+	TypeInvalidSyntax = "invalidSyntax"
+	TypeInvalidPath   = "invalidPath"
+	TypeNoTarget      = "noTarget"
+	TypeInvalidValue  = "invalidValue"
+)
+
+// Meta is common attribute of resource
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+// NewMeta new meta of resource, version is entity tag of last modified time
+func NewMeta(resourceType string, location string, created time.Time, lastModified time.Time) *Meta {
+	return &Meta{
+		ResourceType: resourceType,
+		Created:      created.UTC().Format(time.RFC3339),
+		LastModified: lastModified.UTC().Format(time.RFC3339),
+		Location:     location,
+		Version:      ETag(lastModified),
+	}
+}
+
+// ListResponse is page of query result, startIndex is 1-based
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int64         `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// NewListResponse new list response of resources
+func NewListResponse(total int64, startIndex int, resources []interface{}) *ListResponse {
+	if resources == nil {
+		resources = make([]interface{}, 0)
+	}
+
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// ETag weak entity tag of resource modified at t
+func ETag(t time.Time) string {
+	return `W/"` + strconv.FormatInt(t.UnixMilli(), 10) + `"`
+}
+
+// MatchETag tell If-Match or If-None-Match header contain etag by weak comparison,
+// * match any etag
+func MatchETag(header string, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// WithType attach scimType to error kind
+func WithType(kind *errors.Exception, scimType string) *errors.Exception {
+	return kind.WithDetails(errors.Detail{Reason: scimType})
+}
+
+// ErrorView is SCIM error response, status is string by RFC 7644 section 3.12
+type ErrorView struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// ErrorResponse error response of SCIM endpoints for go-kit
+func ErrorResponse(ctx context.Context, err error, w http.ResponseWriter) {
+	exception := errors.TryConvert(err)
+	if exception == nil {
+		exception = errors.New(err.Error())
+	}
+
+	view := &ErrorView{
+		Schemas: []string{SchemaError},
+		Status:  strconv.Itoa(exception.Status),
+		Detail:  exception.Message,
+	}
+	for _, detail := range exception.Details {
+		if detail.Reason != "" {
+			view.ScimType = detail.Reason
+			break
+		}
+	}
+
+	if retryAfter := exception.RetryAfter(); retryAfter > 0 {
+		w.Header().Set("Retry-After", errors.RetryAfterSeconds(retryAfter))
+	}
+	w.Header().Set("Content-Type", MediaType)
+	w.WriteHeader(exception.Status)
+	_ = json.NewEncoder(w).Encode(view)
+}
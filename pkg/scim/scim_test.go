@@ -0,0 +1,187 @@
+package scim_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/scim"
+)
+
+func TestParseFilter(t *testing.T) {
+	user := map[string]interface{}{
+		"username":       "bjensen",
+		"name.givenname": "Barbara",
+		"emails.value":   "bjensen@example.com",
+		"emails.type":    "work",
+		"active":         true,
+		"title":          nil,
+	}
+
+	tests := []struct {
+		filter string
+		match  bool
+		err    bool
+	}{
+		{filter: `userName eq "bjensen"`, match: true},
+		{filter: `USERNAME Eq "BJensen"`, match: true},
+		{filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "bjensen"`, match: true},
+		{filter: `userName ne "bjensen"`, match: false},
+		{filter: `name.givenName sw "Bar"`, match: true},
+		{filter: `emails.value ew "@example.com"`, match: true},
+		{filter: `emails.value co "jensen@"`, match: true},
+		{filter: `emails[type eq "work" and value co "example"]`, match: true},
+		{filter: `active eq true`, match: true},
+		{filter: `active eq false`, match: false},
+		{filter: `title pr`, match: false},
+		{filter: `username pr and not (active eq false)`, match: true},
+		{filter: `userName eq "other" or userName eq "bjensen" and active eq true`, match: true},
+		{filter: `(userName eq "other" or userName eq "bjensen") and active eq false`, match: false},
+		{filter: `userName eq "a \"quoted\" name"`, match: false},
+		{filter: `userName gt "a"`, match: true},
+		{filter: ``, err: true},
+		{filter: `userName eq`, err: true},
+		{filter: `userName xx "bjensen"`, err: true},
+		{filter: `userName eq bjensen`, err: true},
+		{filter: `userName eq "bjensen`, err: true},
+		{filter: `(userName eq "bjensen"`, err: true},
+		{filter: `userName eq "bjensen")`, err: true},
+		{filter: `not userName eq "bjensen"`, err: true},
+		{filter: `user$name eq "bjensen"`, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := scim.ParseFilter(tt.filter)
+			if tt.err {
+				assert.True(t, errors.Is(err, errors.ErrInvalidInput), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.match, f.Match(user))
+		})
+	}
+}
+
+func TestParseFilter_Precedence(t *testing.T) {
+	f, err := scim.ParseFilter(`a eq "1" or b eq "2" and c eq "3"`)
+	assert.NoError(t, err)
+	assert.Equal(t, scim.OpOr, f.Op)
+	assert.Equal(t, "a", f.Filters[0].Path)
+	assert.Equal(t, scim.OpAnd, f.Filters[1].Op)
+
+	f, err = scim.ParseFilter(`meta.lastModified gt "2011-05-13T04:42:34Z" and count le 10`)
+	assert.NoError(t, err)
+	assert.Equal(t, "meta.lastmodified", f.Filters[0].Path)
+	assert.Equal(t, float64(10), f.Filters[1].Value)
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+		filtered bool
+		err      bool
+	}{
+		{path: "userName", expected: "username"},
+		{path: "name.givenName", expected: "name.givenname"},
+		{path: "urn:ietf:params:scim:schemas:core:2.0:User:active", expected: "active"},
+		{path: `members[value eq "2819c223"]`, expected: "members", filtered: true},
+		{path: `emails[type eq "work"].value`, expected: "emails.value", filtered: true},
+		{path: "", err: true},
+		{path: `members[value eq "2819c223"`, err: true},
+		{path: `members[value eq]`, err: true},
+		{path: `emails[type eq "work"]value`, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			p, err := scim.ParsePath(tt.path)
+			if tt.err {
+				assert.True(t, errors.Is(err, errors.ErrInvalidInput), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, p.String())
+			assert.Equal(t, tt.filtered, p.Filter != nil)
+		})
+	}
+}
+
+func TestPatchRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  bool
+	}{
+		{
+			name: "Success",
+			body: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"Replace","path":"active","value":"False"}]}`,
+		},
+		{
+			name: "Miss Schema",
+			body: `{"schemas":[],"Operations":[{"op":"replace","path":"active","value":false}]}`,
+			err:  true,
+		},
+		{
+			name: "No Operation",
+			body: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[]}`,
+			err:  true,
+		},
+		{
+			name: "Remove Without Path",
+			body: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"remove"}]}`,
+			err:  true,
+		},
+		{
+			name: "Unknown Operation",
+			body: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"move","path":"active"}]}`,
+			err:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req scim.PatchRequest
+			assert.NoError(t, json.Unmarshal([]byte(tt.body), &req))
+
+			err := req.Validate()
+			if tt.err {
+				assert.True(t, errors.Is(err, errors.ErrInvalidInput), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, scim.PatchReplace, req.Operations[0].Op)
+
+			active, err := scim.Bool(req.Operations[0].Value)
+			assert.NoError(t, err)
+			assert.False(t, active)
+		})
+	}
+}
+
+func TestMatchETag(t *testing.T) {
+	etag := scim.ETag(time.UnixMilli(1700000000000))
+	assert.Equal(t, `W/"1700000000000"`, etag)
+	assert.True(t, scim.MatchETag(etag, etag))
+	assert.True(t, scim.MatchETag(`"1700000000000"`, etag))
+	assert.True(t, scim.MatchETag(`W/"1", W/"1700000000000"`, etag))
+	assert.True(t, scim.MatchETag("*", etag))
+	assert.False(t, scim.MatchETag(`W/"1"`, etag))
+}
+
+func TestErrorResponse(t *testing.T) {
+	w := httptest.NewRecorder()
+	scim.ErrorResponse(context.Background(), errors.Wrap(scim.WithType(errors.ErrInvalidInput, scim.TypeInvalidFilter), "bad filter"), w)
+
+	assert.Equal(t, 400, w.Code)
+	assert.Equal(t, scim.MediaType, w.Header().Get("Content-Type"))
+
+	var view scim.ErrorView
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &view))
+	assert.Equal(t, []string{scim.SchemaError}, view.Schemas)
+	assert.Equal(t, "400", view.Status)
+	assert.Equal(t, scim.TypeInvalidFilter, view.ScimType)
+}
@@ -0,0 +1,10 @@
+package secret
+
+// Config for cipher encrypt secret at rest
+type Config struct {
+	// Key base64 encoded 32 bytes AES-256 key
+	Key string `mapstructure:"key"`
+	// Ephemeral allow generating key at startup when key is empty, only for local development
+	// since secret encrypted by ephemeral key can not be decrypted after restart
+	Ephemeral bool `mapstructure:"ephemeral"`
+}
@@ -0,0 +1,105 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// KeySize AES-256 key size
+	KeySize = 32
+)
+
+// Cipher encrypt and decrypt secret stored in datastore
+type Cipher interface {
+	// Encrypt seal plaintext, the result is base64 encoded
+	Encrypt(plaintext []byte) (ciphertext string, err error)
+	// Decrypt open ciphertext produced by Encrypt
+	Decrypt(ciphertext string) (plaintext []byte, err error)
+}
+
+type aesgcm struct {
+	aead cipher.AEAD
+}
+
+// NewCipher new AES-GCM cipher from config
+// when no key is configured an ephemeral key is generated only if config allow it,
+// secret encrypted by ephemeral key can not be decrypted after restart
+func NewCipher(config Config) (Cipher, error) {
+	var (
+		key []byte
+		err error
+	)
+
+	if config.Key == "" {
+		if !config.Ephemeral {
+			return nil, fmt.Errorf("secret: no encryption key configured")
+		}
+
+		key = make([]byte, KeySize)
+		_, err = rand.Read(key)
+		if err != nil {
+			return nil, fmt.Errorf("secret: failed to generate key %w", err)
+		}
+
+		log.Warn().Msg("secret: no encryption key configured, use ephemeral key")
+	} else {
+		key, err = base64.StdEncoding.DecodeString(config.Key)
+		if err != nil {
+			return nil, fmt.Errorf("secret: key is not base64 encoded %w", err)
+		}
+	}
+
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("secret: key size=%v must be %v bytes", len(key), KeySize)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("secret: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("secret: %w", err)
+	}
+
+	return &aesgcm{aead: aead}, nil
+}
+
+// Encrypt random nonce is prepended to sealed data
+func (c *aesgcm) Encrypt(plaintext []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("secret: failed to generate nonce %w", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt split nonce and open sealed data
+func (c *aesgcm) Decrypt(ciphertext string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("secret: ciphertext is not base64 encoded %w", err)
+	}
+
+	size := c.aead.NonceSize()
+	if len(sealed) < size {
+		return nil, fmt.Errorf("secret: ciphertext is too short")
+	}
+
+	plaintext, err := c.aead.Open(nil, sealed[:size], sealed[size:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("secret: failed to decrypt %w", err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,36 @@
+package secret
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCipher(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(make([]byte, KeySize))
+
+	c, err := NewCipher(Config{Key: key})
+	if err != nil {
+		t.Fatalf("NewCipher() error = %v", err)
+	}
+
+	ciphertext, err := c.Encrypt([]byte("JBSWY3DPEHPK3PXP"))
+	assert.NoError(t, err)
+	assert.NotContains(t, ciphertext, "JBSWY3DPEHPK3PXP")
+
+	plaintext, err := c.Decrypt(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", string(plaintext))
+
+	other, _ := NewCipher(Config{Ephemeral: true})
+	_, err = other.Decrypt(ciphertext)
+	assert.Error(t, err)
+
+	// key must be configured outside local development
+	_, err = NewCipher(Config{})
+	assert.Error(t, err)
+
+	_, err = NewCipher(Config{Key: base64.StdEncoding.EncodeToString([]byte("short"))})
+	assert.Error(t, err)
+}
@@ -0,0 +1,55 @@
+// Package tenant carry the tenant of request in context.
+// Tenant is put into context by signin, signup and authn middleware,
+// repository scope query of user by the tenant found in context.
+// Empty tenant id is the default tenant which own users created before multi-tenancy.
+package tenant
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Default id of default tenant
+const Default = ""
+
+type tenantKey struct{}
+
+// NewContext return context carry tenant id
+func NewContext(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// FromContext read tenant id put by NewContext,
+// ok is false when request is not scoped to any tenant
+func FromContext(ctx context.Context) (tenantID string, ok bool) {
+	tenantID, ok = ctx.Value(tenantKey{}).(string)
+	return tenantID, ok
+}
+
+// ID read tenant id put by NewContext, default tenant is returned when absent
+func ID(ctx context.Context) string {
+	tenantID, _ := FromContext(ctx)
+	return tenantID
+}
+
+// Scope is gorm scope filter column by tenant of context,
+// query is not filtered when request is not scoped to any tenant
+func Scope(ctx context.Context, column string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tenantID, ok := FromContext(ctx)
+		if !ok {
+			return db
+		}
+		return db.Where(column+" = ?", tenantID)
+	}
+}
+
+// Qualify prefix name with tenant id, name in default tenant is kept
+// so key of existing users like lockout counter is unchanged
+func Qualify(tenantID string, name string) string {
+	if tenantID == Default {
+		return name
+	}
+	return tenantID + "/" + name
+}
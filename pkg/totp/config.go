@@ -0,0 +1,10 @@
+package totp
+
+// Config for time-based one-time password
+type Config struct {
+	// Issuer is shown in authenticator app beside account name
+	Issuer string `mapstructure:"issuer"`
+	// Skew is how many time steps before and after current step are accepted
+	// to tolerate clock drift of device, 0 only accept current step
+	Skew uint `mapstructure:"skew"`
+}
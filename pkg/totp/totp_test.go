@@ -0,0 +1,66 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+func TestGenerateCode(t *testing.T) {
+	// test vectors from RFC 6238 appendix B, SHA1 mode truncated to 6 digits
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		name     string
+		time     int64
+		expected string
+	}{
+		{name: "59", time: 59, expected: "287082"},
+		{name: "1111111109", time: 1111111109, expected: "081804"},
+		{name: "1111111111", time: 1111111111, expected: "050471"},
+		{name: "1234567890", time: 1234567890, expected: "005924"},
+		{name: "2000000000", time: 2000000000, expected: "279037"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := GenerateCode(secret, Counter(time.Unix(tt.time, 0)))
+			if err != nil {
+				t.Fatalf("GenerateCode() error = %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("GenerateCode() = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	now := time.Now()
+	previous, _ := GenerateCode(secret, Counter(now)-1)
+	stale, _ := GenerateCode(secret, Counter(now)-3)
+
+	tests := []struct {
+		name string
+		code string
+		skew uint
+		ok   bool
+	}{
+		{name: "Previous Step Within Skew", code: previous, skew: 1, ok: true},
+		{name: "Previous Step Without Skew", code: previous, skew: 0, ok: false},
+		{name: "Stale Code", code: stale, skew: 1, ok: false},
+		{name: "Wrong Length", code: "12345", skew: 1, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := Validate(secret, tt.code, now, tt.skew)
+			if ok != tt.ok {
+				t.Errorf("Validate() = %v, want %v", ok, tt.ok)
+			}
+		})
+	}
+}
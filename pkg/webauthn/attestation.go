@@ -0,0 +1,219 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// authenticator data flags
+const (
+	FlagUserPresent        byte = 0x01
+	FlagUserVerified       byte = 0x04
+	FlagBackupEligible     byte = 0x08
+	FlagBackupState        byte = 0x10
+	FlagAttestedCredential byte = 0x40
+	FlagExtensionData      byte = 0x80
+)
+
+// attestation statement formats
+const (
+	AttestationFormatNone   = "none"
+	AttestationFormatPacked = "packed"
+)
+
+const (
+	authDataMinLength      = 37
+	aaguidLength           = 16
+	maxCredentialIDLength  = 1023
+	credentialIDLengthSize = 2
+)
+
+// oidAAGUID is id-fido-gen-ce-aaguid extension of attestation certificate
+var oidAAGUID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 45724, 1, 1, 4}
+
+// AuthenticatorData define authenticator data of ceremony
+type AuthenticatorData struct {
+	RPIDHash  []byte
+	Flags     byte
+	SignCount uint32
+
+	// AttestedCredential only present in registration
+	AttestedCredential *AttestedCredential
+}
+
+// AttestedCredential define credential created by authenticator
+type AttestedCredential struct {
+	AAGUID       []byte
+	CredentialID []byte
+	// PublicKey is COSE_Key encoded
+	PublicKey []byte
+}
+
+// HasFlag authenticator data has flag set
+func (d *AuthenticatorData) HasFlag(flag byte) bool {
+	return d.Flags&flag == flag
+}
+
+// ParseAuthenticatorData decode authenticator data
+func ParseAuthenticatorData(data []byte) (*AuthenticatorData, error) {
+	if len(data) < authDataMinLength {
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "webauthn: authenticator data length=%v is too short", len(data))
+	}
+
+	authData := &AuthenticatorData{
+		RPIDHash:  append([]byte(nil), data[:32]...),
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[authDataMinLength:]
+
+	if authData.HasFlag(FlagAttestedCredential) {
+		if len(rest) < aaguidLength+credentialIDLengthSize {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: attested credential data is too short")
+		}
+
+		aaguid := rest[:aaguidLength]
+		idLength := int(binary.BigEndian.Uint16(rest[aaguidLength:]))
+		rest = rest[aaguidLength+credentialIDLengthSize:]
+		if idLength > maxCredentialIDLength || idLength > len(rest) {
+			return nil, errors.Wrapf(errors.ErrInvalidInput, "webauthn: credential id length=%v is invalid", idLength)
+		}
+
+		credentialID := rest[:idLength]
+		rest = rest[idLength:]
+
+		_, after, err := cborDecode(rest)
+		if err != nil {
+			return nil, err
+		}
+
+		authData.AttestedCredential = &AttestedCredential{
+			AAGUID:       append([]byte(nil), aaguid...),
+			CredentialID: append([]byte(nil), credentialID...),
+			PublicKey:    append([]byte(nil), rest[:len(rest)-len(after)]...),
+		}
+		rest = after
+	}
+
+	if authData.HasFlag(FlagExtensionData) {
+		_, after, err := cborDecode(rest)
+		if err != nil {
+			return nil, err
+		}
+		rest = after
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: trailing bytes after authenticator data")
+	}
+
+	return authData, nil
+}
+
+// attestationObject define decoded attestation object
+type attestationObject struct {
+	Format   string
+	Stmt     map[interface{}]interface{}
+	AuthData []byte
+}
+
+// parseAttestationObject decode CBOR attestation object
+func parseAttestationObject(data []byte) (*attestationObject, error) {
+	v, rest, err := cborDecode(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: trailing bytes after attestation object")
+	}
+
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: attestation object is not map")
+	}
+
+	obj := &attestationObject{}
+	obj.Format, _ = m["fmt"].(string)
+	obj.Stmt, _ = m["attStmt"].(map[interface{}]interface{})
+	obj.AuthData, _ = m["authData"].([]byte)
+	if obj.Format == "" || obj.Stmt == nil || obj.AuthData == nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: attestation object missing fmt, attStmt or authData")
+	}
+
+	return obj, nil
+}
+
+// verifyAttestationStatement verify statement of supported format.
+// packed attestation certificate is checked against authenticator data,
+// but chain is not validated against trust anchors, so attestation
+// only proves possession of credential key and not authenticator model
+func verifyAttestationStatement(obj *attestationObject, authData *AuthenticatorData, credentialKey *PublicKey, clientDataHash []byte) error {
+	switch obj.Format {
+	case AttestationFormatNone:
+		if len(obj.Stmt) != 0 {
+			return errors.Wrap(errors.ErrInvalidInput, "webauthn: none attestation statement is not empty")
+		}
+		return nil
+
+	case AttestationFormatPacked:
+		return verifyPackedAttestation(obj, authData, credentialKey, clientDataHash)
+
+	default:
+		return errors.Wrapf(errors.ErrInvalidInput, "webauthn: unsupported attestation format %v", obj.Format)
+	}
+}
+
+func verifyPackedAttestation(obj *attestationObject, authData *AuthenticatorData, credentialKey *PublicKey, clientDataHash []byte) error {
+	alg, _ := obj.Stmt["alg"].(int64)
+	sig, _ := obj.Stmt["sig"].([]byte)
+	if sig == nil {
+		return errors.Wrap(errors.ErrInvalidInput, "webauthn: packed attestation missing sig")
+	}
+
+	signed := append(append([]byte(nil), obj.AuthData...), clientDataHash...)
+
+	x5c, ok := obj.Stmt["x5c"].([]interface{})
+	if !ok {
+		// self attestation is signed by credential private key
+		if alg != credentialKey.Algorithm {
+			return errors.Wrapf(errors.ErrInvalidInput, "webauthn: self attestation alg=%v not match credential alg=%v", alg, credentialKey.Algorithm)
+		}
+		return credentialKey.Verify(signed, sig)
+	}
+
+	if len(x5c) == 0 {
+		return errors.Wrap(errors.ErrInvalidInput, "webauthn: packed attestation x5c is empty")
+	}
+	der, _ := x5c[0].([]byte)
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return errors.Wrapf(errors.ErrInvalidInput, "webauthn: failed to parse attestation certificate %v", err)
+	}
+
+	if cert.Version != 3 || cert.IsCA {
+		return errors.Wrap(errors.ErrInvalidInput, "webauthn: attestation certificate must be version 3 leaf")
+	}
+
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidAAGUID) {
+			continue
+		}
+
+		var aaguid []byte
+		_, err = asn1.Unmarshal(ext.Value, &aaguid)
+		if err != nil || !bytes.Equal(aaguid, authData.AttestedCredential.AAGUID) {
+			return errors.Wrap(errors.ErrInvalidInput, "webauthn: attestation certificate aaguid not match")
+		}
+	}
+
+	err = cert.CheckSignature(x509SignatureAlgorithm(alg), signed, sig)
+	if err != nil {
+		return errors.Wrapf(errors.ErrUnauthorized, "webauthn: packed attestation signature is invalid %v", err)
+	}
+
+	return nil
+}
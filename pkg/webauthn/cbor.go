@@ -0,0 +1,192 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+const (
+	cborMajorUnsigned = 0
+	cborMajorNegative = 1
+	cborMajorBytes    = 2
+	cborMajorText     = 3
+	cborMajorArray    = 4
+	cborMajorMap      = 5
+	cborMajorTag      = 6
+	cborMajorSimple   = 7
+
+	// cborMaxDepth bound nesting of untrusted input
+	cborMaxDepth = 16
+)
+
+// cborDecoder decode the subset of CBOR (RFC 8949) produced by authenticators,
+// integer is decoded as int64, byte string as []byte, text string as string,
+// array as []interface{} and map as map[interface{}]interface{}.
+// indefinite length item, tag and float are not supported
+type cborDecoder struct {
+	data []byte
+	off  int
+}
+
+// cborDecode decode first item of data and return the bytes after it
+func cborDecode(data []byte) (v interface{}, rest []byte, err error) {
+	d := &cborDecoder{data: data}
+
+	v, err = d.decode(0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return v, data[d.off:], nil
+}
+
+func (d *cborDecoder) decode(depth int) (interface{}, error) {
+	if depth > cborMaxDepth {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: cbor nested too deep")
+	}
+	if d.off >= len(d.data) {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: unexpected end of cbor")
+	}
+
+	initial := d.data[d.off]
+	d.off++
+	major := initial >> 5
+	info := initial & 0x1f
+
+	if major == cborMajorSimple {
+		switch info {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22, 23:
+			return nil, nil
+		default:
+			return nil, errors.Wrapf(errors.ErrInvalidInput, "webauthn: unsupported cbor simple value %v", info)
+		}
+	}
+
+	n, err := d.argument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborMajorUnsigned:
+		if n > math.MaxInt64 {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: cbor integer overflow")
+		}
+		return int64(n), nil
+
+	case cborMajorNegative:
+		if n > math.MaxInt64 {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: cbor integer overflow")
+		}
+		return -1 - int64(n), nil
+
+	case cborMajorBytes:
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+
+	case cborMajorText:
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+
+	case cborMajorArray:
+		// each item takes at least one byte
+		if n > uint64(len(d.data)-d.off) {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: cbor array length exceeds input")
+		}
+		items := make([]interface{}, 0, n)
+		for i := uint64(0); i < n; i++ {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+
+	case cborMajorMap:
+		if n > uint64(len(d.data)-d.off)/2 {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: cbor map length exceeds input")
+		}
+		m := make(map[interface{}]interface{}, n)
+		for i := uint64(0); i < n; i++ {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, errors.Wrapf(errors.ErrInvalidInput, "webauthn: unsupported cbor map key type %T", key)
+			}
+			if _, ok := m[key]; ok {
+				return nil, errors.Wrapf(errors.ErrInvalidInput, "webauthn: duplicate cbor map key %v", key)
+			}
+
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+
+	default:
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "webauthn: unsupported cbor major type %v", major)
+	}
+}
+
+// argument read the argument following initial byte
+func (d *cborDecoder) argument(info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		b, err := d.read(1)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(b[0]), nil
+	case info == 25:
+		b, err := d.read(2)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case info == 26:
+		b, err := d.read(4)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	case info == 27:
+		b, err := d.read(8)
+		if err != nil {
+			return 0, err
+		}
+		return binary.BigEndian.Uint64(b), nil
+	default:
+		return 0, errors.Wrapf(errors.ErrInvalidInput, "webauthn: unsupported cbor additional info %v", info)
+	}
+}
+
+func (d *cborDecoder) read(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.off) {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: unexpected end of cbor")
+	}
+
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
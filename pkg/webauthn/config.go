@@ -0,0 +1,16 @@
+package webauthn
+
+// Config for webauthn relying party
+type Config struct {
+	// RPID is the effective domain credential scoped to, e.g. example.com
+	RPID string `mapstructure:"rp_id"`
+	// RPName is shown by authenticator when user register credential
+	RPName string `mapstructure:"rp_name"`
+	// Origins are accepted origins of client data, e.g. https://example.com
+	Origins []string `mapstructure:"origins"`
+	// UserVerification is required, preferred or discouraged, default is preferred
+	// only required makes ceremony reject authenticator not verified user
+	UserVerification string `mapstructure:"user_verification"`
+	// Attestation is conveyance preference, default is none
+	Attestation string `mapstructure:"attestation"`
+}
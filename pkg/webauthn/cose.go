@@ -0,0 +1,151 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"math/big"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// COSE algorithm identifiers supported for credential
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// COSE key parameters (RFC 9053)
+const (
+	coseKeyType      int64 = 1
+	coseKeyAlg       int64 = 3
+	coseKeyCurve     int64 = -1
+	coseKeyX         int64 = -2
+	coseKeyY         int64 = -3
+	coseKeyRSAModule int64 = -1
+	coseKeyRSAExp    int64 = -2
+
+	coseKeyTypeOKP int64 = 1
+	coseKeyTypeEC2 int64 = 2
+	coseKeyTypeRSA int64 = 3
+
+	coseCurveP256    int64 = 1
+	coseCurveEd25519 int64 = 6
+)
+
+// SupportedAlgorithms algorithms relying party accept, in order of preference
+var SupportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// PublicKey define credential public key decoded from COSE_Key
+type PublicKey struct {
+	Algorithm int64
+	Key       crypto.PublicKey
+}
+
+// ParsePublicKey decode COSE_Key encoded credential public key
+func ParsePublicKey(coseKey []byte) (*PublicKey, error) {
+	v, rest, err := cborDecode(coseKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: trailing bytes after cose key")
+	}
+
+	return parsePublicKey(v)
+}
+
+func parsePublicKey(v interface{}) (*PublicKey, error) {
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: cose key is not map")
+	}
+
+	kty, _ := m[coseKeyType].(int64)
+	alg, _ := m[coseKeyAlg].(int64)
+
+	switch {
+	case kty == coseKeyTypeEC2 && alg == AlgES256:
+		crv, _ := m[coseKeyCurve].(int64)
+		x, _ := m[coseKeyX].([]byte)
+		y, _ := m[coseKeyY].([]byte)
+		if crv != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: invalid ES256 cose key")
+		}
+
+		pub := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: ES256 point is not on curve")
+		}
+		return &PublicKey{Algorithm: alg, Key: pub}, nil
+
+	case kty == coseKeyTypeOKP && alg == AlgEdDSA:
+		crv, _ := m[coseKeyCurve].(int64)
+		x, _ := m[coseKeyX].([]byte)
+		if crv != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: invalid EdDSA cose key")
+		}
+		return &PublicKey{Algorithm: alg, Key: ed25519.PublicKey(x)}, nil
+
+	case kty == coseKeyTypeRSA && alg == AlgRS256:
+		n, _ := m[coseKeyRSAModule].([]byte)
+		e, _ := m[coseKeyRSAExp].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: invalid RS256 cose key")
+		}
+		return &PublicKey{
+			Algorithm: alg,
+			Key: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			},
+		}, nil
+
+	default:
+		return nil, errors.Wrapf(errors.ErrInvalidInput, "webauthn: unsupported cose key type=%v alg=%v", kty, alg)
+	}
+}
+
+// Verify check signature over data
+func (k *PublicKey) Verify(data []byte, sig []byte) error {
+	ok := false
+
+	switch pub := k.Key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		ok = ecdsa.VerifyASN1(pub, digest[:], sig)
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(pub, data, sig)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		ok = rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil
+	}
+
+	if !ok {
+		return errors.Wrapf(errors.ErrUnauthorized, "webauthn: signature of alg=%v is invalid", k.Algorithm)
+	}
+	return nil
+}
+
+// x509SignatureAlgorithm map COSE algorithm to x509 signature algorithm
+func x509SignatureAlgorithm(alg int64) x509.SignatureAlgorithm {
+	switch alg {
+	case AlgES256:
+		return x509.ECDSAWithSHA256
+	case AlgEdDSA:
+		return x509.PureEd25519
+	case AlgRS256:
+		return x509.SHA256WithRSA
+	default:
+		return x509.UnknownSignatureAlgorithm
+	}
+}
@@ -0,0 +1,40 @@
+package webauthn
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+// URLEncodedBytes is binary field of webauthn json,
+// encoded as unpadded base64url and padding is tolerated when decoding
+type URLEncodedBytes []byte
+
+// MarshalJSON implement json.Marshaler
+func (b URLEncodedBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+// UnmarshalJSON implement json.Unmarshaler
+func (b *URLEncodedBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return errors.Wrapf(errors.ErrInvalidInput, "webauthn: field is not base64url encoded %v", err)
+	}
+
+	*b = decoded
+	return nil
+}
+
+// String unpadded base64url encoding
+func (b URLEncodedBytes) String() string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
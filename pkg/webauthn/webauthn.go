@@ -0,0 +1,326 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/errors"
+)
+
+const (
+	// ChallengeSize bytes of random challenge
+	ChallengeSize = 32
+	// Timeout how long client can complete ceremony
+	Timeout = 5 * 60 * time.Second
+
+	// CredentialTypePublicKey is the only credential type of webauthn
+	CredentialTypePublicKey = "public-key"
+
+	// user verification requirement
+	UserVerificationRequired    = "required"
+	UserVerificationPreferred   = "preferred"
+	UserVerificationDiscouraged = "discouraged"
+
+	// client data types
+	clientDataTypeCreate = "webauthn.create"
+	clientDataTypeGet    = "webauthn.get"
+)
+
+// RPEntity define relying party
+type RPEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity define user account credential created for
+type UserEntity struct {
+	ID          URLEncodedBytes `json:"id"`
+	Name        string          `json:"name"`
+	DisplayName string          `json:"displayName"`
+}
+
+// CredentialParameter define credential algorithm relying party accept
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// CredentialDescriptor identify credential
+type CredentialDescriptor struct {
+	Type string          `json:"type"`
+	ID   URLEncodedBytes `json:"id"`
+}
+
+// AuthenticatorSelection define authenticator requirement
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions is PublicKeyCredentialCreationOptions passed to navigator.credentials.create
+type CreationOptions struct {
+	Challenge              URLEncodedBytes        `json:"challenge"`
+	RP                     RPEntity               `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials,omitempty"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions is PublicKeyCredentialRequestOptions passed to navigator.credentials.get
+type RequestOptions struct {
+	Challenge        URLEncodedBytes        `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials,omitempty"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// AttestationResponse define AuthenticatorAttestationResponse of registration
+type AttestationResponse struct {
+	ClientDataJSON    []byte
+	AttestationObject []byte
+}
+
+// AssertionResponse define AuthenticatorAssertionResponse of authentication
+type AssertionResponse struct {
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	// UserHandle is user id of discoverable credential, relying party
+	// should check it belongs to owner of credential
+	UserHandle []byte
+}
+
+// Credential define verified credential of registration
+type Credential struct {
+	ID []byte
+	// PublicKey is COSE_Key encoded
+	PublicKey         []byte
+	AAGUID            []byte
+	SignCount         uint32
+	AttestationFormat string
+	BackupEligible    bool
+}
+
+// CollectedClientData define client data signed by authenticator
+type CollectedClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin,omitempty"`
+}
+
+// RelyingParty run webauthn ceremonies
+type RelyingParty struct {
+	config Config
+}
+
+// New relying party from config
+func New(config Config) *RelyingParty {
+	if config.UserVerification == "" {
+		config.UserVerification = UserVerificationPreferred
+	}
+	if config.Attestation == "" {
+		config.Attestation = AttestationFormatNone
+	}
+
+	return &RelyingParty{
+		config: config,
+	}
+}
+
+// NewChallenge generate random challenge
+func NewChallenge() ([]byte, error) {
+	challenge := make([]byte, ChallengeSize)
+	_, err := rand.Read(challenge)
+	if err != nil {
+		return nil, errors.Wrapf(errors.ErrInternal, "webauthn: failed to generate challenge err %v", err)
+	}
+	return challenge, nil
+}
+
+// CreationOptions options of registration ceremony,
+// excluded credentials prevent user register same authenticator twice
+func (rp *RelyingParty) CreationOptions(challenge []byte, user UserEntity, exclude [][]byte) *CreationOptions {
+	params := make([]CredentialParameter, 0, len(SupportedAlgorithms))
+	for _, alg := range SupportedAlgorithms {
+		params = append(params, CredentialParameter{Type: CredentialTypePublicKey, Alg: alg})
+	}
+
+	return &CreationOptions{
+		Challenge: challenge,
+		RP: RPEntity{
+			ID:   rp.config.RPID,
+			Name: rp.config.RPName,
+		},
+		User:               user,
+		PubKeyCredParams:   params,
+		Timeout:            Timeout.Milliseconds(),
+		ExcludeCredentials: descriptors(exclude),
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: rp.config.UserVerification,
+		},
+		Attestation: rp.config.Attestation,
+	}
+}
+
+// RequestOptions options of authentication ceremony,
+// empty allowed credentials let user pick discoverable credential
+func (rp *RelyingParty) RequestOptions(challenge []byte, allow [][]byte) *RequestOptions {
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          Timeout.Milliseconds(),
+		RPID:             rp.config.RPID,
+		AllowCredentials: descriptors(allow),
+		UserVerification: rp.config.UserVerification,
+	}
+}
+
+// VerifyRegistration verify attestation response of challenge and return the new credential
+func (rp *RelyingParty) VerifyRegistration(challenge []byte, resp *AttestationResponse) (*Credential, error) {
+	err := rp.verifyClientData(resp.ClientDataJSON, clientDataTypeCreate, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := parseAttestationObject(resp.AttestationObject)
+	if err != nil {
+		return nil, err
+	}
+
+	authData, err := ParseAuthenticatorData(obj.AuthData)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rp.verifyAuthenticatorData(authData)
+	if err != nil {
+		return nil, err
+	}
+
+	if authData.AttestedCredential == nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "webauthn: authenticator data has no attested credential")
+	}
+
+	credentialKey, err := ParsePublicKey(authData.AttestedCredential.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+	err = verifyAttestationStatement(obj, authData, credentialKey, clientDataHash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:                authData.AttestedCredential.CredentialID,
+		PublicKey:         authData.AttestedCredential.PublicKey,
+		AAGUID:            authData.AttestedCredential.AAGUID,
+		SignCount:         authData.SignCount,
+		AttestationFormat: obj.Format,
+		BackupEligible:    authData.HasFlag(FlagBackupEligible),
+	}, nil
+}
+
+// VerifyAssertion verify assertion response of challenge signed by credential
+// and return the new sign count. sign count not increased means the
+// authenticator may be cloned, unless authenticator never count
+func (rp *RelyingParty) VerifyAssertion(challenge []byte, credential *Credential, resp *AssertionResponse) (signCount uint32, err error) {
+	err = rp.verifyClientData(resp.ClientDataJSON, clientDataTypeGet, challenge)
+	if err != nil {
+		return 0, err
+	}
+
+	authData, err := ParseAuthenticatorData(resp.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+
+	err = rp.verifyAuthenticatorData(authData)
+	if err != nil {
+		return 0, err
+	}
+
+	credentialKey, err := ParsePublicKey(credential.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+	signed := append(append([]byte(nil), resp.AuthenticatorData...), clientDataHash[:]...)
+	err = credentialKey.Verify(signed, resp.Signature)
+	if err != nil {
+		return 0, err
+	}
+
+	if (authData.SignCount != 0 || credential.SignCount != 0) && authData.SignCount <= credential.SignCount {
+		return 0, errors.Wrapf(
+			errors.ErrUnauthorized,
+			"webauthn: sign count=%v not greater than stored=%v, authenticator may be cloned",
+			authData.SignCount, credential.SignCount,
+		)
+	}
+
+	return authData.SignCount, nil
+}
+
+// verifyClientData check type, challenge and origin of client data
+func (rp *RelyingParty) verifyClientData(clientDataJSON []byte, typ string, challenge []byte) error {
+	var clientData CollectedClientData
+	err := json.Unmarshal(clientDataJSON, &clientData)
+	if err != nil {
+		return errors.Wrapf(errors.ErrInvalidInput, "webauthn: client data is not json %v", err)
+	}
+
+	if clientData.Type != typ {
+		return errors.Wrapf(errors.ErrInvalidInput, "webauthn: client data type=%v is not expected type=%v", clientData.Type, typ)
+	}
+
+	expected := URLEncodedBytes(challenge).String()
+	if subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(expected)) != 1 {
+		return errors.Wrap(errors.ErrUnauthorized, "webauthn: client data challenge not match")
+	}
+
+	for _, origin := range rp.config.Origins {
+		if clientData.Origin == origin {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(errors.ErrUnauthorized, "webauthn: origin=%v is not allowed", clientData.Origin)
+}
+
+// verifyAuthenticatorData check rp id hash and user flags
+func (rp *RelyingParty) verifyAuthenticatorData(authData *AuthenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(rp.config.RPID))
+	if !bytes.Equal(authData.RPIDHash, rpIDHash[:]) {
+		return errors.Wrap(errors.ErrUnauthorized, "webauthn: rp id hash not match")
+	}
+
+	if !authData.HasFlag(FlagUserPresent) {
+		return errors.Wrap(errors.ErrUnauthorized, "webauthn: user is not present")
+	}
+
+	if rp.config.UserVerification == UserVerificationRequired && !authData.HasFlag(FlagUserVerified) {
+		return errors.Wrap(errors.ErrUnauthorized, "webauthn: user is not verified")
+	}
+
+	return nil
+}
+
+func descriptors(ids [][]byte) []CredentialDescriptor {
+	list := make([]CredentialDescriptor, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, CredentialDescriptor{Type: CredentialTypePublicKey, ID: id})
+	}
+	return list
+}
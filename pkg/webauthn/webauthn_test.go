@@ -0,0 +1,249 @@
+package webauthn_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karta0898098/iam/pkg/errors"
+	"github.com/karta0898098/iam/pkg/webauthn"
+	"github.com/karta0898098/iam/pkg/webauthn/webauthntest"
+)
+
+const (
+	rpID   = "localhost"
+	origin = "http://localhost:3000"
+)
+
+var config = webauthn.Config{
+	RPID:    rpID,
+	RPName:  "IAM",
+	Origins: []string{origin},
+}
+
+func TestRelyingParty_VerifyRegistration(t *testing.T) {
+	challenge, _ := webauthn.NewChallenge()
+
+	tests := []struct {
+		name          string
+		config        webauthn.Config
+		authenticator func() *webauthntest.Authenticator
+		format        string
+		challenge     []byte
+		err           error
+	}{
+		{
+			name:   "None Attestation",
+			config: config,
+			authenticator: func() *webauthntest.Authenticator {
+				return webauthntest.NewAuthenticator(rpID, origin)
+			},
+			format:    webauthn.AttestationFormatNone,
+			challenge: challenge,
+			err:       nil,
+		},
+		{
+			name:   "Packed Self Attestation",
+			config: config,
+			authenticator: func() *webauthntest.Authenticator {
+				return webauthntest.NewAuthenticator(rpID, origin)
+			},
+			format:    webauthn.AttestationFormatPacked,
+			challenge: challenge,
+			err:       nil,
+		},
+		{
+			name:   "Packed Certificate Attestation",
+			config: config,
+			authenticator: func() *webauthntest.Authenticator {
+				a := webauthntest.NewAuthenticator(rpID, origin)
+				a.X5C = true
+				return a
+			},
+			format:    webauthn.AttestationFormatPacked,
+			challenge: challenge,
+			err:       nil,
+		},
+		{
+			name:   "Unsupported Attestation Format",
+			config: config,
+			authenticator: func() *webauthntest.Authenticator {
+				return webauthntest.NewAuthenticator(rpID, origin)
+			},
+			format:    "tpm",
+			challenge: challenge,
+			err:       errors.ErrInvalidInput,
+		},
+		{
+			name:   "Challenge Not Match",
+			config: config,
+			authenticator: func() *webauthntest.Authenticator {
+				return webauthntest.NewAuthenticator(rpID, origin)
+			},
+			format:    webauthn.AttestationFormatNone,
+			challenge: []byte("other challenge"),
+			err:       errors.ErrUnauthorized,
+		},
+		{
+			name:   "Origin Not Allowed",
+			config: config,
+			authenticator: func() *webauthntest.Authenticator {
+				return webauthntest.NewAuthenticator(rpID, "https://evil.example.com")
+			},
+			format:    webauthn.AttestationFormatNone,
+			challenge: challenge,
+			err:       errors.ErrUnauthorized,
+		},
+		{
+			name:   "RP ID Not Match",
+			config: config,
+			authenticator: func() *webauthntest.Authenticator {
+				return webauthntest.NewAuthenticator("example.com", origin)
+			},
+			format:    webauthn.AttestationFormatNone,
+			challenge: challenge,
+			err:       errors.ErrUnauthorized,
+		},
+		{
+			name: "User Verification Required",
+			config: webauthn.Config{
+				RPID:             rpID,
+				Origins:          []string{origin},
+				UserVerification: webauthn.UserVerificationRequired,
+			},
+			authenticator: func() *webauthntest.Authenticator {
+				a := webauthntest.NewAuthenticator(rpID, origin)
+				a.Flags = webauthn.FlagUserPresent
+				return a
+			},
+			format:    webauthn.AttestationFormatNone,
+			challenge: challenge,
+			err:       errors.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := webauthn.New(tt.config)
+			a := tt.authenticator()
+
+			clientDataJSON, attestationObject := a.Create(challenge, tt.format)
+			credential, err := rp.VerifyRegistration(tt.challenge, &webauthn.AttestationResponse{
+				ClientDataJSON:    clientDataJSON,
+				AttestationObject: attestationObject,
+			})
+			if tt.err != nil || err != nil {
+				if !errors.Is(tt.err, err) {
+					t.Errorf("VerifyRegistration() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+
+			assert.Equal(t, a.CredentialID, credential.ID)
+			assert.Equal(t, a.PublicKey(), credential.PublicKey)
+			assert.Equal(t, a.SignCount, credential.SignCount)
+			assert.Equal(t, tt.format, credential.AttestationFormat)
+		})
+	}
+}
+
+func TestRelyingParty_VerifyAssertion(t *testing.T) {
+	rp := webauthn.New(config)
+	challenge, _ := webauthn.NewChallenge()
+
+	tests := []struct {
+		name      string
+		setup     func() (*webauthntest.Authenticator, *webauthn.Credential)
+		tamper    func(resp *webauthn.AssertionResponse)
+		challenge []byte
+		err       error
+	}{
+		{
+			name: "Success",
+			setup: func() (*webauthntest.Authenticator, *webauthn.Credential) {
+				a := webauthntest.NewAuthenticator(rpID, origin)
+				return a, &webauthn.Credential{ID: a.CredentialID, PublicKey: a.PublicKey(), SignCount: 5}
+			},
+			challenge: challenge,
+			err:       nil,
+		},
+		{
+			name: "Authenticator Never Count",
+			setup: func() (*webauthntest.Authenticator, *webauthn.Credential) {
+				a := webauthntest.NewAuthenticator(rpID, origin)
+				a.Counting = false
+				return a, &webauthn.Credential{ID: a.CredentialID, PublicKey: a.PublicKey(), SignCount: 0}
+			},
+			challenge: challenge,
+			err:       nil,
+		},
+		{
+			name: "Sign Count Not Increased",
+			setup: func() (*webauthntest.Authenticator, *webauthn.Credential) {
+				a := webauthntest.NewAuthenticator(rpID, origin)
+				a.SignCount = 2
+				return a, &webauthn.Credential{ID: a.CredentialID, PublicKey: a.PublicKey(), SignCount: 10}
+			},
+			challenge: challenge,
+			err:       errors.ErrUnauthorized,
+		},
+		{
+			name: "Signature By Other Key",
+			setup: func() (*webauthntest.Authenticator, *webauthn.Credential) {
+				a := webauthntest.NewAuthenticator(rpID, origin)
+				other := webauthntest.NewAuthenticator(rpID, origin)
+				return a, &webauthn.Credential{ID: a.CredentialID, PublicKey: other.PublicKey()}
+			},
+			challenge: challenge,
+			err:       errors.ErrUnauthorized,
+		},
+		{
+			name: "Authenticator Data Tampered",
+			setup: func() (*webauthntest.Authenticator, *webauthn.Credential) {
+				a := webauthntest.NewAuthenticator(rpID, origin)
+				return a, &webauthn.Credential{ID: a.CredentialID, PublicKey: a.PublicKey()}
+			},
+			tamper: func(resp *webauthn.AssertionResponse) {
+				resp.AuthenticatorData[32] |= webauthn.FlagBackupState
+			},
+			challenge: challenge,
+			err:       errors.ErrUnauthorized,
+		},
+		{
+			name: "Challenge Not Match",
+			setup: func() (*webauthntest.Authenticator, *webauthn.Credential) {
+				a := webauthntest.NewAuthenticator(rpID, origin)
+				return a, &webauthn.Credential{ID: a.CredentialID, PublicKey: a.PublicKey()}
+			},
+			challenge: []byte("other challenge"),
+			err:       errors.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, credential := tt.setup()
+			if tt.err == nil && a.Counting {
+				a.SignCount = credential.SignCount
+			}
+
+			clientDataJSON, authenticatorData, signature := a.Get(challenge)
+			resp := &webauthn.AssertionResponse{
+				ClientDataJSON:    clientDataJSON,
+				AuthenticatorData: authenticatorData,
+				Signature:         signature,
+			}
+			if tt.tamper != nil {
+				tt.tamper(resp)
+			}
+
+			signCount, err := rp.VerifyAssertion(tt.challenge, credential, resp)
+			if tt.err != nil || err != nil {
+				if !errors.Is(tt.err, err) {
+					t.Errorf("VerifyAssertion() error = %v, wantErr %v", err, tt.err)
+				}
+				return
+			}
+
+			assert.Equal(t, a.SignCount, signCount)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Package webauthntest provide software authenticator for testing webauthn ceremonies
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/karta0898098/iam/pkg/webauthn"
+)
+
+// Authenticator is software authenticator holding one ES256 credential
+type Authenticator struct {
+	RPID   string
+	Origin string
+	// Flags of authenticator data, default is user present and verified
+	Flags byte
+	// SignCount is increased before each assertion, keep 0 to simulate authenticator never count
+	SignCount uint32
+	// Counting increase sign count on each ceremony
+	Counting bool
+	// X5C packed attestation carry certificate instead of self attestation
+	X5C bool
+
+	CredentialID []byte
+	AAGUID       []byte
+	key          *ecdsa.PrivateKey
+}
+
+// NewAuthenticator new authenticator with fresh credential
+func NewAuthenticator(rpID string, origin string) *Authenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	return &Authenticator{
+		RPID:         rpID,
+		Origin:       origin,
+		Flags:        webauthn.FlagUserPresent | webauthn.FlagUserVerified,
+		Counting:     true,
+		CredentialID: id,
+		AAGUID:       make([]byte, 16),
+		key:          key,
+	}
+}
+
+// PublicKey COSE_Key encoded credential public key
+func (a *Authenticator) PublicKey() []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	a.key.X.FillBytes(x)
+	a.key.Y.FillBytes(y)
+
+	return encode(cborMap{
+		{int64(1), int64(2)},
+		{int64(3), webauthn.AlgES256},
+		{int64(-1), int64(1)},
+		{int64(-2), x},
+		{int64(-3), y},
+	})
+}
+
+// Create run registration ceremony and return client data json and attestation object
+func (a *Authenticator) Create(challenge []byte, format string) (clientDataJSON []byte, attestationObject []byte) {
+	clientDataJSON = a.clientData("webauthn.create", challenge)
+
+	attested := append([]byte(nil), a.AAGUID...)
+	attested = append(attested, byte(len(a.CredentialID)>>8), byte(len(a.CredentialID)))
+	attested = append(attested, a.CredentialID...)
+	attested = append(attested, a.PublicKey()...)
+
+	authData := a.authData(a.Flags|webauthn.FlagAttestedCredential, attested)
+
+	stmt := cborMap{}
+	if format == webauthn.AttestationFormatPacked {
+		hash := sha256.Sum256(clientDataJSON)
+		signed := append(append([]byte(nil), authData...), hash[:]...)
+
+		if a.X5C {
+			attestationKey, cert := newAttestationCertificate()
+			stmt = cborMap{
+				{"alg", webauthn.AlgES256},
+				{"sig", sign(attestationKey, signed)},
+				{"x5c", []interface{}{cert}},
+			}
+		} else {
+			stmt = cborMap{
+				{"alg", webauthn.AlgES256},
+				{"sig", sign(a.key, signed)},
+			}
+		}
+	}
+
+	attestationObject = encode(cborMap{
+		{"fmt", format},
+		{"attStmt", stmt},
+		{"authData", authData},
+	})
+
+	return clientDataJSON, attestationObject
+}
+
+// Get run authentication ceremony and return client data json, authenticator data and signature
+func (a *Authenticator) Get(challenge []byte) (clientDataJSON []byte, authenticatorData []byte, signature []byte) {
+	clientDataJSON = a.clientData("webauthn.get", challenge)
+	authenticatorData = a.authData(a.Flags, nil)
+
+	hash := sha256.Sum256(clientDataJSON)
+	signature = sign(a.key, append(append([]byte(nil), authenticatorData...), hash[:]...))
+
+	return clientDataJSON, authenticatorData, signature
+}
+
+func (a *Authenticator) clientData(typ string, challenge []byte) []byte {
+	b, _ := json.Marshal(webauthn.CollectedClientData{
+		Type:      typ,
+		Challenge: base64.RawURLEncoding.EncodeToString(challenge),
+		Origin:    a.Origin,
+	})
+	return b
+}
+
+func (a *Authenticator) authData(flags byte, attested []byte) []byte {
+	if a.Counting {
+		a.SignCount++
+	}
+
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	data := append([]byte(nil), rpIDHash[:]...)
+	data = append(data, flags)
+	count := make([]byte, 4)
+	binary.BigEndian.PutUint32(count, a.SignCount)
+	data = append(data, count...)
+	return append(data, attested...)
+}
+
+func sign(key *ecdsa.PrivateKey, data []byte) []byte {
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+func newAttestationCertificate() (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			Organization:       []string{"webauthntest"},
+			OrganizationalUnit: []string{"Authenticator Attestation"},
+			CommonName:         "webauthntest",
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  false,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	return key, der
+}
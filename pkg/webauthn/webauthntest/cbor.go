@@ -0,0 +1,65 @@
+package webauthntest
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// cborMap is CBOR map keeping the order of pairs
+type cborMap []cborPair
+
+type cborPair struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// encode CBOR item, only types used by webauthn are supported
+func encode(v interface{}) []byte {
+	switch x := v.(type) {
+	case int64:
+		if x >= 0 {
+			return header(0, uint64(x))
+		}
+		return header(1, uint64(-1-x))
+	case []byte:
+		return append(header(2, uint64(len(x))), x...)
+	case string:
+		return append(header(3, uint64(len(x))), x...)
+	case []interface{}:
+		b := header(4, uint64(len(x)))
+		for _, item := range x {
+			b = append(b, encode(item)...)
+		}
+		return b
+	case cborMap:
+		b := header(5, uint64(len(x)))
+		for _, pair := range x {
+			b = append(b, encode(pair.Key)...)
+			b = append(b, encode(pair.Value)...)
+		}
+		return b
+	default:
+		panic(fmt.Sprintf("webauthntest: unsupported cbor type %T", v))
+	}
+}
+
+func header(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		b := []byte{major<<5 | 25, 0, 0}
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		return b
+	case n <= 0xffffffff:
+		b := []byte{major<<5 | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		return b
+	default:
+		b := []byte{major<<5 | 27, 0, 0, 0, 0, 0, 0, 0, 0}
+		binary.BigEndian.PutUint64(b[1:], n)
+		return b
+	}
+}